@@ -92,6 +92,7 @@ type taskContext struct {
 	currentCommand command.Command
 	expansions     util.Expansions
 	privateVars    map[string]bool
+	redactor       *client.Redactor
 	logger         client.LoggerProducer
 	jasper         jasper.Manager
 	logs           *apimodels.TaskLogs
@@ -105,6 +106,10 @@ type taskContext struct {
 	project        *model.Project
 	taskModel      *task.Task
 	oomTracker     jasper.OOMTracker
+	commandStats   []apimodels.CommandStats
+	// currentCommandFailedTests is whether the current command sent failed
+	// test results.
+	currentCommandFailedTests bool
 	sync.RWMutex
 }
 
@@ -472,6 +477,7 @@ func (a *Agent) fetchProjectConfig(ctx context.Context, tc *taskContext) error {
 	tc.project = project
 	tc.expansions = expAndVars.Expansions
 	tc.privateVars = expAndVars.PrivateVars
+	tc.redactor = newPrivateVarsRedactor(tc.expansions, tc.privateVars)
 	return nil
 }
 
@@ -686,11 +692,15 @@ func (a *Agent) finishTask(ctx context.Context, tc *taskContext, status string,
 	a.killProcs(ctx, tc, false)
 
 	if tc.logger != nil {
+		if n := tc.redactor.Count(); n > 0 {
+			tc.logger.Execution().Infof("Redacted %d occurrence(s) of private variables from the task logs.", n)
+		}
 		tc.logger.Execution().Infof("Sending final task status: '%s'.", detail.Status)
 		flushCtx, cancel := context.WithTimeout(ctx, time.Minute)
 		defer cancel()
 		grip.Error(errors.Wrap(tc.logger.Flush(flushCtx), "flushing logs"))
 	}
+	detail.CommandStats = tc.getCommandStats()
 	grip.Infof("Sending final task status: '%s'.", detail.Status)
 	resp, err := a.comm.EndTask(ctx, detail, tc.task)
 	if err != nil {
@@ -751,6 +761,9 @@ func (a *Agent) endTaskResponse(tc *taskContext, status string, message string)
 		Message:         message,
 		Logs:            tc.logs,
 	}
+	if status == evergreen.TaskFailed && tc.getCurrentCommand() != nil {
+		detail.FailingCommandFailedTests = tc.getCurrentCommandFailedTests()
+	}
 	if tc.taskConfig != nil {
 		detail.Modules.Prefixes = tc.taskConfig.ModulePaths
 	}
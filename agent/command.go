@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -35,6 +36,7 @@ var (
 type runCommandsOptions struct {
 	isTaskCommands bool
 	failPreAndPost bool
+	block          string
 }
 
 func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []model.PluginCommandConf,
@@ -42,6 +44,7 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 	var cmds []command.Command
 	defer func() { err = recovery.HandlePanicWithError(recover(), err, "run commands") }()
 
+	options.block = block
 	for i, commandInfo := range commands {
 		if err := ctx.Err(); err != nil {
 			return errors.Wrap(err, "canceled while running commands")
@@ -96,12 +99,12 @@ func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, commandInfo
 				fullCommandName, tc.taskConfig.BuildVariant.Name, index, total)
 			continue
 		}
-		if len(cmds) == 1 {
-			tc.logger.Task().Infof("Running command %s (step %d of %d).", fullCommandName, index, total)
-		} else {
+		step := strconv.Itoa(index)
+		if len(cmds) > 1 {
 			// for functions with more than one command
-			tc.logger.Task().Infof("Running command %s (step %d.%d of %d).", fullCommandName, index, idx+1, total)
+			step = fmt.Sprintf("%d.%d", index, idx+1)
 		}
+		tc.logger.Task().Infof("Running command %s (step %s of %d).", fullCommandName, step, total)
 
 		ctx, commandSpan := a.tracer.Start(ctx, cmd.Name(), trace.WithAttributes(
 			attribute.String(commandNameAttribute, cmd.Name()),
@@ -110,7 +113,10 @@ func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, commandInfo
 		tc.taskConfig.Expansions.Put(otelParentIDExpansion, commandSpan.SpanContext().SpanID().String())
 		tc.taskConfig.Expansions.Put(otelCollectorEndpointExpansion, a.opts.TraceCollectorEndpoint)
 
-		if err := a.runCommand(ctx, tc, logger, commandInfo, cmd, fullCommandName, options); err != nil {
+		profiler := startCommandProfiler(ctx, fullCommandName, options.block, step)
+		err := a.runCommand(ctx, tc, logger, commandInfo, cmd, fullCommandName, options)
+		tc.addCommandStats(profiler.stop(ctx))
+		if err != nil {
 			commandSpan.SetStatus(codes.Error, "running command")
 			commandSpan.RecordError(err, trace.WithAttributes(tc.taskConfig.TaskAttributes()...))
 			commandSpan.End()
@@ -141,6 +147,13 @@ func (a *Agent) runCommand(ctx context.Context, tc *taskContext, logger client.L
 		tc.setCurrentIdleTimeout(nil)
 	}
 
+	tc.taskConfig.SetCommandFailedTests(false)
+	if options.isTaskCommands || options.failPreAndPost {
+		defer func() {
+			tc.setCurrentCommandFailedTests(tc.taskConfig.CommandFailedTests())
+		}()
+	}
+
 	start := time.Now()
 	// We have seen cases where calling exec.*Cmd.Wait() waits for too long if
 	// the process has called subprocesses. It will wait until a subprocess
@@ -155,6 +168,9 @@ func (a *Agent) runCommand(ctx context.Context, tc *taskContext, logger client.L
 		}()
 		cmdChan <- cmd.Execute(ctx, a.comm, logger, tc.taskConfig)
 	}()
+	// Send any output that was held back for redaction so that it's logged
+	// with this command rather than with the next one.
+	defer client.FlushHeldMessages(logger)
 	select {
 	case err := <-cmdChan:
 		if err != nil {
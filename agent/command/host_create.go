@@ -16,6 +16,8 @@ import (
 	"github.com/pkg/errors"
 )
 
+const poolLeasePollInterval = 30 * time.Second
+
 type createHost struct {
 	CreateHost *apimodels.CreateHost
 	File       string
@@ -97,6 +99,10 @@ func (c *createHost) Execute(ctx context.Context, comm client.Communicator,
 	}
 	startTime := time.Now()
 
+	if c.CreateHost.Pool != "" {
+		return errors.Wrapf(c.leaseFromPool(ctx, comm, logger, taskData), "leasing hosts from host pool '%s'", c.CreateHost.Pool)
+	}
+
 	c.logAMI(ctx, comm, logger, taskData)
 	ids, err := comm.CreateHost(ctx, taskData, *c.CreateHost)
 	if err != nil {
@@ -110,6 +116,37 @@ func (c *createHost) Execute(ctx context.Context, comm client.Communicator,
 	return nil
 }
 
+// leaseFromPool leases hosts from the host pool, waiting for other tasks to
+// return enough hosts to the pool if it doesn't have enough free hosts.
+func (c *createHost) leaseFromPool(ctx context.Context, comm client.Communicator, logger client.LoggerProducer,
+	taskData client.TaskData) error {
+	timeoutTimer := time.NewTimer(time.Duration(c.CreateHost.SetupTimeoutSecs) * time.Second)
+	defer timeoutTimer.Stop()
+	pollTicker := time.NewTicker(poolLeasePollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		ids, err := comm.CreateHost(ctx, taskData, *c.CreateHost)
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			logger.Task().Infof("host.create: leased hosts %v from host pool '%s'.", ids, c.CreateHost.Pool)
+			return nil
+		}
+		logger.Task().Infof("host.create: host pool '%s' does not have %s free host(s), waiting for other tasks to return hosts to the pool.",
+			c.CreateHost.Pool, c.CreateHost.NumHosts)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeoutTimer.C:
+			return errors.New("reached timeout waiting for free hosts in the host pool")
+		case <-pollTicker.C:
+		}
+	}
+}
+
 func (c *createHost) logAMI(ctx context.Context, comm client.Communicator, logger client.LoggerProducer,
 	taskData client.TaskData) {
 	if c.CreateHost.CloudProvider != apimodels.ProviderEC2 {
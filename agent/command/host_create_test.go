@@ -131,6 +131,31 @@ func (s *createHostSuite) TestParseFromFile() {
 
 }
 
+func (s *createHostSuite) TestPoolParamValidation() {
+	// host settings come from the pool
+	s.params["pool"] = "cluster"
+	s.NoError(s.cmd.ParseParams(s.params))
+	err := s.cmd.expandAndValidate(s.conf)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "host settings must be defined in the host pool rather than in host.create")
+	s.Contains(err.Error(), "scope cannot be set for hosts leased from a host pool")
+
+	s.params = map[string]interface{}{
+		"pool":      "${pool_name}",
+		"num_hosts": 3,
+	}
+	s.conf.Expansions.Put("pool_name", "cluster")
+	s.NoError(s.cmd.ParseParams(s.params))
+	s.NoError(s.cmd.expandAndValidate(s.conf))
+	s.Equal("cluster", s.cmd.CreateHost.Pool)
+	s.Equal("3", s.cmd.CreateHost.NumHosts)
+	s.Equal(apimodels.DefaultSetupTimeoutSecs, s.cmd.CreateHost.SetupTimeoutSecs)
+
+	s.params["num_hosts"] = 11
+	s.NoError(s.cmd.ParseParams(s.params))
+	s.Contains(s.cmd.expandAndValidate(s.conf).Error(), "num hosts must be between 1 and 10")
+}
+
 func (s *createHostSuite) TestParamValidation() {
 	// having no ami or distro is an error
 	s.params["distro"] = ""
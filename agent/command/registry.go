@@ -24,6 +24,7 @@ func init() {
 		"archive.auto_extract":                  autoExtractFactory,
 		evergreen.AttachResultsCommandName:      attachResultsFactory,
 		evergreen.AttachXUnitResultsCommandName: xunitResultsFactory,
+		evergreen.AttachTestResultsCommandName:  formattedResultsFactory,
 		evergreen.AttachArtifactsCommandName:    attachArtifactsFactory,
 		evergreen.HostCreateCommandName:         createHostFactory,
 		"ec2.assume_role":                       ec2AssumeRoleFactory,
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+const (
+	testResultsFormatJUnit      = "junit"
+	testResultsFormatXUnit      = "xunit"
+	testResultsFormatTAP        = "tap"
+	testResultsFormatGoTestJSON = "gotest_json"
+)
+
+var validTestResultsFormats = []string{
+	testResultsFormatJUnit,
+	testResultsFormatXUnit,
+	testResultsFormatTAP,
+	testResultsFormatGoTestJSON,
+}
+
+// testResultsParser parses a single test results report.
+type testResultsParser interface {
+	// Parse reads in the report.
+	Parse(io.Reader) error
+	// Logs returns the log lines for the report.
+	Logs() []string
+	// Results returns the test results from the report. Each result's line
+	// number is the zero-indexed line in Logs where the test starts.
+	Results() []testresult.TestResult
+}
+
+// newTestResultsParser returns a parser for the given report format.
+func newTestResultsParser(format string) (testResultsParser, error) {
+	switch format {
+	case testResultsFormatJUnit, testResultsFormatXUnit:
+		return &junitParser{}, nil
+	case testResultsFormatTAP:
+		return &tapParser{}, nil
+	case testResultsFormatGoTestJSON:
+		return &goTestJSONParser{}, nil
+	default:
+		return nil, errors.Errorf("unrecognized test results format '%s'", format)
+	}
+}
+
+// formattedResults parses files containing test results in one of several
+// common report formats and sends the results back to the server.
+type formattedResults struct {
+	// Files is a list of file globs to parse.
+	Files []string `mapstructure:"files" plugin:"expand"`
+
+	// Format is the format of the report files.
+	Format string `mapstructure:"format" plugin:"expand"`
+
+	// OptionalOutput, when set to true, causes this command to be skipped
+	// over without an error when no files are found to be parsed.
+	OptionalOutput   string `mapstructure:"optional_output" plugin:"expand"`
+	outputIsOptional bool
+
+	base
+}
+
+func formattedResultsFactory() Command   { return &formattedResults{} }
+func (c *formattedResults) Name() string { return evergreen.AttachTestResultsCommandName }
+
+// ParseParams reads and validates the command parameters.
+func (c *formattedResults) ParseParams(params map[string]interface{}) error {
+	var err error
+	if err = mapstructure.Decode(params, c); err != nil {
+		return errors.Wrap(err, "decoding mapstructure params")
+	}
+
+	if c.OptionalOutput != "" {
+		c.outputIsOptional, err = strconv.ParseBool(c.OptionalOutput)
+		if err != nil {
+			return errors.Wrap(err, "parsing optional output parameter as a boolean")
+		}
+	}
+
+	if len(c.Files) == 0 {
+		return errors.New("must specify at least one file pattern to parse")
+	}
+	// The format may be an expansion, so it can only be validated here if it
+	// is a literal value.
+	if !strings.Contains(c.Format, "${") && !utility.StringSliceContains(validTestResultsFormats, c.Format) {
+		return errors.Errorf("format must be one of: %s", strings.Join(validTestResultsFormats, ", "))
+	}
+
+	return nil
+}
+
+// Execute parses the specified report files and sends the test results found
+// in them back to the server.
+func (c *formattedResults) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *internal.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "applying expansions")
+	}
+
+	for i, file := range c.Files {
+		c.Files[i] = getJoinedWithWorkDir(conf, file)
+	}
+
+	reportFiles, err := globFiles(c.Files...)
+	if err != nil {
+		return errors.Wrap(err, "obtaining names of report files")
+	}
+	if len(reportFiles) == 0 {
+		if c.outputIsOptional {
+			return nil
+		}
+		return errors.New("no files found to be parsed")
+	}
+
+	logs, results, err := c.parseReportFiles(ctx, logger, conf, reportFiles)
+	if err != nil {
+		return errors.Wrap(err, "parsing report files")
+	}
+
+	return errors.Wrap(sendTestLogsAndResults(ctx, comm, logger, conf, logs, results), "sending test logs and test results")
+}
+
+// parseReportFiles parses all of the given report files and returns a test
+// log for each file along with its corresponding test results.
+func (c *formattedResults) parseReportFiles(ctx context.Context, logger client.LoggerProducer,
+	conf *internal.TaskConfig, reportFiles []string) ([]model.TestLog, [][]testresult.TestResult, error) {
+
+	var (
+		logs    []model.TestLog
+		results [][]testresult.TestResult
+	)
+	for _, reportFile := range reportFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, errors.Wrap(err, "canceled while processing report files")
+		}
+
+		log, fileResults, err := c.parseReportFile(conf, reportFile)
+		if err != nil {
+			// Continue on error to let the other files be parsed.
+			logger.Task().Error(errors.Wrapf(err, "parsing file '%s'", reportFile))
+			continue
+		}
+		if len(fileResults) == 0 {
+			logger.Task().Infof("Report file '%s' contained no test results.", reportFile)
+			continue
+		}
+
+		logs = append(logs, log)
+		results = append(results, fileResults)
+	}
+
+	if len(results) == 0 {
+		return nil, nil, errors.New("report files contained no results")
+	}
+
+	return logs, results, nil
+}
+
+func (c *formattedResults) parseReportFile(conf *internal.TaskConfig, reportFile string) (model.TestLog, []testresult.TestResult, error) {
+	parser, err := newTestResultsParser(c.Format)
+	if err != nil {
+		return model.TestLog{}, nil, err
+	}
+
+	f, err := os.Open(reportFile)
+	if err != nil {
+		return model.TestLog{}, nil, errors.Wrap(err, "opening file")
+	}
+	defer f.Close()
+
+	if err = parser.Parse(f); err != nil {
+		return model.TestLog{}, nil, errors.Wrap(err, "parsing file")
+	}
+
+	logName := strings.TrimSuffix(filepath.Base(reportFile), filepath.Ext(reportFile))
+	log := model.TestLog{
+		Name:          logName,
+		Task:          conf.Task.Id,
+		TaskExecution: conf.Task.Execution,
+		Lines:         parser.Logs(),
+	}
+
+	results := parser.Results()
+	for i := range results {
+		results[i].LogTestName = logName
+	}
+
+	return log, results, nil
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormattedResultsParseParams(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		params    map[string]interface{}
+		expectErr bool
+	}{
+		"SucceedsWithValidFormat": {
+			params: map[string]interface{}{"files": []string{"report.tap"}, "format": "tap"},
+		},
+		"SucceedsWithExpandedFormat": {
+			params: map[string]interface{}{"files": []string{"report.xml"}, "format": "${format}"},
+		},
+		"FailsWithoutFiles": {
+			params:    map[string]interface{}{"format": "junit"},
+			expectErr: true,
+		},
+		"FailsWithInvalidFormat": {
+			params:    map[string]interface{}{"files": []string{"report.txt"}, "format": "csv"},
+			expectErr: true,
+		},
+		"FailsWithInvalidOptionalOutput": {
+			params:    map[string]interface{}{"files": []string{"report.json"}, "format": "gotest_json", "optional_output": "maybe"},
+			expectErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			cmd := formattedResultsFactory()
+			err := cmd.ParseParams(testCase.params)
+			if testCase.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMakeCedarTestResultsWithAttempts(t *testing.T) {
+	results := []testresult.TestResult{
+		{TestName: "flaky", Status: evergreen.TestFailedStatus, Attempt: 0},
+		{TestName: "flaky", Status: evergreen.TestSucceededStatus, Attempt: 1},
+		{TestName: "passing", Status: evergreen.TestSucceededStatus},
+	}
+	cedarResults, failed := makeCedarTestResults("id", &task.Task{}, results)
+	require.Len(t, cedarResults.Results, 3)
+	assert.False(t, failed, "tests that pass on a retry should not fail the results")
+	assert.EqualValues(t, 1, cedarResults.Results[1].Trial)
+
+	results = append(results, testresult.TestResult{TestName: "flaky", Status: evergreen.TestFailedStatus, Attempt: 2})
+	_, failed = makeCedarTestResults("id", &task.Task{}, results)
+	assert.True(t, failed)
+}
@@ -0,0 +1,172 @@
+package command
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/pkg/errors"
+)
+
+// goTestJSONEvent is a single event emitted by `go test -json` (see
+// `go doc test2json`).
+type goTestJSONEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+const (
+	goTestJSONActionRun    = "run"
+	goTestJSONActionPass   = "pass"
+	goTestJSONActionFail   = "fail"
+	goTestJSONActionSkip   = "skip"
+	goTestJSONActionOutput = "output"
+)
+
+// goTestJSONParser parses the event stream produced by `go test -json`. Tests
+// that run more than once (e.g. with -count or a retrying test runner) are
+// reported as separate attempts.
+type goTestJSONParser struct {
+	logs    []string
+	results []*testresult.TestResult
+
+	// running maps each package and test to its current attempt.
+	running map[string]*testresult.TestResult
+	// attempts counts the number of times each package and test has run.
+	attempts map[string]int
+	// packageHasTests records whether each package ran any tests.
+	packageHasTests map[string]bool
+}
+
+// Logs returns the output lines of the test run.
+func (jp *goTestJSONParser) Logs() []string {
+	return jp.logs
+}
+
+// Results returns the test results parsed from the event stream.
+func (jp *goTestJSONParser) Results() []testresult.TestResult {
+	results := make([]testresult.TestResult, 0, len(jp.results))
+	for _, res := range jp.results {
+		results = append(results, *res)
+	}
+	return results
+}
+
+// Parse reads in a `go test -json` event stream and stores the results and
+// logs. Lines that are not valid JSON events (such as build output) are kept
+// as logs.
+func (jp *goTestJSONParser) Parse(output io.Reader) error {
+	jp.running = map[string]*testresult.TestResult{}
+	jp.attempts = map[string]int{}
+	jp.packageHasTests = map[string]bool{}
+
+	scanner := bufio.NewScanner(output)
+	// Test output lines can be long, so allow up to 1MB per event.
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		event := goTestJSONEvent{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil || event.Action == "" {
+			jp.logs = append(jp.logs, line)
+			continue
+		}
+		jp.handleEvent(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "reading go test JSON output")
+	}
+
+	// Tests that never finished (e.g. because of a panic or timeout) are
+	// considered failed.
+	for key, res := range jp.running {
+		res.Status = evergreen.TestFailedStatus
+		delete(jp.running, key)
+	}
+
+	return nil
+}
+
+func (jp *goTestJSONParser) handleEvent(event goTestJSONEvent) {
+	key := fmt.Sprintf("%s/%s", event.Package, event.Test)
+
+	switch event.Action {
+	case goTestJSONActionOutput:
+		jp.logs = append(jp.logs, strings.TrimSuffix(event.Output, "\n"))
+	case goTestJSONActionRun:
+		if event.Test == "" {
+			return
+		}
+		jp.packageHasTests[event.Package] = true
+		res := &testresult.TestResult{
+			TestName:      event.Test,
+			Status:        evergreen.TestFailedStatus,
+			Attempt:       jp.attempts[key],
+			LineNum:       len(jp.logs),
+			TestStartTime: event.Time,
+			TestEndTime:   event.Time,
+		}
+		jp.attempts[key]++
+		jp.running[key] = res
+		jp.results = append(jp.results, res)
+	case goTestJSONActionPass, goTestJSONActionFail, goTestJSONActionSkip:
+		status := goTestJSONStatus(event.Action)
+		if event.Test == "" {
+			// A package that fails without running any tests (e.g. a
+			// build failure) is reported as a single failed result.
+			if event.Action == goTestJSONActionFail && !jp.packageHasTests[event.Package] {
+				jp.results = append(jp.results, &testresult.TestResult{
+					TestName:      event.Package,
+					Status:        status,
+					LineNum:       jp.lastLineNum(),
+					TestStartTime: event.Time,
+					TestEndTime:   event.Time,
+				})
+			}
+			return
+		}
+		res, ok := jp.running[key]
+		if !ok {
+			// If there was no run event, stub out the result.
+			res = &testresult.TestResult{
+				TestName:      event.Test,
+				Attempt:       jp.attempts[key],
+				LineNum:       jp.lastLineNum(),
+				TestStartTime: event.Time,
+			}
+			jp.attempts[key]++
+			jp.results = append(jp.results, res)
+		}
+		res.Status = status
+		res.TestEndTime = res.TestStartTime.Add(time.Duration(event.Elapsed * float64(time.Second)))
+		delete(jp.running, key)
+	}
+}
+
+// lastLineNum returns the zero-indexed line number of the most recent log
+// line.
+func (jp *goTestJSONParser) lastLineNum() int {
+	if len(jp.logs) == 0 {
+		return 0
+	}
+	return len(jp.logs) - 1
+}
+
+func goTestJSONStatus(action string) string {
+	switch action {
+	case goTestJSONActionPass:
+		return evergreen.TestSucceededStatus
+	case goTestJSONActionSkip:
+		return evergreen.TestSkippedStatus
+	default:
+		return evergreen.TestFailedStatus
+	}
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoTestJSONParser(t *testing.T) {
+	f, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "test_results", "gotest.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	parser := &goTestJSONParser{}
+	require.NoError(t, parser.Parse(f))
+
+	results := parser.Results()
+	require.Len(t, results, 5)
+
+	assert.Equal(t, "TestPass", results[0].TestName)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[0].Status)
+	assert.Equal(t, time.Second, results[0].Duration())
+	assert.Equal(t, "=== RUN   TestPass", parser.Logs()[results[0].LineNum])
+
+	// A test that runs twice is reported as two attempts.
+	assert.Equal(t, "TestFlaky", results[1].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[1].Status)
+	assert.Zero(t, results[1].Attempt)
+	assert.Equal(t, "TestFlaky", results[2].TestName)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[2].Status)
+	assert.Equal(t, 1, results[2].Attempt)
+	assert.Equal(t, "--- FAIL: TestFlaky (0.50s)", parser.Logs()[results[1].LineNum+1])
+
+	assert.Equal(t, "TestSkip", results[3].TestName)
+	assert.Equal(t, evergreen.TestSkippedStatus, results[3].Status)
+
+	// A package that fails to build is reported as a failed result.
+	assert.Equal(t, "example.com/broken", results[4].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[4].Status)
+
+	assert.Contains(t, parser.Logs(), "broken/broken.go:3:1: syntax error")
+}
+
+func TestGoTestJSONParserUnfinishedTest(t *testing.T) {
+	output := `{"Time":"2023-01-01T00:00:00Z","Action":"run","Package":"example.com/pkg","Test":"TestPanic"}
+{"Time":"2023-01-01T00:00:00Z","Action":"output","Package":"example.com/pkg","Test":"TestPanic","Output":"panic: oops\n"}
+`
+	parser := &goTestJSONParser{}
+	require.NoError(t, parser.Parse(strings.NewReader(output)))
+
+	results := parser.Results()
+	require.Len(t, results, 1)
+	assert.Equal(t, "TestPanic", results[0].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[0].Status)
+	assert.Equal(t, []string{"panic: oops"}, parser.Logs())
+}
@@ -0,0 +1,224 @@
+package command
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// junitTestSuites is the root element of a JUnit report that contains
+// multiple test suites.
+type junitTestSuites struct {
+	Suites []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite is a JUnit test suite. Unlike the basic XUnit format, test
+// suites may be arbitrarily nested and carry properties.
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Time       customFloat      `xml:"time,attr"`
+	Properties []junitProperty  `xml:"properties>property"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+	Suites     []junitTestSuite `xml:"testsuite"`
+	Error      *failureDetails  `xml:"error"`
+	SysOut     string           `xml:"system-out"`
+	SysErr     string           `xml:"system-err"`
+}
+
+// junitTestCase is a JUnit test case, including the Maven Surefire rerun
+// extensions for retried tests.
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       customFloat     `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Failure    *failureDetails `xml:"failure"`
+	Error      *failureDetails `xml:"error"`
+	Skipped    *failureDetails `xml:"skipped"`
+	SysOut     string          `xml:"system-out"`
+	SysErr     string          `xml:"system-err"`
+	// RerunFailures and RerunErrors are reruns of a test that failed on
+	// every attempt.
+	RerunFailures []junitRerun `xml:"rerunFailure"`
+	RerunErrors   []junitRerun `xml:"rerunError"`
+	// FlakyFailures and FlakyErrors are failed attempts of a test that
+	// eventually passed.
+	FlakyFailures []junitRerun `xml:"flakyFailure"`
+	FlakyErrors   []junitRerun `xml:"flakyError"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitRerun is a single retried attempt of a test case.
+type junitRerun struct {
+	Message    string      `xml:"message,attr"`
+	Type       string      `xml:"type,attr"`
+	Time       customFloat `xml:"time,attr"`
+	Content    string      `xml:",chardata"`
+	StackTrace string      `xml:"stackTrace"`
+	SysOut     string      `xml:"system-out"`
+	SysErr     string      `xml:"system-err"`
+}
+
+// junitParser parses JUnit XML reports, including nested test suites,
+// properties, and retried tests.
+type junitParser struct {
+	logs    []string
+	results []testresult.TestResult
+}
+
+// Logs returns the log lines generated from the report.
+func (jp *junitParser) Logs() []string {
+	return jp.logs
+}
+
+// Results returns the test results parsed from the report. Retried tests
+// report one result per attempt.
+func (jp *junitParser) Results() []testresult.TestResult {
+	return jp.results
+}
+
+// Parse reads in a JUnit XML report and stores the results and logs.
+func (jp *junitParser) Parse(report io.Reader) error {
+	data, err := io.ReadAll(report)
+	if err != nil {
+		return errors.Wrap(err, "reading report")
+	}
+
+	// The root element may be either <testsuites> or <testsuite>.
+	var root junitTestSuites
+	if err = xml.Unmarshal(data, &root); err != nil {
+		return errors.Wrap(err, "unmarshalling JUnit test suites")
+	}
+	if len(root.Suites) == 0 {
+		var suite junitTestSuite
+		if err = xml.Unmarshal(data, &suite); err != nil {
+			return errors.Wrap(err, "unmarshalling JUnit test suite")
+		}
+		root.Suites = []junitTestSuite{suite}
+	}
+
+	for idx, suite := range root.Suites {
+		jp.addSuite(suite, nil, idx)
+	}
+
+	return nil
+}
+
+func (jp *junitParser) addSuite(suite junitTestSuite, parents []string, idx int) {
+	if suite.Name != "" {
+		parents = append(append([]string{}, parents...), suite.Name)
+	}
+	jp.logs = append(jp.logs, fmt.Sprintf("suite: %s", strings.Join(parents, ".")))
+	jp.logs = append(jp.logs, propertyLines(suite.Properties)...)
+	jp.logs = append(jp.logs, constructSystemLogs(suite.SysOut, suite.SysErr)...)
+
+	if len(suite.TestCases) == 0 && len(suite.Suites) == 0 && suite.Error != nil {
+		// If there are no test cases but there is an error, generate a
+		// default test case.
+		name := suite.Name
+		if name == "" {
+			name = fmt.Sprintf("Unnamed Test-%d", idx)
+		}
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:  name,
+			Time:  suite.Time,
+			Error: suite.Error,
+		})
+	}
+
+	for _, tc := range suite.TestCases {
+		jp.addTestCase(tc)
+	}
+	for childIdx, child := range suite.Suites {
+		jp.addSuite(child, parents, childIdx)
+	}
+}
+
+func (jp *junitParser) addTestCase(tc junitTestCase) {
+	name := tc.Name
+	if tc.ClassName != "" {
+		name = fmt.Sprintf("%s.%s", tc.ClassName, tc.Name)
+	}
+	// Replace spaces, dashes, etc. with underscores.
+	name = util.CleanForPath(name)
+
+	attempt := 0
+	// Flaky attempts all happened before the final, passing attempt.
+	for _, rerun := range append(tc.FlakyFailures, tc.FlakyErrors...) {
+		jp.addAttempt(name, attempt, evergreen.TestFailedStatus, rerun.Time, rerun.lines("FLAKY FAILURE", tc.Properties))
+		attempt++
+	}
+
+	status := evergreen.TestSucceededStatus
+	var lines []string
+	switch {
+	case tc.Failure != nil:
+		status = evergreen.TestFailedStatus
+		lines = tc.Failure.toBasicTestLog("FAILURE").Lines
+	case tc.Error != nil:
+		status = evergreen.TestFailedStatus
+		lines = tc.Error.toBasicTestLog("ERROR").Lines
+	case tc.Skipped != nil:
+		status = evergreen.TestSkippedStatus
+	}
+	lines = append(lines, propertyLines(tc.Properties)...)
+	lines = append(lines, constructSystemLogs(tc.SysOut, tc.SysErr)...)
+	jp.addAttempt(name, attempt, status, tc.Time, lines)
+	attempt++
+
+	// Reruns of a failed test happened after the initial, failing attempt.
+	for _, rerun := range append(tc.RerunFailures, tc.RerunErrors...) {
+		jp.addAttempt(name, attempt, evergreen.TestFailedStatus, rerun.Time, rerun.lines("RERUN FAILURE", tc.Properties))
+		attempt++
+	}
+}
+
+func (jp *junitParser) addAttempt(name string, attempt int, status string, seconds customFloat, lines []string) {
+	if math.IsNaN(float64(seconds)) || math.IsInf(float64(seconds), 0) {
+		seconds = 0
+	}
+	lineNum := len(jp.logs)
+	jp.logs = append(jp.logs, fmt.Sprintf("test: %s (attempt %d): %s", name, attempt, status))
+	jp.logs = append(jp.logs, lines...)
+
+	start := time.Now()
+	jp.results = append(jp.results, testresult.TestResult{
+		TestName:      name,
+		Status:        status,
+		Attempt:       attempt,
+		LineNum:       lineNum,
+		TestStartTime: start,
+		TestEndTime:   start.Add(time.Duration(float64(seconds) * float64(time.Second))),
+	})
+}
+
+func (r junitRerun) lines(rerunType string, props []junitProperty) []string {
+	lines := []string{fmt.Sprintf("%s: %s (%s)", rerunType, r.Message, r.Type)}
+	for _, content := range []string{r.Content, r.StackTrace} {
+		if content = strings.TrimSpace(content); content != "" {
+			lines = append(lines, strings.Split(content, "\n")...)
+		}
+	}
+	lines = append(lines, propertyLines(props)...)
+	return append(lines, constructSystemLogs(r.SysOut, r.SysErr)...)
+}
+
+func propertyLines(props []junitProperty) []string {
+	var lines []string
+	for _, prop := range props {
+		lines = append(lines, fmt.Sprintf("property: %s=%s", prop.Name, prop.Value))
+	}
+	return lines
+}
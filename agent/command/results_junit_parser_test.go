@@ -0,0 +1,70 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitParser(t *testing.T) {
+	f, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "test_results", "junit_nested.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	parser := &junitParser{}
+	require.NoError(t, parser.Parse(f))
+
+	results := parser.Results()
+	require.Len(t, results, 7)
+
+	assert.Equal(t, "pkg.Outer.test_pass", results[0].TestName)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[0].Status)
+	assert.Zero(t, results[0].Attempt)
+
+	// Flaky failures are reported as failed attempts before the final,
+	// passing attempt.
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, "pkg.Inner.test_flaky", results[i].TestName)
+		assert.Equal(t, i-1, results[i].Attempt)
+	}
+	assert.Equal(t, evergreen.TestFailedStatus, results[1].Status)
+	assert.Equal(t, evergreen.TestFailedStatus, results[2].Status)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[3].Status)
+	assert.Equal(t, "FLAKY FAILURE: timed out (TimeoutError)", parser.Logs()[results[1].LineNum+1])
+
+	// Reruns are reported as failed attempts after the initial failure.
+	assert.Equal(t, "pkg.Inner.test_broken", results[4].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[4].Status)
+	assert.Zero(t, results[4].Attempt)
+	assert.Equal(t, "pkg.Inner.test_broken", results[5].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[5].Status)
+	assert.Equal(t, 1, results[5].Attempt)
+
+	assert.Equal(t, "pkg.Inner.test_skipped", results[6].TestName)
+	assert.Equal(t, evergreen.TestSkippedStatus, results[6].Status)
+
+	logs := parser.Logs()
+	assert.Contains(t, logs, "suite: outer")
+	assert.Contains(t, logs, "suite: outer.inner")
+	assert.Contains(t, logs, "property: python=3.11")
+	assert.Contains(t, logs, "property: owner=storage")
+	for _, res := range results {
+		require.True(t, res.LineNum < len(logs))
+		assert.Contains(t, logs[res.LineNum], res.TestName)
+	}
+}
+
+func TestJUnitParserSingleSuiteRoot(t *testing.T) {
+	f, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "xunit", "junit_1.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	parser := &junitParser{}
+	require.NoError(t, parser.Parse(f))
+	assert.NotEmpty(t, parser.Results())
+}
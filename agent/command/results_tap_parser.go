@@ -0,0 +1,202 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/pkg/errors"
+)
+
+var (
+	// Match a TAP test point, saving the ok/not ok status, the optional
+	// test number, the optional description and the optional directive.
+	tapTestPointRegex = regexp.MustCompile(`^(ok|not ok)\b\s*(\d+)?\s*(?:-\s*)?([^#]*?)\s*(?:#\s*(\w+)\b.*)?$`)
+
+	// Match a TAP v14 subtest comment and save the subtest name.
+	tapSubtestRegex = regexp.MustCompile(`^#\s*Subtest:\s*(.*)$`)
+
+	// Match a bail out line and save the reason.
+	tapBailOutRegex = regexp.MustCompile(`^Bail out!\s*(.*)$`)
+
+	// Match the duration from a YAML diagnostic block.
+	tapDurationRegex = regexp.MustCompile(`^\s*duration_ms:\s*([0-9.]+)`)
+)
+
+const (
+	tapIndent        = "    "
+	tapDirectiveSkip = "SKIP"
+	tapDirectiveTodo = "TODO"
+)
+
+// tapParser parses TAP version 13 and 14 output, including nested subtests
+// and YAML diagnostic blocks. Test points that appear more than once with the
+// same name are treated as retried attempts of the same test.
+type tapParser struct {
+	logs    []string
+	results []testresult.TestResult
+
+	// points holds every parsed test point in the order it was read.
+	points []*testresult.TestResult
+	// pending holds the test points at each nesting level that have not yet
+	// been claimed by a parent test point. Subtests are only fully named
+	// once their parent test point is read.
+	pending map[int][]*testresult.TestResult
+	// subtestNames holds the names declared by "# Subtest:" comments,
+	// keyed by the comment's nesting level.
+	subtestNames map[int]string
+	// last is the most recently parsed test point, which owns any YAML
+	// diagnostic block that follows it.
+	last      *testresult.TestResult
+	inYAML    bool
+	yamlLevel int
+}
+
+// Logs returns the lines of the TAP output.
+func (tp *tapParser) Logs() []string {
+	return tp.logs
+}
+
+// Results returns the test results parsed from the TAP output.
+func (tp *tapParser) Results() []testresult.TestResult {
+	return tp.results
+}
+
+// Parse reads in TAP output and stores the results and logs.
+func (tp *tapParser) Parse(output io.Reader) error {
+	tp.pending = map[int][]*testresult.TestResult{}
+	tp.subtestNames = map[int]string{}
+
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		// Logs are appended before handling the line, so len(tp.logs)-1 is
+		// the current zero-indexed line number.
+		line := scanner.Text()
+		tp.logs = append(tp.logs, line)
+		tp.handleLine(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "reading TAP output")
+	}
+
+	for _, point := range tp.points {
+		tp.results = append(tp.results, *point)
+	}
+	tp.assignAttempts()
+
+	return nil
+}
+
+func (tp *tapParser) handleLine(line string) {
+	level := 0
+	for strings.HasPrefix(line, tapIndent) {
+		line = strings.TrimPrefix(line, tapIndent)
+		level++
+	}
+	trimmed := strings.TrimSpace(line)
+
+	if tp.inYAML {
+		if level == tp.yamlLevel && trimmed == "..." {
+			tp.inYAML = false
+			return
+		}
+		if tp.last != nil {
+			if matches := tapDurationRegex.FindStringSubmatch(trimmed); len(matches) == 2 {
+				if ms, err := strconv.ParseFloat(matches[1], 64); err == nil {
+					tp.last.TestEndTime = tp.last.TestStartTime.Add(time.Duration(ms * float64(time.Millisecond)))
+				}
+			}
+		}
+		return
+	}
+
+	switch {
+	case trimmed == "---" && tp.last != nil:
+		tp.inYAML = true
+		tp.yamlLevel = level
+	case tapSubtestRegex.MatchString(trimmed):
+		tp.subtestNames[level] = strings.TrimSpace(tapSubtestRegex.FindStringSubmatch(trimmed)[1])
+	case tapBailOutRegex.MatchString(trimmed):
+		reason := strings.TrimSpace(tapBailOutRegex.FindStringSubmatch(trimmed)[1])
+		name := "Bail out!"
+		if reason != "" {
+			name = fmt.Sprintf("Bail out! %s", reason)
+		}
+		tp.addTestPoint(level, name, evergreen.TestFailedStatus)
+	case tapTestPointRegex.MatchString(trimmed):
+		matches := tapTestPointRegex.FindStringSubmatch(trimmed)
+		name := strings.TrimSpace(matches[3])
+		if name == "" {
+			name = fmt.Sprintf("test %s", matches[2])
+		}
+		tp.addTestPoint(level, name, tapStatus(matches[1] == "ok", strings.ToUpper(matches[4])))
+	}
+}
+
+// tapStatus converts a TAP test point's status and directive to a test
+// status. Failing TODO tests are expected to fail, so they do not count as
+// failures.
+func tapStatus(ok bool, directive string) string {
+	switch {
+	case directive == tapDirectiveSkip:
+		return evergreen.TestSkippedStatus
+	case directive == tapDirectiveTodo && !ok:
+		return evergreen.TestSilentlyFailedStatus
+	case ok:
+		return evergreen.TestSucceededStatus
+	default:
+		return evergreen.TestFailedStatus
+	}
+}
+
+func (tp *tapParser) addTestPoint(level int, name, status string) {
+	start := time.Now()
+	result := &testresult.TestResult{
+		TestName:      name,
+		Status:        status,
+		LineNum:       len(tp.logs) - 1,
+		TestStartTime: start,
+		TestEndTime:   start,
+	}
+
+	// This test point is the parent of any unclaimed test points one level
+	// deeper, so prefix their names with the subtest's name. The subtest
+	// comment may be indented either at the subtest's level or its
+	// parent's.
+	if children := tp.pending[level+1]; len(children) > 0 {
+		parentName := name
+		if subtestName := tp.subtestNames[level+1]; subtestName != "" {
+			parentName = subtestName
+		} else if subtestName := tp.subtestNames[level]; subtestName != "" {
+			parentName = subtestName
+		}
+		for _, child := range children {
+			child.TestName = fmt.Sprintf("%s/%s", parentName, child.TestName)
+		}
+		tp.pending[level] = append(tp.pending[level], children...)
+		delete(tp.pending, level+1)
+	}
+	delete(tp.subtestNames, level+1)
+	delete(tp.subtestNames, level)
+
+	tp.pending[level] = append(tp.pending[level], result)
+	tp.points = append(tp.points, result)
+	tp.last = result
+}
+
+// assignAttempts numbers the attempts of tests that were reported more than
+// once.
+func (tp *tapParser) assignAttempts() {
+	attempts := map[string]int{}
+	for i := range tp.results {
+		name := tp.results[i].TestName
+		tp.results[i].Attempt = attempts[name]
+		attempts[name]++
+	}
+}
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTAPParser(t *testing.T) {
+	f, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "test_results", "tap_subtests.tap"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	parser := &tapParser{}
+	require.NoError(t, parser.Parse(f))
+
+	results := parser.Results()
+	require.Len(t, results, 7)
+
+	expected := []struct {
+		name    string
+		status  string
+		attempt int
+	}{
+		{name: "first test", status: evergreen.TestSucceededStatus},
+		{name: "second test", status: evergreen.TestFailedStatus},
+		{name: "parent/child one", status: evergreen.TestSucceededStatus},
+		{name: "parent/child two", status: evergreen.TestSilentlyFailedStatus},
+		{name: "parent", status: evergreen.TestSucceededStatus},
+		{name: "skipped test", status: evergreen.TestSkippedStatus},
+		{name: "second test", status: evergreen.TestFailedStatus, attempt: 1},
+	}
+	for i, exp := range expected {
+		assert.Equal(t, exp.name, results[i].TestName)
+		assert.Equal(t, exp.status, results[i].Status, exp.name)
+		assert.Equal(t, exp.attempt, results[i].Attempt, exp.name)
+		assert.Contains(t, parser.Logs()[results[i].LineNum], strings.TrimPrefix(exp.name, "parent/"))
+	}
+	assert.Equal(t, 250*time.Millisecond, results[1].Duration())
+}
+
+func TestTAPParserBailOut(t *testing.T) {
+	parser := &tapParser{}
+	require.NoError(t, parser.Parse(strings.NewReader("TAP version 13\n1..2\nok 1\nBail out! database unavailable\n")))
+
+	results := parser.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, "test 1", results[0].TestName)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[0].Status)
+	assert.Equal(t, "Bail out! database unavailable", results[1].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[1].Status)
+}
@@ -29,9 +29,16 @@ func sendTestResults(ctx context.Context, comm client.Communicator, logger clien
 	logger.Task().Info("Attaching test results...")
 	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
 
-	if err := sendTestResultsToCedar(ctx, conf, td, comm, results); err != nil {
+	if conf.TestResultsService == testresult.TestResultsServiceLocal {
+		if err := sendTestResultsToLocal(ctx, td, comm, results); err != nil {
+			return errors.Wrap(err, "sending test results to the local test results service")
+		}
+	} else if err := sendTestResultsToCedar(ctx, conf, td, comm, results); err != nil {
 		return errors.Wrap(err, "sending test results to Cedar")
 	}
+	if hasFailedTestResults(results) {
+		conf.SetCommandFailedTests(true)
+	}
 
 	logger.Task().Info("Successfully attached results.")
 
@@ -40,6 +47,12 @@ func sendTestResults(ctx context.Context, comm client.Communicator, logger clien
 
 // sendTestLog sends test logs to the backend logging service.
 func sendTestLog(ctx context.Context, comm client.Communicator, conf *internal.TaskConfig, log *model.TestLog) error {
+	if conf.TestResultsService == testresult.TestResultsServiceLocal {
+		td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
+		_, err := comm.SendTestLog(ctx, td, log)
+		return errors.Wrap(err, "sending test log to the local test log store")
+	}
+
 	return errors.Wrap(sendTestLogToCedar(ctx, conf.Task, comm, log), "sending test logs to Cedar")
 }
 
@@ -104,6 +117,18 @@ func sendTestResultsToCedar(ctx context.Context, conf *internal.TaskConfig, td c
 	return nil
 }
 
+func sendTestResultsToLocal(ctx context.Context, td client.TaskData, comm client.Communicator, results []testresult.TestResult) error {
+	if err := comm.SendTestResults(ctx, td, results); err != nil {
+		return errors.Wrap(err, "adding test results")
+	}
+
+	if err := comm.SetResultsInfo(ctx, td, testresult.TestResultsServiceLocal, hasFailedTestResults(results)); err != nil {
+		return errors.Wrap(err, "setting results info in the task")
+	}
+
+	return nil
+}
+
 func sendTestLogToCedar(ctx context.Context, t *task.Task, comm client.Communicator, log *model.TestLog) error {
 	conn, err := comm.GetCedarGRPCConn(ctx)
 	if err != nil {
@@ -153,7 +178,6 @@ func makeCedarTestResultsRecord(conf *internal.TaskConfig, displayTaskInfo *apim
 
 func makeCedarTestResults(id string, t *task.Task, results []testresult.TestResult) (testresults.Results, bool) {
 	rs := testresults.Results{ID: id}
-	failed := false
 	for _, r := range results {
 		if r.DisplayTestName == "" {
 			r.DisplayTestName = r.TestName
@@ -165,6 +189,7 @@ func makeCedarTestResults(id string, t *task.Task, results []testresult.TestResu
 			TestName:        utility.RandomString(),
 			DisplayTestName: r.DisplayTestName,
 			GroupID:         r.GroupID,
+			Trial:           int32(r.Attempt),
 			Status:          r.Status,
 			LogTestName:     r.LogTestName,
 			LogURL:          r.LogURL,
@@ -174,11 +199,33 @@ func makeCedarTestResults(id string, t *task.Task, results []testresult.TestResu
 			TestStarted:     r.TestStartTime,
 			TestEnded:       r.TestEndTime,
 		})
+	}
 
-		if r.Status == evergreen.TestFailedStatus {
-			failed = true
+	return rs, hasFailedTestResults(results)
+}
+
+// hasFailedTestResults returns whether any of the test results failed. Only
+// the final attempt of a retried test determines whether the results failed,
+// so tests that pass on a rerun do not fail the task.
+func hasFailedTestResults(results []testresult.TestResult) bool {
+	finalAttempts := getFinalTestAttempts(results)
+	for _, r := range results {
+		if r.Status == evergreen.TestFailedStatus && r.Attempt == finalAttempts[r.TestName] {
+			return true
 		}
 	}
 
-	return rs, failed
+	return false
+}
+
+// getFinalTestAttempts returns a mapping of each test name to the attempt
+// number of its final attempt.
+func getFinalTestAttempts(results []testresult.TestResult) map[string]int {
+	finalAttempts := map[string]int{}
+	for _, r := range results {
+		if r.Attempt > finalAttempts[r.TestName] {
+			finalAttempts[r.TestName] = r.Attempt
+		}
+	}
+	return finalAttempts
 }
@@ -183,6 +183,37 @@ func TestSendTestResults(t *testing.T) {
 			})
 		}
 	})
+	t.Run("ToLocal", func(t *testing.T) {
+		conf.TestResultsService = testresult.TestResultsServiceLocal
+		defer func() {
+			conf.TestResultsService = ""
+		}()
+
+		t.Run("PassingResults", func(t *testing.T) {
+			comm.LocalTestResults = nil
+			comm.ResultsService = ""
+			comm.ResultsFailed = false
+			require.NoError(t, sendTestResults(ctx, comm, logger, conf, results))
+
+			assert.Equal(t, results, comm.LocalTestResults)
+			assert.Equal(t, testresult.TestResultsServiceLocal, comm.ResultsService)
+			assert.False(t, comm.ResultsFailed)
+		})
+		t.Run("FailingResults", func(t *testing.T) {
+			comm.LocalTestResults = nil
+			comm.ResultsService = ""
+			comm.ResultsFailed = false
+			results[0].Status = evergreen.TestFailedStatus
+			defer func() {
+				results[0].Status = "pass"
+			}()
+			require.NoError(t, sendTestResults(ctx, comm, logger, conf, results))
+
+			assert.Equal(t, results, comm.LocalTestResults)
+			assert.Equal(t, testresult.TestResultsServiceLocal, comm.ResultsService)
+			assert.True(t, comm.ResultsFailed)
+		})
+	})
 }
 
 func TestSendTestLog(t *testing.T) {
@@ -266,6 +297,16 @@ func TestSendTestLog(t *testing.T) {
 			})
 		}
 	})
+	t.Run("ToLocal", func(t *testing.T) {
+		conf.TestResultsService = testresult.TestResultsServiceLocal
+		defer func() {
+			conf.TestResultsService = ""
+		}()
+
+		require.NoError(t, sendTestLog(ctx, comm, conf, log))
+		require.Len(t, comm.TestLogs, 1)
+		assert.Equal(t, log.Lines, comm.TestLogs[0].Lines)
+	})
 }
 
 func setupCedarServer(ctx context.Context, t *testing.T, comm *client.Mock) *timberutil.MockCedarServer {
@@ -0,0 +1,239 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/mongodb/grip/recovery"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	commandStatsInterval = time.Second
+	mainCommandBlock     = "task"
+)
+
+// commandProfiler samples the host's resource usage while a single command
+// runs.
+type commandProfiler struct {
+	stats     apimodels.CommandStats
+	acc       commandStatsAccumulator
+	prevCPU   *cpu.TimesStat
+	startDisk *diskCounters
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	mu     sync.Mutex
+}
+
+// startCommandProfiler starts sampling resource usage for the command until
+// stop is called.
+func startCommandProfiler(ctx context.Context, command, block, step string) *commandProfiler {
+	if block == "" {
+		block = mainCommandBlock
+	}
+	p := &commandProfiler{
+		stats: apimodels.CommandStats{
+			Command:   command,
+			Block:     block,
+			Step:      step,
+			StartTime: time.Now(),
+		},
+		done: make(chan struct{}),
+	}
+	p.prevCPU = readCPUTimes(ctx)
+	p.startDisk = readDiskCounters(ctx)
+
+	ctx, p.cancel = context.WithCancel(ctx)
+	go func() {
+		defer close(p.done)
+		defer recovery.LogStackTraceAndContinue("command profiler")
+
+		ticker := time.NewTicker(commandStatsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.sample(ctx)
+			}
+		}
+	}()
+
+	return p
+}
+
+// stop stops sampling and returns the resources used by the command.
+func (p *commandProfiler) stop(ctx context.Context) apimodels.CommandStats {
+	p.cancel()
+	<-p.done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Commands that finish between samples still get one sample.
+	if p.acc.samples == 0 {
+		p.sampleLocked(ctx)
+	}
+
+	stats := p.stats
+	stats.Duration = time.Since(stats.StartTime)
+	p.acc.fill(&stats)
+	if p.startDisk != nil {
+		if endDisk := readDiskCounters(ctx); endDisk != nil {
+			stats.DiskReadBytes = counterDelta(p.startDisk.readBytes, endDisk.readBytes)
+			stats.DiskWriteBytes = counterDelta(p.startDisk.writeBytes, endDisk.writeBytes)
+		}
+	}
+
+	return stats
+}
+
+func (p *commandProfiler) sample(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sampleLocked(ctx)
+}
+
+func (p *commandProfiler) sampleLocked(ctx context.Context) {
+	var cpuPercent float64
+	if times := readCPUTimes(ctx); times != nil {
+		if p.prevCPU != nil {
+			cpuPercent = cpuBusyPercent(*p.prevCPU, *times)
+		}
+		p.prevCPU = times
+	}
+	p.acc.add(cpuPercent, processTreeRSS(ctx, int32(os.Getpid())))
+}
+
+// commandStatsAccumulator aggregates the samples taken while a command runs.
+type commandStatsAccumulator struct {
+	samples  int
+	cpuTotal float64
+	cpuPeak  float64
+	rssTotal uint64
+	rssPeak  uint64
+}
+
+func (a *commandStatsAccumulator) add(cpuPercent float64, rss uint64) {
+	a.samples++
+	a.cpuTotal += cpuPercent
+	if cpuPercent > a.cpuPeak {
+		a.cpuPeak = cpuPercent
+	}
+	a.rssTotal += rss
+	if rss > a.rssPeak {
+		a.rssPeak = rss
+	}
+}
+
+func (a *commandStatsAccumulator) fill(stats *apimodels.CommandStats) {
+	if a.samples == 0 {
+		return
+	}
+	stats.PeakCPUPercent = a.cpuPeak
+	stats.AvgCPUPercent = a.cpuTotal / float64(a.samples)
+	stats.PeakRSSBytes = a.rssPeak
+	stats.AvgRSSBytes = a.rssTotal / uint64(a.samples)
+}
+
+func readCPUTimes(ctx context.Context) *cpu.TimesStat {
+	times, err := cpu.TimesWithContext(ctx, false)
+	if err != nil || len(times) == 0 {
+		return nil
+	}
+	return &times[0]
+}
+
+// cpuBusyPercent returns the percentage of CPU time spent busy between two
+// readings of the host's aggregate CPU times.
+func cpuBusyPercent(prev, cur cpu.TimesStat) float64 {
+	idle := (cur.Idle + cur.Iowait) - (prev.Idle + prev.Iowait)
+	total := cpuTotal(cur) - cpuTotal(prev)
+	if total <= 0 {
+		return 0
+	}
+	busy := 100 * (total - idle) / total
+	if busy < 0 {
+		return 0
+	}
+	return busy
+}
+
+func cpuTotal(t cpu.TimesStat) float64 {
+	return t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq + t.Softirq + t.Steal
+}
+
+type diskCounters struct {
+	readBytes  uint64
+	writeBytes uint64
+}
+
+func readDiskCounters(ctx context.Context) *diskCounters {
+	counters, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		return nil
+	}
+	total := &diskCounters{}
+	for _, c := range counters {
+		total.readBytes += c.ReadBytes
+		total.writeBytes += c.WriteBytes
+	}
+	return total
+}
+
+// counterDelta returns the increase in a monotonic counter, or zero if the
+// counter was reset, such as when a disk was detached.
+func counterDelta(start, end uint64) uint64 {
+	if end < start {
+		return 0
+	}
+	return end - start
+}
+
+// processTreeRSS returns the total resident set size of the process and all
+// of its descendants. Processes that exit while it is being computed are
+// skipped.
+func processTreeRSS(ctx context.Context, pid int32) uint64 {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return 0
+	}
+	byPid := map[int32]*process.Process{}
+	children := map[int32][]int32{}
+	for _, p := range procs {
+		byPid[p.Pid] = p
+		ppid, err := p.PpidWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		children[ppid] = append(children[ppid], p.Pid)
+	}
+
+	var total uint64
+	queue := []int32{pid}
+	seen := map[int32]bool{}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+
+		if p, ok := byPid[cur]; ok {
+			if info, err := p.MemoryInfoWithContext(ctx); err == nil {
+				total += info.RSS
+			}
+		}
+		queue = append(queue, children[cur]...)
+	}
+	return total
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandStatsAccumulator(t *testing.T) {
+	t.Run("NoSamples", func(t *testing.T) {
+		acc := commandStatsAccumulator{}
+		stats := apimodels.CommandStats{}
+		acc.fill(&stats)
+		assert.Zero(t, stats.PeakCPUPercent)
+		assert.Zero(t, stats.AvgRSSBytes)
+	})
+	t.Run("PeakAndAverage", func(t *testing.T) {
+		acc := commandStatsAccumulator{}
+		acc.add(10, 100)
+		acc.add(50, 300)
+		acc.add(30, 200)
+
+		stats := apimodels.CommandStats{}
+		acc.fill(&stats)
+		assert.Equal(t, 50.0, stats.PeakCPUPercent)
+		assert.Equal(t, 30.0, stats.AvgCPUPercent)
+		assert.EqualValues(t, 300, stats.PeakRSSBytes)
+		assert.EqualValues(t, 200, stats.AvgRSSBytes)
+	})
+}
+
+func TestCPUBusyPercent(t *testing.T) {
+	prev := cpu.TimesStat{User: 10, System: 10, Idle: 80}
+	t.Run("Busy", func(t *testing.T) {
+		cur := cpu.TimesStat{User: 40, System: 20, Idle: 140}
+		assert.InDelta(t, 40, cpuBusyPercent(prev, cur), 0.001)
+	})
+	t.Run("IOWaitIsIdle", func(t *testing.T) {
+		cur := cpu.TimesStat{User: 10, System: 10, Idle: 130, Iowait: 50}
+		assert.Zero(t, cpuBusyPercent(prev, cur))
+	})
+	t.Run("NoElapsedTime", func(t *testing.T) {
+		assert.Zero(t, cpuBusyPercent(prev, prev))
+	})
+}
+
+func TestCounterDelta(t *testing.T) {
+	assert.EqualValues(t, 5, counterDelta(10, 15))
+	assert.Zero(t, counterDelta(15, 10))
+}
+
+func TestCommandProfiler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NotZero(t, processTreeRSS(ctx, int32(os.Getpid())))
+
+	p := startCommandProfiler(ctx, "'shell.exec'", "", "2.1")
+	time.Sleep(10 * time.Millisecond)
+	stats := p.stop(ctx)
+	assert.Equal(t, "'shell.exec'", stats.Command)
+	assert.Equal(t, mainCommandBlock, stats.Block)
+	assert.Equal(t, "2.1", stats.Step)
+	assert.False(t, stats.StartTime.IsZero())
+	assert.True(t, stats.Duration >= 10*time.Millisecond)
+	assert.NotZero(t, stats.PeakRSSBytes)
+	assert.NotZero(t, stats.AvgRSSBytes)
+}
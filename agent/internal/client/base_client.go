@@ -14,11 +14,13 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/cloud"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/artifact"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/juniper/gopb"
@@ -372,17 +374,17 @@ func (c *baseCommunicator) GetLoggerProducer(ctx context.Context, td TaskData, c
 	}
 	underlying := []send.Sender{}
 
-	exec, senders, err := c.makeSender(ctx, td, config.Agent, apimodels.AgentLogPrefix, evergreen.LogTypeAgent)
+	exec, senders, err := c.makeSender(ctx, td, config.Agent, config.Redactor, apimodels.AgentLogPrefix, evergreen.LogTypeAgent)
 	if err != nil {
 		return nil, errors.Wrap(err, "making agent logger")
 	}
 	underlying = append(underlying, senders...)
-	task, senders, err := c.makeSender(ctx, td, config.Task, apimodels.TaskLogPrefix, evergreen.LogTypeTask)
+	task, senders, err := c.makeSender(ctx, td, config.Task, config.Redactor, apimodels.TaskLogPrefix, evergreen.LogTypeTask)
 	if err != nil {
 		return nil, errors.Wrap(err, "making task logger")
 	}
 	underlying = append(underlying, senders...)
-	system, senders, err := c.makeSender(ctx, td, config.System, apimodels.SystemLogPrefix, evergreen.LogTypeSystem)
+	system, senders, err := c.makeSender(ctx, td, config.System, config.Redactor, apimodels.SystemLogPrefix, evergreen.LogTypeSystem)
 	if err != nil {
 		return nil, errors.Wrap(err, "making system logger")
 	}
@@ -396,7 +398,7 @@ func (c *baseCommunicator) GetLoggerProducer(ctx context.Context, td TaskData, c
 	}, nil
 }
 
-func (c *baseCommunicator) makeSender(ctx context.Context, td TaskData, opts []LogOpts, prefix string, logType string) (send.Sender, []send.Sender, error) {
+func (c *baseCommunicator) makeSender(ctx context.Context, td TaskData, opts []LogOpts, redactor *Redactor, prefix string, logType string) (send.Sender, []send.Sender, error) {
 	levelInfo := send.LevelInfo{Default: level.Info, Threshold: level.Debug}
 	senders := []send.Sender{grip.GetSender()}
 	underlyingBufferedSenders := []send.Sender{}
@@ -513,7 +515,12 @@ func (c *baseCommunicator) makeSender(ctx context.Context, td TaskData, opts []L
 		senders = append(senders, sender)
 	}
 
-	return send.NewConfiguredMultiSender(senders...), underlyingBufferedSenders, nil
+	sender := send.NewConfiguredMultiSender(senders...)
+	if redactor != nil {
+		sender = makeRedactingSender(sender, redactor)
+	}
+
+	return sender, underlyingBufferedSenders, nil
 }
 
 // SendLogMessages posts a group of log messages for a task.
@@ -753,6 +760,24 @@ func (c *baseCommunicator) SetResultsInfo(ctx context.Context, taskData TaskData
 	return nil
 }
 
+// SendTestResults appends test results to the task in the local test
+// results service. The results are sent with a batch ID so that retried
+// requests don't store them more than once.
+func (c *baseCommunicator) SendTestResults(ctx context.Context, taskData TaskData, results []testresult.TestResult) error {
+	info := requestInfo{
+		method:   http.MethodPost,
+		taskData: &taskData,
+	}
+	info.path = fmt.Sprintf("tasks/%s/test_results?batch_id=%s", taskData.ID, mgobson.NewObjectId().Hex())
+	resp, err := c.retryRequest(ctx, info, results)
+	if err != nil {
+		return util.RespErrorf(resp, errors.Wrap(err, "sending test results").Error())
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 func (c *baseCommunicator) NewPush(ctx context.Context, taskData TaskData, req *apimodels.S3CopyRequest) (*model.PushLog, error) {
 	newPushLog := model.PushLog{}
 	info := requestInfo{
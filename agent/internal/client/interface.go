@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/mongodb/grip"
 	"google.golang.org/grpc"
@@ -75,6 +76,9 @@ type SharedCommunicator interface {
 	GetCedarGRPCConn(context.Context) (*grpc.ClientConn, error)
 	// SetResultsInfo sets the test results information in the task.
 	SetResultsInfo(context.Context, TaskData, string, bool) error
+	// SendTestResults appends test results to the task in the local test
+	// results service.
+	SendTestResults(context.Context, TaskData, []testresult.TestResult) error
 	// GetDataPipesConfig returns the Data-Pipes service configuration.
 	GetDataPipesConfig(context.Context) (*apimodels.DataPipesConfig, error)
 
@@ -146,6 +150,9 @@ type LoggerConfig struct {
 	System []LogOpts
 	Agent  []LogOpts
 	Task   []LogOpts
+	// Redactor, if set, scrubs sensitive values from all log messages
+	// before they're sent.
+	Redactor *Redactor
 }
 
 type LogOpts struct {
@@ -0,0 +1,389 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/manifest"
+	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	restmodel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/logging"
+	"github.com/mongodb/grip/send"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+const (
+	// LocalLogDirectory is the directory in the output directory that
+	// contains the task's agent, task and system logs.
+	LocalLogDirectory = "logs"
+	// LocalTestLogDirectory is the directory in the output directory that
+	// contains the test logs.
+	LocalTestLogDirectory = "test_logs"
+	// LocalTestResultsFile is the file in the output directory that
+	// contains the test results as JSON.
+	LocalTestResultsFile = "test_results.json"
+	// LocalArtifactsFile is the file in the output directory that contains
+	// the attached artifacts as JSON.
+	LocalArtifactsFile = "artifacts.json"
+	// LocalTaskEndDetailFile is the file in the output directory that
+	// contains the task's final status as JSON.
+	LocalTaskEndDetailFile = "task_end_detail.json"
+)
+
+// LocalCommunicatorOptions are the data that the local communicator serves
+// in place of the app server.
+type LocalCommunicatorOptions struct {
+	Project    *model.Project
+	ProjectRef *model.ProjectRef
+	Task       *task.Task
+	Expansions util.Expansions
+	// OutputDirectory is the directory where logs, test results and
+	// artifacts are written.
+	OutputDirectory string
+	// LogToStdout additionally prints the task logs to standard output.
+	LogToStdout bool
+}
+
+// LocalCommunicator is a Communicator that runs a task without an app
+// server. It serves the task and project from memory and writes everything
+// the task reports to files in the output directory. Operations that need
+// the app server, such as creating hosts or generating tasks, return an
+// error.
+type LocalCommunicator struct {
+	opts LocalCommunicatorOptions
+
+	lastMessageSent time.Time
+	testResults     []testresult.TestResult
+	numTestLogs     int
+	files           []*artifact.File
+	keyVals         map[string]int64
+	endTaskDetail   *apimodels.TaskEndDetail
+	mu              sync.RWMutex
+}
+
+// NewLocalCommunicator returns a Communicator that runs the task in the
+// options locally.
+func NewLocalCommunicator(opts LocalCommunicatorOptions) (*LocalCommunicator, error) {
+	if opts.Project == nil || opts.ProjectRef == nil || opts.Task == nil {
+		return nil, errors.New("must specify a project, project ref and task")
+	}
+	if opts.OutputDirectory == "" {
+		return nil, errors.New("must specify an output directory")
+	}
+	for _, dir := range []string{LocalLogDirectory, LocalTestLogDirectory} {
+		if err := os.MkdirAll(filepath.Join(opts.OutputDirectory, dir), 0755); err != nil {
+			return nil, errors.Wrapf(err, "making output directory '%s'", dir)
+		}
+	}
+
+	return &LocalCommunicator{
+		opts:    opts,
+		keyVals: map[string]int64{},
+	}, nil
+}
+
+// EndTaskDetail returns the final status of the task, or nil if it has not
+// finished.
+func (c *LocalCommunicator) EndTaskDetail() *apimodels.TaskEndDetail {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.endTaskDetail
+}
+
+func (c *LocalCommunicator) Close() {}
+
+func (c *LocalCommunicator) UpdateLastMessageTime() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastMessageSent = time.Now()
+}
+
+func (c *LocalCommunicator) LastMessageAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.lastMessageSent
+}
+
+func (c *LocalCommunicator) EndTask(ctx context.Context, detail *apimodels.TaskEndDetail, td TaskData) (*apimodels.EndTaskResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.endTaskDetail = detail
+	return &apimodels.EndTaskResponse{ShouldExit: true}, errors.Wrap(c.writeJSON(LocalTaskEndDetailFile, detail), "writing task end detail")
+}
+
+func (c *LocalCommunicator) GetNextTask(ctx context.Context, details *apimodels.GetNextTaskDetails) (*apimodels.NextTaskResponse, error) {
+	return nil, errNotAvailableLocally("getting the next task")
+}
+
+func (c *LocalCommunicator) GetAgentSetupData(ctx context.Context) (*apimodels.AgentSetupData, error) {
+	return &apimodels.AgentSetupData{TestResultsService: testresult.TestResultsServiceLocal}, nil
+}
+
+func (c *LocalCommunicator) StartTask(ctx context.Context, td TaskData) error { return nil }
+
+func (c *LocalCommunicator) GetTask(ctx context.Context, td TaskData) (*task.Task, error) {
+	return c.opts.Task, nil
+}
+
+func (c *LocalCommunicator) GetDisplayTaskInfoFromExecution(ctx context.Context, td TaskData) (*apimodels.DisplayTaskInfo, error) {
+	return &apimodels.DisplayTaskInfo{}, nil
+}
+
+func (c *LocalCommunicator) GetProjectRef(ctx context.Context, td TaskData) (*model.ProjectRef, error) {
+	return c.opts.ProjectRef, nil
+}
+
+func (c *LocalCommunicator) GetDistroView(ctx context.Context, td TaskData) (*apimodels.DistroView, error) {
+	return &apimodels.DistroView{}, nil
+}
+
+func (c *LocalCommunicator) GetDistroAMI(ctx context.Context, distro, region string, td TaskData) (string, error) {
+	return "", errNotAvailableLocally("getting a distro AMI")
+}
+
+func (c *LocalCommunicator) GetProject(ctx context.Context, td TaskData) (*model.Project, error) {
+	return c.opts.Project, nil
+}
+
+func (c *LocalCommunicator) Heartbeat(ctx context.Context, td TaskData) (string, error) {
+	return "", nil
+}
+
+func (c *LocalCommunicator) GetExpansionsAndVars(ctx context.Context, td TaskData) (*apimodels.ExpansionsAndVars, error) {
+	expansions := util.Expansions{}
+	expansions.Update(c.opts.Expansions)
+	return &apimodels.ExpansionsAndVars{
+		Expansions:  expansions,
+		Parameters:  map[string]string{},
+		Vars:        map[string]string{},
+		PrivateVars: map[string]bool{},
+	}, nil
+}
+
+func (c *LocalCommunicator) GetCedarConfig(ctx context.Context) (*apimodels.CedarConfig, error) {
+	return nil, errNotAvailableLocally("getting the Cedar configuration")
+}
+
+func (c *LocalCommunicator) GetCedarGRPCConn(ctx context.Context) (*grpc.ClientConn, error) {
+	return nil, errNotAvailableLocally("connecting to Cedar")
+}
+
+func (c *LocalCommunicator) SetResultsInfo(ctx context.Context, td TaskData, service string, failed bool) error {
+	return nil
+}
+
+func (c *LocalCommunicator) SendTestResults(ctx context.Context, td TaskData, results []testresult.TestResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.testResults = append(c.testResults, results...)
+	return errors.Wrap(c.writeJSON(LocalTestResultsFile, c.testResults), "writing test results")
+}
+
+func (c *LocalCommunicator) GetDataPipesConfig(ctx context.Context) (*apimodels.DataPipesConfig, error) {
+	return nil, errNotAvailableLocally("getting the Data-Pipes configuration")
+}
+
+func (c *LocalCommunicator) GetPullRequestInfo(ctx context.Context, td TaskData, prNum int, owner, repo string, lastAttempt bool) (*apimodels.PullRequestInfo, error) {
+	return nil, errNotAvailableLocally("getting pull request info")
+}
+
+func (c *LocalCommunicator) DisableHost(ctx context.Context, hostID string, info apimodels.DisableInfo) error {
+	return errNotAvailableLocally("disabling a host")
+}
+
+// GetLoggerProducer returns a logger producer that writes the agent, task and
+// system logs to files in the output directory. The logger configuration is
+// ignored.
+func (c *LocalCommunicator) GetLoggerProducer(ctx context.Context, td TaskData, config *LoggerConfig) (LoggerProducer, error) {
+	var redactor *Redactor
+	if config != nil {
+		redactor = config.Redactor
+	}
+	levelInfo := send.LevelInfo{Default: level.Info, Threshold: level.Debug}
+
+	var underlying []send.Sender
+	makeSender := func(prefix, name string, stdout bool) (send.Sender, error) {
+		fileSender, err := send.NewPlainFileLogger(name, filepath.Join(c.opts.OutputDirectory, LocalLogDirectory, name+".log"), levelInfo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating %s file logger", name)
+		}
+		underlying = append(underlying, fileSender)
+		grip.Error(fileSender.SetFormatter(send.MakeDefaultFormatter()))
+		senders := []send.Sender{fileSender}
+		if stdout {
+			stdoutSender, err := send.NewPlainLogger(name, levelInfo)
+			if err != nil {
+				return nil, errors.Wrapf(err, "creating %s standard output logger", name)
+			}
+			grip.Error(stdoutSender.SetFormatter(send.MakeDefaultFormatter()))
+			senders = append(senders, stdoutSender)
+		}
+
+		sender := send.NewConfiguredMultiSender(senders...)
+		if prefix == apimodels.TaskLogPrefix {
+			sender = makeTimeoutLogSender(sender, c)
+		}
+		if redactor != nil {
+			sender = makeRedactingSender(sender, redactor)
+		}
+		return sender, nil
+	}
+
+	exec, err := makeSender(apimodels.AgentLogPrefix, "agent", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "making agent logger")
+	}
+	task, err := makeSender(apimodels.TaskLogPrefix, "task", c.opts.LogToStdout)
+	if err != nil {
+		return nil, errors.Wrap(err, "making task logger")
+	}
+	system, err := makeSender(apimodels.SystemLogPrefix, "system", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "making system logger")
+	}
+
+	return &logHarness{
+		execution:                 logging.MakeGrip(exec),
+		task:                      logging.MakeGrip(task),
+		system:                    logging.MakeGrip(system),
+		underlyingBufferedSenders: underlying,
+	}, nil
+}
+
+func (c *LocalCommunicator) GetLoggerMetadata() LoggerMetadata { return LoggerMetadata{} }
+
+func (c *LocalCommunicator) SendLogMessages(ctx context.Context, td TaskData, msgs []apimodels.LogMessage) error {
+	return nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// SendTestLog writes the test log to a file in the test log directory and
+// returns the file's path as the log's ID.
+func (c *LocalCommunicator) SendTestLog(ctx context.Context, td TaskData, log *model.TestLog) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := unsafeFileNameChars.ReplaceAllString(log.Name, "_")
+	path := filepath.Join(c.opts.OutputDirectory, LocalTestLogDirectory, fmt.Sprintf("%s-%d.log", name, c.numTestLogs))
+	c.numTestLogs++
+	f, err := os.Create(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "creating test log file '%s'", path)
+	}
+	defer f.Close()
+	for _, line := range log.Lines {
+		if _, err = fmt.Fprintln(f, line); err != nil {
+			return "", errors.Wrapf(err, "writing test log file '%s'", path)
+		}
+	}
+	return path, nil
+}
+
+func (c *LocalCommunicator) GetTaskPatch(ctx context.Context, td TaskData, patchID string) (*patchmodel.Patch, error) {
+	return nil, errNotAvailableLocally("getting the task's patch")
+}
+
+func (c *LocalCommunicator) GetPatchFile(ctx context.Context, td TaskData, patchFileID string) (string, error) {
+	return "", errNotAvailableLocally("getting a patch file")
+}
+
+func (c *LocalCommunicator) NewPush(ctx context.Context, td TaskData, req *apimodels.S3CopyRequest) (*model.PushLog, error) {
+	return nil, errNotAvailableLocally("pushing files")
+}
+
+func (c *LocalCommunicator) UpdatePushStatus(ctx context.Context, td TaskData, pushLog *model.PushLog) error {
+	return errNotAvailableLocally("pushing files")
+}
+
+func (c *LocalCommunicator) AttachFiles(ctx context.Context, td TaskData, files []*artifact.File) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.files = append(c.files, files...)
+	return errors.Wrap(c.writeJSON(LocalArtifactsFile, c.files), "writing artifacts")
+}
+
+func (c *LocalCommunicator) GetManifest(ctx context.Context, td TaskData) (*manifest.Manifest, error) {
+	return &manifest.Manifest{}, nil
+}
+
+func (c *LocalCommunicator) KeyValInc(ctx context.Context, td TaskData, kv *model.KeyVal) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keyVals[kv.Key]++
+	kv.Value = c.keyVals[kv.Key]
+	return nil
+}
+
+func (c *LocalCommunicator) GenerateTasks(ctx context.Context, td TaskData, jsonBytes []json.RawMessage) error {
+	return errNotAvailableLocally("generating tasks")
+}
+
+func (c *LocalCommunicator) GenerateTasksPoll(ctx context.Context, td TaskData) (*apimodels.GeneratePollResponse, error) {
+	return nil, errNotAvailableLocally("generating tasks")
+}
+
+func (c *LocalCommunicator) CreateHost(ctx context.Context, td TaskData, options apimodels.CreateHost) ([]string, error) {
+	return nil, errNotAvailableLocally("creating hosts")
+}
+
+func (c *LocalCommunicator) ListHosts(ctx context.Context, td TaskData) (restmodel.HostListResults, error) {
+	return restmodel.HostListResults{}, errNotAvailableLocally("listing hosts")
+}
+
+func (c *LocalCommunicator) GetDockerLogs(ctx context.Context, hostID string, startTime time.Time, endTime time.Time, isError bool) ([]byte, error) {
+	return nil, errNotAvailableLocally("getting Docker logs")
+}
+
+func (c *LocalCommunicator) GetDockerStatus(ctx context.Context, hostID string) (*cloud.ContainerStatus, error) {
+	return nil, errNotAvailableLocally("getting Docker status")
+}
+
+func (c *LocalCommunicator) ConcludeMerge(ctx context.Context, patchID, status string, td TaskData) error {
+	return errNotAvailableLocally("concluding a commit queue merge")
+}
+
+func (c *LocalCommunicator) GetAdditionalPatches(ctx context.Context, patchID string, td TaskData) ([]string, error) {
+	return nil, errNotAvailableLocally("getting additional commit queue patches")
+}
+
+func (c *LocalCommunicator) SetDownstreamParams(ctx context.Context, downstreamParams []patchmodel.Parameter, td TaskData) error {
+	return errNotAvailableLocally("setting downstream parameters")
+}
+
+// writeJSON writes the value as JSON to the file in the output directory.
+// The caller must hold the lock.
+func (c *LocalCommunicator) writeJSON(fileName string, val interface{}) error {
+	data, err := json.MarshalIndent(val, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshalling JSON")
+	}
+	return errors.WithStack(os.WriteFile(filepath.Join(c.opts.OutputDirectory, fileName), data, 0644))
+}
+
+func errNotAvailableLocally(operation string) error {
+	return errors.Errorf("%s is not available when running a task locally", operation)
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalCommunicator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	makeComm := func(t *testing.T) *LocalCommunicator {
+		comm, err := NewLocalCommunicator(LocalCommunicatorOptions{
+			Project:         &model.Project{Identifier: "project"},
+			ProjectRef:      &model.ProjectRef{Id: "project"},
+			Task:            &task.Task{Id: "task"},
+			Expansions:      util.Expansions{"key": "value"},
+			OutputDirectory: t.TempDir(),
+		})
+		require.NoError(t, err)
+		return comm
+	}
+	readJSON := func(t *testing.T, path string, out interface{}) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, out))
+	}
+	td := TaskData{ID: "task"}
+
+	t.Run("RequiresOptions", func(t *testing.T) {
+		_, err := NewLocalCommunicator(LocalCommunicatorOptions{OutputDirectory: t.TempDir()})
+		assert.Error(t, err)
+		_, err = NewLocalCommunicator(LocalCommunicatorOptions{
+			Project:    &model.Project{},
+			ProjectRef: &model.ProjectRef{},
+			Task:       &task.Task{},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("ServesTaskFromMemory", func(t *testing.T) {
+		comm := makeComm(t)
+		tsk, err := comm.GetTask(ctx, td)
+		require.NoError(t, err)
+		assert.Equal(t, "task", tsk.Id)
+
+		expansions, err := comm.GetExpansionsAndVars(ctx, td)
+		require.NoError(t, err)
+		assert.Equal(t, "value", expansions.Expansions.Get("key"))
+		expansions.Expansions.Put("key", "modified")
+		expansions, err = comm.GetExpansionsAndVars(ctx, td)
+		require.NoError(t, err)
+		assert.Equal(t, "value", expansions.Expansions.Get("key"), "expansions should be copied")
+	})
+	t.Run("EndTaskWritesDetail", func(t *testing.T) {
+		comm := makeComm(t)
+		assert.Nil(t, comm.EndTaskDetail())
+
+		resp, err := comm.EndTask(ctx, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed}, td)
+		require.NoError(t, err)
+		assert.True(t, resp.ShouldExit)
+		require.NotZero(t, comm.EndTaskDetail())
+		assert.Equal(t, evergreen.TaskFailed, comm.EndTaskDetail().Status)
+
+		detail := apimodels.TaskEndDetail{}
+		readJSON(t, filepath.Join(comm.opts.OutputDirectory, LocalTaskEndDetailFile), &detail)
+		assert.Equal(t, evergreen.TaskFailed, detail.Status)
+	})
+	t.Run("SendTestResultsAppends", func(t *testing.T) {
+		comm := makeComm(t)
+		require.NoError(t, comm.SendTestResults(ctx, td, []testresult.TestResult{{TestName: "test1"}}))
+		require.NoError(t, comm.SendTestResults(ctx, td, []testresult.TestResult{{TestName: "test2"}}))
+
+		var results []testresult.TestResult
+		readJSON(t, filepath.Join(comm.opts.OutputDirectory, LocalTestResultsFile), &results)
+		require.Len(t, results, 2)
+		assert.Equal(t, "test1", results[0].TestName)
+		assert.Equal(t, "test2", results[1].TestName)
+	})
+	t.Run("SendTestLogWritesFile", func(t *testing.T) {
+		comm := makeComm(t)
+		path1, err := comm.SendTestLog(ctx, td, &model.TestLog{Name: "dir/test", Lines: []string{"line1", "line2"}})
+		require.NoError(t, err)
+		path2, err := comm.SendTestLog(ctx, td, &model.TestLog{Name: "dir/test", Lines: []string{"line3"}})
+		require.NoError(t, err)
+		assert.NotEqual(t, path1, path2)
+		assert.Equal(t, filepath.Join(comm.opts.OutputDirectory, LocalTestLogDirectory), filepath.Dir(path1))
+
+		data, err := os.ReadFile(path1)
+		require.NoError(t, err)
+		assert.Equal(t, "line1\nline2\n", string(data))
+	})
+	t.Run("AttachFilesAppends", func(t *testing.T) {
+		comm := makeComm(t)
+		require.NoError(t, comm.AttachFiles(ctx, td, []*artifact.File{{Name: "file1", Link: "link1"}}))
+		require.NoError(t, comm.AttachFiles(ctx, td, []*artifact.File{{Name: "file2", Link: "link2"}}))
+
+		var files []artifact.File
+		readJSON(t, filepath.Join(comm.opts.OutputDirectory, LocalArtifactsFile), &files)
+		require.Len(t, files, 2)
+		assert.Equal(t, "file1", files[0].Name)
+		assert.Equal(t, "file2", files[1].Name)
+	})
+	t.Run("LoggerProducerWritesFiles", func(t *testing.T) {
+		comm := makeComm(t)
+		logger, err := comm.GetLoggerProducer(ctx, td, nil)
+		require.NoError(t, err)
+		logger.Task().Info("task message")
+		logger.Execution().Info("agent message")
+		require.NoError(t, logger.Close())
+
+		data, err := os.ReadFile(filepath.Join(comm.opts.OutputDirectory, LocalLogDirectory, "task.log"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "task message")
+		assert.NotContains(t, string(data), "agent message")
+		data, err = os.ReadFile(filepath.Join(comm.opts.OutputDirectory, LocalLogDirectory, "agent.log"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "agent message")
+	})
+	t.Run("UnavailableOperationsError", func(t *testing.T) {
+		comm := makeComm(t)
+		assert.Error(t, comm.GenerateTasks(ctx, td, nil))
+		_, err := comm.CreateHost(ctx, td, apimodels.CreateHost{})
+		assert.Error(t, err)
+	})
+}
@@ -331,7 +331,11 @@ func (c *Mock) GetMockMessages() map[string][]apimodels.LogMessage {
 
 // GetLoggerProducer constructs a single channel log producer.
 func (c *Mock) GetLoggerProducer(ctx context.Context, td TaskData, config *LoggerConfig) (LoggerProducer, error) {
-	return NewSingleChannelLogHarness(td.ID, newEvergreenLogSender(ctx, c, apimodels.AgentLogPrefix, td, defaultLogBufferSize, defaultLogBufferTime)), nil
+	sender := newEvergreenLogSender(ctx, c, apimodels.AgentLogPrefix, td, defaultLogBufferSize, defaultLogBufferTime)
+	if config != nil && config.Redactor != nil {
+		sender = makeRedactingSender(sender, config.Redactor)
+	}
+	return NewSingleChannelLogHarness(td.ID, sender), nil
 }
 
 func (c *Mock) GetLoggerMetadata() LoggerMetadata {
@@ -394,6 +398,15 @@ func (*Mock) CreateSpawnHost(ctx context.Context, spawnRequest *model.HostReques
 	return mockHost, nil
 }
 
+// SendTestResults appends the test results to the mock's local test results.
+func (c *Mock) SendTestResults(ctx context.Context, td TaskData, results []testresult.TestResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.LocalTestResults = append(c.LocalTestResults, results...)
+	return nil
+}
+
 func (c *Mock) SetResultsInfo(ctx context.Context, td TaskData, service string, failed bool) error {
 	c.ResultsService = service
 	if failed {
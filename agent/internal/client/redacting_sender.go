@@ -0,0 +1,250 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+)
+
+// RedactedPlaceholder replaces redacted values in log messages.
+const RedactedPlaceholder = "<REDACTED>"
+
+const (
+	// minLineTargetLength is the minimum length of a single line of a
+	// multiline value for it to be redacted on its own. Shorter lines, such
+	// as braces in a JSON document, are too common to redact.
+	minLineTargetLength = 8
+	// minPartialTargetLength is the minimum length of the first lines of a
+	// multiline value at the end of a message for the message to be held
+	// back in case the rest of the value is in the next message.
+	minPartialTargetLength = 4
+)
+
+// Redactor scrubs sensitive values from log messages and counts how many
+// values it has redacted. It is safe for concurrent use.
+type Redactor struct {
+	targets []string
+	count   int64
+}
+
+// NewRedactor returns a Redactor that scrubs the given values as well as
+// their base64 and URL-encoded forms. Since log output is sent line by
+// line, each sufficiently long line of a value that spans multiple lines is
+// also scrubbed on its own so that it's redacted even when the value is split
+// across separate log messages.
+func NewRedactor(values []string) *Redactor {
+	seen := map[string]bool{}
+	var targets []string
+	addTarget := func(target string) {
+		if target == "" || seen[target] {
+			return
+		}
+		seen[target] = true
+		targets = append(targets, target)
+	}
+
+	for _, val := range values {
+		if strings.TrimSpace(val) == "" {
+			continue
+		}
+		addTarget(val)
+		addTarget(base64.StdEncoding.EncodeToString([]byte(val)))
+		addTarget(base64.RawStdEncoding.EncodeToString([]byte(val)))
+		addTarget(base64.URLEncoding.EncodeToString([]byte(val)))
+		addTarget(base64.RawURLEncoding.EncodeToString([]byte(val)))
+		addTarget(url.QueryEscape(val))
+		addTarget(url.PathEscape(val))
+		if strings.Contains(val, "\n") {
+			for _, line := range strings.Split(val, "\n") {
+				line = strings.TrimSpace(line)
+				if len(line) < minLineTargetLength || strings.IndexFunc(line, isAlphanumeric) < 0 {
+					continue
+				}
+				addTarget(line)
+			}
+		}
+	}
+
+	// Longer targets are replaced first so that a value is redacted in
+	// full before any of its individual lines are.
+	sort.SliceStable(targets, func(i, j int) bool { return len(targets[i]) > len(targets[j]) })
+
+	return &Redactor{targets: targets}
+}
+
+func isAlphanumeric(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Redact returns the string with all sensitive values replaced and the
+// number of values that were replaced.
+func (r *Redactor) Redact(s string) (string, int) {
+	if r == nil {
+		return s, 0
+	}
+
+	s, n := r.redact(s)
+	r.addCount(n)
+
+	return s, n
+}
+
+func (r *Redactor) redact(s string) (string, int) {
+	var n int
+	for _, target := range r.targets {
+		if count := strings.Count(s, target); count > 0 {
+			s = strings.ReplaceAll(s, target, RedactedPlaceholder)
+			n += count
+		}
+	}
+	return s, n
+}
+
+func (r *Redactor) addCount(n int) {
+	if n > 0 {
+		atomic.AddInt64(&r.count, int64(n))
+	}
+}
+
+// endsWithPartialTarget returns whether the string ends with the lines at the
+// start, but not the whole, of a multiline sensitive value.
+func (r *Redactor) endsWithPartialTarget(s string) bool {
+	for _, target := range r.targets {
+		for i := len(target) - 1; i >= minPartialTargetLength; i-- {
+			if strings.HasPrefix(target[i:], messageBoundary) && strings.HasSuffix(s, target[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Count returns the total number of values that have been redacted.
+func (r *Redactor) Count() int64 {
+	if r == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&r.count)
+}
+
+// redactingSender redacts sensitive values from messages before passing them
+// to the underlying sender. Output is logged line by line, so a message that
+// ends with the start of a sensitive value is held back until the next message
+// arrives to check whether the two lines together contain the value.
+type redactingSender struct {
+	send.Sender
+	redactor *Redactor
+
+	mu      sync.Mutex
+	pending message.Composer
+}
+
+// messageBoundary separates a held message from the next one when checking
+// whether a sensitive value spans both of them.
+const messageBoundary = "\n"
+
+func (s *redactingSender) Send(m message.Composer) {
+	if !m.Loggable() {
+		s.Sender.Send(m)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending != nil {
+		pending := s.pending
+		s.pending = nil
+
+		redactedPending, pendingCount := s.redactor.redact(pending.String())
+		redactedMsg, msgCount := s.redactor.redact(m.String())
+		combined, combinedCount := s.redactor.redact(pending.String() + messageBoundary + m.String())
+		if combinedCount > pendingCount+msgCount {
+			// A sensitive value spans both messages, so they can only
+			// be redacted together. The boundary between them is kept
+			// so that the lines aren't joined in the output.
+			s.redactor.addCount(combinedCount)
+			s.sendOrHold(message.NewDefaultMessage(m.Priority(), combined), combined, combinedCount)
+			return
+		}
+
+		s.redactor.addCount(pendingCount)
+		s.sendRedacted(pending, redactedPending, pendingCount)
+		s.redactor.addCount(msgCount)
+		s.sendOrHold(m, redactedMsg, msgCount)
+		return
+	}
+
+	redacted, n := s.redactor.Redact(m.String())
+	s.sendOrHold(m, redacted, n)
+}
+
+// sendOrHold sends the redacted message unless it ends with the start of a
+// sensitive value, in which case it's held until the next message.
+func (s *redactingSender) sendOrHold(m message.Composer, redacted string, n int) {
+	if s.redactor.endsWithPartialTarget(redacted) {
+		if n > 0 {
+			m = message.NewDefaultMessage(m.Priority(), redacted)
+		}
+		s.pending = m
+		return
+	}
+	s.sendRedacted(m, redacted, n)
+}
+
+func (s *redactingSender) sendRedacted(m message.Composer, redacted string, n int) {
+	if n == 0 {
+		s.Sender.Send(m)
+		return
+	}
+	s.Sender.Send(message.NewDefaultMessage(m.Priority(), redacted))
+}
+
+// flushPending sends the held message, if any.
+func (s *redactingSender) flushPending() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending != nil {
+		s.Sender.Send(s.pending)
+		s.pending = nil
+	}
+}
+
+func (s *redactingSender) Flush(ctx context.Context) error {
+	s.flushPending()
+	return s.Sender.Flush(ctx)
+}
+
+func (s *redactingSender) Close() error {
+	s.flushPending()
+	return s.Sender.Close()
+}
+
+// FlushHeldMessages sends any messages that the logger's redacting senders
+// are holding back without flushing the underlying senders. It should be
+// called once a command has finished so that its last output isn't held until
+// another command logs.
+func FlushHeldMessages(logger LoggerProducer) {
+	for _, journaler := range []grip.Journaler{logger.Execution(), logger.Task(), logger.System()} {
+		if s, ok := journaler.GetSender().(*redactingSender); ok {
+			s.flushPending()
+		}
+	}
+}
+
+func makeRedactingSender(sender send.Sender, redactor *Redactor) send.Sender {
+	return &redactingSender{
+		Sender:   sender,
+		redactor: redactor,
+	}
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor(t *testing.T) {
+	const secret = "hunter2&pass/word"
+	redactor := NewRedactor([]string{secret, "", "  "})
+
+	for name, test := range map[string]struct {
+		input    string
+		expected string
+		count    int
+	}{
+		"Plain": {
+			input:    "the password is " + secret,
+			expected: "the password is " + RedactedPlaceholder,
+			count:    1,
+		},
+		"Repeated": {
+			input:    secret + " " + secret,
+			expected: RedactedPlaceholder + " " + RedactedPlaceholder,
+			count:    2,
+		},
+		"Base64": {
+			input:    "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(secret)),
+			expected: "Authorization: Basic " + RedactedPlaceholder,
+			count:    1,
+		},
+		"URLEncoded": {
+			input:    "https://example.com/?token=" + url.QueryEscape(secret),
+			expected: "https://example.com/?token=" + RedactedPlaceholder,
+			count:    1,
+		},
+		"NoMatch": {
+			input:    "nothing to see here",
+			expected: "nothing to see here",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			redacted, n := redactor.Redact(test.input)
+			assert.Equal(t, test.expected, redacted)
+			assert.Equal(t, test.count, n)
+		})
+	}
+	assert.EqualValues(t, 5, redactor.Count())
+
+	t.Run("MultilineValue", func(t *testing.T) {
+		redactor := NewRedactor([]string{"-----BEGIN KEY-----\nabcdefgh\n-----END KEY-----"})
+		redacted, n := redactor.Redact("abcdefgh")
+		assert.Equal(t, RedactedPlaceholder, redacted, "a line of the value logged on its own should be redacted")
+		assert.Equal(t, 1, n)
+
+		redacted, n = redactor.Redact("key: -----BEGIN KEY-----\nabcdefgh\n-----END KEY-----")
+		assert.Equal(t, "key: "+RedactedPlaceholder, redacted, "the whole value should be redacted before its lines")
+		assert.Equal(t, 1, n)
+	})
+	t.Run("ShortLinesOfMultilineValue", func(t *testing.T) {
+		redactor := NewRedactor([]string{"{\n  \"key\": \"abcdefgh\"\n}\n-------\nab"})
+		for _, line := range []string{"{", "}", "-------", "ab"} {
+			redacted, n := redactor.Redact(line)
+			assert.Equal(t, line, redacted, "short or punctuation-only lines should not be redacted on their own")
+			assert.Zero(t, n)
+		}
+		redacted, n := redactor.Redact(`"key": "abcdefgh"`)
+		assert.Equal(t, RedactedPlaceholder, redacted)
+		assert.Equal(t, 1, n)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var redactor *Redactor
+		redacted, n := redactor.Redact(secret)
+		assert.Equal(t, secret, redacted)
+		assert.Zero(t, n)
+		assert.Zero(t, redactor.Count())
+	})
+}
+
+func TestRedactingSender(t *testing.T) {
+	internal := send.MakeInternalLogger()
+	redactor := NewRedactor([]string{"secret"})
+	sender := makeRedactingSender(internal, redactor)
+
+	sender.Send(message.NewDefaultMessage(level.Info, "my secret value"))
+	msg, ok := internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "my <REDACTED> value", msg.Message.String())
+	assert.Equal(t, level.Info, msg.Message.Priority())
+
+	sender.Send(message.MakeFields(message.Fields{"token": "secret"}))
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.NotContains(t, msg.Message.String(), "secret")
+
+	unredacted := message.NewDefaultMessage(level.Info, "nothing sensitive")
+	sender.Send(unredacted)
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, unredacted, msg.Message, "messages without sensitive values should be sent unmodified")
+
+	assert.EqualValues(t, 2, redactor.Count())
+}
+
+func TestRedactingSenderSplitValue(t *testing.T) {
+	internal := send.MakeInternalLogger()
+	redactor := NewRedactor([]string{"super\nsecret"})
+	sender := makeRedactingSender(internal, redactor)
+
+	sender.Send(message.NewDefaultMessage(level.Info, "token=super"))
+	assert.False(t, internal.HasMessage(), "a message ending with the first line of a value should be held")
+	sender.Send(message.NewDefaultMessage(level.Info, "secret done"))
+	msg, ok := internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "token=<REDACTED> done", msg.Message.String())
+	assert.False(t, internal.HasMessage())
+	assert.EqualValues(t, 1, redactor.Count())
+
+	sender.Send(message.NewDefaultMessage(level.Info, "a super"))
+	sender.Send(message.NewDefaultMessage(level.Info, "secretary"))
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "a <REDACTED>ary", msg.Message.String())
+	assert.EqualValues(t, 2, redactor.Count())
+
+	sender.Send(message.NewDefaultMessage(level.Info, "a super"))
+	sender.Send(message.NewDefaultMessage(level.Info, "unrelated"))
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "a super", msg.Message.String(), "a held message should be sent on its own if the next one doesn't complete the value")
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "unrelated", msg.Message.String())
+
+	sender.Send(message.NewDefaultMessage(level.Info, "token=supersecret"))
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "token=supersecret", msg.Message.String(), "values split within a line should not be held")
+
+	sender.Send(message.NewDefaultMessage(level.Info, "last super"))
+	assert.False(t, internal.HasMessage())
+	require.NoError(t, sender.Flush(context.Background()))
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "last super", msg.Message.String(), "flushing should send the held message")
+
+	sender.Send(message.NewDefaultMessage(level.Info, "closing super"))
+	assert.False(t, internal.HasMessage())
+	require.NoError(t, sender.Close())
+	msg, ok = internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "closing super", msg.Message.String(), "closing should send the held message")
+	assert.EqualValues(t, 2, redactor.Count())
+}
+
+func TestFlushHeldMessages(t *testing.T) {
+	internal := send.MakeInternalLogger()
+	logger := NewSingleChannelLogHarness("test", makeRedactingSender(internal, NewRedactor([]string{"super\nsecret"})))
+
+	logger.Execution().Info("token=super")
+	assert.False(t, internal.HasMessage())
+	FlushHeldMessages(logger)
+	msg, ok := internal.GetMessageSafe()
+	require.True(t, ok)
+	assert.Equal(t, "token=super", msg.Message.String())
+	assert.False(t, logger.Closed())
+}
@@ -36,6 +36,13 @@ type TaskConfig struct {
 	EC2Keys            []evergreen.EC2Key
 	ModulePaths        map[string]string
 	CedarTestResultsID string
+	// TestResultsService is the service to which test results and test logs
+	// are sent.
+	TestResultsService string
+
+	// commandFailedTests is whether the command that is currently running
+	// has sent failed test results.
+	commandFailedTests bool
 
 	mu sync.RWMutex
 }
@@ -69,6 +76,22 @@ func (t *TaskConfig) GetExecTimeout() int {
 	return t.Timeout.ExecTimeoutSecs
 }
 
+// SetCommandFailedTests sets whether the command that is currently running
+// has sent failed test results.
+func (t *TaskConfig) SetCommandFailedTests(failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.commandFailedTests = failed
+}
+
+// CommandFailedTests returns whether the command that is currently running
+// has sent failed test results.
+func (t *TaskConfig) CommandFailedTests() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.commandFailedTests
+}
+
 func NewTaskConfig(workDir string, d *apimodels.DistroView, p *model.Project, t *task.Task, r *model.ProjectRef, patchDoc *patch.Patch, e util.Expansions) (*TaskConfig, error) {
 	// do a check on if the project is empty
 	if p == nil {
@@ -0,0 +1,234 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	localProjectID  = "local"
+	localVersionID  = "local"
+	localTaskSecret = "local"
+)
+
+// LocalOptions configure running a single task from a project configuration
+// file on the local machine.
+type LocalOptions struct {
+	// ProjectPath is the path to the project configuration file.
+	ProjectPath string
+	// TaskName is the name of the task to run.
+	TaskName string
+	// BuildVariant is the build variant to run the task in. It can be
+	// omitted if only one build variant lists the task.
+	BuildVariant string
+	// WorkingDirectory is the directory in which the task directory is
+	// created.
+	WorkingDirectory string
+	// OutputDirectory is the directory where the logs, test results and
+	// artifacts are written.
+	OutputDirectory string
+	// Expansions are added to the task's expansions, overriding the
+	// expansions defined in the project configuration.
+	Expansions map[string]string
+	// LogToStdout additionally prints the task logs to standard output.
+	LogToStdout bool
+}
+
+func (o *LocalOptions) validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(o.ProjectPath == "", "must specify a project configuration file")
+	catcher.NewWhen(o.TaskName == "", "must specify a task")
+	catcher.NewWhen(o.WorkingDirectory == "", "must specify a working directory")
+	catcher.NewWhen(o.OutputDirectory == "", "must specify an output directory")
+	return catcher.Resolve()
+}
+
+// RunLocal runs a task from a project configuration file on the local machine
+// with the same command semantics as the agent, including the pre, post and
+// timeout blocks and the setup and teardown of its task group. It returns
+// the task's final status. Unlike the agent, it keeps the task directory so
+// that it can be inspected after the task finishes.
+func RunLocal(ctx context.Context, opts LocalOptions) (*apimodels.TaskEndDetail, error) {
+	if err := opts.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid options")
+	}
+
+	data, err := os.ReadFile(opts.ProjectPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading project configuration file '%s'", opts.ProjectPath)
+	}
+	project := &model.Project{}
+	loadOpts := &model.GetProjectOpts{
+		ReadFileFrom: model.ReadFromLocal,
+	}
+	if _, err = model.LoadProjectInto(ctx, data, loadOpts, "", project); err != nil {
+		return nil, errors.Wrapf(err, "loading project configuration file '%s'", opts.ProjectPath)
+	}
+	bvtu, err := findLocalTask(project, opts.TaskName, opts.BuildVariant)
+	if err != nil {
+		return nil, err
+	}
+
+	projectRef := &model.ProjectRef{
+		Id:         localProjectID,
+		Identifier: project.Identifier,
+		Owner:      project.Owner,
+		Repo:       project.Repo,
+		Branch:     project.Branch,
+		Enabled:    true,
+	}
+	if projectRef.Identifier == "" {
+		projectRef.Identifier = localProjectID
+	}
+	project.Identifier = projectRef.Identifier
+	tsk := &task.Task{
+		Id:           fmt.Sprintf("%s_%s_%s", localProjectID, bvtu.Variant, bvtu.Name),
+		Secret:       localTaskSecret,
+		DisplayName:  bvtu.Name,
+		BuildVariant: bvtu.Variant,
+		BuildId:      fmt.Sprintf("%s_%s", localProjectID, bvtu.Variant),
+		Version:      localVersionID,
+		Project:      projectRef.Id,
+		Requester:    evergreen.PatchVersionRequester,
+		TaskGroup:    bvtu.GroupName,
+		Status:       evergreen.TaskDispatched,
+	}
+
+	comm, err := client.NewLocalCommunicator(client.LocalCommunicatorOptions{
+		Project:         project,
+		ProjectRef:      projectRef,
+		Task:            tsk,
+		Expansions:      localExpansions(tsk, projectRef, opts.Expansions),
+		OutputDirectory: opts.OutputDirectory,
+		LogToStdout:     opts.LogToStdout,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "making local communicator")
+	}
+	if err = os.MkdirAll(opts.WorkingDirectory, 0755); err != nil {
+		return nil, errors.Wrapf(err, "making working directory '%s'", opts.WorkingDirectory)
+	}
+	a, err := newWithCommunicator(ctx, Options{
+		Mode:             HostMode,
+		WorkingDirectory: opts.WorkingDirectory,
+		LogPrefix:        filepath.Join(opts.OutputDirectory, client.LocalLogDirectory, "agent-process"),
+	}, comm)
+	if err != nil {
+		return nil, errors.Wrap(err, "making agent")
+	}
+	defer a.Close(ctx)
+	a.SetDefaultLogger(grip.GetSender())
+
+	tc := a.prepareNextTask(ctx, &apimodels.NextTaskResponse{
+		TaskId:     tsk.Id,
+		TaskSecret: tsk.Secret,
+		TaskGroup:  tsk.TaskGroup,
+		Version:    tsk.Version,
+		Build:      tsk.BuildId,
+	}, &taskContext{})
+	if err = a.fetchProjectConfig(ctx, tc); err != nil {
+		return nil, errors.Wrap(err, "loading project configuration")
+	}
+	tc.jasper = a.jasper
+
+	if _, err = a.runTask(ctx, tc); err != nil {
+		return nil, errors.Wrap(err, "running task")
+	}
+	a.runLocalTeardownGroup(ctx, tc)
+
+	detail := comm.EndTaskDetail()
+	if detail == nil {
+		return nil, errors.New("task did not finish")
+	}
+	return detail, nil
+}
+
+// runLocalTeardownGroup runs the task group's teardown commands, if any, but
+// unlike runPostGroupCommands, it does not remove the task directory.
+func (a *Agent) runLocalTeardownGroup(ctx context.Context, tc *taskContext) {
+	defer func() {
+		if tc.logger != nil {
+			grip.Error(tc.logger.Close())
+		}
+	}()
+	if tc.taskConfig == nil {
+		return
+	}
+	taskGroup, err := tc.taskConfig.GetTaskGroup(tc.taskGroup)
+	if err != nil {
+		tc.logger.Execution().Error(errors.Wrap(err, "fetching task group for post-group commands"))
+		return
+	}
+	if taskGroup.TeardownGroup == nil {
+		return
+	}
+
+	defer a.killProcs(ctx, tc, true)
+	ctx, cancel := a.withCallbackTimeout(ctx, tc)
+	defer cancel()
+	err = a.runCommands(ctx, tc, taskGroup.TeardownGroup.List(), runCommandsOptions{}, postBlock)
+	tc.logger.Execution().Error(errors.Wrap(err, "running post-group commands"))
+}
+
+// findLocalTask finds the task to run in the build variant. If no build
+// variant is given, the task must be listed in exactly one build variant.
+func findLocalTask(project *model.Project, taskName, variant string) (*model.BuildVariantTaskUnit, error) {
+	var matches []model.BuildVariantTaskUnit
+	for _, bvtu := range project.FindAllBuildVariantTasks() {
+		if bvtu.Name != taskName {
+			continue
+		}
+		if variant != "" && bvtu.Variant != variant {
+			continue
+		}
+		matches = append(matches, bvtu)
+	}
+
+	switch len(matches) {
+	case 0:
+		if variant != "" {
+			return nil, errors.Errorf("task '%s' is not in build variant '%s'", taskName, variant)
+		}
+		return nil, errors.Errorf("task '%s' is not in any build variant", taskName)
+	case 1:
+		return &matches[0], nil
+	default:
+		variants := make([]string, 0, len(matches))
+		for _, bvtu := range matches {
+			variants = append(variants, bvtu.Variant)
+		}
+		return nil, errors.Errorf("task '%s' is in multiple build variants, so one must be specified: %s", taskName, strings.Join(variants, ", "))
+	}
+}
+
+// localExpansions returns the expansions that the app server would populate
+// for the task, followed by the given expansions.
+func localExpansions(tsk *task.Task, projectRef *model.ProjectRef, overrides map[string]string) util.Expansions {
+	expansions := util.Expansions{}
+	expansions.Put("execution", "0")
+	expansions.Put("version_id", tsk.Version)
+	expansions.Put("task_id", tsk.Id)
+	expansions.Put("task_name", tsk.DisplayName)
+	expansions.Put("build_id", tsk.BuildId)
+	expansions.Put("build_variant", tsk.BuildVariant)
+	expansions.Put("project", projectRef.Identifier)
+	expansions.Put("project_identifier", projectRef.Identifier)
+	expansions.Put("project_id", projectRef.Id)
+	expansions.Put("branch_name", projectRef.Branch)
+	expansions.Put("requester", "patch")
+	expansions.Put("is_patch", "true")
+	expansions.Update(overrides)
+	return expansions
+}
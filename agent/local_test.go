@@ -0,0 +1,187 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const localTestProject = `
+functions:
+  write_file:
+    - command: shell.exec
+      params:
+        script: echo "${greeting} from ${task_name} on ${build_variant}" > ${output_file}
+
+tasks:
+  - name: succeeds
+    commands:
+      - func: write_file
+        vars:
+          output_file: greeting.txt
+  - name: fails
+    commands:
+      - command: shell.exec
+        params:
+          script: exit 1
+  - name: grouped
+    commands:
+      - command: shell.exec
+        params:
+          script: echo grouped > grouped.txt
+
+task_groups:
+  - name: group
+    setup_group:
+      - command: shell.exec
+        params:
+          script: echo setup > setup_group.txt
+    teardown_group:
+      - command: shell.exec
+        params:
+          script: echo teardown > teardown_group.txt
+    tasks:
+      - grouped
+
+buildvariants:
+  - name: bv1
+    display_name: Variant 1
+    run_on:
+      - local
+    expansions:
+      greeting: hello
+    tasks:
+      - succeeds
+      - fails
+      - group
+  - name: bv2
+    display_name: Variant 2
+    run_on:
+      - local
+    tasks:
+      - fails
+`
+
+func TestFindLocalTask(t *testing.T) {
+	project := &model.Project{}
+	_, err := model.LoadProjectInto(context.Background(), []byte(localTestProject), nil, "", project)
+	require.NoError(t, err)
+
+	t.Run("UniqueTask", func(t *testing.T) {
+		bvtu, err := findLocalTask(project, "succeeds", "")
+		require.NoError(t, err)
+		assert.Equal(t, "bv1", bvtu.Variant)
+	})
+	t.Run("TaskInGroup", func(t *testing.T) {
+		bvtu, err := findLocalTask(project, "grouped", "")
+		require.NoError(t, err)
+		assert.Equal(t, "group", bvtu.GroupName)
+	})
+	t.Run("AmbiguousTaskRequiresVariant", func(t *testing.T) {
+		_, err := findLocalTask(project, "fails", "")
+		assert.Error(t, err)
+
+		bvtu, err := findLocalTask(project, "fails", "bv2")
+		require.NoError(t, err)
+		assert.Equal(t, "bv2", bvtu.Variant)
+	})
+	t.Run("MissingTask", func(t *testing.T) {
+		_, err := findLocalTask(project, "nonexistent", "")
+		assert.Error(t, err)
+		_, err = findLocalTask(project, "succeeds", "bv2")
+		assert.Error(t, err)
+	})
+}
+
+func TestLocalExpansions(t *testing.T) {
+	tsk := &task.Task{
+		Id:           "task_id",
+		DisplayName:  "task_name",
+		BuildVariant: "bv",
+		BuildId:      "build_id",
+		Version:      "version_id",
+	}
+	projectRef := &model.ProjectRef{Id: "project_id", Identifier: "project_identifier"}
+	expansions := localExpansions(tsk, projectRef, map[string]string{"build_variant": "override", "extra": "value"})
+
+	assert.Equal(t, "task_id", expansions.Get("task_id"))
+	assert.Equal(t, "task_name", expansions.Get("task_name"))
+	assert.Equal(t, "project_identifier", expansions.Get("project"))
+	assert.Equal(t, "true", expansions.Get("is_patch"))
+	assert.Equal(t, "override", expansions.Get("build_variant"))
+	assert.Equal(t, "value", expansions.Get("extra"))
+}
+
+func TestRunLocal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	projectPath := filepath.Join(t.TempDir(), "project.yml")
+	require.NoError(t, os.WriteFile(projectPath, []byte(localTestProject), 0644))
+
+	// findTaskFile returns the path to the file in the task directory, which
+	// is the only directory in the working directory.
+	findTaskFile := func(t *testing.T, workDir, name string) string {
+		matches, err := filepath.Glob(filepath.Join(workDir, "*", name))
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		return matches[0]
+	}
+	makeOpts := func(t *testing.T, taskName, variant string) LocalOptions {
+		return LocalOptions{
+			ProjectPath:      projectPath,
+			TaskName:         taskName,
+			BuildVariant:     variant,
+			WorkingDirectory: t.TempDir(),
+			OutputDirectory:  t.TempDir(),
+		}
+	}
+
+	t.Run("Succeeds", func(t *testing.T) {
+		opts := makeOpts(t, "succeeds", "")
+		opts.Expansions = map[string]string{"build_variant": "override"}
+		detail, err := RunLocal(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, evergreen.TaskSucceeded, detail.Status)
+
+		data, err := os.ReadFile(findTaskFile(t, opts.WorkingDirectory, "greeting.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello from succeeds on override\n", string(data))
+
+		assert.FileExists(t, filepath.Join(opts.OutputDirectory, client.LocalTaskEndDetailFile))
+		data, err = os.ReadFile(filepath.Join(opts.OutputDirectory, client.LocalLogDirectory, "task.log"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Task completed - SUCCESS.")
+	})
+	t.Run("Fails", func(t *testing.T) {
+		detail, err := RunLocal(ctx, makeOpts(t, "fails", "bv2"))
+		require.NoError(t, err)
+		assert.Equal(t, evergreen.TaskFailed, detail.Status)
+	})
+	t.Run("RunsTaskGroupSetupAndTeardown", func(t *testing.T) {
+		opts := makeOpts(t, "grouped", "")
+		detail, err := RunLocal(ctx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, evergreen.TaskSucceeded, detail.Status)
+
+		for _, name := range []string{"setup_group.txt", "grouped.txt", "teardown_group.txt"} {
+			assert.FileExists(t, findTaskFile(t, opts.WorkingDirectory, name))
+		}
+	})
+	t.Run("InvalidTask", func(t *testing.T) {
+		_, err := RunLocal(ctx, makeOpts(t, "fails", ""))
+		assert.Error(t, err)
+	})
+	t.Run("MissingOptions", func(t *testing.T) {
+		_, err := RunLocal(ctx, LocalOptions{ProjectPath: projectPath})
+		assert.Error(t, err)
+	})
+}
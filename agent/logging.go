@@ -132,7 +132,7 @@ func (a *Agent) prepLogger(tc *taskContext, c *model.LoggerConfig, commandName s
 		logDir = filepath.Join(logDir, commandName)
 		grip.Error(errors.Wrapf(os.MkdirAll(logDir, os.ModeDir|os.ModePerm), "making log directory '%s' for command '%s'", logDir, commandName))
 	}
-	config := client.LoggerConfig{}
+	config := client.LoggerConfig{Redactor: tc.redactor}
 
 	var defaultLogger string
 	if tc.taskConfig != nil && tc.taskConfig.ProjectRef != nil {
@@ -165,6 +165,21 @@ func (a *Agent) prepLogger(tc *taskContext, c *model.LoggerConfig, commandName s
 	return config
 }
 
+// newPrivateVarsRedactor returns a redactor that scrubs the values of the
+// private variables from the task's logs.
+func newPrivateVarsRedactor(expansions map[string]string, privateVars map[string]bool) *client.Redactor {
+	var values []string
+	for key, isPrivate := range privateVars {
+		if !isPrivate {
+			continue
+		}
+		if val := expansions[key]; val != "" {
+			values = append(values, val)
+		}
+	}
+	return client.NewRedactor(values)
+}
+
 func (a *Agent) prepSingleLogger(tc *taskContext, in model.LogOpts, logDir, fileName string) client.LogOpts {
 	splunkServer, err := tc.expansions.ExpandString(in.SplunkServer)
 	if err != nil {
@@ -333,3 +333,16 @@ func TestTimberSender(t *testing.T) {
 	}
 	assert.NoError(t, agt.startLogging(ctx, tc))
 }
+
+func TestNewPrivateVarsRedactor(t *testing.T) {
+	expansions := util.Expansions{
+		"private": "p4ssw0rd",
+		"public":  "visible",
+		"empty":   "",
+	}
+	redactor := newPrivateVarsRedactor(expansions, map[string]bool{"private": true, "empty": true, "public": false})
+
+	redacted, n := redactor.Redact("p4ssw0rd visible")
+	assert.Equal(t, client.RedactedPlaceholder+" visible", redacted)
+	assert.Equal(t, 1, n)
+}
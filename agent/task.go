@@ -193,6 +193,7 @@ func (tc *taskContext) setCurrentCommand(command command.Command) {
 	tc.Lock()
 	defer tc.Unlock()
 	tc.currentCommand = command
+	tc.currentCommandFailedTests = false
 
 	if tc.logger != nil {
 		tc.logger.Execution().Infof("Current command set to '%s' (%s).", tc.currentCommand.DisplayName(), tc.currentCommand.Type())
@@ -205,6 +206,18 @@ func (tc *taskContext) getCurrentCommand() command.Command {
 	return tc.currentCommand
 }
 
+func (tc *taskContext) setCurrentCommandFailedTests(failed bool) {
+	tc.Lock()
+	defer tc.Unlock()
+	tc.currentCommandFailedTests = failed
+}
+
+func (tc *taskContext) getCurrentCommandFailedTests() bool {
+	tc.RLock()
+	defer tc.RUnlock()
+	return tc.currentCommandFailedTests
+}
+
 func (tc *taskContext) setCurrentIdleTimeout(cmd command.Command) {
 	tc.Lock()
 	defer tc.Unlock()
@@ -267,6 +280,20 @@ func (tc *taskContext) getOomTrackerInfo() *apimodels.OOMTrackerInfo {
 	}
 }
 
+func (tc *taskContext) addCommandStats(stats apimodels.CommandStats) {
+	tc.Lock()
+	defer tc.Unlock()
+
+	tc.commandStats = append(tc.commandStats, stats)
+}
+
+func (tc *taskContext) getCommandStats() []apimodels.CommandStats {
+	tc.RLock()
+	defer tc.RUnlock()
+
+	return tc.commandStats
+}
+
 func (tc *taskContext) oomTrackerEnabled(cloudProvider string) bool {
 	return tc.project.OomTracker && !utility.StringSliceContains(evergreen.ProviderContainer, cloudProvider)
 }
@@ -345,6 +372,7 @@ func (a *Agent) makeTaskConfig(ctx context.Context, tc *taskContext) (*internal.
 	taskConfig.Redacted = tc.privateVars
 	taskConfig.TaskSync = a.opts.SetupData.TaskSync
 	taskConfig.EC2Keys = a.opts.SetupData.EC2Keys
+	taskConfig.TestResultsService = a.opts.SetupData.TestResultsService
 
 	return taskConfig, nil
 }
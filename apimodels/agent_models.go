@@ -67,6 +67,34 @@ type TaskEndDetail struct {
 	OOMTracker      *OOMTrackerInfo `bson:"oom_killer,omitempty" json:"oom_killer,omitempty"`
 	Logs            *TaskLogs       `bson:"-" json:"logs,omitempty"`
 	Modules         ModuleCloneInfo `bson:"modules,omitempty" json:"modules,omitempty"`
+	CommandStats    []CommandStats  `bson:"command_stats,omitempty" json:"command_stats,omitempty"`
+	// FailingCommandFailedTests is whether the command that failed the task
+	// sent failed test results.
+	FailingCommandFailedTests bool `bson:"failing_command_failed_tests,omitempty" json:"failing_command_failed_tests,omitempty"`
+}
+
+// CommandStats are the resources used on the host while a single command ran.
+// CPU usage is the percentage of the host's total CPU capacity in use, and
+// memory usage is the resident set size of the agent and the processes it
+// started.
+type CommandStats struct {
+	Command        string        `bson:"command" json:"command"`
+	Block          string        `bson:"block" json:"block"`
+	Step           string        `bson:"step" json:"step"`
+	StartTime      time.Time     `bson:"start_time" json:"start_time"`
+	Duration       time.Duration `bson:"duration" json:"duration"`
+	PeakCPUPercent float64       `bson:"peak_cpu_percent" json:"peak_cpu_percent"`
+	AvgCPUPercent  float64       `bson:"avg_cpu_percent" json:"avg_cpu_percent"`
+	PeakRSSBytes   uint64        `bson:"peak_rss_bytes" json:"peak_rss_bytes"`
+	AvgRSSBytes    uint64        `bson:"avg_rss_bytes" json:"avg_rss_bytes"`
+	DiskReadBytes  uint64        `bson:"disk_read_bytes" json:"disk_read_bytes"`
+	DiskWriteBytes uint64        `bson:"disk_write_bytes" json:"disk_write_bytes"`
+}
+
+// SameCommand returns whether the stats are for the same command in the same
+// position of the task as the other stats.
+func (s CommandStats) SameCommand(other CommandStats) bool {
+	return s.Command == other.Command && s.Block == other.Block && s.Step == other.Step
 }
 
 type OOMTrackerInfo struct {
@@ -114,6 +142,7 @@ type AgentSetupData struct {
 	EC2Keys                []evergreen.EC2Key      `json:"ec2_keys"`
 	LogkeeperURL           string                  `json:"logkeeper_url"`
 	TraceCollectorEndpoint string                  `json:"trace_collector_endpoint"`
+	TestResultsService     string                  `json:"test_results_service"`
 }
 
 // NextTaskResponse represents the response sent back when an agent asks for a next task
@@ -141,6 +170,10 @@ type CreateHost struct {
 	TeardownTimeoutSecs int    `mapstructure:"timeout_teardown_secs" json:"timeout_teardown_secs" yaml:"timeout_teardown_secs"`
 	Retries             int    `mapstructure:"retries" json:"retries" yaml:"retries"`
 
+	// Pool is the name of the project's host pool to lease hosts from rather
+	// than creating new hosts. The hosts' settings come from the pool.
+	Pool string `mapstructure:"pool" json:"pool,omitempty" yaml:"pool,omitempty" plugin:"expand"`
+
 	// EC2-related settings
 	AMI             string      `mapstructure:"ami" json:"ami" yaml:"ami" plugin:"expand"`
 	Distro          string      `mapstructure:"distro" json:"distro" yaml:"distro" plugin:"expand"`
@@ -305,7 +338,31 @@ func (ch *CreateHost) setNumHosts() error {
 	return nil
 }
 
+// validatePool validates the options for leasing hosts from a host pool.
+func (ch *CreateHost) validatePool() error {
+	catcher := grip.NewBasicCatcher()
+
+	catcher.Add(ch.setNumHosts())
+	if ch.CloudProvider != "" || ch.Distro != "" || ch.AMI != "" || ch.Image != "" {
+		catcher.New("host settings must be defined in the host pool rather than in host.create")
+	}
+	if ch.Scope != "" {
+		catcher.New("scope cannot be set for hosts leased from a host pool")
+	}
+	if ch.SetupTimeoutSecs == 0 {
+		ch.SetupTimeoutSecs = DefaultSetupTimeoutSecs
+	}
+	if ch.SetupTimeoutSecs < 60 || ch.SetupTimeoutSecs > 3600 {
+		catcher.New("timeout setup (seconds) must be between 60 and 3600")
+	}
+	return catcher.Resolve()
+}
+
 func (ch *CreateHost) Validate() error {
+	if ch.Pool != "" {
+		return ch.validatePool()
+	}
+
 	if ch.CloudProvider == ProviderEC2 || ch.CloudProvider == "" { //default
 		ch.CloudProvider = ProviderEC2
 		return ch.ValidateEC2()
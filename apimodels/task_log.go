@@ -156,33 +156,39 @@ func ReadBuildloggerToChan(ctx context.Context, taskID string, r io.ReadCloser,
 			return
 		}
 
-		severity := int(level.Info)
-		if strings.HasPrefix(line, "[P: ") {
-			severity, err = strconv.Atoi(strings.TrimSpace(line[3:6]))
-			if err != nil {
-				grip.Error(message.WrapError(err, message.Fields{
-					"task_id": taskID,
-					"message": "problem reading buildlogger log line severity",
-				}))
-				err = nil
-			}
-			line = line[8:]
-		}
-
 		select {
 		case <-ctx.Done():
 			grip.Error(message.WrapError(ctx.Err(), message.Fields{
 				"task_id": taskID,
 				"message": "context error while reading buildlogger log lines",
 			}))
-		case lines <- LogMessage{
-			Message:  strings.TrimSuffix(line, "\n"),
-			Severity: GetSeverityMapping(severity),
-		}:
+		case lines <- ParseBuildloggerLine(taskID, line):
 		}
 	}
 }
 
+// ParseBuildloggerLine parses the severity and message from a Cedar
+// buildlogger log line.
+func ParseBuildloggerLine(taskID, line string) LogMessage {
+	severity := int(level.Info)
+	if strings.HasPrefix(line, "[P: ") {
+		var err error
+		severity, err = strconv.Atoi(strings.TrimSpace(line[3:6]))
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"task_id": taskID,
+				"message": "problem reading buildlogger log line severity",
+			}))
+		}
+		line = line[8:]
+	}
+
+	return LogMessage{
+		Message:  strings.TrimSuffix(line, "\n"),
+		Severity: GetSeverityMapping(severity),
+	}
+}
+
 // ReadBuildloggerToSlice returns a slice of LogMessages from an io.ReadCloser.
 func ReadBuildloggerToSlice(ctx context.Context, taskID string, r io.ReadCloser) []LogMessage {
 	lines := []LogMessage{}
@@ -71,6 +71,15 @@ type Manager interface {
 	// GetVolumeAttachment gets a volume's attachment
 	GetVolumeAttachment(context.Context, string) (*VolumeAttachment, error)
 
+	// CreateSnapshot saves the state of a host in the provider so that it can
+	// later be restored on a new host, and records the provider's resources
+	// in the snapshot.
+	CreateSnapshot(context.Context, *host.Host, *host.Snapshot) error
+
+	// DeleteSnapshot deletes a snapshot's resources in the provider and
+	// removes the snapshot.
+	DeleteSnapshot(context.Context, *host.Snapshot) error
+
 	// CheckInstanceType determines if the given instance type is available in the current region.
 	CheckInstanceType(context.Context, string) error
 
@@ -137,6 +146,8 @@ func GetSettings(provider string) (ProviderSettings, error) {
 		return &GCESettings{}, nil
 	case evergreen.ProviderNameVsphere:
 		return &vsphereSettings{}, nil
+	case evergreen.ProviderNameLibvirt:
+		return &LibvirtSettings{}, nil
 	}
 	return nil, errors.Errorf("invalid provider name '%s'", provider)
 }
@@ -181,6 +192,8 @@ func GetManager(ctx context.Context, env evergreen.Environment, mgrOpts ManagerO
 		provider = &gceManager{}
 	case evergreen.ProviderNameVsphere:
 		provider = &vsphereManager{}
+	case evergreen.ProviderNameLibvirt:
+		provider = &libvirtManager{env: env}
 	default:
 		return nil, errors.Errorf("no known provider '%s'", mgrOpts.Provider)
 	}
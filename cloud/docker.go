@@ -2,6 +2,7 @@ package cloud
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -224,6 +225,48 @@ func (m *dockerManager) GetVolumeAttachment(context.Context, string) (*VolumeAtt
 	return nil, errors.New("can't get volume attachment with Docker provider")
 }
 
+// CreateSnapshot commits the host's container to a new image on its parent.
+func (m *dockerManager) CreateSnapshot(ctx context.Context, h *host.Host, snapshot *host.Snapshot) error {
+	parent, err := h.GetParent()
+	if err != nil {
+		return errors.Wrapf(err, "retrieving parent for host '%s'", h.Id)
+	}
+
+	image := fmt.Sprintf(dockerSnapshotImage, snapshot.ID)
+	if _, err = m.client.CommitContainer(ctx, parent, h.Id, image); err != nil {
+		return errors.Wrapf(err, "committing container for host '%s'", h.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message":   "committed Docker container for snapshot",
+		"container": h.Id,
+		"parent":    parent.Id,
+		"snapshot":  snapshot.ID,
+		"image":     image,
+	})
+
+	return errors.Wrapf(snapshot.SetProviderResources(image, ""), "recording image for snapshot '%s'", snapshot.ID)
+}
+
+// DeleteSnapshot removes the snapshot's image from the parent that holds it.
+func (m *dockerManager) DeleteSnapshot(ctx context.Context, snapshot *host.Snapshot) error {
+	if snapshot.ImageID != "" && snapshot.ParentID != "" {
+		parent, err := host.FindOneId(snapshot.ParentID)
+		if err != nil {
+			return errors.Wrapf(err, "finding parent '%s' for snapshot '%s'", snapshot.ParentID, snapshot.ID)
+		}
+		// The image is removed along with its parent, so there's nothing to
+		// clean up once the parent is gone.
+		if parent != nil && parent.Status != evergreen.HostTerminated {
+			if err = m.client.RemoveImage(ctx, parent, snapshot.ImageID); err != nil {
+				return errors.Wrapf(err, "removing image for snapshot '%s'", snapshot.ID)
+			}
+		}
+	}
+
+	return errors.Wrapf(snapshot.Remove(), "deleting snapshot '%s' in DB", snapshot.ID)
+}
+
 func (m *dockerManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with Docker provider")
 }
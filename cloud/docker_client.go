@@ -41,6 +41,7 @@ type DockerClient interface {
 	RemoveContainer(context.Context, *host.Host, string) error
 	StartContainer(context.Context, *host.Host, string) error
 	ListImages(context.Context, *host.Host) ([]types.ImageSummary, error)
+	CommitContainer(context.Context, *host.Host, string, string) (string, error)
 }
 
 type dockerClientImpl struct {
@@ -61,6 +62,9 @@ type ContainerStatus struct {
 const (
 	provisionedImageTag = "%s:provisioned"
 	imageImportTimeout  = 10 * time.Minute
+	// dockerSnapshotImage is the reference for the image committed from a
+	// container for a spawn host snapshot.
+	dockerSnapshotImage = "evergreen-snapshot:%s"
 )
 
 func GetDockerClient(s *evergreen.Settings) DockerClient {
@@ -542,6 +546,32 @@ func (c *dockerClientImpl) StartContainer(ctx context.Context, h *host.Host, con
 	return nil
 }
 
+// CommitContainer creates a new image with the given reference from the
+// current state of the container on the host machine and returns the ID of
+// the new image.
+func (c *dockerClientImpl) CommitContainer(ctx context.Context, h *host.Host, containerID, reference string) (string, error) {
+	dockerClient, err := c.generateClient(h)
+	if err != nil {
+		return "", errors.Wrap(err, "generating Docker client")
+	}
+
+	grip.Info(makeDockerLogMessage("ContainerCommit", h.Id, message.Fields{
+		"container": containerID,
+		"reference": reference,
+	}))
+
+	opts := types.ContainerCommitOptions{
+		Reference: reference,
+		Comment:   fmt.Sprintf("snapshot of container '%s'", containerID),
+	}
+	resp, err := dockerClient.ContainerCommit(ctx, containerID, opts)
+	if err != nil {
+		return "", errors.Wrapf(err, "committing container '%s'", containerID)
+	}
+
+	return resp.ID, nil
+}
+
 func makeDockerLogMessage(name, parent string, data interface{}) message.Fields {
 	return message.Fields{
 		"message":  "Docker API call",
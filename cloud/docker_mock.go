@@ -25,6 +25,7 @@ type dockerClientMock struct {
 	failList     bool
 	failRemove   bool
 	failStart    bool
+	failCommit   bool
 
 	// Other options
 	hasOpenPorts bool
@@ -167,3 +168,10 @@ func (c *dockerClientMock) StartContainer(context.Context, *host.Host, string) e
 	}
 	return nil
 }
+
+func (c *dockerClientMock) CommitContainer(context.Context, *host.Host, string, string) (string, error) {
+	if c.failCommit {
+		return "", errors.New("failed to commit container")
+	}
+	return "sha256:snapshot", nil
+}
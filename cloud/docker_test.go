@@ -61,7 +61,7 @@ func (s *DockerSuite) SetupTest() {
 }
 
 func (s *DockerSuite) TearDownTest() {
-	s.NoError(db.Clear(host.Collection))
+	s.NoError(db.ClearCollections(host.Collection, host.SnapshotsCollection))
 }
 
 func (s *DockerSuite) TestConfigureAPICall() {
@@ -138,6 +138,35 @@ func (s *DockerSuite) TestTerminateInstanceDB() {
 	s.Error(err)
 }
 
+func (s *DockerSuite) TestSnapshot() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	s.Require().NoError(h.Insert())
+	snapshot := &host.Snapshot{
+		ID:       "snapshot",
+		ParentID: s.parentHost.Id,
+		Status:   host.SnapshotStatusCreating,
+	}
+	s.Require().NoError(snapshot.Insert())
+
+	mock, ok := s.client.(*dockerClientMock)
+	s.Require().True(ok)
+	mock.failCommit = true
+	s.Error(s.manager.CreateSnapshot(ctx, h, snapshot))
+	s.Empty(snapshot.ImageID)
+
+	mock.failCommit = false
+	s.Require().NoError(s.manager.CreateSnapshot(ctx, h, snapshot))
+	s.Equal("evergreen-snapshot:snapshot", snapshot.ImageID)
+
+	s.Require().NoError(s.manager.DeleteSnapshot(ctx, snapshot))
+	dbSnapshot, err := host.FindSnapshotByID(snapshot.ID)
+	s.NoError(err)
+	s.Nil(dbSnapshot)
+}
+
 func (s *DockerSuite) TestSpawnInvalidSettings() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -197,6 +198,11 @@ const (
 	checkSuccessAttempts   = 12
 	checkSuccessInitPeriod = 2 * time.Second
 	checkSuccessMaxDelay   = 2 * time.Minute
+
+	// checkSnapshotAttempts is the number of times to check whether a
+	// snapshot's image and volume snapshot are ready. Images can take much
+	// longer to create than other resources.
+	checkSnapshotAttempts = 40
 )
 
 const (
@@ -1098,6 +1104,10 @@ func (m *ec2Manager) CreateVolume(ctx context.Context, volume *host.Volume) (*ho
 		input.Iops = aws.Int32(defaultIops)
 	}
 
+	if volume.SnapshotID != "" {
+		input.SnapshotId = aws.String(volume.SnapshotID)
+	}
+
 	resp, err := m.client.CreateVolume(ctx, input)
 
 	if err != nil {
@@ -1169,6 +1179,177 @@ func (m *ec2Manager) GetVolumeAttachment(ctx context.Context, volumeID string) (
 	return attachment, nil
 }
 
+// CreateSnapshot creates an AMI of the host's root device and a separate EBS
+// snapshot of its home volume, then waits for both to be ready. Other volumes
+// attached to the host are left out of the AMI.
+func (m *ec2Manager) CreateSnapshot(ctx context.Context, h *host.Host, snapshot *host.Snapshot) error {
+	if h.Status != evergreen.HostRunning && h.Status != evergreen.HostStopped {
+		return errors.Errorf("cannot snapshot host '%s' because its status ('%s') is not running or stopped", h.Id, h.Status)
+	}
+
+	if err := m.client.Create(ctx, m.credentials, m.region); err != nil {
+		return errors.Wrap(err, "creating client")
+	}
+	defer m.client.Close()
+
+	tags := []types.Tag{
+		{Key: aws.String(evergreen.TagOwner), Value: aws.String(snapshot.CreatedBy)},
+		{Key: aws.String(evergreen.TagExpireOn), Value: aws.String(snapshot.Expiration.Add(time.Hour * 24 * evergreen.SpawnHostExpireDays).Format(evergreen.ExpireOnFormat))},
+	}
+	excludedDevices := []types.BlockDeviceMapping{}
+	for _, attachment := range h.Volumes {
+		excludedDevices = append(excludedDevices, types.BlockDeviceMapping{
+			DeviceName: aws.String(attachment.DeviceName),
+			NoDevice:   aws.String(""),
+		})
+	}
+	imageOut, err := m.client.CreateImage(ctx, &ec2.CreateImageInput{
+		InstanceId:          aws.String(h.Id),
+		Name:                aws.String(fmt.Sprintf("evergreen-snapshot-%s", snapshot.ID)),
+		Description:         aws.String(fmt.Sprintf("snapshot of spawn host '%s'", h.Id)),
+		NoReboot:            aws.Bool(true),
+		BlockDeviceMappings: excludedDevices,
+		TagSpecifications: []types.TagSpecification{
+			{ResourceType: types.ResourceTypeImage, Tags: tags},
+			{ResourceType: types.ResourceTypeSnapshot, Tags: tags},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "creating image of host '%s'", h.Id)
+	}
+	if imageOut.ImageId == nil {
+		return errors.New("new image returned by EC2 does not have an ID")
+	}
+	// Record the image before creating anything else so that it can be
+	// cleaned up if the rest of the snapshot fails.
+	if err = snapshot.SetProviderResources(*imageOut.ImageId, ""); err != nil {
+		return errors.Wrapf(err, "recording image for snapshot '%s'", snapshot.ID)
+	}
+
+	if homeVolume := h.HomeVolume(); homeVolume != nil {
+		volumeSnapshotOut, err := m.client.CreateSnapshot(ctx, &ec2.CreateSnapshotInput{
+			VolumeId:    aws.String(homeVolume.VolumeID),
+			Description: aws.String(fmt.Sprintf("home volume snapshot of spawn host '%s'", h.Id)),
+			TagSpecifications: []types.TagSpecification{
+				{ResourceType: types.ResourceTypeSnapshot, Tags: tags},
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "creating snapshot of home volume '%s'", homeVolume.VolumeID)
+		}
+		if volumeSnapshotOut.SnapshotId == nil {
+			return errors.New("new volume snapshot returned by EC2 does not have an ID")
+		}
+		if err = snapshot.SetProviderResources(snapshot.ImageID, *volumeSnapshotOut.SnapshotId); err != nil {
+			return errors.Wrapf(err, "recording home volume snapshot for snapshot '%s'", snapshot.ID)
+		}
+	}
+
+	return errors.Wrapf(m.waitForSnapshot(ctx, snapshot), "waiting for snapshot '%s' to be ready", snapshot.ID)
+}
+
+// waitForSnapshot polls the snapshot's image and volume snapshot until they're
+// ready to be restored from.
+func (m *ec2Manager) waitForSnapshot(ctx context.Context, snapshot *host.Snapshot) error {
+	return utility.Retry(
+		ctx,
+		func() (bool, error) {
+			imageOut, err := m.client.DescribeImages(ctx, &ec2.DescribeImagesInput{
+				ImageIds: []string{snapshot.ImageID},
+			})
+			if err != nil {
+				return false, errors.Wrapf(err, "describing image '%s'", snapshot.ImageID)
+			}
+			if len(imageOut.Images) == 0 {
+				return true, errors.Errorf("image '%s' not found", snapshot.ImageID)
+			}
+			switch imageOut.Images[0].State {
+			case types.ImageStateAvailable:
+			case types.ImageStatePending:
+				return true, errors.Errorf("image '%s' is still pending", snapshot.ImageID)
+			default:
+				return false, errors.Errorf("image '%s' is in unexpected state '%s'", snapshot.ImageID, imageOut.Images[0].State)
+			}
+
+			if snapshot.HomeVolumeSnapshotID == "" {
+				return false, nil
+			}
+			volumeSnapshotOut, err := m.client.DescribeSnapshots(ctx, &ec2.DescribeSnapshotsInput{
+				SnapshotIds: []string{snapshot.HomeVolumeSnapshotID},
+			})
+			if err != nil {
+				return false, errors.Wrapf(err, "describing volume snapshot '%s'", snapshot.HomeVolumeSnapshotID)
+			}
+			if len(volumeSnapshotOut.Snapshots) == 0 {
+				return true, errors.Errorf("volume snapshot '%s' not found", snapshot.HomeVolumeSnapshotID)
+			}
+			switch volumeSnapshotOut.Snapshots[0].State {
+			case types.SnapshotStateCompleted:
+				return false, nil
+			case types.SnapshotStatePending:
+				return true, errors.Errorf("volume snapshot '%s' is still pending", snapshot.HomeVolumeSnapshotID)
+			default:
+				return false, errors.Errorf("volume snapshot '%s' is in unexpected state '%s'", snapshot.HomeVolumeSnapshotID, volumeSnapshotOut.Snapshots[0].State)
+			}
+		}, utility.RetryOptions{
+			MaxAttempts: checkSnapshotAttempts,
+			MinDelay:    checkSuccessInitPeriod,
+			MaxDelay:    checkSuccessMaxDelay,
+		})
+}
+
+// DeleteSnapshot deregisters the snapshot's AMI and deletes the EBS snapshots
+// backing it, including the home volume snapshot.
+func (m *ec2Manager) DeleteSnapshot(ctx context.Context, snapshot *host.Snapshot) error {
+	if err := m.client.Create(ctx, m.credentials, m.region); err != nil {
+		return errors.Wrap(err, "creating client")
+	}
+	defer m.client.Close()
+
+	volumeSnapshotIDs := []string{}
+	if snapshot.ImageID != "" {
+		// Deregistering an AMI doesn't delete the EBS snapshots that back it,
+		// so they have to be found before the AMI is gone.
+		imageOut, err := m.client.DescribeImages(ctx, &ec2.DescribeImagesInput{
+			ImageIds: []string{snapshot.ImageID},
+		})
+		if err != nil && !strings.Contains(err.Error(), EC2ImageNotFound) {
+			return errors.Wrapf(err, "describing image '%s'", snapshot.ImageID)
+		}
+		if imageOut != nil {
+			for _, image := range imageOut.Images {
+				for _, mapping := range image.BlockDeviceMappings {
+					if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+						volumeSnapshotIDs = append(volumeSnapshotIDs, *mapping.Ebs.SnapshotId)
+					}
+				}
+			}
+		}
+
+		if _, err = m.client.DeregisterImage(ctx, &ec2.DeregisterImageInput{
+			ImageId: aws.String(snapshot.ImageID),
+		}); err != nil {
+			return errors.Wrapf(err, "deregistering image '%s'", snapshot.ImageID)
+		}
+	}
+	if snapshot.HomeVolumeSnapshotID != "" {
+		volumeSnapshotIDs = append(volumeSnapshotIDs, snapshot.HomeVolumeSnapshotID)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, id := range volumeSnapshotIDs {
+		_, err := m.client.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{
+			SnapshotId: aws.String(id),
+		})
+		catcher.Wrapf(err, "deleting volume snapshot '%s'", id)
+	}
+	if catcher.HasErrors() {
+		return catcher.Resolve()
+	}
+
+	return errors.Wrapf(snapshot.Remove(), "deleting snapshot '%s' in DB", snapshot.ID)
+}
+
 func (m *ec2Manager) modifyVolumeExpiration(ctx context.Context, volume *host.Volume, newExpiration time.Time) error {
 	if err := volume.SetExpiration(newExpiration); err != nil {
 		return errors.Wrapf(err, "updating expiration for volume '%s'", volume.ID)
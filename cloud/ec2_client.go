@@ -80,6 +80,24 @@ type AWSClient interface {
 	// DescribeVolumes is a wrapper for ec2.DescribeVolumes.
 	DescribeVolumes(context.Context, *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error)
 
+	// CreateImage is a wrapper for ec2.CreateImage.
+	CreateImage(context.Context, *ec2.CreateImageInput) (*ec2.CreateImageOutput, error)
+
+	// DescribeImages is a wrapper for ec2.DescribeImages.
+	DescribeImages(context.Context, *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error)
+
+	// DeregisterImage is a wrapper for ec2.DeregisterImage.
+	DeregisterImage(context.Context, *ec2.DeregisterImageInput) (*ec2.DeregisterImageOutput, error)
+
+	// CreateSnapshot is a wrapper for ec2.CreateSnapshot.
+	CreateSnapshot(context.Context, *ec2.CreateSnapshotInput) (*ec2.CreateSnapshotOutput, error)
+
+	// DescribeSnapshots is a wrapper for ec2.DescribeSnapshots.
+	DescribeSnapshots(context.Context, *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error)
+
+	// DeleteSnapshot is a wrapper for ec2.DeleteSnapshot.
+	DeleteSnapshot(context.Context, *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error)
+
 	// DescribeSubnets is a wrapper for ec2.DescribeSubnets.
 	DescribeSubnets(context.Context, *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
 
@@ -583,6 +601,171 @@ func (c *awsClientImpl) DescribeVolumes(ctx context.Context, input *ec2.Describe
 	return output, nil
 }
 
+// CreateImage is a wrapper for ec2.CreateImage.
+func (c *awsClientImpl) CreateImage(ctx context.Context, input *ec2.CreateImageInput) (*ec2.CreateImageOutput, error) {
+	var output *ec2.CreateImageOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("CreateImage", fmt.Sprintf("%T", c), input)
+			output, err = c.client.CreateImage(ctx, input)
+			if err != nil {
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					grip.Debug(message.WrapError(apiErr, msg))
+					if strings.Contains(apiErr.Error(), EC2InvalidParam) || strings.Contains(apiErr.Error(), EC2DuplicateImageName) {
+						return false, err
+					}
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// DescribeImages is a wrapper for ec2.DescribeImages.
+func (c *awsClientImpl) DescribeImages(ctx context.Context, input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	var output *ec2.DescribeImagesOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("DescribeImages", fmt.Sprintf("%T", c), input)
+			output, err = c.client.DescribeImages(ctx, input)
+			if err != nil {
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					grip.Debug(message.WrapError(apiErr, msg))
+					if strings.Contains(apiErr.Error(), EC2ImageNotFound) {
+						return false, err
+					}
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// DeregisterImage is a wrapper for ec2.DeregisterImage.
+func (c *awsClientImpl) DeregisterImage(ctx context.Context, input *ec2.DeregisterImageInput) (*ec2.DeregisterImageOutput, error) {
+	var output *ec2.DeregisterImageOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("DeregisterImage", fmt.Sprintf("%T", c), input)
+			output, err = c.client.DeregisterImage(ctx, input)
+			if err != nil {
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					grip.Debug(message.WrapError(apiErr, msg))
+					if strings.Contains(apiErr.Error(), EC2ImageNotFound) {
+						return false, nil
+					}
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// CreateSnapshot is a wrapper for ec2.CreateSnapshot.
+func (c *awsClientImpl) CreateSnapshot(ctx context.Context, input *ec2.CreateSnapshotInput) (*ec2.CreateSnapshotOutput, error) {
+	var output *ec2.CreateSnapshotOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("CreateSnapshot", fmt.Sprintf("%T", c), input)
+			output, err = c.client.CreateSnapshot(ctx, input)
+			if err != nil {
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					grip.Debug(message.WrapError(apiErr, msg))
+					if strings.Contains(apiErr.Error(), EC2InvalidParam) {
+						return false, err
+					}
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// DescribeSnapshots is a wrapper for ec2.DescribeSnapshots.
+func (c *awsClientImpl) DescribeSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	var output *ec2.DescribeSnapshotsOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("DescribeSnapshots", fmt.Sprintf("%T", c), input)
+			output, err = c.client.DescribeSnapshots(ctx, input)
+			if err != nil {
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					grip.Debug(message.WrapError(apiErr, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// DeleteSnapshot is a wrapper for ec2.DeleteSnapshot.
+func (c *awsClientImpl) DeleteSnapshot(ctx context.Context, input *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
+	var output *ec2.DeleteSnapshotOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("DeleteSnapshot", fmt.Sprintf("%T", c), input)
+			output, err = c.client.DeleteSnapshot(ctx, input)
+			if err != nil {
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					grip.Debug(message.WrapError(apiErr, msg))
+					if strings.Contains(apiErr.Error(), EC2SnapshotNotFound) {
+						return false, nil
+					}
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 // DescribeSubnets is a wrapper for ec2.DescribeSubnets.
 func (c *awsClientImpl) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
 	var output *ec2.DescribeSubnetsOutput
@@ -1005,6 +1188,12 @@ type awsClientMock struct { //nolint
 	*ec2.DetachVolumeInput
 	*ec2.ModifyVolumeInput
 	*ec2.DescribeVolumesInput
+	*ec2.CreateImageInput
+	*ec2.DescribeImagesInput
+	*ec2.DeregisterImageInput
+	*ec2.CreateSnapshotInput
+	*ec2.DescribeSnapshotsInput
+	DeleteSnapshotInputs []*ec2.DeleteSnapshotInput
 	*ec2.DescribeSubnetsInput
 	*ec2.DescribeVpcsInput
 	*ec2.CreateKeyPairInput
@@ -1192,6 +1381,65 @@ func (c *awsClientMock) DescribeVolumes(ctx context.Context, input *ec2.Describe
 	}, nil
 }
 
+// CreateImage is a mock for ec2.CreateImage.
+func (c *awsClientMock) CreateImage(ctx context.Context, input *ec2.CreateImageInput) (*ec2.CreateImageOutput, error) {
+	c.CreateImageInput = input
+	return &ec2.CreateImageOutput{ImageId: aws.String("ami-snapshot")}, nil
+}
+
+// DescribeImages is a mock for ec2.DescribeImages.
+func (c *awsClientMock) DescribeImages(ctx context.Context, input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	c.DescribeImagesInput = input
+	return &ec2.DescribeImagesOutput{
+		Images: []types.Image{
+			{
+				ImageId: aws.String(input.ImageIds[0]),
+				State:   types.ImageStateAvailable,
+				BlockDeviceMappings: []types.BlockDeviceMapping{
+					{
+						DeviceName: aws.String("/dev/sda1"),
+						Ebs:        &types.EbsBlockDevice{SnapshotId: aws.String("snap-root")},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// DeregisterImage is a mock for ec2.DeregisterImage.
+func (c *awsClientMock) DeregisterImage(ctx context.Context, input *ec2.DeregisterImageInput) (*ec2.DeregisterImageOutput, error) {
+	c.DeregisterImageInput = input
+	return nil, nil
+}
+
+// CreateSnapshot is a mock for ec2.CreateSnapshot.
+func (c *awsClientMock) CreateSnapshot(ctx context.Context, input *ec2.CreateSnapshotInput) (*ec2.CreateSnapshotOutput, error) {
+	c.CreateSnapshotInput = input
+	return &ec2.CreateSnapshotOutput{
+		SnapshotId: aws.String("snap-home"),
+		VolumeId:   input.VolumeId,
+	}, nil
+}
+
+// DescribeSnapshots is a mock for ec2.DescribeSnapshots.
+func (c *awsClientMock) DescribeSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	c.DescribeSnapshotsInput = input
+	return &ec2.DescribeSnapshotsOutput{
+		Snapshots: []types.Snapshot{
+			{
+				SnapshotId: aws.String(input.SnapshotIds[0]),
+				State:      types.SnapshotStateCompleted,
+			},
+		},
+	}, nil
+}
+
+// DeleteSnapshot is a mock for ec2.DeleteSnapshot.
+func (c *awsClientMock) DeleteSnapshot(ctx context.Context, input *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
+	c.DeleteSnapshotInputs = append(c.DeleteSnapshotInputs, input)
+	return nil, nil
+}
+
 // DescribeSubnets is a mock for ec2.DescribeSubnets.
 func (c *awsClientMock) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
 	c.DescribeSubnetsInput = input
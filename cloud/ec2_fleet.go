@@ -399,6 +399,14 @@ func (m *ec2FleetManager) GetVolumeAttachment(context.Context, string) (*VolumeA
 	return nil, errors.New("can't get volume attachment with EC2 fleet provider")
 }
 
+func (m *ec2FleetManager) CreateSnapshot(context.Context, *host.Host, *host.Snapshot) error {
+	return errors.New("can't create snapshot with EC2 fleet provider")
+}
+
+func (m *ec2FleetManager) DeleteSnapshot(context.Context, *host.Snapshot) error {
+	return errors.New("can't delete snapshot with EC2 fleet provider")
+}
+
 func (m *ec2FleetManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
 	if err := m.client.Create(ctx, m.credentials, m.region); err != nil {
 		return "", errors.Wrap(err, "creating client")
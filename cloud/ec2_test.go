@@ -57,7 +57,7 @@ func TestEC2Suite(t *testing.T) {
 }
 
 func (s *EC2Suite) SetupTest() {
-	s.Require().NoError(db.ClearCollections(host.Collection, host.VolumesCollection, task.Collection, model.ProjectVarsCollection))
+	s.Require().NoError(db.ClearCollections(host.Collection, host.VolumesCollection, host.SnapshotsCollection, task.Collection, model.ProjectVarsCollection))
 	s.onDemandOpts = &EC2ManagerOptions{
 		client: &awsClientMock{},
 	}
@@ -1255,6 +1255,69 @@ func (s *EC2Suite) TestDeleteVolume() {
 	s.NoError(err)
 }
 
+func (s *EC2Suite) TestCreateSnapshot() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.h.Status = evergreen.HostRunning
+	s.h.Volumes = []host.VolumeAttachment{
+		{VolumeID: "home-volume", DeviceName: "/dev/sdb", IsHome: true},
+		{VolumeID: "other-volume", DeviceName: "/dev/sdc"},
+	}
+	snapshot := &host.Snapshot{
+		ID:         "snapshot",
+		CreatedBy:  "test-user",
+		Status:     host.SnapshotStatusCreating,
+		Expiration: time.Now().Add(time.Hour),
+	}
+	s.Require().NoError(snapshot.Insert())
+	s.Require().NoError(s.onDemandManager.CreateSnapshot(ctx, s.h, snapshot))
+
+	imageInput := *s.mock.CreateImageInput
+	s.Equal("h1", *imageInput.InstanceId)
+	s.True(*imageInput.NoReboot)
+	s.Require().Len(imageInput.BlockDeviceMappings, 2, "attached volumes should be excluded from the image")
+	s.Equal("/dev/sdb", *imageInput.BlockDeviceMappings[0].DeviceName)
+	s.Equal("", *imageInput.BlockDeviceMappings[0].NoDevice)
+	s.Equal("home-volume", *s.mock.CreateSnapshotInput.VolumeId)
+
+	s.Equal("ami-snapshot", snapshot.ImageID)
+	s.Equal("snap-home", snapshot.HomeVolumeSnapshotID)
+	dbSnapshot, err := host.FindSnapshotByID(snapshot.ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(dbSnapshot)
+	s.Equal("ami-snapshot", dbSnapshot.ImageID)
+	s.Equal("snap-home", dbSnapshot.HomeVolumeSnapshotID)
+
+	s.h.Status = evergreen.HostTerminated
+	s.Error(s.onDemandManager.CreateSnapshot(ctx, s.h, snapshot))
+}
+
+func (s *EC2Suite) TestDeleteSnapshot() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshot := &host.Snapshot{
+		ID:                   "snapshot",
+		ImageID:              "ami-snapshot",
+		HomeVolumeSnapshotID: "snap-home",
+		Status:               host.SnapshotStatusAvailable,
+	}
+	s.Require().NoError(snapshot.Insert())
+	s.Require().NoError(s.onDemandManager.DeleteSnapshot(ctx, snapshot))
+
+	s.Equal("ami-snapshot", *s.mock.DeregisterImageInput.ImageId)
+	deleted := []string{}
+	for _, input := range s.mock.DeleteSnapshotInputs {
+		deleted = append(deleted, *input.SnapshotId)
+	}
+	s.ElementsMatch([]string{"snap-root", "snap-home"}, deleted)
+
+	dbSnapshot, err := host.FindSnapshotByID(snapshot.ID)
+	s.NoError(err)
+	s.Nil(dbSnapshot)
+}
+
 func (s *EC2Suite) TestAttachVolume() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
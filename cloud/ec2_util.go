@@ -31,6 +31,9 @@ const (
 	EC2InsufficientCapacity = "InsufficientInstanceCapacity"
 	EC2InvalidParam         = "InvalidParameterValue"
 	EC2VolumeNotFound       = "InvalidVolume.NotFound"
+	EC2ImageNotFound        = "InvalidAMIID.NotFound"
+	EC2DuplicateImageName   = "InvalidAMIName.Duplicate"
+	EC2SnapshotNotFound     = "InvalidSnapshot.NotFound"
 	EC2VolumeResizeRate     = "VolumeModificationRateExceeded"
 	ec2TemplateNameExists   = "InvalidLaunchTemplateName.AlreadyExistsException"
 )
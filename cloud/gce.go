@@ -236,6 +236,14 @@ func (m *gceManager) GetVolumeAttachment(context.Context, string) (*VolumeAttach
 	return nil, errors.New("can't get volume attachment with GCE provider")
 }
 
+func (m *gceManager) CreateSnapshot(context.Context, *host.Host, *host.Snapshot) error {
+	return errors.New("can't create snapshot with GCE provider")
+}
+
+func (m *gceManager) DeleteSnapshot(context.Context, *host.Snapshot) error {
+	return errors.New("can't delete snapshot with GCE provider")
+}
+
 func (m *gceManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with GCE provider")
 }
@@ -0,0 +1,759 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	defaultLibvirtPool     = "default"
+	defaultLibvirtNetwork  = "default"
+	defaultLibvirtNumCPUs  = 2
+	defaultLibvirtMemoryMB = 4096
+)
+
+// LibvirtSettings specifies the settings used to configure a libvirt VM.
+type LibvirtSettings struct {
+	// Hypervisors are the names of the hypervisors on which the VM may be
+	// created. If unset, any configured hypervisor may be used. The VM is
+	// created on the candidate hypervisor running the fewest VMs.
+	Hypervisors []string `mapstructure:"hypervisors" json:"hypervisors,omitempty" bson:"hypervisors,omitempty"`
+	// BaseImage is the name of the volume that is cloned to create the VM's
+	// root disk.
+	BaseImage string `mapstructure:"base_image" json:"base_image" bson:"base_image"`
+	// StoragePool is the storage pool containing the base image, in which the
+	// VM's root disk is created.
+	StoragePool string `mapstructure:"storage_pool" json:"storage_pool,omitempty" bson:"storage_pool,omitempty"`
+	// Network is the libvirt network to which the VM is connected.
+	Network string `mapstructure:"network" json:"network,omitempty" bson:"network,omitempty"`
+
+	NumCPUs  int `mapstructure:"num_cpus" json:"num_cpus,omitempty" bson:"num_cpus,omitempty"`
+	MemoryMB int `mapstructure:"memory_mb" json:"memory_mb,omitempty" bson:"memory_mb,omitempty"`
+}
+
+// Validate verifies a set of LibvirtSettings and sets defaults for any unset
+// optional fields.
+func (opts *LibvirtSettings) Validate() error {
+	if opts.BaseImage == "" {
+		return errors.New("base image must not be blank")
+	}
+	if opts.NumCPUs < 0 {
+		return errors.New("number of CPUs must be non-negative")
+	}
+	if opts.MemoryMB < 0 {
+		return errors.New("memory in MB must be non-negative")
+	}
+
+	if opts.StoragePool == "" {
+		opts.StoragePool = defaultLibvirtPool
+	}
+	if opts.Network == "" {
+		opts.Network = defaultLibvirtNetwork
+	}
+	if opts.NumCPUs == 0 {
+		opts.NumCPUs = defaultLibvirtNumCPUs
+	}
+	if opts.MemoryMB == 0 {
+		opts.MemoryMB = defaultLibvirtMemoryMB
+	}
+
+	return nil
+}
+
+// FromDistroSettings loads the libvirt settings from the distro.
+func (opts *LibvirtSettings) FromDistroSettings(d distro.Distro, _ string) error {
+	if len(d.ProviderSettingsList) != 0 {
+		bytes, err := d.ProviderSettingsList[0].MarshalBSON()
+		if err != nil {
+			return errors.Wrap(err, "marshalling provider setting into BSON")
+		}
+		if err := bson.Unmarshal(bytes, opts); err != nil {
+			return errors.Wrap(err, "unmarshalling BSON into provider settings")
+		}
+	}
+	return nil
+}
+
+// libvirtManager implements the Manager interface for VMs running on libvirt
+// hypervisors. Each host's zone is the name of the hypervisor on which its VM
+// runs, and each volume's availability zone is the name of the hypervisor
+// on which the volume is stored.
+type libvirtManager struct {
+	env         evergreen.Environment
+	hypervisors map[string]evergreen.LibvirtHypervisor
+	// newClient returns a client connected to the hypervisor with the given
+	// URI.
+	newClient func(uri string) libvirtClient
+}
+
+// Configure loads the hypervisors from the admin settings.
+func (m *libvirtManager) Configure(ctx context.Context, s *evergreen.Settings) error {
+	m.hypervisors = map[string]evergreen.LibvirtHypervisor{}
+	for _, hv := range s.Providers.Libvirt.Hypervisors {
+		m.hypervisors[hv.Name] = hv
+	}
+	if len(m.hypervisors) == 0 {
+		return errors.New("no libvirt hypervisors are configured")
+	}
+
+	if m.newClient == nil {
+		m.newClient = newLibvirtClient
+	}
+
+	return nil
+}
+
+// getClient returns a client for the named hypervisor.
+func (m *libvirtManager) getClient(hypervisor string) (libvirtClient, error) {
+	hv, ok := m.hypervisors[hypervisor]
+	if !ok {
+		return nil, errors.Errorf("libvirt hypervisor '%s' is not configured", hypervisor)
+	}
+	return m.newClient(hv.URI), nil
+}
+
+// getVolumePool returns the storage pool for volumes on the named hypervisor.
+func (m *libvirtManager) getVolumePool(hypervisor string) string {
+	if pool := m.hypervisors[hypervisor].VolumePool; pool != "" {
+		return pool
+	}
+	return defaultLibvirtPool
+}
+
+// chooseHypervisor returns the candidate hypervisor that is running the
+// fewest VMs. Hypervisors that cannot be reached are skipped.
+func (m *libvirtManager) chooseHypervisor(ctx context.Context, s *LibvirtSettings) (string, error) {
+	candidates := s.Hypervisors
+	if len(candidates) == 0 {
+		for name := range m.hypervisors {
+			candidates = append(candidates, name)
+		}
+		sort.Strings(candidates)
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	chosen := ""
+	minRunning := 0
+	catcher := grip.NewBasicCatcher()
+	for _, name := range candidates {
+		client, err := m.getClient(name)
+		if err != nil {
+			catcher.Add(err)
+			continue
+		}
+		domains, err := client.ListDomains(ctx)
+		if err != nil {
+			catcher.Wrapf(err, "listing domains on hypervisor '%s'", name)
+			continue
+		}
+
+		numRunning := 0
+		for _, state := range domains {
+			if libvirtToEvgStatus(state) == StatusRunning {
+				numRunning++
+			}
+		}
+		if chosen == "" || numRunning < minRunning {
+			chosen = name
+			minRunning = numRunning
+		}
+	}
+
+	if chosen == "" {
+		return "", errors.Wrap(catcher.Resolve(), "no libvirt hypervisors are available")
+	}
+	grip.Warning(message.WrapError(catcher.Resolve(), message.Fields{
+		"message":    "could not check some libvirt hypervisors while choosing where to spawn a host",
+		"chosen":     chosen,
+		"candidates": candidates,
+	}))
+
+	return chosen, nil
+}
+
+// libvirtRootVolumeName returns the name of the root volume for the host.
+func libvirtRootVolumeName(hostID string) string {
+	return fmt.Sprintf("%s-root.qcow2", hostID)
+}
+
+// libvirtVolumeName returns the name of the volume in the storage pool.
+func libvirtVolumeName(volumeID string) string {
+	return fmt.Sprintf("%s.qcow2", volumeID)
+}
+
+// SpawnHost creates a new VM by cloning the distro's base image and booting a
+// domain from it. The host's zone is set to the hypervisor running the VM.
+func (m *libvirtManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
+	if h.Distro.Provider != evergreen.ProviderNameLibvirt {
+		return nil, errors.Errorf("can't spawn instance for distro '%s': distro provider is '%s'", h.Distro.Id, h.Distro.Provider)
+	}
+
+	s := &LibvirtSettings{}
+	if err := s.FromDistroSettings(h.Distro, ""); err != nil {
+		return nil, errors.Wrapf(err, "getting provider settings from distro '%s'", h.Distro.Id)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid provider settings in distro '%s'", h.Distro.Id)
+	}
+
+	hypervisor, err := m.chooseHypervisor(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "choosing hypervisor")
+	}
+	client, err := m.getClient(hypervisor)
+	if err != nil {
+		return nil, err
+	}
+
+	rootVolume := libvirtRootVolumeName(h.Id)
+	if err = client.CloneVolume(ctx, s.StoragePool, s.BaseImage, rootVolume); err != nil {
+		return nil, errors.Wrapf(err, "cloning base image '%s' for host '%s'", s.BaseImage, h.Id)
+	}
+
+	spec := libvirtDomainSpec{
+		Name:       h.Id,
+		NumCPUs:    s.NumCPUs,
+		MemoryMB:   s.MemoryMB,
+		Network:    s.Network,
+		RootPool:   s.StoragePool,
+		RootVolume: rootVolume,
+	}
+	if err = client.DefineDomain(ctx, spec); err != nil {
+		grip.Error(message.WrapError(client.DeleteVolume(ctx, s.StoragePool, rootVolume), message.Fields{
+			"message":    "could not clean up root volume after failing to define domain",
+			"host_id":    h.Id,
+			"hypervisor": hypervisor,
+		}))
+		return nil, errors.Wrapf(err, "defining domain for host '%s'", h.Id)
+	}
+	if err = client.StartDomain(ctx, h.Id); err != nil {
+		catcher := grip.NewBasicCatcher()
+		catcher.Add(client.UndefineDomain(ctx, h.Id))
+		catcher.Add(client.DeleteVolume(ctx, s.StoragePool, rootVolume))
+		grip.Error(message.WrapError(catcher.Resolve(), message.Fields{
+			"message":    "could not clean up domain after failing to start it",
+			"host_id":    h.Id,
+			"hypervisor": hypervisor,
+		}))
+		return nil, errors.Wrapf(err, "starting domain for host '%s'", h.Id)
+	}
+
+	h.Zone = hypervisor
+
+	grip.Debug(message.Fields{
+		"message":    "spawned new libvirt instance",
+		"host_id":    h.Id,
+		"distro":     h.Distro.Id,
+		"hypervisor": hypervisor,
+	})
+
+	return h, nil
+}
+
+func (m *libvirtManager) ModifyHost(context.Context, *host.Host, host.HostModifyOptions) error {
+	return errors.New("can't modify instances with libvirt provider")
+}
+
+// GetInstanceStatus returns the current status of the host's VM.
+func (m *libvirtManager) GetInstanceStatus(ctx context.Context, h *host.Host) (CloudStatus, error) {
+	if h.Zone == "" {
+		// The host was never created on a hypervisor.
+		return StatusNonExistent, nil
+	}
+	client, err := m.getClient(h.Zone)
+	if err != nil {
+		return StatusUnknown, err
+	}
+
+	state, err := client.GetDomainState(ctx, h.Id)
+	if errors.Cause(err) == errLibvirtNotFound {
+		return StatusNonExistent, nil
+	}
+	if err != nil {
+		return StatusUnknown, errors.Wrapf(err, "getting state of domain for host '%s'", h.Id)
+	}
+
+	return libvirtToEvgStatus(state), nil
+}
+
+// GetInstanceStatuses returns the current status of each host's VM, listing
+// the domains on each hypervisor only once.
+func (m *libvirtManager) GetInstanceStatuses(ctx context.Context, hosts []host.Host) (map[string]CloudStatus, error) {
+	hostsByHypervisor := map[string][]host.Host{}
+	for _, h := range hosts {
+		hostsByHypervisor[h.Zone] = append(hostsByHypervisor[h.Zone], h)
+	}
+
+	statuses := map[string]CloudStatus{}
+	for hypervisor, hypervisorHosts := range hostsByHypervisor {
+		if hypervisor == "" {
+			for _, h := range hypervisorHosts {
+				statuses[h.Id] = StatusNonExistent
+			}
+			continue
+		}
+
+		client, err := m.getClient(hypervisor)
+		if err != nil {
+			return nil, err
+		}
+		domains, err := client.ListDomains(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing domains on hypervisor '%s'", hypervisor)
+		}
+
+		for _, h := range hypervisorHosts {
+			state, ok := domains[h.Id]
+			if !ok {
+				statuses[h.Id] = StatusNonExistent
+				continue
+			}
+			statuses[h.Id] = libvirtToEvgStatus(state)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (m *libvirtManager) SetPortMappings(context.Context, *host.Host, *host.Host) error {
+	return errors.New("can't set port mappings with libvirt provider")
+}
+
+// TerminateInstance powers off and deletes the host's VM and its root disk.
+// Attached volumes are detached but not deleted.
+func (m *libvirtManager) TerminateInstance(ctx context.Context, h *host.Host, user, reason string) error {
+	if h.Status == evergreen.HostTerminated {
+		return errors.Errorf("cannot terminate host '%s' because it's already marked as terminated", h.Id)
+	}
+
+	if h.Zone != "" {
+		client, err := m.getClient(h.Zone)
+		if err != nil {
+			return err
+		}
+
+		// Destroying a domain that is not running fails, so only return
+		// errors if the domain could not be removed entirely.
+		grip.Debug(message.WrapError(client.DestroyDomain(ctx, h.Id), message.Fields{
+			"message": "could not power off domain, it may already be stopped",
+			"host_id": h.Id,
+		}))
+		if err = client.UndefineDomain(ctx, h.Id); err != nil && errors.Cause(err) != errLibvirtNotFound {
+			return errors.Wrapf(err, "undefining domain for host '%s'", h.Id)
+		}
+
+		s := &LibvirtSettings{}
+		if err = s.FromDistroSettings(h.Distro, ""); err != nil {
+			return errors.Wrapf(err, "getting provider settings from distro '%s'", h.Distro.Id)
+		}
+		if s.StoragePool == "" {
+			s.StoragePool = defaultLibvirtPool
+		}
+		if err = client.DeleteVolume(ctx, s.StoragePool, libvirtRootVolumeName(h.Id)); err != nil && errors.Cause(err) != errLibvirtNotFound {
+			return errors.Wrapf(err, "deleting root volume for host '%s'", h.Id)
+		}
+	}
+
+	for _, vol := range h.Volumes {
+		grip.Error(message.WrapError(host.UnsetVolumeHost(vol.VolumeID), message.Fields{
+			"host_id":   h.Id,
+			"volume_id": vol.VolumeID,
+			"op":        "terminating host",
+			"message":   "problem un-setting host info on volume records",
+		}))
+	}
+
+	grip.Info(message.Fields{
+		"message":    "terminated libvirt instance",
+		"user":       user,
+		"host_id":    h.Id,
+		"distro":     h.Distro.Id,
+		"hypervisor": h.Zone,
+	})
+
+	return errors.Wrap(h.Terminate(user, reason), "terminating host in DB")
+}
+
+// StopInstance shuts down the host's VM and waits for it to stop.
+func (m *libvirtManager) StopInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status == evergreen.HostStopped {
+		return errors.Errorf("cannot stop host '%s' because it is already marked as stopped", h.Id)
+	} else if h.Status != evergreen.HostRunning && h.Status != evergreen.HostStopping {
+		return errors.Errorf("cannot stop host '%s' because its status ('%s') is not a stoppable state", h.Id, h.Status)
+	}
+
+	client, err := m.getClient(h.Zone)
+	if err != nil {
+		return err
+	}
+	if err = client.ShutdownDomain(ctx, h.Id); err != nil {
+		return errors.Wrapf(err, "shutting down domain for host '%s'", h.Id)
+	}
+	grip.Error(message.WrapError(h.SetStopping(user), message.Fields{
+		"message": "could not mark host as stopping, continuing to poll instance status anyways",
+		"host_id": h.Id,
+		"user":    user,
+	}))
+
+	if err = m.waitForStatus(ctx, client, h, StatusStopped); err != nil {
+		return errors.Wrap(err, "checking if host stopped")
+	}
+
+	grip.Info(message.Fields{
+		"message":    "stopped libvirt instance",
+		"user":       user,
+		"host_id":    h.Id,
+		"distro":     h.Distro.Id,
+		"hypervisor": h.Zone,
+	})
+
+	return errors.Wrap(h.SetStopped(user), "marking DB host as stopped")
+}
+
+// StartInstance boots the host's stopped VM and waits for it to run.
+func (m *libvirtManager) StartInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status != evergreen.HostStopped {
+		return errors.Errorf("cannot start host '%s' because its status is '%s'", h.Id, h.Status)
+	}
+
+	client, err := m.getClient(h.Zone)
+	if err != nil {
+		return err
+	}
+	if err = client.StartDomain(ctx, h.Id); err != nil {
+		return errors.Wrapf(err, "starting domain for host '%s'", h.Id)
+	}
+
+	if err = m.waitForStatus(ctx, client, h, StatusRunning); err != nil {
+		return errors.Wrap(err, "checking if host started")
+	}
+
+	// The VM may have been leased a new address when it restarted.
+	ip, err := client.GetDomainIP(ctx, h.Id)
+	grip.Warning(message.WrapError(err, message.Fields{
+		"message": "could not get IP address of started host",
+		"host_id": h.Id,
+	}))
+	if err == nil && ip != h.Host {
+		grip.Error(message.WrapError(h.SetDNSName(ip), message.Fields{
+			"message": "could not update DNS name of started host",
+			"host_id": h.Id,
+		}))
+	}
+
+	grip.Info(message.Fields{
+		"message":    "started libvirt instance",
+		"user":       user,
+		"host_id":    h.Id,
+		"distro":     h.Distro.Id,
+		"hypervisor": h.Zone,
+	})
+
+	return errors.Wrap(h.SetRunning(user), "marking DB host as running")
+}
+
+// waitForStatus polls the VM until it reaches the expected status.
+func (m *libvirtManager) waitForStatus(ctx context.Context, client libvirtClient, h *host.Host, expected CloudStatus) error {
+	return utility.Retry(
+		ctx,
+		func() (bool, error) {
+			state, err := client.GetDomainState(ctx, h.Id)
+			if err != nil {
+				return false, errors.Wrap(err, "getting domain state")
+			}
+			if status := libvirtToEvgStatus(state); status != expected {
+				return true, errors.Errorf("host status is '%s', not '%s'", status, expected)
+			}
+			return false, nil
+		}, utility.RetryOptions{
+			MaxAttempts: checkSuccessAttempts,
+			MinDelay:    checkSuccessInitPeriod,
+			MaxDelay:    checkSuccessMaxDelay,
+		})
+}
+
+// AttachVolume attaches a volume stored on the host's hypervisor to the host.
+func (m *libvirtManager) AttachVolume(ctx context.Context, h *host.Host, attachment *host.VolumeAttachment) error {
+	v, err := host.FindVolumeByID(attachment.VolumeID)
+	if err != nil {
+		return errors.Wrapf(err, "getting volume '%s'", attachment.VolumeID)
+	}
+	if v == nil {
+		return errors.Errorf("volume '%s' not found", attachment.VolumeID)
+	}
+	if v.AvailabilityZone != h.Zone {
+		return errors.Errorf("volume '%s' is on hypervisor '%s' but host '%s' is on hypervisor '%s'", v.ID, v.AvailabilityZone, h.Id, h.Zone)
+	}
+
+	if attachment.DeviceName == "" {
+		attachment.DeviceName, err = nextLibvirtDeviceName(h.HostVolumeDeviceNames())
+		if err != nil {
+			return errors.Wrap(err, "generating device name")
+		}
+	}
+
+	client, err := m.getClient(h.Zone)
+	if err != nil {
+		return err
+	}
+	if err = client.AttachDisk(ctx, h.Id, m.getVolumePool(h.Zone), libvirtVolumeName(v.ID), attachment.DeviceName); err != nil {
+		return errors.Wrapf(err, "attaching volume '%s' to host '%s'", v.ID, h.Id)
+	}
+
+	return errors.Wrapf(h.AddVolumeToHost(attachment), "attaching volume '%s' to host '%s' in DB", v.ID, h.Id)
+}
+
+// nextLibvirtDeviceName returns the first virtio device name that is not
+// already in use. The root disk is always vda.
+func nextLibvirtDeviceName(existing []string) (string, error) {
+	for letter := 'b'; letter <= 'z'; letter++ {
+		name := fmt.Sprintf("vd%c", letter)
+		if !utility.StringSliceContains(existing, name) {
+			return name, nil
+		}
+	}
+	return "", errors.New("no device names are available")
+}
+
+// DetachVolume detaches the volume from the host.
+func (m *libvirtManager) DetachVolume(ctx context.Context, h *host.Host, volumeID string) error {
+	v, err := host.FindVolumeByID(volumeID)
+	if err != nil {
+		return errors.Wrapf(err, "getting volume '%s'", volumeID)
+	}
+	if v == nil {
+		return errors.Errorf("volume '%s' not found", volumeID)
+	}
+
+	deviceName := ""
+	for _, attachment := range h.Volumes {
+		if attachment.VolumeID == volumeID {
+			deviceName = attachment.DeviceName
+			break
+		}
+	}
+	if deviceName == "" {
+		return errors.Errorf("volume '%s' is not attached to host '%s'", volumeID, h.Id)
+	}
+
+	client, err := m.getClient(h.Zone)
+	if err != nil {
+		return err
+	}
+	if err = client.DetachDisk(ctx, h.Id, deviceName); err != nil {
+		return errors.Wrapf(err, "detaching volume '%s' from host '%s'", volumeID, h.Id)
+	}
+
+	if v.Expiration.Before(time.Now().Add(evergreen.DefaultSpawnHostExpiration)) {
+		if err = v.SetExpiration(time.Now().Add(evergreen.DefaultSpawnHostExpiration)); err != nil {
+			return errors.Wrapf(err, "updating expiration for volume '%s'", volumeID)
+		}
+	}
+
+	return errors.Wrapf(h.RemoveVolumeFromHost(volumeID), "detaching volume '%s' from host '%s' in DB", volumeID, h.Id)
+}
+
+// CreateVolume creates a new qcow2 volume on the hypervisor named by the
+// volume's availability zone. If there is only one hypervisor, the
+// availability zone may be omitted.
+func (m *libvirtManager) CreateVolume(ctx context.Context, volume *host.Volume) (*host.Volume, error) {
+	if volume.AvailabilityZone == "" && len(m.hypervisors) == 1 {
+		for name := range m.hypervisors {
+			volume.AvailabilityZone = name
+		}
+	}
+	if volume.Size <= 0 {
+		return nil, errors.New("volume size must be positive")
+	}
+
+	client, err := m.getClient(volume.AvailabilityZone)
+	if err != nil {
+		return nil, err
+	}
+
+	volume.ID = fmt.Sprintf("vol-%s", utility.RandomString())
+	if err = client.CreateVolume(ctx, m.getVolumePool(volume.AvailabilityZone), libvirtVolumeName(volume.ID), volume.Size); err != nil {
+		return nil, errors.Wrap(err, "creating volume in client")
+	}
+
+	volume.Expiration = time.Now().Add(evergreen.DefaultSpawnHostExpiration)
+	if err = volume.Insert(); err != nil {
+		return nil, errors.Wrap(err, "creating volume in DB")
+	}
+
+	return volume, nil
+}
+
+// DeleteVolume deletes the volume from its hypervisor.
+func (m *libvirtManager) DeleteVolume(ctx context.Context, volume *host.Volume) error {
+	client, err := m.getClient(volume.AvailabilityZone)
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteVolume(ctx, m.getVolumePool(volume.AvailabilityZone), libvirtVolumeName(volume.ID))
+	if err != nil && errors.Cause(err) != errLibvirtNotFound {
+		return errors.Wrapf(err, "deleting volume '%s' in client", volume.ID)
+	}
+
+	return errors.Wrapf(volume.Remove(), "deleting volume '%s' in DB", volume.ID)
+}
+
+// ModifyVolume modifies the volume's expiration, name, or size.
+func (m *libvirtManager) ModifyVolume(ctx context.Context, volume *host.Volume, opts *model.VolumeModifyOptions) error {
+	if opts.NoExpiration && opts.HasExpiration {
+		return errors.New("can't set both no expiration and has expiration")
+	}
+
+	if !utility.IsZeroTime(opts.Expiration) {
+		if err := volume.SetExpiration(opts.Expiration); err != nil {
+			return errors.Wrapf(err, "updating volume '%s' expiration in DB", volume.ID)
+		}
+		if err := volume.SetNoExpiration(false); err != nil {
+			return errors.Wrapf(err, "clearing volume '%s' no-expiration in DB", volume.ID)
+		}
+	}
+	if opts.NoExpiration {
+		if err := volume.SetExpiration(time.Now().Add(evergreen.SpawnHostNoExpirationDuration)); err != nil {
+			return errors.Wrapf(err, "updating volume '%s' background expiration in DB", volume.ID)
+		}
+		if err := volume.SetNoExpiration(true); err != nil {
+			return errors.Wrapf(err, "setting volume '%s' no-expiration in DB", volume.ID)
+		}
+	}
+	if opts.HasExpiration {
+		if err := volume.SetNoExpiration(false); err != nil {
+			return errors.Wrapf(err, "clearing volume '%s' no-expiration in DB", volume.ID)
+		}
+	}
+
+	if opts.Size > 0 {
+		if opts.Size < volume.Size {
+			return errors.Errorf("cannot shrink volume '%s' from %d GB to %d GB", volume.ID, volume.Size, opts.Size)
+		}
+		client, err := m.getClient(volume.AvailabilityZone)
+		if err != nil {
+			return err
+		}
+		if err = client.ResizeVolume(ctx, m.getVolumePool(volume.AvailabilityZone), libvirtVolumeName(volume.ID), opts.Size); err != nil {
+			return errors.Wrapf(err, "modifying volume '%s' size in client", volume.ID)
+		}
+		if err = volume.SetSize(opts.Size); err != nil {
+			return errors.Wrapf(err, "modifying volume '%s' size in DB", volume.ID)
+		}
+	}
+
+	if opts.NewName != "" {
+		if err := volume.SetDisplayName(opts.NewName); err != nil {
+			return errors.Wrapf(err, "modifying volume '%s' name in DB", volume.ID)
+		}
+	}
+
+	return nil
+}
+
+// GetVolumeAttachment returns the host to which the volume is attached, if
+// any.
+func (m *libvirtManager) GetVolumeAttachment(ctx context.Context, volumeID string) (*VolumeAttachment, error) {
+	v, err := host.FindVolumeByID(volumeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting volume '%s'", volumeID)
+	}
+	if v == nil {
+		return nil, errors.Errorf("volume '%s' not found", volumeID)
+	}
+	if v.Host == "" {
+		return nil, nil
+	}
+
+	h, err := host.FindOneId(v.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting host '%s' for volume '%s'", v.Host, volumeID)
+	}
+	if h == nil {
+		return nil, nil
+	}
+	for _, attachment := range h.Volumes {
+		if attachment.VolumeID == volumeID {
+			return &VolumeAttachment{
+				VolumeID:   volumeID,
+				HostID:     h.Id,
+				DeviceName: attachment.DeviceName,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *libvirtManager) CreateSnapshot(context.Context, *host.Host, *host.Snapshot) error {
+	return errors.New("can't create snapshot with libvirt provider")
+}
+
+func (m *libvirtManager) DeleteSnapshot(context.Context, *host.Snapshot) error {
+	return errors.New("can't delete snapshot with libvirt provider")
+}
+
+func (m *libvirtManager) CheckInstanceType(context.Context, string) error {
+	return errors.New("can't specify instance type with libvirt provider")
+}
+
+// Cleanup is a noop for the libvirt provider.
+func (m *libvirtManager) Cleanup(context.Context) error {
+	return nil
+}
+
+// GetDNSName returns the IPv4 address of the host's VM.
+func (m *libvirtManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
+	client, err := m.getClient(h.Zone)
+	if err != nil {
+		return "", err
+	}
+
+	ip, err := client.GetDomainIP(ctx, h.Id)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting IP for host '%s'", h.Id)
+	}
+
+	return ip, nil
+}
+
+// TimeTilNextPayment returns 0 because on-prem hypervisors are not billed.
+func (m *libvirtManager) TimeTilNextPayment(*host.Host) time.Duration {
+	return time.Duration(0)
+}
+
+// AddSSHKey is a noop for the libvirt provider.
+func (m *libvirtManager) AddSSHKey(context.Context, evergreen.SSHKeyPair) error {
+	return nil
+}
+
+// libvirtToEvgStatus converts a libvirt domain state to a cloud status.
+func libvirtToEvgStatus(state string) CloudStatus {
+	switch state {
+	case libvirtStateRunning, libvirtStateIdle, libvirtStateBlocked:
+		return StatusRunning
+	case libvirtStateInShutdown:
+		return StatusStopping
+	case libvirtStateShutOff, libvirtStatePaused, libvirtStatePMSuspended:
+		return StatusStopped
+	case libvirtStateCrashed:
+		return StatusFailed
+	default:
+		return StatusUnknown
+	}
+}
@@ -0,0 +1,329 @@
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Domain states reported by libvirt.
+const (
+	libvirtStateRunning     = "running"
+	libvirtStateIdle        = "idle"
+	libvirtStateBlocked     = "blocked"
+	libvirtStatePaused      = "paused"
+	libvirtStateInShutdown  = "in shutdown"
+	libvirtStateShutOff     = "shut off"
+	libvirtStateCrashed     = "crashed"
+	libvirtStatePMSuspended = "pmsuspended"
+)
+
+// errLibvirtNotFound indicates that the requested domain or volume does not
+// exist on the hypervisor.
+var errLibvirtNotFound = errors.New("not found")
+
+// libvirtDomainSpec describes a VM to define on a hypervisor.
+type libvirtDomainSpec struct {
+	Name     string
+	NumCPUs  int
+	MemoryMB int
+	Network  string
+	// RootPool and RootVolume are the storage pool and volume of the VM's
+	// boot disk.
+	RootPool   string
+	RootVolume string
+}
+
+// libvirtClient wraps the operations on a single libvirt hypervisor
+// connection.
+type libvirtClient interface {
+	// DefineDomain creates a persistent, stopped domain.
+	DefineDomain(context.Context, libvirtDomainSpec) error
+	// UndefineDomain removes a stopped domain's configuration.
+	UndefineDomain(ctx context.Context, name string) error
+	// StartDomain boots a stopped domain.
+	StartDomain(ctx context.Context, name string) error
+	// ShutdownDomain requests that the domain's guest OS shut down.
+	ShutdownDomain(ctx context.Context, name string) error
+	// DestroyDomain immediately powers off the domain.
+	DestroyDomain(ctx context.Context, name string) error
+	// GetDomainState returns the state of a single domain.
+	GetDomainState(ctx context.Context, name string) (string, error)
+	// ListDomains returns a mapping of every domain on the hypervisor to its
+	// state.
+	ListDomains(context.Context) (map[string]string, error)
+	// GetDomainIP returns the IPv4 address of the domain.
+	GetDomainIP(ctx context.Context, name string) (string, error)
+
+	// CreateVolume creates an empty qcow2 volume in the storage pool.
+	CreateVolume(ctx context.Context, pool, name string, sizeGB int32) error
+	// CloneVolume creates a new volume by copying an existing one.
+	CloneVolume(ctx context.Context, pool, source, name string) error
+	// ResizeVolume grows a volume to the given size.
+	ResizeVolume(ctx context.Context, pool, name string, sizeGB int32) error
+	// DeleteVolume deletes a volume from the storage pool.
+	DeleteVolume(ctx context.Context, pool, name string) error
+	// AttachDisk attaches a volume to the domain as the given target device.
+	AttachDisk(ctx context.Context, domain, pool, volume, target string) error
+	// DetachDisk detaches the target device from the domain.
+	DetachDisk(ctx context.Context, domain, target string) error
+}
+
+// libvirtClientImpl implements libvirtClient by running virsh against the
+// hypervisor's connection URI.
+type libvirtClientImpl struct {
+	uri string
+}
+
+func newLibvirtClient(uri string) libvirtClient {
+	return &libvirtClientImpl{uri: uri}
+}
+
+// virsh runs a virsh command against the hypervisor and returns its output.
+func (c *libvirtClientImpl) virsh(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "virsh", append([]string{"--connect", c.uri, "--quiet"}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if isLibvirtNotFoundMessage(msg) {
+			return "", errors.Wrapf(errLibvirtNotFound, "running virsh %s: %s", args[0], msg)
+		}
+		return "", errors.Wrapf(err, "running virsh %s: %s", args[0], msg)
+	}
+
+	return stdout.String(), nil
+}
+
+func isLibvirtNotFoundMessage(msg string) bool {
+	return strings.Contains(msg, "Domain not found") ||
+		strings.Contains(msg, "failed to get domain") ||
+		strings.Contains(msg, "Storage volume not found") ||
+		strings.Contains(msg, "failed to get vol")
+}
+
+func (c *libvirtClientImpl) DefineDomain(ctx context.Context, spec libvirtDomainSpec) error {
+	rootPath, err := c.volumePath(ctx, spec.RootPool, spec.RootVolume)
+	if err != nil {
+		return errors.Wrap(err, "getting root volume path")
+	}
+	domainXML, err := spec.toXML(rootPath)
+	if err != nil {
+		return errors.Wrap(err, "generating domain XML")
+	}
+
+	// virsh only reads domain definitions from files.
+	f, err := os.CreateTemp("", "evergreen-libvirt-domain-*.xml")
+	if err != nil {
+		return errors.Wrap(err, "creating domain definition file")
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(domainXML); err != nil {
+		f.Close()
+		return errors.Wrap(err, "writing domain definition file")
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrap(err, "closing domain definition file")
+	}
+
+	_, err = c.virsh(ctx, "define", f.Name())
+	return err
+}
+
+func (c *libvirtClientImpl) UndefineDomain(ctx context.Context, name string) error {
+	_, err := c.virsh(ctx, "undefine", name)
+	return err
+}
+
+func (c *libvirtClientImpl) StartDomain(ctx context.Context, name string) error {
+	_, err := c.virsh(ctx, "start", name)
+	return err
+}
+
+func (c *libvirtClientImpl) ShutdownDomain(ctx context.Context, name string) error {
+	_, err := c.virsh(ctx, "shutdown", name)
+	return err
+}
+
+func (c *libvirtClientImpl) DestroyDomain(ctx context.Context, name string) error {
+	_, err := c.virsh(ctx, "destroy", name)
+	return err
+}
+
+func (c *libvirtClientImpl) GetDomainState(ctx context.Context, name string) (string, error) {
+	out, err := c.virsh(ctx, "domstate", name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (c *libvirtClientImpl) ListDomains(ctx context.Context) (map[string]string, error) {
+	out, err := c.virsh(ctx, "list", "--all")
+	if err != nil {
+		return nil, err
+	}
+	return parseLibvirtDomainList(out), nil
+}
+
+// parseLibvirtDomainList parses the output of "virsh list --all", which has
+// one line per domain of the form "<id or -> <name> <state>".
+func parseLibvirtDomainList(out string) map[string]string {
+	domains := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || strings.HasPrefix(fields[0], "---") || fields[0] == "Id" {
+			continue
+		}
+		domains[fields[1]] = strings.Join(fields[2:], " ")
+	}
+	return domains
+}
+
+func (c *libvirtClientImpl) GetDomainIP(ctx context.Context, name string) (string, error) {
+	out, err := c.virsh(ctx, "domifaddr", name)
+	if err != nil {
+		return "", err
+	}
+	ip := parseLibvirtDomainIP(out)
+	if ip == "" {
+		return "", errors.Errorf("domain '%s' has no IPv4 address", name)
+	}
+	return ip, nil
+}
+
+// parseLibvirtDomainIP returns the first IPv4 address from the output of
+// "virsh domifaddr", which has one line per address of the form
+// "<interface> <MAC> <protocol> <address>/<prefix>".
+func parseLibvirtDomainIP(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[2] != "ipv4" {
+			continue
+		}
+		return strings.Split(fields[3], "/")[0]
+	}
+	return ""
+}
+
+func (c *libvirtClientImpl) CreateVolume(ctx context.Context, pool, name string, sizeGB int32) error {
+	_, err := c.virsh(ctx, "vol-create-as", pool, name, fmt.Sprintf("%dG", sizeGB), "--format", "qcow2")
+	return err
+}
+
+func (c *libvirtClientImpl) CloneVolume(ctx context.Context, pool, source, name string) error {
+	_, err := c.virsh(ctx, "vol-clone", "--pool", pool, source, name)
+	return err
+}
+
+func (c *libvirtClientImpl) ResizeVolume(ctx context.Context, pool, name string, sizeGB int32) error {
+	_, err := c.virsh(ctx, "vol-resize", "--pool", pool, name, fmt.Sprintf("%dG", sizeGB))
+	return err
+}
+
+func (c *libvirtClientImpl) DeleteVolume(ctx context.Context, pool, name string) error {
+	_, err := c.virsh(ctx, "vol-delete", "--pool", pool, name)
+	return err
+}
+
+func (c *libvirtClientImpl) AttachDisk(ctx context.Context, domain, pool, volume, target string) error {
+	path, err := c.volumePath(ctx, pool, volume)
+	if err != nil {
+		return errors.Wrap(err, "getting volume path")
+	}
+	_, err = c.virsh(ctx, "attach-disk", domain, path, target, "--driver", "qemu", "--subdriver", "qcow2", "--persistent")
+	return err
+}
+
+func (c *libvirtClientImpl) DetachDisk(ctx context.Context, domain, target string) error {
+	_, err := c.virsh(ctx, "detach-disk", domain, target, "--persistent")
+	return err
+}
+
+// volumePath returns the path of the volume on the hypervisor.
+func (c *libvirtClientImpl) volumePath(ctx context.Context, pool, name string) (string, error) {
+	out, err := c.virsh(ctx, "vol-path", "--pool", pool, name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// libvirtDomainXML is the subset of the libvirt domain XML format needed to
+// define a VM.
+type libvirtDomainXML struct {
+	XMLName xml.Name `xml:"domain"`
+	Type    string   `xml:"type,attr"`
+	Name    string   `xml:"name"`
+	Memory  struct {
+		Unit  string `xml:"unit,attr"`
+		Value int    `xml:",chardata"`
+	} `xml:"memory"`
+	VCPU int `xml:"vcpu"`
+	OS   struct {
+		Type struct {
+			Arch  string `xml:"arch,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"type"`
+		Boot struct {
+			Dev string `xml:"dev,attr"`
+		} `xml:"boot"`
+	} `xml:"os"`
+	Devices struct {
+		Disk struct {
+			Type   string `xml:"type,attr"`
+			Device string `xml:"device,attr"`
+			Driver struct {
+				Name string `xml:"name,attr"`
+				Type string `xml:"type,attr"`
+			} `xml:"driver"`
+			Source struct {
+				File string `xml:"file,attr"`
+			} `xml:"source"`
+			Target struct {
+				Dev string `xml:"dev,attr"`
+				Bus string `xml:"bus,attr"`
+			} `xml:"target"`
+		} `xml:"disk"`
+		Interface struct {
+			Type   string `xml:"type,attr"`
+			Source struct {
+				Network string `xml:"network,attr"`
+			} `xml:"source"`
+			Model struct {
+				Type string `xml:"type,attr"`
+			} `xml:"model"`
+		} `xml:"interface"`
+	} `xml:"devices"`
+}
+
+// toXML generates the libvirt domain XML for a KVM guest booting from the
+// root volume at the given path.
+func (spec libvirtDomainSpec) toXML(rootPath string) ([]byte, error) {
+	d := libvirtDomainXML{Type: "kvm", Name: spec.Name, VCPU: spec.NumCPUs}
+	d.Memory.Unit = "MiB"
+	d.Memory.Value = spec.MemoryMB
+	d.OS.Type.Arch = "x86_64"
+	d.OS.Type.Value = "hvm"
+	d.OS.Boot.Dev = "hd"
+	d.Devices.Disk.Type = "file"
+	d.Devices.Disk.Device = "disk"
+	d.Devices.Disk.Driver.Name = "qemu"
+	d.Devices.Disk.Driver.Type = "qcow2"
+	d.Devices.Disk.Source.File = rootPath
+	d.Devices.Disk.Target.Dev = "vda"
+	d.Devices.Disk.Target.Bus = "virtio"
+	d.Devices.Interface.Type = "network"
+	d.Devices.Interface.Source.Network = spec.Network
+	d.Devices.Interface.Model.Type = "virtio"
+
+	return xml.MarshalIndent(d, "", "  ")
+}
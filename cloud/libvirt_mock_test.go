@@ -0,0 +1,168 @@
+package cloud
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// libvirtClientMock is an in-memory fake of a single libvirt hypervisor.
+type libvirtClientMock struct {
+	// API call options
+	failList   bool
+	failDefine bool
+	failStart  bool
+
+	// domains maps each domain name to its state.
+	domains map[string]string
+	// volumes maps each pool to the sizes of the volumes in it.
+	volumes map[string]map[string]int32
+	// disks maps each domain to its attached volumes, keyed by target.
+	disks map[string]map[string]string
+	ip    string
+}
+
+func newLibvirtClientMock() *libvirtClientMock {
+	return &libvirtClientMock{
+		domains: map[string]string{},
+		volumes: map[string]map[string]int32{},
+		disks:   map[string]map[string]string{},
+		ip:      "10.0.0.1",
+	}
+}
+
+func (c *libvirtClientMock) DefineDomain(_ context.Context, spec libvirtDomainSpec) error {
+	if c.failDefine {
+		return errors.New("failed to define domain")
+	}
+	if _, ok := c.volumes[spec.RootPool][spec.RootVolume]; !ok {
+		return errors.Wrap(errLibvirtNotFound, "root volume")
+	}
+	c.domains[spec.Name] = libvirtStateShutOff
+	return nil
+}
+
+func (c *libvirtClientMock) UndefineDomain(_ context.Context, name string) error {
+	if _, ok := c.domains[name]; !ok {
+		return errLibvirtNotFound
+	}
+	delete(c.domains, name)
+	return nil
+}
+
+func (c *libvirtClientMock) StartDomain(_ context.Context, name string) error {
+	if c.failStart {
+		return errors.New("failed to start domain")
+	}
+	if _, ok := c.domains[name]; !ok {
+		return errLibvirtNotFound
+	}
+	c.domains[name] = libvirtStateRunning
+	return nil
+}
+
+func (c *libvirtClientMock) ShutdownDomain(_ context.Context, name string) error {
+	if _, ok := c.domains[name]; !ok {
+		return errLibvirtNotFound
+	}
+	c.domains[name] = libvirtStateShutOff
+	return nil
+}
+
+func (c *libvirtClientMock) DestroyDomain(_ context.Context, name string) error {
+	state, ok := c.domains[name]
+	if !ok {
+		return errLibvirtNotFound
+	}
+	if state == libvirtStateShutOff {
+		return errors.New("domain is not running")
+	}
+	c.domains[name] = libvirtStateShutOff
+	return nil
+}
+
+func (c *libvirtClientMock) GetDomainState(_ context.Context, name string) (string, error) {
+	state, ok := c.domains[name]
+	if !ok {
+		return "", errLibvirtNotFound
+	}
+	return state, nil
+}
+
+func (c *libvirtClientMock) ListDomains(context.Context) (map[string]string, error) {
+	if c.failList {
+		return nil, errors.New("failed to list domains")
+	}
+	domains := map[string]string{}
+	for name, state := range c.domains {
+		domains[name] = state
+	}
+	return domains, nil
+}
+
+func (c *libvirtClientMock) GetDomainIP(_ context.Context, name string) (string, error) {
+	if _, ok := c.domains[name]; !ok {
+		return "", errLibvirtNotFound
+	}
+	return c.ip, nil
+}
+
+func (c *libvirtClientMock) CreateVolume(_ context.Context, pool, name string, sizeGB int32) error {
+	if c.volumes[pool] == nil {
+		c.volumes[pool] = map[string]int32{}
+	}
+	if _, ok := c.volumes[pool][name]; ok {
+		return errors.New("volume already exists")
+	}
+	c.volumes[pool][name] = sizeGB
+	return nil
+}
+
+func (c *libvirtClientMock) CloneVolume(ctx context.Context, pool, source, name string) error {
+	size, ok := c.volumes[pool][source]
+	if !ok {
+		return errLibvirtNotFound
+	}
+	return c.CreateVolume(ctx, pool, name, size)
+}
+
+func (c *libvirtClientMock) ResizeVolume(_ context.Context, pool, name string, sizeGB int32) error {
+	if _, ok := c.volumes[pool][name]; !ok {
+		return errLibvirtNotFound
+	}
+	c.volumes[pool][name] = sizeGB
+	return nil
+}
+
+func (c *libvirtClientMock) DeleteVolume(_ context.Context, pool, name string) error {
+	if _, ok := c.volumes[pool][name]; !ok {
+		return errLibvirtNotFound
+	}
+	delete(c.volumes[pool], name)
+	return nil
+}
+
+func (c *libvirtClientMock) AttachDisk(_ context.Context, domain, pool, volume, target string) error {
+	if _, ok := c.domains[domain]; !ok {
+		return errLibvirtNotFound
+	}
+	if _, ok := c.volumes[pool][volume]; !ok {
+		return errLibvirtNotFound
+	}
+	if c.disks[domain] == nil {
+		c.disks[domain] = map[string]string{}
+	}
+	if _, ok := c.disks[domain][target]; ok {
+		return errors.Errorf("target '%s' is already in use", target)
+	}
+	c.disks[domain][target] = volume
+	return nil
+}
+
+func (c *libvirtClientMock) DetachDisk(_ context.Context, domain, target string) error {
+	if _, ok := c.disks[domain][target]; !ok {
+		return errLibvirtNotFound
+	}
+	delete(c.disks[domain], target)
+	return nil
+}
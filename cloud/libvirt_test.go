@@ -0,0 +1,378 @@
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/birch"
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LibvirtSuite struct {
+	clients  map[string]*libvirtClientMock
+	manager  *libvirtManager
+	hostOpts host.CreateOptions
+	suite.Suite
+}
+
+func TestLibvirtSuite(t *testing.T) {
+	suite.Run(t, new(LibvirtSuite))
+}
+
+func (s *LibvirtSuite) SetupTest() {
+	s.Require().NoError(db.ClearCollections(host.Collection, host.VolumesCollection))
+
+	s.clients = map[string]*libvirtClientMock{
+		"qemu+ssh://hv1/system": newLibvirtClientMock(),
+		"qemu+ssh://hv2/system": newLibvirtClientMock(),
+	}
+	for _, client := range s.clients {
+		client.volumes[defaultLibvirtPool] = map[string]int32{"ubuntu2204.qcow2": 20}
+	}
+	s.manager = &libvirtManager{
+		newClient: func(uri string) libvirtClient {
+			return s.clients[uri]
+		},
+	}
+	settings := &evergreen.Settings{}
+	settings.Providers.Libvirt.Hypervisors = []evergreen.LibvirtHypervisor{
+		{Name: "hv1", URI: "qemu+ssh://hv1/system"},
+		{Name: "hv2", URI: "qemu+ssh://hv2/system", VolumePool: "volumes"},
+	}
+	s.Require().NoError(s.manager.Configure(context.Background(), settings))
+
+	s.hostOpts = host.CreateOptions{
+		Distro: distro.Distro{
+			Id:                   "distro",
+			Provider:             evergreen.ProviderNameLibvirt,
+			ProviderSettingsList: []*birch.Document{birch.NewDocument(birch.EC.String("base_image", "ubuntu2204.qcow2"))},
+		},
+	}
+}
+
+func (s *LibvirtSuite) TearDownTest() {
+	s.NoError(db.ClearCollections(host.Collection, host.VolumesCollection))
+}
+
+func (s *LibvirtSuite) hv1() *libvirtClientMock {
+	return s.clients["qemu+ssh://hv1/system"]
+}
+
+func (s *LibvirtSuite) hv2() *libvirtClientMock {
+	return s.clients["qemu+ssh://hv2/system"]
+}
+
+func (s *LibvirtSuite) TestValidateSettings() {
+	settings := &LibvirtSettings{BaseImage: "ubuntu2204.qcow2"}
+	s.NoError(settings.Validate())
+	s.Equal(defaultLibvirtPool, settings.StoragePool)
+	s.Equal(defaultLibvirtNetwork, settings.Network)
+	s.Equal(defaultLibvirtNumCPUs, settings.NumCPUs)
+	s.Equal(defaultLibvirtMemoryMB, settings.MemoryMB)
+
+	s.Error((&LibvirtSettings{}).Validate())
+	s.Error((&LibvirtSettings{BaseImage: "ubuntu2204.qcow2", NumCPUs: -1}).Validate())
+	s.Error((&LibvirtSettings{BaseImage: "ubuntu2204.qcow2", MemoryMB: -1}).Validate())
+}
+
+func (s *LibvirtSuite) TestConfigure() {
+	s.Len(s.manager.hypervisors, 2)
+	s.Equal(defaultLibvirtPool, s.manager.getVolumePool("hv1"))
+	s.Equal("volumes", s.manager.getVolumePool("hv2"))
+
+	s.Error((&libvirtManager{}).Configure(context.Background(), &evergreen.Settings{}))
+}
+
+func (s *LibvirtSuite) TestSpawnInvalidSettings() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hostOpts.Distro = distro.Distro{Provider: evergreen.ProviderNameEc2Fleet}
+	h, err := s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Error(err)
+	s.Nil(h)
+
+	s.hostOpts.Distro = distro.Distro{Provider: evergreen.ProviderNameLibvirt}
+	h, err = s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Error(err)
+	s.Nil(h)
+
+	s.hostOpts.Distro = distro.Distro{
+		Provider: evergreen.ProviderNameLibvirt,
+		ProviderSettingsList: []*birch.Document{birch.NewDocument(
+			birch.EC.String("base_image", "ubuntu2204.qcow2"),
+			birch.EC.SliceString("hypervisors", []string{"nonexistent"}),
+		)},
+	}
+	h, err = s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Error(err)
+	s.Nil(h)
+}
+
+func (s *LibvirtSuite) TestSpawnChoosesLeastLoadedHypervisor() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hv1().domains["existing"] = libvirtStateRunning
+
+	h, err := s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Require().NoError(err)
+	s.Equal("hv2", h.Zone)
+	s.Equal(libvirtStateRunning, s.hv2().domains[h.Id])
+	s.Contains(s.hv2().volumes[defaultLibvirtPool], libvirtRootVolumeName(h.Id))
+
+	// Stopped domains do not count toward a hypervisor's load.
+	s.hv1().domains["existing"] = libvirtStateShutOff
+	h, err = s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Require().NoError(err)
+	s.Equal("hv1", h.Zone)
+}
+
+func (s *LibvirtSuite) TestSpawnSkipsUnavailableHypervisors() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hv1().failList = true
+	h, err := s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Require().NoError(err)
+	s.Equal("hv2", h.Zone)
+
+	s.hv2().failList = true
+	h, err = s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Error(err)
+	s.Nil(h)
+}
+
+func (s *LibvirtSuite) TestSpawnCleansUpOnFailure() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hostOpts.Distro.ProviderSettingsList[0].Set(birch.EC.SliceString("hypervisors", []string{"hv1"}))
+
+	s.hv1().failDefine = true
+	h := host.NewIntent(s.hostOpts)
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.NotContains(s.hv1().volumes[defaultLibvirtPool], libvirtRootVolumeName(h.Id))
+
+	s.hv1().failDefine = false
+	s.hv1().failStart = true
+	h = host.NewIntent(s.hostOpts)
+	_, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.NotContains(s.hv1().domains, h.Id)
+	s.NotContains(s.hv1().volumes[defaultLibvirtPool], libvirtRootVolumeName(h.Id))
+}
+
+func (s *LibvirtSuite) TestGetInstanceStatus() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h, err := s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Require().NoError(err)
+
+	status, err := s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+
+	s.clients[s.manager.hypervisors[h.Zone].URI].domains[h.Id] = libvirtStateShutOff
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusStopped, status)
+
+	delete(s.clients[s.manager.hypervisors[h.Zone].URI].domains, h.Id)
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusNonExistent, status)
+
+	status, err = s.manager.GetInstanceStatus(ctx, &host.Host{Id: "intent"})
+	s.NoError(err)
+	s.Equal(StatusNonExistent, status)
+}
+
+func (s *LibvirtSuite) TestGetInstanceStatuses() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hv1().domains["h1"] = libvirtStateRunning
+	s.hv1().domains["h2"] = libvirtStateInShutdown
+	s.hv2().domains["h3"] = libvirtStateCrashed
+	hosts := []host.Host{
+		{Id: "h1", Zone: "hv1"},
+		{Id: "h2", Zone: "hv1"},
+		{Id: "h3", Zone: "hv2"},
+		{Id: "h4", Zone: "hv2"},
+		{Id: "h5"},
+	}
+
+	statuses, err := s.manager.GetInstanceStatuses(ctx, hosts)
+	s.Require().NoError(err)
+	s.Equal(map[string]CloudStatus{
+		"h1": StatusRunning,
+		"h2": StatusStopping,
+		"h3": StatusFailed,
+		"h4": StatusNonExistent,
+		"h5": StatusNonExistent,
+	}, statuses)
+
+	s.hv2().failList = true
+	_, err = s.manager.GetInstanceStatuses(ctx, hosts)
+	s.Error(err)
+}
+
+func (s *LibvirtSuite) TestStopAndStartInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h, err := s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Require().NoError(err)
+	h.Status = evergreen.HostRunning
+	s.Require().NoError(h.Insert())
+
+	s.Require().NoError(s.manager.StopInstance(ctx, h, evergreen.User))
+	dbHost, err := host.FindOneId(h.Id)
+	s.Require().NoError(err)
+	s.Equal(evergreen.HostStopped, dbHost.Status)
+
+	s.clients[s.manager.hypervisors[h.Zone].URI].ip = "10.0.0.2"
+	s.Require().NoError(s.manager.StartInstance(ctx, dbHost, evergreen.User))
+	dbHost, err = host.FindOneId(h.Id)
+	s.Require().NoError(err)
+	s.Equal(evergreen.HostRunning, dbHost.Status)
+	s.Equal("10.0.0.2", dbHost.Host)
+
+	s.Error(s.manager.StartInstance(ctx, dbHost, evergreen.User))
+}
+
+func (s *LibvirtSuite) TestTerminateInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h, err := s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Require().NoError(err)
+	s.Require().NoError(h.Insert())
+	client := s.clients[s.manager.hypervisors[h.Zone].URI]
+
+	s.Require().NoError(s.manager.TerminateInstance(ctx, h, evergreen.User, ""))
+	s.NotContains(client.domains, h.Id)
+	s.NotContains(client.volumes[defaultLibvirtPool], libvirtRootVolumeName(h.Id))
+	dbHost, err := host.FindOneId(h.Id)
+	s.Require().NoError(err)
+	s.Equal(evergreen.HostTerminated, dbHost.Status)
+
+	s.Error(s.manager.TerminateInstance(ctx, dbHost, evergreen.User, ""))
+}
+
+func (s *LibvirtSuite) TestVolumes() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hostOpts.Distro.ProviderSettingsList[0].Set(birch.EC.SliceString("hypervisors", []string{"hv2"}))
+	h, err := s.manager.SpawnHost(ctx, host.NewIntent(s.hostOpts))
+	s.Require().NoError(err)
+	s.Require().NoError(h.Insert())
+
+	// The hypervisor must be specified when there is more than one.
+	_, err = s.manager.CreateVolume(ctx, &host.Volume{Size: 10})
+	s.Error(err)
+
+	v, err := s.manager.CreateVolume(ctx, &host.Volume{Size: 10, AvailabilityZone: "hv2"})
+	s.Require().NoError(err)
+	s.Equal(int32(10), s.hv2().volumes["volumes"][libvirtVolumeName(v.ID)])
+
+	attachment := &host.VolumeAttachment{VolumeID: v.ID}
+	s.Require().NoError(s.manager.AttachVolume(ctx, h, attachment))
+	s.Equal("vdb", attachment.DeviceName)
+	s.Equal(libvirtVolumeName(v.ID), s.hv2().disks[h.Id]["vdb"])
+
+	volumeAttachment, err := s.manager.GetVolumeAttachment(ctx, v.ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(volumeAttachment)
+	s.Equal(h.Id, volumeAttachment.HostID)
+	s.Equal("vdb", volumeAttachment.DeviceName)
+
+	s.Require().NoError(s.manager.DetachVolume(ctx, h, v.ID))
+	s.Empty(s.hv2().disks[h.Id])
+
+	// Volumes cannot be attached to hosts on other hypervisors.
+	other, err := s.manager.CreateVolume(ctx, &host.Volume{Size: 10, AvailabilityZone: "hv1"})
+	s.Require().NoError(err)
+	s.Error(s.manager.AttachVolume(ctx, h, &host.VolumeAttachment{VolumeID: other.ID}))
+
+	s.Require().NoError(s.manager.DeleteVolume(ctx, v))
+	s.NotContains(s.hv2().volumes["volumes"], libvirtVolumeName(v.ID))
+	dbVolume, err := host.FindVolumeByID(v.ID)
+	s.NoError(err)
+	s.Nil(dbVolume)
+}
+
+func TestNextLibvirtDeviceName(t *testing.T) {
+	name, err := nextLibvirtDeviceName(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "vdb", name)
+
+	name, err = nextLibvirtDeviceName([]string{"vdb", "vdd"})
+	assert.NoError(t, err)
+	assert.Equal(t, "vdc", name)
+
+	var all []string
+	for letter := 'b'; letter <= 'z'; letter++ {
+		all = append(all, "vd"+string(letter))
+	}
+	_, err = nextLibvirtDeviceName(all)
+	assert.Error(t, err)
+}
+
+func TestLibvirtToEvgStatus(t *testing.T) {
+	assert.Equal(t, StatusRunning, libvirtToEvgStatus(libvirtStateRunning))
+	assert.Equal(t, StatusRunning, libvirtToEvgStatus(libvirtStateIdle))
+	assert.Equal(t, StatusRunning, libvirtToEvgStatus(libvirtStateBlocked))
+	assert.Equal(t, StatusStopping, libvirtToEvgStatus(libvirtStateInShutdown))
+	assert.Equal(t, StatusStopped, libvirtToEvgStatus(libvirtStateShutOff))
+	assert.Equal(t, StatusStopped, libvirtToEvgStatus(libvirtStatePaused))
+	assert.Equal(t, StatusStopped, libvirtToEvgStatus(libvirtStatePMSuspended))
+	assert.Equal(t, StatusFailed, libvirtToEvgStatus(libvirtStateCrashed))
+	assert.Equal(t, StatusUnknown, libvirtToEvgStatus("???"))
+}
+
+func TestParseLibvirtDomainList(t *testing.T) {
+	out := ` 1    host-1   running
+ -    host-2   shut off
+ 3    host-3   in shutdown
+`
+	assert.Equal(t, map[string]string{
+		"host-1": libvirtStateRunning,
+		"host-2": libvirtStateShutOff,
+		"host-3": libvirtStateInShutdown,
+	}, parseLibvirtDomainList(out))
+	assert.Empty(t, parseLibvirtDomainList(""))
+}
+
+func TestParseLibvirtDomainIP(t *testing.T) {
+	out := ` vnet0      52:54:00:aa:bb:cc    ipv6         fe80::1/64
+ vnet0      52:54:00:aa:bb:cc    ipv4         192.168.122.10/24
+`
+	assert.Equal(t, "192.168.122.10", parseLibvirtDomainIP(out))
+	assert.Empty(t, parseLibvirtDomainIP(""))
+}
+
+func TestLibvirtDomainSpecToXML(t *testing.T) {
+	spec := libvirtDomainSpec{
+		Name:     "host",
+		NumCPUs:  4,
+		MemoryMB: 8192,
+		Network:  "default",
+	}
+	out, err := spec.toXML("/var/lib/libvirt/images/host-root.qcow2")
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `<domain type="kvm">`)
+	assert.Contains(t, string(out), `<memory unit="MiB">8192</memory>`)
+	assert.Contains(t, string(out), `<vcpu>4</vcpu>`)
+	assert.Contains(t, string(out), `<source file="/var/lib/libvirt/images/host-root.qcow2"></source>`)
+	assert.Contains(t, string(out), `<source network="default"></source>`)
+}
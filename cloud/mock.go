@@ -137,6 +137,8 @@ func (m *mockState) IterInstances() <-chan MockInstance {
 type mockManager struct {
 	Instances map[string]MockInstance
 	Volumes   map[string]MockVolume
+	// Snapshots maps the ID of each snapshot to the ID of its source host.
+	Snapshots map[string]string
 	mutex     *sync.RWMutex
 }
 
@@ -440,6 +442,33 @@ func (m *mockManager) CheckInstanceType(ctx context.Context, instanceType string
 	return nil
 }
 
+func (m *mockManager) CreateSnapshot(ctx context.Context, h *host.Host, snapshot *host.Snapshot) error {
+	l := m.mutex
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := m.Instances[h.Id]; !ok {
+		return errors.Errorf("unable to fetch host '%s'", h.Id)
+	}
+	if m.Snapshots == nil {
+		m.Snapshots = map[string]string{}
+	}
+	m.Snapshots[snapshot.ID] = h.Id
+
+	homeVolumeSnapshotID := ""
+	if h.HomeVolume() != nil {
+		homeVolumeSnapshotID = "mock-volume-snapshot-" + snapshot.ID
+	}
+	return errors.WithStack(snapshot.SetProviderResources("mock-image-"+snapshot.ID, homeVolumeSnapshotID))
+}
+
+func (m *mockManager) DeleteSnapshot(ctx context.Context, snapshot *host.Snapshot) error {
+	l := m.mutex
+	l.Lock()
+	defer l.Unlock()
+	delete(m.Snapshots, snapshot.ID)
+	return errors.WithStack(snapshot.Remove())
+}
+
 // Cleanup is a noop for the mock provider.
 func (m *mockManager) Cleanup(context.Context) error {
 	return nil
@@ -236,6 +236,14 @@ func (m *openStackManager) GetVolumeAttachment(context.Context, string) (*Volume
 	return nil, errors.New("can't get volume attachment with OpenStack provider")
 }
 
+func (m *openStackManager) CreateSnapshot(context.Context, *host.Host, *host.Snapshot) error {
+	return errors.New("can't create snapshot with OpenStack provider")
+}
+
+func (m *openStackManager) DeleteSnapshot(context.Context, *host.Snapshot) error {
+	return errors.New("can't delete snapshot with OpenStack provider")
+}
+
 func (m *openStackManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with OpenStack provider")
 }
@@ -39,6 +39,7 @@ type SpawnOptions struct {
 	IsCluster             bool
 	HomeVolumeSize        int
 	HomeVolumeID          string
+	SnapshotID            string
 	Expiration            *time.Time
 }
 
@@ -107,6 +108,18 @@ func CreateSpawnHost(ctx context.Context, so SpawnOptions, settings *evergreen.S
 	if d == nil {
 		return nil, errors.Errorf("distro '%s' not found", so.DistroId)
 	}
+	var snapshot *host.Snapshot
+	if so.SnapshotID != "" {
+		snapshot, err = findSnapshotToRestore(so, d)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if so.HomeVolumeID != "" && snapshot.HomeVolumeSnapshotID != "" {
+			return nil, errors.New("cannot attach an existing home volume when restoring a snapshot that has its own home volume")
+		}
+		// The snapshot's resources only exist in the region it was created in.
+		so.Region = snapshot.Region
+	}
 	if so.Region == "" && evergreen.IsEc2Provider(d.Provider) {
 		u := gimlet.GetUser(ctx)
 		dbUser, ok := u.(*user.DBUser)
@@ -153,6 +166,9 @@ func CreateSpawnHost(ctx context.Context, so SpawnOptions, settings *evergreen.S
 	if err != nil {
 		return nil, errors.Wrap(err, "getting new provider settings")
 	}
+	if snapshot != nil && evergreen.IsEc2Provider(d.Provider) {
+		d.ProviderSettingsList[0].Set(birch.EC.String("ami", snapshot.ImageID))
+	}
 
 	if so.InstanceType != "" {
 		if err := CheckInstanceTypeValid(ctx, *d, so.InstanceType, settings.Providers.AWS.AllowedInstanceTypes); err != nil {
@@ -193,6 +209,25 @@ func CreateSpawnHost(ctx context.Context, so SpawnOptions, settings *evergreen.S
 		HomeVolumeID:         so.HomeVolumeID,
 		Region:               so.Region,
 	}
+	if snapshot != nil {
+		hostOptions.IsVirtualWorkstation = hostOptions.IsVirtualWorkstation || snapshot.IsVirtualWorkstation
+		if snapshot.HomeVolumeSnapshotID != "" {
+			hostOptions.HomeVolumeSnapshotID = snapshot.HomeVolumeSnapshotID
+			// The restored home volume can't be smaller than the volume that
+			// was snapshotted.
+			if hostOptions.HomeVolumeSize < snapshot.HomeVolumeSize {
+				hostOptions.HomeVolumeSize = snapshot.HomeVolumeSize
+			}
+		}
+		if snapshot.Provider == evergreen.ProviderNameDocker {
+			hostOptions.ParentID = snapshot.ParentID
+			hostOptions.DockerOptions = host.DockerOptions{
+				Image:          snapshot.ImageID,
+				Method:         distro.DockerImageBuildTypePull,
+				SkipImageBuild: true,
+			}
+		}
+	}
 
 	intentHost := host.NewIntent(hostOptions)
 	if intentHost == nil { // theoretically this should not happen
@@ -201,6 +236,34 @@ func CreateSpawnHost(ctx context.Context, so SpawnOptions, settings *evergreen.S
 	return intentHost, nil
 }
 
+// findSnapshotToRestore finds the snapshot that the spawn host should be
+// restored from and checks that it can be restored with the spawn options.
+func findSnapshotToRestore(so SpawnOptions, d *distro.Distro) (*host.Snapshot, error) {
+	snapshot, err := host.FindSnapshotByID(so.SnapshotID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding snapshot '%s'", so.SnapshotID)
+	}
+	if snapshot == nil || snapshot.CreatedBy != so.UserName {
+		return nil, errors.Errorf("snapshot '%s' not found", so.SnapshotID)
+	}
+	if snapshot.Status != host.SnapshotStatusAvailable {
+		return nil, errors.Errorf("snapshot '%s' cannot be restored because its status is '%s'", snapshot.ID, snapshot.Status)
+	}
+	if snapshot.DistroID != d.Id {
+		return nil, errors.Errorf("snapshot '%s' was created from distro '%s' and cannot be restored with distro '%s'", snapshot.ID, snapshot.DistroID, d.Id)
+	}
+	if snapshot.Provider == evergreen.ProviderNameDocker {
+		parent, err := host.FindOneId(snapshot.ParentID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding parent host '%s' of snapshot '%s'", snapshot.ParentID, snapshot.ID)
+		}
+		if parent == nil || parent.Status != evergreen.HostRunning {
+			return nil, errors.Errorf("parent host '%s' holding snapshot '%s' is not running", snapshot.ParentID, snapshot.ID)
+		}
+	}
+	return snapshot, nil
+}
+
 // assumes distro already modified to have one region
 func CheckInstanceTypeValid(ctx context.Context, d distro.Distro, requestedType string, allowedTypes []string) error {
 	if !utility.StringSliceContains(allowedTypes, requestedType) {
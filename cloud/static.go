@@ -146,6 +146,14 @@ func (m *staticManager) GetVolumeAttachment(context.Context, string) (*VolumeAtt
 	return nil, errors.New("can't get volume attachment with static provider")
 }
 
+func (m *staticManager) CreateSnapshot(context.Context, *host.Host, *host.Snapshot) error {
+	return errors.New("can't create snapshot with static provider")
+}
+
+func (m *staticManager) DeleteSnapshot(context.Context, *host.Snapshot) error {
+	return errors.New("can't delete snapshot with static provider")
+}
+
 func (staticMgr *staticManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with static provider")
 }
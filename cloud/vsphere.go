@@ -196,6 +196,14 @@ func (m *vsphereManager) GetVolumeAttachment(context.Context, string) (*VolumeAt
 	return nil, errors.New("can't get volume attachment with vSphere provider")
 }
 
+func (m *vsphereManager) CreateSnapshot(context.Context, *host.Host, *host.Snapshot) error {
+	return errors.New("can't create snapshot with vSphere provider")
+}
+
+func (m *vsphereManager) DeleteSnapshot(context.Context, *host.Snapshot) error {
+	return errors.New("can't delete snapshot with vSphere provider")
+}
+
 func (m *vsphereManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with vSphere provider")
 }
@@ -56,9 +56,12 @@ func buildApp() *cli.App {
 		operations.Pull(),
 		operations.Evaluate(),
 		operations.Validate(),
+		operations.RunLocal(),
 		operations.List(),
 		operations.LastGreen(),
 		operations.Subscriptions(),
+		operations.Quarantine(),
+		operations.Logs(),
 		operations.CommitQueue(),
 		operations.Scheduler(),
 		operations.Client(),
@@ -13,6 +13,7 @@ var (
 	cloudProvidersAWSKey       = bsonutil.MustHaveTag(CloudProviders{}, "AWS")
 	cloudProvidersDockerKey    = bsonutil.MustHaveTag(CloudProviders{}, "Docker")
 	cloudProvidersGCEKey       = bsonutil.MustHaveTag(CloudProviders{}, "GCE")
+	cloudProvidersLibvirtKey   = bsonutil.MustHaveTag(CloudProviders{}, "Libvirt")
 	cloudProvidersOpenStackKey = bsonutil.MustHaveTag(CloudProviders{}, "OpenStack")
 	cloudProvidersVSphereKey   = bsonutil.MustHaveTag(CloudProviders{}, "VSphere")
 )
@@ -22,6 +23,7 @@ type CloudProviders struct {
 	AWS       AWSConfig       `bson:"aws" json:"aws" yaml:"aws"`
 	Docker    DockerConfig    `bson:"docker" json:"docker" yaml:"docker"`
 	GCE       GCEConfig       `bson:"gce" json:"gce" yaml:"gce"`
+	Libvirt   LibvirtConfig   `bson:"libvirt" json:"libvirt" yaml:"libvirt"`
 	OpenStack OpenStackConfig `bson:"openstack" json:"openstack" yaml:"openstack"`
 	VSphere   VSphereConfig   `bson:"vsphere" json:"vsphere" yaml:"vsphere"`
 }
@@ -60,6 +62,7 @@ func (c *CloudProviders) Set() error {
 			cloudProvidersAWSKey:       c.AWS,
 			cloudProvidersDockerKey:    c.Docker,
 			cloudProvidersGCEKey:       c.GCE,
+			cloudProvidersLibvirtKey:   c.Libvirt,
 			cloudProvidersOpenStackKey: c.OpenStack,
 			cloudProvidersVSphereKey:   c.VSphere,
 		},
@@ -71,6 +74,7 @@ func (c *CloudProviders) Set() error {
 func (c *CloudProviders) ValidateAndDefault() error {
 	catcher := grip.NewBasicCatcher()
 	catcher.Wrap(c.AWS.Pod.Validate(), "invalid ECS config")
+	catcher.Wrap(c.Libvirt.Validate(), "invalid libvirt config")
 	return catcher.Resolve()
 }
 
@@ -372,6 +376,36 @@ type GCEConfig struct {
 	TokenURI     string `bson:"token_uri" json:"token_uri" yaml:"token_uri"`
 }
 
+// LibvirtConfig stores the hypervisors that can run libvirt VMs.
+type LibvirtConfig struct {
+	Hypervisors []LibvirtHypervisor `bson:"hypervisors" json:"hypervisors" yaml:"hypervisors"`
+}
+
+// LibvirtHypervisor is a single hypervisor host managed through libvirt.
+type LibvirtHypervisor struct {
+	// Name is the unique name used to refer to the hypervisor.
+	Name string `bson:"name" json:"name" yaml:"name"`
+	// URI is the libvirt connection URI for the hypervisor (e.g.
+	// qemu+ssh://user@hypervisor.example.com/system).
+	URI string `bson:"uri" json:"uri" yaml:"uri"`
+	// VolumePool is the storage pool in which to create volumes. If unset, it
+	// defaults to the "default" pool.
+	VolumePool string `bson:"volume_pool" json:"volume_pool" yaml:"volume_pool"`
+}
+
+// Validate checks that the hypervisors are uniquely named and have URIs.
+func (c *LibvirtConfig) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	names := map[string]bool{}
+	for _, hv := range c.Hypervisors {
+		catcher.NewWhen(hv.Name == "", "hypervisor name cannot be empty")
+		catcher.ErrorfWhen(hv.URI == "", "hypervisor '%s' must have a connection URI", hv.Name)
+		catcher.ErrorfWhen(names[hv.Name], "duplicate hypervisor name '%s'", hv.Name)
+		names[hv.Name] = true
+	}
+	return catcher.Resolve()
+}
+
 // VSphereConfig stores auth info for VMware vSphere. The config fields refer
 // to your vCenter server, a centralized management tool for the vSphere suite.
 type VSphereConfig struct {
@@ -14,6 +14,15 @@ type CommitQueueConfig struct {
 	CommitterEmail             string `yaml:"committer_email" bson:"committer_email" json:"committer_email"`
 	BatchSize                  int    `yaml:"batch_size" bson:"batch_size" json:"batch_size"`
 	MaxSystemFailedTaskRetries int    `yaml:"max_system_failed_task_retries" bson:"max_system_failed_task_retries" json:"max_system_failed_task_retries"`
+	// BisectBatches determines whether a batch of items is tested together
+	// and split in half when it fails until the failing items are found,
+	// rather than testing every item in the batch separately.
+	BisectBatches bool `yaml:"bisect_batches" bson:"bisect_batches" json:"bisect_batches"`
+	// SpeculativeDepth is the maximum number of items that can be processed
+	// at once. Each item is tested on top of the items before it, so later
+	// items can start before the earlier ones are merged. If it's not set,
+	// the next batch only starts after the previous batch is merged.
+	SpeculativeDepth int `yaml:"speculative_depth" bson:"speculative_depth" json:"speculative_depth"`
 }
 
 var (
@@ -22,6 +31,8 @@ var (
 	committerEmailKey             = bsonutil.MustHaveTag(CommitQueueConfig{}, "CommitterEmail")
 	commitQueueBatchSizeKey       = bsonutil.MustHaveTag(CommitQueueConfig{}, "BatchSize")
 	maxSystemFailedTaskRetriesKey = bsonutil.MustHaveTag(CommitQueueConfig{}, "MaxSystemFailedTaskRetries")
+	bisectBatchesKey              = bsonutil.MustHaveTag(CommitQueueConfig{}, "BisectBatches")
+	speculativeDepthKey           = bsonutil.MustHaveTag(CommitQueueConfig{}, "SpeculativeDepth")
 )
 
 func (c *CommitQueueConfig) SectionId() string { return "commit_queue" }
@@ -62,11 +73,16 @@ func (c *CommitQueueConfig) Set() error {
 			committerEmailKey:             c.CommitterEmail,
 			commitQueueBatchSizeKey:       c.BatchSize,
 			maxSystemFailedTaskRetriesKey: c.MaxSystemFailedTaskRetries,
+			bisectBatchesKey:              c.BisectBatches,
+			speculativeDepthKey:           c.SpeculativeDepth,
 		},
 	}, options.Update().SetUpsert(true))
 	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
 }
 
 func (c *CommitQueueConfig) ValidateAndDefault() error {
+	if c.SpeculativeDepth < 0 {
+		return errors.New("speculative depth cannot be negative")
+	}
 	return nil
 }
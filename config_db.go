@@ -51,6 +51,7 @@ var (
 	pprofPortKey          = bsonutil.MustHaveTag(Settings{}, "PprofPort")
 	githubPRCreatorOrgKey = bsonutil.MustHaveTag(Settings{}, "GithubPRCreatorOrg")
 	githubOrgsKey         = bsonutil.MustHaveTag(Settings{}, "GithubOrgs")
+	gitLabURLKey          = bsonutil.MustHaveTag(Settings{}, "GitLabURL")
 	disabledGQLQueriesKey = bsonutil.MustHaveTag(Settings{}, "DisabledGQLQueries")
 	containerPoolsKey     = bsonutil.MustHaveTag(Settings{}, "ContainerPools")
 	commitQueueKey        = bsonutil.MustHaveTag(Settings{}, "CommitQueue")
@@ -87,6 +88,8 @@ var (
 	cacheStatsJobDisabledKey           = bsonutil.MustHaveTag(ServiceFlags{}, "CacheStatsJobDisabled")
 	cacheStatsEndpointDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "CacheStatsEndpointDisabled")
 	taskReliabilityDisabledKey         = bsonutil.MustHaveTag(ServiceFlags{}, "TaskReliabilityDisabled")
+	testFlakinessDisabledKey           = bsonutil.MustHaveTag(ServiceFlags{}, "TestFlakinessDisabled")
+	predictiveTaskSelectionDisabledKey = bsonutil.MustHaveTag(ServiceFlags{}, "PredictiveTaskSelectionDisabled")
 	commitQueueDisabledKey             = bsonutil.MustHaveTag(ServiceFlags{}, "CommitQueueDisabled")
 	hostAllocatorDisabledKey           = bsonutil.MustHaveTag(ServiceFlags{}, "HostAllocatorDisabled")
 	podAllocatorDisabledKey            = bsonutil.MustHaveTag(ServiceFlags{}, "PodAllocatorDisabled")
@@ -114,9 +117,15 @@ var (
 	unexpirableHostsPerUserKey   = bsonutil.MustHaveTag(SpawnHostConfig{}, "UnexpirableHostsPerUser")
 	unexpirableVolumesPerUserKey = bsonutil.MustHaveTag(SpawnHostConfig{}, "UnexpirableVolumesPerUser")
 	spawnhostsPerUserKey         = bsonutil.MustHaveTag(SpawnHostConfig{}, "SpawnHostsPerUser")
+	snapshotsPerUserKey          = bsonutil.MustHaveTag(SpawnHostConfig{}, "SnapshotsPerUser")
 
 	tracerEnabledKey        = bsonutil.MustHaveTag(TracerConfig{}, "Enabled")
 	tracerCollectorEndpoint = bsonutil.MustHaveTag(TracerConfig{}, "CollectorEndpoint")
+
+	projectVarsEncryptionProviderKey    = bsonutil.MustHaveTag(ProjectVarsEncryptionConfig{}, "Provider")
+	projectVarsEncryptionKeyringPathKey = bsonutil.MustHaveTag(ProjectVarsEncryptionConfig{}, "KeyringPath")
+	projectVarsEncryptionKMSKeyIDKey    = bsonutil.MustHaveTag(ProjectVarsEncryptionConfig{}, "KMSKeyID")
+	projectVarsEncryptionKMSRegionKey   = bsonutil.MustHaveTag(ProjectVarsEncryptionConfig{}, "KMSRegion")
 )
 
 func byId(id string) bson.M {
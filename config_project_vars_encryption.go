@@ -0,0 +1,99 @@
+package evergreen
+
+import (
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// ProjectVarsEncryptionProviderLocal wraps data keys with keys from a
+	// keyring file on the app servers.
+	ProjectVarsEncryptionProviderLocal = "local"
+	// ProjectVarsEncryptionProviderKMS wraps data keys with an AWS KMS key.
+	ProjectVarsEncryptionProviderKMS = "kms"
+)
+
+// ProjectVarsEncryptionConfig configures encryption at rest for private and
+// admin-only project variables. If no provider is set, project variables
+// are stored in plaintext.
+type ProjectVarsEncryptionConfig struct {
+	// Provider is the key provider used to wrap the data keys that encrypt
+	// project variables.
+	Provider string `yaml:"provider" bson:"provider" json:"provider"`
+	// KeyringPath is the path to the keyring file for the local provider.
+	KeyringPath string `yaml:"keyring_path" bson:"keyring_path" json:"keyring_path"`
+	// KMSKeyID is the ID, ARN, or alias of the KMS key for the KMS provider.
+	KMSKeyID string `yaml:"kms_key_id" bson:"kms_key_id" json:"kms_key_id"`
+	// KMSRegion is the AWS region of the KMS key.
+	KMSRegion string `yaml:"kms_region" bson:"kms_region" json:"kms_region"`
+}
+
+// SectionId returns the ID of this config section.
+func (c *ProjectVarsEncryptionConfig) SectionId() string { return "project_vars_encryption" }
+
+// Get populates the config from the database.
+func (c *ProjectVarsEncryptionConfig) Get(env Environment) error {
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+	res := coll.FindOne(ctx, byId(c.SectionId()))
+	if err := res.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			*c = ProjectVarsEncryptionConfig{}
+			return nil
+		}
+		return errors.Wrapf(err, "getting config section '%s'", c.SectionId())
+	}
+
+	if err := res.Decode(c); err != nil {
+		return errors.Wrapf(err, "decoding config section '%s'", c.SectionId())
+	}
+
+	return nil
+}
+
+// Set sets the document in the database to match the in-memory config struct.
+func (c *ProjectVarsEncryptionConfig) Set() error {
+	env := GetEnvironment()
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+
+	_, err := coll.UpdateOne(ctx, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			projectVarsEncryptionProviderKey:    c.Provider,
+			projectVarsEncryptionKeyringPathKey: c.KeyringPath,
+			projectVarsEncryptionKMSKeyIDKey:    c.KMSKeyID,
+			projectVarsEncryptionKMSRegionKey:   c.KMSRegion,
+		},
+	}, options.Update().SetUpsert(true))
+	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
+}
+
+// ValidateAndDefault validates the project variable encryption
+// configuration.
+func (c *ProjectVarsEncryptionConfig) ValidateAndDefault() error {
+	switch c.Provider {
+	case "":
+	case ProjectVarsEncryptionProviderLocal:
+		if c.KeyringPath == "" {
+			return errors.New("local project variable encryption provider requires a keyring path")
+		}
+	case ProjectVarsEncryptionProviderKMS:
+		if c.KMSKeyID == "" {
+			return errors.New("KMS project variable encryption provider requires a KMS key ID")
+		}
+	default:
+		return errors.Errorf("invalid project variable encryption provider '%s'", c.Provider)
+	}
+	return nil
+}
+
+// Enabled returns whether project variables should be encrypted at rest.
+func (c *ProjectVarsEncryptionConfig) Enabled() bool {
+	return c.Provider != ""
+}
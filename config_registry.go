@@ -48,6 +48,7 @@ func resetRegistry() error {
 		&NotifyConfig{},
 		&PodLifecycleConfig{},
 		&ProjectCreationConfig{},
+		&ProjectVarsEncryptionConfig{},
 		&RepoTrackerConfig{},
 		&SchedulerConfig{},
 		&ServiceFlags{},
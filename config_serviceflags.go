@@ -26,6 +26,8 @@ type ServiceFlags struct {
 	CacheStatsJobDisabled           bool `bson:"cache_stats_job_disabled" json:"cache_stats_job_disabled"`
 	CacheStatsEndpointDisabled      bool `bson:"cache_stats_endpoint_disabled" json:"cache_stats_endpoint_disabled"`
 	TaskReliabilityDisabled         bool `bson:"task_reliability_disabled" json:"task_reliability_disabled"`
+	TestFlakinessDisabled           bool `bson:"test_flakiness_disabled" json:"test_flakiness_disabled"`
+	PredictiveTaskSelectionDisabled bool `bson:"predictive_task_selection_disabled" json:"predictive_task_selection_disabled"`
 	CommitQueueDisabled             bool `bson:"commit_queue_disabled" json:"commit_queue_disabled"`
 	HostAllocatorDisabled           bool `bson:"host_allocator_disabled" json:"host_allocator_disabled"`
 	PodAllocatorDisabled            bool `bson:"pod_allocator_disabled" json:"pod_allocator_disabled"`
@@ -99,6 +101,8 @@ func (c *ServiceFlags) Set() error {
 			cacheStatsJobDisabledKey:           c.CacheStatsJobDisabled,
 			cacheStatsEndpointDisabledKey:      c.CacheStatsEndpointDisabled,
 			taskReliabilityDisabledKey:         c.TaskReliabilityDisabled,
+			testFlakinessDisabledKey:           c.TestFlakinessDisabled,
+			predictiveTaskSelectionDisabledKey: c.PredictiveTaskSelectionDisabled,
 			commitQueueDisabledKey:             c.CommitQueueDisabled,
 			hostAllocatorDisabledKey:           c.HostAllocatorDisabled,
 			podAllocatorDisabledKey:            c.PodAllocatorDisabled,
@@ -11,6 +11,7 @@ type SpawnHostConfig struct {
 	UnexpirableHostsPerUser   int `yaml:"unexpirable_hosts_per_user" bson:"unexpirable_hosts_per_user" json:"unexpirable_hosts_per_user"`
 	UnexpirableVolumesPerUser int `yaml:"unexpirable_volumes_per_user" bson:"unexpirable_volumes_per_user" json:"unexpirable_volumes_per_user"`
 	SpawnHostsPerUser         int `yaml:"spawn_hosts_per_user" bson:"spawn_hosts_per_user" json:"spawn_hosts_per_user"`
+	SnapshotsPerUser          int `yaml:"snapshots_per_user" bson:"snapshots_per_user" json:"snapshots_per_user"`
 }
 
 func (c *SpawnHostConfig) SectionId() string { return "spawnhost" }
@@ -48,6 +49,7 @@ func (c *SpawnHostConfig) Set() error {
 			unexpirableHostsPerUserKey:   c.UnexpirableHostsPerUser,
 			unexpirableVolumesPerUserKey: c.UnexpirableVolumesPerUser,
 			spawnhostsPerUserKey:         c.SpawnHostsPerUser,
+			snapshotsPerUserKey:          c.SnapshotsPerUser,
 		},
 	}, options.Update().SetUpsert(true))
 	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
@@ -63,5 +65,8 @@ func (c *SpawnHostConfig) ValidateAndDefault() error {
 	if c.UnexpirableVolumesPerUser < 0 {
 		c.UnexpirableVolumesPerUser = DefaultUnexpirableVolumesPerUser
 	}
+	if c.SnapshotsPerUser < 0 {
+		c.SnapshotsPerUser = DefaultMaxSnapshotsPerUser
+	}
 	return nil
 }
@@ -960,6 +960,28 @@ func (s *AdminSuite) TestTracerConfig() {
 	s.Equal(config, settings.Tracer)
 }
 
+func (s *AdminSuite) TestProjectVarsEncryptionConfig() {
+	config := ProjectVarsEncryptionConfig{
+		Provider:  ProjectVarsEncryptionProviderKMS,
+		KMSKeyID:  "alias/evergreen",
+		KMSRegion: "us-east-1",
+	}
+
+	s.NoError(config.Set())
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.ProjectVarsEncryption)
+
+	config.Provider = ""
+	s.NoError(config.Set())
+
+	settings, err = GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.ProjectVarsEncryption)
+}
+
 func (s *AdminSuite) TestDataPipesConfig() {
 	config := DataPipesConfig{
 		Host:         "https://url.com",
@@ -129,6 +129,9 @@ const (
 	// running, the task is considered stranded.
 	TaskDescriptionStranded  = "stranded"
 	TaskDescriptionNoResults = "expected test results, but none attached"
+	// TaskDescriptionQuarantinedFailures indicates that a task succeeded
+	// because all of its failed tests are quarantined.
+	TaskDescriptionQuarantinedFailures = "quarantined failures"
 	// TaskDescriptionContainerUnallocatable indicates that the reason a
 	// container task failed is because it cannot be allocated a container.
 	TaskDescriptionContainerUnallocatable = "container task cannot be allocated"
@@ -150,6 +153,9 @@ const (
 	TestSilentlyFailedStatus = "silentfail"
 	TestSkippedStatus        = "skip"
 	TestSucceededStatus      = "pass"
+	// TestQuarantinedStatus is the status of a failed test that is
+	// quarantined in its project, so it did not fail its task.
+	TestQuarantinedStatus = "quarantined"
 
 	BuildStarted   = "started"
 	BuildCreated   = "created"
@@ -262,6 +268,8 @@ const (
 	DefaultMaxVolumeSizePerUser         = 500
 	DefaultUnexpirableHostsPerUser      = 1
 	DefaultUnexpirableVolumesPerUser    = 1
+	DefaultMaxSnapshotsPerUser          = 2
+	SpawnHostSnapshotExpiration         = 24 * time.Hour * 30
 
 	// host resource tag names
 	TagName             = "name"
@@ -522,6 +530,7 @@ const (
 	ProviderNameStatic      = "static"
 	ProviderNameOpenstack   = "openstack"
 	ProviderNameVsphere     = "vsphere"
+	ProviderNameLibvirt     = "libvirt"
 	ProviderNameMock        = "mock"
 
 	// DefaultEC2Region is the default region where hosts should be spawned.
@@ -555,6 +564,7 @@ var (
 		ProviderNameGce,
 		ProviderNameOpenstack,
 		ProviderNameVsphere,
+		ProviderNameLibvirt,
 		ProviderNameMock,
 		ProviderNameDocker,
 	}
@@ -568,6 +578,7 @@ var (
 		ProviderNameGce,
 		ProviderNameOpenstack,
 		ProviderNameVsphere,
+		ProviderNameLibvirt,
 	}
 
 	ProviderContainer = []string{
@@ -643,12 +654,14 @@ const (
 	AttachResultsCommandName      = "attach.results"
 	AttachArtifactsCommandName    = "attach.artifacts"
 	AttachXUnitResultsCommandName = "attach.xunit_results"
+	AttachTestResultsCommandName  = "attach.test_results"
 )
 
 var AttachCommands = []string{
 	AttachResultsCommandName,
 	AttachArtifactsCommandName,
 	AttachXUnitResultsCommandName,
+	AttachTestResultsCommandName,
 }
 
 type SenderKey int
@@ -661,12 +674,13 @@ const (
 	SenderJIRAComment
 	SenderEmail
 	SenderGeneric
+	SenderChatWebhook
 )
 
 func (k SenderKey) Validate() error {
 	switch k {
 	case SenderGithubStatus, SenderEvergreenWebhook, SenderSlack, SenderJIRAComment, SenderJIRAIssue,
-		SenderEmail, SenderGeneric:
+		SenderEmail, SenderGeneric, SenderChatWebhook:
 		return nil
 	default:
 		return errors.New("invalid sender defined")
@@ -689,6 +703,8 @@ func (k SenderKey) String() string {
 		return "jira-issue"
 	case SenderGeneric:
 		return "generic"
+	case SenderChatWebhook:
+		return "chat-webhook"
 	default:
 		return "<error:unknown>"
 	}
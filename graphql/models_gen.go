@@ -251,6 +251,7 @@ type SpawnHostInput struct {
 	UserDataScript          *string         `json:"userDataScript,omitempty"`
 	UseTaskConfig           *bool           `json:"useTaskConfig,omitempty"`
 	VolumeID                *string         `json:"volumeId,omitempty"`
+	SnapshotID              *string         `json:"snapshotId,omitempty"`
 }
 
 // SpawnVolumeInput is the input to the spawnVolume mutation.
@@ -265,6 +266,7 @@ type SpawnVolumeInput struct {
 }
 
 type Subscriber struct {
+	ChatWebhookSubscriber *model.APIChatWebhookSubscriber `json:"chatWebhookSubscriber,omitempty"`
 	EmailSubscriber       *string                         `json:"emailSubscriber,omitempty"`
 	GithubCheckSubscriber *model.APIGithubCheckSubscriber `json:"githubCheckSubscriber,omitempty"`
 	GithubPRSubscriber    *model.APIGithubPRSubscriber    `json:"githubPRSubscriber,omitempty"`
@@ -351,6 +353,21 @@ type TestFilterOptions struct {
 	Page     *int               `json:"page,omitempty"`
 }
 
+// TestFlakinessOptions is an input for the testFlakiness query.
+// It's used to filter and aggregate the daily test statistics of a project.
+type TestFlakinessOptions struct {
+	AfterDate      *time.Time `json:"afterDate,omitempty"`
+	BeforeDate     *time.Time `json:"beforeDate,omitempty"`
+	BuildVariants  []string   `json:"buildVariants,omitempty"`
+	GroupByVariant *bool      `json:"groupByVariant,omitempty"`
+	Limit          *int       `json:"limit,omitempty"`
+	MinCommits     *int       `json:"minCommits,omitempty"`
+	Requesters     []string   `json:"requesters,omitempty"`
+	Significance   *float64   `json:"significance,omitempty"`
+	Tasks          []string   `json:"tasks,omitempty"`
+	Tests          []string   `json:"tests,omitempty"`
+}
+
 // TestSortOptions is an input for the task.Tests query.
 // It's used to define sort criteria for test results of a task.
 type TestSortOptions struct {
@@ -281,6 +281,19 @@ func (r *mutationResolver) SchedulePatchTasks(ctx context.Context, patchID strin
 	return &patchID, nil
 }
 
+// ScheduleSkippedTasks is the resolver for the scheduleSkippedTasks field.
+func (r *mutationResolver) ScheduleSkippedTasks(ctx context.Context, patchID string) (*restModel.APIPatch, error) {
+	statusCode, err := units.ScheduleSkippedTasks(ctx, evergreen.GetEnvironment(), patchID)
+	if err != nil {
+		return nil, mapHTTPStatusToGqlError(ctx, statusCode, werrors.Errorf("Error scheduling skipped tasks for patch '%s': %s", patchID, err.Error()))
+	}
+	scheduledPatch, err := data.FindPatchById(patchID)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("Error getting patch '%s': %s", patchID, err))
+	}
+	return scheduledPatch, nil
+}
+
 // ScheduleUndispatchedBaseTasks is the resolver for the scheduleUndispatchedBaseTasks field.
 func (r *mutationResolver) ScheduleUndispatchedBaseTasks(ctx context.Context, patchID string) ([]*restModel.APITask, error) {
 	opts := task.GetTasksByVersionOptions{
@@ -580,6 +593,27 @@ func (r *mutationResolver) PromoteVarsToRepo(ctx context.Context, projectID stri
 	return true, nil
 }
 
+// QuarantineTest is the resolver for the quarantineTest field.
+func (r *mutationResolver) QuarantineTest(ctx context.Context, projectID string, pattern string, reason *string) (*restModel.APIQuarantinedTest, error) {
+	usr := mustHaveUser(ctx)
+	q := model.QuarantinedTest{
+		ProjectID: projectID,
+		Pattern:   pattern,
+		Reason:    utility.FromStringPtr(reason),
+		Author:    usr.Username(),
+		CreatedAt: time.Now(),
+	}
+	if err := q.Validate(); err != nil {
+		return nil, InputValidationError.Send(ctx, err.Error())
+	}
+	if err := q.Upsert(); err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("quarantining test pattern '%s' in project '%s': %s", pattern, projectID, err.Error()))
+	}
+	res := &restModel.APIQuarantinedTest{}
+	res.BuildFromService(q)
+	return res, nil
+}
+
 // RemoveFavoriteProject is the resolver for the removeFavoriteProject field.
 func (r *mutationResolver) RemoveFavoriteProject(ctx context.Context, identifier string) (*restModel.APIProjectRef, error) {
 	p, err := model.FindBranchProjectRef(identifier)
@@ -622,6 +656,14 @@ func (r *mutationResolver) SaveRepoSettingsForSection(ctx context.Context, repoS
 	return changes, nil
 }
 
+// UnquarantineTest is the resolver for the unquarantineTest field.
+func (r *mutationResolver) UnquarantineTest(ctx context.Context, projectID string, pattern string) (bool, error) {
+	if err := model.RemoveQuarantinedTest(projectID, pattern); err != nil {
+		return false, InternalServerError.Send(ctx, fmt.Sprintf("removing quarantined test pattern '%s' from project '%s': %s", pattern, projectID, err.Error()))
+	}
+	return true, nil
+}
+
 // AttachVolumeToHost is the resolver for the attachVolumeToHost field.
 func (r *mutationResolver) AttachVolumeToHost(ctx context.Context, volumeAndHost VolumeHost) (bool, error) {
 	statusCode, err := cloud.AttachVolume(ctx, volumeAndHost.VolumeID, volumeAndHost.HostID)
@@ -631,6 +673,29 @@ func (r *mutationResolver) AttachVolumeToHost(ctx context.Context, volumeAndHost
 	return statusCode == http.StatusOK, nil
 }
 
+// CreateSpawnHostSnapshot is the resolver for the createSpawnHostSnapshot field.
+func (r *mutationResolver) CreateSpawnHostSnapshot(ctx context.Context, hostID string, displayName *string) (*restModel.APISpawnHostSnapshot, error) {
+	h, err := host.FindOneByIdOrTag(hostID)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("finding host '%s': %s", hostID, err.Error()))
+	}
+	if h == nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("host '%s' not found", hostID))
+	}
+	usr := mustHaveUser(ctx)
+	if !host.CanUpdateSpawnHost(h, usr) {
+		return nil, Forbidden.Send(ctx, "You are not authorized to snapshot this host")
+	}
+
+	snapshot, statusCode, err := data.CreateSpawnHostSnapshot(ctx, evergreen.GetEnvironment(), usr, h, utility.FromStringPtr(displayName))
+	if err != nil {
+		return nil, mapHTTPStatusToGqlError(ctx, statusCode, err)
+	}
+	apiSnapshot := &restModel.APISpawnHostSnapshot{}
+	apiSnapshot.BuildFromService(*snapshot)
+	return apiSnapshot, nil
+}
+
 // DetachVolumeFromHost is the resolver for the detachVolumeFromHost field.
 func (r *mutationResolver) DetachVolumeFromHost(ctx context.Context, volumeID string) (bool, error) {
 	statusCode, err := cloud.DetachVolume(ctx, volumeID)
@@ -810,6 +875,24 @@ func (r *mutationResolver) SpawnVolume(ctx context.Context, spawnVolumeInput Spa
 	return true, nil
 }
 
+// RemoveSpawnHostSnapshot is the resolver for the removeSpawnHostSnapshot field.
+func (r *mutationResolver) RemoveSpawnHostSnapshot(ctx context.Context, snapshotID string) (bool, error) {
+	usr := mustHaveUser(ctx)
+	snapshot, err := host.FindSnapshotByID(snapshotID)
+	if err != nil {
+		return false, InternalServerError.Send(ctx, fmt.Sprintf("finding snapshot '%s': %s", snapshotID, err.Error()))
+	}
+	if snapshot == nil || snapshot.CreatedBy != usr.Id {
+		return false, ResourceNotFound.Send(ctx, fmt.Sprintf("snapshot '%s' not found", snapshotID))
+	}
+
+	statusCode, err := data.DeleteSpawnHostSnapshot(ctx, evergreen.GetEnvironment(), usr, snapshot)
+	if err != nil {
+		return false, mapHTTPStatusToGqlError(ctx, statusCode, err)
+	}
+	return statusCode == http.StatusOK, nil
+}
+
 // RemoveVolume is the resolver for the removeVolume field.
 func (r *mutationResolver) RemoveVolume(ctx context.Context, volumeID string) (bool, error) {
 	statusCode, err := cloud.DeleteVolume(ctx, volumeID)
@@ -1084,6 +1167,19 @@ func (r *mutationResolver) RemovePublicKey(ctx context.Context, keyName string)
 	return myPublicKeys, nil
 }
 
+// ReplayWebhookDelivery is the resolver for the replayWebhookDelivery field.
+func (r *mutationResolver) ReplayWebhookDelivery(ctx context.Context, subscriptionID string, deliveryID string) (*restModel.APIWebhookDelivery, error) {
+	delivery, err := data.ReplayWebhookDelivery(ctx, mustHaveUser(ctx), subscriptionID, deliveryID)
+	if err != nil {
+		gimletErr, ok := err.(gimlet.ErrorResponse)
+		if ok {
+			return nil, mapHTTPStatusToGqlError(ctx, gimletErr.StatusCode, err)
+		}
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("replaying webhook delivery '%s': %s", deliveryID, err.Error()))
+	}
+	return delivery, nil
+}
+
 // SaveSubscription is the resolver for the saveSubscription field.
 func (r *mutationResolver) SaveSubscription(ctx context.Context, subscription restModel.APISubscription) (bool, error) {
 	usr := mustHaveUser(ctx)
@@ -24,6 +24,7 @@ import (
 	"github.com/evergreen-ci/evergreen/rest/data"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/plank"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/anser/bsonutil"
@@ -532,6 +533,22 @@ func (r *queryResolver) MyVolumes(ctx context.Context) ([]*restModel.APIVolume,
 	return getAPIVolumeList(volumes)
 }
 
+// MySnapshots is the resolver for the mySnapshots field.
+func (r *queryResolver) MySnapshots(ctx context.Context) ([]*restModel.APISpawnHostSnapshot, error) {
+	usr := mustHaveUser(ctx)
+	snapshots, err := host.FindSnapshotsByUser(usr.Id)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, err.Error())
+	}
+	apiSnapshots := []*restModel.APISpawnHostSnapshot{}
+	for _, s := range snapshots {
+		apiSnapshot := &restModel.APISpawnHostSnapshot{}
+		apiSnapshot.BuildFromService(s)
+		apiSnapshots = append(apiSnapshots, apiSnapshot)
+	}
+	return apiSnapshots, nil
+}
+
 // LogkeeperBuildMetadata is the resolver for the logkeeperBuildMetadata field.
 func (r *queryResolver) LogkeeperBuildMetadata(ctx context.Context, buildID string) (*plank.Build, error) {
 	client := plank.NewLogkeeperClient(plank.NewLogkeeperClientOptions{
@@ -637,6 +654,51 @@ func (r *queryResolver) TaskTestSample(ctx context.Context, tasks []string, filt
 	return apiSamples, nil
 }
 
+// TestFlakiness is the resolver for the testFlakiness field.
+func (r *queryResolver) TestFlakiness(ctx context.Context, projectIdentifier string, options *TestFlakinessOptions) ([]*restModel.APITestFlakiness, error) {
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("error retrieving service flags: %s", err.Error()))
+	}
+	if flags.TestFlakinessDisabled {
+		return nil, ServiceUnavailable.Send(ctx, "test flakiness statistics are disabled")
+	}
+
+	filter := makeTestFlakinessFilter(projectIdentifier, options)
+	if err = filter.ValidateForTestFlakiness(); err != nil {
+		return nil, InputValidationError.Send(ctx, fmt.Sprintf("invalid test flakiness options: %s", err.Error()))
+	}
+	scores, err := data.GetTestFlakinessScores(filter)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("error getting test flakiness scores for project '%s': %s", projectIdentifier, err.Error()))
+	}
+
+	apiScores := make([]*restModel.APITestFlakiness, 0, len(scores))
+	for i := range scores {
+		apiScores = append(apiScores, &scores[i])
+	}
+	return apiScores, nil
+}
+
+// QuarantinedTests is the resolver for the quarantinedTests field.
+func (r *queryResolver) QuarantinedTests(ctx context.Context, projectIdentifier string) ([]*restModel.APIQuarantinedTest, error) {
+	projectID, err := model.GetIdForProject(projectIdentifier)
+	if err != nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("finding project '%s': %s", projectIdentifier, err.Error()))
+	}
+	quarantined, err := model.FindQuarantinedTestsForProject(projectID)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("finding quarantined tests for project '%s': %s", projectIdentifier, err.Error()))
+	}
+	res := []*restModel.APIQuarantinedTest{}
+	for _, q := range quarantined {
+		apiQuarantined := &restModel.APIQuarantinedTest{}
+		apiQuarantined.BuildFromService(q)
+		res = append(res, apiQuarantined)
+	}
+	return res, nil
+}
+
 // MyPublicKeys is the resolver for the myPublicKeys field.
 func (r *queryResolver) MyPublicKeys(ctx context.Context) ([]*restModel.APIPubKey, error) {
 	publicKeys := getMyPublicKeys(ctx)
@@ -688,6 +750,23 @@ func (r *queryResolver) UserSettings(ctx context.Context) (*restModel.APIUserSet
 	return &userSettings, nil
 }
 
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, subscriptionID string, limit *int) ([]*restModel.APIWebhookDelivery, error) {
+	deliveries, err := data.GetWebhookDeliveries(mustHaveUser(ctx), subscriptionID, utility.FromIntPtr(limit))
+	if err != nil {
+		gimletErr, ok := err.(gimlet.ErrorResponse)
+		if ok {
+			return nil, mapHTTPStatusToGqlError(ctx, gimletErr.StatusCode, err)
+		}
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("getting webhook deliveries for subscription '%s': %s", subscriptionID, err.Error()))
+	}
+	res := []*restModel.APIWebhookDelivery{}
+	for i := range deliveries {
+		res = append(res, &deliveries[i])
+	}
+	return res, nil
+}
+
 // CommitQueue is the resolver for the commitQueue field.
 func (r *queryResolver) CommitQueue(ctx context.Context, projectIdentifier string) (*restModel.APICommitQueue, error) {
 	commitQueue, err := data.FindCommitQueueForProject(projectIdentifier)
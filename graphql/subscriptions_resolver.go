@@ -50,6 +50,13 @@ func (r *subscriberWrapperResolver) Subscriber(ctx context.Context, obj *model.A
 				event.JIRAIssueSubscriberType, err.Error()))
 		}
 		res.JiraIssueSubscriber = sub
+	case event.ChatWebhookSubscriberType:
+		sub := &model.APIChatWebhookSubscriber{}
+		if err := mapstructure.Decode(obj.Target, &sub); err != nil {
+			return nil, InternalServerError.Send(ctx, fmt.Sprintf("problem building %s subscriber from service: %s",
+				event.ChatWebhookSubscriberType, err.Error()))
+		}
+		res.ChatWebhookSubscriber = sub
 	case event.JIRACommentSubscriberType:
 		res.JiraCommentSubscriber = obj.Target.(*string)
 	case event.EmailSubscriberType:
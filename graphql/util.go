@@ -16,6 +16,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/reliability"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/model/user"
@@ -448,7 +449,7 @@ func mapHTTPStatusToGqlError(ctx context.Context, httpStatus int, err error) *gq
 		return InternalServerError.Send(ctx, err.Error())
 	case http.StatusNotFound:
 		return ResourceNotFound.Send(ctx, err.Error())
-	case http.StatusUnauthorized:
+	case http.StatusUnauthorized, http.StatusForbidden:
 		return Forbidden.Send(ctx, err.Error())
 	case http.StatusBadRequest:
 		return InputValidationError.Send(ctx, err.Error())
@@ -911,6 +912,9 @@ func getHostRequestOptions(ctx context.Context, usr *user.DBUser, spawnHostInput
 	if spawnHostInput.VolumeID != nil {
 		options.HomeVolumeID = *spawnHostInput.VolumeID
 	}
+	if spawnHostInput.SnapshotID != nil {
+		options.SnapshotID = *spawnHostInput.SnapshotID
+	}
 	if spawnHostInput.Expiration != nil {
 		options.Expiration = spawnHostInput.Expiration
 	}
@@ -1048,3 +1052,54 @@ func getBaseTaskTestResultsOptions(ctx context.Context, dbTask *task.Task) ([]te
 
 	return taskOpts, nil
 }
+
+// makeTestFlakinessFilter converts the testFlakiness query options into a
+// test flakiness filter, filling in defaults for any unset options.
+func makeTestFlakinessFilter(projectIdentifier string, opts *TestFlakinessOptions) reliability.TestFlakinessFilter {
+	if opts == nil {
+		opts = &TestFlakinessOptions{}
+	}
+
+	beforeDate := utility.GetUTCDay(time.Now())
+	if opts.BeforeDate != nil {
+		beforeDate = utility.GetUTCDay(*opts.BeforeDate)
+	}
+	afterDate := beforeDate.Add(-28 * 24 * time.Hour)
+	if opts.AfterDate != nil {
+		afterDate = utility.GetUTCDay(*opts.AfterDate)
+	}
+	requesters := opts.Requesters
+	if len(requesters) == 0 {
+		requesters = []string{evergreen.RepotrackerVersionRequester}
+	}
+	groupBy := reliability.TestGroupByTask
+	if utility.FromBoolPtr(opts.GroupByVariant) {
+		groupBy = reliability.TestGroupByVariant
+	}
+	limit := 100
+	if opts.Limit != nil {
+		limit = *opts.Limit
+	}
+	minCommits := 1
+	if opts.MinCommits != nil {
+		minCommits = *opts.MinCommits
+	}
+	significance := reliability.DefaultSignificance
+	if opts.Significance != nil {
+		significance = *opts.Significance
+	}
+
+	return reliability.TestFlakinessFilter{
+		Project:       projectIdentifier,
+		Requesters:    requesters,
+		AfterDate:     afterDate,
+		BeforeDate:    beforeDate,
+		Tests:         opts.Tests,
+		Tasks:         opts.Tasks,
+		BuildVariants: opts.BuildVariants,
+		GroupBy:       groupBy,
+		MinCommits:    minCommits,
+		Limit:         limit,
+		Significance:  significance,
+	}
+}
@@ -27,6 +27,7 @@ const (
 	UIRequester           = "ui"
 	APIRequester          = "api"
 	WebhookRequester      = "webhook"
+	QuarantineRequester   = "quarantine"
 	MaxMetadataLinks      = 1
 	MaxMetadataTextLength = 40
 )
@@ -139,6 +139,32 @@ func UpdateAnnotationNote(taskId string, execution int, originalMessage, newMess
 	return errors.Wrapf(err, "updating note for task '%s'", taskId)
 }
 
+// AddSystemNote adds a message generated by Evergreen to the task
+// annotation's note. If the annotation already has a note, the message is
+// appended to it rather than replacing it.
+func AddSystemNote(taskId string, execution int, message, requester string) error {
+	annotation, err := FindOneByTaskIdAndExecution(taskId, execution)
+	if err != nil {
+		return errors.Wrap(err, "finding task annotation")
+	}
+	if annotation != nil && annotation.Note != nil && annotation.Note.Message != "" {
+		message = annotation.Note.Message + "\n\n" + message
+	}
+
+	note := Note{
+		Message: message,
+		Source:  &Source{Requester: requester, Author: evergreen.User, Time: time.Now()},
+	}
+	_, err = db.Upsert(
+		Collection,
+		ByTaskIdAndExecution(taskId, execution),
+		bson.M{
+			"$set": bson.M{NoteKey: note},
+		},
+	)
+	return errors.Wrapf(err, "adding system note for task '%s'", taskId)
+}
+
 // SetAnnotationMetadataLinks sets the metadata links for a task annotation.
 func SetAnnotationMetadataLinks(ctx context.Context, taskId string, execution int, username string, metadataLinks ...MetadataLink) error {
 	now := time.Now()
@@ -3,7 +3,10 @@ package model
 import (
 	"context"
 	"strconv"
+	"time"
 
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/patch"
@@ -11,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/google/go-github/v52/github"
 	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func GetModulesFromPR(ctx context.Context, githubToken string, modules []commitqueue.Module, projectConfig *Project) ([]*github.PullRequest, []patch.ModulePatch, error) {
@@ -104,3 +108,179 @@ func preventMergeForItem(item commitqueue.CommitQueueItem, user string) error {
 
 	return nil
 }
+
+// StartCommitQueueBatchTest tests the commit queue items with the given issues
+// together. Since the version of each item also includes the changes from the
+// items before it, only the tasks in the version of the last item run. The
+// merge tasks of the items depend on those tasks, so the items are merged as
+// soon as the batch passes. The tasks of the other items that are being
+// bisected are deactivated, since their results would not isolate the item
+// that caused the failure.
+func StartCommitQueueBatchTest(cq *commitqueue.CommitQueue, issues []string, caller string) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	testIndex := cq.FindItem(issues[len(issues)-1])
+	if testIndex < 0 {
+		return errors.Errorf("item '%s' not found in commit queue for project '%s'", issues[len(issues)-1], cq.ProjectID)
+	}
+	testVersion := cq.Queue[testIndex].Version
+
+	testTasks, err := task.FindCommitQueueTestTasksForVersion(testVersion)
+	if err != nil {
+		return errors.Wrapf(err, "finding tasks for version '%s'", testVersion)
+	}
+	testTaskIDs := make([]string, 0, len(testTasks))
+	toActivate := []task.Task{}
+	toRestart := []string{}
+	for _, t := range testTasks {
+		testTaskIDs = append(testTaskIDs, t.Id)
+		if t.IsFinished() {
+			toRestart = append(toRestart, t.Id)
+		} else if !t.Activated {
+			toActivate = append(toActivate, t)
+		}
+	}
+	if len(toRestart) > 0 {
+		if err = RestartVersion(testVersion, toRestart, false, caller); err != nil {
+			return errors.Wrapf(err, "restarting tasks in version '%s'", testVersion)
+		}
+	}
+	if len(toActivate) > 0 {
+		if err = task.ActivateTasks(toActivate, time.Now(), true, caller); err != nil {
+			return errors.Wrapf(err, "activating tasks in version '%s'", testVersion)
+		}
+	}
+
+	inBatch := map[string]bool{}
+	for _, issue := range issues {
+		inBatch[issue] = true
+	}
+	for i, item := range cq.Queue {
+		if i == testIndex || item.Version == "" || item.BisectionStatus == commitqueue.BisectionStatusPassed {
+			continue
+		}
+		if !inBatch[item.Issue] && !item.IsBisecting() {
+			continue
+		}
+		if err = deactivateCommitQueueTestTasks(item.Version, caller); err != nil {
+			return errors.Wrapf(err, "deactivating tasks for item '%s'", item.Issue)
+		}
+	}
+
+	for _, issue := range issues {
+		item := cq.Queue[cq.FindItem(issue)]
+		mergeTask, err := task.FindMergeTaskForVersion(item.Version)
+		if err != nil {
+			return errors.Wrapf(err, "finding merge task for item '%s'", issue)
+		}
+		if mergeTask == nil {
+			return errors.Errorf("merge task for item '%s' not found", issue)
+		}
+		if err = setMergeTaskTestDependencies(mergeTask, testTaskIDs); err != nil {
+			return errors.Wrapf(err, "setting dependencies for merge task '%s'", mergeTask.Id)
+		}
+	}
+
+	return errors.Wrap(cq.StartBatchTest(issues), "recording batch test")
+}
+
+// deactivateCommitQueueTestTasks deactivates the tasks in the version that
+// have not been dispatched yet.
+func deactivateCommitQueueTestTasks(versionID, caller string) error {
+	tasks, err := task.FindCommitQueueTestTasksForVersion(versionID)
+	if err != nil {
+		return errors.Wrapf(err, "finding tasks for version '%s'", versionID)
+	}
+	toDeactivate := []task.Task{}
+	for _, t := range tasks {
+		if t.Activated && t.Status == evergreen.TaskUndispatched {
+			toDeactivate = append(toDeactivate, t)
+		}
+	}
+	if len(toDeactivate) == 0 {
+		return nil
+	}
+	return task.DeactivateTasks(toDeactivate, false, caller)
+}
+
+// setMergeTaskTestDependencies replaces the merge task's dependencies on test
+// tasks with dependencies on the given tasks. Its dependencies on the merge
+// tasks before it in the queue are kept so that items are still merged in
+// order.
+func setMergeTaskTestDependencies(mergeTask *task.Task, testTaskIDs []string) error {
+	depIDs := make([]string, 0, len(mergeTask.DependsOn))
+	for _, dep := range mergeTask.DependsOn {
+		depIDs = append(depIDs, dep.TaskId)
+	}
+	mergeDeps, err := task.FindAll(db.Query(bson.M{
+		task.IdKey:               bson.M{"$in": depIDs},
+		task.CommitQueueMergeKey: true,
+	}).WithFields(task.IdKey))
+	if err != nil {
+		return errors.Wrap(err, "finding merge task dependencies")
+	}
+	isMergeDep := map[string]bool{}
+	for _, dep := range mergeDeps {
+		isMergeDep[dep.Id] = true
+	}
+
+	for _, depID := range depIDs {
+		if isMergeDep[depID] {
+			continue
+		}
+		if err = mergeTask.RemoveDependency(depID); err != nil {
+			return errors.Wrapf(err, "removing dependency '%s'", depID)
+		}
+	}
+	for _, id := range testTaskIDs {
+		if err = mergeTask.AddDependency(task.Dependency{TaskId: id, Status: evergreen.TaskSucceeded}); err != nil {
+			return errors.Wrapf(err, "adding dependency '%s'", id)
+		}
+	}
+
+	return errors.Wrap(RecomputeNumDependents(*mergeTask), "recomputing number of dependents")
+}
+
+// GetCommitQueueBatchTestStatus returns the bisection status of the batch that
+// is tested by the version. The batch fails as soon as any of its tasks fails,
+// and passes once all of its tasks have succeeded.
+func GetCommitQueueBatchTestStatus(versionID string) (string, error) {
+	tasks, err := task.FindCommitQueueTestTasksForVersion(versionID)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding tasks for version '%s'", versionID)
+	}
+	status := commitqueue.BisectionStatusPassed
+	for _, t := range tasks {
+		if !t.Activated {
+			continue
+		}
+		if !t.IsFinished() || t.ResetWhenFinished {
+			status = commitqueue.BisectionStatusTesting
+			continue
+		}
+		if t.Status != evergreen.TaskSucceeded {
+			return commitqueue.BisectionStatusFailed, nil
+		}
+	}
+	return status, nil
+}
+
+// CommitQueueLaneForPatch returns the commit queue lane that the patch's
+// changes are in. Changes to modules are always in the shared lane, since
+// lanes only contain paths in the project's repository.
+func CommitQueueLaneForPatch(p *patch.Patch) (string, error) {
+	projectRef, err := FindMergedProjectRef(p.Project, "", false)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding project '%s'", p.Project)
+	}
+	if projectRef == nil || len(projectRef.CommitQueue.Lanes) == 0 {
+		return commitqueue.SharedLane, nil
+	}
+	for _, modulePatch := range p.Patches {
+		if modulePatch.ModuleName != "" {
+			return commitqueue.SharedLane, nil
+		}
+	}
+	return projectRef.CommitQueue.LaneForFiles(p.FilesChanged()), nil
+}
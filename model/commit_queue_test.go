@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type CommitQueueSuite struct {
@@ -75,3 +76,64 @@ func (s *CommitQueueSuite) TestPreventMergeForItem() {
 	s.NoError(err)
 	s.Equal(int64(-1), mergeTask.Priority)
 }
+
+func (s *CommitQueueSuite) TestGetCommitQueueBatchTestStatus() {
+	s.NoError(db.ClearCollections(task.Collection))
+	tasks := []task.Task{
+		{Id: "t1", Version: "v1", Activated: true, Status: evergreen.TaskSucceeded},
+		{Id: "t2", Version: "v1", Activated: true, Status: evergreen.TaskStarted},
+		{Id: "t3", Version: "v1", Activated: false, Status: evergreen.TaskUndispatched},
+		{Id: "merge", Version: "v1", Activated: true, Status: evergreen.TaskUndispatched, CommitQueueMerge: true},
+	}
+	for _, t := range tasks {
+		s.NoError(t.Insert())
+	}
+
+	status, err := GetCommitQueueBatchTestStatus("v1")
+	s.NoError(err)
+	s.Equal(commitqueue.BisectionStatusTesting, status)
+
+	s.NoError(task.UpdateOne(task.ById("t2"), bson.M{"$set": bson.M{task.StatusKey: evergreen.TaskSucceeded}}))
+	status, err = GetCommitQueueBatchTestStatus("v1")
+	s.NoError(err)
+	s.Equal(commitqueue.BisectionStatusPassed, status)
+
+	s.NoError(task.UpdateOne(task.ById("t1"), bson.M{"$set": bson.M{task.StatusKey: evergreen.TaskFailed}}))
+	status, err = GetCommitQueueBatchTestStatus("v1")
+	s.NoError(err)
+	s.Equal(commitqueue.BisectionStatusFailed, status)
+}
+
+func (s *CommitQueueSuite) TestSetMergeTaskTestDependencies() {
+	s.NoError(db.ClearCollections(task.Collection))
+	tasks := []task.Task{
+		{Id: "merge1", Version: "v1", CommitQueueMerge: true},
+		{Id: "test1", Version: "v1"},
+		{Id: "test2", Version: "v2"},
+		{
+			Id:               "merge2",
+			Version:          "v2",
+			CommitQueueMerge: true,
+			DependsOn: []task.Dependency{
+				{TaskId: "merge1", Status: task.AllStatuses},
+				{TaskId: "test2", Status: evergreen.TaskSucceeded, Unattainable: true},
+			},
+		},
+	}
+	for _, t := range tasks {
+		s.NoError(t.Insert())
+	}
+
+	mergeTask, err := task.FindOneId("merge2")
+	s.NoError(err)
+	s.Require().NotNil(mergeTask)
+	s.NoError(setMergeTaskTestDependencies(mergeTask, []string{"test1"}))
+
+	mergeTask, err = task.FindOneId("merge2")
+	s.NoError(err)
+	s.Require().NotNil(mergeTask)
+	s.ElementsMatch([]task.Dependency{
+		{TaskId: "merge1", Status: task.AllStatuses},
+		{TaskId: "test1", Status: evergreen.TaskSucceeded},
+	}, mergeTask.DependsOn)
+}
@@ -0,0 +1,188 @@
+package commitqueue
+
+import (
+	"time"
+
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/pkg/errors"
+)
+
+// Bisection statuses describe what is known about an item that is being
+// tested in a batch. When a batch fails, the failed items are split in half
+// and the first half is tested on its own until the item that caused the
+// failure is found.
+const (
+	// BisectionStatusTesting indicates that the item is in the batch that is
+	// currently being tested.
+	BisectionStatusTesting = "testing"
+	// BisectionStatusPassed indicates that the item passed in a batch, so it
+	// can be merged.
+	BisectionStatusPassed = "passed"
+	// BisectionStatusFailed indicates that the item was in a batch that failed,
+	// so it may have caused the failure.
+	BisectionStatusFailed = "failed"
+	// BisectionStatusPending indicates that the item needs to be retested
+	// because the items before it were found to cause the failure of the last
+	// batch that it was in.
+	BisectionStatusPending = "pending"
+)
+
+// BisectionStep is a test of a batch of commit queue items that included the
+// item.
+type BisectionStep struct {
+	// Version is the ID of the version that tested the batch, which is the
+	// version of the last item in the batch. Since the version of each item
+	// includes the changes from the items before it in the queue, it tests
+	// the whole batch.
+	Version string `bson:"version"`
+	// Items are the issues of the items in the batch.
+	Items []string `bson:"items"`
+	// Status is the result of the test, which is either testing, passed or
+	// failed.
+	Status     string    `bson:"status"`
+	StartTime  time.Time `bson:"start_time"`
+	FinishTime time.Time `bson:"finish_time,omitempty"`
+}
+
+func (s *BisectionStep) MarshalBSON() ([]byte, error)  { return mgobson.Marshal(s) }
+func (s *BisectionStep) UnmarshalBSON(in []byte) error { return mgobson.Unmarshal(in, s) }
+
+// IsBisecting returns whether the item is being tested in a batch with other
+// items.
+func (i *CommitQueueItem) IsBisecting() bool {
+	return i.BisectionStatus != ""
+}
+
+// BatchItems returns the items that are being tested in a batch, in the order
+// that they are in the queue.
+func (q *CommitQueue) BatchItems() []CommitQueueItem {
+	items := []CommitQueueItem{}
+	for _, item := range q.Queue {
+		if item.Version != "" && item.IsBisecting() {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// BatchTestVersion returns the ID of the version that is testing the current
+// batch, if any.
+func (q *CommitQueue) BatchTestVersion() string {
+	for _, item := range q.BatchItems() {
+		if item.BisectionStatus == BisectionStatusTesting && len(item.BisectionHistory) > 0 {
+			return item.BisectionHistory[len(item.BisectionHistory)-1].Version
+		}
+	}
+	return ""
+}
+
+// StartBatchTest marks the items with the given issues as being tested
+// together by the version of the last item and records the test in their
+// bisection history.
+func (q *CommitQueue) StartBatchTest(issues []string) error {
+	if len(issues) == 0 {
+		return errors.New("cannot test an empty batch")
+	}
+	lastIndex := q.FindItem(issues[len(issues)-1])
+	if lastIndex < 0 {
+		return errors.Errorf("item '%s' not found in queue", issues[len(issues)-1])
+	}
+	step := BisectionStep{
+		Version:   q.Queue[lastIndex].Version,
+		Items:     issues,
+		Status:    BisectionStatusTesting,
+		StartTime: time.Now(),
+	}
+	if step.Version == "" {
+		return errors.Errorf("item '%s' does not have a version to test the batch", issues[len(issues)-1])
+	}
+
+	for _, issue := range issues {
+		index := q.FindItem(issue)
+		if index < 0 {
+			return errors.Errorf("item '%s' not found in queue", issue)
+		}
+		q.Queue[index].BisectionStatus = BisectionStatusTesting
+		q.Queue[index].BisectionHistory = append(q.Queue[index].BisectionHistory, step)
+		if err := setBisection(q.ProjectID, q.Queue[index]); err != nil {
+			return errors.Wrapf(err, "updating bisection for item '%s'", issue)
+		}
+	}
+	return nil
+}
+
+// FinishBatchTest records the result of the batch that is currently being
+// tested.
+func (q *CommitQueue) FinishBatchTest(passed bool) error {
+	for _, index := range finishBatchTest(q.Queue, passed, time.Now()) {
+		if err := setBisection(q.ProjectID, q.Queue[index]); err != nil {
+			return errors.Wrapf(err, "updating bisection for item '%s'", q.Queue[index].Issue)
+		}
+	}
+	return nil
+}
+
+// finishBatchTest records the result of the batch test in the items and
+// returns the indexes of the items that were modified. If the batch passed,
+// its items can be merged. If it failed, the items that previously failed but
+// were not in the batch have to be retested, because the failures they were
+// in may have been caused by the items in this batch.
+func finishBatchTest(items []CommitQueueItem, passed bool, finishTime time.Time) []int {
+	status := BisectionStatusFailed
+	if passed {
+		status = BisectionStatusPassed
+	}
+
+	var modified []int
+	for i := range items {
+		switch items[i].BisectionStatus {
+		case BisectionStatusTesting:
+			items[i].BisectionStatus = status
+			if len(items[i].BisectionHistory) > 0 {
+				step := &items[i].BisectionHistory[len(items[i].BisectionHistory)-1]
+				step.Status = status
+				step.FinishTime = finishTime
+			}
+		case BisectionStatusFailed:
+			if passed {
+				continue
+			}
+			items[i].BisectionStatus = BisectionStatusPending
+		default:
+			continue
+		}
+		modified = append(modified, i)
+	}
+	return modified
+}
+
+// PlanBisection returns the item that caused the current batch to fail, if
+// it's been found, and the issues of the items that should be tested next. If
+// the failure was narrowed down to multiple items, the first half of them is
+// tested next.
+func (q *CommitQueue) PlanBisection() (culprit string, next []string) {
+	return planBisection(q.BatchItems())
+}
+
+func planBisection(items []CommitQueueItem) (string, []string) {
+	var failed, pending []string
+	for _, item := range items {
+		switch item.BisectionStatus {
+		case BisectionStatusTesting:
+			return "", nil
+		case BisectionStatusFailed:
+			failed = append(failed, item.Issue)
+		case BisectionStatusPending:
+			pending = append(pending, item.Issue)
+		}
+	}
+
+	switch {
+	case len(failed) == 1:
+		return failed[0], pending
+	case len(failed) > 1:
+		return "", failed[:len(failed)/2]
+	default:
+		return "", pending
+	}
+}
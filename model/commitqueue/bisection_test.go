@@ -0,0 +1,121 @@
+package commitqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeBisectionItems(statuses ...string) []CommitQueueItem {
+	issues := []string{"a", "b", "c", "d", "e", "f"}
+	items := []CommitQueueItem{}
+	for i, status := range statuses {
+		item := CommitQueueItem{
+			Issue:           issues[i],
+			Version:         "v_" + issues[i],
+			BisectionStatus: status,
+		}
+		if status != "" {
+			item.BisectionHistory = []BisectionStep{{Status: BisectionStatusTesting}}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func TestFinishBatchTest(t *testing.T) {
+	finishTime := time.Now()
+
+	t.Run("PassedBatchPassesItsItems", func(t *testing.T) {
+		items := makeBisectionItems(BisectionStatusTesting, BisectionStatusTesting, BisectionStatusFailed)
+		modified := finishBatchTest(items, true, finishTime)
+		assert.Equal(t, []int{0, 1}, modified)
+		for _, item := range items[:2] {
+			assert.Equal(t, BisectionStatusPassed, item.BisectionStatus)
+			require.Len(t, item.BisectionHistory, 1)
+			assert.Equal(t, BisectionStatusPassed, item.BisectionHistory[0].Status)
+			assert.Equal(t, finishTime, item.BisectionHistory[0].FinishTime)
+		}
+		assert.Equal(t, BisectionStatusFailed, items[2].BisectionStatus)
+	})
+	t.Run("FailedBatchRetestsPreviouslyFailedItems", func(t *testing.T) {
+		items := makeBisectionItems(BisectionStatusPassed, BisectionStatusTesting, BisectionStatusFailed)
+		modified := finishBatchTest(items, false, finishTime)
+		assert.Equal(t, []int{1, 2}, modified)
+		assert.Equal(t, BisectionStatusPassed, items[0].BisectionStatus)
+		assert.Equal(t, BisectionStatusFailed, items[1].BisectionStatus)
+		assert.Equal(t, BisectionStatusFailed, items[1].BisectionHistory[0].Status)
+		assert.Equal(t, BisectionStatusPending, items[2].BisectionStatus)
+	})
+	t.Run("IgnoresItemsNotBeingBisected", func(t *testing.T) {
+		items := makeBisectionItems("", "")
+		assert.Empty(t, finishBatchTest(items, false, finishTime))
+	})
+}
+
+func TestPlanBisection(t *testing.T) {
+	t.Run("WaitsForRunningTest", func(t *testing.T) {
+		culprit, next := planBisection(makeBisectionItems(BisectionStatusFailed, BisectionStatusTesting))
+		assert.Empty(t, culprit)
+		assert.Empty(t, next)
+	})
+	t.Run("TestsFirstHalfOfFailedItems", func(t *testing.T) {
+		culprit, next := planBisection(makeBisectionItems(BisectionStatusFailed, BisectionStatusFailed, BisectionStatusFailed, BisectionStatusFailed, BisectionStatusFailed))
+		assert.Empty(t, culprit)
+		assert.Equal(t, []string{"a", "b"}, next)
+	})
+	t.Run("FindsSingleFailedItem", func(t *testing.T) {
+		culprit, next := planBisection(makeBisectionItems(BisectionStatusPassed, BisectionStatusFailed, BisectionStatusPending, BisectionStatusPending))
+		assert.Equal(t, "b", culprit)
+		assert.Equal(t, []string{"c", "d"}, next)
+	})
+	t.Run("RetestsPendingItemsWithoutFailures", func(t *testing.T) {
+		culprit, next := planBisection(makeBisectionItems(BisectionStatusPassed, BisectionStatusPending))
+		assert.Empty(t, culprit)
+		assert.Equal(t, []string{"b"}, next)
+	})
+	t.Run("DoesNothingOnceAllItemsPassed", func(t *testing.T) {
+		culprit, next := planBisection(makeBisectionItems(BisectionStatusPassed, BisectionStatusPassed))
+		assert.Empty(t, culprit)
+		assert.Empty(t, next)
+	})
+}
+
+func TestBisectionOfFailedBatch(t *testing.T) {
+	// The batch a-d fails because of c.
+	items := makeBisectionItems(BisectionStatusTesting, BisectionStatusTesting, BisectionStatusTesting, BisectionStatusTesting)
+	culprit := "c"
+	runTest := func(next []string) {
+		passed := true
+		for _, issue := range next {
+			if issue == culprit {
+				passed = false
+			}
+		}
+		for i := range items {
+			for _, issue := range next {
+				if items[i].Issue == issue {
+					items[i].BisectionStatus = BisectionStatusTesting
+				}
+			}
+		}
+		finishBatchTest(items, passed, time.Now())
+	}
+
+	runTest([]string{"a", "b", "c", "d"})
+	found, next := planBisection(items)
+	assert.Empty(t, found)
+	assert.Equal(t, []string{"a", "b"}, next)
+
+	runTest(next)
+	found, next = planBisection(items)
+	assert.Empty(t, found)
+	assert.Equal(t, []string{"c"}, next)
+
+	runTest(next)
+	found, next = planBisection(items)
+	assert.Equal(t, culprit, found)
+	assert.Equal(t, []string{"d"}, next)
+}
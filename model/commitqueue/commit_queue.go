@@ -36,6 +36,14 @@ type CommitQueueItem struct {
 	// QueueLengthAtEnqueue is the length of the queue when the item was enqueued. Used for tracking the speed of the
 	// commit queue as this value is logged when a commit queue item is processed.
 	QueueLengthAtEnqueue int `bson:"queue_length_at_enqueue"`
+	// Lane is the name of the lane that the item is processed in, which is
+	// the shared lane if its changes are not all in the paths of one lane.
+	Lane string `bson:"lane,omitempty"`
+	// BisectionStatus is what is known about the item if it's being tested
+	// in a batch with other items.
+	BisectionStatus string `bson:"bisection_status,omitempty"`
+	// BisectionHistory are the tests of the batches that included the item.
+	BisectionHistory []BisectionStep `bson:"bisection_history,omitempty"`
 }
 
 func (i *CommitQueueItem) MarshalBSON() ([]byte, error)  { return mgobson.Marshal(i) }
@@ -83,12 +91,12 @@ func (q *CommitQueue) EnqueueAtFront(item CommitQueueItem) (int, error) {
 		return position, errors.New("item already in queue")
 	}
 
+	// Items in different lanes are processed independently, so the
+	// processing items are not necessarily all at the front of the queue.
 	newPos := 0
 	for i, item := range q.Queue {
 		if item.Version != "" {
 			newPos = i + 1
-		} else {
-			break
 		}
 	}
 	item.EnqueueTime = time.Now()
@@ -146,6 +154,17 @@ func (q *CommitQueue) Processing() bool {
 	return false
 }
 
+// NumProcessing returns the number of items that are being processed.
+func (q *CommitQueue) NumProcessing() int {
+	num := 0
+	for _, item := range q.Queue {
+		if item.Version != "" {
+			num++
+		}
+	}
+	return num
+}
+
 func (q *CommitQueue) Remove(issue string) (*CommitQueueItem, error) {
 	itemIndex := q.FindItem(issue)
 	if itemIndex < 0 {
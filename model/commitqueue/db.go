@@ -21,6 +21,8 @@ var (
 	EnqueueTimeKey          = bsonutil.MustHaveTag(CommitQueueItem{}, "EnqueueTime")
 	ProcessingStartTimeKey  = bsonutil.MustHaveTag(CommitQueueItem{}, "ProcessingStartTime")
 	QueueLengthAtEnqueueKey = bsonutil.MustHaveTag(CommitQueueItem{}, "QueueLengthAtEnqueue")
+	BisectionStatusKey      = bsonutil.MustHaveTag(CommitQueueItem{}, "BisectionStatus")
+	BisectionHistoryKey     = bsonutil.MustHaveTag(CommitQueueItem{}, "BisectionHistory")
 )
 
 func updateOne(query interface{}, update interface{}) error {
@@ -106,6 +108,20 @@ func addVersionAndTime(id string, item CommitQueueItem) error {
 		})
 }
 
+func setBisection(id string, item CommitQueueItem) error {
+	return updateOne(
+		bson.M{
+			IdKey: id,
+			bsonutil.GetDottedKeyName(QueueKey, IssueKey): item.Issue,
+		},
+		bson.M{
+			"$set": bson.M{
+				bsonutil.GetDottedKeyName(QueueKey, "$", BisectionStatusKey):  item.BisectionStatus,
+				bsonutil.GetDottedKeyName(QueueKey, "$", BisectionHistoryKey): item.BisectionHistory,
+			},
+		})
+}
+
 // remove removes a given item from a project's commit queue. Make sure to pass the actual
 // issue identifier and not the patch or version
 func remove(project, issue string) error {
@@ -0,0 +1,75 @@
+package commitqueue
+
+// SharedLane is the lane of the items whose changes are not all in the paths
+// of a single lane. An item in the shared lane is only processed once all the
+// items before it have been merged, and the items after it wait for it to be
+// merged, so it's tested against all the changes before it.
+const SharedLane = ""
+
+// ActiveLanes returns the lanes that can be processed, each as a queue of the
+// lane's items in order. If the first item is in the shared lane, only the
+// shared items at the front of the queue can be processed. Otherwise, the
+// items before the first shared item are processed independently in their own
+// lanes.
+func (q *CommitQueue) ActiveLanes() []CommitQueue {
+	if len(q.Queue) == 0 {
+		return nil
+	}
+
+	if q.Queue[0].Lane == SharedLane {
+		shared := CommitQueue{ProjectID: q.ProjectID}
+		for _, item := range q.Queue {
+			if item.Lane != SharedLane {
+				break
+			}
+			shared.Queue = append(shared.Queue, item)
+		}
+		return []CommitQueue{shared}
+	}
+
+	lanes := []CommitQueue{}
+	laneIndexes := map[string]int{}
+	for _, item := range q.Queue {
+		if item.Lane == SharedLane {
+			break
+		}
+		i, ok := laneIndexes[item.Lane]
+		if !ok {
+			i = len(lanes)
+			laneIndexes[item.Lane] = i
+			lanes = append(lanes, CommitQueue{ProjectID: q.ProjectID})
+		}
+		lanes[i].Queue = append(lanes[i].Queue, item)
+	}
+	return lanes
+}
+
+// ActiveLane returns the active lane with the given name. The returned queue
+// is empty if the lane has no items that can be processed.
+func (q *CommitQueue) ActiveLane(name string) CommitQueue {
+	for _, lane := range q.ActiveLanes() {
+		if len(lane.Queue) > 0 && lane.Queue[0].Lane == name {
+			return lane
+		}
+	}
+	return CommitQueue{ProjectID: q.ProjectID}
+}
+
+// LaneForItem returns a queue of the items in the same lane as the item
+// matching the issue, in order. Since items are only tested against the
+// changes of the items before them in their lane, functions that look at the
+// items before or after an item should only consider its lane. It returns the
+// whole queue if the item is not found.
+func (q *CommitQueue) LaneForItem(issue string) *CommitQueue {
+	index := q.FindItem(issue)
+	if index < 0 {
+		return q
+	}
+	lane := &CommitQueue{ProjectID: q.ProjectID}
+	for _, item := range q.Queue {
+		if item.Lane == q.Queue[index].Lane {
+			lane.Queue = append(lane.Queue, item)
+		}
+	}
+	return lane
+}
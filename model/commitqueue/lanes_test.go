@@ -0,0 +1,74 @@
+package commitqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issuesInQueue(q CommitQueue) []string {
+	issues := []string{}
+	for _, item := range q.Queue {
+		issues = append(issues, item.Issue)
+	}
+	return issues
+}
+
+func TestActiveLanes(t *testing.T) {
+	t.Run("EmptyQueue", func(t *testing.T) {
+		q := CommitQueue{ProjectID: "mci"}
+		assert.Empty(t, q.ActiveLanes())
+	})
+	t.Run("SharedItemsAtFront", func(t *testing.T) {
+		q := CommitQueue{
+			ProjectID: "mci",
+			Queue: []CommitQueueItem{
+				{Issue: "1"},
+				{Issue: "2"},
+				{Issue: "3", Lane: "docs"},
+				{Issue: "4"},
+			},
+		}
+		lanes := q.ActiveLanes()
+		require.Len(t, lanes, 1)
+		assert.Equal(t, "mci", lanes[0].ProjectID)
+		assert.Equal(t, []string{"1", "2"}, issuesInQueue(lanes[0]))
+	})
+	t.Run("LaneItemsBeforeSharedItem", func(t *testing.T) {
+		q := CommitQueue{
+			ProjectID: "mci",
+			Queue: []CommitQueueItem{
+				{Issue: "1", Lane: "docs"},
+				{Issue: "2", Lane: "storage"},
+				{Issue: "3", Lane: "docs"},
+				{Issue: "4"},
+				{Issue: "5", Lane: "storage"},
+			},
+		}
+		lanes := q.ActiveLanes()
+		require.Len(t, lanes, 2)
+		assert.Equal(t, []string{"1", "3"}, issuesInQueue(lanes[0]))
+		assert.Equal(t, []string{"2"}, issuesInQueue(lanes[1]))
+
+		assert.Equal(t, []string{"2"}, issuesInQueue(q.ActiveLane("storage")))
+		assert.Empty(t, q.ActiveLane(SharedLane).Queue)
+	})
+}
+
+func TestLaneForItem(t *testing.T) {
+	q := CommitQueue{
+		ProjectID: "mci",
+		Queue: []CommitQueueItem{
+			{Issue: "1", Lane: "docs", Version: "v1"},
+			{Issue: "2", Lane: "storage"},
+			{Issue: "3", Lane: "docs"},
+			{Issue: "4"},
+		},
+	}
+
+	assert.Equal(t, []string{"1", "3"}, issuesInQueue(*q.LaneForItem("3")))
+	assert.Equal(t, []string{"1", "3"}, issuesInQueue(*q.LaneForItem("v1")))
+	assert.Equal(t, []string{"4"}, issuesInQueue(*q.LaneForItem("4")))
+	assert.Equal(t, []string{"1", "2", "3", "4"}, issuesInQueue(*q.LaneForItem("nonexistent")))
+}
@@ -254,10 +254,23 @@ type PlannerSettings struct {
 	ExpectedRuntimeFactor     int64         `bson:"expected_runtime_factor" json:"expected_runtime_factor" mapstructure:"expected_runtime_factor"`
 	GenerateTaskFactor        int64         `bson:"generate_task_factor" json:"generate_task_factor" mapstructure:"generate_task_factor"`
 	StepbackTaskFactor        int64         `bson:"stepback_task_factor" json:"stepback_task_factor" mapstructure:"stepback_task_factor"`
+	// FairShare enables weighting tasks so that each project in the distro
+	// stays near its share of the distro's recent host time.
+	FairShare       *bool          `bson:"fair_share" json:"fair_share" mapstructure:"fair_share,omitempty"`
+	FairShareFactor int64          `bson:"fair_share_factor" json:"fair_share_factor" mapstructure:"fair_share_factor"`
+	FairShareWindow time.Duration  `bson:"fair_share_window" json:"fair_share_window" mapstructure:"fair_share_window,omitempty"`
+	ProjectShares   []ProjectShare `bson:"project_shares,omitempty" json:"project_shares,omitempty" mapstructure:"project_shares,omitempty"`
 
 	maxDurationPerHost time.Duration
 }
 
+// ProjectShare is the fraction of a distro's host time that a project is
+// entitled to when fair share planning is enabled.
+type ProjectShare struct {
+	Project string  `bson:"project" json:"project" mapstructure:"project"`
+	Share   float64 `bson:"share" json:"share" mapstructure:"share"`
+}
+
 type DispatcherSettings struct {
 	Version string `bson:"version" json:"version" mapstructure:"version"`
 }
@@ -284,6 +297,11 @@ const (
 	CommunicationMethodLegacySSH = "legacy-ssh"
 	CommunicationMethodSSH       = "ssh"
 	CommunicationMethodRPC       = "rpc"
+
+	// DefaultFairShareWindow is how far back the host time used by each
+	// project is considered when fair share planning is enabled and no
+	// window is configured.
+	DefaultFairShareWindow = 24 * time.Hour
 )
 
 // validBootstrapMethods includes all recognized bootstrap methods.
@@ -360,6 +378,37 @@ func (s *PlannerSettings) GetExpectedRuntimeFactor() int64 {
 	return s.ExpectedRuntimeFactor
 }
 
+func (s *PlannerSettings) ShouldUseFairShare() bool {
+	return utility.FromBoolPtr(s.FairShare)
+}
+
+func (s *PlannerSettings) GetFairShareFactor() int64 {
+	if s.FairShareFactor <= 0 {
+		return 1
+	}
+	return s.FairShareFactor
+}
+
+// GetFairShareWindow returns how far back to look at the host time used by
+// each project when fair share planning is enabled.
+func (s *PlannerSettings) GetFairShareWindow() time.Duration {
+	if s.FairShareWindow <= 0 {
+		return DefaultFairShareWindow
+	}
+	return s.FairShareWindow
+}
+
+// GetProjectShare returns the configured share of the distro's host time
+// for the project and whether one is configured.
+func (s *PlannerSettings) GetProjectShare(project string) (float64, bool) {
+	for _, ps := range s.ProjectShares {
+		if ps.Project == project {
+			return ps.Share, true
+		}
+	}
+	return 0, false
+}
+
 // GenerateName generates a unique instance name for a host in a distro.
 func (d *Distro) GenerateName() string {
 	switch d.Provider {
@@ -501,6 +550,8 @@ func (d *Distro) GetImageID() (string, error) {
 		key = "image_name"
 	case evergreen.ProviderNameVsphere:
 		key = "template"
+	case evergreen.ProviderNameLibvirt:
+		key = "base_image"
 	case evergreen.ProviderNameMock, evergreen.ProviderNameStatic, evergreen.ProviderNameOpenstack:
 		return "", nil
 	default:
@@ -695,6 +746,10 @@ func (d *Distro) GetResolvedPlannerSettings(s *evergreen.Settings) (PlannerSetti
 		MainlineTimeInQueueFactor: ps.MainlineTimeInQueueFactor,
 		ExpectedRuntimeFactor:     ps.ExpectedRuntimeFactor,
 		GenerateTaskFactor:        ps.GenerateTaskFactor,
+		FairShare:                 ps.FairShare,
+		FairShareFactor:           ps.FairShareFactor,
+		FairShareWindow:           ps.FairShareWindow,
+		ProjectShares:             ps.ProjectShares,
 		maxDurationPerHost:        evergreen.MaxDurationPerDistroHost,
 	}
 
@@ -0,0 +1,104 @@
+// Package envelope implements envelope encryption: values are encrypted with
+// a data key, and the data key is in turn encrypted ("wrapped") with a key
+// encryption key managed by a KeyProvider, so that only the wrapped data key
+// needs to be stored alongside the encrypted values.
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DataKeySize is the size in bytes of data keys, which are AES-256 keys.
+	DataKeySize = 32
+
+	// nonceSize and tagSize are the sizes in bytes of the nonce and the
+	// authentication tag that AES-GCM adds to the plaintext.
+	nonceSize = 12
+	tagSize   = 16
+)
+
+// KeyProvider wraps and unwraps data keys with the key encryption keys that
+// it manages.
+type KeyProvider interface {
+	// Name returns the name of the provider.
+	Name() string
+	// WrapKey encrypts the data key with the provider's current key
+	// encryption key. It returns the ID of the key encryption key that was
+	// used and the wrapped data key.
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey decrypts a data key that was wrapped with the key
+	// encryption key with the given ID.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// GenerateDataKey returns a new random data key.
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Wrap(err, "generating random data key")
+	}
+	return key, nil
+}
+
+// Encrypt encrypts and authenticates the plaintext with the key using
+// AES-GCM. The additional data, which may be nil, is authenticated but not
+// encrypted, and must be passed to Decrypt to decrypt the ciphertext. The
+// random nonce is prepended to the returned ciphertext.
+func Encrypt(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "generating nonce")
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt decrypts ciphertext that was encrypted with the key and the
+// additional data by Encrypt.
+func Decrypt(key, ciphertext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting ciphertext")
+	}
+
+	return plaintext, nil
+}
+
+// CiphertextSize returns the size in bytes of the ciphertext that Encrypt
+// returns for a plaintext of the given size.
+func CiphertextSize(plaintextSize int) int {
+	return nonceSize + plaintextSize + tagSize
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating cipher")
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating GCM cipher")
+	}
+	return gcm, nil
+}
@@ -0,0 +1,114 @@
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, err := GenerateDataKey()
+	require.NoError(t, err)
+	require.Len(t, key, DataKeySize)
+
+	additionalData := []byte("project")
+	ciphertext, err := Encrypt(key, []byte("hunter2"), additionalData)
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "hunter2")
+	assert.Len(t, ciphertext, CiphertextSize(len("hunter2")))
+
+	again, err := Encrypt(key, []byte("hunter2"), additionalData)
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again, "each encryption should use a fresh nonce")
+
+	plaintext, err := Decrypt(key, ciphertext, additionalData)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", string(plaintext))
+
+	t.Run("WrongKey", func(t *testing.T) {
+		otherKey, err := GenerateDataKey()
+		require.NoError(t, err)
+		_, err = Decrypt(otherKey, ciphertext, additionalData)
+		assert.Error(t, err)
+	})
+	t.Run("WrongAdditionalData", func(t *testing.T) {
+		_, err := Decrypt(key, ciphertext, []byte("other_project"))
+		assert.Error(t, err)
+		_, err = Decrypt(key, ciphertext, nil)
+		assert.Error(t, err)
+	})
+	t.Run("Tampered", func(t *testing.T) {
+		tampered := append([]byte{}, ciphertext...)
+		tampered[len(tampered)-1] ^= 0xff
+		_, err := Decrypt(key, tampered, additionalData)
+		assert.Error(t, err)
+	})
+	t.Run("TooShort", func(t *testing.T) {
+		_, err := Decrypt(key, []byte("short"), additionalData)
+		assert.Error(t, err)
+	})
+}
+
+func TestLocalProvider(t *testing.T) {
+	ctx := context.Background()
+	oldKey, err := GenerateDataKey()
+	require.NoError(t, err)
+	newKey, err := GenerateDataKey()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keyring.yml")
+	contents := "primary: old\nkeys:\n  old: " + base64.StdEncoding.EncodeToString(oldKey) + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	provider, err := NewLocalProvider(path)
+	require.NoError(t, err)
+	assert.Equal(t, ProviderLocal, provider.Name())
+
+	dataKey, err := GenerateDataKey()
+	require.NoError(t, err)
+	keyID, wrapped, err := provider.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, "old", keyID)
+
+	unwrapped, err := provider.UnwrapKey(ctx, keyID, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	t.Run("RotatedPrimary", func(t *testing.T) {
+		rotated, err := NewKeyringProvider(Keyring{
+			Primary: "new",
+			Keys: map[string]string{
+				"old": base64.StdEncoding.EncodeToString(oldKey),
+				"new": base64.StdEncoding.EncodeToString(newKey),
+			},
+		})
+		require.NoError(t, err)
+
+		unwrapped, err := rotated.UnwrapKey(ctx, keyID, wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, dataKey, unwrapped, "keys wrapped with a previous primary should still unwrap")
+
+		newKeyID, _, err := rotated.WrapKey(ctx, dataKey)
+		require.NoError(t, err)
+		assert.Equal(t, "new", newKeyID)
+	})
+	t.Run("UnknownKey", func(t *testing.T) {
+		_, err := provider.UnwrapKey(ctx, "nonexistent", wrapped)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidKeyring", func(t *testing.T) {
+		_, err := NewKeyringProvider(Keyring{})
+		assert.Error(t, err, "keyring without a primary key should be invalid")
+
+		_, err = NewKeyringProvider(Keyring{Primary: "missing", Keys: map[string]string{"old": base64.StdEncoding.EncodeToString(oldKey)}})
+		assert.Error(t, err, "primary key must be in the keyring")
+
+		_, err = NewKeyringProvider(Keyring{Primary: "short", Keys: map[string]string{"short": base64.StdEncoding.EncodeToString([]byte("short"))}})
+		assert.Error(t, err, "keys must be the right size")
+	})
+}
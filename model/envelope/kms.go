@@ -0,0 +1,65 @@
+package envelope
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+// ProviderKMS is the name of the AWS KMS key provider.
+const ProviderKMS = "kms"
+
+type kmsProvider struct {
+	keyID  string
+	client *kms.KMS
+}
+
+// NewKMSProvider returns a key provider that wraps data keys with the AWS
+// KMS key with the given ID, ARN, or alias. Credentials are loaded from the
+// default AWS credential chain.
+func NewKMSProvider(keyID, region string) (KeyProvider, error) {
+	if keyID == "" {
+		return nil, errors.New("KMS key ID must be specified")
+	}
+
+	sess, err := session.NewSession(&aws.Config{Region: utility.ToStringPtr(region)})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating AWS session")
+	}
+
+	return &kmsProvider{
+		keyID:  keyID,
+		client: kms.New(sess),
+	}, nil
+}
+
+func (p *kmsProvider) Name() string { return ProviderKMS }
+
+func (p *kmsProvider) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	out, err := p.client.EncryptWithContext(ctx, &kms.EncryptInput{
+		KeyId:     utility.ToStringPtr(p.keyID),
+		Plaintext: dataKey,
+	})
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "wrapping data key with KMS key '%s'", p.keyID)
+	}
+
+	// KMS returns the ARN of the key that was used, which stays stable even
+	// if the provider is configured with an alias that is later repointed.
+	return utility.FromStringPtr(out.KeyId), out.CiphertextBlob, nil
+}
+
+func (p *kmsProvider) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	out, err := p.client.DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:          utility.ToStringPtr(keyID),
+		CiphertextBlob: wrapped,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unwrapping data key with KMS key '%s'", keyID)
+	}
+	return out.Plaintext, nil
+}
@@ -0,0 +1,93 @@
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ProviderLocal is the name of the local keyring key provider.
+const ProviderLocal = "local"
+
+// Keyring is a set of named key encryption keys, one of which is the primary
+// key that new data keys are wrapped with. Keys are base64-encoded AES-256
+// keys. Keys that are no longer primary should be kept in the keyring until
+// all data keys wrapped with them have been rewrapped.
+type Keyring struct {
+	Primary string            `yaml:"primary"`
+	Keys    map[string]string `yaml:"keys"`
+}
+
+type localProvider struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// NewLocalProvider returns a key provider that wraps data keys with the keys
+// in the keyring file at the given path. The file is a YAML-encoded Keyring.
+func NewLocalProvider(path string) (KeyProvider, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading keyring file '%s'", path)
+	}
+
+	keyring := Keyring{}
+	if err = yaml.Unmarshal(contents, &keyring); err != nil {
+		return nil, errors.Wrapf(err, "parsing keyring file '%s'", path)
+	}
+
+	return NewKeyringProvider(keyring)
+}
+
+// NewKeyringProvider returns a key provider that wraps data keys with the
+// keys in the keyring.
+func NewKeyringProvider(keyring Keyring) (KeyProvider, error) {
+	if keyring.Primary == "" {
+		return nil, errors.New("keyring must have a primary key")
+	}
+
+	p := &localProvider{
+		primary: keyring.Primary,
+		keys:    make(map[string][]byte, len(keyring.Keys)),
+	}
+	for id, encoded := range keyring.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decoding key '%s'", id)
+		}
+		if len(key) != DataKeySize {
+			return nil, errors.Errorf("key '%s' must be %d bytes, but is %d bytes", id, DataKeySize, len(key))
+		}
+		p.keys[id] = key
+	}
+	if _, ok := p.keys[p.primary]; !ok {
+		return nil, errors.Errorf("primary key '%s' is not in the keyring", p.primary)
+	}
+
+	return p, nil
+}
+
+func (p *localProvider) Name() string { return ProviderLocal }
+
+func (p *localProvider) WrapKey(_ context.Context, dataKey []byte) (string, []byte, error) {
+	wrapped, err := Encrypt(p.keys[p.primary], dataKey, nil)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "wrapping data key with key '%s'", p.primary)
+	}
+	return p.primary, wrapped, nil
+}
+
+func (p *localProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, errors.Errorf("key '%s' is not in the keyring", keyID)
+	}
+	dataKey, err := Decrypt(key, wrapped, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unwrapping data key with key '%s'", keyID)
+	}
+	return dataKey, nil
+}
@@ -1,7 +1,11 @@
 package event
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"text/template"
 
 	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/utility"
@@ -18,6 +22,7 @@ const (
 	EvergreenWebhookSubscriberType  = "evergreen-webhook"
 	EmailSubscriberType             = "email"
 	SlackSubscriberType             = "slack"
+	ChatWebhookSubscriberType       = "chat-webhook"
 	EnqueuePatchSubscriberType      = "enqueue-patch"
 	SubscriberTypeNone              = "none"
 	RunChildPatchSubscriberType     = "run-child-patch"
@@ -35,6 +40,7 @@ var SubscriberTypes = []string{
 	EvergreenWebhookSubscriberType,
 	EmailSubscriberType,
 	SlackSubscriberType,
+	ChatWebhookSubscriberType,
 	EnqueuePatchSubscriberType,
 	RunChildPatchSubscriberType,
 }
@@ -78,6 +84,8 @@ func (s *Subscriber) SetBSON(raw mgobson.Raw) error {
 		s.Target = &WebhookSubscriber{}
 	case JIRAIssueSubscriberType:
 		s.Target = &JIRAIssueSubscriber{}
+	case ChatWebhookSubscriberType:
+		s.Target = &ChatWebhookSubscriber{}
 	case JIRACommentSubscriberType, EmailSubscriberType, SlackSubscriberType:
 		str := ""
 		s.Target = &str
@@ -124,6 +132,10 @@ func (s *Subscriber) Validate() error {
 		catcher.Add(v.validate())
 	case *WebhookSubscriber:
 		catcher.Add(v.validate())
+	case ChatWebhookSubscriber:
+		catcher.Add(v.validate())
+	case *ChatWebhookSubscriber:
+		catcher.Add(v.validate())
 	}
 
 	return catcher.Resolve()
@@ -136,6 +148,49 @@ type WebhookSubscriber struct {
 	MinDelayMS int             `bson:"min_delay_ms"`
 	TimeoutMS  int             `bson:"timeout_ms"`
 	Headers    []WebhookHeader `bson:"headers"`
+	// PayloadTemplate, if set, is a Go text/template that shapes the body
+	// posted to the URL. It's executed against the default JSON payload,
+	// decoded so that its fields are accessed by their JSON names (e.g.
+	// {{.display_name}}). The "json" function encodes a value as JSON.
+	PayloadTemplate string `bson:"payload_template,omitempty"`
+}
+
+var webhookPayloadTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		out, err := json.Marshal(v)
+		return string(out), err
+	},
+}
+
+func (s *WebhookSubscriber) parsePayloadTemplate() (*template.Template, error) {
+	return template.New("webhook-payload").Funcs(webhookPayloadTemplateFuncs).Parse(s.PayloadTemplate)
+}
+
+// RenderPayload returns the body to post for the given default JSON payload.
+// If the subscriber has no payload template, the payload is returned
+// unchanged.
+func (s *WebhookSubscriber) RenderPayload(payload []byte) ([]byte, error) {
+	if s.PayloadTemplate == "" {
+		return payload, nil
+	}
+
+	tmpl, err := s.parsePayloadTemplate()
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing payload template")
+	}
+
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err = decoder.Decode(&data); err != nil {
+		return nil, errors.Wrap(err, "decoding JSON payload")
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, data); err != nil {
+		return nil, errors.Wrap(err, "executing payload template")
+	}
+	return buf.Bytes(), nil
 }
 
 type WebhookHeader struct {
@@ -169,6 +224,55 @@ func (s *WebhookSubscriber) validate() error {
 		catcher.AddWhen(header.Value == "", errors.New("header value cannot be empty"))
 	}
 
+	if s.PayloadTemplate != "" {
+		_, err := s.parsePayloadTemplate()
+		catcher.Wrap(err, "invalid payload template")
+	}
+
+	return catcher.Resolve()
+}
+
+const (
+	// ChatWebhookFormatAdaptiveCard posts messages as Adaptive Cards, which
+	// Microsoft Teams incoming webhooks accept.
+	ChatWebhookFormatAdaptiveCard = "adaptive-card"
+	// ChatWebhookFormatMarkdown posts messages as a JSON object with a single
+	// Markdown "text" field, which Mattermost, Rocket.Chat and most other
+	// Slack-compatible incoming webhooks accept.
+	ChatWebhookFormatMarkdown = "markdown"
+)
+
+var ChatWebhookFormats = []string{
+	ChatWebhookFormatAdaptiveCard,
+	ChatWebhookFormatMarkdown,
+}
+
+// ChatWebhookSubscriber posts messages to a chat service through an incoming
+// webhook URL.
+type ChatWebhookSubscriber struct {
+	URL    string `bson:"url"`
+	Format string `bson:"format"`
+}
+
+func (s *ChatWebhookSubscriber) String() string {
+	if len(s.URL) == 0 {
+		return "NIL_URL"
+	}
+	return s.URL
+}
+
+func (s *ChatWebhookSubscriber) validate() error {
+	catcher := grip.NewBasicCatcher()
+	if s.URL == "" {
+		catcher.New("url cannot be empty")
+	} else if u, err := url.Parse(s.URL); err != nil {
+		catcher.Wrap(err, "invalid url")
+	} else {
+		catcher.ErrorfWhen(u.Scheme != "http" && u.Scheme != "https", "url scheme must be http or https")
+		catcher.NewWhen(u.Host == "", "url must include a host")
+	}
+	catcher.ErrorfWhen(!utility.StringSliceContains(ChatWebhookFormats, s.Format), "'%s' is not a valid chat webhook format", s.Format)
+
 	return catcher.Resolve()
 }
 
@@ -245,6 +349,13 @@ func NewEmailSubscriber(t string) Subscriber {
 	}
 }
 
+func NewChatWebhookSubscriber(s ChatWebhookSubscriber) Subscriber {
+	return Subscriber{
+		Type:   ChatWebhookSubscriberType,
+		Target: s,
+	}
+}
+
 func NewSlackSubscriber(t string) Subscriber {
 	return Subscriber{
 		Type:   SlackSubscriberType,
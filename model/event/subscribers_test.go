@@ -48,10 +48,17 @@ func TestSubscribers(t *testing.T) {
 			Type:   JIRACommentSubscriberType,
 			Target: &targetTicket,
 		},
+		{
+			Type: ChatWebhookSubscriberType,
+			Target: &ChatWebhookSubscriber{
+				URL:    "https://chat.example.com/hooks/abc",
+				Format: ChatWebhookFormatMarkdown,
+			},
+		},
 	}
 	expected := []string{"github_pull_request-evergreen-ci-evergreen-9001-sadasdkjsad-",
 		"evergreen-webhook-https://example.com", "email-hi@example.com",
-		"jira-issue-BF-Fail", "jira-comment-BF-1234"}
+		"jira-issue-BF-Fail", "jira-comment-BF-1234", "chat-webhook-https://chat.example.com/hooks/abc"}
 	for i := range subs {
 		assert.NoError(db.Insert(SubscriptionsCollection, subs[i]))
 		assert.Equal(expected[i], subs[i].String())
@@ -60,7 +67,7 @@ func TestSubscribers(t *testing.T) {
 	fetchedSubs := []Subscriber{}
 	assert.NoError(db.FindAllQ(SubscriptionsCollection, db.Q{}, &fetchedSubs))
 
-	assert.Len(fetchedSubs, 5)
+	assert.Len(fetchedSubs, 6)
 
 	for i := range subs {
 		assert.Contains(fetchedSubs, subs[i])
@@ -161,6 +168,65 @@ func TestValidate(t *testing.T) {
 			},
 			errorExpected: false,
 		},
+		"WebhookInvalidPayloadTemplate": {
+			s: Subscriber{
+				Type: EvergreenWebhookSubscriberType,
+				Target: WebhookSubscriber{
+					URL:             "https://evergreen.mongodb.com",
+					Secret:          []byte("shh"),
+					PayloadTemplate: `{"text": "{{.status"}`,
+				},
+			},
+			errorExpected: true,
+		},
+		"ValidWebhookWithPayloadTemplate": {
+			s: Subscriber{
+				Type: EvergreenWebhookSubscriberType,
+				Target: WebhookSubscriber{
+					URL:             "https://evergreen.mongodb.com",
+					Secret:          []byte("shh"),
+					PayloadTemplate: `{"text": {{json .status}}}`,
+				},
+			},
+			errorExpected: false,
+		},
+		"ValidChatWebhook": {
+			s: Subscriber{
+				Type: ChatWebhookSubscriberType,
+				Target: &ChatWebhookSubscriber{
+					URL:    "https://example.webhook.office.com/webhookb2/abc",
+					Format: ChatWebhookFormatAdaptiveCard,
+				},
+			},
+			errorExpected: false,
+		},
+		"ChatWebhookMissingURL": {
+			s: Subscriber{
+				Type:   ChatWebhookSubscriberType,
+				Target: ChatWebhookSubscriber{Format: ChatWebhookFormatMarkdown},
+			},
+			errorExpected: true,
+		},
+		"ChatWebhookNonHTTPURL": {
+			s: Subscriber{
+				Type: ChatWebhookSubscriberType,
+				Target: ChatWebhookSubscriber{
+					URL:    "ftp://chat.example.com/hooks/abc",
+					Format: ChatWebhookFormatMarkdown,
+				},
+			},
+			errorExpected: true,
+		},
+		"ChatWebhookInvalidFormat": {
+			s: Subscriber{
+				Type: ChatWebhookSubscriberType,
+				Target: ChatWebhookSubscriber{
+					URL:    "https://chat.example.com/hooks/abc",
+					Format: "html",
+				},
+			},
+			errorExpected: true,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			if testCase.errorExpected {
@@ -171,3 +237,33 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookSubscriberRenderPayload(t *testing.T) {
+	payload := []byte(`{"display_name": "compile \"all\"", "status": "failed", "execution": 2, "details": {"timed_out": true}}`)
+
+	t.Run("NoTemplate", func(t *testing.T) {
+		sub := WebhookSubscriber{}
+		body, err := sub.RenderPayload(payload)
+		require.NoError(t, err)
+		assert.Equal(t, payload, body)
+	})
+	t.Run("Template", func(t *testing.T) {
+		sub := WebhookSubscriber{
+			PayloadTemplate: `{"text": {{json (printf "%s %s (execution %s)" .display_name .status .execution)}}, "timed_out": {{.details.timed_out}}}`,
+		}
+		body, err := sub.RenderPayload(payload)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"text": "compile \"all\" failed (execution 2)", "timed_out": true}`, string(body))
+	})
+	t.Run("MissingField", func(t *testing.T) {
+		sub := WebhookSubscriber{PayloadTemplate: `{{.nonexistent}}`}
+		body, err := sub.RenderPayload(payload)
+		require.NoError(t, err)
+		assert.Equal(t, "<no value>", string(body))
+	})
+	t.Run("InvalidPayload", func(t *testing.T) {
+		sub := WebhookSubscriber{PayloadTemplate: `{{.status}}`}
+		_, err := sub.RenderPayload([]byte("not json"))
+		assert.Error(t, err)
+	})
+}
@@ -35,6 +35,7 @@ var (
 	subscriptionOwnerTypeKey      = bsonutil.MustHaveTag(Subscription{}, "OwnerType")
 	subscriptionTriggerDataKey    = bsonutil.MustHaveTag(Subscription{}, "TriggerData")
 	subscriptionLastUpdatedKey    = bsonutil.MustHaveTag(Subscription{}, "LastUpdated")
+	subscriptionDigestIntervalKey = bsonutil.MustHaveTag(Subscription{}, "DigestIntervalMinutes")
 
 	filterObjectKey       = bsonutil.MustHaveTag(Filter{}, "Object")
 	filterIDKey           = bsonutil.MustHaveTag(Filter{}, "ID")
@@ -60,6 +61,8 @@ const (
 	VersionDurationKey                               = "version-duration-secs"
 	VersionPercentChangeKey                          = "version-percent-change"
 	TestRegexKey                                     = "test-regex"
+	CommandMemoryPercentChangeKey                    = "command-memory-percent-change"
+	CommandDurationPercentChangeKey                  = "command-duration-percent-change"
 	RenotifyIntervalKey                              = "renotify-interval"
 	GeneralSubscriptionPatchOutcome                  = "patch-outcome"
 	GeneralSubscriptionPatchFirstFailure             = "patch-first-failure"
@@ -92,6 +95,24 @@ const (
 	TriggerTaskStarted               = "task-started"
 )
 
+const (
+	// MinDigestIntervalMinutes and MaxDigestIntervalMinutes bound how long
+	// a digest subscription accumulates notifications, from 5 minutes to
+	// daily.
+	MinDigestIntervalMinutes = 5
+	MaxDigestIntervalMinutes = 24 * 60
+)
+
+// DigestSubscriberTypes are the subscriber types that can receive
+// notifications as a digest.
+var DigestSubscriberTypes = []string{
+	EmailSubscriberType,
+	SlackSubscriberType,
+	ChatWebhookSubscriberType,
+	JIRACommentSubscriberType,
+	EvergreenWebhookSubscriberType,
+}
+
 type Subscription struct {
 	ID             string            `bson:"_id"`
 	ResourceType   string            `bson:"type"`
@@ -104,6 +125,10 @@ type Subscription struct {
 	Owner          string            `bson:"owner"`
 	TriggerData    map[string]string `bson:"trigger_data,omitempty"`
 	LastUpdated    time.Time         `bson:"last_updated,omitempty"`
+	// DigestIntervalMinutes, if set, accumulates the subscription's
+	// notifications for the given number of minutes and sends them as a
+	// single digest at the end of each interval.
+	DigestIntervalMinutes int `bson:"digest_interval_minutes,omitempty"`
 }
 
 type unmarshalSubscription struct {
@@ -117,6 +142,8 @@ type unmarshalSubscription struct {
 	OwnerType      OwnerType         `bson:"owner_type"`
 	Owner          string            `bson:"owner"`
 	TriggerData    map[string]string `bson:"trigger_data,omitempty"`
+
+	DigestIntervalMinutes int `bson:"digest_interval_minutes,omitempty"`
 }
 
 func (d *Subscription) UnmarshalBSON(in []byte) error {
@@ -140,6 +167,7 @@ func (s *Subscription) SetBSON(raw mgobson.Raw) error {
 	s.Owner = temp.Owner
 	s.OwnerType = temp.OwnerType
 	s.TriggerData = temp.TriggerData
+	s.DigestIntervalMinutes = temp.DigestIntervalMinutes
 
 	return nil
 }
@@ -470,6 +498,7 @@ func (s *Subscription) Upsert() error {
 		subscriptionOwnerKey:          s.Owner,
 		subscriptionOwnerTypeKey:      s.OwnerType,
 		subscriptionTriggerDataKey:    s.TriggerData,
+		subscriptionDigestIntervalKey: s.DigestIntervalMinutes,
 	}
 	if !utility.IsZeroTime(s.LastUpdated) {
 		update[subscriptionLastUpdatedKey] = s.LastUpdated
@@ -579,12 +608,33 @@ func (s *Subscription) Validate() error {
 		s.Subscriber.Type == JIRACommentSubscriberType {
 		catcher.New("JIRA comment subscription not allowed for all tasks in the project")
 	}
+	catcher.Add(s.validateDigest())
 	catcher.Add(s.ValidateSelectors())
 	catcher.Add(s.runCustomValidation())
 	catcher.Add(s.Subscriber.Validate())
 	return catcher.Resolve()
 }
 
+// DigestInterval returns the interval over which the subscription's
+// notifications are accumulated into a digest, or zero if the subscription
+// doesn't use digests.
+func (s *Subscription) DigestInterval() time.Duration {
+	return time.Duration(s.DigestIntervalMinutes) * time.Minute
+}
+
+func (s *Subscription) validateDigest() error {
+	if s.DigestIntervalMinutes == 0 {
+		return nil
+	}
+
+	catcher := grip.NewBasicCatcher()
+	catcher.ErrorfWhen(s.DigestIntervalMinutes < MinDigestIntervalMinutes || s.DigestIntervalMinutes > MaxDigestIntervalMinutes,
+		"digest interval must be between %d and %d minutes", MinDigestIntervalMinutes, MaxDigestIntervalMinutes)
+	catcher.ErrorfWhen(!utility.StringSliceContains(DigestSubscriberTypes, s.Subscriber.Type),
+		"subscriber type '%s' does not support digests", s.Subscriber.Type)
+	return catcher.Resolve()
+}
+
 func (s *Subscription) runCustomValidation() error {
 	catcher := grip.NewBasicCatcher()
 
@@ -606,6 +656,12 @@ func (s *Subscription) runCustomValidation() error {
 	if buildPercentVal, ok := s.TriggerData[BuildPercentChangeKey]; ok {
 		catcher.Wrap(validatePositiveFloat(buildPercentVal), "invalid build percentage runtime change")
 	}
+	if commandMemoryPercentVal, ok := s.TriggerData[CommandMemoryPercentChangeKey]; ok {
+		catcher.Wrap(validatePositiveFloat(commandMemoryPercentVal), "invalid command percentage memory change")
+	}
+	if commandDurationPercentVal, ok := s.TriggerData[CommandDurationPercentChangeKey]; ok {
+		catcher.Wrap(validatePositiveFloat(commandDurationPercentVal), "invalid command percentage duration change")
+	}
 	if testRegex, ok := s.TriggerData[TestRegexKey]; ok {
 		catcher.Wrap(validateRegex(testRegex), "invalid test regex")
 	}
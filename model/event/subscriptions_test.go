@@ -477,6 +477,24 @@ func (s *subscriptionsSuite) TestValidateSelectors() {
 	s.Error(noFilterParams.ValidateSelectors())
 }
 
+func (s *subscriptionsSuite) TestValidateDigest() {
+	sub := s.subscriptions[0]
+	s.NoError(sub.validateDigest(), "subscriptions without a digest interval should be valid")
+
+	sub.DigestIntervalMinutes = 60
+	s.NoError(sub.validateDigest())
+	s.Equal(time.Hour, sub.DigestInterval())
+
+	sub.DigestIntervalMinutes = MinDigestIntervalMinutes - 1
+	s.Error(sub.validateDigest())
+	sub.DigestIntervalMinutes = MaxDigestIntervalMinutes + 1
+	s.Error(sub.validateDigest())
+
+	sub.DigestIntervalMinutes = 60
+	sub.Subscriber = Subscriber{Type: GithubPullRequestSubscriberType}
+	s.Error(sub.validateDigest(), "GitHub status subscribers should not support digests")
+}
+
 func (s *subscriptionsSuite) TestFromSelectors() {
 	s.Run("NoType", func() {
 		f := Filter{}
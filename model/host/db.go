@@ -23,8 +23,9 @@ import (
 
 const (
 	// Collection is the name of the MongoDB collection that stores hosts.
-	Collection        = "hosts"
-	VolumesCollection = "volumes"
+	Collection          = "hosts"
+	VolumesCollection   = "volumes"
+	SnapshotsCollection = "snapshots"
 )
 
 var (
@@ -97,6 +98,11 @@ var (
 	SpawnOptionsBuildIDKey             = bsonutil.MustHaveTag(SpawnOptions{}, "BuildID")
 	SpawnOptionsTimeoutKey             = bsonutil.MustHaveTag(SpawnOptions{}, "TimeoutTeardown")
 	SpawnOptionsSpawnedByTaskKey       = bsonutil.MustHaveTag(SpawnOptions{}, "SpawnedByTask")
+	SpawnOptionsHostPoolKey            = bsonutil.MustHaveTag(SpawnOptions{}, "HostPool")
+	SpawnOptionsVersionIDKey           = bsonutil.MustHaveTag(SpawnOptions{}, "VersionID")
+	SpawnOptionsLeasedByTaskIDKey      = bsonutil.MustHaveTag(SpawnOptions{}, "LeasedByTaskID")
+	SpawnOptionsLeasedByTaskExecKey    = bsonutil.MustHaveTag(SpawnOptions{}, "LeasedByTaskExecution")
+	SpawnOptionsPoolSlotKey            = bsonutil.MustHaveTag(SpawnOptions{}, "PoolSlot")
 	VolumeIDKey                        = bsonutil.MustHaveTag(Volume{}, "ID")
 	VolumeDisplayNameKey               = bsonutil.MustHaveTag(Volume{}, "DisplayName")
 	VolumeCreatedByKey                 = bsonutil.MustHaveTag(Volume{}, "CreatedBy")
@@ -312,6 +318,10 @@ func AllHostsSpawnedByTasksToTerminate() ([]Host, error) {
 	hosts = append(hosts, buildHosts...)
 	catcher.Wrap(err, "finding hosts whose builds have finished")
 
+	poolHosts, err := allPoolHostsOfFinishedVersions()
+	hosts = append(hosts, poolHosts...)
+	catcher.Wrap(err, "finding pool hosts whose versions have finished")
+
 	if catcher.HasErrors() {
 		return nil, catcher.Resolve()
 	}
@@ -155,6 +155,10 @@ type Host struct {
 	// HomeVolumeSize is the size of the home volume in GB
 	HomeVolumeSize int    `bson:"home_volume_size" json:"home_volume_size"`
 	HomeVolumeID   string `bson:"home_volume_id" json:"home_volume_id"`
+	// HomeVolumeSnapshotID is the ID of the provider's volume snapshot that
+	// the home volume is created from when the host is restored from a spawn
+	// host snapshot.
+	HomeVolumeSnapshotID string `bson:"home_volume_snapshot_id,omitempty" json:"home_volume_snapshot_id,omitempty"`
 }
 
 type Tag struct {
@@ -318,6 +322,29 @@ type SpawnOptions struct {
 
 	// SpawnedByTask indicates that this host has been spawned by a task.
 	SpawnedByTask bool `bson:"spawned_by_task,omitempty" json:"spawned_by_task,omitempty"`
+
+	// HostPool is the name of the project's host pool that this host belongs
+	// to. Hosts in a pool are leased to tasks in the version in VersionID one
+	// at a time, and are torn down when the version finishes.
+	HostPool string `bson:"host_pool,omitempty" json:"host_pool,omitempty"`
+
+	// VersionID is the version that provisioned the host pool.
+	VersionID string `bson:"version_id,omitempty" json:"version_id,omitempty"`
+
+	// LeasedByTaskID is the task that this pool host is leased to. It is
+	// unset when the host is free.
+	LeasedByTaskID string `bson:"leased_by_task_id,omitempty" json:"leased_by_task_id,omitempty"`
+
+	// LeasedByTaskExecution is the execution number of the task that this pool
+	// host is leased to. Like TaskExecutionNumber, this field is deliberately
+	// NOT omitempty in order to support the aggregation in
+	// FindPoolHostsLeasedByFinishedTasks().
+	LeasedByTaskExecution int `bson:"leased_by_task_execution" json:"leased_by_task_execution"`
+
+	// PoolSlot is the 1-based slot in its host pool that this host occupies.
+	// No two hosts in a pool can occupy the same slot, which limits the
+	// number of hosts in the pool to its size.
+	PoolSlot int `bson:"pool_slot,omitempty" json:"pool_slot,omitempty"`
 }
 
 type newParentsNeededParams struct {
@@ -33,6 +33,7 @@ type CreateOptions struct {
 	IsCluster             bool
 	HomeVolumeSize        int
 	HomeVolumeID          string
+	HomeVolumeSnapshotID  string
 }
 
 // NewIntent creates an intent host using the given host settings. An intent host is a host that
@@ -69,6 +70,7 @@ func NewIntent(options CreateOptions) *Host {
 		IsVirtualWorkstation:  options.IsVirtualWorkstation,
 		HomeVolumeSize:        options.HomeVolumeSize,
 		HomeVolumeID:          options.HomeVolumeID,
+		HomeVolumeSnapshotID:  options.HomeVolumeSnapshotID,
 		NoExpiration:          options.NoExpiration,
 		ExpirationTime:        options.ExpirationTime,
 		ProvisionOptions:      options.ProvisionOptions,
@@ -91,6 +93,7 @@ func (h *Host) GetCreateOptions() CreateOptions {
 		IsVirtualWorkstation:  h.IsVirtualWorkstation,
 		HomeVolumeSize:        h.HomeVolumeSize,
 		HomeVolumeID:          h.HomeVolumeID,
+		HomeVolumeSnapshotID:  h.HomeVolumeSnapshotID,
 		NoExpiration:          h.NoExpiration,
 		ExpirationTime:        h.ExpirationTime,
 		ProvisionOptions:      h.ProvisionOptions,
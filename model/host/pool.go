@@ -0,0 +1,260 @@
+package host
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PoolSlotIndex is the unique index that prevents two hosts from occupying
+// the same slot in a host pool. It only applies to hosts with a slot.
+var PoolSlotIndex = mongo.IndexModel{
+	Keys: bson.D{
+		{Key: bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsVersionIDKey), Value: 1},
+		{Key: bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsHostPoolKey), Value: 1},
+		{Key: bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsPoolSlotKey), Value: 1},
+	},
+	Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+		bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsPoolSlotKey): bson.M{"$exists": true},
+	}),
+}
+
+// EnsurePoolIndexes creates the indexes for host pools if they don't already
+// exist.
+func EnsurePoolIndexes(ctx context.Context, env evergreen.Environment) error {
+	_, err := env.DB().Collection(Collection).Indexes().CreateOne(ctx, PoolSlotIndex)
+	return errors.Wrap(err, "creating host pool slot index")
+}
+
+// byPool returns a query that finds the hosts in the version's host pool that
+// have not been torn down.
+func byPool(versionID, pool string) bson.M {
+	return bson.M{
+		StatusKey: bson.M{"$in": evergreen.UpHostStatus},
+		bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsVersionIDKey): versionID,
+		bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsHostPoolKey):  pool,
+	}
+}
+
+// InsertPoolHost inserts the intent host into a free slot of its version's
+// host pool, which has the given size. A slot is free if no host occupies it
+// or if the host occupying it has been torn down. It returns false without
+// inserting the host if the pool is full. Reserving the slot and inserting
+// the host is a single atomic operation, so concurrent callers cannot exceed
+// the pool's size.
+func InsertPoolHost(h *Host, size int) (bool, error) {
+	versionID, pool := h.SpawnOptions.VersionID, h.SpawnOptions.HostPool
+	for slot := 1; slot <= size; slot++ {
+		inserted, err := insertPoolHostInSlot(h, slot)
+		if err != nil {
+			return false, err
+		}
+		if inserted {
+			return true, nil
+		}
+
+		freed, err := freePoolSlot(versionID, pool, slot)
+		if err != nil {
+			return false, err
+		}
+		if !freed {
+			continue
+		}
+		inserted, err = insertPoolHostInSlot(h, slot)
+		if err != nil {
+			return false, err
+		}
+		if inserted {
+			return true, nil
+		}
+	}
+
+	h.SpawnOptions.PoolSlot = 0
+	return false, nil
+}
+
+// insertPoolHostInSlot inserts the host into the given pool slot. It returns
+// false if the slot is already occupied.
+func insertPoolHostInSlot(h *Host, slot int) (bool, error) {
+	h.SpawnOptions.PoolSlot = slot
+	err := h.Insert()
+	if db.IsDuplicateKey(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "inserting host '%s' into slot %d of host pool '%s'", h.Id, slot, h.SpawnOptions.HostPool)
+	}
+	return true, nil
+}
+
+// freePoolSlot frees the pool slot if the host occupying it has been torn
+// down. It returns whether the slot was freed.
+func freePoolSlot(versionID, pool string, slot int) (bool, error) {
+	err := UpdateOne(
+		bson.M{
+			StatusKey: bson.M{"$nin": evergreen.UpHostStatus},
+			bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsVersionIDKey): versionID,
+			bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsHostPoolKey):  pool,
+			bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsPoolSlotKey):  slot,
+		},
+		bson.M{
+			"$unset": bson.M{bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsPoolSlotKey): 1},
+		},
+	)
+	if adb.ResultsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "freeing slot %d of host pool '%s' for version '%s'", slot, pool, versionID)
+	}
+	return true, nil
+}
+
+// LeaseFreePoolHost leases a host in the version's host pool that is not
+// leased to any task to the given task execution. The host's teardown timeout
+// is extended to the given time, so that a pool host that is reused by many
+// tasks isn't torn down for outliving the task that created it. It returns nil
+// if the pool has no free hosts.
+func LeaseFreePoolHost(versionID, pool, taskID string, execution int, timeoutTeardown time.Time) (*Host, error) {
+	query := byPool(versionID, pool)
+	query[bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskIDKey)] = bson.M{"$exists": false}
+
+	h := &Host{}
+	_, err := db.FindAndModify(Collection, query, []string{CreateTimeKey}, adb.Change{
+		Update: bson.M{
+			"$set": bson.M{
+				bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskIDKey):   taskID,
+				bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskExecKey): execution,
+				bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsTimeoutKey):          timeoutTeardown,
+			},
+		},
+		ReturnNew: true,
+	}, h)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "leasing host from pool '%s' for version '%s'", pool, versionID)
+	}
+	return h, nil
+}
+
+// ReleasePoolHost returns the pool host to its pool if it is still leased to
+// the given task execution, so that it can be leased to another task.
+func ReleasePoolHost(hostID, taskID string, execution int) error {
+	err := UpdateOne(
+		bson.M{
+			IdKey: hostID,
+			bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskIDKey):   taskID,
+			bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskExecKey): execution,
+		},
+		bson.M{
+			"$unset": bson.M{
+				bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskIDKey): 1,
+			},
+			"$set": bson.M{
+				bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskExecKey): 0,
+			},
+		},
+	)
+	if adb.ResultsNotFound(err) {
+		return nil
+	}
+	return errors.Wrapf(err, "releasing pool host '%s' from task '%s' execution %d", hostID, taskID, execution)
+}
+
+// FindHostsLeasedByTask finds running pool hosts that are leased to the given
+// task execution.
+func FindHostsLeasedByTask(taskID string, execution int) ([]Host, error) {
+	query := db.Query(bson.M{
+		StatusKey: evergreen.HostRunning,
+		bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskIDKey):   taskID,
+		bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskExecKey): execution,
+	})
+	hosts, err := Find(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding hosts leased by task '%s' for execution %d", taskID, execution)
+	}
+	return hosts, nil
+}
+
+// FindPoolHostsLeasedByFinishedTasks finds pool hosts that should be reset and
+// returned to their pool because the task they're leased to has finished.
+func FindPoolHostsLeasedByFinishedTasks() ([]Host, error) {
+	const leasingTasks = "leasing_tasks"
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			StatusKey: bson.M{"$in": evergreen.UpHostStatus},
+			bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskIDKey): bson.M{"$exists": true}}},
+		{"$lookup": bson.M{
+			"from":         task.Collection,
+			"localField":   bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskIDKey),
+			"foreignField": task.IdKey,
+			"as":           leasingTasks,
+		}},
+		{"$unwind": "$" + leasingTasks},
+		{"$match": bson.M{
+			"$or": []bson.M{
+				{
+					bsonutil.GetDottedKeyName(leasingTasks, task.StatusKey): bson.M{"$in": evergreen.TaskCompletedStatuses},
+				},
+				// If the task has been restarted since it leased the host,
+				// the lease belongs to an old execution.
+				{
+					"$expr": bson.M{
+						"$gt": []string{
+							"$" + bsonutil.GetDottedKeyName(leasingTasks, task.ExecutionKey),
+							"$" + bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsLeasedByTaskExecKey),
+						},
+					},
+				},
+			},
+		}},
+		{"$project": bson.M{leasingTasks: 0}},
+	}
+	var hosts []Host
+	if err := db.Aggregate(Collection, pipeline, &hosts); err != nil {
+		return nil, errors.Wrap(err, "finding pool hosts leased by finished tasks")
+	}
+	return hosts, nil
+}
+
+// allPoolHostsOfFinishedVersions finds pool hosts that should be terminated
+// because their version has no activated tasks left to run.
+func allPoolHostsOfFinishedVersions() ([]Host, error) {
+	const unfinishedTasks = "unfinished_tasks"
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			StatusKey: bson.M{"$in": evergreen.UpHostStatus},
+			bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsHostPoolKey): bson.M{"$exists": true}}},
+		{"$lookup": bson.M{
+			"from": task.Collection,
+			"let":  bson.M{"version": "$" + bsonutil.GetDottedKeyName(SpawnOptionsKey, SpawnOptionsVersionIDKey)},
+			"pipeline": []bson.M{
+				{"$match": bson.M{
+					"$expr":           bson.M{"$eq": []string{"$" + task.VersionKey, "$$version"}},
+					task.ActivatedKey: true,
+					task.StatusKey:    bson.M{"$nin": evergreen.TaskCompletedStatuses},
+				}},
+				{"$limit": 1},
+			},
+			"as": unfinishedTasks,
+		}},
+		{"$match": bson.M{unfinishedTasks: bson.M{"$size": 0}}},
+		{"$project": bson.M{unfinishedTasks: 0}},
+	}
+	var hosts []Host
+	if err := db.Aggregate(Collection, pipeline, &hosts); err != nil {
+		return nil, err
+	}
+	return hosts, nil
+}
@@ -0,0 +1,244 @@
+package host
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaseFreePoolHost(t *testing.T) {
+	require.NoError(t, db.ClearCollections(Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection))
+	}()
+
+	hosts := []Host{
+		{
+			Id:     "leased",
+			Status: evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{
+				HostPool:       "cluster",
+				VersionID:      "v1",
+				LeasedByTaskID: "t0",
+				SpawnedByTask:  true,
+			},
+		},
+		{
+			Id:     "free",
+			Status: evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{
+				HostPool:      "cluster",
+				VersionID:     "v1",
+				SpawnedByTask: true,
+			},
+		},
+		{
+			Id:     "other_version",
+			Status: evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{
+				HostPool:      "cluster",
+				VersionID:     "v2",
+				SpawnedByTask: true,
+			},
+		},
+		{
+			Id:     "terminated",
+			Status: evergreen.HostTerminated,
+			SpawnOptions: SpawnOptions{
+				HostPool:      "cluster",
+				VersionID:     "v1",
+				SpawnedByTask: true,
+			},
+		},
+	}
+	for _, h := range hosts {
+		require.NoError(t, h.Insert())
+	}
+
+	timeoutTeardown := time.Now().Add(time.Hour).Round(time.Millisecond)
+	h, err := LeaseFreePoolHost("v1", "cluster", "t1", 1, timeoutTeardown)
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	assert.Equal(t, "free", h.Id)
+	assert.Equal(t, "t1", h.SpawnOptions.LeasedByTaskID)
+	assert.Equal(t, 1, h.SpawnOptions.LeasedByTaskExecution)
+	assert.True(t, timeoutTeardown.Equal(h.SpawnOptions.TimeoutTeardown), "leasing should extend the host's teardown timeout")
+
+	h, err = LeaseFreePoolHost("v1", "cluster", "t2", 0, timeoutTeardown)
+	require.NoError(t, err)
+	assert.Nil(t, h, "pool should not have any free hosts")
+
+	leased, err := FindHostsLeasedByTask("t1", 1)
+	require.NoError(t, err)
+	require.Len(t, leased, 1)
+	assert.Equal(t, "free", leased[0].Id)
+
+	require.NoError(t, ReleasePoolHost("free", "t1", 0), "releasing with the wrong execution should no-op")
+	h, err = FindOneId("free")
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	assert.Equal(t, "t1", h.SpawnOptions.LeasedByTaskID)
+
+	require.NoError(t, ReleasePoolHost("free", "t1", 1))
+	h, err = LeaseFreePoolHost("v1", "cluster", "t2", 0, timeoutTeardown)
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	assert.Equal(t, "free", h.Id)
+	assert.Equal(t, "t2", h.SpawnOptions.LeasedByTaskID)
+}
+
+func TestInsertPoolHost(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	require.NoError(t, db.ClearCollections(Collection))
+	require.NoError(t, EnsurePoolIndexes(ctx, env))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection))
+	}()
+
+	makePoolHost := func(id, version string) *Host {
+		return &Host{
+			Id:           id,
+			Status:       evergreen.HostUninitialized,
+			SpawnOptions: SpawnOptions{HostPool: "cluster", VersionID: version, SpawnedByTask: true},
+		}
+	}
+
+	h0 := makePoolHost("h0", "v1")
+	inserted, err := InsertPoolHost(h0, 2)
+	require.NoError(t, err)
+	require.True(t, inserted)
+	assert.Equal(t, 1, h0.SpawnOptions.PoolSlot)
+
+	h1 := makePoolHost("h1", "v1")
+	inserted, err = InsertPoolHost(h1, 2)
+	require.NoError(t, err)
+	require.True(t, inserted)
+	assert.Equal(t, 2, h1.SpawnOptions.PoolSlot)
+
+	h2 := makePoolHost("h2", "v1")
+	inserted, err = InsertPoolHost(h2, 2)
+	require.NoError(t, err)
+	assert.False(t, inserted, "full pool should not accept another host")
+	dbHost, err := FindOneId("h2")
+	require.NoError(t, err)
+	assert.Nil(t, dbHost)
+
+	otherVersion := makePoolHost("other_version", "v2")
+	inserted, err = InsertPoolHost(otherVersion, 2)
+	require.NoError(t, err)
+	require.True(t, inserted, "pools of different versions should not share slots")
+	assert.Equal(t, 1, otherVersion.SpawnOptions.PoolSlot)
+
+	require.NoError(t, h0.SetStatus(evergreen.HostTerminated, evergreen.User, ""))
+	h3 := makePoolHost("h3", "v1")
+	inserted, err = InsertPoolHost(h3, 2)
+	require.NoError(t, err)
+	require.True(t, inserted, "slot of terminated host should be reused")
+	assert.Equal(t, 1, h3.SpawnOptions.PoolSlot)
+
+	dbHost, err = FindOneId("h0")
+	require.NoError(t, err)
+	require.NotNil(t, dbHost)
+	assert.Zero(t, dbHost.SpawnOptions.PoolSlot, "terminated host should give up its slot")
+}
+
+func TestFindPoolHostsLeasedByFinishedTasks(t *testing.T) {
+	require.NoError(t, db.ClearCollections(Collection, task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection, task.Collection))
+	}()
+
+	tasks := []task.Task{
+		{Id: "running", Status: evergreen.TaskStarted},
+		{Id: "finished", Status: evergreen.TaskSucceeded},
+		{Id: "restarted", Status: evergreen.TaskStarted, Execution: 1},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, tsk.Insert())
+	}
+	hosts := []Host{
+		{
+			Id:           "leased_by_running",
+			Status:       evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{HostPool: "cluster", VersionID: "v1", LeasedByTaskID: "running"},
+		},
+		{
+			Id:           "leased_by_finished",
+			Status:       evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{HostPool: "cluster", VersionID: "v1", LeasedByTaskID: "finished"},
+		},
+		{
+			Id:           "leased_by_old_execution",
+			Status:       evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{HostPool: "cluster", VersionID: "v1", LeasedByTaskID: "restarted"},
+		},
+		{
+			Id:           "free",
+			Status:       evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{HostPool: "cluster", VersionID: "v1"},
+		},
+	}
+	for _, h := range hosts {
+		require.NoError(t, h.Insert())
+	}
+
+	found, err := FindPoolHostsLeasedByFinishedTasks()
+	require.NoError(t, err)
+	ids := []string{}
+	for _, h := range found {
+		ids = append(ids, h.Id)
+	}
+	assert.ElementsMatch(t, []string{"leased_by_finished", "leased_by_old_execution"}, ids)
+}
+
+func TestAllPoolHostsOfFinishedVersions(t *testing.T) {
+	require.NoError(t, db.ClearCollections(Collection, task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection, task.Collection))
+	}()
+
+	tasks := []task.Task{
+		{Id: "t1", Version: "running_version", Activated: true, Status: evergreen.TaskStarted},
+		{Id: "t2", Version: "running_version", Activated: true, Status: evergreen.TaskSucceeded},
+		{Id: "t3", Version: "finished_version", Activated: true, Status: evergreen.TaskFailed},
+		{Id: "t4", Version: "finished_version", Activated: false, Status: evergreen.TaskUndispatched},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, tsk.Insert())
+	}
+	hosts := []Host{
+		{
+			Id:           "running_version_host",
+			Status:       evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{HostPool: "cluster", VersionID: "running_version", SpawnedByTask: true},
+		},
+		{
+			Id:           "finished_version_host",
+			Status:       evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{HostPool: "cluster", VersionID: "finished_version", SpawnedByTask: true},
+		},
+		{
+			Id:           "not_pool_host",
+			Status:       evergreen.HostRunning,
+			SpawnOptions: SpawnOptions{TaskID: "t1", SpawnedByTask: true},
+		},
+	}
+	for _, h := range hosts {
+		require.NoError(t, h.Insert())
+	}
+
+	found, err := allPoolHostsOfFinishedVersions()
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "finished_version_host", found[0].Id)
+}
@@ -0,0 +1,134 @@
+package host
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// SnapshotStatusCreating indicates that the snapshot's resources are
+	// still being created by the provider.
+	SnapshotStatusCreating = "creating"
+	// SnapshotStatusAvailable indicates that the snapshot can be used to
+	// restore a spawn host.
+	SnapshotStatusAvailable = "available"
+	// SnapshotStatusFailed indicates that the snapshot could not be created.
+	SnapshotStatusFailed = "failed"
+)
+
+// Snapshot is a saved copy of a spawn host's state that can be used to
+// restore the host on a new instance.
+type Snapshot struct {
+	ID               string `bson:"_id" json:"id"`
+	DisplayName      string `bson:"display_name" json:"display_name"`
+	CreatedBy        string `bson:"created_by" json:"created_by"`
+	SourceHostID     string `bson:"source_host_id" json:"source_host_id"`
+	DistroID         string `bson:"distro_id" json:"distro_id"`
+	Provider         string `bson:"provider" json:"provider"`
+	Region           string `bson:"region,omitempty" json:"region,omitempty"`
+	AvailabilityZone string `bson:"availability_zone,omitempty" json:"availability_zone,omitempty"`
+	InstanceType     string `bson:"instance_type,omitempty" json:"instance_type,omitempty"`
+	// ParentID is the ID of the parent host that holds the snapshot's image
+	// for container hosts.
+	ParentID             string `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	IsVirtualWorkstation bool   `bson:"is_virtual_workstation" json:"is_virtual_workstation"`
+	// ImageID is the provider's ID for the image of the host (e.g. the AMI for
+	// EC2 or the committed image for Docker).
+	ImageID string `bson:"image_id,omitempty" json:"image_id,omitempty"`
+	// HomeVolumeSnapshotID is the provider's ID for the snapshot of the host's
+	// home volume, if the host has one.
+	HomeVolumeSnapshotID string    `bson:"home_volume_snapshot_id,omitempty" json:"home_volume_snapshot_id,omitempty"`
+	HomeVolumeSize       int       `bson:"home_volume_size,omitempty" json:"home_volume_size,omitempty"`
+	Status               string    `bson:"status" json:"status"`
+	CreationTime         time.Time `bson:"creation_time" json:"creation_time"`
+	Expiration           time.Time `bson:"expiration" json:"expiration"`
+}
+
+var (
+	SnapshotIDKey                   = bsonutil.MustHaveTag(Snapshot{}, "ID")
+	SnapshotCreatedByKey            = bsonutil.MustHaveTag(Snapshot{}, "CreatedBy")
+	SnapshotImageIDKey              = bsonutil.MustHaveTag(Snapshot{}, "ImageID")
+	SnapshotHomeVolumeSnapshotIDKey = bsonutil.MustHaveTag(Snapshot{}, "HomeVolumeSnapshotID")
+	SnapshotStatusKey               = bsonutil.MustHaveTag(Snapshot{}, "Status")
+	SnapshotCreationTimeKey         = bsonutil.MustHaveTag(Snapshot{}, "CreationTime")
+	SnapshotExpirationKey           = bsonutil.MustHaveTag(Snapshot{}, "Expiration")
+)
+
+// Insert inserts the snapshot into the snapshots collection.
+func (s *Snapshot) Insert() error {
+	s.CreationTime = time.Now()
+	return db.Insert(SnapshotsCollection, s)
+}
+
+// Remove removes the snapshot from the snapshots collection. This does not
+// delete the snapshot's resources in the provider.
+func (s *Snapshot) Remove() error {
+	return db.Remove(SnapshotsCollection, bson.M{SnapshotIDKey: s.ID})
+}
+
+// SetStatus sets the snapshot's status.
+func (s *Snapshot) SetStatus(status string) error {
+	if err := db.UpdateId(SnapshotsCollection, s.ID, bson.M{"$set": bson.M{SnapshotStatusKey: status}}); err != nil {
+		return errors.WithStack(err)
+	}
+	s.Status = status
+	return nil
+}
+
+// SetProviderResources records the IDs of the resources that the provider
+// created for the snapshot, so that they can be restored from or cleaned up.
+func (s *Snapshot) SetProviderResources(imageID, homeVolumeSnapshotID string) error {
+	err := db.UpdateId(SnapshotsCollection, s.ID, bson.M{"$set": bson.M{
+		SnapshotImageIDKey:              imageID,
+		SnapshotHomeVolumeSnapshotIDKey: homeVolumeSnapshotID,
+	}})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	s.ImageID = imageID
+	s.HomeVolumeSnapshotID = homeVolumeSnapshotID
+	return nil
+}
+
+// FindSnapshotByID finds a snapshot by its ID.
+func FindSnapshotByID(id string) (*Snapshot, error) {
+	s := &Snapshot{}
+	err := db.FindOneQ(SnapshotsCollection, db.Query(bson.M{SnapshotIDKey: id}), s)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	return s, err
+}
+
+// FindSnapshotsByUser finds all snapshots created by the given user, sorted
+// from newest to oldest.
+func FindSnapshotsByUser(userID string) ([]Snapshot, error) {
+	snapshots := []Snapshot{}
+	q := db.Query(bson.M{SnapshotCreatedByKey: userID}).Sort([]string{"-" + SnapshotCreationTimeKey})
+	return snapshots, db.FindAllQ(SnapshotsCollection, q, &snapshots)
+}
+
+// CountSnapshotsForUser counts the snapshots that count towards the user's
+// snapshot quota. Failed snapshots are not counted.
+func CountSnapshotsForUser(userID string) (int, error) {
+	return db.Count(SnapshotsCollection, bson.M{
+		SnapshotCreatedByKey: userID,
+		SnapshotStatusKey:    bson.M{"$ne": SnapshotStatusFailed},
+	})
+}
+
+// FindSnapshotsToDelete finds snapshots that expired before the given time
+// and snapshots that failed to be created.
+func FindSnapshotsToDelete(expirationTime time.Time) ([]Snapshot, error) {
+	snapshots := []Snapshot{}
+	q := db.Query(bson.M{"$or": []bson.M{
+		{SnapshotExpirationKey: bson.M{"$lte": expirationTime}},
+		{SnapshotStatusKey: SnapshotStatusFailed},
+	}})
+	return snapshots, db.FindAllQ(SnapshotsCollection, q, &snapshots)
+}
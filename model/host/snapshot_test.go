@@ -0,0 +1,78 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotsForUser(t *testing.T) {
+	require.NoError(t, db.ClearCollections(SnapshotsCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(SnapshotsCollection))
+	}()
+
+	snapshots := []Snapshot{
+		{ID: "s0", CreatedBy: "me", Status: SnapshotStatusAvailable},
+		{ID: "s1", CreatedBy: "me", Status: SnapshotStatusFailed},
+		{ID: "s2", CreatedBy: "me", Status: SnapshotStatusCreating},
+		{ID: "s3", CreatedBy: "you", Status: SnapshotStatusAvailable},
+	}
+	for _, s := range snapshots {
+		require.NoError(t, s.Insert())
+	}
+
+	count, err := CountSnapshotsForUser("me")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "failed snapshots should not count towards the quota")
+
+	found, err := FindSnapshotsByUser("me")
+	require.NoError(t, err)
+	assert.Len(t, found, 3)
+
+	s, err := FindSnapshotByID("s2")
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	require.NoError(t, s.SetProviderResources("image", "volume-snapshot"))
+	require.NoError(t, s.SetStatus(SnapshotStatusAvailable))
+
+	s, err = FindSnapshotByID("s2")
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	assert.Equal(t, "image", s.ImageID)
+	assert.Equal(t, "volume-snapshot", s.HomeVolumeSnapshotID)
+	assert.Equal(t, SnapshotStatusAvailable, s.Status)
+
+	require.NoError(t, s.Remove())
+	s, err = FindSnapshotByID("s2")
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestFindSnapshotsToDelete(t *testing.T) {
+	require.NoError(t, db.ClearCollections(SnapshotsCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(SnapshotsCollection))
+	}()
+
+	now := time.Now()
+	snapshots := []Snapshot{
+		{ID: "expired", Status: SnapshotStatusAvailable, Expiration: now.Add(-time.Hour)},
+		{ID: "failed", Status: SnapshotStatusFailed, Expiration: now.Add(time.Hour)},
+		{ID: "unexpired", Status: SnapshotStatusAvailable, Expiration: now.Add(time.Hour)},
+	}
+	for _, s := range snapshots {
+		require.NoError(t, s.Insert())
+	}
+
+	toDelete, err := FindSnapshotsToDelete(now)
+	require.NoError(t, err)
+	ids := []string{}
+	for _, s := range toDelete {
+		ids = append(ids, s.ID)
+	}
+	assert.ElementsMatch(t, []string{"expired", "failed"}, ids)
+}
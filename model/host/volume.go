@@ -24,6 +24,9 @@ type Volume struct {
 	Host             string    `bson:"host,omitempty" json:"host"`
 	HomeVolume       bool      `bson:"home_volume" json:"home_volume"`
 	Migrating        bool      `bson:"migrating" json:"migrating"`
+	// SnapshotID is the ID of the provider's snapshot that the volume was
+	// created from, if any.
+	SnapshotID string `bson:"snapshot_id,omitempty" json:"snapshot_id,omitempty"`
 }
 
 // Insert a volume into the volumes collection.
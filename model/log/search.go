@@ -0,0 +1,101 @@
+package log
+
+import (
+	"regexp"
+)
+
+// SearchMatch is a log line that matched a search, along with the lines
+// surrounding it.
+type SearchMatch struct {
+	// LineNumber is the 1-based position of the line in the log.
+	LineNumber int
+	Line       LogLine
+	Before     []LogLine
+	After      []LogLine
+}
+
+// TaskSearchMatch is a log line that matched a search of a task execution's
+// logs.
+type TaskSearchMatch struct {
+	TaskOptions
+	DisplayName  string
+	BuildVariant string
+	SearchMatch
+}
+
+// Searcher finds the lines of a single log that match a regular expression.
+// Lines are added in order with Add, and the searcher stops accepting lines
+// once it has found its limit of matches and their trailing context.
+type Searcher struct {
+	pattern      *regexp.Regexp
+	contextLines int
+	limit        int
+	startAfter   int
+
+	lineNumber int
+	before     []LogLine
+	matches    []SearchMatch
+	// pending is the number of matches, at the end of matches, that are
+	// still waiting for trailing context.
+	pending int
+}
+
+// NewSearcher returns a searcher that returns at most limit matches for the
+// pattern, each with up to contextLines lines of context before and after it.
+// Lines at or before startAfter are not matched, so that a search can resume
+// where a previous one stopped, but are still used as context.
+func NewSearcher(pattern *regexp.Regexp, contextLines, limit, startAfter int) *Searcher {
+	return &Searcher{
+		pattern:      pattern,
+		contextLines: contextLines,
+		limit:        limit,
+		startAfter:   startAfter,
+	}
+}
+
+// Add searches the next line of the log. It returns false once the searcher
+// is done and no more lines need to be added.
+func (s *Searcher) Add(line LogLine) bool {
+	if s.Done() {
+		return false
+	}
+	s.lineNumber++
+
+	for i := len(s.matches) - s.pending; i < len(s.matches); i++ {
+		s.matches[i].After = append(s.matches[i].After, line)
+	}
+	if s.pending > 0 && len(s.matches[len(s.matches)-s.pending].After) == s.contextLines {
+		s.pending--
+	}
+
+	if s.lineNumber > s.startAfter && len(s.matches) < s.limit && s.pattern.MatchString(line.Data) {
+		s.matches = append(s.matches, SearchMatch{
+			LineNumber: s.lineNumber,
+			Line:       line,
+			Before:     append([]LogLine{}, s.before...),
+		})
+		if s.contextLines > 0 {
+			s.pending++
+		}
+	}
+
+	if s.contextLines > 0 {
+		if len(s.before) == s.contextLines {
+			s.before = s.before[1:]
+		}
+		s.before = append(s.before, line)
+	}
+
+	return !s.Done()
+}
+
+// Done returns whether the searcher has found its limit of matches and their
+// trailing context.
+func (s *Searcher) Done() bool {
+	return len(s.matches) >= s.limit && s.pending == 0
+}
+
+// Matches returns the matches found so far.
+func (s *Searcher) Matches() []SearchMatch {
+	return s.matches
+}
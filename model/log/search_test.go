@@ -0,0 +1,82 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchLines(s *Searcher, n int) int {
+	added := 0
+	for i := 1; i <= n; i++ {
+		added++
+		if !s.Add(LogLine{Data: fmt.Sprintf("line %d", i)}) {
+			break
+		}
+	}
+	return added
+}
+
+func lineData(lines []LogLine) []string {
+	data := []string{}
+	for _, line := range lines {
+		data = append(data, line.Data)
+	}
+	return data
+}
+
+func TestSearcher(t *testing.T) {
+	t.Run("MatchesWithContext", func(t *testing.T) {
+		s := NewSearcher(regexp.MustCompile(`^line (1|5|6)$`), 2, 10, 0)
+		assert.Equal(t, 10, searchLines(s, 10))
+
+		matches := s.Matches()
+		require.Len(t, matches, 3)
+		assert.Equal(t, 1, matches[0].LineNumber)
+		assert.Empty(t, matches[0].Before)
+		assert.Equal(t, []string{"line 2", "line 3"}, lineData(matches[0].After))
+
+		assert.Equal(t, 5, matches[1].LineNumber)
+		assert.Equal(t, "line 5", matches[1].Line.Data)
+		assert.Equal(t, []string{"line 3", "line 4"}, lineData(matches[1].Before))
+		assert.Equal(t, []string{"line 6", "line 7"}, lineData(matches[1].After))
+
+		assert.Equal(t, 6, matches[2].LineNumber)
+		assert.Equal(t, []string{"line 4", "line 5"}, lineData(matches[2].Before))
+		assert.Equal(t, []string{"line 7", "line 8"}, lineData(matches[2].After))
+	})
+	t.Run("StopsAtLimitAfterTrailingContext", func(t *testing.T) {
+		s := NewSearcher(regexp.MustCompile(`line`), 1, 2, 0)
+		assert.Equal(t, 3, searchLines(s, 10))
+		assert.True(t, s.Done())
+		matches := s.Matches()
+		require.Len(t, matches, 2)
+		assert.Equal(t, []string{"line 3"}, lineData(matches[1].After))
+		assert.False(t, s.Add(LogLine{Data: "line 4"}))
+	})
+	t.Run("NoContext", func(t *testing.T) {
+		s := NewSearcher(regexp.MustCompile(`line 2`), 0, 1, 0)
+		assert.Equal(t, 2, searchLines(s, 10))
+		require.Len(t, s.Matches(), 1)
+		assert.Empty(t, s.Matches()[0].Before)
+		assert.Empty(t, s.Matches()[0].After)
+	})
+	t.Run("TruncatedTrailingContext", func(t *testing.T) {
+		s := NewSearcher(regexp.MustCompile(`line 3`), 5, 10, 0)
+		searchLines(s, 4)
+		require.Len(t, s.Matches(), 1)
+		assert.Equal(t, []string{"line 1", "line 2"}, lineData(s.Matches()[0].Before))
+		assert.Equal(t, []string{"line 4"}, lineData(s.Matches()[0].After))
+	})
+	t.Run("ResumesAfterLine", func(t *testing.T) {
+		s := NewSearcher(regexp.MustCompile(`line`), 1, 1, 4)
+		searchLines(s, 10)
+		matches := s.Matches()
+		require.Len(t, matches, 1)
+		assert.Equal(t, 5, matches[0].LineNumber)
+		assert.Equal(t, []string{"line 4"}, lineData(matches[0].Before), "skipped lines should still be used as context")
+	})
+}
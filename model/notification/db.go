@@ -67,6 +67,9 @@ func (n *Notification) SetBSON(raw mgobson.Raw) error {
 	case event.SlackSubscriberType:
 		n.Payload = &SlackPayload{}
 
+	case event.ChatWebhookSubscriberType:
+		n.Payload = &ChatPayload{}
+
 	case event.GithubPullRequestSubscriberType, event.GithubCheckSubscriberType:
 		n.Payload = &message.GithubStatus{}
 
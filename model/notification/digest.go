@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	DigestEntriesCollection = "notification_digest_entries"
+)
+
+//nolint:megacheck,unused
+var (
+	digestEntryIDKey             = bsonutil.MustHaveTag(DigestEntry{}, "ID")
+	digestEntrySubscriptionIDKey = bsonutil.MustHaveTag(DigestEntry{}, "SubscriptionID")
+	digestEntryDueAtKey          = bsonutil.MustHaveTag(DigestEntry{}, "DueAt")
+	digestEntryAttemptsKey       = bsonutil.MustHaveTag(DigestEntry{}, "Attempts")
+)
+
+// DigestEntry is a notification that's waiting to be sent as part of a
+// digest. Rather than the notification's payload, it records the object the
+// event was about, so that the entries of a digest can be grouped and counted.
+type DigestEntry struct {
+	ID             string           `bson:"_id"`
+	SubscriptionID string           `bson:"subscription_id"`
+	Subscriber     event.Subscriber `bson:"subscriber"`
+	Trigger        string           `bson:"trigger"`
+	EventID        string           `bson:"event_id"`
+
+	Object       string `bson:"object"`
+	ResourceID   string `bson:"resource_id"`
+	DisplayName  string `bson:"display_name,omitempty"`
+	Status       string `bson:"status,omitempty"`
+	Project      string `bson:"project,omitempty"`
+	Version      string `bson:"version,omitempty"`
+	Build        string `bson:"build,omitempty"`
+	BuildVariant string `bson:"build_variant,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at"`
+	// DueAt is the end of the digest interval that the entry belongs to.
+	DueAt time.Time `bson:"due_at"`
+	// Attempts is the number of times that sending the digest containing
+	// the entry has failed.
+	Attempts int `bson:"attempts,omitempty"`
+}
+
+// NewDigestEntry creates a digest entry for an event matching the
+// subscription, due at the end of the subscription's current digest
+// interval.
+func NewDigestEntry(eventID string, sub *event.Subscription, attributes event.Attributes, now time.Time) DigestEntry {
+	entry := DigestEntry{
+		ID:             fmt.Sprintf("%s-%s", eventID, sub.ID),
+		SubscriptionID: sub.ID,
+		Subscriber:     sub.Subscriber,
+		Trigger:        sub.Trigger,
+		EventID:        eventID,
+		Object:         firstAttribute(attributes.Object),
+		ResourceID:     firstAttribute(attributes.ID),
+		DisplayName:    firstAttribute(attributes.DisplayName),
+		Status:         firstAttribute(attributes.Status),
+		Project:        firstAttribute(attributes.Project),
+		Version:        firstAttribute(attributes.InVersion),
+		Build:          firstAttribute(attributes.InBuild),
+		BuildVariant:   firstAttribute(attributes.BuildVariant),
+		CreatedAt:      now,
+		DueAt:          DigestDueAt(now, sub.DigestInterval()),
+	}
+
+	// Versions and builds are the groups that their own entries belong to.
+	switch entry.Object {
+	case event.ObjectVersion:
+		entry.Version = entry.ResourceID
+	case event.ObjectBuild:
+		entry.Build = entry.ResourceID
+	}
+
+	return entry
+}
+
+func firstAttribute(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// DigestDueAt returns the end of the digest interval that contains t.
+// Intervals are aligned to UTC, so daily digests are due at midnight UTC.
+func DigestDueAt(t time.Time, interval time.Duration) time.Time {
+	return t.UTC().Truncate(interval).Add(interval)
+}
+
+// InsertDigestEntries adds entries to their subscriptions' pending digests.
+// Entries that are already pending are ignored, so an event can be processed
+// more than once.
+func InsertDigestEntries(entries ...DigestEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(entries))
+	for i := range entries {
+		docs[i] = &entries[i]
+	}
+	err := db.InsertManyUnordered(DigestEntriesCollection, docs...)
+	if err != nil && !db.IsDuplicateKey(err) {
+		return errors.Wrap(err, "inserting digest entries")
+	}
+	return nil
+}
+
+// FindDueDigestEntries returns the pending digest entries whose interval has
+// ended by the given time.
+func FindDueDigestEntries(now time.Time) ([]DigestEntry, error) {
+	entries := []DigestEntry{}
+	q := db.Query(bson.M{digestEntryDueAtKey: bson.M{"$lte": now}}).
+		Sort([]string{digestEntrySubscriptionIDKey, digestEntryDueAtKey})
+	if err := db.FindAllQ(DigestEntriesCollection, q, &entries); err != nil {
+		return nil, errors.Wrap(err, "finding due digest entries")
+	}
+	return entries, nil
+}
+
+// RemoveDigestEntries removes digest entries once they've been sent.
+func RemoveDigestEntries(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return errors.Wrap(db.RemoveAll(DigestEntriesCollection, bson.M{digestEntryIDKey: bson.M{"$in": ids}}), "removing digest entries")
+}
+
+// IncDigestEntryAttempts records a failed attempt to send the digest
+// containing the entries.
+func IncDigestEntryAttempts(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := db.UpdateAll(
+		DigestEntriesCollection,
+		bson.M{digestEntryIDKey: bson.M{"$in": ids}},
+		bson.M{"$inc": bson.M{digestEntryAttemptsKey: 1}},
+	)
+	return errors.Wrap(err, "incrementing digest entry attempts")
+}
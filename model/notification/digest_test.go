@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestDueAt(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 7, 30, 0, time.UTC)
+	assert.Equal(t, time.Date(2023, 5, 1, 12, 15, 0, 0, time.UTC), DigestDueAt(now, 15*time.Minute))
+	assert.Equal(t, time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC), DigestDueAt(now, 24*time.Hour))
+	assert.Equal(t, time.Date(2023, 5, 1, 12, 15, 0, 0, time.UTC), DigestDueAt(time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC), 15*time.Minute),
+		"a time at the start of an interval should be due at its end")
+}
+
+func TestNewDigestEntry(t *testing.T) {
+	sub := &event.Subscription{
+		ID:                    "sub",
+		Trigger:               event.TriggerOutcome,
+		DigestIntervalMinutes: 60,
+		Subscriber:            event.Subscriber{Type: event.SlackSubscriberType, Target: "#evergreen"},
+	}
+	now := time.Date(2023, 5, 1, 12, 7, 30, 0, time.UTC)
+
+	entry := NewDigestEntry("event", sub, event.Attributes{
+		Object:       []string{event.ObjectTask},
+		ID:           []string{"task"},
+		DisplayName:  []string{"compile"},
+		Status:       []string{evergreen.TaskFailed},
+		Project:      []string{"mci"},
+		InVersion:    []string{"version"},
+		InBuild:      []string{"build"},
+		BuildVariant: []string{"ubuntu"},
+	}, now)
+	assert.Equal(t, "event-sub", entry.ID)
+	assert.Equal(t, "sub", entry.SubscriptionID)
+	assert.Equal(t, sub.Subscriber, entry.Subscriber)
+	assert.Equal(t, event.TriggerOutcome, entry.Trigger)
+	assert.Equal(t, "task", entry.ResourceID)
+	assert.Equal(t, "version", entry.Version)
+	assert.Equal(t, "build", entry.Build)
+	assert.Equal(t, "ubuntu", entry.BuildVariant)
+	assert.Equal(t, time.Date(2023, 5, 1, 13, 0, 0, 0, time.UTC), entry.DueAt)
+
+	entry = NewDigestEntry("event", sub, event.Attributes{
+		Object: []string{event.ObjectVersion},
+		ID:     []string{"version"},
+	}, now)
+	assert.Equal(t, "version", entry.Version, "a version's entry should be grouped with its own version")
+}
+
+func TestDigestEntries(t *testing.T) {
+	require.NoError(t, db.Clear(DigestEntriesCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(DigestEntriesCollection))
+	}()
+
+	now := time.Now().Round(time.Millisecond)
+	entries := []DigestEntry{
+		{ID: "e1", SubscriptionID: "sub2", DueAt: now.Add(-time.Minute)},
+		{ID: "e2", SubscriptionID: "sub1", DueAt: now.Add(-time.Minute)},
+		{ID: "e3", SubscriptionID: "sub1", DueAt: now.Add(time.Hour)},
+	}
+	require.NoError(t, InsertDigestEntries(entries...))
+	require.NoError(t, InsertDigestEntries(entries[0]), "inserting an existing entry should be a no-op")
+
+	due, err := FindDueDigestEntries(now)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, "e2", due[0].ID, "due entries should be sorted by subscription")
+	assert.Equal(t, "e1", due[1].ID)
+
+	require.NoError(t, IncDigestEntryAttempts([]string{"e1"}))
+	require.NoError(t, IncDigestEntryAttempts([]string{"e1", "e2"}))
+	due, err = FindDueDigestEntries(now)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, 1, due[0].Attempts)
+	assert.Equal(t, 2, due[1].Attempts)
+
+	require.NoError(t, RemoveDigestEntries([]string{"e1", "e2"}))
+	due, err = FindDueDigestEntries(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "e3", due[0].ID)
+}
@@ -57,8 +57,9 @@ type Notification struct {
 }
 
 type NotificationMetadata struct {
-	TaskID        string `bson:"task_id,omitempty"`
-	TaskExecution int    `bson:"task_execution,omitempty"`
+	TaskID         string `bson:"task_id,omitempty"`
+	TaskExecution  int    `bson:"task_execution,omitempty"`
+	SubscriptionID string `bson:"subscription_id,omitempty"`
 }
 
 // SenderKey returns an evergreen.SenderKey to get a grip sender for this
@@ -80,6 +81,9 @@ func (n *Notification) SenderKey() (evergreen.SenderKey, error) {
 	case event.SlackSubscriberType:
 		return evergreen.SenderSlack, nil
 
+	case event.ChatWebhookSubscriberType:
+		return evergreen.SenderChatWebhook, nil
+
 	case event.GithubPullRequestSubscriberType, event.GithubCheckSubscriberType:
 		return evergreen.SenderGithubStatus, nil
 
@@ -106,6 +110,12 @@ func (n *Notification) Composer(env evergreen.Environment) (message.Composer, er
 			return nil, errors.New("evergreen-webhook payload is invalid")
 		}
 
+		body, err := sub.RenderPayload(payload.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "rendering evergreen-webhook payload")
+		}
+
+		payload.Body = body
 		payload.Secret = sub.Secret
 		payload.URL = sub.URL
 		payload.NotificationID = n.ID
@@ -115,6 +125,9 @@ func (n *Notification) Composer(env evergreen.Environment) (message.Composer, er
 		for _, header := range sub.Headers {
 			payload.Headers.Add(header.Key, header.Value)
 		}
+		if n.Metadata.SubscriptionID != "" {
+			payload.OnAttempt = recordWebhookDelivery(n.Metadata.SubscriptionID, payload, false)
+		}
 
 		return util.NewWebhookMessage(*payload), nil
 
@@ -181,6 +194,28 @@ func (n *Notification) Composer(env evergreen.Environment) (message.Composer, er
 
 		return message.NewSlackMessage(level.Notice, formattedTarget, payload.Body, payload.Attachments), nil
 
+	case event.ChatWebhookSubscriberType:
+		sub, ok := n.Subscriber.Target.(*event.ChatWebhookSubscriber)
+		if !ok {
+			return nil, errors.New("chat-webhook subscriber is invalid")
+		}
+
+		payload, ok := n.Payload.(*ChatPayload)
+		if !ok || payload == nil {
+			return nil, errors.New("chat-webhook payload is invalid")
+		}
+
+		body, err := payload.Render(sub.Format)
+		if err != nil {
+			return nil, errors.Wrap(err, "rendering chat-webhook payload")
+		}
+
+		return util.NewChatWebhookMessage(util.ChatWebhook{
+			NotificationID: n.ID,
+			URL:            sub.URL,
+			Body:           body,
+		}), nil
+
 	case event.GithubPullRequestSubscriberType:
 		sub := n.Subscriber.Target.(*event.GithubPullRequestSubscriber)
 		payload, ok := n.Payload.(*message.GithubStatus)
@@ -270,6 +305,12 @@ func (n *Notification) SetTaskMetadata(ID string, execution int) {
 	n.Metadata.TaskExecution = execution
 }
 
+// SetSubscriptionMetadata records the subscription that generated the
+// notification.
+func (n *Notification) SetSubscriptionMetadata(subscriptionID string) {
+	n.Metadata.SubscriptionID = subscriptionID
+}
+
 // FormatSlackTarget uses the slackMemberId instead of the userName when possible.
 func FormatSlackTarget(target string) (string, error) {
 	if strings.HasPrefix(target, "@") {
@@ -298,6 +339,7 @@ type NotificationStats struct {
 	Slack             int `json:"slack" bson:"slack" yaml:"slack"`
 	GithubCheck       int `json:"github_check" bson:"github_check" yaml:"github_check"`
 	EnqueuePatch      int `json:"enqueue_patch" bson:"enqueue_patch" yaml:"enqueue_patch"`
+	ChatWebhook       int `json:"chat_webhook" bson:"chat_webhook" yaml:"chat_webhook"`
 }
 
 func CollectUnsentNotificationStats() (*NotificationStats, error) {
@@ -354,6 +396,9 @@ func CollectUnsentNotificationStats() (*NotificationStats, error) {
 		case event.SlackSubscriberType:
 			nStats.Slack = data.Count
 
+		case event.ChatWebhookSubscriberType:
+			nStats.ChatWebhook = data.Count
+
 		case event.EnqueuePatchSubscriberType:
 			nStats.EnqueuePatch = data.Count
 
@@ -242,6 +242,69 @@ func (s *notificationSuite) TestWebhookPayload() {
 	s.True(c.Loggable())
 }
 
+func (s *notificationSuite) TestWebhookPayloadWithTemplate() {
+	s.n.ID = "1"
+	s.n.Subscriber.Type = event.EvergreenWebhookSubscriberType
+	s.n.Subscriber.Target = event.WebhookSubscriber{
+		URL:             "https://example.com",
+		Secret:          []byte("it's dangerous to go alone. take this!"),
+		PayloadTemplate: `{"text": "I am a {{.iama}}"}`,
+	}
+	s.n.Payload = &util.EvergreenWebhook{
+		Body: []byte(`{"iama": "potato"}`),
+	}
+	s.n.SetSubscriptionMetadata("subscription")
+
+	s.NoError(InsertMany(s.n))
+
+	n, err := Find(s.n.ID)
+	s.NoError(err)
+	s.Require().NotNil(n)
+	s.Equal("subscription", n.Metadata.SubscriptionID)
+
+	c, err := n.Composer(s.env)
+	s.NoError(err)
+	s.Require().NotNil(c)
+	s.True(c.Loggable())
+	s.Equal(`{"text": "I am a potato"}`, c.String())
+	webhook, ok := c.Raw().(*util.EvergreenWebhook)
+	s.Require().True(ok)
+	s.NotNil(webhook.OnAttempt, "webhooks for a subscription should record their deliveries")
+}
+
+func (s *notificationSuite) TestChatWebhookPayload() {
+	s.n.ID = "1"
+	s.n.Subscriber.Type = event.ChatWebhookSubscriberType
+	s.n.Subscriber.Target = event.ChatWebhookSubscriber{
+		URL:    "https://chat.example.com/hooks/abc",
+		Format: event.ChatWebhookFormatMarkdown,
+	}
+	s.n.Payload = &ChatPayload{
+		Text: "Host has spawned",
+	}
+
+	s.NoError(InsertMany(s.n))
+
+	n, err := Find(s.n.ID)
+	s.NoError(err)
+	s.Require().NotNil(n)
+	s.IsType(&ChatPayload{}, n.Payload)
+
+	key, err := n.SenderKey()
+	s.NoError(err)
+	s.Equal(evergreen.SenderChatWebhook, key)
+
+	c, err := n.Composer(s.env)
+	s.NoError(err)
+	s.Require().NotNil(c)
+	s.True(c.Loggable())
+	s.JSONEq(`{"text": "Host has spawned"}`, c.String())
+	chat, ok := c.Raw().(*util.ChatWebhook)
+	s.Require().True(ok)
+	s.Equal("https://chat.example.com/hooks/abc", chat.URL)
+	s.Equal("1", chat.NotificationID)
+}
+
 func (s *notificationSuite) TestJIRACommentPayload() {
 	s.n.ID = "1"
 	s.n.Subscriber.Type = event.JIRACommentSubscriberType
@@ -1,8 +1,234 @@
 package notification
 
-import "github.com/mongodb/grip/message"
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
 
 type SlackPayload struct {
 	Body        string                    `bson:"body"`
 	Attachments []message.SlackAttachment `bson:"attachments"`
 }
+
+// ChatPayload is a message for a chat service that's posted through an
+// incoming webhook. The text of the message and its sections is Markdown.
+type ChatPayload struct {
+	Text     string        `bson:"text"`
+	Sections []ChatSection `bson:"sections,omitempty"`
+}
+
+// ChatSection is a block of the message with an optional linked title and a
+// list of facts.
+type ChatSection struct {
+	Title  string     `bson:"title,omitempty"`
+	URL    string     `bson:"url,omitempty"`
+	Text   string     `bson:"text,omitempty"`
+	Facts  []ChatFact `bson:"facts,omitempty"`
+	Footer string     `bson:"footer,omitempty"`
+}
+
+type ChatFact struct {
+	Name  string `bson:"name"`
+	Value string `bson:"value"`
+}
+
+var (
+	slackLabeledLinkRegex = regexp.MustCompile(`<([^<>|\s]+)\|([^<>]*)>`)
+	slackLinkRegex        = regexp.MustCompile(`<((?:https?|mailto):[^<>|\s]+)>`)
+)
+
+// slackToMarkdown converts Slack's link markup into Markdown links.
+func slackToMarkdown(text string) string {
+	text = slackLabeledLinkRegex.ReplaceAllString(text, "[$2]($1)")
+	return slackLinkRegex.ReplaceAllString(text, "$1")
+}
+
+// NewChatPayload creates a chat message with the same content as the Slack
+// message, so that chat webhook subscribers are notified of everything Slack
+// subscribers are.
+func NewChatPayload(slack *SlackPayload) *ChatPayload {
+	if slack == nil {
+		return nil
+	}
+
+	payload := &ChatPayload{Text: slackToMarkdown(slack.Body)}
+	for _, attachment := range slack.Attachments {
+		section := ChatSection{
+			Title:  slackToMarkdown(attachment.Title),
+			URL:    attachment.TitleLink,
+			Text:   slackToMarkdown(attachment.Text),
+			Footer: slackToMarkdown(attachment.Footer),
+		}
+		for _, field := range attachment.Fields {
+			if field == nil {
+				continue
+			}
+			section.Facts = append(section.Facts, ChatFact{
+				Name:  slackToMarkdown(field.Title),
+				Value: slackToMarkdown(field.Value),
+			})
+		}
+		payload.Sections = append(payload.Sections, section)
+	}
+
+	return payload
+}
+
+// Render returns the JSON body to post to an incoming webhook that accepts
+// messages in the given format.
+func (p *ChatPayload) Render(format string) ([]byte, error) {
+	var body interface{}
+	switch format {
+	case event.ChatWebhookFormatAdaptiveCard:
+		body = p.adaptiveCard()
+	case event.ChatWebhookFormatMarkdown:
+		body = map[string]string{"text": p.markdown()}
+	default:
+		return nil, errors.Errorf("unknown chat webhook format '%s'", format)
+	}
+
+	out, err := json.Marshal(body)
+	return out, errors.Wrap(err, "marshalling chat message")
+}
+
+func (p *ChatPayload) markdown() string {
+	parts := []string{p.Text}
+	for _, section := range p.Sections {
+		lines := []string{}
+		switch {
+		case section.Title != "" && section.URL != "":
+			lines = append(lines, fmt.Sprintf("**[%s](%s)**", section.Title, section.URL))
+		case section.Title != "":
+			lines = append(lines, fmt.Sprintf("**%s**", section.Title))
+		}
+		if section.Text != "" {
+			lines = append(lines, section.Text)
+		}
+		for _, fact := range section.Facts {
+			lines = append(lines, fmt.Sprintf("- **%s:** %s", fact.Name, fact.Value))
+		}
+		if section.Footer != "" {
+			lines = append(lines, fmt.Sprintf("_%s_", section.Footer))
+		}
+		if len(lines) > 0 {
+			parts = append(parts, strings.Join(lines, "\n"))
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+)
+
+type adaptiveCardMessage struct {
+	Type        string                   `json:"type"`
+	Attachments []adaptiveCardAttachment `json:"attachments"`
+}
+
+type adaptiveCardAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string                   `json:"$schema"`
+	Type    string                   `json:"type"`
+	Version string                   `json:"version"`
+	Body    []map[string]interface{} `json:"body"`
+	Actions []map[string]interface{} `json:"actions,omitempty"`
+}
+
+type adaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+func adaptiveCardTextBlock(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "TextBlock",
+		"text": text,
+		"wrap": true,
+	}
+}
+
+// adaptiveCard returns a message containing the payload as an Adaptive Card.
+// Each section is a container whose title links to the section's URL.
+func (p *ChatPayload) adaptiveCard() adaptiveCardMessage {
+	card := adaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		Body:    []map[string]interface{}{adaptiveCardTextBlock(p.Text)},
+	}
+
+	for _, section := range p.Sections {
+		items := []map[string]interface{}{}
+		if section.Title != "" {
+			title := adaptiveCardTextBlock(section.Title)
+			title["weight"] = "Bolder"
+			items = append(items, title)
+		}
+		if section.Text != "" {
+			items = append(items, adaptiveCardTextBlock(section.Text))
+		}
+		if len(section.Facts) > 0 {
+			facts := []adaptiveCardFact{}
+			for _, fact := range section.Facts {
+				facts = append(facts, adaptiveCardFact{Title: fact.Name, Value: fact.Value})
+			}
+			items = append(items, map[string]interface{}{
+				"type":  "FactSet",
+				"facts": facts,
+			})
+		}
+		if section.Footer != "" {
+			footer := adaptiveCardTextBlock(section.Footer)
+			footer["isSubtle"] = true
+			footer["size"] = "Small"
+			items = append(items, footer)
+		}
+		if len(items) > 0 {
+			container := map[string]interface{}{
+				"type":      "Container",
+				"separator": true,
+				"items":     items,
+			}
+			if section.URL != "" {
+				container["selectAction"] = map[string]interface{}{
+					"type": "Action.OpenUrl",
+					"url":  section.URL,
+				}
+			}
+			card.Body = append(card.Body, container)
+		}
+		if section.URL != "" {
+			title := section.Title
+			if title == "" {
+				title = "Open"
+			}
+			card.Actions = append(card.Actions, map[string]interface{}{
+				"type":  "Action.OpenUrl",
+				"title": title,
+				"url":   section.URL,
+			})
+		}
+	}
+
+	return adaptiveCardMessage{
+		Type: "message",
+		Attachments: []adaptiveCardAttachment{{
+			ContentType: adaptiveCardContentType,
+			Content:     card,
+		}},
+	}
+}
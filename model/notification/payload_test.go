@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChatPayload(t *testing.T) {
+	assert.Nil(t, NewChatPayload(nil))
+
+	payload := NewChatPayload(&SlackPayload{
+		Body: "The task <https://evergreen.example.com/task/t1|compile> in 'mci' has failed!",
+		Attachments: []message.SlackAttachment{
+			{
+				Title:     "Evergreen Host: h1",
+				TitleLink: "https://evergreen.example.com/spawn",
+				Text:      "see <https://evergreen.example.com/host/h1>",
+				Fields: []*message.SlackAttachmentField{
+					{Title: "Distro", Value: "ubuntu2204"},
+					nil,
+					{Title: "IDE", Value: "<https://evergreen.example.com/host/h1/ide|IDE>"},
+				},
+				Footer: "Subscription: s1; Event: e1",
+			},
+		},
+	})
+	require.NotNil(t, payload)
+	assert.Equal(t, "The task [compile](https://evergreen.example.com/task/t1) in 'mci' has failed!", payload.Text)
+	require.Len(t, payload.Sections, 1)
+	section := payload.Sections[0]
+	assert.Equal(t, "Evergreen Host: h1", section.Title)
+	assert.Equal(t, "https://evergreen.example.com/spawn", section.URL)
+	assert.Equal(t, "see https://evergreen.example.com/host/h1", section.Text)
+	assert.Equal(t, []ChatFact{
+		{Name: "Distro", Value: "ubuntu2204"},
+		{Name: "IDE", Value: "[IDE](https://evergreen.example.com/host/h1/ide)"},
+	}, section.Facts)
+	assert.Equal(t, "Subscription: s1; Event: e1", section.Footer)
+}
+
+func TestChatPayloadRender(t *testing.T) {
+	payload := &ChatPayload{
+		Text: "Host has spawned",
+		Sections: []ChatSection{
+			{
+				Title:  "Evergreen Host: h1",
+				URL:    "https://evergreen.example.com/spawn",
+				Facts:  []ChatFact{{Name: "Distro", Value: "ubuntu2204"}},
+				Footer: "Subscription: s1",
+			},
+		},
+	}
+
+	t.Run("Markdown", func(t *testing.T) {
+		out, err := payload.Render(event.ChatWebhookFormatMarkdown)
+		require.NoError(t, err)
+		body := map[string]string{}
+		require.NoError(t, json.Unmarshal(out, &body))
+		assert.Equal(t, "Host has spawned\n\n**[Evergreen Host: h1](https://evergreen.example.com/spawn)**\n- **Distro:** ubuntu2204\n_Subscription: s1_", body["text"])
+	})
+	t.Run("AdaptiveCard", func(t *testing.T) {
+		out, err := payload.Render(event.ChatWebhookFormatAdaptiveCard)
+		require.NoError(t, err)
+		msg := adaptiveCardMessage{}
+		require.NoError(t, json.Unmarshal(out, &msg))
+		assert.Equal(t, "message", msg.Type)
+		require.Len(t, msg.Attachments, 1)
+		assert.Equal(t, adaptiveCardContentType, msg.Attachments[0].ContentType)
+
+		card := msg.Attachments[0].Content
+		assert.Equal(t, "AdaptiveCard", card.Type)
+		require.Len(t, card.Body, 2)
+		assert.Equal(t, "Host has spawned", card.Body[0]["text"])
+		assert.Equal(t, "Container", card.Body[1]["type"])
+		items, ok := card.Body[1]["items"].([]interface{})
+		require.True(t, ok)
+		assert.Len(t, items, 3)
+		require.Len(t, card.Actions, 1)
+		assert.Equal(t, "Action.OpenUrl", card.Actions[0]["type"])
+		assert.Equal(t, "https://evergreen.example.com/spawn", card.Actions[0]["url"])
+	})
+	t.Run("UnknownFormat", func(t *testing.T) {
+		_, err := payload.Render("html")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,224 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	WebhookDeliveriesCollection = "webhook_deliveries"
+
+	// webhookDeliveryTTL is how long deliveries are kept in the delivery
+	// log.
+	webhookDeliveryTTL = 30 * 24 * time.Hour
+)
+
+//nolint:megacheck,unused
+var (
+	webhookDeliveryIDKey             = bsonutil.MustHaveTag(WebhookDelivery{}, "ID")
+	webhookDeliverySubscriptionIDKey = bsonutil.MustHaveTag(WebhookDelivery{}, "SubscriptionID")
+	webhookDeliveryTimeKey           = bsonutil.MustHaveTag(WebhookDelivery{}, "Time")
+)
+
+// safeWebhookHeaders are the request headers whose values are kept in the
+// delivery log. The values of all other headers are redacted, since they may
+// contain credentials for the webhook's receiver.
+var safeWebhookHeaders = map[string]bool{
+	"Accept":                      true,
+	"Accept-Encoding":             true,
+	"Content-Length":              true,
+	"Content-Type":                true,
+	"User-Agent":                  true,
+	"X-Evergreen-Notification-Id": true,
+}
+
+// RedactWebhookHeaders returns a copy of the headers with the values of all
+// headers that are not known to be safe replaced by empty strings.
+func RedactWebhookHeaders(headers http.Header) http.Header {
+	if headers == nil {
+		return nil
+	}
+	redacted := http.Header{}
+	for key, values := range headers {
+		if safeWebhookHeaders[http.CanonicalHeaderKey(key)] {
+			redacted[key] = append([]string{}, values...)
+			continue
+		}
+		redacted[key] = make([]string, len(values))
+	}
+	return redacted
+}
+
+// WebhookDeliveryTTLIndex expires deliveries from the delivery log once they
+// are older than the TTL.
+var WebhookDeliveryTTLIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: webhookDeliveryTimeKey, Value: 1}},
+	Options: options.Index().SetExpireAfterSeconds(int32(webhookDeliveryTTL.Seconds())),
+}
+
+// EnsureWebhookDeliveryIndexes creates the indexes for the delivery log if
+// they don't already exist.
+func EnsureWebhookDeliveryIndexes(ctx context.Context, env evergreen.Environment) error {
+	_, err := env.DB().Collection(WebhookDeliveriesCollection).Indexes().CreateOne(ctx, WebhookDeliveryTTLIndex)
+	return errors.Wrap(err, "creating webhook delivery TTL index")
+}
+
+// WebhookDelivery is a single attempt to deliver a webhook notification. The
+// deliveries of a subscription make up its delivery log.
+type WebhookDelivery struct {
+	ID             string      `bson:"_id"`
+	SubscriptionID string      `bson:"subscription_id"`
+	NotificationID string      `bson:"notification_id"`
+	URL            string      `bson:"url"`
+	RequestHeaders http.Header `bson:"request_headers,omitempty"`
+	RequestBody    string      `bson:"request_body"`
+	// Attempt is the 1-based number of the attempt within the delivery.
+	Attempt int `bson:"attempt"`
+	// Replay is true if the delivery was manually replayed.
+	Replay       bool          `bson:"replay,omitempty"`
+	StatusCode   int           `bson:"status_code,omitempty"`
+	ResponseBody string        `bson:"response_body,omitempty"`
+	Latency      time.Duration `bson:"latency"`
+	Error        string        `bson:"error,omitempty"`
+	Time         time.Time     `bson:"time"`
+}
+
+// Succeeded returns whether the webhook's receiver accepted the delivery.
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.Error == "" && d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+// NewWebhookDelivery creates a delivery log entry for an attempt to deliver
+// the webhook on behalf of the subscription. Sensitive request headers are
+// redacted.
+func NewWebhookDelivery(subscriptionID string, webhook *util.EvergreenWebhook, attempt util.WebhookAttempt) WebhookDelivery {
+	delivery := WebhookDelivery{
+		ID:             utility.RandomString(),
+		SubscriptionID: subscriptionID,
+		NotificationID: webhook.NotificationID,
+		URL:            webhook.URL,
+		RequestHeaders: RedactWebhookHeaders(attempt.RequestHeaders),
+		RequestBody:    string(webhook.Body),
+		Attempt:        attempt.Attempt,
+		StatusCode:     attempt.StatusCode,
+		ResponseBody:   string(attempt.ResponseBody),
+		Latency:        attempt.Latency,
+		Time:           attempt.StartedAt,
+	}
+	if attempt.Err != nil {
+		delivery.Error = attempt.Err.Error()
+	}
+	return delivery
+}
+
+// Insert adds the delivery to the delivery log. Expired deliveries are
+// removed by the TTL index.
+func (d *WebhookDelivery) Insert() error {
+	return errors.Wrapf(db.Insert(WebhookDeliveriesCollection, d), "inserting webhook delivery '%s'", d.ID)
+}
+
+// FindWebhookDeliveryByID returns the delivery with the given ID, or nil if it
+// doesn't exist.
+func FindWebhookDeliveryByID(id string) (*WebhookDelivery, error) {
+	delivery := &WebhookDelivery{}
+	err := db.FindOneQ(WebhookDeliveriesCollection, db.Query(bson.M{webhookDeliveryIDKey: id}), delivery)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding webhook delivery '%s'", id)
+	}
+	return delivery, nil
+}
+
+// FindRecentWebhookDeliveries returns up to limit of the subscription's most
+// recent deliveries that have not expired, from most to least recent.
+func FindRecentWebhookDeliveries(subscriptionID string, limit int) ([]WebhookDelivery, error) {
+	deliveries := []WebhookDelivery{}
+	// The TTL index only removes expired deliveries periodically, so they
+	// are filtered out explicitly.
+	q := db.Query(bson.M{
+		webhookDeliverySubscriptionIDKey: subscriptionID,
+		webhookDeliveryTimeKey:           bson.M{"$gte": time.Now().Add(-webhookDeliveryTTL)},
+	}).
+		Sort([]string{"-" + webhookDeliveryTimeKey}).
+		Limit(limit)
+	if err := db.FindAllQ(WebhookDeliveriesCollection, q, &deliveries); err != nil {
+		return nil, errors.Wrapf(err, "finding webhook deliveries for subscription '%s'", subscriptionID)
+	}
+	return deliveries, nil
+}
+
+// recordWebhookDelivery returns a webhook attempt callback that adds each
+// attempt to the subscription's delivery log.
+func recordWebhookDelivery(subscriptionID string, webhook *util.EvergreenWebhook, replay bool) func(util.WebhookAttempt) {
+	return func(attempt util.WebhookAttempt) {
+		delivery := NewWebhookDelivery(subscriptionID, webhook, attempt)
+		delivery.Replay = replay
+		grip.Error(message.WrapError(delivery.Insert(), message.Fields{
+			"message":         "could not record webhook delivery",
+			"subscription_id": subscriptionID,
+			"notification_id": webhook.NotificationID,
+			"attempt":         attempt.Attempt,
+		}))
+	}
+}
+
+// ReplayWebhookDelivery posts the request body of a previous delivery to the
+// subscriber again. The subscriber's current URL, secret and headers are used,
+// so a replay can be used to retry a delivery after fixing the subscription.
+// Only the previous delivery's headers that were not redacted are sent again.
+// The replay is attempted once and is added to the delivery log.
+func ReplayWebhookDelivery(ctx context.Context, delivery *WebhookDelivery, sub *event.WebhookSubscriber) (*WebhookDelivery, error) {
+	webhook := &util.EvergreenWebhook{
+		NotificationID: delivery.NotificationID,
+		URL:            sub.URL,
+		Secret:         sub.Secret,
+		Body:           []byte(delivery.RequestBody),
+		Headers:        http.Header{},
+		TimeoutMS:      sub.TimeoutMS,
+	}
+	for key, values := range delivery.RequestHeaders {
+		if safeWebhookHeaders[http.CanonicalHeaderKey(key)] {
+			webhook.Headers[key] = append([]string{}, values...)
+		}
+	}
+	for _, header := range sub.Headers {
+		webhook.Headers.Set(header.Key, header.Value)
+	}
+
+	var replayed *WebhookDelivery
+	var recordErr error
+	webhook.OnAttempt = func(attempt util.WebhookAttempt) {
+		d := NewWebhookDelivery(delivery.SubscriptionID, webhook, attempt)
+		d.Replay = true
+		recordErr = d.Insert()
+		replayed = &d
+	}
+
+	// A failed attempt is reported by the replayed delivery rather than as
+	// an error.
+	err := util.DeliverWebhook(ctx, nil, webhook)
+	if replayed == nil {
+		return nil, errors.Wrap(err, "replaying webhook delivery")
+	}
+	if recordErr != nil {
+		return nil, errors.Wrap(recordErr, "recording replayed webhook delivery")
+	}
+	return replayed, nil
+}
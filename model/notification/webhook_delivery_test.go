@@ -0,0 +1,161 @@
+package notification
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryLog(t *testing.T) {
+	require.NoError(t, db.Clear(WebhookDeliveriesCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(WebhookDeliveriesCollection))
+	}()
+
+	webhook := &util.EvergreenWebhook{
+		NotificationID: "notification",
+		URL:            "https://example.com",
+		Body:           []byte(`{"status": "failed"}`),
+	}
+	now := time.Now().Round(time.Millisecond)
+	for i, startedAt := range []time.Time{now.Add(-time.Minute), now, now.Add(-webhookDeliveryTTL - time.Hour)} {
+		delivery := NewWebhookDelivery("subscription", webhook, util.WebhookAttempt{
+			Attempt:    i + 1,
+			StartedAt:  startedAt,
+			Latency:    time.Second,
+			StatusCode: http.StatusOK,
+		})
+		require.NoError(t, delivery.Insert())
+	}
+	other := NewWebhookDelivery("other-subscription", webhook, util.WebhookAttempt{Attempt: 1, StartedAt: now})
+	require.NoError(t, other.Insert())
+
+	deliveries, err := FindRecentWebhookDeliveries("subscription", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2, "expired deliveries should not be returned")
+	assert.Equal(t, 2, deliveries[0].Attempt, "deliveries should be sorted from most to least recent")
+	assert.Equal(t, 1, deliveries[1].Attempt)
+	assert.Equal(t, webhook.URL, deliveries[0].URL)
+	assert.Equal(t, string(webhook.Body), deliveries[0].RequestBody)
+	assert.Equal(t, time.Second, deliveries[0].Latency)
+	assert.True(t, deliveries[0].Succeeded())
+
+	deliveries, err = FindRecentWebhookDeliveries("subscription", 1)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+
+	delivery, err := FindWebhookDeliveryByID(other.ID)
+	require.NoError(t, err)
+	require.NotNil(t, delivery)
+	assert.Equal(t, "other-subscription", delivery.SubscriptionID)
+
+	delivery, err = FindWebhookDeliveryByID("nonexistent")
+	assert.NoError(t, err)
+	assert.Nil(t, delivery)
+}
+
+func TestNewWebhookDeliveryRedactsHeaders(t *testing.T) {
+	webhook := &util.EvergreenWebhook{
+		NotificationID: "notification",
+		URL:            "https://example.com",
+	}
+	delivery := NewWebhookDelivery("subscription", webhook, util.WebhookAttempt{
+		Attempt: 1,
+		RequestHeaders: http.Header{
+			"Content-Type":                []string{"application/json"},
+			"X-Evergreen-Notification-Id": []string{"notification"},
+			"Authorization":               []string{"Bearer token"},
+			"X-Api-Key":                   []string{"key0", "key1"},
+			"X-Evergreen-Signature":       []string{"signature"},
+		},
+	})
+
+	assert.Equal(t, "application/json", delivery.RequestHeaders.Get("Content-Type"))
+	assert.Equal(t, "notification", delivery.RequestHeaders.Get("X-Evergreen-Notification-Id"))
+	assert.Equal(t, []string{""}, delivery.RequestHeaders["Authorization"])
+	assert.Equal(t, []string{"", ""}, delivery.RequestHeaders["X-Api-Key"])
+	assert.Equal(t, []string{""}, delivery.RequestHeaders["X-Evergreen-Signature"])
+}
+
+func TestReplayWebhookDelivery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.Clear(WebhookDeliveriesCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(WebhookDeliveriesCollection))
+	}()
+
+	var receivedBody []byte
+	var receivedHeaders http.Header
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		receivedBody, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		receivedHeaders = r.Header
+		w.WriteHeader(statusCode)
+		_, err = w.Write([]byte("received"))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	original := WebhookDelivery{
+		ID:             "original",
+		SubscriptionID: "subscription",
+		NotificationID: "notification",
+		URL:            "https://old.example.com",
+		RequestHeaders: http.Header{"Content-Type": []string{"application/json"}, "Authorization": []string{"old-token"}},
+		RequestBody:    `{"status": "failed"}`,
+		Attempt:        3,
+		StatusCode:     http.StatusBadGateway,
+		Error:          "response was 502 (Bad Gateway)",
+		Time:           time.Now(),
+	}
+	require.NoError(t, original.Insert())
+	sub := &event.WebhookSubscriber{
+		URL:     server.URL,
+		Secret:  []byte("secret"),
+		Retries: 5,
+		Headers: []event.WebhookHeader{{Key: "Authorization", Value: "new-token"}},
+	}
+
+	replayed, err := ReplayWebhookDelivery(ctx, &original, sub)
+	require.NoError(t, err)
+	require.NotNil(t, replayed)
+	assert.Equal(t, original.RequestBody, string(receivedBody))
+	assert.Equal(t, "application/json", receivedHeaders.Get("Content-Type"))
+	assert.Equal(t, "new-token", receivedHeaders.Get("Authorization"), "current subscriber headers should take precedence")
+	assert.NotEmpty(t, receivedHeaders.Get("X-Evergreen-Signature"))
+	assert.Equal(t, "", replayed.RequestHeaders.Get("Authorization"), "recorded headers should be redacted")
+	assert.Equal(t, "", replayed.RequestHeaders.Get("X-Evergreen-Signature"), "recorded headers should be redacted")
+
+	assert.True(t, replayed.Replay)
+	assert.True(t, replayed.Succeeded())
+	assert.Equal(t, 1, replayed.Attempt)
+	assert.Equal(t, server.URL, replayed.URL)
+	assert.Equal(t, "received", replayed.ResponseBody)
+
+	deliveries, err := FindRecentWebhookDeliveries("subscription", 10)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+
+	t.Run("FailedReplayIsRecorded", func(t *testing.T) {
+		statusCode = http.StatusInternalServerError
+		replayed, err := ReplayWebhookDelivery(ctx, &original, sub)
+		require.NoError(t, err)
+		require.NotNil(t, replayed)
+		assert.False(t, replayed.Succeeded())
+		assert.Equal(t, http.StatusInternalServerError, replayed.StatusCode)
+		assert.Equal(t, 1, replayed.Attempt, "replays should not be retried")
+	})
+}
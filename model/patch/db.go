@@ -39,6 +39,7 @@ var (
 	BuildVariantsKey        = bsonutil.MustHaveTag(Patch{}, "BuildVariants")
 	TasksKey                = bsonutil.MustHaveTag(Patch{}, "Tasks")
 	VariantsTasksKey        = bsonutil.MustHaveTag(Patch{}, "VariantsTasks")
+	SkippedTasksKey         = bsonutil.MustHaveTag(Patch{}, "SkippedTasks")
 	SyncAtEndOptionsKey     = bsonutil.MustHaveTag(Patch{}, "SyncAtEndOpts")
 	PatchesKey              = bsonutil.MustHaveTag(Patch{}, "Patches")
 	ParametersKey           = bsonutil.MustHaveTag(Patch{}, "Parameters")
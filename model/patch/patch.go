@@ -37,6 +37,17 @@ type VariantTasks struct {
 	DisplayTasks []DisplayTask `bson:"displaytasks"`
 }
 
+// SkippedTask is a task that was requested for a patch but was not scheduled
+// because it is unlikely to catch a regression in the patch's changes.
+type SkippedTask struct {
+	Variant string `bson:"variant"`
+	Task    string `bson:"task"`
+	// Score is the estimated probability that the patch's changes cause the
+	// task to fail.
+	Score  float64 `bson:"score"`
+	Reason string  `bson:"reason"`
+}
+
 // MergeVariantsTasks merges two slices of VariantsTasks into a single set.
 func MergeVariantsTasks(vts1, vts2 []VariantTasks) []VariantTasks {
 	bvToVT := map[string]VariantTasks{}
@@ -163,6 +174,9 @@ type Patch struct {
 	// tasks/variants are now scheduled to run). If true, the patch has been
 	// finalized.
 	Activated bool `bson:"activated"`
+	// SkippedTasks are the tasks requested for the patch that predictive task
+	// selection chose not to run.
+	SkippedTasks []SkippedTask `bson:"skipped_tasks,omitempty"`
 	// ProjectStorageMethod describes how the parser project is stored for this
 	// patch before it's finalized. This field is only set while the patch is
 	// unfinalized and is cleared once the patch has been finalized. It may also
@@ -422,6 +436,51 @@ func (p *Patch) SetVariantsTasks(variantsTasks []VariantTasks) error {
 	)
 }
 
+// SetSkippedTasks updates the patch's skipped tasks in the database.
+func (p *Patch) SetSkippedTasks(skipped []SkippedTask) error {
+	update := bson.M{"$set": bson.M{SkippedTasksKey: skipped}}
+	if len(skipped) == 0 {
+		update = bson.M{"$unset": bson.M{SkippedTasksKey: 1}}
+	}
+	if err := UpdateOne(bson.M{IdKey: p.Id}, update); err != nil {
+		return err
+	}
+	p.SkippedTasks = skipped
+	return nil
+}
+
+// SkippedVariantsTasks groups the patch's skipped tasks by build variant.
+func (p *Patch) SkippedVariantsTasks() []VariantTasks {
+	var vts []VariantTasks
+	variantIndex := map[string]int{}
+	for _, st := range p.SkippedTasks {
+		i, ok := variantIndex[st.Variant]
+		if !ok {
+			i = len(vts)
+			variantIndex[st.Variant] = i
+			vts = append(vts, VariantTasks{Variant: st.Variant})
+		}
+		vts[i].Tasks = append(vts[i].Tasks, st.Task)
+	}
+	return vts
+}
+
+// ChangedFiles returns the names of the files modified by the patch. Files
+// changed in a module are prefixed with the module name and a colon.
+func (p *Patch) ChangedFiles() []string {
+	var files []string
+	for _, mp := range p.Patches {
+		for _, summary := range mp.PatchSet.Summary {
+			if mp.ModuleName == "" {
+				files = append(files, summary.Name)
+			} else {
+				files = append(files, mp.ModuleName+":"+summary.Name)
+			}
+		}
+	}
+	return utility.UniqueStrings(files)
+}
+
 // AddBuildVariants adds more buildvarints to a patch document.
 // This is meant to be used after initial patch creation.
 func (p *Patch) AddBuildVariants(bvs []string) error {
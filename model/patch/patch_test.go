@@ -965,3 +965,46 @@ func TestGetCollectiveStatusFromPatchStatuses(t *testing.T) {
 	assert.Equal(t, evergreen.PatchCreated, GetCollectiveStatusFromPatchStatuses(created))
 
 }
+
+func TestChangedFiles(t *testing.T) {
+	p := Patch{
+		Patches: []ModulePatch{
+			{PatchSet: PatchSet{Summary: []thirdparty.Summary{{Name: "main.go"}, {Name: "util.go"}}}},
+			{ModuleName: "lib", PatchSet: PatchSet{Summary: []thirdparty.Summary{{Name: "main.go"}}}},
+		},
+	}
+	assert.Equal(t, []string{"main.go", "util.go", "lib:main.go"}, p.ChangedFiles())
+	assert.Empty(t, (&Patch{}).ChangedFiles())
+}
+
+func TestSkippedTasks(t *testing.T) {
+	require.NoError(t, db.Clear(Collection))
+	defer func() {
+		assert.NoError(t, db.Clear(Collection))
+	}()
+
+	p := Patch{Id: bson.NewObjectId()}
+	require.NoError(t, p.Insert())
+
+	skipped := []SkippedTask{
+		{Variant: "ubuntu", Task: "unit", Score: 0.01, Reason: "reason"},
+		{Variant: "windows", Task: "unit"},
+		{Variant: "ubuntu", Task: "lint"},
+	}
+	require.NoError(t, p.SetSkippedTasks(skipped))
+	assert.Equal(t, []VariantTasks{
+		{Variant: "ubuntu", Tasks: []string{"unit", "lint"}},
+		{Variant: "windows", Tasks: []string{"unit"}},
+	}, p.SkippedVariantsTasks())
+
+	dbPatch, err := FindOneId(p.Id.Hex())
+	require.NoError(t, err)
+	require.NotZero(t, dbPatch)
+	assert.Equal(t, skipped, dbPatch.SkippedTasks)
+
+	require.NoError(t, p.SetSkippedTasks(nil))
+	dbPatch, err = FindOneId(p.Id.Hex())
+	require.NoError(t, err)
+	require.NotZero(t, dbPatch)
+	assert.Empty(t, dbPatch.SkippedTasks)
+}
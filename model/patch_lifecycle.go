@@ -151,6 +151,9 @@ func ConfigurePatch(ctx context.Context, settings *evergreen.Settings, p *patch.
 	if err = p.SetVariantsTasks(tasks.TVPairsToVariantTasks()); err != nil {
 		return http.StatusInternalServerError, errors.Wrap(err, "setting description")
 	}
+	if err = removeScheduledSkippedTasks(p, tasks); err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, "updating skipped tasks")
+	}
 	p.Activated = true
 
 	if p.Version != "" {
@@ -1013,7 +1016,11 @@ func (e *EnqueuePatch) Send() error {
 		return errors.Wrap(err, "making merge patch")
 	}
 
-	_, err = cq.Enqueue(commitqueue.CommitQueueItem{Issue: mergePatch.Id.Hex(), PatchId: mergePatch.Id.Hex(), Source: commitqueue.SourceDiff})
+	lane, err := CommitQueueLaneForPatch(mergePatch)
+	if err != nil {
+		return errors.Wrap(err, "getting commit queue lane")
+	}
+	_, err = cq.Enqueue(commitqueue.CommitQueueItem{Issue: mergePatch.Id.Hex(), PatchId: mergePatch.Id.Hex(), Source: commitqueue.SourceDiff, Lane: lane})
 
 	return errors.Wrap(err, "enqueueing item")
 }
@@ -1165,10 +1172,15 @@ func restartPRItem(p patch.Patch, cq *commitqueue.CommitQueue) error {
 			modules = append(modules, module)
 		}
 	}
+	lane, err := CommitQueueLaneForPatch(&p)
+	if err != nil {
+		return errors.Wrap(err, "getting commit queue lane")
+	}
 	item := commitqueue.CommitQueueItem{
 		Issue:   strconv.Itoa(p.GithubPatchData.PRNumber),
 		Modules: modules,
 		Source:  commitqueue.SourcePullRequest,
+		Lane:    lane,
 	}
 	if _, err := cq.Enqueue(item); err != nil {
 		return errors.Wrap(err, "enqueuing item")
@@ -1217,7 +1229,11 @@ func restartDiffItem(p patch.Patch, cq *commitqueue.CommitQueue) error {
 	if err = newPatch.Insert(); err != nil {
 		return errors.Wrap(err, "inserting patch")
 	}
-	if _, err = cq.Enqueue(commitqueue.CommitQueueItem{Issue: newPatch.Id.Hex(), PatchId: newPatch.Id.Hex(), Source: commitqueue.SourceDiff}); err != nil {
+	lane, err := CommitQueueLaneForPatch(&newPatch)
+	if err != nil {
+		return errors.Wrap(err, "getting commit queue lane")
+	}
+	if _, err = cq.Enqueue(commitqueue.CommitQueueItem{Issue: newPatch.Id.Hex(), PatchId: newPatch.Id.Hex(), Source: commitqueue.SourceDiff, Lane: lane}); err != nil {
 		return errors.Wrap(err, "enqueuing item")
 	}
 	return nil
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/evergreen/model/build"
@@ -43,6 +44,7 @@ type Project struct {
 	RemotePath         string                     `yaml:"remote_path,omitempty" bson:"remote_path"` // deprecated
 	Branch             string                     `yaml:"branch,omitempty" bson:"branch_name"`      // deprecated
 	Stepback           bool                       `yaml:"stepback,omitempty" bson:"stepback"`
+	StepbackBisect     *bool                      `yaml:"stepback_bisect,omitempty" bson:"stepback_bisect,omitempty"`
 	PreErrorFailsTask  bool                       `yaml:"pre_error_fails_task,omitempty" bson:"pre_error_fails_task,omitempty"`
 	PostErrorFailsTask bool                       `yaml:"post_error_fails_task,omitempty" bson:"post_error_fails_task,omitempty"`
 	OomTracker         bool                       `yaml:"oom_tracker,omitempty" bson:"oom_tracker"`
@@ -59,6 +61,7 @@ type Project struct {
 	CallbackTimeout    int                        `yaml:"callback_timeout_secs,omitempty" bson:"callback_timeout_secs"`
 	Modules            ModuleList                 `yaml:"modules,omitempty" bson:"modules"`
 	Containers         []Container                `yaml:"containers,omitempty" bson:"containers"`
+	HostPools          []HostPool                 `yaml:"host_pools,omitempty" bson:"host_pools,omitempty"`
 	BuildVariants      BuildVariants              `yaml:"buildvariants,omitempty" bson:"build_variants"`
 	Functions          map[string]*YAMLCommandSet `yaml:"functions,omitempty" bson:"functions"`
 	TaskGroups         []TaskGroup                `yaml:"task_groups,omitempty" bson:"task_groups"`
@@ -394,6 +397,19 @@ type ContainerSystem struct {
 	WindowsVersion  evergreen.WindowsVersion `yaml:"windows_version,omitempty" bson:"windows_version"`
 }
 
+// HostPool is a named pool of hosts that is provisioned once for a version and
+// whose hosts are leased to the version's tasks by host.create.
+type HostPool struct {
+	Name string `yaml:"name" bson:"name"`
+	// Size is the maximum number of hosts in the pool.
+	Size int `yaml:"size" bson:"size"`
+	// ResetScript runs on each host when the task that leased it finishes,
+	// before the host is leased to another task.
+	ResetScript string `yaml:"reset_script,omitempty" bson:"reset_script,omitempty"`
+	// Host is the host.create configuration for the hosts in the pool.
+	Host apimodels.CreateHost `yaml:"host" bson:"host"`
+}
+
 type Module struct {
 	Name       string `yaml:"name,omitempty" bson:"name"`
 	Branch     string `yaml:"branch,omitempty" bson:"branch"`
@@ -1476,6 +1492,17 @@ func (p *Project) FindProjectTask(name string) *ProjectTask {
 	return nil
 }
 
+// FindHostPool returns the host pool with the given name, or nil if the
+// project doesn't define it.
+func (p *Project) FindHostPool(name string) *HostPool {
+	for _, pool := range p.HostPools {
+		if pool.Name == name {
+			return &pool
+		}
+	}
+	return nil
+}
+
 // findMatchingProjectTasks returns a list of tasks in a project that match the given regexp.
 func (p *Project) findMatchingProjectTasks(tRegex *regexp.Regexp) []string {
 	var res []string
@@ -29,6 +29,8 @@ var (
 	parametersKey  = bsonutil.MustHaveTag(ProjectAlias{}, "Parameters")
 	variantTagsKey = bsonutil.MustHaveTag(ProjectAlias{}, "VariantTags")
 	taskTagsKey    = bsonutil.MustHaveTag(ProjectAlias{}, "TaskTags")
+
+	predictiveSelectionKey = bsonutil.MustHaveTag(ProjectAlias{}, "PredictiveSelection")
 )
 
 const (
@@ -77,6 +79,11 @@ type ProjectAlias struct {
 	Task        string            `bson:"task,omitempty" json:"task" yaml:"task"`
 	TaskTags    []string          `bson:"tags,omitempty" json:"tags" yaml:"task_tags"`
 	Parameters  []patch.Parameter `bson:"parameters,omitempty" json:"parameters" yaml:"parameters"`
+	// PredictiveSelection, if set on a patch alias, runs only the subset of
+	// the alias's tasks that are likely to catch a regression in the files
+	// changed by the patch. The remaining tasks are recorded on the patch as
+	// skipped.
+	PredictiveSelection bool `bson:"predictive_selection,omitempty" json:"predictive_selection,omitempty" yaml:"predictive_selection,omitempty"`
 
 	// Source is not stored; indicates where the alias is stored for the project.
 	Source string `bson:"-" json:"-" yaml:"-"`
@@ -435,6 +442,8 @@ func (p *ProjectAlias) Upsert() error {
 		taskTagsKey:    p.TaskTags,
 		taskKey:        p.Task,
 		parametersKey:  p.Parameters,
+
+		predictiveSelectionKey: p.PredictiveSelection,
 	}
 
 	_, err := db.Upsert(ProjectAliasCollection, bson.M{
@@ -609,6 +618,9 @@ func ValidateProjectAliases(aliases []ProjectAlias, aliasType string) []string {
 		if strings.TrimSpace(pd.GitTag) != "" || strings.TrimSpace(pd.RemotePath) != "" {
 			errs = append(errs, fmt.Sprintf("%s: cannot define git tag or remote path on line #%d", aliasType, i+1))
 		}
+		if pd.PredictiveSelection && !IsPatchAlias(pd.Alias) {
+			errs = append(errs, fmt.Sprintf("%s: predictive selection can only be enabled for patch aliases on line #%d", aliasType, i+1))
+		}
 		errs = append(errs, validateAliasPatchDefinition(pd, aliasType, i+1)...)
 	}
 
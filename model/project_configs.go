@@ -36,6 +36,9 @@ type ProjectConfigFields struct {
 	TaskSync                 *TaskSyncOptions               `yaml:"task_sync,omitempty" bson:"task_sync,omitempty"`
 	GithubTriggerAliases     []string                       `yaml:"github_trigger_aliases,omitempty" bson:"github_trigger_aliases,omitempty"`
 	ContainerSizeDefinitions []ContainerResources           `yaml:"container_size_definitions,omitempty" bson:"container_size_definitions,omitempty"`
+	// LintRules maps validation rule IDs to the severity ("error", "warning"
+	// or "ignore") that the project uses for the rule's findings.
+	LintRules map[string]string `yaml:"lint_rules,omitempty" bson:"lint_rules,omitempty"`
 }
 
 // Comment above is used by the linter to detect the end of the struct.
@@ -78,6 +78,7 @@ type ParserProject struct {
 
 	// Beginning of ParserProject mergeable fields (this comment is used by the linter).
 	Stepback           *bool                      `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+	StepbackBisect     *bool                      `yaml:"stepback_bisect,omitempty" bson:"stepback_bisect,omitempty"`
 	PreErrorFailsTask  *bool                      `yaml:"pre_error_fails_task,omitempty" bson:"pre_error_fails_task,omitempty"`
 	PostErrorFailsTask *bool                      `yaml:"post_error_fails_task,omitempty" bson:"post_error_fails_task,omitempty"`
 	OomTracker         *bool                      `yaml:"oom_tracker,omitempty" bson:"oom_tracker,omitempty"`
@@ -98,6 +99,7 @@ type ParserProject struct {
 	CallbackTimeout    *int                       `yaml:"callback_timeout_secs,omitempty" bson:"callback_timeout_secs,omitempty"`
 	Modules            []Module                   `yaml:"modules,omitempty" bson:"modules,omitempty"`
 	Containers         []Container                `yaml:"containers,omitempty" bson:"containers,omitempty"`
+	HostPools          []HostPool                 `yaml:"host_pools,omitempty" bson:"host_pools,omitempty"`
 	BuildVariants      []parserBV                 `yaml:"buildvariants,omitempty" bson:"buildvariants,omitempty"`
 	Functions          map[string]*YAMLCommandSet `yaml:"functions,omitempty" bson:"functions,omitempty"`
 	TaskGroups         []parserTaskGroup          `yaml:"task_groups,omitempty" bson:"task_groups,omitempty"`
@@ -694,6 +696,9 @@ type GetProjectOpts struct {
 	ReadFileFrom    string
 	Identifier      string
 	UnmarshalStrict bool
+	// FileGetter, if set, fetches files from the project's repository for
+	// projects that aren't hosted on GitHub.
+	FileGetter func(ctx context.Context, path, revision string) ([]byte, error)
 }
 
 type PatchOpts struct {
@@ -741,6 +746,13 @@ func retrieveFile(ctx context.Context, opts GetProjectOpts) ([]byte, error) {
 		}
 		return fileContents, nil
 	default:
+		if opts.FileGetter != nil {
+			fileContents, err := opts.FileGetter(ctx, opts.RemotePath, opts.Revision)
+			if err != nil {
+				return nil, errors.Wrapf(err, "fetching project file for project '%s' at revision '%s'", opts.Identifier, opts.Revision)
+			}
+			return fileContents, nil
+		}
 		if opts.Token == "" {
 			conf, err := evergreen.GetConfig()
 			if err != nil {
@@ -896,6 +908,7 @@ func TranslateProject(pp *ParserProject) (*Project, error) {
 	proj := &Project{
 		Enabled:            utility.FromBoolPtr(pp.Enabled),
 		Stepback:           utility.FromBoolPtr(pp.Stepback),
+		StepbackBisect:     pp.StepbackBisect,
 		PreErrorFailsTask:  utility.FromBoolPtr(pp.PreErrorFailsTask),
 		PostErrorFailsTask: utility.FromBoolPtr(pp.PostErrorFailsTask),
 		OomTracker:         utility.FromBoolPtr(pp.OomTracker),
@@ -910,6 +923,7 @@ func TranslateProject(pp *ParserProject) (*Project, error) {
 		Ignore:             pp.Ignore,
 		Parameters:         pp.Parameters,
 		Containers:         pp.Containers,
+		HostPools:          pp.HostPools,
 		Pre:                pp.Pre,
 		Post:               pp.Post,
 		EarlyTermination:   pp.EarlyTermination,
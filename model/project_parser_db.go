@@ -36,6 +36,7 @@ var (
 	ParserProjectCallbackTimeoutKey   = bsonutil.MustHaveTag(ParserProject{}, "CallbackTimeout")
 	ParserProjectModulesKey           = bsonutil.MustHaveTag(ParserProject{}, "Modules")
 	ParserProjectContainersKey        = bsonutil.MustHaveTag(ParserProject{}, "Containers")
+	ParserProjectHostPoolsKey         = bsonutil.MustHaveTag(ParserProject{}, "HostPools")
 	ParserProjectBuildVariantsKey     = bsonutil.MustHaveTag(ParserProject{}, "BuildVariants")
 	ParserProjectFunctionsKey         = bsonutil.MustHaveTag(ParserProject{}, "Functions")
 	ParserProjectTaskGroupsKey        = bsonutil.MustHaveTag(ParserProject{}, "TaskGroups")
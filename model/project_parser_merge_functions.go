@@ -12,7 +12,7 @@ const MergeProjectConfigError = "error merging project configs"
 
 // mergeUnorderedUnique merges fields that are lists where the order doesn't matter.
 // These fields can be defined throughout multiple yamls but cannot contain duplicate keys.
-// These fields are: [task, task group, parameter, module, function, container]
+// These fields are: [task, task group, parameter, module, function, container, host pool]
 func (pp *ParserProject) mergeUnorderedUnique(toMerge *ParserProject) error {
 	catcher := grip.NewBasicCatcher()
 
@@ -81,6 +81,19 @@ func (pp *ParserProject) mergeUnorderedUnique(toMerge *ParserProject) error {
 		containerExist[container.Name] = true
 	}
 
+	hostPoolExist := map[string]bool{}
+	for _, hostPool := range pp.HostPools {
+		hostPoolExist[hostPool.Name] = true
+	}
+	for _, hostPool := range toMerge.HostPools {
+		if _, ok := hostPoolExist[hostPool.Name]; ok {
+			catcher.Errorf("host pool '%s' has been declared already", hostPool.Name)
+			continue
+		}
+		pp.HostPools = append(pp.HostPools, hostPool)
+		hostPoolExist[hostPool.Name] = true
+	}
+
 	for key, val := range toMerge.Functions {
 		if _, ok := pp.Functions[key]; ok {
 			catcher.Errorf("function '%s' has been declared already", key)
@@ -135,7 +148,7 @@ func (pp *ParserProject) mergeOrderedUnique(toMerge *ParserProject) error {
 
 // mergeUnique merges fields that are non-lists.
 // These fields can only be defined in one yaml.
-// These fields are: [stepback, batch time, pre/post error fails task, OOM tracker, display name, command type, callback/exec timeout, task annotations, build baron]
+// These fields are: [stepback, stepback bisect, batch time, pre/post error fails task, OOM tracker, display name, command type, callback/exec timeout, task annotations, build baron]
 func (pp *ParserProject) mergeUnique(toMerge *ParserProject) error {
 	catcher := grip.NewBasicCatcher()
 
@@ -145,6 +158,12 @@ func (pp *ParserProject) mergeUnique(toMerge *ParserProject) error {
 		pp.Stepback = toMerge.Stepback
 	}
 
+	if pp.StepbackBisect != nil && toMerge.StepbackBisect != nil {
+		catcher.New("stepback bisect can only be defined in one YAML")
+	} else if toMerge.StepbackBisect != nil {
+		pp.StepbackBisect = toMerge.StepbackBisect
+	}
+
 	if pp.BatchTime != nil && toMerge.BatchTime != nil {
 		catcher.New("batch time can only be defined in one YAML")
 	} else if toMerge.BatchTime != nil {
@@ -36,6 +36,7 @@ import (
 	"github.com/mongodb/jasper"
 	"github.com/pkg/errors"
 	"github.com/robfig/cron"
+	ignore "github.com/sabhiram/go-gitignore"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
@@ -47,18 +48,25 @@ type ProjectRef struct {
 	// Identifier must be unique, but is modifiable. Used by users.
 	Identifier string `bson:"identifier" json:"identifier" yaml:"identifier"`
 
-	DisplayName            string              `bson:"display_name" json:"display_name,omitempty" yaml:"display_name"`
-	Enabled                bool                `bson:"enabled,omitempty" json:"enabled,omitempty" yaml:"enabled"`
-	Private                *bool               `bson:"private,omitempty" json:"private,omitempty" yaml:"private"`
-	Restricted             *bool               `bson:"restricted,omitempty" json:"restricted,omitempty" yaml:"restricted"`
-	Owner                  string              `bson:"owner_name" json:"owner_name" yaml:"owner"`
-	Repo                   string              `bson:"repo_name" json:"repo_name" yaml:"repo"`
-	Branch                 string              `bson:"branch_name" json:"branch_name" yaml:"branch"`
-	RemotePath             string              `bson:"remote_path" json:"remote_path" yaml:"remote_path"`
+	DisplayName string `bson:"display_name" json:"display_name,omitempty" yaml:"display_name"`
+	Enabled     bool   `bson:"enabled,omitempty" json:"enabled,omitempty" yaml:"enabled"`
+	Private     *bool  `bson:"private,omitempty" json:"private,omitempty" yaml:"private"`
+	Restricted  *bool  `bson:"restricted,omitempty" json:"restricted,omitempty" yaml:"restricted"`
+	Owner       string `bson:"owner_name" json:"owner_name" yaml:"owner"`
+	Repo        string `bson:"repo_name" json:"repo_name" yaml:"repo"`
+	Branch      string `bson:"branch_name" json:"branch_name" yaml:"branch"`
+	RemotePath  string `bson:"remote_path" json:"remote_path" yaml:"remote_path"`
+	// RepoProvider is the source control provider hosting the project's
+	// repository. If unset, the repository is on GitHub.
+	RepoProvider string `bson:"repo_provider,omitempty" json:"repo_provider,omitempty" yaml:"repo_provider"`
+	// RepoURL is the remote URL of the repository for generic git projects.
+	// GitLab projects use the GitLab URL from the admin settings instead.
+	RepoURL                string              `bson:"repo_url,omitempty" json:"repo_url,omitempty" yaml:"repo_url"`
 	PatchingDisabled       *bool               `bson:"patching_disabled,omitempty" json:"patching_disabled,omitempty"`
 	RepotrackerDisabled    *bool               `bson:"repotracker_disabled,omitempty" json:"repotracker_disabled,omitempty" yaml:"repotracker_disabled"`
 	DispatchingDisabled    *bool               `bson:"dispatching_disabled,omitempty" json:"dispatching_disabled,omitempty" yaml:"dispatching_disabled"`
 	StepbackDisabled       *bool               `bson:"stepback_disabled,omitempty" json:"stepback_disabled,omitempty" yaml:"stepback_disabled"`
+	StepbackBisect         *bool               `bson:"stepback_bisect,omitempty" json:"stepback_bisect,omitempty" yaml:"stepback_bisect"`
 	VersionControlEnabled  *bool               `bson:"version_control_enabled,omitempty" json:"version_control_enabled,omitempty" yaml:"version_control_enabled"`
 	PRTestingEnabled       *bool               `bson:"pr_testing_enabled,omitempty" json:"pr_testing_enabled,omitempty" yaml:"pr_testing_enabled"`
 	ManualPRTestingEnabled *bool               `bson:"manual_pr_testing_enabled,omitempty" json:"manual_pr_testing_enabled,omitempty" yaml:"manual_pr_testing_enabled"`
@@ -152,10 +160,37 @@ type ExternalLink struct {
 	URLTemplate string `bson:"url_template,omitempty" json:"url_template,omitempty" yaml:"url_template,omitempty"`
 }
 
+const (
+	// RepoProviderGithub indicates that the project's repository is hosted
+	// on GitHub.
+	RepoProviderGithub = "github"
+	// RepoProviderGitLab indicates that the project's repository is hosted
+	// on GitLab.
+	RepoProviderGitLab = "gitlab"
+	// RepoProviderGit indicates that the project's repository is polled
+	// with git from an arbitrary remote URL.
+	RepoProviderGit = "git"
+)
+
 type CommitQueueParams struct {
 	Enabled     *bool  `bson:"enabled" json:"enabled" yaml:"enabled"`
 	MergeMethod string `bson:"merge_method" json:"merge_method" yaml:"merge_method"`
 	Message     string `bson:"message,omitempty" json:"message,omitempty" yaml:"message"`
+	// Lanes are groups of paths in the repository whose changes can be
+	// tested and merged independently of changes to other lanes.
+	Lanes []CommitQueueLane `bson:"lanes,omitempty" json:"lanes,omitempty" yaml:"lanes,omitempty"`
+	// SpeculativeDepth is the maximum number of items that can be processed
+	// at once in each lane. If it's set, it overrides the admin setting.
+	SpeculativeDepth int `bson:"speculative_depth,omitempty" json:"speculative_depth,omitempty" yaml:"speculative_depth,omitempty"`
+}
+
+// CommitQueueLane is a group of paths in the repository. Commit queue items
+// whose changes are all in the lane's paths are tested and merged separately
+// from the items in other lanes.
+type CommitQueueLane struct {
+	Name string `bson:"name" json:"name" yaml:"name"`
+	// Paths are gitignore-style patterns that match the files in the lane.
+	Paths []string `bson:"paths" json:"paths" yaml:"paths"`
 }
 
 // TaskSyncOptions contains information about which features are allowed for
@@ -303,6 +338,8 @@ var (
 	ProjectRefDisplayNameKey              = bsonutil.MustHaveTag(ProjectRef{}, "DisplayName")
 	ProjectRefDeactivatePreviousKey       = bsonutil.MustHaveTag(ProjectRef{}, "DeactivatePrevious")
 	ProjectRefRemotePathKey               = bsonutil.MustHaveTag(ProjectRef{}, "RemotePath")
+	ProjectRefRepoProviderKey             = bsonutil.MustHaveTag(ProjectRef{}, "RepoProvider")
+	ProjectRefRepoURLKey                  = bsonutil.MustHaveTag(ProjectRef{}, "RepoURL")
 	ProjectRefHiddenKey                   = bsonutil.MustHaveTag(ProjectRef{}, "Hidden")
 	ProjectRefRepotrackerErrorKey         = bsonutil.MustHaveTag(ProjectRef{}, "RepotrackerError")
 	ProjectRefDisabledStatsCacheKey       = bsonutil.MustHaveTag(ProjectRef{}, "DisabledStatsCache")
@@ -320,6 +357,7 @@ var (
 	projectRefPatchingDisabledKey         = bsonutil.MustHaveTag(ProjectRef{}, "PatchingDisabled")
 	projectRefDispatchingDisabledKey      = bsonutil.MustHaveTag(ProjectRef{}, "DispatchingDisabled")
 	projectRefStepbackDisabledKey         = bsonutil.MustHaveTag(ProjectRef{}, "StepbackDisabled")
+	projectRefStepbackBisectKey           = bsonutil.MustHaveTag(ProjectRef{}, "StepbackBisect")
 	projectRefVersionControlEnabledKey    = bsonutil.MustHaveTag(ProjectRef{}, "VersionControlEnabled")
 	projectRefNotifyOnFailureKey          = bsonutil.MustHaveTag(ProjectRef{}, "NotifyOnBuildFailure")
 	projectRefSpawnHostScriptPathKey      = bsonutil.MustHaveTag(ProjectRef{}, "SpawnHostScriptPath")
@@ -373,6 +411,12 @@ func (p *ProjectRef) IsStepbackDisabled() bool {
 	return utility.FromBoolPtr(p.StepbackDisabled)
 }
 
+// IsStepbackBisect returns true if stepback should bisect the range of
+// untested commits rather than walk back one commit at a time.
+func (p *ProjectRef) IsStepbackBisect() bool {
+	return utility.FromBoolPtr(p.StepbackBisect)
+}
+
 func (p *ProjectRef) IsAutoPRTestingEnabled() bool {
 	return utility.FromBoolPtr(p.PRTestingEnabled)
 }
@@ -436,6 +480,46 @@ func (p *CommitQueueParams) IsEnabled() bool {
 	return utility.FromBoolPtr(p.Enabled)
 }
 
+// LaneForFiles returns the name of the first lane that contains all of the
+// files, or the shared lane if there is no such lane.
+func (p *CommitQueueParams) LaneForFiles(files []string) string {
+	if len(files) == 0 {
+		return commitqueue.SharedLane
+	}
+	for _, lane := range p.Lanes {
+		// CompileIgnoreLines has a silly API: it always returns a nil error.
+		matcher := ignore.CompileIgnoreLines(lane.Paths...)
+		inLane := true
+		for _, f := range files {
+			if !matcher.MatchesPath(f) {
+				inLane = false
+				break
+			}
+		}
+		if inLane {
+			return lane.Name
+		}
+	}
+	return commitqueue.SharedLane
+}
+
+// ValidateLanes checks that the commit queue lanes have unique names and
+// paths.
+func (p *CommitQueueParams) ValidateLanes() error {
+	catcher := grip.NewBasicCatcher()
+	names := map[string]bool{}
+	for _, lane := range p.Lanes {
+		catcher.NewWhen(lane.Name == "", "lane name cannot be empty")
+		catcher.ErrorfWhen(lane.Name != "" && names[lane.Name], "lane name '%s' is used more than once", lane.Name)
+		catcher.ErrorfWhen(len(lane.Paths) == 0, "lane '%s' must have at least one path", lane.Name)
+		for _, path := range lane.Paths {
+			catcher.ErrorfWhen(strings.TrimSpace(path) == "", "lane '%s' cannot have an empty path", lane.Name)
+		}
+		names[lane.Name] = true
+	}
+	return catcher.Resolve()
+}
+
 func (ts *TaskSyncOptions) IsPatchEnabled() bool {
 	return utility.FromBoolPtr(ts.PatchEnabled)
 }
@@ -1171,6 +1255,10 @@ func getCommonProjectVariables(projectIds []string) (*ProjectVars, error) {
 		if vars == nil {
 			continue
 		}
+		// Values must be decrypted to compare them across projects.
+		if err = vars.Decrypt(); err != nil {
+			return nil, errors.Wrapf(err, "decrypting variables for project '%s'", id)
+		}
 		if i == 0 {
 			if vars.Vars != nil {
 				commonProjectVariables = vars.Vars
@@ -1955,6 +2043,7 @@ func SaveProjectPageForSection(projectId string, p *ProjectRef, section ProjectP
 			projectRefSpawnHostScriptPathKey:   p.SpawnHostScriptPath,
 			projectRefDispatchingDisabledKey:   p.DispatchingDisabled,
 			projectRefStepbackDisabledKey:      p.StepbackDisabled,
+			projectRefStepbackBisectKey:        p.StepbackBisect,
 			projectRefVersionControlEnabledKey: p.VersionControlEnabled,
 			ProjectRefDeactivatePreviousKey:    p.DeactivatePrevious,
 			projectRefRepotrackerDisabledKey:   p.RepotrackerDisabled,
@@ -1970,6 +2059,8 @@ func SaveProjectPageForSection(projectId string, p *ProjectRef, section ProjectP
 		if !isRepo && !p.UseRepoSettings() && !defaultToRepo {
 			setUpdate[ProjectRefOwnerKey] = p.Owner
 			setUpdate[ProjectRefRepoKey] = p.Repo
+			setUpdate[ProjectRefRepoProviderKey] = p.RepoProvider
+			setUpdate[ProjectRefRepoURLKey] = p.RepoURL
 
 		}
 		// some fields shouldn't be set to nil when defaulting to the repo
@@ -2394,7 +2485,56 @@ func (p *ProjectRef) ValidateOwnerAndRepo(validOrgs []string) error {
 		return errors.New("no owner/repo specified")
 	}
 
-	return validateOwner(p.Owner, validOrgs)
+	switch p.GetRepoProvider() {
+	case RepoProviderGithub:
+		return validateOwner(p.Owner, validOrgs)
+	case RepoProviderGitLab:
+		if p.RepoURL != "" {
+			return errors.New("GitLab projects cannot specify a repo URL because the GitLab URL is set by admins")
+		}
+		return nil
+	case RepoProviderGit:
+		if p.RepoURL == "" {
+			return errors.New("generic git projects must specify a repo URL")
+		}
+		return errors.Wrap(validateGitRepoURL(p.RepoURL), "invalid repo URL")
+	default:
+		return errors.Errorf("invalid repo provider '%s'", p.RepoProvider)
+	}
+}
+
+// GetRepoProvider returns the source control provider hosting the project's
+// repository.
+func (p *ProjectRef) GetRepoProvider() string {
+	if p.RepoProvider == "" {
+		return RepoProviderGithub
+	}
+	return p.RepoProvider
+}
+
+// scpStyleRepoURLRegex matches scp-style git remotes, such as
+// git@example.com:group/repo.git.
+var scpStyleRepoURLRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*@[A-Za-z0-9][A-Za-z0-9.-]*:[A-Za-z0-9._~/-]+$`)
+
+// validateGitRepoURL checks that the URL is an HTTPS, SSH or scp-style git
+// remote. Other forms, such as local paths, file URLs and anything that git
+// could parse as an option, are rejected because the app server fetches the
+// repository.
+func validateGitRepoURL(repoURL string) error {
+	if scpStyleRepoURLRegex.MatchString(repoURL) {
+		return nil
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return errors.Wrapf(err, "parsing URL '%s'", repoURL)
+	}
+	if u.Scheme != "https" && u.Scheme != "ssh" {
+		return errors.Errorf("URL '%s' must be an HTTPS, SSH or scp-style git remote", repoURL)
+	}
+	if u.Hostname() == "" || strings.HasPrefix(u.Hostname(), "-") || strings.HasPrefix(u.User.Username(), "-") {
+		return errors.Errorf("URL '%s' has an invalid host", repoURL)
+	}
+	return nil
 }
 
 func validateOwner(owner string, validOrgs []string) error {
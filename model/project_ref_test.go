@@ -2936,6 +2936,43 @@ func TestValidateOwnerAndRepo(t *testing.T) {
 	project.Owner = "evergreen-ci"
 	err = project.ValidateOwnerAndRepo([]string{"evergreen-ci"})
 	assert.NoError(t, err)
+
+	// GitLab projects aren't restricted to the allowed GitHub orgs
+	project.Owner = "some-group"
+	assert.Error(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
+	project.RepoProvider = RepoProviderGitLab
+	assert.NoError(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
+
+	// generic git projects must have a repo URL
+	project.RepoProvider = RepoProviderGit
+	assert.Error(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
+	project.RepoURL = "git@git.example.com:some-group/repo.git"
+	assert.NoError(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
+	project.RepoURL = "https://git.example.com/some-group/repo.git"
+	assert.NoError(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
+	project.RepoURL = "ssh://git@git.example.com/some-group/repo.git"
+	assert.NoError(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
+
+	// only HTTPS, SSH and scp-style URLs are allowed
+	for _, repoURL := range []string{
+		"--upload-pack=touch /tmp/pwned",
+		"file:///etc",
+		"/var/lib/repo.git",
+		"http://git.example.com/some-group/repo.git",
+		"ssh://-oProxyCommand=evil/repo.git",
+	} {
+		project.RepoURL = repoURL
+		assert.Error(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}), repoURL)
+	}
+
+	// GitLab projects cannot choose the host that receives the GitLab token
+	project.RepoProvider = RepoProviderGitLab
+	project.RepoURL = "https://attacker.example.com"
+	assert.Error(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
+	project.RepoURL = ""
+
+	project.RepoProvider = "svn"
+	assert.Error(t, project.ValidateOwnerAndRepo([]string{"evergreen-ci"}))
 }
 
 func TestProjectCanDispatchTask(t *testing.T) {
@@ -3108,3 +3145,59 @@ func TestSetContainerSecrets(t *testing.T) {
 		assert.Empty(t, dbProjRef.RepotrackerError)
 	})
 }
+
+func TestCommitQueueLaneForFiles(t *testing.T) {
+	params := CommitQueueParams{
+		Lanes: []CommitQueueLane{
+			{Name: "docs", Paths: []string{"docs/", "*.md"}},
+			{Name: "storage", Paths: []string{"src/storage/"}},
+		},
+	}
+
+	assert.Equal(t, "docs", params.LaneForFiles([]string{"docs/index.md", "README.md"}))
+	assert.Equal(t, "storage", params.LaneForFiles([]string{"src/storage/engine.go"}))
+	assert.Equal(t, commitqueue.SharedLane, params.LaneForFiles([]string{"docs/index.md", "src/storage/engine.go"}))
+	assert.Equal(t, commitqueue.SharedLane, params.LaneForFiles([]string{"src/main.go"}))
+	assert.Equal(t, commitqueue.SharedLane, params.LaneForFiles(nil))
+	assert.Equal(t, commitqueue.SharedLane, (&CommitQueueParams{}).LaneForFiles([]string{"docs/index.md"}))
+}
+
+func TestValidateCommitQueueLanes(t *testing.T) {
+	for tName, tCase := range map[string]struct {
+		lanes   []CommitQueueLane
+		isValid bool
+	}{
+		"NoLanes": {isValid: true},
+		"ValidLanes": {
+			lanes: []CommitQueueLane{
+				{Name: "docs", Paths: []string{"docs/"}},
+				{Name: "storage", Paths: []string{"src/storage/"}},
+			},
+			isValid: true,
+		},
+		"EmptyName": {
+			lanes: []CommitQueueLane{{Paths: []string{"docs/"}}},
+		},
+		"DuplicateName": {
+			lanes: []CommitQueueLane{
+				{Name: "docs", Paths: []string{"docs/"}},
+				{Name: "docs", Paths: []string{"*.md"}},
+			},
+		},
+		"NoPaths": {
+			lanes: []CommitQueueLane{{Name: "docs"}},
+		},
+		"EmptyPath": {
+			lanes: []CommitQueueLane{{Name: "docs", Paths: []string{" "}}},
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			params := CommitQueueParams{Lanes: tCase.lanes}
+			if tCase.isValid {
+				assert.NoError(t, params.ValidateLanes())
+			} else {
+				assert.Error(t, params.ValidateLanes())
+			}
+		})
+	}
+}
@@ -48,6 +48,11 @@ type ProjectVars struct {
 
 	// AdminOnlyVars keeps track of variables that are only accessible by project admins
 	AdminOnlyVars map[string]bool `bson:"admin_only_vars" json:"admin_only_vars"`
+
+	// encryptedFor maps variables whose encrypted values came from other
+	// project variables, such as a repo's, to the ID of the variables that
+	// the values were encrypted for.
+	encryptedFor map[string]string
 }
 
 type AWSSSHKey struct {
@@ -94,7 +99,7 @@ func FindMergedProjectVars(projectID string) (*ProjectVars, error) {
 		return projectVars, nil
 	}
 	if projectVars == nil {
-		repoVars.Id = project.Id
+		repoVars.changeID(project.Id)
 		return repoVars, nil
 	}
 
@@ -160,7 +165,7 @@ func CopyProjectVars(oldProjectId, newProjectId string) error {
 	if vars == nil {
 		vars = &ProjectVars{}
 	}
-	vars.Id = newProjectId
+	vars.changeID(newProjectId)
 	_, err = vars.Upsert()
 	return errors.Wrapf(err, "inserting variables for project '%s", newProjectId)
 }
@@ -195,6 +200,9 @@ func GetAWSKeyForProject(projectId string) (*AWSSSHKey, error) {
 	if vars == nil {
 		return nil, errors.New("no variables for project")
 	}
+	if err = vars.Decrypt(); err != nil {
+		return nil, errors.Wrap(err, "decrypting project vars")
+	}
 	return &AWSSSHKey{
 		Name:  vars.Vars[ProjectAWSSSHKeyName],
 		Value: vars.Vars[ProjectAWSSSHKeyValue],
@@ -202,6 +210,10 @@ func GetAWSKeyForProject(projectId string) (*AWSSSHKey, error) {
 }
 
 func (projectVars *ProjectVars) Upsert() (*adb.ChangeInfo, error) {
+	vars, err := projectVars.varsForStorage()
+	if err != nil {
+		return nil, errors.Wrapf(err, "preparing variables for project '%s'", projectVars.Id)
+	}
 	return db.Upsert(
 		ProjectVarsCollection,
 		bson.M{
@@ -209,7 +221,7 @@ func (projectVars *ProjectVars) Upsert() (*adb.ChangeInfo, error) {
 		},
 		bson.M{
 			"$set": bson.M{
-				projectVarsMapKey:   projectVars.Vars,
+				projectVarsMapKey:   vars,
 				privateVarsMapKey:   projectVars.PrivateVars,
 				adminOnlyVarsMapKey: projectVars.AdminOnlyVars,
 			},
@@ -218,9 +230,15 @@ func (projectVars *ProjectVars) Upsert() (*adb.ChangeInfo, error) {
 }
 
 func (projectVars *ProjectVars) Insert() error {
+	vars, err := projectVars.varsForStorage()
+	if err != nil {
+		return errors.Wrapf(err, "preparing variables for project '%s'", projectVars.Id)
+	}
+	toInsert := *projectVars
+	toInsert.Vars = vars
 	return db.Insert(
 		ProjectVarsCollection,
-		projectVars,
+		&toInsert,
 	)
 }
 
@@ -232,7 +250,11 @@ func (projectVars *ProjectVars) FindAndModify(varsToDelete []string) (*adb.Chang
 		len(projectVars.AdminOnlyVars) == 0 && len(varsToDelete) == 0 {
 		return nil, nil
 	}
-	for key, val := range projectVars.Vars {
+	vars, err := projectVars.varsToModify(varsToDelete)
+	if err != nil {
+		return nil, errors.Wrapf(err, "preparing variables for project '%s'", projectVars.Id)
+	}
+	for key, val := range vars {
 		setUpdate[bsonutil.GetDottedKeyName(projectVarsMapKey, key)] = val
 	}
 	for key, val := range projectVars.PrivateVars {
@@ -266,15 +288,75 @@ func (projectVars *ProjectVars) FindAndModify(varsToDelete []string) (*adb.Chang
 	)
 }
 
-func (projectVars *ProjectVars) GetVars(t *task.Task) map[string]string {
+// varsToModify returns the variables that FindAndModify should set, in the
+// form they should be stored. Since the update may change whether existing
+// variables are private or admin-only without changing their values, the
+// existing variables are included if the way they're stored must change.
+func (projectVars *ProjectVars) varsToModify(varsToDelete []string) (map[string]string, error) {
+	existing, err := FindOneProjectVars(projectVars.Id)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding existing variables")
+	}
+	if existing == nil {
+		existing = &ProjectVars{}
+	}
+	for _, key := range varsToDelete {
+		delete(existing.Vars, key)
+		delete(existing.PrivateVars, key)
+		delete(existing.AdminOnlyVars, key)
+	}
+
+	merged := &ProjectVars{
+		Id:            projectVars.Id,
+		Vars:          map[string]string{},
+		PrivateVars:   map[string]bool{},
+		AdminOnlyVars: map[string]bool{},
+	}
+	for key, val := range existing.Vars {
+		merged.Vars[key] = val
+	}
+	for key, val := range projectVars.Vars {
+		merged.Vars[key] = val
+	}
+	for _, flags := range []map[string]bool{existing.PrivateVars, projectVars.PrivateVars} {
+		for key, val := range flags {
+			merged.PrivateVars[key] = val
+		}
+	}
+	for _, flags := range []map[string]bool{existing.AdminOnlyVars, projectVars.AdminOnlyVars} {
+		for key, val := range flags {
+			merged.AdminOnlyVars[key] = val
+		}
+	}
+
+	stored, err := merged.varsForStorage()
+	if err != nil {
+		return nil, err
+	}
 	vars := map[string]string{}
+	for key, val := range stored {
+		if _, ok := projectVars.Vars[key]; ok || existing.Vars[key] != val {
+			vars[key] = val
+		}
+	}
+	return vars, nil
+}
+
+// GetVars returns the decrypted variables that the task is allowed to
+// access.
+func (projectVars *ProjectVars) GetVars(t *task.Task) (map[string]string, error) {
+	vars := &ProjectVars{Id: projectVars.Id, Vars: map[string]string{}}
 	isAdmin := projectVars.ShouldGetAdminOnlyVars(t)
 	for k, v := range projectVars.Vars {
 		if !projectVars.AdminOnlyVars[k] || isAdmin {
-			vars[k] = v
+			vars.Vars[k] = v
+			vars.setEncryptionID(k, projectVars.encryptionID(k))
 		}
 	}
-	return vars
+	if err := vars.Decrypt(); err != nil {
+		return nil, err
+	}
+	return vars.Vars, nil
 }
 
 func (projectVars *ProjectVars) ShouldGetAdminOnlyVars(t *task.Task) bool {
@@ -330,6 +412,21 @@ func (projectVars *ProjectVars) RedactPrivateVars() *ProjectVars {
 		} else {
 			res.Vars[k] = v
 		}
+		// Admin-only variables that are not private are encrypted at rest
+		// but are still visible to project admins.
+		if isEncryptedVar(res.Vars[k]) {
+			toDecrypt := &ProjectVars{Id: projectVars.encryptionID(k), Vars: map[string]string{k: res.Vars[k]}}
+			if err := toDecrypt.Decrypt(); err != nil {
+				grip.Error(message.WrapError(err, message.Fields{
+					"message":  "could not decrypt admin-only variable, redacting it",
+					"project":  projectVars.Id,
+					"variable": k,
+				}))
+				res.Vars[k] = ""
+			} else {
+				res.Vars[k] = toDecrypt.Vars[k]
+			}
+		}
 		if val, ok := projectVars.AdminOnlyVars[k]; ok && val {
 			res.AdminOnlyVars[k] = projectVars.AdminOnlyVars[k]
 		}
@@ -338,15 +435,24 @@ func (projectVars *ProjectVars) RedactPrivateVars() *ProjectVars {
 	return res
 }
 
+// GetVarsByValue returns the decrypted variables of all projects that have
+// a variable with the given value.
 func GetVarsByValue(val string) ([]*ProjectVars, error) {
-	matchingProjects := []*ProjectVars{}
+	candidates := []*ProjectVars{}
+	valueKey := bsonutil.GetDottedKeyName(projectVarsMapKey, "v")
 	pipeline := []bson.M{
 		{"$addFields": bson.M{projectVarsMapKey: bson.M{"$objectToArray": "$" + projectVarsMapKey}}},
-		{"$match": bson.M{bsonutil.GetDottedKeyName(projectVarsMapKey, "v"): val}},
+		// Encrypted values can't be matched in the database, so projects
+		// with encrypted values of the same length as the value are checked
+		// after decrypting them.
+		{"$match": bson.M{"$or": []bson.M{
+			{valueKey: val},
+			{valueKey: bson.M{"$regex": encryptedVarPattern(len(val))}},
+		}}},
 		{"$addFields": bson.M{projectVarsMapKey: bson.M{"$arrayToObject": "$" + projectVarsMapKey}}},
 	}
 
-	err := db.Aggregate(ProjectVarsCollection, pipeline, &matchingProjects)
+	err := db.Aggregate(ProjectVarsCollection, pipeline, &candidates)
 
 	if adb.ResultsNotFound(err) {
 		return nil, nil
@@ -354,9 +460,45 @@ func GetVarsByValue(val string) ([]*ProjectVars, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	var matchingProjects []*ProjectVars
+	for _, projectVars := range candidates {
+		hasValue, err := projectVars.hasValue(val)
+		if err != nil {
+			return nil, err
+		}
+		if !hasValue {
+			continue
+		}
+		if err = projectVars.Decrypt(); err != nil {
+			return nil, err
+		}
+		matchingProjects = append(matchingProjects, projectVars)
+	}
 	return matchingProjects, nil
 }
 
+// hasValue returns whether any of the variables has the given value. Only
+// the encrypted values that could have the value are decrypted.
+func (projectVars *ProjectVars) hasValue(val string) (bool, error) {
+	for key, v := range projectVars.Vars {
+		if v == val {
+			return true, nil
+		}
+		if !isEncryptedVar(v) || !couldBeEncryptedValue(v, val) {
+			continue
+		}
+		toDecrypt := &ProjectVars{Id: projectVars.encryptionID(key), Vars: map[string]string{key: v}}
+		if err := toDecrypt.Decrypt(); err != nil {
+			return false, err
+		}
+		if toDecrypt.Vars[key] == val {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // MergeWithRepoVars merges the project and repo variables
 func (projectVars *ProjectVars) MergeWithRepoVars(repoVars *ProjectVars) {
 	if projectVars.Vars == nil {
@@ -375,6 +517,7 @@ func (projectVars *ProjectVars) MergeWithRepoVars(repoVars *ProjectVars) {
 	for key, val := range repoVars.Vars {
 		if _, ok := projectVars.Vars[key]; !ok {
 			projectVars.Vars[key] = val
+			projectVars.setEncryptionID(key, repoVars.encryptionID(key))
 			if v, ok := repoVars.PrivateVars[key]; ok {
 				projectVars.PrivateVars[key] = v
 			}
@@ -0,0 +1,486 @@
+package model
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/envelope"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// ProjectVarsDataKeysCollection stores the wrapped data keys that
+	// encrypt project variables.
+	ProjectVarsDataKeysCollection = "project_vars_data_keys"
+
+	// encryptedVarPrefix prefixes encrypted project variable values, which
+	// have the form "<prefix><version>:<data key ID>:<base64 ciphertext>".
+	encryptedVarPrefix = "evgenc:"
+	// encryptedVarV2Prefix prefixes values whose ciphertext is authenticated
+	// with the ID of the project variables they belong to, so that a value
+	// copied into another project's variables can't be decrypted there.
+	// Values must be decrypted and re-encrypted to move them between
+	// projects.
+	encryptedVarV2Prefix = encryptedVarPrefix + "v2:"
+	// encryptedVarV1Prefix prefixes values that were encrypted before values
+	// were bound to their project. They can still be decrypted, and are
+	// re-encrypted the next time their project's variables are saved.
+	encryptedVarV1Prefix = encryptedVarPrefix + "v1:"
+)
+
+var (
+	dataKeyIdKey         = bsonutil.MustHaveTag(projectVarsDataKey{}, "Id")
+	dataKeyCreatedAtKey  = bsonutil.MustHaveTag(projectVarsDataKey{}, "CreatedAt")
+	dataKeyProviderKey   = bsonutil.MustHaveTag(projectVarsDataKey{}, "Provider")
+	dataKeyKeyIDKey      = bsonutil.MustHaveTag(projectVarsDataKey{}, "KeyID")
+	dataKeyWrappedKeyKey = bsonutil.MustHaveTag(projectVarsDataKey{}, "WrappedKey")
+)
+
+// projectVarsDataKey is a data key that encrypts project variables, wrapped
+// by the configured key provider.
+type projectVarsDataKey struct {
+	Id         string    `bson:"_id"`
+	CreatedAt  time.Time `bson:"created_at"`
+	Provider   string    `bson:"provider"`
+	KeyID      string    `bson:"key_id"`
+	WrappedKey []byte    `bson:"wrapped_key"`
+}
+
+// projectVarsKeys caches the key provider and unwrapped data keys so that
+// the key provider is not called every time a variable is decrypted.
+var projectVarsKeys = struct {
+	mu       sync.Mutex
+	conf     evergreen.ProjectVarsEncryptionConfig
+	provider envelope.KeyProvider
+	dataKeys map[string][]byte
+}{dataKeys: map[string][]byte{}}
+
+// maxRegexRepeat is the largest number of repetitions that a MongoDB regular
+// expression can match.
+const maxRegexRepeat = 65535
+
+// encodedCiphertextLen returns the length of the base64-encoded ciphertext of
+// an encrypted value whose plaintext has the given length. Since encryption
+// doesn't hide the length of the plaintext, this is used to skip decrypting
+// values that can't match a plaintext.
+func encodedCiphertextLen(plaintextLen int) int {
+	return base64.StdEncoding.EncodedLen(envelope.CiphertextSize(plaintextLen))
+}
+
+// encryptedVarPattern returns a regular expression that matches encrypted
+// values whose plaintext has the given length. If the values would be too
+// long for a regular expression to match their length, it matches all
+// encrypted values.
+func encryptedVarPattern(plaintextLen int) string {
+	n := encodedCiphertextLen(plaintextLen)
+	if n > maxRegexRepeat {
+		return "^" + encryptedVarPrefix
+	}
+	return fmt.Sprintf("^%s[^:]+:[^:]+:[A-Za-z0-9+/=]{%d}$", encryptedVarPrefix, n)
+}
+
+// couldBeEncryptedValue returns whether the encrypted value could be an
+// encryption of the plaintext.
+func couldBeEncryptedValue(encrypted, plaintext string) bool {
+	encoded := encrypted[strings.LastIndex(encrypted, ":")+1:]
+	return len(encoded) == encodedCiphertextLen(len(plaintext))
+}
+
+// isEncryptedVar returns whether the project variable value is encrypted.
+func isEncryptedVar(val string) bool {
+	return strings.HasPrefix(val, encryptedVarPrefix)
+}
+
+// isSensitiveVar returns whether the project variable should be encrypted
+// at rest.
+func (projectVars *ProjectVars) isSensitiveVar(key string) bool {
+	return projectVars.PrivateVars[key] || projectVars.AdminOnlyVars[key]
+}
+
+// encryptionID returns the ID of the project variables that the variable's
+// value was encrypted for. This is the ID of the variables themselves unless
+// the value was merged from, or the variables copied from, other variables.
+func (projectVars *ProjectVars) encryptionID(key string) string {
+	if id, ok := projectVars.encryptedFor[key]; ok {
+		return id
+	}
+	return projectVars.Id
+}
+
+// setEncryptionID records the ID of the project variables that the
+// variable's value was encrypted for, if the value is encrypted.
+func (projectVars *ProjectVars) setEncryptionID(key, id string) {
+	if !isEncryptedVar(projectVars.Vars[key]) {
+		return
+	}
+	if projectVars.encryptedFor == nil {
+		projectVars.encryptedFor = map[string]string{}
+	}
+	projectVars.encryptedFor[key] = id
+}
+
+// changeID changes the ID of the project variables, keeping track of the ID
+// that their encrypted values were encrypted for so that they can still be
+// decrypted, and are re-encrypted for the new ID when they're saved.
+func (projectVars *ProjectVars) changeID(id string) {
+	for key := range projectVars.Vars {
+		projectVars.setEncryptionID(key, projectVars.encryptionID(key))
+	}
+	projectVars.Id = id
+}
+
+// isCurrentlyEncrypted returns whether the variable's value is encrypted in
+// the current format for the variables' own ID.
+func (projectVars *ProjectVars) isCurrentlyEncrypted(key string) bool {
+	return strings.HasPrefix(projectVars.Vars[key], encryptedVarV2Prefix) && projectVars.encryptionID(key) == projectVars.Id
+}
+
+func projectVarsEncryptionConfig() evergreen.ProjectVarsEncryptionConfig {
+	settings := evergreen.GetEnvironment().Settings()
+	if settings == nil {
+		return evergreen.ProjectVarsEncryptionConfig{}
+	}
+	return settings.ProjectVarsEncryption
+}
+
+// getProjectVarsKeyProvider returns the configured key provider, or nil if
+// project variable encryption is disabled. The caller must hold the lock.
+func getProjectVarsKeyProvider() (envelope.KeyProvider, error) {
+	conf := projectVarsEncryptionConfig()
+	if !conf.Enabled() {
+		return nil, nil
+	}
+	if projectVarsKeys.provider != nil && projectVarsKeys.conf == conf {
+		return projectVarsKeys.provider, nil
+	}
+
+	var provider envelope.KeyProvider
+	var err error
+	switch conf.Provider {
+	case evergreen.ProjectVarsEncryptionProviderLocal:
+		provider, err = envelope.NewLocalProvider(conf.KeyringPath)
+	case evergreen.ProjectVarsEncryptionProviderKMS:
+		provider, err = envelope.NewKMSProvider(conf.KMSKeyID, conf.KMSRegion)
+	default:
+		err = errors.Errorf("unrecognized project variable encryption provider '%s'", conf.Provider)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "creating project variable encryption key provider")
+	}
+
+	projectVarsKeys.conf = conf
+	projectVarsKeys.provider = provider
+	return provider, nil
+}
+
+// getDataKey returns the unwrapped data key with the given ID. The caller
+// must hold the lock.
+func getDataKey(ctx context.Context, id string) ([]byte, error) {
+	if key, ok := projectVarsKeys.dataKeys[id]; ok {
+		return key, nil
+	}
+
+	dataKey := &projectVarsDataKey{}
+	err := db.FindOneQ(ProjectVarsDataKeysCollection, db.Query(bson.M{dataKeyIdKey: id}), dataKey)
+	if adb.ResultsNotFound(err) {
+		return nil, errors.Errorf("data key '%s' not found", id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding data key '%s'", id)
+	}
+
+	provider, err := getProjectVarsKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, errors.New("project variable encryption is not configured")
+	}
+	if provider.Name() != dataKey.Provider {
+		return nil, errors.Errorf("data key '%s' was wrapped by key provider '%s' but the configured provider is '%s'", id, dataKey.Provider, provider.Name())
+	}
+
+	key, err := provider.UnwrapKey(ctx, dataKey.KeyID, dataKey.WrappedKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unwrapping data key '%s'", id)
+	}
+	projectVarsKeys.dataKeys[id] = key
+
+	return key, nil
+}
+
+// getActiveDataKey returns the newest data key, creating one if none exist
+// yet. The caller must hold the lock.
+func getActiveDataKey(ctx context.Context, provider envelope.KeyProvider) (string, []byte, error) {
+	dataKey := &projectVarsDataKey{}
+	q := db.Query(bson.M{}).Sort([]string{"-" + dataKeyCreatedAtKey})
+	err := db.FindOneQ(ProjectVarsDataKeysCollection, q, dataKey)
+	if err != nil && !adb.ResultsNotFound(err) {
+		return "", nil, errors.Wrap(err, "finding active data key")
+	}
+	if err == nil {
+		key, err := getDataKey(ctx, dataKey.Id)
+		return dataKey.Id, key, err
+	}
+
+	return createDataKey(ctx, provider)
+}
+
+// createDataKey generates and stores a new data key, which becomes the
+// active data key. The caller must hold the lock.
+func createDataKey(ctx context.Context, provider envelope.KeyProvider) (string, []byte, error) {
+	key, err := envelope.GenerateDataKey()
+	if err != nil {
+		return "", nil, err
+	}
+	keyID, wrapped, err := provider.WrapKey(ctx, key)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "wrapping new data key")
+	}
+
+	dataKey := projectVarsDataKey{
+		Id:         utility.RandomString(),
+		CreatedAt:  time.Now(),
+		Provider:   provider.Name(),
+		KeyID:      keyID,
+		WrappedKey: wrapped,
+	}
+	if err = db.Insert(ProjectVarsDataKeysCollection, dataKey); err != nil {
+		return "", nil, errors.Wrap(err, "inserting new data key")
+	}
+	projectVarsKeys.dataKeys[dataKey.Id] = key
+
+	return dataKey.Id, key, nil
+}
+
+// encryptVar encrypts a project variable value for the project variables
+// with the given ID.
+func encryptVar(dataKeyID string, dataKey []byte, val, projectVarsID string) (string, error) {
+	ciphertext, err := envelope.Encrypt(dataKey, []byte(val), []byte(projectVarsID))
+	if err != nil {
+		return "", err
+	}
+	return encryptedVarV2Prefix + dataKeyID + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decryptVar decrypts a project variable value that was encrypted for the
+// project variables with the given ID. The caller must hold the lock.
+func decryptVar(ctx context.Context, val, projectVarsID string) (string, error) {
+	var additionalData []byte
+	switch {
+	case strings.HasPrefix(val, encryptedVarV2Prefix):
+		val = strings.TrimPrefix(val, encryptedVarV2Prefix)
+		additionalData = []byte(projectVarsID)
+	case strings.HasPrefix(val, encryptedVarV1Prefix):
+		val = strings.TrimPrefix(val, encryptedVarV1Prefix)
+	default:
+		return "", errors.New("unrecognized encrypted value version")
+	}
+	dataKeyID, encoded, ok := strings.Cut(val, ":")
+	if !ok {
+		return "", errors.New("malformed encrypted value")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding encrypted value")
+	}
+	dataKey, err := getDataKey(ctx, dataKeyID)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := envelope.Decrypt(dataKey, ciphertext, additionalData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// varsForStorage returns the variables as they should be stored: if
+// encryption is enabled, private and admin-only values are encrypted for the
+// variables' ID, and values that are no longer private or admin-only are
+// decrypted. The variables themselves are not modified.
+func (projectVars *ProjectVars) varsForStorage() (map[string]string, error) {
+	if projectVars.Vars == nil {
+		return nil, nil
+	}
+
+	projectVarsKeys.mu.Lock()
+	defer projectVarsKeys.mu.Unlock()
+
+	provider, err := getProjectVarsKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := evergreen.GetEnvironment().Context()
+	defer cancel()
+
+	var dataKeyID string
+	var dataKey []byte
+	vars := make(map[string]string, len(projectVars.Vars))
+	for key, val := range projectVars.Vars {
+		sensitive := projectVars.isSensitiveVar(key)
+		// Values that were encrypted for other variables or in an old format
+		// are decrypted so they can be re-encrypted for these variables.
+		if isEncryptedVar(val) && (!sensitive || (provider != nil && !projectVars.isCurrentlyEncrypted(key))) {
+			if val, err = decryptVar(ctx, val, projectVars.encryptionID(key)); err != nil {
+				return nil, errors.Wrapf(err, "decrypting variable '%s'", key)
+			}
+		}
+		if provider != nil && sensitive && !isEncryptedVar(val) {
+			if dataKey == nil {
+				if dataKeyID, dataKey, err = getActiveDataKey(ctx, provider); err != nil {
+					return nil, errors.Wrap(err, "getting data key")
+				}
+			}
+			if val, err = encryptVar(dataKeyID, dataKey, val, projectVars.Id); err != nil {
+				return nil, errors.Wrapf(err, "encrypting variable '%s'", key)
+			}
+		}
+		vars[key] = val
+	}
+
+	return vars, nil
+}
+
+// Decrypt decrypts all encrypted variable values in place. This should only
+// be used where the caller is authorized to see private variables.
+func (projectVars *ProjectVars) Decrypt() error {
+	if projectVars == nil {
+		return nil
+	}
+
+	projectVarsKeys.mu.Lock()
+	defer projectVarsKeys.mu.Unlock()
+
+	ctx, cancel := evergreen.GetEnvironment().Context()
+	defer cancel()
+
+	for key, val := range projectVars.Vars {
+		if !isEncryptedVar(val) {
+			continue
+		}
+		plaintext, err := decryptVar(ctx, val, projectVars.encryptionID(key))
+		if err != nil {
+			return errors.Wrapf(err, "decrypting variable '%s' for project '%s'", key, projectVars.Id)
+		}
+		projectVars.Vars[key] = plaintext
+	}
+	projectVars.encryptedFor = nil
+
+	return nil
+}
+
+// EncryptAllProjectVars encrypts the private and admin-only variables of
+// every project that are not yet encrypted, or that are encrypted in an old
+// format. If reencrypt is set, a new data
+// key is generated and all sensitive variables are re-encrypted with it. It
+// returns the number of projects that were updated.
+func EncryptAllProjectVars(ctx context.Context, reencrypt bool) (int, error) {
+	conf := projectVarsEncryptionConfig()
+	if !conf.Enabled() {
+		return 0, errors.New("project variable encryption is not configured")
+	}
+
+	if reencrypt {
+		projectVarsKeys.mu.Lock()
+		provider, err := getProjectVarsKeyProvider()
+		if err == nil {
+			_, _, err = createDataKey(ctx, provider)
+		}
+		projectVarsKeys.mu.Unlock()
+		if err != nil {
+			return 0, errors.Wrap(err, "creating new data key")
+		}
+	}
+
+	allVars := []ProjectVars{}
+	if err := db.FindAllQ(ProjectVarsCollection, db.Query(bson.M{}), &allVars); err != nil {
+		return 0, errors.Wrap(err, "finding project variables")
+	}
+
+	updated := 0
+	for _, projectVars := range allVars {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+		if !projectVars.needsEncryption(reencrypt) {
+			continue
+		}
+		if reencrypt {
+			if err := projectVars.Decrypt(); err != nil {
+				return updated, err
+			}
+		}
+		if _, err := projectVars.Upsert(); err != nil {
+			return updated, errors.Wrapf(err, "encrypting variables for project '%s'", projectVars.Id)
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+func (projectVars *ProjectVars) needsEncryption(reencrypt bool) bool {
+	for key := range projectVars.Vars {
+		if projectVars.isSensitiveVar(key) && (reencrypt || !projectVars.isCurrentlyEncrypted(key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RotateProjectVarsKeyEncryptionKey rewraps every data key with the key
+// provider's current key encryption key so that previous key encryption
+// keys can be retired. It returns the number of data keys that were
+// rewrapped.
+func RotateProjectVarsKeyEncryptionKey(ctx context.Context) (int, error) {
+	projectVarsKeys.mu.Lock()
+	defer projectVarsKeys.mu.Unlock()
+
+	provider, err := getProjectVarsKeyProvider()
+	if err != nil {
+		return 0, err
+	}
+	if provider == nil {
+		return 0, errors.New("project variable encryption is not configured")
+	}
+
+	dataKeys := []projectVarsDataKey{}
+	if err = db.FindAllQ(ProjectVarsDataKeysCollection, db.Query(bson.M{}), &dataKeys); err != nil {
+		return 0, errors.Wrap(err, "finding data keys")
+	}
+
+	for i, dataKey := range dataKeys {
+		key, err := getDataKey(ctx, dataKey.Id)
+		if err != nil {
+			return i, err
+		}
+		keyID, wrapped, err := provider.WrapKey(ctx, key)
+		if err != nil {
+			return i, errors.Wrapf(err, "rewrapping data key '%s'", dataKey.Id)
+		}
+		err = db.UpdateId(ProjectVarsDataKeysCollection, dataKey.Id, bson.M{
+			"$set": bson.M{
+				dataKeyProviderKey:   provider.Name(),
+				dataKeyKeyIDKey:      keyID,
+				dataKeyWrappedKeyKey: wrapped,
+			},
+		})
+		if err != nil {
+			return i, errors.Wrapf(err, "updating data key '%s'", dataKey.Id)
+		}
+	}
+
+	return len(dataKeys), nil
+}
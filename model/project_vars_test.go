@@ -1,11 +1,20 @@
 package model
 
 import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/envelope"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestFindOneProjectVar(t *testing.T) {
@@ -314,3 +323,190 @@ func TestAWSVars(t *testing.T) {
 	assert.Equal(false, found.PrivateVars[ProjectAWSSSHKeyName])
 	assert.Equal(true, found.PrivateVars[ProjectAWSSSHKeyValue])
 }
+
+func TestProjectVarsEncryption(t *testing.T) {
+	require.NoError(t, db.ClearCollections(ProjectVarsCollection, ProjectVarsDataKeysCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(ProjectVarsCollection, ProjectVarsDataKeysCollection))
+	}()
+
+	kek, err := envelope.GenerateDataKey()
+	require.NoError(t, err)
+	keyringPath := filepath.Join(t.TempDir(), "keyring.yml")
+	require.NoError(t, os.WriteFile(keyringPath, []byte("primary: kek1\nkeys:\n  kek1: "+base64.StdEncoding.EncodeToString(kek)+"\n"), 0600))
+
+	settings := evergreen.GetEnvironment().Settings()
+	originalConf := settings.ProjectVarsEncryption
+	settings.ProjectVarsEncryption = evergreen.ProjectVarsEncryptionConfig{
+		Provider:    evergreen.ProjectVarsEncryptionProviderLocal,
+		KeyringPath: keyringPath,
+	}
+	defer func() {
+		settings.ProjectVarsEncryption = originalConf
+	}()
+
+	vars := &ProjectVars{
+		Id:            "project",
+		Vars:          map[string]string{"public": "a", "private": "b", "admin": "c"},
+		PrivateVars:   map[string]bool{"private": true},
+		AdminOnlyVars: map[string]bool{"admin": true},
+	}
+	_, err = vars.Upsert()
+	require.NoError(t, err)
+	assert.Equal(t, "b", vars.Vars["private"], "in-memory vars should not be modified")
+
+	dbVars, err := FindOneProjectVars(vars.Id)
+	require.NoError(t, err)
+	require.NotNil(t, dbVars)
+	assert.Equal(t, "a", dbVars.Vars["public"])
+	assert.True(t, isEncryptedVar(dbVars.Vars["private"]))
+	assert.True(t, isEncryptedVar(dbVars.Vars["admin"]))
+
+	t.Run("GetVars", func(t *testing.T) {
+		taskVars, err := dbVars.GetVars(&task.Task{Requester: evergreen.RepotrackerVersionRequester})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"public": "a", "private": "b", "admin": "c"}, taskVars)
+	})
+	t.Run("RedactPrivateVars", func(t *testing.T) {
+		redacted := dbVars.RedactPrivateVars()
+		assert.Equal(t, "", redacted.Vars["private"])
+		assert.Equal(t, "c", redacted.Vars["admin"], "admin-only vars should be decrypted")
+	})
+	t.Run("FindAndModify", func(t *testing.T) {
+		update := &ProjectVars{
+			Id:          vars.Id,
+			Vars:        map[string]string{"new_private": "d"},
+			PrivateVars: map[string]bool{"new_private": true, "private": false},
+		}
+		_, err := update.FindAndModify(nil)
+		require.NoError(t, err)
+
+		dbVars, err := FindOneProjectVars(vars.Id)
+		require.NoError(t, err)
+		assert.True(t, isEncryptedVar(dbVars.Vars["new_private"]))
+		assert.Equal(t, "b", dbVars.Vars["private"], "vars that are no longer private should be decrypted")
+	})
+	t.Run("GetVarsByValue", func(t *testing.T) {
+		matching, err := GetVarsByValue("c")
+		require.NoError(t, err)
+		require.Len(t, matching, 1)
+		assert.Equal(t, "c", matching[0].Vars["admin"])
+	})
+	t.Run("Reencrypt", func(t *testing.T) {
+		before, err := FindOneProjectVars(vars.Id)
+		require.NoError(t, err)
+
+		updated, err := EncryptAllProjectVars(context.Background(), true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated)
+
+		after, err := FindOneProjectVars(vars.Id)
+		require.NoError(t, err)
+		assert.NotEqual(t, before.Vars["admin"], after.Vars["admin"])
+		require.NoError(t, after.Decrypt())
+		assert.Equal(t, "c", after.Vars["admin"])
+	})
+	t.Run("RotateKeyEncryptionKey", func(t *testing.T) {
+		newKEK, err := envelope.GenerateDataKey()
+		require.NoError(t, err)
+		keyring := "primary: kek2\nkeys:\n  kek1: " + base64.StdEncoding.EncodeToString(kek) + "\n  kek2: " + base64.StdEncoding.EncodeToString(newKEK) + "\n"
+		require.NoError(t, os.WriteFile(keyringPath, []byte(keyring), 0600))
+		// Reload the keyring with the new primary key.
+		projectVarsKeys.provider = nil
+
+		rotated, err := RotateProjectVarsKeyEncryptionKey(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, rotated)
+
+		dataKeys := []projectVarsDataKey{}
+		require.NoError(t, db.FindAllQ(ProjectVarsDataKeysCollection, db.Query(bson.M{}), &dataKeys))
+		for _, dataKey := range dataKeys {
+			assert.Equal(t, "kek2", dataKey.KeyID)
+		}
+	})
+	t.Run("ValueCopiedToOtherProject", func(t *testing.T) {
+		dbVars, err := FindOneProjectVars(vars.Id)
+		require.NoError(t, err)
+		copied := ProjectVars{
+			Id:          "other_project",
+			Vars:        map[string]string{"admin": dbVars.Vars["admin"]},
+			PrivateVars: map[string]bool{"admin": true},
+		}
+		require.True(t, isEncryptedVar(copied.Vars["admin"]))
+		require.NoError(t, db.Insert(ProjectVarsCollection, copied))
+		defer func() {
+			assert.NoError(t, db.Remove(ProjectVarsCollection, bson.M{projectVarIdKey: copied.Id}))
+		}()
+
+		otherVars, err := FindOneProjectVars(copied.Id)
+		require.NoError(t, err)
+		assert.Error(t, otherVars.Decrypt(), "values encrypted for another project should not decrypt")
+	})
+	t.Run("CopyProjectVars", func(t *testing.T) {
+		require.NoError(t, CopyProjectVars(vars.Id, "copied_project"))
+		defer func() {
+			assert.NoError(t, db.Remove(ProjectVarsCollection, bson.M{projectVarIdKey: "copied_project"}))
+		}()
+
+		original, err := FindOneProjectVars(vars.Id)
+		require.NoError(t, err)
+		copied, err := FindOneProjectVars("copied_project")
+		require.NoError(t, err)
+		require.NotNil(t, copied)
+		assert.True(t, isEncryptedVar(copied.Vars["admin"]))
+		assert.NotEqual(t, original.Vars["admin"], copied.Vars["admin"], "copied values should be re-encrypted for the new project")
+		require.NoError(t, copied.Decrypt())
+		assert.Equal(t, "c", copied.Vars["admin"])
+	})
+	t.Run("MergedRepoVars", func(t *testing.T) {
+		repoVars, err := FindOneProjectVars(vars.Id)
+		require.NoError(t, err)
+		branchVars := &ProjectVars{Id: "branch_project", Vars: map[string]string{"public": "z"}}
+		branchVars.MergeWithRepoVars(repoVars)
+
+		taskVars, err := branchVars.GetVars(&task.Task{Requester: evergreen.RepotrackerVersionRequester})
+		require.NoError(t, err)
+		assert.Equal(t, "z", taskVars["public"])
+		assert.Equal(t, "c", taskVars["admin"], "repo values should be decrypted for the repo")
+		assert.Equal(t, "c", branchVars.RedactPrivateVars().Vars["admin"])
+	})
+	t.Run("LegacyValue", func(t *testing.T) {
+		projectVarsKeys.mu.Lock()
+		provider, err := getProjectVarsKeyProvider()
+		require.NoError(t, err)
+		dataKeyID, dataKey, err := getActiveDataKey(context.Background(), provider)
+		projectVarsKeys.mu.Unlock()
+		require.NoError(t, err)
+		ciphertext, err := envelope.Encrypt(dataKey, []byte("e"), nil)
+		require.NoError(t, err)
+		legacy := ProjectVars{
+			Id:          "legacy_project",
+			Vars:        map[string]string{"private": encryptedVarV1Prefix + dataKeyID + ":" + base64.StdEncoding.EncodeToString(ciphertext)},
+			PrivateVars: map[string]bool{"private": true},
+		}
+		require.NoError(t, db.Insert(ProjectVarsCollection, legacy))
+
+		updated, err := EncryptAllProjectVars(context.Background(), false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated, "only values in the old format should be re-encrypted")
+
+		dbVars, err := FindOneProjectVars(legacy.Id)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(dbVars.Vars["private"], encryptedVarV2Prefix))
+		require.NoError(t, dbVars.Decrypt())
+		assert.Equal(t, "e", dbVars.Vars["private"])
+	})
+}
+
+func TestEncryptedVarPattern(t *testing.T) {
+	key, err := envelope.GenerateDataKey()
+	require.NoError(t, err)
+	encrypted, err := encryptVar("data_key", key, "hunter2", "project")
+	require.NoError(t, err)
+
+	assert.Regexp(t, encryptedVarPattern(len("hunter2")), encrypted)
+	assert.NotRegexp(t, encryptedVarPattern(len("hunter")), encrypted)
+	assert.True(t, couldBeEncryptedValue(encrypted, "hunter3"))
+	assert.False(t, couldBeEncryptedValue(encrypted, "hunter22"))
+	assert.Equal(t, "^"+encryptedVarPrefix, encryptedVarPattern(maxRegexRepeat))
+}
@@ -89,21 +89,7 @@ type TaskReliability struct {
 // https://en.wikipedia.org/wiki/Binomial_proportion_confidence_interval#Wilson_score_interval
 // and return the lower value (for success rates).
 func (s *TaskReliability) calculateSuccessRate() {
-	total := float64(s.NumTotal)
-	success := float64(s.NumSuccess)
-	low := 0.0
-	high := 0.0
-	p := 0.0
-
-	if total != 0 {
-		p = success / total
-
-		dist := s.Z * math.Sqrt((p*(1.-p)+s.Z*s.Z/(4.*total))/total)
-		denominator := 1. + s.Z*s.Z/total
-		c1 := p + s.Z*s.Z/(2.*total)
-		high = math.Min(1, (c1+dist)/denominator)
-		low = math.Max(0, (c1-dist)/denominator)
-	}
+	low, p, high := wilsonScoreInterval(s.NumSuccess, s.NumTotal, s.Z)
 	s.SuccessRate = (math.Ceil(low*100) / 100)
 	grip.Info(message.Fields{
 		"message":      "calculated task success rate",
@@ -118,6 +104,26 @@ func (s *TaskReliability) calculateSuccessRate() {
 	})
 }
 
+// wilsonScoreInterval returns the lower bound, observed proportion, and upper
+// bound of the Wilson score interval for the given number of successes out of
+// the total number of trials.
+func wilsonScoreInterval(successes, total int, z float64) (low, p, high float64) {
+	if total == 0 {
+		return 0, 0, 0
+	}
+
+	n := float64(total)
+	p = float64(successes) / n
+
+	dist := z * math.Sqrt((p*(1.-p)+z*z/(4.*n))/n)
+	denominator := 1. + z*z/n
+	c1 := p + z*z/(2.*n)
+	high = math.Min(1, (c1+dist)/denominator)
+	low = math.Max(0, (c1-dist)/denominator)
+
+	return low, p, high
+}
+
 // Create a TaskReliability struct from the task stats and calculate the success rate
 // using the z score.
 func newTaskReliability(taskStat taskstats.TaskStats, z float64) TaskReliability {
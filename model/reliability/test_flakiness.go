@@ -0,0 +1,209 @@
+package reliability
+
+// This file provides the query logic for test flakiness scores. See
+// teststats/db.go for details on the structure of the backing
+// daily_test_stats collection.
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/teststats"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestGroupBy represents the possible groupings of test flakiness scores.
+type TestGroupBy string
+
+const (
+	// TestGroupByTask groups the scores by test and task, aggregating across
+	// build variants.
+	TestGroupByTask TestGroupBy = "test_task"
+	// TestGroupByVariant groups the scores by test, task, and build variant.
+	TestGroupByVariant TestGroupBy = "test_task_variant"
+)
+
+// TestFlakinessFilter represents search and aggregation parameters when
+// querying test flakiness scores.
+type TestFlakinessFilter struct {
+	Project       string
+	Requesters    []string
+	AfterDate     time.Time
+	BeforeDate    time.Time
+	Tests         []string
+	Tasks         []string
+	BuildVariants []string
+	GroupBy       TestGroupBy
+	// MinCommits excludes tests that ran on fewer than this number of
+	// commits, since their scores are not meaningful.
+	MinCommits   int
+	Limit        int
+	Significance float64
+}
+
+// ValidateForTestFlakiness validates that the filter is valid for querying
+// test flakiness scores.
+func (f *TestFlakinessFilter) ValidateForTestFlakiness() error {
+	catcher := grip.NewBasicCatcher()
+
+	catcher.NewWhen(f.Project == "", "must specify a project")
+	catcher.NewWhen(len(f.Requesters) == 0, "must specify at least one requester")
+	if !f.AfterDate.Equal(utility.GetUTCDay(f.AfterDate)) {
+		catcher.New("invalid 'after' date")
+	}
+	if !f.BeforeDate.Equal(utility.GetUTCDay(f.BeforeDate)) {
+		catcher.New("invalid 'before' date")
+	}
+	if f.BeforeDate.Before(f.AfterDate) {
+		catcher.New("'after' date restriction must be earlier than 'before' date restriction")
+	}
+	if f.GroupBy != TestGroupByTask && f.GroupBy != TestGroupByVariant {
+		catcher.Errorf("invalid group by '%s'", f.GroupBy)
+	}
+	if f.MinCommits < 0 {
+		catcher.New("minimum number of commits cannot be negative")
+	}
+	if f.Limit > MaxQueryLimit || f.Limit <= 0 {
+		catcher.New("invalid limit")
+	}
+	if f.Significance > MaxSignificanceLimit || f.Significance < MinSignificanceLimit {
+		catcher.New("invalid significance")
+	}
+
+	return catcher.Resolve()
+}
+
+// TestFlakiness represents the flakiness of a test over a period of time.
+type TestFlakiness struct {
+	TestName     string `bson:"test_name"`
+	TaskName     string `bson:"task_name"`
+	BuildVariant string `bson:"variant"`
+	NumPass      int    `bson:"num_pass"`
+	NumFail      int    `bson:"num_fail"`
+	NumCommits   int    `bson:"num_commits"`
+	NumFlaky     int    `bson:"num_flaky"`
+	// FlakeRate is the proportion of commits on which the test was flaky.
+	FlakeRate float64 `bson:"-"`
+	// FlakinessScore is the lower bound of the Wilson score interval of the
+	// flake rate, so tests that flaked on only a handful of commits do not
+	// outrank tests that consistently flake.
+	FlakinessScore float64 `bson:"-"`
+	Z              float64 `bson:"-"`
+}
+
+func (s *TestFlakiness) calculateFlakinessScore() {
+	low, p, _ := wilsonScoreInterval(s.NumFlaky, s.NumCommits, s.Z)
+	s.FlakeRate = p
+	// Rounding error can leave a tiny positive lower bound for tests that
+	// never flaked, which must not be rounded up to a nonzero score.
+	if s.NumFlaky == 0 {
+		s.FlakinessScore = 0
+		return
+	}
+	s.FlakinessScore = math.Ceil(low*100) / 100
+}
+
+// testFlakinessPipeline creates an aggregation pipeline to sum the daily test
+// stats matching the filter.
+func (f TestFlakinessFilter) testFlakinessPipeline() []bson.M {
+	match := bson.M{
+		teststats.DBTestStatsIDProjectKeyFull:   f.Project,
+		teststats.DBTestStatsIDRequesterKeyFull: bson.M{"$in": f.Requesters},
+		teststats.DBTestStatsIDDateKeyFull: bson.M{
+			"$gte": f.AfterDate,
+			"$lte": f.BeforeDate,
+		},
+	}
+	if len(f.Tests) > 0 {
+		match[teststats.DBTestStatsIDTestNameKeyFull] = bson.M{"$in": f.Tests}
+	}
+	if len(f.Tasks) > 0 {
+		match[teststats.DBTestStatsIDTaskNameKeyFull] = bson.M{"$in": f.Tasks}
+	}
+	if len(f.BuildVariants) > 0 {
+		match[teststats.DBTestStatsIDBuildVariantKeyFull] = bson.M{"$in": f.BuildVariants}
+	}
+
+	id := bson.M{
+		"test_name": "$" + teststats.DBTestStatsIDTestNameKeyFull,
+		"task_name": "$" + teststats.DBTestStatsIDTaskNameKeyFull,
+	}
+	if f.GroupBy == TestGroupByVariant {
+		id["variant"] = "$" + teststats.DBTestStatsIDBuildVariantKeyFull
+	}
+
+	return []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id":         id,
+			"num_pass":    bson.M{"$sum": "$" + teststats.DBTestStatsNumPassKey},
+			"num_fail":    bson.M{"$sum": "$" + teststats.DBTestStatsNumFailKey},
+			"num_commits": bson.M{"$sum": "$" + teststats.DBTestStatsNumCommitsKey},
+			"num_flaky":   bson.M{"$sum": "$" + teststats.DBTestStatsNumFlakyKey},
+		}},
+		{"$match": bson.M{"num_commits": bson.M{"$gte": f.MinCommits}}},
+		{"$project": bson.M{
+			"_id":         0,
+			"test_name":   "$_id.test_name",
+			"task_name":   "$_id.task_name",
+			"variant":     "$_id.variant",
+			"num_pass":    1,
+			"num_fail":    1,
+			"num_commits": 1,
+			"num_flaky":   1,
+		}},
+	}
+}
+
+// GetTestFlakinessScores queries the precomputed test statistics using a
+// filter and returns the tests ordered from most to least flaky. The
+// flakiness score is the lower bound of the Wilson confidence interval of the
+// proportion of commits on which the test both failed and passed.
+func GetTestFlakinessScores(filter TestFlakinessFilter) ([]TestFlakiness, error) {
+	if err := filter.ValidateForTestFlakiness(); err != nil {
+		return nil, errors.Wrap(err, "invalid test flakiness filter")
+	}
+
+	var scores []TestFlakiness
+	if err := db.Aggregate(teststats.DailyTestStatsCollection, filter.testFlakinessPipeline(), &scores); err != nil {
+		return nil, errors.Wrap(err, "aggregating test statistics")
+	}
+
+	z := significanceToZ(filter.Significance)
+	for i := range scores {
+		scores[i].Z = z
+		scores[i].calculateFlakinessScore()
+	}
+
+	return sortAndLimitTestFlakiness(scores, filter.Limit), nil
+}
+
+// sortAndLimitTestFlakiness orders the scores from most to least flaky and
+// returns at most limit of them.
+func sortAndLimitTestFlakiness(scores []TestFlakiness, limit int) []TestFlakiness {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].FlakinessScore != scores[j].FlakinessScore {
+			return scores[i].FlakinessScore > scores[j].FlakinessScore
+		}
+		if scores[i].NumFlaky != scores[j].NumFlaky {
+			return scores[i].NumFlaky > scores[j].NumFlaky
+		}
+		if scores[i].TestName != scores[j].TestName {
+			return scores[i].TestName < scores[j].TestName
+		}
+		if scores[i].TaskName != scores[j].TaskName {
+			return scores[i].TaskName < scores[j].TaskName
+		}
+		return scores[i].BuildVariant < scores[j].BuildVariant
+	})
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+
+	return scores
+}
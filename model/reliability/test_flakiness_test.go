@@ -0,0 +1,170 @@
+package reliability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/teststats"
+	_ "github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateForTestFlakiness(t *testing.T) {
+	validFilter := func() TestFlakinessFilter {
+		return TestFlakinessFilter{
+			Project:      project,
+			Requesters:   []string{evergreen.RepotrackerVersionRequester},
+			AfterDate:    day1,
+			BeforeDate:   day2,
+			GroupBy:      TestGroupByTask,
+			Limit:        10,
+			Significance: DefaultSignificance,
+		}
+	}
+
+	filter := validFilter()
+	assert.NoError(t, filter.ValidateForTestFlakiness())
+
+	for name, modify := range map[string]func(*TestFlakinessFilter){
+		"MissingProject":        func(f *TestFlakinessFilter) { f.Project = "" },
+		"MissingRequesters":     func(f *TestFlakinessFilter) { f.Requesters = nil },
+		"AfterDateNotDay":       func(f *TestFlakinessFilter) { f.AfterDate = day1.Add(time.Hour) },
+		"BeforeDateBeforeAfter": func(f *TestFlakinessFilter) { f.BeforeDate, f.AfterDate = day1, day2 },
+		"InvalidGroupBy":        func(f *TestFlakinessFilter) { f.GroupBy = "distro" },
+		"NegativeMinCommits":    func(f *TestFlakinessFilter) { f.MinCommits = -1 },
+		"ZeroLimit":             func(f *TestFlakinessFilter) { f.Limit = 0 },
+		"LimitTooLarge":         func(f *TestFlakinessFilter) { f.Limit = MaxQueryLimit + 1 },
+		"InvalidSignificance":   func(f *TestFlakinessFilter) { f.Significance = 2 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			filter := validFilter()
+			modify(&filter)
+			assert.Error(t, filter.ValidateForTestFlakiness())
+		})
+	}
+}
+
+func TestCalculateFlakinessScore(t *testing.T) {
+	z := significanceToZ(DefaultSignificance)
+
+	noRuns := TestFlakiness{Z: z}
+	noRuns.calculateFlakinessScore()
+	assert.Zero(t, noRuns.FlakeRate)
+	assert.Zero(t, noRuns.FlakinessScore)
+
+	neverFlaky := TestFlakiness{NumCommits: 20, Z: z}
+	neverFlaky.calculateFlakinessScore()
+	assert.Zero(t, neverFlaky.FlakeRate)
+	assert.Zero(t, neverFlaky.FlakinessScore)
+
+	// A test that flaked on a few commits scores lower than one with the
+	// same flake rate over many more commits.
+	fewCommits := TestFlakiness{NumFlaky: 1, NumCommits: 4, Z: z}
+	fewCommits.calculateFlakinessScore()
+	manyCommits := TestFlakiness{NumFlaky: 25, NumCommits: 100, Z: z}
+	manyCommits.calculateFlakinessScore()
+	assert.Equal(t, 0.25, fewCommits.FlakeRate)
+	assert.Equal(t, 0.25, manyCommits.FlakeRate)
+	assert.Less(t, fewCommits.FlakinessScore, manyCommits.FlakinessScore)
+	assert.Less(t, manyCommits.FlakinessScore, manyCommits.FlakeRate)
+}
+
+func TestSortAndLimitTestFlakiness(t *testing.T) {
+	scores := []TestFlakiness{
+		{TestName: "b", FlakinessScore: 0.1, NumFlaky: 1},
+		{TestName: "c", FlakinessScore: 0.5, NumFlaky: 1},
+		{TestName: "a", FlakinessScore: 0.1, NumFlaky: 1},
+		{TestName: "d", FlakinessScore: 0.1, NumFlaky: 3},
+	}
+
+	sorted := sortAndLimitTestFlakiness(scores, 10)
+	require.Len(t, sorted, 4)
+	assert.Equal(t, "c", sorted[0].TestName)
+	assert.Equal(t, "d", sorted[1].TestName)
+	assert.Equal(t, "a", sorted[2].TestName)
+	assert.Equal(t, "b", sorted[3].TestName)
+
+	limited := sortAndLimitTestFlakiness(scores, 2)
+	require.Len(t, limited, 2)
+	assert.Equal(t, "c", limited[0].TestName)
+	assert.Equal(t, "d", limited[1].TestName)
+}
+
+func TestGetTestFlakinessScores(t *testing.T) {
+	require.NoError(t, db.Clear(teststats.DailyTestStatsCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(teststats.DailyTestStatsCollection))
+	}()
+
+	insertStats := func(testName, variant string, date time.Time, numCommits, numFlaky int) {
+		require.NoError(t, db.Insert(teststats.DailyTestStatsCollection, teststats.DBTestStats{
+			Id: teststats.DBTestStatsID{
+				TestName:     testName,
+				TaskName:     task1,
+				BuildVariant: variant,
+				Project:      project,
+				Requester:    evergreen.RepotrackerVersionRequester,
+				Date:         date,
+			},
+			NumPass:    numCommits - numFlaky,
+			NumFail:    numFlaky,
+			NumCommits: numCommits,
+			NumFlaky:   numFlaky,
+		}))
+	}
+	insertStats("flaky", variant1, day1, 10, 4)
+	insertStats("flaky", variant2, day2, 10, 1)
+	insertStats("stable", variant1, day1, 20, 0)
+	insertStats("rare", variant1, day1, 1, 1)
+	insertStats("old", variant1, day1.Add(-24*time.Hour), 10, 10)
+
+	filter := TestFlakinessFilter{
+		Project:      project,
+		Requesters:   []string{evergreen.RepotrackerVersionRequester},
+		AfterDate:    day1,
+		BeforeDate:   day2,
+		GroupBy:      TestGroupByTask,
+		MinCommits:   2,
+		Limit:        10,
+		Significance: DefaultSignificance,
+	}
+
+	t.Run("GroupByTask", func(t *testing.T) {
+		scores, err := GetTestFlakinessScores(filter)
+		require.NoError(t, err)
+		require.Len(t, scores, 2)
+
+		assert.Equal(t, "flaky", scores[0].TestName)
+		assert.Equal(t, task1, scores[0].TaskName)
+		assert.Empty(t, scores[0].BuildVariant)
+		assert.Equal(t, 20, scores[0].NumCommits)
+		assert.Equal(t, 5, scores[0].NumFlaky)
+		assert.Equal(t, 0.25, scores[0].FlakeRate)
+		assert.True(t, scores[0].FlakinessScore > 0)
+
+		assert.Equal(t, "stable", scores[1].TestName)
+		assert.Zero(t, scores[1].FlakinessScore)
+	})
+	t.Run("GroupByVariant", func(t *testing.T) {
+		variantFilter := filter
+		variantFilter.GroupBy = TestGroupByVariant
+		variantFilter.Tests = []string{"flaky"}
+		scores, err := GetTestFlakinessScores(variantFilter)
+		require.NoError(t, err)
+		require.Len(t, scores, 2)
+
+		assert.Equal(t, variant1, scores[0].BuildVariant)
+		assert.Equal(t, 4, scores[0].NumFlaky)
+		assert.Equal(t, variant2, scores[1].BuildVariant)
+		assert.Equal(t, 1, scores[1].NumFlaky)
+	})
+	t.Run("InvalidFilter", func(t *testing.T) {
+		invalidFilter := filter
+		invalidFilter.Limit = 0
+		_, err := GetTestFlakinessScores(invalidFilter)
+		assert.Error(t, err)
+	})
+}
@@ -86,6 +86,7 @@ var (
 	PriorityKey                    = bsonutil.MustHaveTag(Task{}, "Priority")
 	ActivatedByKey                 = bsonutil.MustHaveTag(Task{}, "ActivatedBy")
 	StepbackDepthKey               = bsonutil.MustHaveTag(Task{}, "StepbackDepth")
+	StepbackInfoKey                = bsonutil.MustHaveTag(Task{}, "StepbackInfo")
 	ExecutionTasksKey              = bsonutil.MustHaveTag(Task{}, "ExecutionTasks")
 	DisplayOnlyKey                 = bsonutil.MustHaveTag(Task{}, "DisplayOnly")
 	DisplayTaskIdKey               = bsonutil.MustHaveTag(Task{}, "DisplayTaskId")
@@ -98,6 +99,7 @@ var (
 	ResultsServiceKey              = bsonutil.MustHaveTag(Task{}, "ResultsService")
 	HasCedarResultsKey             = bsonutil.MustHaveTag(Task{}, "HasCedarResults")
 	ResultsFailedKey               = bsonutil.MustHaveTag(Task{}, "ResultsFailed")
+	QuarantinedFailuresKey         = bsonutil.MustHaveTag(Task{}, "QuarantinedFailures")
 	IsGithubCheckKey               = bsonutil.MustHaveTag(Task{}, "IsGithubCheck")
 	HostCreateDetailsKey           = bsonutil.MustHaveTag(Task{}, "HostCreateDetails")
 
@@ -424,6 +426,59 @@ func ByVersion(version string) bson.M {
 	}
 }
 
+// QueuedInDistroAtTime produces a query that returns the execution tasks in
+// the distro that were activated but had not started at the given time. Tasks
+// that never started are only returned if they are still waiting to run.
+func QueuedInDistroAtTime(distroID string, ts time.Time) bson.M {
+	return bson.M{
+		DistroIdKey:      distroID,
+		DisplayOnlyKey:   bson.M{"$ne": true},
+		ActivatedTimeKey: bson.M{"$gt": utility.ZeroTime, "$lte": ts},
+		"$or": []bson.M{
+			{StartTimeKey: bson.M{"$gt": ts}},
+			{
+				StartTimeKey: bson.M{"$lte": utility.ZeroTime},
+				StatusKey:    evergreen.TaskUndispatched,
+				ActivatedKey: true,
+			},
+		},
+	}
+}
+
+// RunningInDistroAtTime produces a query that returns the tasks in the
+// distro that were running at the given time.
+func RunningInDistroAtTime(distroID string, ts time.Time) bson.M {
+	return bson.M{
+		DistroIdKey:  distroID,
+		StartTimeKey: bson.M{"$gt": utility.ZeroTime, "$lte": ts},
+		"$or": []bson.M{
+			{FinishTimeKey: bson.M{"$gt": ts}},
+			{
+				FinishTimeKey: bson.M{"$lte": utility.ZeroTime},
+				StatusKey:     bson.M{"$in": evergreen.TaskInProgressStatuses},
+			},
+		},
+	}
+}
+
+// RanInDistroSince produces a query that returns the tasks in the distro
+// that started running and were either still running or finished after the
+// given time.
+func RanInDistroSince(distroID string, ts time.Time) bson.M {
+	return bson.M{
+		DistroIdKey:    distroID,
+		DisplayOnlyKey: bson.M{"$ne": true},
+		StartTimeKey:   bson.M{"$gt": utility.ZeroTime},
+		"$or": []bson.M{
+			{FinishTimeKey: bson.M{"$gt": ts}},
+			{
+				FinishTimeKey: bson.M{"$lte": utility.ZeroTime},
+				StatusKey:     bson.M{"$in": evergreen.TaskInProgressStatuses},
+			},
+		},
+	}
+}
+
 // DisplayTasksByVersion produces a query that returns all display tasks for the given version.
 func DisplayTasksByVersion(version string, includeNeverActivatedTasks bool) bson.M {
 	// assumes that all ExecutionTasks know of their corresponding DisplayTask (i.e. DisplayTaskIdKey not null or "")
@@ -580,6 +635,22 @@ func ByBeforeRevision(revisionOrder int, buildVariant, displayName, project, req
 	}, []string{"-" + RevisionOrderNumberKey}
 }
 
+// ByBetweenRevisions returns a query for the tasks matching the given task
+// identity whose revision order numbers are strictly between the given bounds,
+// sorted by ascending revision order.
+func ByBetweenRevisions(lowerOrder, upperOrder int, buildVariant, displayName, project, requester string) (bson.M, []string) {
+	return bson.M{
+		BuildVariantKey: buildVariant,
+		DisplayNameKey:  displayName,
+		RequesterKey:    requester,
+		RevisionOrderNumberKey: bson.M{
+			"$gt": lowerOrder,
+			"$lt": upperOrder,
+		},
+		ProjectKey: project,
+	}, []string{RevisionOrderNumberKey}
+}
+
 func ByActivatedBeforeRevisionWithStatuses(revisionOrder int, statuses []string, buildVariant string, displayName string, project string) (bson.M, []string) {
 	return bson.M{
 		BuildVariantKey: buildVariant,
@@ -1608,6 +1679,16 @@ func FindMergeTaskForVersion(versionId string) (*Task, error) {
 	return task, err
 }
 
+// FindCommitQueueTestTasksForVersion returns the execution tasks in a commit
+// queue version other than the merge task.
+func FindCommitQueueTestTasksForVersion(versionId string) ([]Task, error) {
+	return FindAll(db.Query(bson.M{
+		VersionKey:          versionId,
+		CommitQueueMergeKey: bson.M{"$ne": true},
+		DisplayOnlyKey:      bson.M{"$ne": true},
+	}))
+}
+
 // FindOld returns all non-display tasks from the old tasks collection that
 // satisfy the given query.
 func FindOld(filter bson.M) ([]Task, error) {
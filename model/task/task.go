@@ -92,6 +92,9 @@ type Task struct {
 	HasCedarResults   bool                `bson:"has_cedar_results,omitempty" json:"has_cedar_results,omitempty"`
 	ResultsFailed     bool                `bson:"results_failed,omitempty" json:"results_failed,omitempty"`
 	MustHaveResults   bool                `bson:"must_have_results,omitempty" json:"must_have_results,omitempty"`
+	// QuarantinedFailures are the names of the failed tests that did not fail
+	// the task because they are quarantined in the project.
+	QuarantinedFailures []string `bson:"quarantined_failures,omitempty" json:"quarantined_failures,omitempty"`
 	// only relevant if the task is running.  the time of the last heartbeat
 	// sent back by the agent
 	LastHeartbeat time.Time `bson:"last_heartbeat" json:"last_heartbeat"`
@@ -104,6 +107,10 @@ type Task struct {
 	// StepbackDepth indicates how far into stepback this task was activated, starting at 1 for stepback tasks.
 	// After EVG-17949, should either remove this field/logging or use it to limit stepback depth.
 	StepbackDepth int `bson:"stepback_depth" json:"stepback_depth"`
+	// StepbackInfo records the state of a bisect stepback search that this
+	// task is participating in. It is only set for projects that use bisect
+	// stepback.
+	StepbackInfo *StepbackInfo `bson:"stepback_info,omitempty" json:"stepback_info,omitempty"`
 
 	// ContainerAllocated indicates whether this task has been allocated a
 	// container to run it. It only applies to tasks running in containers.
@@ -408,6 +415,21 @@ type AbortInfo struct {
 	PRClosed   bool   `bson:"pr_closed,omitempty" json:"pr_closed,omitempty"`
 }
 
+// StepbackInfo holds the state of a bisect stepback search. The search
+// narrows the range of commits between the last known passing and first known
+// failing task until the commit that introduced the failure is found.
+type StepbackInfo struct {
+	// LastFailingStepbackTaskId is the earliest known failing task in the
+	// bisection range.
+	LastFailingStepbackTaskId string `bson:"last_failing_stepback_task_id,omitempty" json:"last_failing_stepback_task_id,omitempty"`
+	// LastPassingStepbackTaskId is the latest known passing task in the
+	// bisection range.
+	LastPassingStepbackTaskId string `bson:"last_passing_stepback_task_id,omitempty" json:"last_passing_stepback_task_id,omitempty"`
+	// NextStepbackTaskId is the task that was activated next as a result of
+	// this task finishing. It is empty if the bisection has completed.
+	NextStepbackTaskId string `bson:"next_stepback_task_id,omitempty" json:"next_stepback_task_id,omitempty"`
+}
+
 var (
 	AllStatuses = "*"
 )
@@ -871,6 +893,24 @@ func (t *Task) PreviousCompletedTask(project string, statuses []string) (*Task,
 	return FindOne(query)
 }
 
+// FindMidwayTask returns the task halfway between the two given tasks (by
+// revision order) that has the same build variant, display name, project and
+// requester. It returns nil if there are no tasks between them.
+func FindMidwayTask(lower, upper Task) (*Task, error) {
+	if lower.RevisionOrderNumber > upper.RevisionOrderNumber {
+		lower, upper = upper, lower
+	}
+	filter, sort := ByBetweenRevisions(lower.RevisionOrderNumber, upper.RevisionOrderNumber, upper.BuildVariant, upper.DisplayName, upper.Project, upper.Requester)
+	count, err := Count(db.Query(filter))
+	if err != nil {
+		return nil, errors.Wrap(err, "counting tasks between revisions")
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return FindOne(db.Query(filter).Sort(sort).Skip(count / 2))
+}
+
 func (t *Task) cacheExpectedDuration() error {
 	return UpdateOne(
 		bson.M{
@@ -1447,6 +1487,20 @@ func (t *Task) SetStepbackDepth(stepbackDepth int) error {
 		})
 }
 
+// SetStepbackInfo sets the bisect stepback info for the task.
+func (t *Task) SetStepbackInfo(info StepbackInfo) error {
+	t.StepbackInfo = &info
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				StepbackInfoKey: info,
+			},
+		})
+}
+
 // SetResultsInfo sets the task's test results info.
 //
 // Note that if failedResults is false, ResultsFailed is not set. This is
@@ -1476,6 +1530,16 @@ func (t *Task) SetResultsInfo(service string, failedResults bool) error {
 	return errors.WithStack(UpdateOne(bson.M{IdKey: t.Id}, bson.M{"$set": set}))
 }
 
+// SetQuarantinedFailures sets the names of the task's failed tests that are
+// quarantined.
+func (t *Task) SetQuarantinedFailures(testNames []string) error {
+	t.QuarantinedFailures = testNames
+	return errors.WithStack(UpdateOne(
+		bson.M{IdKey: t.Id},
+		bson.M{"$set": bson.M{QuarantinedFailuresKey: testNames}},
+	))
+}
+
 // HasResults returns whether the task has test results or not.
 func (t *Task) HasResults() bool {
 	if t.DisplayOnly && len(t.ExecutionTasks) > 0 {
@@ -1995,6 +2059,7 @@ func resetTaskUpdate(t *Task) bson.M {
 		t.Details = apimodels.TaskEndDetail{}
 		t.ResultsService = ""
 		t.ResultsFailed = false
+		t.QuarantinedFailures = nil
 		t.HasCedarResults = false
 		t.ResetWhenFinished = false
 		t.ResetFailedWhenFinished = false
@@ -2023,6 +2088,7 @@ func resetTaskUpdate(t *Task) bson.M {
 			DetailsKey:                 "",
 			ResultsServiceKey:          "",
 			ResultsFailedKey:           "",
+			QuarantinedFailuresKey:     "",
 			HasCedarResultsKey:         "",
 			ResetWhenFinishedKey:       "",
 			ResetFailedWhenFinishedKey: "",
@@ -2606,7 +2672,7 @@ func (t *Task) CreateTestResultsTaskOptions() ([]testresult.TaskOptions, error)
 		} else {
 			query := ByIds(t.ExecutionTasks)
 			query["$or"] = hasResults
-			execTasksWithResults, err = FindWithFields(query, ExecutionKey, ResultsServiceKey, HasCedarResultsKey)
+			execTasksWithResults, err = FindWithFields(query, ExecutionKey, ResultsServiceKey, HasCedarResultsKey, QuarantinedFailuresKey)
 		}
 		if err != nil {
 			return nil, errors.Wrap(err, "getting execution tasks for display task")
@@ -2618,9 +2684,10 @@ func (t *Task) CreateTestResultsTaskOptions() ([]testresult.TaskOptions, error)
 				taskID = execTask.OldTaskId
 			}
 			taskOpts = append(taskOpts, testresult.TaskOptions{
-				TaskID:         taskID,
-				Execution:      execTask.Execution,
-				ResultsService: execTask.ResultsService,
+				TaskID:           taskID,
+				Execution:        execTask.Execution,
+				ResultsService:   execTask.ResultsService,
+				QuarantinedTests: execTask.QuarantinedFailures,
 			})
 		}
 	} else if t.HasResults() {
@@ -2629,9 +2696,10 @@ func (t *Task) CreateTestResultsTaskOptions() ([]testresult.TaskOptions, error)
 			taskID = t.OldTaskId
 		}
 		taskOpts = append(taskOpts, testresult.TaskOptions{
-			TaskID:         taskID,
-			Execution:      t.Execution,
-			ResultsService: t.ResultsService,
+			TaskID:           taskID,
+			Execution:        t.Execution,
+			ResultsService:   t.ResultsService,
+			QuarantinedTests: t.QuarantinedFailures,
 		})
 	}
 
@@ -0,0 +1,264 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+// VersionGraph is a version's task dependency graph in which each task is
+// annotated with its timing, along with the critical path through the graph.
+type VersionGraph struct {
+	VersionID string
+	// Nodes are the version's tasks in topological order, with each task
+	// coming after the tasks it depends on.
+	Nodes []VersionGraphNode
+	// Edges point from depended on tasks to the tasks that depend on them.
+	Edges []VersionGraphEdge
+	// CriticalPath is the IDs of the chain of tasks, starting with the first
+	// to run, that determined when the version finished or is expected to
+	// finish.
+	CriticalPath []string
+	// StartTime is when the first of the version's tasks started.
+	StartTime time.Time
+	// FinishTime is when the last of the version's tasks finished or is
+	// expected to finish.
+	FinishTime time.Time
+	// Makespan is the time between the version's start and finish.
+	Makespan time.Duration
+}
+
+// VersionGraphNode is a task in a VersionGraph.
+type VersionGraphNode struct {
+	TaskNode
+	Status           string
+	Activated        bool
+	ExpectedDuration time.Duration
+	StartTime        time.Time
+	FinishTime       time.Time
+	// EstimatedFinishTime is the finish time of a finished task, or when an
+	// unfinished task is expected to finish based on its expected duration
+	// and the tasks it depends on. It's zero for tasks that won't run.
+	EstimatedFinishTime time.Time
+	OnCriticalPath      bool
+}
+
+// VersionGraphEdge is a dependency in a VersionGraph.
+type VersionGraphEdge struct {
+	// From is the ID of the depended on task.
+	From string
+	// To is the ID of the dependent task.
+	To string
+	// Status is the status specified by the dependency, if any.
+	Status string
+}
+
+// GetVersionGraph returns the dependency graph of the version's tasks, with
+// each task annotated with its expected duration and actual start and finish
+// times.
+func GetVersionGraph(versionID string) (*VersionGraph, error) {
+	tasks, err := Find(ByVersion(versionID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting tasks for version '%s'", versionID)
+	}
+	for i := range tasks {
+		if tasks[i].DisplayOnly {
+			continue
+		}
+		tasks[i].ExpectedDuration = tasks[i].FetchExpectedDuration().Average
+	}
+
+	return newVersionGraph(versionID, tasks, time.Now())
+}
+
+func newVersionGraph(versionID string, tasks []Task, now time.Time) (*VersionGraph, error) {
+	// Display tasks don't run on their own, so only their execution tasks
+	// are part of the graph.
+	tasksByID := make(map[string]Task, len(tasks))
+	execTasks := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		if t.DisplayOnly {
+			continue
+		}
+		tasksByID[t.Id] = t
+		execTasks = append(execTasks, t)
+	}
+
+	g := taskDependencyGraph(execTasks, true)
+	sorted, err := g.TopologicalStableSort()
+	if err != nil {
+		return nil, errors.Wrap(err, "sorting tasks")
+	}
+
+	vg := &VersionGraph{VersionID: versionID}
+	finishTimes := make(map[string]time.Time, len(sorted))
+	for _, tNode := range sorted {
+		t := tasksByID[tNode.ID]
+		node := VersionGraphNode{
+			TaskNode:         tNode,
+			Status:           t.Status,
+			Activated:        t.Activated,
+			ExpectedDuration: t.ExpectedDuration,
+			StartTime:        t.StartTime,
+			FinishTime:       t.FinishTime,
+		}
+
+		switch {
+		case t.IsFinished():
+			node.EstimatedFinishTime = t.FinishTime
+		case t.IsInProgress() && !utility.IsZeroTime(t.StartTime):
+			node.EstimatedFinishTime = laterTime(t.StartTime.Add(t.ExpectedDuration), now)
+		case t.WillRun() || t.IsInProgress():
+			start := now
+			for _, edge := range g.EdgesIntoTask(tNode) {
+				start = laterTime(start, finishTimes[edge.From.ID])
+			}
+			node.EstimatedFinishTime = start.Add(t.ExpectedDuration)
+		}
+		if !utility.IsZeroTime(node.EstimatedFinishTime) {
+			finishTimes[tNode.ID] = node.EstimatedFinishTime
+		}
+		if !utility.IsZeroTime(t.StartTime) && (utility.IsZeroTime(vg.StartTime) || t.StartTime.Before(vg.StartTime)) {
+			vg.StartTime = t.StartTime
+		}
+
+		vg.Nodes = append(vg.Nodes, node)
+		for _, edge := range g.EdgesIntoTask(tNode) {
+			vg.Edges = append(vg.Edges, VersionGraphEdge{From: edge.From.ID, To: edge.To.ID, Status: edge.Status})
+		}
+	}
+
+	vg.CriticalPath = criticalPath(g, vg.Nodes, finishTimes)
+	onPath := make(map[string]bool, len(vg.CriticalPath))
+	for _, id := range vg.CriticalPath {
+		onPath[id] = true
+	}
+	for i := range vg.Nodes {
+		vg.Nodes[i].OnCriticalPath = onPath[vg.Nodes[i].ID]
+	}
+	if len(vg.CriticalPath) > 0 {
+		vg.FinishTime = finishTimes[vg.CriticalPath[len(vg.CriticalPath)-1]]
+		if utility.IsZeroTime(vg.StartTime) {
+			vg.StartTime = now
+		}
+		if vg.FinishTime.After(vg.StartTime) {
+			vg.Makespan = vg.FinishTime.Sub(vg.StartTime)
+		}
+	}
+
+	return vg, nil
+}
+
+// criticalPath returns the IDs of the tasks on the critical path, which
+// starts from the task that finishes last and repeatedly follows the
+// dependency that finished last, since that dependency is what held the task
+// back.
+func criticalPath(g DependencyGraph, nodes []VersionGraphNode, finishTimes map[string]time.Time) []string {
+	var last *TaskNode
+	for i := range nodes {
+		finish, ok := finishTimes[nodes[i].ID]
+		if !ok {
+			continue
+		}
+		if last == nil || finish.After(finishTimes[last.ID]) {
+			last = &nodes[i].TaskNode
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	path := []string{last.ID}
+	visited := map[string]bool{last.ID: true}
+	current := *last
+	for {
+		var next *TaskNode
+		for _, edge := range g.EdgesIntoTask(current) {
+			dep := edge.From
+			finish, ok := finishTimes[dep.ID]
+			if !ok || visited[dep.ID] {
+				continue
+			}
+			if next == nil || finish.After(finishTimes[next.ID]) || (finish.Equal(finishTimes[next.ID]) && dep.ID < next.ID) {
+				next = &dep
+			}
+		}
+		if next == nil {
+			break
+		}
+		path = append(path, next.ID)
+		visited[next.ID] = true
+		current = *next
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func laterTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// DOT renders the graph in the Graphviz DOT language, highlighting the tasks
+// and dependencies on the critical path.
+func (vg *VersionGraph) DOT() string {
+	onPath := make(map[string]bool, len(vg.CriticalPath))
+	for _, id := range vg.CriticalPath {
+		onPath[id] = true
+	}
+	criticalEdges := map[VersionGraphEdge]bool{}
+	for i := 1; i < len(vg.CriticalPath); i++ {
+		criticalEdges[VersionGraphEdge{From: vg.CriticalPath[i-1], To: vg.CriticalPath[i]}] = true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", vg.VersionID)
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+	for _, n := range vg.Nodes {
+		label := fmt.Sprintf("%s\n%s\n%s\nexpected %s", n.Variant, n.Name, n.Status, n.ExpectedDuration)
+		if !utility.IsZeroTime(n.StartTime) && !utility.IsZeroTime(n.FinishTime) {
+			label += fmt.Sprintf("\nactual %s", n.FinishTime.Sub(n.StartTime))
+		}
+		attrs := []string{fmt.Sprintf("label=%q", label)}
+		if n.OnCriticalPath {
+			attrs = append(attrs, "color=red", "penwidth=2")
+		}
+		fmt.Fprintf(&b, "\t%q [%s];\n", n.ID, strings.Join(attrs, ", "))
+	}
+
+	edges := make([]VersionGraphEdge, len(vg.Edges))
+	copy(edges, vg.Edges)
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	for _, e := range edges {
+		var attrs []string
+		if e.Status != "" {
+			attrs = append(attrs, fmt.Sprintf("label=%q", e.Status))
+		}
+		if criticalEdges[VersionGraphEdge{From: e.From, To: e.To}] {
+			attrs = append(attrs, "color=red", "penwidth=2")
+		}
+		fmt.Fprintf(&b, "\t%q -> %q", e.From, e.To)
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVersionGraph(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Hour)
+	tasks := []Task{
+		{
+			Id:               "compile",
+			DisplayName:      "compile",
+			BuildVariant:     "bv",
+			Status:           evergreen.TaskSucceeded,
+			Activated:        true,
+			ExpectedDuration: 10 * time.Minute,
+			StartTime:        start,
+			FinishTime:       start.Add(10 * time.Minute),
+		},
+		{
+			Id:               "lint",
+			DisplayName:      "lint",
+			BuildVariant:     "bv",
+			Status:           evergreen.TaskSucceeded,
+			Activated:        true,
+			ExpectedDuration: 5 * time.Minute,
+			StartTime:        start,
+			FinishTime:       start.Add(5 * time.Minute),
+		},
+		{
+			Id:               "unit",
+			DisplayName:      "unit",
+			BuildVariant:     "bv",
+			Status:           evergreen.TaskStarted,
+			Activated:        true,
+			ExpectedDuration: 30 * time.Minute,
+			StartTime:        start.Add(10 * time.Minute),
+			DependsOn:        []Dependency{{TaskId: "compile", Status: evergreen.TaskSucceeded}},
+		},
+		{
+			Id:               "integration",
+			DisplayName:      "integration",
+			BuildVariant:     "bv",
+			Status:           evergreen.TaskUndispatched,
+			Activated:        true,
+			ExpectedDuration: 20 * time.Minute,
+			DependsOn:        []Dependency{{TaskId: "unit"}, {TaskId: "lint"}},
+		},
+		{
+			Id:               "docs",
+			DisplayName:      "docs",
+			BuildVariant:     "bv",
+			Status:           evergreen.TaskUndispatched,
+			ExpectedDuration: 3 * time.Hour,
+		},
+		{
+			Id:             "display",
+			DisplayName:    "display",
+			BuildVariant:   "bv",
+			DisplayOnly:    true,
+			ExecutionTasks: []string{"unit", "integration"},
+		},
+	}
+
+	vg, err := newVersionGraph("version", tasks, now)
+	require.NoError(t, err)
+
+	require.Len(t, vg.Nodes, 5, "display tasks should not be part of the graph")
+	nodes := map[string]VersionGraphNode{}
+	for _, n := range vg.Nodes {
+		nodes[n.ID] = n
+		for _, e := range vg.Edges {
+			if e.To == n.ID {
+				assert.Contains(t, nodes, e.From, "dependencies should come before dependent tasks")
+			}
+		}
+	}
+	assert.Len(t, vg.Edges, 3)
+	assert.Contains(t, vg.Edges, VersionGraphEdge{From: "compile", To: "unit", Status: evergreen.TaskSucceeded})
+
+	assert.Equal(t, start.Add(10*time.Minute), nodes["compile"].EstimatedFinishTime)
+	assert.Equal(t, now, nodes["unit"].EstimatedFinishTime, "overdue task should be expected to finish no earlier than now")
+	assert.Equal(t, now.Add(20*time.Minute), nodes["integration"].EstimatedFinishTime)
+	assert.Zero(t, nodes["docs"].EstimatedFinishTime, "inactive task should not have an estimated finish")
+
+	assert.Equal(t, []string{"compile", "unit", "integration"}, vg.CriticalPath)
+	assert.True(t, nodes["compile"].OnCriticalPath)
+	assert.False(t, nodes["lint"].OnCriticalPath)
+	assert.Equal(t, start, vg.StartTime)
+	assert.Equal(t, now.Add(20*time.Minute), vg.FinishTime)
+	assert.Equal(t, 80*time.Minute, vg.Makespan)
+
+	t.Run("DOT", func(t *testing.T) {
+		dot := vg.DOT()
+		assert.Contains(t, dot, `digraph "version" {`)
+		assert.Contains(t, dot, `"compile" -> "unit" [label="success", color=red, penwidth=2];`)
+		assert.Contains(t, dot, `"lint" -> "integration";`)
+		assert.Contains(t, dot, `"docs" [label="bv\ndocs\nundispatched\nexpected 3h0m0s"];`)
+	})
+	t.Run("NoTasks", func(t *testing.T) {
+		vg, err := newVersionGraph("version", nil, now)
+		require.NoError(t, err)
+		assert.Empty(t, vg.Nodes)
+		assert.Empty(t, vg.CriticalPath)
+		assert.Zero(t, vg.Makespan)
+	})
+}
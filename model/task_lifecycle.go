@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"time"
@@ -479,50 +480,67 @@ func DeactivatePreviousTasks(t *task.Task, caller string) error {
 	return nil
 }
 
-// Returns true if the task should stepback upon failure, and false
-// otherwise. Note that the setting is obtained from the top-level
-// project, if not explicitly set on the task or disabled at the project level.
-func getStepback(taskId string) (bool, error) {
+// stepbackInstructions describes whether and how a task should step back.
+type stepbackInstructions struct {
+	shouldStepback bool
+	bisect         bool
+}
+
+// getStepback returns whether the task should stepback upon failure and, if
+// so, whether it should bisect. Note that the setting is obtained from the
+// top-level project, if not explicitly set on the task or disabled at the
+// project level.
+func getStepback(taskId string) (stepbackInstructions, error) {
 	t, err := task.FindOneId(taskId)
 	if err != nil {
-		return false, errors.Wrapf(err, "finding task '%s'", taskId)
+		return stepbackInstructions{}, errors.Wrapf(err, "finding task '%s'", taskId)
 	}
 	if t == nil {
-		return false, errors.Errorf("task '%s' not found", taskId)
+		return stepbackInstructions{}, errors.Errorf("task '%s' not found", taskId)
 	}
 	projectRef, err := FindMergedProjectRef(t.Project, "", false)
 	if err != nil {
-		return false, errors.Wrapf(err, "finding merged project ref for task '%s'", taskId)
+		return stepbackInstructions{}, errors.Wrapf(err, "finding merged project ref for task '%s'", taskId)
 	}
 	if projectRef == nil {
-		return false, errors.Errorf("project for task '%s' not found", taskId)
+		return stepbackInstructions{}, errors.Errorf("project for task '%s' not found", taskId)
 	}
 	// Disabling the feature at the project level takes precedent.
 	if projectRef.IsStepbackDisabled() {
-		return false, nil
+		return stepbackInstructions{}, nil
 	}
 
 	project, err := FindProjectFromVersionID(t.Version)
 	if err != nil {
-		return false, errors.WithStack(err)
+		return stepbackInstructions{}, errors.WithStack(err)
+	}
+
+	// The project YAML overrides the bisect policy specified by the project
+	// ref.
+	s := stepbackInstructions{bisect: projectRef.IsStepbackBisect()}
+	if project.StepbackBisect != nil {
+		s.bisect = *project.StepbackBisect
 	}
 
 	projectTask := project.FindProjectTask(t.DisplayName)
 	// Check if the task overrides the stepback policy specified by the project
 	if projectTask != nil && projectTask.Stepback != nil {
-		return *projectTask.Stepback, nil
+		s.shouldStepback = *projectTask.Stepback
+		return s, nil
 	}
 
 	// Check if the build variant overrides the stepback policy specified by the project
 	for _, buildVariant := range project.BuildVariants {
 		if t.BuildVariant == buildVariant.Name {
 			if buildVariant.Stepback != nil {
-				return *buildVariant.Stepback, nil
+				s.shouldStepback = *buildVariant.Stepback
+				return s, nil
 			}
 			break
 		}
 	}
-	return project.Stepback, nil
+	s.shouldStepback = project.Stepback
+	return s, nil
 }
 
 // doStepBack performs a stepback on the task if there is a previous task and if not it returns nothing.
@@ -556,6 +574,105 @@ func doStepback(t *task.Task) error {
 	return errors.WithStack(activatePreviousTask(t.Id, evergreen.StepbackTaskActivator, nil, t.StepbackDepth+1))
 }
 
+// doBisectStepback continues (or starts) a bisect stepback from the given
+// finished task. It activates the task halfway between the last known passing
+// and first known failing tasks, and records the search state on the tasks so
+// the next task to finish can narrow the range further.
+func doBisectStepback(t *task.Task, status string) error {
+	var lastPassing, lastFailing *task.Task
+	var err error
+	if t.StepbackInfo != nil && t.StepbackInfo.NextStepbackTaskId == "" {
+		if status == evergreen.TaskSucceeded {
+			lastPassing = t
+			lastFailing, err = task.FindOneId(t.StepbackInfo.LastFailingStepbackTaskId)
+		} else {
+			lastFailing = t
+			lastPassing, err = task.FindOneId(t.StepbackInfo.LastPassingStepbackTaskId)
+		}
+		if err != nil {
+			return errors.Wrap(err, "finding bisect stepback bounds")
+		}
+		if lastPassing == nil || lastFailing == nil {
+			return errors.Errorf("bisect stepback bounds for task '%s' not found", t.Id)
+		}
+	} else {
+		// See if there is a prior success for this particular task. If there
+		// isn't, there is no range to bisect.
+		lastPassing, err = t.PreviousCompletedTask(t.Project, []string{evergreen.TaskSucceeded})
+		if err != nil {
+			return errors.Wrap(err, "locating previous successful task")
+		}
+		if lastPassing == nil {
+			return nil
+		}
+		// If an earlier failure already exists after the last success, it
+		// has already started the bisection for this range.
+		prevFailed, err := t.PreviousCompletedTask(t.Project, evergreen.TaskFailureStatuses)
+		if err != nil {
+			return errors.Wrap(err, "locating previous failed task")
+		}
+		if prevFailed != nil && prevFailed.RevisionOrderNumber > lastPassing.RevisionOrderNumber {
+			return nil
+		}
+		lastFailing = t
+	}
+
+	var midway *task.Task
+	for {
+		midway, err = task.FindMidwayTask(*lastPassing, *lastFailing)
+		if err != nil {
+			return errors.Wrap(err, "finding midway task")
+		}
+		if midway == nil || !midway.IsFinished() {
+			break
+		}
+		// The midway task already ran, so its result narrows the range
+		// without running anything new.
+		if midway.Status == evergreen.TaskSucceeded {
+			lastPassing = midway
+		} else {
+			lastFailing = midway
+		}
+	}
+
+	info := task.StepbackInfo{
+		LastFailingStepbackTaskId: lastFailing.Id,
+		LastPassingStepbackTaskId: lastPassing.Id,
+	}
+	if midway == nil {
+		// The bisection has converged: lastFailing is the first failing task.
+		return errors.Wrap(t.SetStepbackInfo(info), "setting stepback info")
+	}
+
+	nextInfo := info
+	nextInfo.NextStepbackTaskId = midway.Id
+	if err = t.SetStepbackInfo(nextInfo); err != nil {
+		return errors.Wrap(err, "setting stepback info")
+	}
+	if err = midway.SetStepbackInfo(info); err != nil {
+		return errors.Wrapf(err, "setting stepback info for task '%s'", midway.Id)
+	}
+
+	grip.Debug(message.Fields{
+		"message":             "bisecting stepback",
+		"project_id":          t.Project,
+		"task_id":             t.Id,
+		"midway_task_id":      midway.Id,
+		"last_passing_task":   lastPassing.Id,
+		"last_failing_task":   lastFailing.Id,
+		"stepback_depth":      t.StepbackDepth + 1,
+		"midway_order_number": midway.RevisionOrderNumber,
+	})
+
+	if midway.Activated || midway.Priority < 0 {
+		return nil
+	}
+	if err = SetActiveState(evergreen.StepbackTaskActivator, true, *midway); err != nil {
+		return errors.Wrapf(err, "setting task '%s' active", midway.Id)
+	}
+	return errors.Wrap(midway.SetStepbackDepth(t.StepbackDepth+1), "setting stepback depth")
+}
+
 // MarkEnd updates the task as being finished, performs a stepback if necessary, and updates the build status
 func MarkEnd(settings *evergreen.Settings, t *task.Task, caller string, finishTime time.Time, detail *apimodels.TaskEndDetail,
 	deactivatePrevious bool) error {
@@ -563,11 +680,21 @@ func MarkEnd(settings *evergreen.Settings, t *task.Task, caller string, finishTi
 	const slowThreshold = time.Second
 
 	detailsCopy := *detail
-	if t.ResultsFailed && detailsCopy.Status != evergreen.TaskFailed {
+	resultsOnlyFailure := t.ResultsFailed && detailsCopy.Status != evergreen.TaskFailed
+	if resultsOnlyFailure {
 		detailsCopy.Type = evergreen.CommandTypeTest
 		detailsCopy.Status = evergreen.TaskFailed
 	}
 
+	quarantinedFailures, err := getQuarantinedFailures(context.Background(), evergreen.GetEnvironment(), t, &detailsCopy, resultsOnlyFailure)
+	if err != nil {
+		return errors.Wrap(err, "checking task for quarantined test failures")
+	}
+	if len(quarantinedFailures) > 0 {
+		detailsCopy.Status = evergreen.TaskSucceeded
+		detailsCopy.Description = evergreen.TaskDescriptionQuarantinedFailures
+	}
+
 	if t.Status == detailsCopy.Status {
 		grip.Warning(message.Fields{
 			"message": "tried to mark task as finished twice",
@@ -580,6 +707,16 @@ func MarkEnd(settings *evergreen.Settings, t *task.Task, caller string, finishTi
 		detailsCopy.Description = evergreen.TaskDescriptionNoResults
 	}
 
+	if len(quarantinedFailures) > 0 {
+		if err = t.SetQuarantinedFailures(quarantinedFailures); err != nil {
+			return errors.Wrap(err, "setting quarantined test failures")
+		}
+		grip.Error(message.WrapError(annotateQuarantinedFailures(t, quarantinedFailures), message.Fields{
+			"message": "could not annotate task with quarantined test failures",
+			"task":    t.Id,
+		}))
+	}
+
 	t.Details = detailsCopy
 	if utility.IsZeroTime(t.StartTime) {
 		grip.Warning(message.Fields{
@@ -591,7 +728,7 @@ func MarkEnd(settings *evergreen.Settings, t *task.Task, caller string, finishTi
 		})
 	}
 	startPhaseAt := time.Now()
-	err := t.MarkEnd(finishTime, &detailsCopy)
+	err = t.MarkEnd(finishTime, &detailsCopy)
 
 	grip.NoticeWhen(time.Since(startPhaseAt) > slowThreshold, message.Fields{
 		"message":       "slow operation",
@@ -824,7 +961,7 @@ func RestartItemsAfterVersion(cq *commitqueue.CommitQueue, project, version, cal
 
 	foundItem := false
 	catcher := grip.NewBasicCatcher()
-	for _, item := range cq.Queue {
+	for _, item := range cq.LaneForItem(version).Queue {
 		if item.Version == "" {
 			return nil
 		}
@@ -958,13 +1095,18 @@ func HandleEndTaskForCommitQueueTask(t *task.Task, status string) error {
 	if cq == nil {
 		return errors.Errorf("no commit queue found for '%s'", t.Project)
 	}
+	if i := cq.FindItem(t.Version); !t.CommitQueueMerge && i >= 0 && cq.Queue[i].IsBisecting() {
+		// The commit queue job bisects the batch based on the test results,
+		// so the item is dequeued only once it's known to cause the failure.
+		return nil
+	}
 	if status != evergreen.TaskSucceeded && !t.Aborted {
 		return dequeueAndRestartWithStepback(cq, t, evergreen.MergeTestRequester, fmt.Sprintf("task '%s' failed", t.DisplayName))
 	} else if status == evergreen.TaskSucceeded {
 		// Query for all cq version tasks after this one; they may have been waiting to see if this
 		// one was the cause of the failure, in which case we should dequeue and restart.
 		foundVersion := false
-		for _, item := range cq.Queue {
+		for _, item := range cq.LaneForItem(t.Version).Queue {
 			if item.Version == "" {
 				return nil // no longer looking at scheduled versions
 			}
@@ -1000,10 +1142,11 @@ func HandleEndTaskForCommitQueueTask(t *task.Task, status string) error {
 // Otherwise, the failure may be a result of those untested commits so we will wait for the earlier tasks to run
 // and handle dequeuing (merge still won't run for failed task versions because of dependencies).
 func dequeueAndRestartWithStepback(cq *commitqueue.CommitQueue, t *task.Task, caller, reason string) error {
-	if i := cq.FindItem(t.Version); i > 0 {
+	lane := cq.LaneForItem(t.Version)
+	if i := lane.FindItem(t.Version); i > 0 {
 		prevVersions := []string{}
 		for j := 0; j < i; j++ {
-			prevVersions = append(prevVersions, cq.Queue[j].Version)
+			prevVersions = append(prevVersions, lane.Queue[j].Version)
 		}
 		// if any of the commit queue tasks higher on the queue haven't finished, then they will handle dequeuing.
 		previousTaskNeedsToRun, err := task.HasUnfinishedTaskForVersions(prevVersions, t.DisplayName, t.BuildVariant)
@@ -1051,8 +1194,10 @@ func tryDequeueAndAbortCommitQueueItem(p *patch.Patch, cq commitqueue.CommitQueu
 
 // removeNextMergeTaskDependency basically removes the given merge task from a linked list of
 // merge task dependencies. It makes the next merge not depend on the current one and also makes
-// the next merge depend on the previous one, if there is one
+// the next merge depend on the previous one, if there is one. Merge tasks
+// only depend on the merge tasks in the same commit queue lane.
 func removeNextMergeTaskDependency(cq commitqueue.CommitQueue, currentIssue string) error {
+	cq = *cq.LaneForItem(currentIssue)
 	currentIndex := cq.FindItem(currentIssue)
 	if currentIndex < 0 {
 		return errors.New("commit queue item not found")
@@ -1105,15 +1250,20 @@ func evalStepback(t *task.Task, caller, status string, deactivatePrevious bool)
 	// Stepback if the task failed regularly _or_ if we are currently stepping back and we encountered any failure.
 	if (status == evergreen.TaskFailed && !t.Aborted) ||
 		(evergreen.IsFailedTaskStatus(status) && t.ActivatedBy == evergreen.StepbackTaskActivator) {
-		var shouldStepBack bool
-		shouldStepBack, err := getStepback(t.Id)
+		s, err := getStepback(t.Id)
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		if !shouldStepBack {
+		if !s.shouldStepback {
 			return nil
 		}
 
+		// Task group tasks must run sequentially and generated tasks may not
+		// exist in intermediate versions, so both use linear stepback.
+		if s.bisect && !t.IsPartOfSingleHostTaskGroup() && t.GeneratedBy == "" {
+			return errors.Wrap(doBisectStepback(t, status), "performing bisect stepback")
+		}
+
 		if t.IsPartOfSingleHostTaskGroup() {
 			// Stepback earlier task group tasks as well because these need to be run sequentially.
 			catcher := grip.NewBasicCatcher()
@@ -1135,6 +1285,9 @@ func evalStepback(t *task.Task, caller, status string, deactivatePrevious bool)
 		}
 		return errors.Wrap(doStepback(t), "performing stepback")
 
+	} else if status == evergreen.TaskSucceeded && t.StepbackInfo != nil && t.StepbackInfo.NextStepbackTaskId == "" {
+		// A passing task in a bisect stepback narrows the range further.
+		return errors.Wrap(doBisectStepback(t, status), "performing bisect stepback")
 	} else if status == evergreen.TaskSucceeded && deactivatePrevious && t.Requester == evergreen.RepotrackerVersionRequester {
 		// if the task was successful and is a mainline commit (not git tag or project trigger),
 		// ignore running previous activated tasks for this buildvariant
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/db/mgo/bson"
 	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/annotations"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
@@ -3234,7 +3236,7 @@ buildvariants:
 			Convey("then the value should be false", func() {
 				val, err := getStepback(testTask.Id)
 				So(err, ShouldBeNil)
-				So(val, ShouldBeFalse)
+				So(val.shouldStepback, ShouldBeFalse)
 			})
 		})
 		Convey("if the task does not override the setting", func() {
@@ -3243,7 +3245,20 @@ buildvariants:
 			Convey("then the value should be true", func() {
 				val, err := getStepback(testTask.Id)
 				So(err, ShouldBeNil)
-				So(val, ShouldBeTrue)
+				So(val.shouldStepback, ShouldBeTrue)
+			})
+		})
+
+		Convey("if the project ref enables bisect stepback", func() {
+			testTask := &task.Task{Id: "t1", DisplayName: "nil", Project: projRef.Id, Version: ver.Id}
+			So(testTask.Insert(), ShouldBeNil)
+			projRef.StepbackBisect = utility.TruePtr()
+			So(projRef.Upsert(), ShouldBeNil)
+			Convey("then the task should bisect", func() {
+				val, err := getStepback(testTask.Id)
+				So(err, ShouldBeNil)
+				So(val.shouldStepback, ShouldBeTrue)
+				So(val.bisect, ShouldBeTrue)
 			})
 		})
 
@@ -3253,7 +3268,7 @@ buildvariants:
 			Convey("then the value should be true", func() {
 				val, err := getStepback(testTask.Id)
 				So(err, ShouldBeNil)
-				So(val, ShouldBeTrue)
+				So(val.shouldStepback, ShouldBeTrue)
 			})
 		})
 
@@ -3263,7 +3278,7 @@ buildvariants:
 			Convey("then the value should be false", func() {
 				val, err := getStepback(testTask.Id)
 				So(err, ShouldBeNil)
-				So(val, ShouldBeFalse)
+				So(val.shouldStepback, ShouldBeFalse)
 			})
 		})
 
@@ -3273,7 +3288,7 @@ buildvariants:
 			Convey("then the value should be true", func() {
 				val, err := getStepback(testTask.Id)
 				So(err, ShouldBeNil)
-				So(val, ShouldBeTrue)
+				So(val.shouldStepback, ShouldBeTrue)
 			})
 		})
 
@@ -3283,7 +3298,7 @@ buildvariants:
 			Convey("then the value should be true", func() {
 				val, err := getStepback(testTask.Id)
 				So(err, ShouldBeNil)
-				So(val, ShouldBeTrue)
+				So(val.shouldStepback, ShouldBeTrue)
 			})
 		})
 
@@ -3293,7 +3308,7 @@ buildvariants:
 			Convey("then the value should be false", func() {
 				val, err := getStepback(testTask.Id)
 				So(err, ShouldBeNil)
-				So(val, ShouldBeFalse)
+				So(val.shouldStepback, ShouldBeFalse)
 			})
 		})
 
@@ -5243,6 +5258,137 @@ func TestMarkEndWithNoResults(t *testing.T) {
 	assert.Equal(t, evergreen.TaskSucceeded, dbTask.Status)
 }
 
+func TestMarkEndWithQuarantinedFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := evergreen.GetEnvironment()
+
+	require.NoError(t, db.ClearCollections(task.Collection, build.Collection, VersionCollection, ParserProjectCollection, event.EventCollection, QuarantinedTestsCollection, annotations.Collection))
+	require.NoError(t, testresult.ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(QuarantinedTestsCollection, annotations.Collection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	b := build.Build{Id: "b", Version: "v"}
+	require.NoError(t, b.Insert())
+	v := &Version{Id: "v", Requester: evergreen.RepotrackerVersionRequester, Status: evergreen.VersionStarted}
+	require.NoError(t, v.Insert())
+	pp := ParserProject{Id: v.Id, Identifier: utility.ToStringPtr("sample")}
+	require.NoError(t, pp.Insert())
+	q := QuarantinedTest{ProjectID: "p", Pattern: "^TestFlaky"}
+	require.NoError(t, q.Upsert())
+
+	makeTask := func(id string, failedTests ...string) *task.Task {
+		tsk := &task.Task{
+			Id:             id,
+			Project:        "p",
+			Status:         evergreen.TaskStarted,
+			Activated:      true,
+			ActivatedTime:  time.Now(),
+			BuildId:        b.Id,
+			Version:        v.Id,
+			Requester:      evergreen.PatchVersionRequester,
+			ResultsService: testresult.TestResultsServiceLocal,
+			ResultsFailed:  true,
+		}
+		require.NoError(t, tsk.Insert())
+		results := []testresult.TestResult{{TaskID: id, TestName: "TestStable", Status: evergreen.TestSucceededStatus}}
+		for _, testName := range failedTests {
+			results = append(results, testresult.TestResult{TaskID: id, TestName: testName, Status: evergreen.TestFailedStatus})
+		}
+		require.NoError(t, testresult.InsertLocal(ctx, env, results...))
+		return tsk
+	}
+	details := &apimodels.TaskEndDetail{
+		Status:                    evergreen.TaskFailed,
+		Type:                      evergreen.CommandTypeTest,
+		FailingCommandFailedTests: true,
+	}
+
+	t.Run("OnlyQuarantinedFailuresSucceeds", func(t *testing.T) {
+		tsk := makeTask("t1", "TestFlakyA", "TestFlakyB")
+		require.NoError(t, MarkEnd(&evergreen.Settings{}, tsk, "", time.Now(), details, false))
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		assert.Equal(t, evergreen.TaskSucceeded, dbTask.Status)
+		assert.Equal(t, evergreen.TaskDescriptionQuarantinedFailures, dbTask.Details.Description)
+		assert.ElementsMatch(t, []string{"TestFlakyA", "TestFlakyB"}, dbTask.QuarantinedFailures)
+
+		annotation, err := annotations.FindOneByTaskIdAndExecution(tsk.Id, tsk.Execution)
+		require.NoError(t, err)
+		require.NotNil(t, annotation)
+		require.NotNil(t, annotation.Note)
+		assert.Contains(t, annotation.Note.Message, "TestFlakyA")
+		assert.Equal(t, annotations.QuarantineRequester, annotation.Note.Source.Requester)
+
+		taskOpts, err := dbTask.CreateTestResultsTaskOptions()
+		require.NoError(t, err)
+		results, err := testresult.GetMergedTaskTestResults(ctx, env, taskOpts, nil)
+		require.NoError(t, err)
+		statuses := map[string]string{}
+		for _, result := range results.Results {
+			statuses[result.TestName] = result.Status
+		}
+		assert.Equal(t, evergreen.TestSucceededStatus, statuses["TestStable"])
+		assert.Equal(t, evergreen.TestQuarantinedStatus, statuses["TestFlakyA"])
+		assert.Equal(t, evergreen.TestQuarantinedStatus, statuses["TestFlakyB"])
+	})
+	t.Run("UnquarantinedFailureFails", func(t *testing.T) {
+		tsk := makeTask("t2", "TestFlakyA", "TestBroken")
+		require.NoError(t, MarkEnd(&evergreen.Settings{}, tsk, "", time.Now(), details, false))
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		assert.Equal(t, evergreen.TaskFailed, dbTask.Status)
+		assert.Empty(t, dbTask.QuarantinedFailures)
+	})
+	t.Run("FailedTestResultsOnlySucceeds", func(t *testing.T) {
+		tsk := makeTask("t4", "TestFlakyA")
+		success := &apimodels.TaskEndDetail{Status: evergreen.TaskSucceeded}
+		require.NoError(t, MarkEnd(&evergreen.Settings{}, tsk, "", time.Now(), success, false))
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		assert.Equal(t, evergreen.TaskSucceeded, dbTask.Status)
+		assert.Equal(t, []string{"TestFlakyA"}, dbTask.QuarantinedFailures)
+	})
+	t.Run("OtherCommandFailureFails", func(t *testing.T) {
+		tsk := makeTask("t5", "TestFlakyA")
+		lintFailure := &apimodels.TaskEndDetail{
+			Status:      evergreen.TaskFailed,
+			Type:        evergreen.CommandTypeTest,
+			Description: "lint",
+		}
+		require.NoError(t, MarkEnd(&evergreen.Settings{}, tsk, "", time.Now(), lintFailure, false))
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		assert.Equal(t, evergreen.TaskFailed, dbTask.Status)
+		assert.Equal(t, "lint", dbTask.Details.Description)
+		assert.Empty(t, dbTask.QuarantinedFailures)
+	})
+	t.Run("SystemFailureFails", func(t *testing.T) {
+		tsk := makeTask("t3", "TestFlakyA")
+		systemFailure := &apimodels.TaskEndDetail{
+			Status: evergreen.TaskFailed,
+			Type:   evergreen.CommandTypeSystem,
+		}
+		require.NoError(t, MarkEnd(&evergreen.Settings{}, tsk, "", time.Now(), systemFailure, false))
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		assert.Equal(t, evergreen.TaskFailed, dbTask.Status)
+		assert.Empty(t, dbTask.QuarantinedFailures)
+	})
+}
+
 func TestDisplayTaskUpdates(t *testing.T) {
 	require.NoError(t, db.ClearCollections(task.Collection, event.EventCollection))
 	assert := assert.New(t)
@@ -5906,6 +6052,114 @@ tasks:
 	assert.True(checkTask.Activated)
 }
 
+func TestEvalStepbackBisect(t *testing.T) {
+	assert.NoError(t, db.ClearCollections(task.Collection, ProjectRefCollection, ParserProjectCollection, distro.Collection, build.Collection, VersionCollection))
+	yml := `
+stepback: true
+stepback_bisect: true
+buildvariants:
+- name: "bv"
+  run_on: distro
+  tasks:
+  - name: task
+tasks:
+- name: task
+  `
+	proj := ProjectRef{
+		Id: "proj",
+	}
+	require.NoError(t, proj.Insert())
+	d := distro.Distro{
+		Id: "distro",
+	}
+	require.NoError(t, d.Insert())
+	v := Version{
+		Id:        "sample_version",
+		Requester: evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(t, v.Insert())
+	pp := &ParserProject{}
+	require.NoError(t, util.UnmarshalYAMLWithFallback([]byte(yml), &pp))
+	pp.Id = v.Id
+	require.NoError(t, pp.Insert())
+
+	// Tasks 1 through 9 exist; task 1 passed and task 9 failed.
+	for i := 1; i <= 9; i++ {
+		tsk := task.Task{
+			Id:                  fmt.Sprintf("t%d", i),
+			BuildId:             fmt.Sprintf("b%d", i),
+			Status:              evergreen.TaskUndispatched,
+			BuildVariant:        "bv",
+			DisplayName:         "task",
+			Project:             "proj",
+			RevisionOrderNumber: i,
+			Requester:           evergreen.RepotrackerVersionRequester,
+			Version:             v.Id,
+		}
+		if i == 1 {
+			tsk.Status = evergreen.TaskSucceeded
+			tsk.Activated = true
+		}
+		if i == 9 {
+			tsk.Status = evergreen.TaskFailed
+			tsk.Activated = true
+		}
+		require.NoError(t, tsk.Insert())
+		b := build.Build{
+			Id:           tsk.BuildId,
+			BuildVariant: "bv",
+		}
+		require.NoError(t, b.Insert())
+	}
+
+	checkActivated := func(t *testing.T, id string) *task.Task {
+		tsk, err := task.FindOneId(id)
+		require.NoError(t, err)
+		require.NotNil(t, tsk)
+		assert.True(t, tsk.Activated, id)
+		assert.Equal(t, evergreen.StepbackTaskActivator, tsk.ActivatedBy, id)
+		require.NotNil(t, tsk.StepbackInfo, id)
+		return tsk
+	}
+
+	failed, err := task.FindOneId("t9")
+	require.NoError(t, err)
+	require.NoError(t, evalStepback(failed, "", evergreen.TaskFailed, false))
+
+	// The midpoint of t2..t8 is activated first.
+	midway := checkActivated(t, "t5")
+	assert.Equal(t, "t1", midway.StepbackInfo.LastPassingStepbackTaskId)
+	assert.Equal(t, "t9", midway.StepbackInfo.LastFailingStepbackTaskId)
+	failed, err = task.FindOneId("t9")
+	require.NoError(t, err)
+	require.NotNil(t, failed.StepbackInfo)
+	assert.Equal(t, "t5", failed.StepbackInfo.NextStepbackTaskId)
+
+	// t5 passing narrows the range to t6..t8.
+	require.NoError(t, evalStepback(midway, "", evergreen.TaskSucceeded, false))
+	midway = checkActivated(t, "t7")
+	assert.Equal(t, "t5", midway.StepbackInfo.LastPassingStepbackTaskId)
+	assert.Equal(t, "t9", midway.StepbackInfo.LastFailingStepbackTaskId)
+
+	// t7 failing narrows the range to t6.
+	require.NoError(t, evalStepback(midway, "", evergreen.TaskFailed, false))
+	midway = checkActivated(t, "t6")
+	assert.Equal(t, "t5", midway.StepbackInfo.LastPassingStepbackTaskId)
+	assert.Equal(t, "t7", midway.StepbackInfo.LastFailingStepbackTaskId)
+
+	// t6 failing completes the bisection without activating anything else.
+	require.NoError(t, evalStepback(midway, "", evergreen.TaskFailed, false))
+	midway, err = task.FindOneId("t6")
+	require.NoError(t, err)
+	assert.Empty(t, midway.StepbackInfo.NextStepbackTaskId)
+	assert.Equal(t, "t6", midway.StepbackInfo.LastFailingStepbackTaskId)
+	for _, id := range []string{"t2", "t3", "t4", "t8"} {
+		tsk, err := task.FindOneId(id)
+		require.NoError(t, err)
+		assert.False(t, tsk.Activated, id)
+	}
+}
+
 func TestEvalStepbackTaskGroup(t *testing.T) {
 	assert.NoError(t, db.ClearCollections(task.Collection, ParserProjectCollection, VersionCollection, build.Collection, event.EventCollection, ProjectRefCollection))
 	v1 := Version{
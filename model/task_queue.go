@@ -45,6 +45,24 @@ type DistroQueueInfo struct {
 	// SecondaryQueue refers to whether or not this info refers to a secondary queue.
 	// Tags don't match due to outdated naming convention.
 	SecondaryQueue bool `bson:"alias_queue" json:"alias_queue"`
+	// ProjectShares are the projects' shares of the distro's host time when
+	// the distro plans its tasks using fair share.
+	ProjectShares []ProjectShareInfo `bson:"project_shares,omitempty" json:"project_shares,omitempty"`
+}
+
+// ProjectShareInfo compares the share of a distro's host time that a project
+// is entitled to with the share of the distro's recent host time it used.
+type ProjectShareInfo struct {
+	Project string `bson:"project" json:"project"`
+	// Share is the fraction of the distro's host time the project is
+	// entitled to.
+	Share float64 `bson:"share" json:"share"`
+	// Usage is the fraction of the distro's host time the project used
+	// within the fair share window.
+	Usage float64 `bson:"usage" json:"usage"`
+	// HostTime is the host time the project used within the fair share
+	// window.
+	HostTime time.Duration `bson:"host_time" json:"host_time"`
 }
 
 func GetDistroQueueInfo(distroID string) (DistroQueueInfo, error) {
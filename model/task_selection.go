@@ -0,0 +1,143 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/taskselection"
+	"github.com/pkg/errors"
+)
+
+// SelectPredictedTasks narrows down the patch's tasks to those likely to
+// catch a regression in the patch's changes if the patch alias has opted into
+// predictive task selection. The tasks that are not selected are recorded as
+// the patch's skipped tasks so that they can be scheduled later.
+func (p *Project) SelectPredictedTasks(patchDoc *patch.Patch, alias string) error {
+	if alias == "" || len(patchDoc.VariantsTasks) == 0 {
+		return nil
+	}
+	aliases, err := findAliasesForPatch(p.Identifier, alias, patchDoc)
+	if err != nil {
+		return errors.Wrapf(err, "retrieving alias '%s'", alias)
+	}
+	if !hasPredictiveSelection(aliases) {
+		return nil
+	}
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		return errors.Wrap(err, "getting service flags")
+	}
+	if flags.PredictiveTaskSelectionDisabled {
+		return nil
+	}
+
+	// Display tasks run all of their execution tasks, so they are selected or
+	// skipped as a whole.
+	candidatePairs := map[taskselection.Candidate][]TVPair{}
+	var candidates []taskselection.Candidate
+	for _, pair := range VariantTasksToTVPairs(patchDoc.VariantsTasks).ExecTasks {
+		c := taskselection.Candidate{BuildVariant: pair.Variant, TaskName: pair.TaskName}
+		if bv := p.FindBuildVariant(pair.Variant); bv != nil {
+			if dt := bv.GetDisplayTask(pair.TaskName); dt != nil {
+				c.TaskName = dt.Name
+			}
+		}
+		if _, ok := candidatePairs[c]; !ok {
+			candidates = append(candidates, c)
+		}
+		candidatePairs[c] = append(candidatePairs[c], pair)
+	}
+
+	predictions, err := taskselection.Predict(patchDoc.Project, patchDoc.ChangedFiles(), candidates)
+	if err != nil {
+		return errors.Wrap(err, "predicting tasks to run")
+	}
+	sort.SliceStable(predictions, func(i, j int) bool {
+		return predictions[i].Score > predictions[j].Score
+	})
+	// A patch needs at least one task, so always run the task most likely
+	// to fail.
+	if len(predictions) > 0 {
+		predictions[0].Selected = true
+	}
+
+	var selected TaskVariantPairs
+	for _, prediction := range predictions {
+		if prediction.Selected {
+			selected.ExecTasks = append(selected.ExecTasks, candidatePairs[prediction.Candidate]...)
+		}
+	}
+	selected = p.extractDisplayTasks(selected)
+	selected.ExecTasks, err = IncludeDependencies(p, selected.ExecTasks, patchDoc.GetRequester(), nil)
+	if err != nil {
+		return errors.Wrap(err, "including dependencies of selected tasks")
+	}
+
+	// Skipped tasks can still end up running as a dependency of a selected
+	// task.
+	willRun := map[TVPair]bool{}
+	for _, pair := range selected.ExecTasks {
+		willRun[pair] = true
+	}
+	var skipped []patch.SkippedTask
+	for _, prediction := range predictions {
+		if prediction.Selected {
+			continue
+		}
+		for _, pair := range candidatePairs[prediction.Candidate] {
+			if willRun[pair] {
+				continue
+			}
+			skipped = append(skipped, patch.SkippedTask{
+				Variant: pair.Variant,
+				Task:    pair.TaskName,
+				Score:   prediction.Score,
+				Reason:  prediction.Reason,
+			})
+		}
+	}
+	sort.Slice(skipped, func(i, j int) bool {
+		if skipped[i].Variant != skipped[j].Variant {
+			return skipped[i].Variant < skipped[j].Variant
+		}
+		return skipped[i].Task < skipped[j].Task
+	})
+
+	patchDoc.VariantsTasks = selected.TVPairsToVariantTasks()
+	patchDoc.BuildVariants, patchDoc.Tasks = patch.ResolveVariantTasks(patchDoc.VariantsTasks)
+	patchDoc.SkippedTasks = skipped
+
+	return nil
+}
+
+func hasPredictiveSelection(aliases []ProjectAlias) bool {
+	for _, a := range aliases {
+		if a.PredictiveSelection {
+			return true
+		}
+	}
+	return false
+}
+
+// removeScheduledSkippedTasks removes the tasks that are now part of the
+// patch from its skipped tasks.
+func removeScheduledSkippedTasks(p *patch.Patch, pairs TaskVariantPairs) error {
+	if len(p.SkippedTasks) == 0 {
+		return nil
+	}
+	scheduled := map[TVPair]bool{}
+	for _, pair := range pairs.ExecTasks {
+		scheduled[pair] = true
+	}
+	var remaining []patch.SkippedTask
+	for _, st := range p.SkippedTasks {
+		if !scheduled[TVPair{Variant: st.Variant, TaskName: st.Task}] {
+			remaining = append(remaining, st)
+		}
+	}
+	if len(remaining) == len(p.SkippedTasks) {
+		return nil
+	}
+	return p.SetSkippedTasks(remaining)
+}
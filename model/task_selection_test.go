@@ -0,0 +1,89 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/taskselection"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectPredictedTasks(t *testing.T) {
+	require.NoError(t, db.ClearCollections(ProjectAliasCollection, ProjectRefCollection, taskselection.FileTaskStatsCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(ProjectAliasCollection, ProjectRefCollection, taskselection.FileTaskStatsCollection))
+	}()
+
+	pRef := ProjectRef{Id: "proj", Identifier: "proj"}
+	require.NoError(t, pRef.Insert())
+	for _, alias := range []ProjectAlias{
+		{ProjectID: "proj", Alias: "predict", Variant: ".*", Task: ".*", PredictiveSelection: true},
+		{ProjectID: "proj", Alias: "all", Variant: ".*", Task: ".*"},
+	} {
+		require.NoError(t, alias.Upsert())
+	}
+	for taskName, numRegressions := range map[string]int{"compile": 0, "unit": 10, "lint": 0} {
+		require.NoError(t, db.Insert(taskselection.FileTaskStatsCollection, taskselection.DBFileTaskStats{
+			Id: taskselection.DBFileTaskStatsID{
+				Project:      "proj",
+				File:         "main.go",
+				BuildVariant: "bv",
+				TaskName:     taskName,
+			},
+			NumRuns:        50,
+			NumRegressions: numRegressions,
+		}))
+	}
+
+	project := &Project{
+		Identifier: "proj",
+		BuildVariants: []BuildVariant{
+			{
+				Name: "bv",
+				Tasks: []BuildVariantTaskUnit{
+					{Name: "compile", Variant: "bv"},
+					{Name: "unit", Variant: "bv", DependsOn: []TaskUnitDependency{{Name: "compile"}}},
+					{Name: "lint", Variant: "bv"},
+				},
+			},
+		},
+		Tasks: []ProjectTask{{Name: "compile"}, {Name: "unit"}, {Name: "lint"}},
+	}
+	makePatch := func() *patch.Patch {
+		return &patch.Patch{
+			Id:      mgobson.NewObjectId(),
+			Project: "proj",
+			VariantsTasks: []patch.VariantTasks{
+				{Variant: "bv", Tasks: []string{"compile", "unit", "lint"}},
+			},
+			Patches: []patch.ModulePatch{
+				{PatchSet: patch.PatchSet{Summary: []thirdparty.Summary{{Name: "main.go"}}}},
+			},
+		}
+	}
+
+	t.Run("SkipsUnlikelyTasks", func(t *testing.T) {
+		p := makePatch()
+		require.NoError(t, project.SelectPredictedTasks(p, "predict"))
+
+		require.Len(t, p.VariantsTasks, 1)
+		assert.ElementsMatch(t, []string{"compile", "unit"}, p.VariantsTasks[0].Tasks, "dependencies of selected tasks should run")
+		assert.ElementsMatch(t, []string{"compile", "unit"}, p.Tasks)
+		require.Len(t, p.SkippedTasks, 1)
+		assert.Equal(t, "bv", p.SkippedTasks[0].Variant)
+		assert.Equal(t, "lint", p.SkippedTasks[0].Task)
+		assert.NotEmpty(t, p.SkippedTasks[0].Reason)
+	})
+	t.Run("NoopWithoutPredictiveAlias", func(t *testing.T) {
+		p := makePatch()
+		require.NoError(t, project.SelectPredictedTasks(p, "all"))
+
+		require.Len(t, p.VariantsTasks, 1)
+		assert.Len(t, p.VariantsTasks[0].Tasks, 3)
+		assert.Empty(t, p.SkippedTasks)
+	})
+}
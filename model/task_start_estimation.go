@@ -121,6 +121,33 @@ func GetEstimatedStartTime(t task.Task) (time.Duration, error) {
 	return createSimulatorModel(*queue, hosts).simulate(queuePos), nil
 }
 
+// SimulateStartTimes returns how long after the start of the simulation each
+// of the tasks with the given expected durations is expected to start if they
+// are dispatched in order to hosts that become available after the given
+// durations. It returns nil if there are no hosts.
+func SimulateStartTimes(taskDurations []time.Duration, hostAvailability []time.Duration) []time.Duration {
+	if len(hostAvailability) == 0 || len(taskDurations) == 0 {
+		return nil
+	}
+
+	estimator := estimatedTimeSimulator{}
+	for _, d := range taskDurations {
+		_ = estimator.tasks.Enqueue(estimatedTask{duration: d})
+	}
+	for _, d := range hostAvailability {
+		if d < 0 {
+			d = 0
+		}
+		estimator.hosts = append(estimator.hosts, estimatedHost{timeToCompletion: d})
+	}
+
+	startTimes := make([]time.Duration, 0, len(taskDurations))
+	for pos := range taskDurations {
+		startTimes = append(startTimes, estimator.simulate(pos))
+	}
+	return startTimes
+}
+
 func createSimulatorModel(taskQueue TaskQueue, hosts []host.Host) *estimatedTimeSimulator {
 	estimator := estimatedTimeSimulator{}
 	for i := 0; i < len(taskQueue.Queue); i++ {
@@ -174,6 +174,13 @@ func (s *estimatorSuite) TestEvenDistribution() {
 	}
 }
 
+func (s *estimatorSuite) TestSimulateStartTimes() {
+	durations := []time.Duration{10 * time.Minute, 10 * time.Minute, 10 * time.Minute}
+	s.Equal([]time.Duration{0, 5 * time.Minute, 10 * time.Minute}, SimulateStartTimes(durations, []time.Duration{5 * time.Minute, -time.Minute}))
+	s.Nil(SimulateStartTimes(durations, nil))
+	s.Nil(SimulateStartTimes(nil, []time.Duration{0}))
+}
+
 type QueueSuite struct {
 	q estimatedTaskQueue
 	suite.Suite
@@ -0,0 +1,104 @@
+package taskselection
+
+// This file provides database layer logic for the statistics used to predict
+// which tasks are likely to catch a regression in a patch.
+// The database schema is the following:
+// *file_task_stats*
+// {
+//   "_id": {
+//     "project": <Project Id (string)>,
+//     "file": <Name of a file changed by a patch (string)>,
+//     "variant": <Build variant (string)>,
+//     "task_name": <Task display name (string)>,
+//   },
+//   "num_runs": <Number of patches changing the file where the task ran and passed in the base commit (int)>,
+//   "num_regressions": <Number of those patches where the task failed (int)>,
+//   "last_update": <Date of the job run that last updated this document (date)>
+// }
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FileTaskStatsCollection = "file_task_stats"
+	bulkSize                = 1000
+)
+
+// DBFileTaskStatsID represents the _id field for file_task_stats documents.
+type DBFileTaskStatsID struct {
+	Project      string `bson:"project"`
+	File         string `bson:"file"`
+	BuildVariant string `bson:"variant"`
+	TaskName     string `bson:"task_name"`
+}
+
+// DBFileTaskStats represents the file_task_stats documents.
+type DBFileTaskStats struct {
+	Id             DBFileTaskStatsID `bson:"_id"`
+	NumRuns        int               `bson:"num_runs"`
+	NumRegressions int               `bson:"num_regressions"`
+	LastUpdate     time.Time         `bson:"last_update"`
+}
+
+var (
+	DBFileTaskStatsIdKey             = bsonutil.MustHaveTag(DBFileTaskStats{}, "Id")
+	DBFileTaskStatsNumRunsKey        = bsonutil.MustHaveTag(DBFileTaskStats{}, "NumRuns")
+	DBFileTaskStatsNumRegressionsKey = bsonutil.MustHaveTag(DBFileTaskStats{}, "NumRegressions")
+	DBFileTaskStatsLastUpdateKey     = bsonutil.MustHaveTag(DBFileTaskStats{}, "LastUpdate")
+
+	DBFileTaskStatsIDProjectKey      = bsonutil.MustHaveTag(DBFileTaskStatsID{}, "Project")
+	DBFileTaskStatsIDFileKey         = bsonutil.MustHaveTag(DBFileTaskStatsID{}, "File")
+	DBFileTaskStatsIDBuildVariantKey = bsonutil.MustHaveTag(DBFileTaskStatsID{}, "BuildVariant")
+	DBFileTaskStatsIDTaskNameKey     = bsonutil.MustHaveTag(DBFileTaskStatsID{}, "TaskName")
+
+	DBFileTaskStatsIDProjectKeyFull      = bsonutil.GetDottedKeyName(DBFileTaskStatsIdKey, DBFileTaskStatsIDProjectKey)
+	DBFileTaskStatsIDFileKeyFull         = bsonutil.GetDottedKeyName(DBFileTaskStatsIdKey, DBFileTaskStatsIDFileKey)
+	DBFileTaskStatsIDBuildVariantKeyFull = bsonutil.GetDottedKeyName(DBFileTaskStatsIdKey, DBFileTaskStatsIDBuildVariantKey)
+	DBFileTaskStatsIDTaskNameKeyFull     = bsonutil.GetDottedKeyName(DBFileTaskStatsIdKey, DBFileTaskStatsIDTaskNameKey)
+)
+
+// findFileTaskStats returns the stats of the given tasks for the given files
+// in the project.
+func findFileTaskStats(projectID string, files, taskNames []string) ([]DBFileTaskStats, error) {
+	stats := []DBFileTaskStats{}
+	q := db.Query(bson.M{
+		DBFileTaskStatsIDProjectKeyFull:  projectID,
+		DBFileTaskStatsIDFileKeyFull:     bson.M{"$in": files},
+		DBFileTaskStatsIDTaskNameKeyFull: bson.M{"$in": taskNames},
+	})
+	if err := db.FindAllQ(FileTaskStatsCollection, q, &stats); err != nil {
+		return nil, errors.Wrapf(err, "finding file task stats for project '%s'", projectID)
+	}
+	return stats, nil
+}
+
+// GetFileTaskStatsDoc returns the file task stats document with the given
+// ID, if it exists.
+func GetFileTaskStatsDoc(id DBFileTaskStatsID) (*DBFileTaskStats, error) {
+	doc := DBFileTaskStats{}
+	err := db.FindOneQ(FileTaskStatsCollection, db.Query(bson.M{DBFileTaskStatsIdKey: id}), &doc)
+	if err != nil {
+		if adb.ResultsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "finding file task stats")
+	}
+	return &doc, nil
+}
+
+// isTestFailure returns whether the task failed because of its own commands
+// rather than its host or setup.
+func isTestFailure(status, failureType string) bool {
+	if status != evergreen.TaskFailed {
+		return false
+	}
+	return failureType != evergreen.CommandTypeSystem && failureType != evergreen.CommandTypeSetup
+}
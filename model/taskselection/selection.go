@@ -0,0 +1,168 @@
+package taskselection
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinRuns is the number of times a task must have run in patches that
+	// changed a file before its results are trusted for that file. A task
+	// without enough history for any of a patch's changed files is always
+	// selected.
+	MinRuns = 5
+	// MinScore is the estimated probability of a regression below which a
+	// task is skipped.
+	MinScore = 0.02
+
+	// priorWeight is the number of pseudo-runs, each failing at the task's
+	// baseline failure rate, added to a task's history for a file. It keeps
+	// a handful of lucky runs from driving a task's score to zero.
+	priorWeight = 2.0
+	// baselineDays is the number of days of mainline task stats used to
+	// compute a task's baseline failure rate.
+	baselineDays = 28
+)
+
+// Candidate is a task that predictive selection can choose to run or skip.
+type Candidate struct {
+	BuildVariant string
+	TaskName     string
+}
+
+// Prediction is the outcome of predictive selection for a single candidate
+// task.
+type Prediction struct {
+	Candidate
+	// Selected indicates whether the task should run.
+	Selected bool
+	// Score is the estimated probability that the patch's changes cause the
+	// task to fail.
+	Score float64
+	// Reason explains why the task was selected or skipped.
+	Reason string
+}
+
+// Predict estimates, for each candidate task, the probability that a patch
+// changing the given files causes the task to fail, and selects the tasks
+// likely enough to catch a regression. Estimates combine how often the task
+// regressed in past patches that changed the same files with the task's
+// baseline mainline failure rate.
+func Predict(projectID string, files []string, candidates []Candidate) ([]Prediction, error) {
+	if len(files) == 0 || len(candidates) == 0 {
+		return predict(files, candidates, nil, nil), nil
+	}
+
+	taskNames := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		taskNames = append(taskNames, c.TaskName)
+	}
+	taskNames = utility.UniqueStrings(taskNames)
+
+	stats, err := findFileTaskStats(projectID, files, taskNames)
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := getBaselineFailureRates(projectID, taskNames)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting baseline failure rates")
+	}
+
+	return predict(files, candidates, stats, baseline), nil
+}
+
+func predict(files []string, candidates []Candidate, stats []DBFileTaskStats, baseline map[Candidate]float64) []Prediction {
+	statsByFile := map[Candidate]map[string]DBFileTaskStats{}
+	for _, s := range stats {
+		c := Candidate{BuildVariant: s.Id.BuildVariant, TaskName: s.Id.TaskName}
+		if statsByFile[c] == nil {
+			statsByFile[c] = map[string]DBFileTaskStats{}
+		}
+		statsByFile[c][s.Id.File] = s
+	}
+
+	predictions := make([]Prediction, 0, len(candidates))
+	for _, c := range candidates {
+		prediction := Prediction{Candidate: c, Selected: true}
+		if len(files) == 0 {
+			prediction.Reason = "the patch has no changed files"
+			predictions = append(predictions, prediction)
+			continue
+		}
+
+		var worst DBFileTaskStats
+		enoughHistory := true
+		for _, file := range files {
+			s, ok := statsByFile[c][file]
+			if !ok || s.NumRuns < MinRuns {
+				enoughHistory = false
+				break
+			}
+			score := (float64(s.NumRegressions) + priorWeight*baseline[c]) / (float64(s.NumRuns) + priorWeight)
+			if worst.Id.File == "" || score > prediction.Score {
+				prediction.Score = score
+				worst = s
+			}
+		}
+
+		switch {
+		case !enoughHistory:
+			prediction.Score = baseline[c]
+			prediction.Reason = "not enough history for the changed files"
+		case prediction.Score >= MinScore:
+			prediction.Reason = fmt.Sprintf("regressed in %d of %d patches that changed '%s'", worst.NumRegressions, worst.NumRuns, worst.Id.File)
+		default:
+			prediction.Selected = false
+			prediction.Reason = fmt.Sprintf("regressed in only %d of %d patches that changed '%s'", worst.NumRegressions, worst.NumRuns, worst.Id.File)
+		}
+		predictions = append(predictions, prediction)
+	}
+
+	return predictions
+}
+
+// getBaselineFailureRates returns the fraction of mainline runs of each of
+// the tasks that failed their tests in recent days.
+func getBaselineFailureRates(projectID string, taskNames []string) (map[Candidate]float64, error) {
+	today := utility.GetUTCDay(time.Now())
+	filter := taskstats.StatsFilter{
+		Project:      projectID,
+		Requesters:   []string{evergreen.RepotrackerVersionRequester},
+		AfterDate:    today.Add(-baselineDays * 24 * time.Hour),
+		BeforeDate:   today.Add(24 * time.Hour),
+		Tasks:        taskNames,
+		GroupNumDays: baselineDays + 1,
+		GroupBy:      taskstats.GroupByVariant,
+		Limit:        taskstats.MaxQueryLimit,
+		Sort:         taskstats.SortEarliestFirst,
+	}
+
+	rates := map[Candidate]float64{}
+	for {
+		stats, err := taskstats.GetTaskStats(filter)
+		if err != nil {
+			return nil, err
+		}
+		hasMore := len(stats) == filter.Limit
+		if hasMore {
+			// The last result is the first result of the next page.
+			startAt := taskstats.StartAtFromTaskStats(&stats[len(stats)-1])
+			filter.StartAt = &startAt
+			stats = stats[:len(stats)-1]
+		}
+		for _, s := range stats {
+			if s.NumTotal == 0 {
+				continue
+			}
+			rates[Candidate{BuildVariant: s.BuildVariant, TaskName: s.TaskName}] = float64(s.NumTestFailed) / float64(s.NumTotal)
+		}
+		if !hasMore {
+			return rates, nil
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package taskselection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredict(t *testing.T) {
+	compile := Candidate{BuildVariant: "ubuntu", TaskName: "compile"}
+	unit := Candidate{BuildVariant: "ubuntu", TaskName: "unit"}
+	lint := Candidate{BuildVariant: "ubuntu", TaskName: "lint"}
+	stat := func(c Candidate, file string, runs, regressions int) DBFileTaskStats {
+		return DBFileTaskStats{
+			Id: DBFileTaskStatsID{
+				Project:      "project",
+				File:         file,
+				BuildVariant: c.BuildVariant,
+				TaskName:     c.TaskName,
+			},
+			NumRuns:        runs,
+			NumRegressions: regressions,
+		}
+	}
+	files := []string{"main.go", "util.go"}
+	stats := []DBFileTaskStats{
+		stat(compile, "main.go", 20, 5),
+		stat(compile, "util.go", 20, 0),
+		stat(unit, "main.go", 50, 0),
+		stat(unit, "util.go", 50, 0),
+		stat(lint, "main.go", 50, 0),
+	}
+	baseline := map[Candidate]float64{unit: 0.1}
+
+	predictions := predict(files, []Candidate{compile, unit, lint}, stats, baseline)
+	require.Len(t, predictions, 3)
+
+	assert.Equal(t, compile, predictions[0].Candidate)
+	assert.True(t, predictions[0].Selected)
+	assert.InDelta(t, 5.0/22.0, predictions[0].Score, 0.0001)
+	assert.Contains(t, predictions[0].Reason, "main.go")
+
+	assert.Equal(t, unit, predictions[1].Candidate)
+	assert.False(t, predictions[1].Selected, "task that never regressed should be skipped")
+	assert.InDelta(t, 0.2/52.0, predictions[1].Score, 0.0001)
+	assert.Equal(t, "regressed in only 0 of 50 patches that changed 'main.go'", predictions[1].Reason)
+
+	assert.Equal(t, lint, predictions[2].Candidate)
+	assert.True(t, predictions[2].Selected, "task without history for every changed file should be selected")
+
+	t.Run("NotEnoughRuns", func(t *testing.T) {
+		predictions := predict([]string{"main.go"}, []Candidate{unit}, []DBFileTaskStats{stat(unit, "main.go", MinRuns-1, 0)}, nil)
+		require.Len(t, predictions, 1)
+		assert.True(t, predictions[0].Selected)
+	})
+	t.Run("NoChangedFiles", func(t *testing.T) {
+		predictions := predict(nil, []Candidate{unit}, stats, baseline)
+		require.Len(t, predictions, 1)
+		assert.True(t, predictions[0].Selected)
+	})
+}
+
+func TestGenerateStats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := testutil.NewEnvironment(ctx, t)
+	require.NoError(t, db.ClearCollections(FileTaskStatsCollection, patch.Collection, task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(FileTaskStatsCollection, patch.Collection, task.Collection))
+	}()
+
+	now := time.Now()
+	p := patch.Patch{
+		Id:         mgobson.NewObjectId(),
+		Project:    "project",
+		Githash:    "base",
+		Version:    "patch_version",
+		Status:     evergreen.PatchFailed,
+		FinishTime: now.Add(-time.Minute),
+		Patches: []patch.ModulePatch{
+			{PatchSet: patch.PatchSet{Summary: []thirdparty.Summary{{Name: "main.go"}}}},
+			{ModuleName: "module", PatchSet: patch.PatchSet{Summary: []thirdparty.Summary{{Name: "lib.go"}}}},
+		},
+	}
+	require.NoError(t, p.Insert())
+
+	tasks := []task.Task{
+		{Id: "base_compile", Project: "project", Revision: "base", Requester: evergreen.RepotrackerVersionRequester, BuildVariant: "ubuntu", DisplayName: "compile", Status: evergreen.TaskSucceeded},
+		{Id: "base_unit", Project: "project", Revision: "base", Requester: evergreen.RepotrackerVersionRequester, BuildVariant: "ubuntu", DisplayName: "unit", Status: evergreen.TaskSucceeded},
+		{Id: "base_lint", Project: "project", Revision: "base", Requester: evergreen.RepotrackerVersionRequester, BuildVariant: "ubuntu", DisplayName: "lint", Status: evergreen.TaskFailed},
+		{Id: "patch_compile", Project: "project", Version: "patch_version", Requester: evergreen.PatchVersionRequester, BuildVariant: "ubuntu", DisplayName: "compile", Status: evergreen.TaskFailed, Details: apimodels.TaskEndDetail{Type: evergreen.CommandTypeTest}},
+		{Id: "patch_unit", Project: "project", Version: "patch_version", Requester: evergreen.PatchVersionRequester, BuildVariant: "ubuntu", DisplayName: "unit", Status: evergreen.TaskFailed, Details: apimodels.TaskEndDetail{Type: evergreen.CommandTypeSystem}},
+		{Id: "patch_lint", Project: "project", Version: "patch_version", Requester: evergreen.PatchVersionRequester, BuildVariant: "ubuntu", DisplayName: "lint", Status: evergreen.TaskFailed},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, tsk.Insert())
+	}
+
+	opts := GenerateStatsOptions{
+		ProjectID: "project",
+		Start:     now.Add(-time.Hour),
+		End:       now,
+	}
+	require.NoError(t, GenerateStats(ctx, env, opts))
+
+	for _, file := range []string{"main.go", "module:lib.go"} {
+		compile, err := GetFileTaskStatsDoc(DBFileTaskStatsID{Project: "project", File: file, BuildVariant: "ubuntu", TaskName: "compile"})
+		require.NoError(t, err)
+		require.NotZero(t, compile)
+		assert.Equal(t, 1, compile.NumRuns)
+		assert.Equal(t, 1, compile.NumRegressions)
+
+		unit, err := GetFileTaskStatsDoc(DBFileTaskStatsID{Project: "project", File: file, BuildVariant: "ubuntu", TaskName: "unit"})
+		require.NoError(t, err)
+		require.NotZero(t, unit)
+		assert.Equal(t, 1, unit.NumRuns)
+		assert.Zero(t, unit.NumRegressions, "system failures should not count as regressions")
+
+		lint, err := GetFileTaskStatsDoc(DBFileTaskStatsID{Project: "project", File: file, BuildVariant: "ubuntu", TaskName: "lint"})
+		require.NoError(t, err)
+		assert.Zero(t, lint, "tasks that failed on the base commit should be ignored")
+	}
+
+	// Stats accumulate across windows.
+	require.NoError(t, GenerateStats(ctx, env, opts))
+	compile, err := GetFileTaskStatsDoc(DBFileTaskStatsID{Project: "project", File: "main.go", BuildVariant: "ubuntu", TaskName: "compile"})
+	require.NoError(t, err)
+	require.NotZero(t, compile)
+	assert.Equal(t, 2, compile.NumRuns)
+}
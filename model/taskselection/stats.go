@@ -0,0 +1,159 @@
+package taskselection
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxFilesPerPatch is the largest number of changed files a patch can have
+// for its results to be used. Larger patches are usually merges or bulk
+// refactors whose failures say little about any individual file.
+const maxFilesPerPatch = 100
+
+// GenerateStatsOptions represents the options for generating file task stats.
+type GenerateStatsOptions struct {
+	ProjectID string
+	// Start and End are the bounds of the window of time within which
+	// patches finished.
+	Start time.Time
+	End   time.Time
+}
+
+type fileTaskCounts struct {
+	numRuns        int
+	numRegressions int
+}
+
+// GenerateStats updates the file task stats of a project with the results of
+// the patches that finished within the given window of time. For each file
+// changed by a patch, a task counts as a run if it also succeeded on the
+// patch's base commit, and as a regression if it then failed in the patch.
+func GenerateStats(ctx context.Context, env evergreen.Environment, opts GenerateStatsOptions) error {
+	patches, err := patch.Find(db.Query(bson.M{
+		patch.ProjectKey:    opts.ProjectID,
+		patch.FinishTimeKey: bson.M{"$gte": opts.Start, "$lt": opts.End},
+		patch.VersionKey:    bson.M{"$ne": ""},
+	}).WithFields(
+		patch.IdKey,
+		patch.VersionKey,
+		patch.GithashKey,
+		bsonutil.GetDottedKeyName(patch.PatchesKey, patch.ModulePatchNameKey),
+		bsonutil.GetDottedKeyName(patch.PatchesKey, patch.ModulePatchSetKey, patch.PatchSetSummaryKey),
+	))
+	if err != nil {
+		return errors.Wrapf(err, "finding patches for project '%s'", opts.ProjectID)
+	}
+
+	counts := map[DBFileTaskStatsID]fileTaskCounts{}
+	for _, p := range patches {
+		files := p.ChangedFiles()
+		if len(files) == 0 || len(files) > maxFilesPerPatch {
+			continue
+		}
+
+		results, err := getPatchTaskResults(opts.ProjectID, p)
+		if err != nil {
+			return errors.Wrapf(err, "getting task results for patch '%s'", p.Id.Hex())
+		}
+		for _, file := range files {
+			for tv, regressed := range results {
+				id := DBFileTaskStatsID{
+					Project:      opts.ProjectID,
+					File:         file,
+					BuildVariant: tv.BuildVariant,
+					TaskName:     tv.TaskName,
+				}
+				c := counts[id]
+				c.numRuns++
+				if regressed {
+					c.numRegressions++
+				}
+				counts[id] = c
+			}
+		}
+	}
+
+	return errors.Wrap(updateFileTaskStats(ctx, env, counts), "updating file task stats")
+}
+
+// getPatchTaskResults returns whether each of the patch's finished tasks
+// regressed relative to the same task on the patch's base commit. Tasks that
+// did not succeed on the base commit are omitted since their failures in the
+// patch can't be attributed to its changes.
+func getPatchTaskResults(projectID string, p patch.Patch) (map[Candidate]bool, error) {
+	fields := []string{task.DisplayNameKey, task.BuildVariantKey, task.StatusKey, task.DetailsKey}
+	patchTasks, err := task.FindAll(db.Query(bson.M{
+		task.VersionKey: p.Version,
+		task.StatusKey:  bson.M{"$in": evergreen.TaskCompletedStatuses},
+	}).WithFields(fields...))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding patch tasks")
+	}
+	if len(patchTasks) == 0 {
+		return nil, nil
+	}
+	baseTasks, err := task.FindAll(db.Query(bson.M{
+		task.ProjectKey:   projectID,
+		task.RevisionKey:  p.Githash,
+		task.RequesterKey: evergreen.RepotrackerVersionRequester,
+		task.StatusKey:    evergreen.TaskSucceeded,
+	}).WithFields(fields...))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding base tasks")
+	}
+
+	passedOnBase := map[Candidate]bool{}
+	for _, t := range baseTasks {
+		passedOnBase[Candidate{BuildVariant: t.BuildVariant, TaskName: t.DisplayName}] = true
+	}
+	results := map[Candidate]bool{}
+	for _, t := range patchTasks {
+		tv := Candidate{BuildVariant: t.BuildVariant, TaskName: t.DisplayName}
+		if !passedOnBase[tv] {
+			continue
+		}
+		results[tv] = isTestFailure(t.Status, t.Details.Type)
+	}
+	return results, nil
+}
+
+func updateFileTaskStats(ctx context.Context, env evergreen.Environment, counts map[DBFileTaskStatsID]fileTaskCounts) error {
+	now := time.Now()
+	buf := make([]mongo.WriteModel, 0, bulkSize)
+	flush := func() error {
+		if len(buf) == 0 {
+			return nil
+		}
+		_, err := env.DB().Collection(FileTaskStatsCollection).BulkWrite(ctx, buf, options.BulkWrite().SetOrdered(false))
+		buf = buf[:0]
+		return errors.Wrapf(err, "bulk writing to collection '%s'", FileTaskStatsCollection)
+	}
+	for id, c := range counts {
+		buf = append(buf, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{DBFileTaskStatsIdKey: id}).
+			SetUpdate(bson.M{
+				"$inc": bson.M{
+					DBFileTaskStatsNumRunsKey:        c.numRuns,
+					DBFileTaskStatsNumRegressionsKey: c.numRegressions,
+				},
+				"$set": bson.M{DBFileTaskStatsLastUpdateKey: now},
+			}).
+			SetUpsert(true))
+		if len(buf) == bulkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/annotations"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const QuarantinedTestsCollection = "quarantined_tests"
+
+// QuarantinedTest is a pattern matching the names of tests in a project that
+// are known to be flaky. A task whose only failures are quarantined tests
+// succeeds instead of failing.
+type QuarantinedTest struct {
+	ProjectID string `bson:"project_id" json:"project_id"`
+	// Pattern is a regular expression matched against the display names of
+	// failed tests.
+	Pattern   string    `bson:"pattern" json:"pattern"`
+	Reason    string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	Author    string    `bson:"author,omitempty" json:"author,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+var (
+	quarantinedTestProjectIDKey = bsonutil.MustHaveTag(QuarantinedTest{}, "ProjectID")
+	quarantinedTestPatternKey   = bsonutil.MustHaveTag(QuarantinedTest{}, "Pattern")
+	quarantinedTestReasonKey    = bsonutil.MustHaveTag(QuarantinedTest{}, "Reason")
+	quarantinedTestAuthorKey    = bsonutil.MustHaveTag(QuarantinedTest{}, "Author")
+	quarantinedTestCreatedAtKey = bsonutil.MustHaveTag(QuarantinedTest{}, "CreatedAt")
+)
+
+// Validate checks that the quarantined test has a project and a valid
+// pattern.
+func (q *QuarantinedTest) Validate() error {
+	if q.ProjectID == "" {
+		return errors.New("must specify a project")
+	}
+	if strings.TrimSpace(q.Pattern) == "" {
+		return errors.New("must specify a test name pattern")
+	}
+	if _, err := regexp.Compile(q.Pattern); err != nil {
+		return errors.Wrapf(err, "invalid test name pattern '%s'", q.Pattern)
+	}
+	return nil
+}
+
+// Upsert quarantines the tests matching the pattern in the project. If the
+// pattern is already quarantined, its reason and author are updated.
+func (q *QuarantinedTest) Upsert() error {
+	if err := q.Validate(); err != nil {
+		return errors.Wrap(err, "invalid quarantined test")
+	}
+	if q.CreatedAt.IsZero() {
+		q.CreatedAt = time.Now()
+	}
+
+	_, err := db.Upsert(
+		QuarantinedTestsCollection,
+		bson.M{
+			quarantinedTestProjectIDKey: q.ProjectID,
+			quarantinedTestPatternKey:   q.Pattern,
+		},
+		bson.M{
+			"$set": bson.M{
+				quarantinedTestReasonKey:    q.Reason,
+				quarantinedTestAuthorKey:    q.Author,
+				quarantinedTestCreatedAtKey: q.CreatedAt,
+			},
+		},
+	)
+	return errors.Wrapf(err, "quarantining test pattern '%s' in project '%s'", q.Pattern, q.ProjectID)
+}
+
+// RemoveQuarantinedTest removes the pattern from the project's quarantined
+// tests. It is a no-op if the pattern is not quarantined.
+func RemoveQuarantinedTest(projectID, pattern string) error {
+	err := db.Remove(QuarantinedTestsCollection, bson.M{
+		quarantinedTestProjectIDKey: projectID,
+		quarantinedTestPatternKey:   pattern,
+	})
+	return errors.Wrapf(err, "removing quarantined test pattern '%s' from project '%s'", pattern, projectID)
+}
+
+// FindQuarantinedTestsForProject returns all quarantined tests in the
+// project, sorted by pattern.
+func FindQuarantinedTestsForProject(projectID string) ([]QuarantinedTest, error) {
+	quarantined := []QuarantinedTest{}
+	q := db.Query(bson.M{quarantinedTestProjectIDKey: projectID}).Sort([]string{quarantinedTestPatternKey})
+	if err := db.FindAllQ(QuarantinedTestsCollection, q, &quarantined); err != nil {
+		return nil, errors.Wrapf(err, "finding quarantined tests for project '%s'", projectID)
+	}
+	return quarantined, nil
+}
+
+// getQuarantinedFailures returns the names of the task's failed tests if all
+// of them are quarantined in the task's project. If the task failed for any
+// other reason or has failed tests that are not quarantined, it returns no
+// tests.
+//
+// A command failure is only attributed to the failed tests if the command
+// that failed the task is the one that sent the failed test results. If
+// resultsOnly is true, the failed test results are the only reason the task
+// failed.
+func getQuarantinedFailures(ctx context.Context, env evergreen.Environment, t *task.Task, details *apimodels.TaskEndDetail, resultsOnly bool) ([]string, error) {
+	if details.Status != evergreen.TaskFailed || details.TimedOut || !t.ResultsFailed {
+		return nil, nil
+	}
+	if !resultsOnly && !details.FailingCommandFailedTests {
+		return nil, nil
+	}
+	if details.Type == evergreen.CommandTypeSystem || details.Type == evergreen.CommandTypeSetup {
+		return nil, nil
+	}
+
+	quarantined, err := FindQuarantinedTestsForProject(t.Project)
+	if err != nil {
+		return nil, err
+	}
+	if len(quarantined) == 0 {
+		return nil, nil
+	}
+	patterns := make([]string, 0, len(quarantined))
+	for _, q := range quarantined {
+		patterns = append(patterns, q.Pattern)
+	}
+
+	taskOpts, err := t.CreateTestResultsTaskOptions()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating test results task options")
+	}
+	if len(taskOpts) == 0 {
+		return nil, nil
+	}
+	samples, err := testresult.GetFailedTestSamples(ctx, env, taskOpts, patterns)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting failed test samples")
+	}
+
+	var failures []string
+	for _, sample := range samples {
+		if len(sample.MatchingFailedTestNames) != sample.TotalFailedNames {
+			return nil, nil
+		}
+		failures = append(failures, sample.MatchingFailedTestNames...)
+	}
+
+	return utility.UniqueStrings(failures), nil
+}
+
+// annotateQuarantinedFailures adds a note to the task's annotation listing
+// the quarantined tests that failed.
+func annotateQuarantinedFailures(t *task.Task, failures []string) error {
+	message := "Quarantined failures: " + strings.Join(failures, ", ")
+	return annotations.AddSystemNote(t.Id, t.Execution, message, annotations.QuarantineRequester)
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantinedTestValidate(t *testing.T) {
+	for name, q := range map[string]QuarantinedTest{
+		"MissingProject": {Pattern: "TestA"},
+		"MissingPattern": {ProjectID: "p"},
+		"BlankPattern":   {ProjectID: "p", Pattern: "  "},
+		"InvalidRegex":   {ProjectID: "p", Pattern: "Test("},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, q.Validate())
+		})
+	}
+	q := QuarantinedTest{ProjectID: "p", Pattern: "^TestA$"}
+	assert.NoError(t, q.Validate())
+}
+
+func TestQuarantinedTests(t *testing.T) {
+	require.NoError(t, db.ClearCollections(QuarantinedTestsCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(QuarantinedTestsCollection))
+	}()
+
+	for _, q := range []QuarantinedTest{
+		{ProjectID: "p1", Pattern: "TestB", Reason: "flaky"},
+		{ProjectID: "p1", Pattern: "TestA"},
+		{ProjectID: "p2", Pattern: "TestA"},
+	} {
+		require.NoError(t, q.Upsert())
+	}
+	invalid := QuarantinedTest{ProjectID: "p1", Pattern: "Test("}
+	assert.Error(t, invalid.Upsert())
+
+	quarantined, err := FindQuarantinedTestsForProject("p1")
+	require.NoError(t, err)
+	require.Len(t, quarantined, 2)
+	assert.Equal(t, "TestA", quarantined[0].Pattern)
+	assert.Equal(t, "TestB", quarantined[1].Pattern)
+	assert.Equal(t, "flaky", quarantined[1].Reason)
+
+	updated := QuarantinedTest{ProjectID: "p1", Pattern: "TestB", Reason: "very flaky", Author: "me"}
+	require.NoError(t, updated.Upsert())
+	quarantined, err = FindQuarantinedTestsForProject("p1")
+	require.NoError(t, err)
+	require.Len(t, quarantined, 2)
+	assert.Equal(t, "very flaky", quarantined[1].Reason)
+	assert.Equal(t, "me", quarantined[1].Author)
+
+	require.NoError(t, RemoveQuarantinedTest("p1", "TestA"))
+	require.NoError(t, RemoveQuarantinedTest("p1", "TestC"))
+	quarantined, err = FindQuarantinedTestsForProject("p1")
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+	assert.Equal(t, "TestB", quarantined[0].Pattern)
+
+	quarantined, err = FindQuarantinedTestsForProject("p2")
+	require.NoError(t, err)
+	assert.Len(t, quarantined, 1)
+}
@@ -2,73 +2,196 @@ package testresult
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/mongodb/anser/bsonutil"
 	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const Collection = "testresults"
 
+// maxResultsPerDocument is the maximum number of test results stored in a
+// single DB document. Large test suites are split across multiple documents
+// so that no single document exceeds the maximum BSON document size.
+const maxResultsPerDocument = 5000
+
+// dbTaskTestResults is a chunk of test results for a single task execution.
+// A task execution's results may be spread across several documents, which
+// are merged when read.
 type dbTaskTestResults struct {
-	ID      dbTaskTestResultsID  `bson:"_id"`
-	Stats   TaskTestResultsStats `bson:"stats"`
-	Results []TestResult         `bson:"results"`
+	ID        string               `bson:"_id"`
+	TaskID    string               `bson:"task_id"`
+	Execution int                  `bson:"execution"`
+	Stats     TaskTestResultsStats `bson:"stats"`
+	Results   []TestResult         `bson:"results"`
 }
 
-type dbTaskTestResultsID struct {
-	TaskID    string `bson:"task_id"`
-	Execution int    `bson:"execution"`
+var (
+	idKey        = bsonutil.MustHaveTag(dbTaskTestResults{}, "ID")
+	taskIDKey    = bsonutil.MustHaveTag(dbTaskTestResults{}, "TaskID")
+	executionKey = bsonutil.MustHaveTag(dbTaskTestResults{}, "Execution")
+	statsKey     = bsonutil.MustHaveTag(dbTaskTestResults{}, "Stats")
+	resultsKey   = bsonutil.MustHaveTag(dbTaskTestResults{}, "Results")
+
+	testNameKey = bsonutil.MustHaveTag(TestResult{}, "TestName")
+	statusKey   = bsonutil.MustHaveTag(TestResult{}, "Status")
+	attemptKey  = bsonutil.MustHaveTag(TestResult{}, "Attempt")
+)
+
+// failedCountFields are the fields of the results needed to count the failed
+// tests of a task execution.
+var failedCountFields = []string{
+	bsonutil.GetDottedKeyName(resultsKey, testNameKey),
+	bsonutil.GetDottedKeyName(resultsKey, statusKey),
+	bsonutil.GetDottedKeyName(resultsKey, attemptKey),
 }
 
-var (
-	idKey      = bsonutil.MustHaveTag(dbTaskTestResults{}, "ID")
-	statsKey   = bsonutil.MustHaveTag(dbTaskTestResults{}, "Stats")
-	resultsKey = bsonutil.MustHaveTag(dbTaskTestResults{}, "Results")
+// TaskExecutionIndex is the index used to look up a task execution's test
+// results.
+var TaskExecutionIndex = bson.D{
+	{
+		Name:  taskIDKey,
+		Value: 1,
+	},
+	{
+		Name:  executionKey,
+		Value: 1,
+	},
+}
+
+// EnsureLocalIndexes creates the indexes for the local test results store if
+// they don't already exist.
+func EnsureLocalIndexes(ctx context.Context, env evergreen.Environment) error {
+	_, err := env.DB().Collection(Collection).Indexes().CreateOne(ctx, mongo.IndexModel{Keys: TaskExecutionIndex})
+	return errors.Wrap(err, "creating local test results index")
+}
 
-	taskIDKey    = bsonutil.MustHaveTag(dbTaskTestResultsID{}, "TaskID")
-	executionKey = bsonutil.MustHaveTag(dbTaskTestResultsID{}, "Execution")
+// dbTaskTestResultsID identifies the test results of a single task
+// execution.
+type dbTaskTestResultsID struct {
+	TaskID    string
+	Execution int
+}
 
-	totalCountKey  = bsonutil.MustHaveTag(TaskTestResultsStats{}, "TotalCount")
-	failedCountKey = bsonutil.MustHaveTag(TaskTestResultsStats{}, "FailedCount")
-)
+// chunkID returns the document ID of a chunk of a batch of results. The ID is
+// deterministic so that sending the same batch again does not insert
+// duplicate results, and it begins with the batch ID so that documents sort
+// in the order their batches were created.
+func (id dbTaskTestResultsID) chunkID(batchID string, chunk int) string {
+	return fmt.Sprintf("%s-%04d-%s-%d", batchID, chunk, id.TaskID, id.Execution)
+}
 
-func (id dbTaskTestResultsID) appendResults(ctx context.Context, env evergreen.Environment, results []TestResult) error {
-	var failedCount int
-	for _, result := range results {
-		if result.Status == evergreen.TestFailedStatus {
-			failedCount++
+func (id dbTaskTestResultsID) appendResults(ctx context.Context, env evergreen.Environment, batchID string, results []TestResult) error {
+	var docs []interface{}
+	for start := 0; start < len(results); start += maxResultsPerDocument {
+		end := start + maxResultsPerDocument
+		if end > len(results) {
+			end = len(results)
 		}
+		chunk := results[start:end]
+
+		docs = append(docs, dbTaskTestResults{
+			ID:        id.chunkID(batchID, len(docs)),
+			TaskID:    id.TaskID,
+			Execution: id.Execution,
+			Stats: TaskTestResultsStats{
+				TotalCount:  len(chunk),
+				FailedCount: countFailedTests(chunk),
+			},
+			Results: chunk,
+		})
+	}
+	if len(docs) == 0 {
+		return nil
 	}
 
-	update := bson.M{
-		"$push": bson.M{resultsKey: bson.M{"$each": results}},
-		"$inc": bson.M{
-			bsonutil.GetDottedKeyName(statsKey, totalCountKey):  len(results),
-			bsonutil.GetDottedKeyName(statsKey, failedCountKey): failedCount,
-		},
+	// The insert is unordered so that the chunks that were not inserted by
+	// an earlier attempt are still inserted when other chunks already
+	// exist.
+	_, err := env.DB().Collection(Collection).InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if db.IsDuplicateKey(err) {
+		return nil
 	}
-	_, err := env.DB().Collection(Collection).UpdateOne(ctx, bson.M{idKey: id}, update, options.Update().SetUpsert(true))
 	return errors.Wrap(err, "appending DB test results")
 }
 
-func appendDBResults(ctx context.Context, env evergreen.Environment, results []TestResult) error {
-	ids := map[dbTaskTestResultsID][]TestResult{}
+func appendDBResults(ctx context.Context, env evergreen.Environment, batchID string, results []TestResult) error {
+	var ids []dbTaskTestResultsID
+	idsToResults := map[dbTaskTestResultsID][]TestResult{}
 	for _, result := range results {
 		id := dbTaskTestResultsID{
 			TaskID:    result.TaskID,
 			Execution: result.Execution,
 		}
-		ids[id] = append(ids[id], result)
+		if _, ok := idsToResults[id]; !ok {
+			ids = append(ids, id)
+		}
+		idsToResults[id] = append(idsToResults[id], result)
 	}
 
-	for id, results := range ids {
-		if err := id.appendResults(ctx, env, results); err != nil {
+	for _, id := range ids {
+		if err := id.appendResults(ctx, env, batchID, idsToResults[id]); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// countFailedTests returns the number of failed test results, counting only
+// the final attempt of each test. A test that fails and then passes when it's
+// retried is not a failure.
+func countFailedTests(results []TestResult) int {
+	finalAttempts := map[string]int{}
+	for _, result := range results {
+		if result.Attempt > finalAttempts[result.TestName] {
+			finalAttempts[result.TestName] = result.Attempt
+		}
+	}
+
+	var count int
+	for _, result := range results {
+		if result.Status == evergreen.TestFailedStatus && result.Attempt == finalAttempts[result.TestName] {
+			count++
+		}
+	}
+	return count
+}
+
+// mergeDBResults merges the test results documents, which must be sorted by
+// task ID and execution, into a single set of results per task execution.
+// Since a test's attempts may be in different documents, the failed count is
+// recounted from the merged results if they were fetched.
+func mergeDBResults(docs []dbTaskTestResults) []dbTaskTestResults {
+	var merged []dbTaskTestResults
+	for _, doc := range docs {
+		if n := len(merged); n > 0 && merged[n-1].TaskID == doc.TaskID && merged[n-1].Execution == doc.Execution {
+			merged[n-1].Stats.TotalCount += doc.Stats.TotalCount
+			merged[n-1].Stats.FailedCount += doc.Stats.FailedCount
+			merged[n-1].Results = append(merged[n-1].Results, doc.Results...)
+			continue
+		}
+
+		merged = append(merged, dbTaskTestResults{
+			TaskID:    doc.TaskID,
+			Execution: doc.Execution,
+			Stats: TaskTestResultsStats{
+				TotalCount:  doc.Stats.TotalCount,
+				FailedCount: doc.Stats.FailedCount,
+			},
+			Results: doc.Results,
+		})
+	}
+	for i := range merged {
+		if len(merged[i].Results) > 0 {
+			merged[i].Stats.FailedCount = countFailedTests(merged[i].Results)
+		}
+	}
+
+	return merged
+}
@@ -15,10 +15,19 @@ import (
 
 const maxSampleSize = 10
 
-// InsertLocal inserts the given test results into the local test results store
-// for testing and local development.
+// InsertLocal appends the given test results to the local test results store.
+// Results are grouped by task execution, so results for several tasks may be
+// inserted at once.
 func InsertLocal(ctx context.Context, env evergreen.Environment, results ...TestResult) error {
-	return errors.Wrap(appendDBResults(ctx, env, results), "inserting local test results")
+	return InsertLocalBatch(ctx, env, bson.NewObjectId().Hex(), results...)
+}
+
+// InsertLocalBatch is the same as InsertLocal, but identifies the results by
+// the given batch ID. Inserting a batch more than once, such as when a
+// request is retried, only stores its results once. Batch IDs should be
+// ObjectIds so that results are stored in the order they were created.
+func InsertLocalBatch(ctx context.Context, env evergreen.Environment, batchID string, results ...TestResult) error {
+	return errors.Wrap(appendDBResults(ctx, env, batchID, results), "inserting local test results")
 }
 
 // ClearLocal clears the local test results store.
@@ -26,7 +35,9 @@ func ClearLocal(ctx context.Context, env evergreen.Environment) error {
 	return errors.Wrap(env.DB().Collection(Collection).Drop(ctx), "clearing the local test results store")
 }
 
-// localService implements the local test results service.
+// localService implements the local test results service, which stores test
+// results in the application database. It is used by deployments that do not
+// have a Cedar service.
 type localService struct {
 	env evergreen.Environment
 }
@@ -60,7 +71,7 @@ func (s *localService) GetMergedTaskTestResults(ctx context.Context, taskOpts []
 }
 
 func (s *localService) GetMergedTaskTestResultsStats(ctx context.Context, taskOpts []TaskOptions) (TaskTestResultsStats, error) {
-	allTaskResults, err := s.get(ctx, taskOpts, statsKey)
+	allTaskResults, err := s.get(ctx, taskOpts, append([]string{statsKey}, failedCountFields...)...)
 	if err != nil {
 		return TaskTestResultsStats{}, errors.Wrap(err, "getting local test results")
 	}
@@ -109,8 +120,8 @@ func (s *localService) GetFailedTestSamples(ctx context.Context, taskOpts []Task
 
 	samples := make([]TaskTestResultsFailedSample, len(allTaskResults))
 	for i, taskResults := range allTaskResults {
-		samples[i].TaskID = taskResults.Results[0].TaskID
-		samples[i].Execution = taskResults.Results[0].Execution
+		samples[i].TaskID = taskResults.TaskID
+		samples[i].Execution = taskResults.Execution
 
 		if taskResults.Stats.FailedCount == 0 {
 			continue
@@ -135,42 +146,46 @@ func (s *localService) GetFailedTestSamples(ctx context.Context, taskOpts []Task
 	return samples, nil
 }
 
-// get fetches the unmerged test results for the given tasks from the local
-// store.
-func (s *localService) get(ctx context.Context, taskOpts []TaskOptions, fields ...string) ([]TaskTestResults, error) {
-	ids := make([]dbTaskTestResultsID, len(taskOpts))
+// get fetches the test results for the given tasks from the local store,
+// merged into a single set of results per task execution. Tasks without any
+// test results are omitted.
+func (s *localService) get(ctx context.Context, taskOpts []TaskOptions, fields ...string) ([]dbTaskTestResults, error) {
+	if len(taskOpts) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]bson.M, len(taskOpts))
 	for i, task := range taskOpts {
-		ids[i].TaskID = task.TaskID
-		ids[i].Execution = task.Execution
+		ids[i] = bson.M{
+			taskIDKey:    task.TaskID,
+			executionKey: task.Execution,
+		}
 	}
 
-	filter := bson.M{idKey: bson.M{"$in": ids}}
+	filter := bson.M{"$or": ids}
 	opts := options.Find()
-	opts.SetSort(bson.D{{Name: taskIDKey, Value: 1}, {Name: executionKey, Value: 1}})
+	opts.SetSort(bson.D{{Name: taskIDKey, Value: 1}, {Name: executionKey, Value: 1}, {Name: idKey, Value: 1}})
 	if len(fields) > 0 {
-		projection := bson.M{}
+		projection := bson.M{
+			taskIDKey:    1,
+			executionKey: 1,
+		}
 		for _, field := range fields {
 			projection[field] = 1
 		}
 		opts.SetProjection(projection)
 	}
 
-	var allDBTaskResults []dbTaskTestResults
+	var docs []dbTaskTestResults
 	cur, err := s.env.DB().Collection(Collection).Find(ctx, filter, opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "finding DB test results")
 	}
-	if err = cur.All(ctx, &allDBTaskResults); err != nil {
+	if err = cur.All(ctx, &docs); err != nil {
 		return nil, errors.Wrap(err, "reading DB test results")
 	}
 
-	allTaskResults := make([]TaskTestResults, len(allDBTaskResults))
-	for i, dbTaskResults := range allDBTaskResults {
-		allTaskResults[i].Stats = dbTaskResults.Stats
-		allTaskResults[i].Results = dbTaskResults.Results
-	}
-
-	return allTaskResults, nil
+	return mergeDBResults(docs), nil
 }
 
 // filterAndSortTestResults takes a slice of test results and returns a
@@ -184,15 +199,19 @@ func (s *localService) filterAndSortTestResults(ctx context.Context, results []T
 	}
 
 	baseStatusMap := map[string]string{}
-	baseResults, err := s.GetMergedTaskTestResults(ctx, opts.BaseTasks, nil)
-	if err != nil {
-		return nil, 0, errors.Wrap(err, "getting base test results")
-	}
-	for _, result := range baseResults.Results {
-		baseStatusMap[result.GetDisplayTestName()] = result.Status
+	if len(opts.BaseTasks) > 0 {
+		baseResults, err := s.GetMergedTaskTestResults(ctx, opts.BaseTasks, nil)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "getting base test results")
+		}
+		// Results are in attempt order, so the base status of a retried
+		// test is the status of its final attempt.
+		for _, result := range baseResults.Results {
+			baseStatusMap[result.GetDisplayTestName()] = result.Status
+		}
 	}
 
-	results, err = s.filterTestResults(results, opts)
+	results, err := s.filterTestResults(results, opts)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "filtering test results")
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/utility"
 	"github.com/stretchr/testify/assert"
@@ -609,3 +610,137 @@ func TestLocalFilterAndSortTestResults(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalServiceLargeResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+	svc := newLocalService(env)
+	require.NoError(t, ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, ClearLocal(ctx, env))
+	}()
+
+	task := TaskOptions{TaskID: "task", Execution: 0}
+	savedResults := make([]TestResult, maxResultsPerDocument+10)
+	for i := 0; i < len(savedResults); i++ {
+		result := getTestResult()
+		result.TaskID = task.TaskID
+		result.Execution = task.Execution
+		if i%10 == 0 {
+			result.Status = evergreen.TestFailedStatus
+		}
+		savedResults[i] = result
+	}
+	require.NoError(t, InsertLocal(ctx, env, savedResults...))
+
+	count, err := env.DB().Collection(Collection).CountDocuments(ctx, bson.M{taskIDKey: task.TaskID})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count, "results should be split across multiple documents")
+
+	stats, err := svc.GetMergedTaskTestResultsStats(ctx, []TaskOptions{task})
+	require.NoError(t, err)
+	assert.Equal(t, len(savedResults), stats.TotalCount)
+	assert.Equal(t, len(savedResults)/10, stats.FailedCount)
+
+	taskResults, err := svc.GetMergedTaskTestResults(ctx, []TaskOptions{task}, &FilterOptions{Limit: 10, Page: maxResultsPerDocument / 10})
+	require.NoError(t, err)
+	assert.Equal(t, len(savedResults), utility.FromIntPtr(taskResults.Stats.FilteredCount))
+	assert.Equal(t, savedResults[maxResultsPerDocument:], taskResults.Results)
+}
+
+func TestLocalServiceRetriedTests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+	svc := newLocalService(env)
+	require.NoError(t, ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, ClearLocal(ctx, env))
+	}()
+
+	task := TaskOptions{TaskID: "task", Execution: 0}
+	firstAttempt := getTestResult()
+	firstAttempt.TaskID = task.TaskID
+	firstAttempt.Status = evergreen.TestFailedStatus
+	failed := getTestResult()
+	failed.TaskID = task.TaskID
+	failed.Status = evergreen.TestFailedStatus
+	require.NoError(t, InsertLocal(ctx, env, firstAttempt, failed))
+
+	// The retry that passes is in a different document than the failed
+	// attempt.
+	retry := firstAttempt
+	retry.Status = evergreen.TestSucceededStatus
+	retry.Attempt = 1
+	require.NoError(t, InsertLocal(ctx, env, retry))
+
+	stats, err := svc.GetMergedTaskTestResultsStats(ctx, []TaskOptions{task})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalCount)
+	assert.Equal(t, 1, stats.FailedCount)
+
+	taskResults, err := svc.GetMergedTaskTestResults(ctx, []TaskOptions{task}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, taskResults.Stats.TotalCount)
+	assert.Equal(t, 1, taskResults.Stats.FailedCount)
+}
+
+func TestCountFailedTests(t *testing.T) {
+	assert.Zero(t, countFailedTests(nil))
+	assert.Equal(t, 2, countFailedTests([]TestResult{
+		{TestName: "test0", Status: evergreen.TestFailedStatus},
+		{TestName: "test1", Status: evergreen.TestFailedStatus},
+		{TestName: "test2", Status: evergreen.TestSucceededStatus},
+	}))
+	assert.Equal(t, 1, countFailedTests([]TestResult{
+		{TestName: "test0", Status: evergreen.TestFailedStatus},
+		{TestName: "test0", Status: evergreen.TestSucceededStatus, Attempt: 1},
+		{TestName: "test1", Status: evergreen.TestSucceededStatus},
+		{TestName: "test1", Status: evergreen.TestFailedStatus, Attempt: 1},
+	}))
+}
+
+func TestMergeDBResults(t *testing.T) {
+	docs := []dbTaskTestResults{
+		{
+			TaskID:  "task0",
+			Stats:   TaskTestResultsStats{TotalCount: 2, FailedCount: 1},
+			Results: []TestResult{{TestName: "test0", Status: evergreen.TestFailedStatus}, {TestName: "test1"}},
+		},
+		{
+			TaskID:  "task0",
+			Stats:   TaskTestResultsStats{TotalCount: 1},
+			Results: []TestResult{{TestName: "test2"}},
+		},
+		{
+			TaskID:    "task0",
+			Execution: 1,
+			Stats:     TaskTestResultsStats{TotalCount: 1, FailedCount: 1},
+			Results:   []TestResult{{TestName: "test0", Status: evergreen.TestFailedStatus}},
+		},
+		{
+			TaskID:    "task0",
+			Execution: 1,
+			Stats:     TaskTestResultsStats{TotalCount: 1},
+			Results:   []TestResult{{TestName: "test0", Status: evergreen.TestSucceededStatus, Attempt: 1}},
+		},
+		{
+			TaskID:  "task1",
+			Stats:   TaskTestResultsStats{TotalCount: 1},
+			Results: []TestResult{{TestName: "test0"}},
+		},
+	}
+
+	merged := mergeDBResults(docs)
+	require.Len(t, merged, 3)
+	assert.Equal(t, "task0", merged[0].TaskID)
+	assert.Equal(t, 0, merged[0].Execution)
+	assert.Equal(t, TaskTestResultsStats{TotalCount: 3, FailedCount: 1}, merged[0].Stats)
+	assert.Equal(t, []TestResult{{TestName: "test0", Status: evergreen.TestFailedStatus}, {TestName: "test1"}, {TestName: "test2"}}, merged[0].Results)
+	assert.Equal(t, "task0", merged[1].TaskID)
+	assert.Equal(t, 1, merged[1].Execution)
+	assert.Equal(t, TaskTestResultsStats{TotalCount: 2}, merged[1].Stats, "failed attempt that passed on retry should not be counted")
+	assert.Equal(t, "task1", merged[2].TaskID)
+	assert.Empty(t, mergeDBResults(nil))
+}
@@ -42,6 +42,10 @@ type TestResult struct {
 	LineNum         int       `json:"line_num" bson:"line_num"`
 	TestStartTime   time.Time `json:"test_start_time" bson:"test_start_time"`
 	TestEndTime     time.Time `json:"test_end_time" bson:"test_end_time"`
+	// Attempt is the zero-indexed attempt number of the test within a single
+	// task execution. Tests that are retried by the test framework (for
+	// example, flaky reruns) report one result per attempt.
+	Attempt int `json:"attempt,omitempty" bson:"attempt,omitempty"`
 }
 
 // GetLogTestName returns the name of the test in the logging backend. This is
@@ -183,7 +187,42 @@ func GetMergedTaskTestResults(ctx context.Context, env evergreen.Environment, ta
 		return TaskTestResults{}, err
 	}
 
-	return svc.GetMergedTaskTestResults(ctx, taskOpts, filterOpts)
+	results, err := svc.GetMergedTaskTestResults(ctx, taskOpts, filterOpts)
+	if err != nil {
+		return TaskTestResults{}, err
+	}
+	markQuarantinedResults(taskOpts, results.Results)
+
+	return results, nil
+}
+
+// markQuarantinedResults sets the status of the failed test results that were
+// quarantined in their task to quarantined. The results service stores them
+// as failures, so filtering and sorting by status treats them as failures.
+func markQuarantinedResults(taskOpts []TaskOptions, results []TestResult) {
+	quarantined := map[dbTaskTestResultsID]map[string]bool{}
+	for _, opts := range taskOpts {
+		if len(opts.QuarantinedTests) == 0 {
+			continue
+		}
+		key := dbTaskTestResultsID{TaskID: opts.TaskID, Execution: opts.Execution}
+		quarantined[key] = map[string]bool{}
+		for _, testName := range opts.QuarantinedTests {
+			quarantined[key][testName] = true
+		}
+	}
+	if len(quarantined) == 0 {
+		return
+	}
+
+	for i, result := range results {
+		if result.Status != evergreen.TestFailedStatus {
+			continue
+		}
+		if quarantined[dbTaskTestResultsID{TaskID: result.TaskID, Execution: result.Execution}][result.GetDisplayTestName()] {
+			results[i].Status = evergreen.TestQuarantinedStatus
+		}
+	}
 }
 
 // GetMergedTaskTestResultsStats returns the aggregated statistics of the test
@@ -281,6 +320,9 @@ type TaskOptions struct {
 	TaskID         string
 	Execution      int
 	ResultsService string
+	// QuarantinedTests are the names of the task's failed tests that were
+	// quarantined in the project when the task finished.
+	QuarantinedTests []string
 }
 
 // SortBy describes the properties by which to sort a set of test results.
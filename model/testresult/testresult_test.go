@@ -192,6 +192,27 @@ func TestGetMergedTaskTestResults(t *testing.T) {
 	}
 }
 
+func TestMarkQuarantinedResults(t *testing.T) {
+	taskOpts := []TaskOptions{
+		{TaskID: "task0", Execution: 1, QuarantinedTests: []string{"TestFlaky"}},
+		{TaskID: "task1", Execution: 0},
+	}
+	results := []TestResult{
+		{TaskID: "task0", Execution: 1, TestName: "TestFlaky", Status: evergreen.TestFailedStatus},
+		{TaskID: "task0", Execution: 1, TestName: "TestFlaky", Status: evergreen.TestSucceededStatus},
+		{TaskID: "task0", Execution: 1, TestName: "TestOther", Status: evergreen.TestFailedStatus},
+		{TaskID: "task0", Execution: 0, TestName: "TestFlaky", Status: evergreen.TestFailedStatus},
+		{TaskID: "task1", Execution: 0, TestName: "TestFlaky", Status: evergreen.TestFailedStatus},
+	}
+
+	markQuarantinedResults(taskOpts, results)
+	assert.Equal(t, evergreen.TestQuarantinedStatus, results[0].Status)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[1].Status)
+	assert.Equal(t, evergreen.TestFailedStatus, results[2].Status)
+	assert.Equal(t, evergreen.TestFailedStatus, results[3].Status)
+	assert.Equal(t, evergreen.TestFailedStatus, results[4].Status)
+}
+
 func TestGetMergedTaskTestResultsStats(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -0,0 +1,87 @@
+package teststats
+
+// This file provides database layer logic for pre-computed test execution
+// statistics.
+// The database schema is the following:
+// *daily_test_stats*
+// {
+//   "_id": {
+//     "test_name": <Test name (string)>,
+//     "task_name": <Task display name (string)>,
+//     "variant": <Build variant (string)>,
+//     "project": <Project Id (string)>,
+//     "requester": <Requester (string)>,
+//     "date": <UTC day period this document covers (date)>,
+//   },
+//   "num_pass": <Number of task executions in which the test's final attempt passed (int)>,
+//   "num_fail": <Number of task executions in which the test's final attempt failed (int)>,
+//   "num_commits": <Number of distinct commits on which the test ran (int)>,
+//   "num_flaky": <Number of commits on which the test both failed and passed (int)>,
+//   "last_update": <Date of the job run that last updated this document (date)>
+// }
+
+import (
+	"time"
+
+	"github.com/mongodb/anser/bsonutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const DailyTestStatsCollection = "daily_test_stats"
+
+// DBTestStatsID represents the _id field for daily_test_stats documents.
+type DBTestStatsID struct {
+	TestName     string    `bson:"test_name"`
+	TaskName     string    `bson:"task_name"`
+	BuildVariant string    `bson:"variant"`
+	Project      string    `bson:"project"`
+	Requester    string    `bson:"requester"`
+	Date         time.Time `bson:"date"`
+}
+
+// DBTestStats represents the daily_test_stats documents.
+type DBTestStats struct {
+	Id         DBTestStatsID `bson:"_id"`
+	NumPass    int           `bson:"num_pass"`
+	NumFail    int           `bson:"num_fail"`
+	NumCommits int           `bson:"num_commits"`
+	NumFlaky   int           `bson:"num_flaky"`
+	LastUpdate time.Time     `bson:"last_update"`
+}
+
+var (
+	// BSON fields for the test stats ID struct.
+	DBTestStatsIDTestNameKey     = bsonutil.MustHaveTag(DBTestStatsID{}, "TestName")
+	DBTestStatsIDTaskNameKey     = bsonutil.MustHaveTag(DBTestStatsID{}, "TaskName")
+	DBTestStatsIDBuildVariantKey = bsonutil.MustHaveTag(DBTestStatsID{}, "BuildVariant")
+	DBTestStatsIDProjectKey      = bsonutil.MustHaveTag(DBTestStatsID{}, "Project")
+	DBTestStatsIDRequesterKey    = bsonutil.MustHaveTag(DBTestStatsID{}, "Requester")
+	DBTestStatsIDDateKey         = bsonutil.MustHaveTag(DBTestStatsID{}, "Date")
+
+	// BSON fields for the test stats struct.
+	DBTestStatsIDKey         = bsonutil.MustHaveTag(DBTestStats{}, "Id")
+	DBTestStatsNumPassKey    = bsonutil.MustHaveTag(DBTestStats{}, "NumPass")
+	DBTestStatsNumFailKey    = bsonutil.MustHaveTag(DBTestStats{}, "NumFail")
+	DBTestStatsNumCommitsKey = bsonutil.MustHaveTag(DBTestStats{}, "NumCommits")
+	DBTestStatsNumFlakyKey   = bsonutil.MustHaveTag(DBTestStats{}, "NumFlaky")
+	DBTestStatsLastUpdateKey = bsonutil.MustHaveTag(DBTestStats{}, "LastUpdate")
+
+	// BSON dotted field names for test stats ID elements.
+	DBTestStatsIDTestNameKeyFull     = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDTestNameKey)
+	DBTestStatsIDTaskNameKeyFull     = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDTaskNameKey)
+	DBTestStatsIDBuildVariantKeyFull = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDBuildVariantKey)
+	DBTestStatsIDProjectKeyFull      = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDProjectKey)
+	DBTestStatsIDRequesterKeyFull    = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDRequesterKey)
+	DBTestStatsIDDateKeyFull         = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDDateKey)
+)
+
+// statsForDayQuery returns a query to find the test stats documents for the
+// given tasks in a project on a single day.
+func statsForDayQuery(projectID, requester string, day time.Time, tasks []string) bson.M {
+	return bson.M{
+		DBTestStatsIDProjectKeyFull:   projectID,
+		DBTestStatsIDRequesterKeyFull: requester,
+		DBTestStatsIDDateKeyFull:      day,
+		DBTestStatsIDTaskNameKeyFull:  bson.M{"$in": tasks},
+	}
+}
@@ -0,0 +1,276 @@
+// Package teststats provides functions to generate pre-computed test
+// statistics. The statistics are aggregated per day and a combination of
+// (project, variant, task, test, requester) and record how often each test
+// passed, failed, and flaked.
+package teststats
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxTasksPerResultsRequest is the maximum number of task executions whose
+// test results are fetched from the test results service at once.
+const maxTasksPerResultsRequest = 100
+
+type GenerateStatsOptions struct {
+	ProjectID string
+	Requester string
+	Tasks     []string
+	Date      time.Time
+}
+
+// GenerateStats aggregates the test results of the given tasks into test
+// stats documents for the given project, requester, and day. The day covered
+// is the UTC day corresponding to the given day parameter. Every execution of
+// the tasks is considered so that tests that fail and then pass on a
+// restarted execution are counted as flaky.
+func GenerateStats(ctx context.Context, env evergreen.Environment, opts GenerateStatsOptions) error {
+	grip.Info(message.Fields{
+		"message":   "generating daily test stats",
+		"project":   opts.ProjectID,
+		"requester": opts.Requester,
+		"day":       opts.Date,
+		"tasks":     opts.Tasks,
+	})
+	start := utility.GetUTCDay(opts.Date)
+	end := start.Add(24 * time.Hour)
+
+	tasks, err := findTaskExecutions(opts.ProjectID, opts.Requester, start, end, opts.Tasks)
+	if err != nil {
+		return errors.Wrap(err, "finding task executions")
+	}
+	runs, err := getTestRuns(ctx, env, tasks)
+	if err != nil {
+		return errors.Wrap(err, "getting test results")
+	}
+
+	stats := computeStats(runs)
+	docs := make([]interface{}, 0, len(stats))
+	lastUpdate := time.Now()
+	for _, s := range stats {
+		s.Id.Project = opts.ProjectID
+		s.Id.Requester = opts.Requester
+		s.Id.Date = start
+		s.LastUpdate = lastUpdate
+		docs = append(docs, s)
+	}
+
+	// Regenerating a day's stats replaces them entirely so that tests which
+	// no longer run are not left behind.
+	if err = db.RemoveAll(DailyTestStatsCollection, statsForDayQuery(opts.ProjectID, opts.Requester, start, opts.Tasks)); err != nil {
+		return errors.Wrap(err, "removing existing daily test stats")
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(db.InsertMany(DailyTestStatsCollection, docs...), "inserting daily test stats")
+}
+
+// findTaskExecutions returns every finished execution of the tasks created
+// in the given time range, including archived executions.
+func findTaskExecutions(projectID, requester string, start, end time.Time, tasks []string) ([]task.Task, error) {
+	query := func() bson.M {
+		return bson.M{
+			task.ProjectKey:     projectID,
+			task.RequesterKey:   requester,
+			task.CreateTimeKey:  bson.M{"$gte": start, "$lt": end},
+			task.DisplayNameKey: bson.M{"$in": tasks},
+			task.StatusKey:      bson.M{"$in": evergreen.TaskCompletedStatuses},
+			"$or":               []bson.M{{task.ResultsServiceKey: bson.M{"$exists": true}}, {task.HasCedarResultsKey: true}},
+		}
+	}
+	fields := []string{
+		task.IdKey,
+		task.ExecutionKey,
+		task.DisplayNameKey,
+		task.BuildVariantKey,
+		task.RevisionKey,
+		task.ResultsServiceKey,
+		task.HasCedarResultsKey,
+		task.OldTaskIdKey,
+		task.ArchivedKey,
+	}
+
+	current, err := task.FindWithFields(query(), fields...)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding current task executions")
+	}
+	old, err := task.FindOldWithFields(query(), fields...)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding archived task executions")
+	}
+
+	return append(current, old...), nil
+}
+
+// testRun is a single attempt of a test in a task execution.
+type testRun struct {
+	TestName     string
+	TaskName     string
+	BuildVariant string
+	Revision     string
+	TaskID       string
+	Execution    int
+	Attempt      int
+	Status       string
+}
+
+type taskExecution struct {
+	taskID    string
+	execution int
+}
+
+// getTestRuns fetches the test results of the task executions.
+func getTestRuns(ctx context.Context, env evergreen.Environment, tasks []task.Task) ([]testRun, error) {
+	executions := map[taskExecution]task.Task{}
+	servicesToOpts := map[string][]testresult.TaskOptions{}
+	for _, t := range tasks {
+		taskOpts, err := t.CreateTestResultsTaskOptions()
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating test results task options for task '%s'", t.Id)
+		}
+		for _, opts := range taskOpts {
+			executions[taskExecution{taskID: opts.TaskID, execution: opts.Execution}] = t
+			servicesToOpts[opts.ResultsService] = append(servicesToOpts[opts.ResultsService], opts)
+		}
+	}
+
+	var runs []testRun
+	for _, allOpts := range servicesToOpts {
+		for start := 0; start < len(allOpts); start += maxTasksPerResultsRequest {
+			end := start + maxTasksPerResultsRequest
+			if end > len(allOpts) {
+				end = len(allOpts)
+			}
+
+			results, err := testresult.GetMergedTaskTestResults(ctx, env, allOpts[start:end], nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range results.Results {
+				t, ok := executions[taskExecution{taskID: result.TaskID, execution: result.Execution}]
+				if !ok {
+					continue
+				}
+				runs = append(runs, testRun{
+					TestName:     result.GetDisplayTestName(),
+					TaskName:     t.DisplayName,
+					BuildVariant: t.BuildVariant,
+					Revision:     t.Revision,
+					TaskID:       result.TaskID,
+					Execution:    result.Execution,
+					Attempt:      result.Attempt,
+					Status:       result.Status,
+				})
+			}
+		}
+	}
+
+	return runs, nil
+}
+
+type testKey struct {
+	testName     string
+	taskName     string
+	buildVariant string
+}
+
+type testExecution struct {
+	taskExecution
+	testKey
+}
+
+type testRevision struct {
+	revision string
+	testKey
+}
+
+// computeStats aggregates the test runs into per-test stats. Each task
+// execution counts as a single pass or failure based on the test's final
+// attempt in that execution. A commit is flaky for a test if the test both
+// failed and passed on that commit, whether in retried attempts within one
+// execution, across restarted executions, or across separate tasks.
+func computeStats(runs []testRun) []DBTestStats {
+	finalAttempts := map[testExecution]testRun{}
+	passedOnRevision := map[testRevision]bool{}
+	failedOnRevision := map[testRevision]bool{}
+	for _, run := range runs {
+		key := testKey{testName: run.TestName, taskName: run.TaskName, buildVariant: run.BuildVariant}
+
+		execKey := testExecution{taskExecution: taskExecution{taskID: run.TaskID, execution: run.Execution}, testKey: key}
+		if final, ok := finalAttempts[execKey]; !ok || run.Attempt >= final.Attempt {
+			finalAttempts[execKey] = run
+		}
+
+		revisionKey := testRevision{revision: run.Revision, testKey: key}
+		switch run.Status {
+		case evergreen.TestSucceededStatus:
+			passedOnRevision[revisionKey] = true
+		case evergreen.TestFailedStatus:
+			failedOnRevision[revisionKey] = true
+		}
+	}
+
+	stats := map[testKey]*DBTestStats{}
+	getStats := func(key testKey) *DBTestStats {
+		s, ok := stats[key]
+		if !ok {
+			s = &DBTestStats{Id: DBTestStatsID{
+				TestName:     key.testName,
+				TaskName:     key.taskName,
+				BuildVariant: key.buildVariant,
+			}}
+			stats[key] = s
+		}
+		return s
+	}
+	for key, final := range finalAttempts {
+		s := getStats(key.testKey)
+		switch final.Status {
+		case evergreen.TestSucceededStatus:
+			s.NumPass++
+		case evergreen.TestFailedStatus:
+			s.NumFail++
+		}
+	}
+	revisions := map[testRevision]bool{}
+	for key, final := range finalAttempts {
+		revisions[testRevision{revision: final.Revision, testKey: key.testKey}] = true
+	}
+	for key := range revisions {
+		s := getStats(key.testKey)
+		s.NumCommits++
+		if passedOnRevision[key] && failedOnRevision[key] {
+			s.NumFlaky++
+		}
+	}
+
+	result := make([]DBTestStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Id.TaskName != result[j].Id.TaskName {
+			return result[i].Id.TaskName < result[j].Id.TaskName
+		}
+		if result[i].Id.BuildVariant != result[j].Id.BuildVariant {
+			return result[i].Id.BuildVariant < result[j].Id.BuildVariant
+		}
+		return result[i].Id.TestName < result[j].Id.TestName
+	})
+
+	return result
+}
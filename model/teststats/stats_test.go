@@ -0,0 +1,190 @@
+package teststats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestComputeStats(t *testing.T) {
+	run := func(testName, revision, taskID string, execution, attempt int, status string) testRun {
+		return testRun{
+			TestName:     testName,
+			TaskName:     "task",
+			BuildVariant: "bv",
+			Revision:     revision,
+			TaskID:       taskID,
+			Execution:    execution,
+			Attempt:      attempt,
+			Status:       status,
+		}
+	}
+
+	t.Run("NoRuns", func(t *testing.T) {
+		assert.Empty(t, computeStats(nil))
+	})
+	t.Run("ConsistentResultsAreNotFlaky", func(t *testing.T) {
+		stats := computeStats([]testRun{
+			run("pass", "r1", "t1", 0, 0, evergreen.TestSucceededStatus),
+			run("pass", "r2", "t2", 0, 0, evergreen.TestSucceededStatus),
+			run("fail", "r1", "t1", 0, 0, evergreen.TestFailedStatus),
+			run("fail", "r2", "t2", 0, 0, evergreen.TestFailedStatus),
+		})
+		require.Len(t, stats, 2)
+		assert.Equal(t, "fail", stats[0].Id.TestName)
+		assert.Equal(t, 0, stats[0].NumPass)
+		assert.Equal(t, 2, stats[0].NumFail)
+		assert.Equal(t, 2, stats[0].NumCommits)
+		assert.Zero(t, stats[0].NumFlaky)
+		assert.Equal(t, "pass", stats[1].Id.TestName)
+		assert.Equal(t, 2, stats[1].NumPass)
+		assert.Equal(t, 0, stats[1].NumFail)
+		assert.Equal(t, 2, stats[1].NumCommits)
+		assert.Zero(t, stats[1].NumFlaky)
+	})
+	t.Run("RetriedAttemptsAreFlaky", func(t *testing.T) {
+		stats := computeStats([]testRun{
+			run("test", "r1", "t1", 0, 1, evergreen.TestSucceededStatus),
+			run("test", "r1", "t1", 0, 0, evergreen.TestFailedStatus),
+		})
+		require.Len(t, stats, 1)
+		assert.Equal(t, 1, stats[0].NumPass)
+		assert.Equal(t, 0, stats[0].NumFail)
+		assert.Equal(t, 1, stats[0].NumCommits)
+		assert.Equal(t, 1, stats[0].NumFlaky)
+	})
+	t.Run("RestartedExecutionsAreFlaky", func(t *testing.T) {
+		stats := computeStats([]testRun{
+			run("test", "r1", "t1", 0, 0, evergreen.TestFailedStatus),
+			run("test", "r1", "t1", 1, 0, evergreen.TestSucceededStatus),
+			run("test", "r2", "t2", 0, 0, evergreen.TestSucceededStatus),
+		})
+		require.Len(t, stats, 1)
+		assert.Equal(t, 2, stats[0].NumPass)
+		assert.Equal(t, 1, stats[0].NumFail)
+		assert.Equal(t, 2, stats[0].NumCommits)
+		assert.Equal(t, 1, stats[0].NumFlaky)
+	})
+	t.Run("SkippedTestsAreNotCounted", func(t *testing.T) {
+		stats := computeStats([]testRun{
+			run("test", "r1", "t1", 0, 0, evergreen.TestSkippedStatus),
+			run("test", "r1", "t1", 1, 0, evergreen.TestSucceededStatus),
+		})
+		require.Len(t, stats, 1)
+		assert.Equal(t, 1, stats[0].NumPass)
+		assert.Equal(t, 0, stats[0].NumFail)
+		assert.Equal(t, 1, stats[0].NumCommits)
+		assert.Zero(t, stats[0].NumFlaky)
+	})
+	t.Run("StatsAreSeparatedByVariant", func(t *testing.T) {
+		otherVariant := run("test", "r1", "t2", 0, 0, evergreen.TestSucceededStatus)
+		otherVariant.BuildVariant = "other"
+		stats := computeStats([]testRun{
+			run("test", "r1", "t1", 0, 0, evergreen.TestFailedStatus),
+			otherVariant,
+		})
+		require.Len(t, stats, 2)
+		for _, s := range stats {
+			assert.Equal(t, 1, s.NumCommits)
+			assert.Zero(t, s.NumFlaky)
+		}
+	})
+}
+
+func TestGenerateStats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	require.NoError(t, db.ClearCollections(task.Collection, task.OldCollection, DailyTestStatsCollection))
+	require.NoError(t, testresult.ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, task.OldCollection, DailyTestStatsCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	day := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	makeTask := func(id, revision string) task.Task {
+		return task.Task{
+			Id:             id,
+			DisplayName:    "test-agent",
+			BuildVariant:   "ubuntu",
+			Project:        "project",
+			Requester:      evergreen.RepotrackerVersionRequester,
+			Revision:       revision,
+			CreateTime:     day.Add(time.Hour),
+			Status:         evergreen.TaskSucceeded,
+			ResultsService: testresult.TestResultsServiceLocal,
+		}
+	}
+
+	// t1 failed TestFlaky on its first execution and passed when
+	// restarted, while t2 ran on a different commit and passed.
+	t1 := makeTask("t1", "r1")
+	t1.Status = evergreen.TaskFailed
+	require.NoError(t, t1.Insert())
+	require.NoError(t, t1.Archive())
+	require.NoError(t, task.UpdateOne(task.ById(t1.Id), bson.M{"$set": bson.M{
+		task.ExecutionKey: 1,
+		task.StatusKey:    evergreen.TaskSucceeded,
+	}}))
+	t2 := makeTask("t2", "r2")
+	require.NoError(t, t2.Insert())
+	// Tasks that are still running are not included.
+	t3 := makeTask("t3", "r3")
+	t3.Status = evergreen.TaskStarted
+	require.NoError(t, t3.Insert())
+
+	require.NoError(t, testresult.InsertLocal(ctx, env,
+		testresult.TestResult{TaskID: "t1", Execution: 0, TestName: "TestFlaky", Status: evergreen.TestFailedStatus},
+		testresult.TestResult{TaskID: "t1", Execution: 0, TestName: "TestStable", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: "t1", Execution: 1, TestName: "TestFlaky", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: "t1", Execution: 1, TestName: "TestStable", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: "t2", Execution: 0, TestName: "TestFlaky", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: "t2", Execution: 0, TestName: "TestStable", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: "t3", Execution: 0, TestName: "TestStable", Status: evergreen.TestFailedStatus},
+	))
+
+	opts := GenerateStatsOptions{
+		ProjectID: "project",
+		Requester: evergreen.RepotrackerVersionRequester,
+		Tasks:     []string{"test-agent"},
+		Date:      day.Add(12 * time.Hour),
+	}
+	require.NoError(t, GenerateStats(ctx, env, opts))
+
+	var stats []DBTestStats
+	require.NoError(t, db.FindAllQ(DailyTestStatsCollection, db.Query(nil).Sort([]string{DBTestStatsIDTestNameKeyFull}), &stats))
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "TestFlaky", stats[0].Id.TestName)
+	assert.Equal(t, "test-agent", stats[0].Id.TaskName)
+	assert.Equal(t, "ubuntu", stats[0].Id.BuildVariant)
+	assert.Equal(t, "project", stats[0].Id.Project)
+	assert.Equal(t, day, stats[0].Id.Date.UTC())
+	assert.Equal(t, 2, stats[0].NumPass)
+	assert.Equal(t, 1, stats[0].NumFail)
+	assert.Equal(t, 2, stats[0].NumCommits)
+	assert.Equal(t, 1, stats[0].NumFlaky)
+
+	assert.Equal(t, "TestStable", stats[1].Id.TestName)
+	assert.Equal(t, 3, stats[1].NumPass)
+	assert.Equal(t, 0, stats[1].NumFail)
+	assert.Equal(t, 2, stats[1].NumCommits)
+	assert.Zero(t, stats[1].NumFlaky)
+
+	// Regenerating the stats replaces the existing ones.
+	require.NoError(t, GenerateStats(ctx, env, opts))
+	count, err := db.Count(DailyTestStatsCollection, bson.M{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -39,6 +39,8 @@ func Admin() cli.Command {
 			updateServiceUser(),
 			getServiceUsers(),
 			deleteServiceUser(),
+			adminScheduler(),
+			adminProjectVars(),
 		},
 	}
 }
@@ -521,6 +523,85 @@ func adminDistroExecute() cli.Command {
 	}
 }
 
+func adminProjectVars() cli.Command {
+	return cli.Command{
+		Name:  "project-vars",
+		Usage: "manage encryption of project variables",
+		Subcommands: []cli.Command{
+			adminEncryptProjectVars(),
+			adminRotateProjectVarsKey(),
+		},
+	}
+}
+
+func adminEncryptProjectVars() cli.Command {
+	const reencryptFlagName = "reencrypt"
+
+	return cli.Command{
+		Name:  "encrypt",
+		Usage: "encrypt all private and admin-only project variables that are stored in plaintext",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  reencryptFlagName,
+				Usage: "generate a new data key and re-encrypt all private and admin-only project variables with it",
+			},
+		},
+		Before: setPlainLogger,
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			updated, err := client.EncryptProjectVars(ctx, c.Bool(reencryptFlagName))
+			if err != nil {
+				return err
+			}
+			grip.Infof("Encrypted variables for %d project(s).", updated)
+
+			return nil
+		},
+	}
+}
+
+func adminRotateProjectVarsKey() cli.Command {
+	return cli.Command{
+		Name:   "rotate-key",
+		Usage:  "rewrap the data keys that encrypt project variables with the current key encryption key",
+		Before: setPlainLogger,
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			rotated, err := client.RotateProjectVarsKey(ctx)
+			if err != nil {
+				return err
+			}
+			grip.Infof("Rewrapped %d data key(s) with the current key encryption key.", rotated)
+
+			return nil
+		},
+	}
+}
+
 func getServiceUsers() cli.Command {
 	return cli.Command{
 		Name:   "get-service-users",
@@ -0,0 +1,155 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cheynewallace/tabby"
+	restmodel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func adminScheduler() cli.Command {
+	return cli.Command{
+		Name:  "scheduler",
+		Usage: "tune the scheduler",
+		Subcommands: []cli.Command{
+			adminSchedulerSimulate(),
+		},
+	}
+}
+
+func adminSchedulerSimulate() cli.Command {
+	const (
+		distroFlagName                    = "distro"
+		numHostsFlagName                  = "hosts"
+		groupVersionsFlagName             = "group_versions"
+		patchFactorFlagName               = "patch_factor"
+		patchTimeInQueueFactorFlagName    = "patch_time_in_queue_factor"
+		commitQueueFactorFlagName         = "commit_queue_factor"
+		mainlineTimeInQueueFactorFlagName = "mainline_time_in_queue_factor"
+		expectedRuntimeFactorFlagName     = "expected_runtime_factor"
+		generateTaskFactorFlagName        = "generate_task_factor"
+		stepbackTaskFactorFlagName        = "stepback_task_factor"
+	)
+	factorFlag := func(name, usage string) cli.Flag {
+		return cli.Int64Flag{
+			Name:  name,
+			Usage: fmt.Sprintf("the candidate %s (defaults to the distro's current value)", usage),
+		}
+	}
+
+	return cli.Command{
+		Name:  "simulate",
+		Usage: "estimate how long a snapshot of a distro's queued tasks would wait to start under candidate planner settings, without modifying the task queue",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(distroFlagName, "d"),
+				Usage: "the distro whose queued tasks to simulate",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(startTimeFlagName, "t"),
+				Usage: "the time to take the snapshot of the queued tasks at (RFC 3339 format, defaults to now)",
+			},
+			cli.IntFlag{
+				Name:  numHostsFlagName,
+				Usage: "the number of hosts available to run the queued tasks (defaults to the number of hosts running tasks at the snapshot time)",
+			},
+			cli.StringFlag{
+				Name:  groupVersionsFlagName,
+				Usage: "whether to plan the tasks in a version together, either 'true' or 'false' (defaults to the distro's current value)",
+			},
+			factorFlag(patchFactorFlagName, "patch factor"),
+			factorFlag(patchTimeInQueueFactorFlagName, "patch time in queue factor"),
+			factorFlag(commitQueueFactorFlagName, "commit queue factor"),
+			factorFlag(mainlineTimeInQueueFactorFlagName, "mainline time in queue factor"),
+			factorFlag(expectedRuntimeFactorFlagName, "expected runtime factor"),
+			factorFlag(generateTaskFactorFlagName, "generate task factor"),
+			factorFlag(stepbackTaskFactorFlagName, "stepback task factor"),
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(distroFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			// Only factors that are set are sent, so that a factor can be
+			// simulated as zero.
+			factor := func(name string) *int64 {
+				if !c.IsSet(name) {
+					return nil
+				}
+				return utility.ToInt64Ptr(c.Int64(name))
+			}
+			req := restmodel.APISchedulerSimulationRequest{
+				Distro:   utility.ToStringPtr(c.String(distroFlagName)),
+				NumHosts: c.Int(numHostsFlagName),
+				PlannerSettings: restmodel.APICandidatePlannerSettings{
+					PatchFactor:               factor(patchFactorFlagName),
+					PatchTimeInQueueFactor:    factor(patchTimeInQueueFactorFlagName),
+					CommitQueueFactor:         factor(commitQueueFactorFlagName),
+					MainlineTimeInQueueFactor: factor(mainlineTimeInQueueFactorFlagName),
+					ExpectedRuntimeFactor:     factor(expectedRuntimeFactorFlagName),
+					GenerateTaskFactor:        factor(generateTaskFactorFlagName),
+					StepbackTaskFactor:        factor(stepbackTaskFactorFlagName),
+				},
+			}
+			if timeString := c.String(startTimeFlagName); timeString != "" {
+				ts, err := time.Parse(time.RFC3339, timeString)
+				if err != nil {
+					return errors.Wrap(err, "parsing snapshot time")
+				}
+				req.SnapshotTime = &ts
+			}
+			if groupVersions := c.String(groupVersionsFlagName); groupVersions != "" {
+				val, err := strconv.ParseBool(groupVersions)
+				if err != nil {
+					return errors.Wrapf(err, "parsing '%s'", groupVersionsFlagName)
+				}
+				req.PlannerSettings.GroupVersions = &val
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			result, err := client.SimulateScheduler(ctx, req)
+			if err != nil {
+				return errors.Wrap(err, "simulating scheduler")
+			}
+
+			fmt.Printf("Simulated %d queued tasks on %d hosts in distro '%s' as of %s.\n",
+				result.NumTasks, result.NumHosts, utility.FromStringPtr(result.Distro), utility.FromTimePtr(result.SnapshotTime).Format(time.RFC3339))
+			printSimulatedWaitTimes(result.Current, result.Candidate)
+			return nil
+		},
+	}
+}
+
+func printSimulatedWaitTimes(current, candidate []restmodel.APIRequesterWaitTimes) {
+	candidateByRequester := map[string]restmodel.APIRequesterWaitTimes{}
+	for _, w := range candidate {
+		candidateByRequester[utility.FromStringPtr(w.Requester)] = w
+	}
+
+	t := tabby.New()
+	t.AddHeader("Requester", "Tasks", "Current Avg Wait", "Candidate Avg Wait", "Current Max Wait", "Candidate Max Wait")
+	for _, cur := range current {
+		requester := utility.FromStringPtr(cur.Requester)
+		cand := candidateByRequester[requester]
+		t.AddLine(requester, cur.NumTasks,
+			cur.AverageWait.ToDuration().Round(time.Second), cand.AverageWait.ToDuration().Round(time.Second),
+			cur.MaxWait.ToDuration().Round(time.Second), cand.MaxWait.ToDuration().Round(time.Second))
+	}
+	t.Print()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/evergreen-ci/evergreen"
@@ -473,8 +474,16 @@ func listCommitQueue(ctx context.Context, client client.Communicator, ac *legacy
 	}
 
 	grip.Infof("Queue Length: %d\n", len(cq.Queue))
+	hasLanes := len(projectRef.CommitQueue.Lanes) > 0
+	if hasLanes {
+		listCommitQueueLanes(cq.Queue, projectRef.CommitQueue.Lanes)
+	}
 	for i, item := range cq.Queue {
-		grip.Infof("%d:", i)
+		if hasLanes {
+			grip.Infof("%d: (lane: %s)", i, commitQueueLaneDisplayName(utility.FromStringPtr(item.Lane)))
+		} else {
+			grip.Infof("%d:", i)
+		}
 		if utility.FromStringPtr(item.Source) == commitqueue.SourcePullRequest {
 			listPRCommitQueueItem(item, projectRef, uiServerHost)
 		} else if utility.FromStringPtr(item.Source) == commitqueue.SourceDiff {
@@ -486,6 +495,44 @@ func listCommitQueue(ctx context.Context, client client.Communicator, ac *legacy
 	return nil
 }
 
+// sharedLaneDisplayName is the name shown for the lane of items whose changes
+// span multiple lanes.
+const sharedLaneDisplayName = "shared"
+
+func commitQueueLaneDisplayName(lane string) string {
+	if lane == commitqueue.SharedLane {
+		return sharedLaneDisplayName
+	}
+	return lane
+}
+
+// listCommitQueueLanes lists the number of items in each of the project's
+// lanes, followed by the shared lane.
+func listCommitQueueLanes(queue []restModel.APICommitQueueItem, lanes []model.CommitQueueLane) {
+	depths := map[string]int{}
+	for _, item := range queue {
+		depths[utility.FromStringPtr(item.Lane)]++
+	}
+
+	grip.Info("Lanes:")
+	for _, lane := range lanes {
+		grip.Infof("\t%s: %d", lane.Name, depths[lane.Name])
+		delete(depths, lane.Name)
+	}
+	grip.Infof("\t%s: %d", sharedLaneDisplayName, depths[commitqueue.SharedLane])
+	delete(depths, commitqueue.SharedLane)
+	// Items can be in lanes that have since been removed from the project.
+	removedLanes := make([]string, 0, len(depths))
+	for name := range depths {
+		removedLanes = append(removedLanes, name)
+	}
+	sort.Strings(removedLanes)
+	for _, name := range removedLanes {
+		grip.Infof("\t%s: %d", name, depths[name])
+	}
+	grip.Info("\n")
+}
+
 func listPRCommitQueueItem(item restModel.APICommitQueueItem, projectRef *model.ProjectRef, uiServerHost string) {
 	issue := utility.FromStringPtr(item.Issue)
 	prDisplay := `
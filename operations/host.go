@@ -22,6 +22,7 @@ func Host() cli.Command {
 			hostRunCommand(),
 			hostRsync(),
 			hostFindBy(),
+			hostSnapshot(),
 		},
 	}
 }
@@ -0,0 +1,227 @@
+package operations
+
+import (
+	"context"
+	"time"
+
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func hostSnapshot() cli.Command {
+	return cli.Command{
+		Name:  "snapshot",
+		Usage: "save and restore the state of spawn hosts",
+		Subcommands: []cli.Command{
+			hostSnapshotCreate(),
+			hostSnapshotList(),
+			hostSnapshotRestore(),
+			hostSnapshotDelete(),
+		},
+	}
+}
+
+func hostSnapshotCreate() cli.Command {
+	return cli.Command{
+		Name:  "create",
+		Usage: "snapshot a running or stopped spawn host",
+		Flags: addHostFlag(
+			cli.StringFlag{
+				Name:  joinFlagNames(displayNameFlagName, "n"),
+				Usage: "name of the snapshot",
+			},
+		),
+		Before: mergeBeforeFuncs(setPlainLogger, requireHostFlag),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			hostID := c.String(hostFlagName)
+			displayName := c.String(displayNameFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			snapshot, err := client.CreateSpawnHostSnapshot(ctx, hostID, displayName)
+			if err != nil {
+				return errors.Wrapf(err, "creating snapshot of host '%s'", hostID)
+			}
+
+			grip.Infof("Snapshot '%s' of host '%s' is being created. Check `evergreen host snapshot list` to see when it's available.", utility.FromStringPtr(snapshot.ID), hostID)
+			return nil
+		},
+	}
+}
+
+func hostSnapshotList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list snapshots for user",
+		Before: setPlainLogger,
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			snapshots, err := client.GetSpawnHostSnapshots(ctx)
+			if err != nil {
+				return err
+			}
+			printSnapshots(snapshots, conf.User)
+			return nil
+		},
+	}
+}
+
+func printSnapshots(snapshots []restModel.APISpawnHostSnapshot, userID string) {
+	if len(snapshots) == 0 {
+		grip.Infof("no snapshots created by user '%s'", userID)
+		return
+	}
+	grip.Infof("%d snapshots created by %s:", len(snapshots), userID)
+	for _, s := range snapshots {
+		grip.Infof("\n%-18s: %s\n", "ID", utility.FromStringPtr(s.ID))
+		if utility.FromStringPtr(s.DisplayName) != "" {
+			grip.Infof("%-18s: %s\n", "Name", utility.FromStringPtr(s.DisplayName))
+		}
+		grip.Infof("%-18s: %s\n", "Status", utility.FromStringPtr(s.Status))
+		grip.Infof("%-18s: %s\n", "Distro", utility.FromStringPtr(s.DistroID))
+		grip.Infof("%-18s: %s\n", "Source Host", utility.FromStringPtr(s.SourceHostID))
+		if s.HomeVolumeSize > 0 {
+			grip.Infof("%-18s: %d\n", "Home Volume Size", s.HomeVolumeSize)
+		}
+		t, err := restModel.FromTimePtr(s.Expiration)
+		if err == nil && !utility.IsZeroTime(t) {
+			grip.Infof("%-18s: %s\n", "Expiration", t.Format(time.RFC3339))
+		}
+	}
+}
+
+func hostSnapshotRestore() cli.Command {
+	const (
+		idFlagName           = "id"
+		keyFlagName          = "key"
+		instanceTypeFlagName = "type"
+		noExpireFlagName     = "no-expire"
+		homeVolumeSizeFlag   = "volume-size"
+	)
+
+	return cli.Command{
+		Name:  "restore",
+		Usage: "spawn a new host from a snapshot",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  idFlagName,
+				Usage: "`ID` of the snapshot to restore",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(keyFlagName, "k"),
+				Usage: "provide either the value of a public key to use, or the Evergreen-managed name of a key",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(instanceTypeFlagName, "i"),
+				Usage: "name of an instance type (defaults to the instance type of the snapshotted host)",
+			},
+			cli.IntFlag{
+				Name:  homeVolumeSizeFlag,
+				Usage: "size in GiB of the restored home volume, if it's larger than the snapshotted volume",
+			},
+			cli.BoolFlag{
+				Name:  noExpireFlagName,
+				Usage: "make host never expire",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(idFlagName), requireStringFlag(keyFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			snapshotID := c.String(idFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			spawnRequest := &restModel.HostRequestOptions{
+				KeyName:        c.String(keyFlagName),
+				InstanceType:   c.String(instanceTypeFlagName),
+				HomeVolumeSize: c.Int(homeVolumeSizeFlag),
+				NoExpiration:   c.Bool(noExpireFlagName),
+			}
+			host, err := client.RestoreSpawnHostSnapshot(ctx, snapshotID, spawnRequest)
+			if err != nil {
+				return errors.Wrapf(err, "restoring snapshot '%s'", snapshotID)
+			}
+
+			grip.Infof("Spawn host created from snapshot '%s' with ID '%s'. Visit the hosts page in Evergreen to check on its status, or check `evergreen host list --mine`", snapshotID, utility.FromStringPtr(host.Id))
+			return nil
+		},
+	}
+}
+
+func hostSnapshotDelete() cli.Command {
+	const idFlagName = "id"
+
+	return cli.Command{
+		Name:  "delete",
+		Usage: "delete a snapshot",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  idFlagName,
+				Usage: "`ID` of the snapshot to delete",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(idFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			snapshotID := c.String(idFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			if err = client.DeleteSpawnHostSnapshot(ctx, snapshotID); err != nil {
+				return errors.Wrapf(err, "deleting snapshot '%s'", snapshotID)
+			}
+			grip.Infof("Deleting snapshot '%s'", snapshotID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const (
+	logsVersionFlagName       = "version"
+	logsBuildFlagName         = "build"
+	logsTaskFlagName          = "task"
+	logsPatternFlagName       = "pattern"
+	logsTypeFlagName          = "type"
+	logsAllExecutionsFlagName = "all_executions"
+	logsContextFlagName       = "context"
+
+	defaultLogsSearchLimit = 100
+	logsSearchPageSize     = 100
+)
+
+func Logs() cli.Command {
+	return cli.Command{
+		Name:   "logs",
+		Usage:  "search task logs",
+		Before: setPlainLogger,
+		Subcommands: []cli.Command{
+			logsSearch(),
+		},
+	}
+}
+
+func logsSearch() cli.Command {
+	return cli.Command{
+		Name:  "search",
+		Usage: "find the task log lines that match a regular expression in a version, build or task",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  logsVersionFlagName,
+				Usage: "search the logs of the tasks in this version",
+			},
+			cli.StringFlag{
+				Name:  logsBuildFlagName,
+				Usage: "search the logs of the tasks in this build",
+			},
+			cli.StringFlag{
+				Name:  logsTaskFlagName,
+				Usage: "search the logs of this task",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(logsPatternFlagName, "p"),
+				Usage: "regular expression matching the log lines",
+			},
+			cli.StringFlag{
+				Name:  logsTypeFlagName,
+				Usage: "type of log to search: 'all', 'task', 'agent' or 'system'",
+				Value: "all",
+			},
+			cli.BoolFlag{
+				Name:  logsAllExecutionsFlagName,
+				Usage: "search every execution of each task, rather than only the latest",
+			},
+			cli.IntFlag{
+				Name:  joinFlagNames(logsContextFlagName, "C"),
+				Usage: "number of lines of context to print around each match",
+				Value: 2,
+			},
+			cli.IntFlag{
+				Name:  joinFlagNames(limitFlagName, "l"),
+				Usage: "stop after this number of matches",
+				Value: defaultLogsSearchLimit,
+			},
+			cli.BoolFlag{
+				Name:  jsonFlagName,
+				Usage: "print each match as a line of JSON",
+			},
+		},
+		Before: mergeBeforeFuncs(
+			mutuallyExclusiveArgs(true, logsVersionFlagName, logsBuildFlagName, logsTaskFlagName),
+			requireStringFlag(logsPatternFlagName),
+			requireIntValueBetween(logsContextFlagName, 0, 10),
+			requireIntValueBetween(limitFlagName, 1, 100000),
+		),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			limit := c.Int(limitFlagName)
+			params := model.APITaskLogSearchParams{
+				VersionID:     c.String(logsVersionFlagName),
+				BuildID:       c.String(logsBuildFlagName),
+				TaskID:        c.String(logsTaskFlagName),
+				Pattern:       c.String(logsPatternFlagName),
+				LogType:       c.String(logsTypeFlagName),
+				AllExecutions: c.Bool(logsAllExecutionsFlagName),
+				ContextLines:  utility.ToIntPtr(c.Int(logsContextFlagName)),
+			}
+			printJSON := c.Bool(jsonFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			found := 0
+			for {
+				params.Limit = logsSearchPageSize
+				if limit-found < params.Limit {
+					params.Limit = limit - found
+				}
+				var matches []model.APITaskLogSearchMatch
+				matches, params.StartAt, err = client.SearchTaskLogs(ctx, params)
+				if err != nil {
+					return errors.Wrap(err, "searching task logs")
+				}
+				for _, match := range matches {
+					if err = printLogSearchMatch(os.Stdout, match, printJSON, found > 0); err != nil {
+						return errors.Wrap(err, "printing log search match")
+					}
+					found++
+				}
+				if params.StartAt == "" || found >= limit {
+					break
+				}
+			}
+
+			if found == 0 {
+				grip.Info("no matching log lines found")
+			} else if params.StartAt != "" {
+				grip.Infof("stopped after %d matches; increase --%s to see more", found, limitFlagName)
+			}
+			return nil
+		},
+	}
+}
+
+// printLogSearchMatch prints a match with its context in the style of grep,
+// or as a single line of JSON.
+func printLogSearchMatch(w io.Writer, match model.APITaskLogSearchMatch, printJSON, separate bool) error {
+	if printJSON {
+		out, err := json.Marshal(match)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	}
+
+	if separate {
+		if _, err := fmt.Fprintln(w, "--"); err != nil {
+			return err
+		}
+	}
+	prefix := fmt.Sprintf("%s (%s) execution %d", utility.FromStringPtr(match.TaskID), utility.FromStringPtr(match.BuildVariant), match.Execution)
+	for _, line := range match.Before {
+		if err := printLogSearchLine(w, prefix, "-", line); err != nil {
+			return err
+		}
+	}
+	if err := printLogSearchLine(w, prefix, ":", match.Line); err != nil {
+		return err
+	}
+	for _, line := range match.After {
+		if err := printLogSearchLine(w, prefix, "-", line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printLogSearchLine(w io.Writer, prefix, separator string, line model.APILogLine) error {
+	ts := ""
+	if line.Timestamp != nil {
+		ts = line.Timestamp.Format(time.RFC3339) + " "
+	}
+	_, err := fmt.Fprintf(w, "%s%s%d%s %s%s\n", prefix, separator, line.LineNumber, separator, ts, utility.FromStringPtr(line.Data))
+	return err
+}
@@ -0,0 +1,154 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const (
+	quarantinePatternFlagName = "pattern"
+	quarantineReasonFlagName  = "reason"
+)
+
+func Quarantine() cli.Command {
+	return cli.Command{
+		Name:   "quarantine",
+		Usage:  "manage tests whose failures do not fail their task",
+		Before: setPlainLogger,
+		Subcommands: []cli.Command{
+			quarantineList(),
+			quarantineAdd(),
+			quarantineRemove(),
+		},
+	}
+}
+
+func addQuarantinePatternFlag(flags ...cli.Flag) []cli.Flag {
+	return append(flags, cli.StringFlag{
+		Name:  quarantinePatternFlagName,
+		Usage: "regular expression matching the names of the tests",
+	})
+}
+
+func quarantineList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list the quarantined tests in a project",
+		Flags:  addProjectFlag(),
+		Before: requireStringFlag(projectFlagName),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			quarantined, err := client.GetQuarantinedTests(ctx, project)
+			if err != nil {
+				return errors.Wrapf(err, "getting quarantined tests for project '%s'", project)
+			}
+			if len(quarantined) == 0 {
+				grip.Infof("no quarantined tests found for project '%s'", project)
+				return nil
+			}
+
+			for _, q := range quarantined {
+				fmt.Printf("%s\t%s\t%s\n", utility.FromStringPtr(q.Pattern), utility.FromStringPtr(q.Author), utility.FromStringPtr(q.Reason))
+			}
+			return nil
+		},
+	}
+}
+
+func quarantineAdd() cli.Command {
+	return cli.Command{
+		Name:  "add",
+		Usage: "quarantine the tests matching a pattern in a project",
+		Flags: addProjectFlag(addQuarantinePatternFlag(
+			cli.StringFlag{
+				Name:  quarantineReasonFlagName,
+				Usage: "why the tests are quarantined",
+			},
+		)...),
+		Before: mergeBeforeFuncs(
+			requireStringFlag(projectFlagName),
+			requireStringFlag(quarantinePatternFlagName),
+		),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+			pattern := c.String(quarantinePatternFlagName)
+			reason := c.String(quarantineReasonFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			if _, err = client.QuarantineTest(ctx, project, pattern, reason); err != nil {
+				return errors.Wrapf(err, "quarantining test pattern '%s' in project '%s'", pattern, project)
+			}
+			grip.Infof("quarantined tests matching '%s' in project '%s'", pattern, project)
+			return nil
+		},
+	}
+}
+
+func quarantineRemove() cli.Command {
+	return cli.Command{
+		Name:  "remove",
+		Usage: "stop quarantining the tests matching a pattern in a project",
+		Flags: addProjectFlag(addQuarantinePatternFlag()...),
+		Before: mergeBeforeFuncs(
+			requireStringFlag(projectFlagName),
+			requireStringFlag(quarantinePatternFlagName),
+		),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+			pattern := c.String(quarantinePatternFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			if err = client.UnquarantineTest(ctx, project, pattern); err != nil {
+				return errors.Wrapf(err, "removing quarantined test pattern '%s' from project '%s'", pattern, project)
+			}
+			grip.Infof("removed quarantined test pattern '%s' from project '%s'", pattern, project)
+			return nil
+		},
+	}
+}
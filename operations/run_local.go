@@ -0,0 +1,136 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func RunLocal() cli.Command {
+	const (
+		taskFlagName       = "task"
+		variantFlagName    = "variant"
+		outputFlagName     = "output"
+		expansionFlagName  = "expansion"
+		defaultProjectPath = ".evergreen.yml"
+		defaultOutputDir   = "evergreen-local"
+	)
+
+	return cli.Command{
+		Name:  "run-local",
+		Usage: "run a task from a project configuration file on the local machine",
+		Flags: addPathFlag(
+			cli.StringFlag{
+				Name:  joinFlagNames(taskFlagName, "t"),
+				Usage: "name of the task to run",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(variantFlagName, "v"),
+				Usage: "build variant to run the task in (only required if multiple build variants list the task)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(dirFlagName, "d"),
+				Usage: "working directory in which the task directory is created (defaults to the output directory)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(outputFlagName, "o"),
+				Usage: "directory to write the logs, test results and artifacts to",
+				Value: defaultOutputDir,
+			},
+			cli.StringSliceFlag{
+				Name:  joinFlagNames(expansionFlagName, "e"),
+				Usage: "specify an expansion as a KEY=VALUE pair, overriding the project's expansions (can be specified multiple times)",
+			},
+			cli.BoolFlag{
+				Name:  joinFlagNames(quietFlagName, "q"),
+				Usage: "do not print the task logs to standard output",
+			},
+		),
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(taskFlagName)),
+		Action: func(c *cli.Context) error {
+			projectPath := c.String(pathFlagName)
+			if projectPath == "" {
+				projectPath = defaultProjectPath
+			}
+			outputDir, err := filepath.Abs(c.String(outputFlagName))
+			if err != nil {
+				return errors.Wrap(err, "getting absolute path of output directory")
+			}
+			workDir := c.String(dirFlagName)
+			if workDir == "" {
+				workDir = outputDir
+			}
+			workDir, err = filepath.Abs(workDir)
+			if err != nil {
+				return errors.Wrap(err, "getting absolute path of working directory")
+			}
+			expansions, err := getKeyValuePairsFromInput(c.StringSlice(expansionFlagName))
+			if err != nil {
+				return errors.Wrap(err, "parsing expansions")
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				sigChan := make(chan os.Signal, 1)
+				signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+				select {
+				case <-sigChan:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			detail, err := agent.RunLocal(ctx, agent.LocalOptions{
+				ProjectPath:      projectPath,
+				TaskName:         c.String(taskFlagName),
+				BuildVariant:     c.String(variantFlagName),
+				WorkingDirectory: workDir,
+				OutputDirectory:  outputDir,
+				Expansions:       expansions,
+				LogToStdout:      !c.Bool(quietFlagName),
+			})
+			if err != nil {
+				return errors.Wrap(err, "running task locally")
+			}
+
+			fmt.Printf("Task finished with status '%s'.\n", detail.Status)
+			if detail.Status != evergreen.TaskSucceeded && detail.Description != "" {
+				fmt.Printf("Failing command: %s\n", detail.Description)
+			}
+			if detail.TimedOut {
+				fmt.Printf("Task timed out (%s).\n", detail.TimeoutType)
+			}
+			fmt.Printf("Logs, test results and artifacts are in '%s'.\n", outputDir)
+			fmt.Printf("The task directory is in '%s'.\n", workDir)
+
+			if detail.Status != evergreen.TaskSucceeded {
+				return errors.Errorf("task finished with status '%s'", detail.Status)
+			}
+			return nil
+		},
+	}
+}
+
+// getKeyValuePairsFromInput parses KEY=VALUE pairs into a map. Values may
+// contain '=' signs.
+func getKeyValuePairsFromInput(pairs []string) (map[string]string, error) {
+	kvs := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			return nil, errors.Errorf("expected a KEY=VALUE pair but got '%s'", pair)
+		}
+		kvs[key] = value
+	}
+	return kvs, nil
+}
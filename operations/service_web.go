@@ -11,6 +11,9 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/service"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/mongodb/amboy"
@@ -68,6 +71,7 @@ func startWebService() cli.Command {
 			})
 
 			grip.EmergencyFatal(errors.Wrap(startSystemCronJobs(ctx, env), "starting background work"))
+			grip.Error(errors.Wrap(ensureIndexes(ctx, env), "creating indexes"))
 
 			var (
 				apiServer *http.Server
@@ -249,3 +253,13 @@ func setupUserManager(env evergreen.Environment, settings *evergreen.Settings) e
 	env.SetUserManagerInfo(info)
 	return nil
 }
+
+// ensureIndexes creates the indexes that the application server relies on if
+// they don't already exist.
+func ensureIndexes(ctx context.Context, env evergreen.Environment) error {
+	catcher := grip.NewBasicCatcher()
+	catcher.Add(testresult.EnsureLocalIndexes(ctx, env))
+	catcher.Add(host.EnsurePoolIndexes(ctx, env))
+	catcher.Add(notification.EnsureWebhookDeliveryIndexes(ctx, env))
+	return catcher.Resolve()
+}
@@ -3,6 +3,8 @@ package operations
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +19,14 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	validateFormatFlagName = "format"
+
+	validateFormatText  = "text"
+	validateFormatJSON  = "json"
+	validateFormatSARIF = "sarif"
+)
+
 func Validate() cli.Command {
 	return cli.Command{
 		Name:  "validate",
@@ -33,14 +43,27 @@ func Validate() cli.Command {
 		}, cli.StringFlag{
 			Name:  joinFlagNames(projectFlagName, "p"),
 			Usage: "specify project identifier in order to run validation requiring project settings",
+		}, cli.StringFlag{
+			Name:  validateFormatFlagName,
+			Usage: fmt.Sprintf("the output format, either '%s', '%s' or '%s'", validateFormatText, validateFormatJSON, validateFormatSARIF),
+			Value: validateFormatText,
 		}),
-		Before: mergeBeforeFuncs(autoUpdateCLI, setPlainLogger, requirePathFlag),
+		Before: mergeBeforeFuncs(autoUpdateCLI, setPlainLogger, requirePathFlag,
+			func(c *cli.Context) error {
+				switch c.String(validateFormatFlagName) {
+				case validateFormatText, validateFormatJSON, validateFormatSARIF:
+					return nil
+				default:
+					return errors.Errorf("invalid format '%s'", c.String(validateFormatFlagName))
+				}
+			}),
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
 			path := c.String(pathFlagName)
 			quiet := c.Bool(quietFlagName)
 			long := c.Bool(longFlagName)
 			projectID := c.String(projectFlagName)
+			format := c.String(validateFormatFlagName)
 			localModulePaths := c.StringSlice(localModulesFlagName)
 			localModuleMap, err := getLocalModulesFromInput(localModulePaths)
 			if err != nil {
@@ -54,7 +77,9 @@ func Validate() cli.Command {
 				return errors.Wrap(err, "loading configuration")
 			}
 
-			client, err := conf.setupRestCommunicator(ctx, !quiet)
+			// Only print messages from the server for text output so that
+			// the JSON and SARIF output can be parsed.
+			client, err := conf.setupRestCommunicator(ctx, !quiet && format == validateFormatText)
 			if err != nil {
 				return errors.Wrap(err, "setting up REST communicator")
 			}
@@ -78,22 +103,91 @@ func Validate() cli.Command {
 				return errors.Wrapf(err, "getting file info for path '%s'", path)
 			}
 
+			paths := []string{path}
 			if fileInfo.Mode()&os.ModeDir != 0 { // directory
 				files, err := os.ReadDir(path)
 				if err != nil {
 					return errors.Wrapf(err, "reading directory '%s'", path)
 				}
-				catcher := grip.NewSimpleCatcher()
+				paths = nil
 				for _, file := range files {
-					catcher.Add(validateFile(filepath.Join(path, file.Name()), ac, quiet, long, localModuleMap, projectID))
+					paths = append(paths, filepath.Join(path, file.Name()))
+				}
+			}
+
+			catcher := grip.NewSimpleCatcher()
+			results := []validator.FileValidationErrors{}
+			for _, filePath := range paths {
+				result, err := validateFile(filePath, ac, quiet, long, localModuleMap, projectID)
+				if err != nil {
+					catcher.Add(err)
+					continue
 				}
-				return catcher.Resolve()
+				if format == validateFormatText {
+					printValidationErrors(result)
+				}
+				if result.Errors.HasError() {
+					catcher.Errorf("%s is an invalid configuration", filePath)
+				}
+				results = append(results, *result)
 			}
 
-			return validateFile(path, ac, quiet, long, localModuleMap, projectID)
+			switch format {
+			case validateFormatJSON:
+				catcher.Wrap(printJSON(makeValidationOutput(results)), "printing validation errors as JSON")
+			case validateFormatSARIF:
+				catcher.Wrap(printJSON(validator.MakeSARIFLog(results)), "printing validation errors as SARIF")
+			}
+
+			return catcher.Resolve()
 		},
 	}
 }
+
+// validationOutput is the JSON output format of a validation error.
+type validationOutput struct {
+	File    string `json:"file"`
+	Level   string `json:"level"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
+
+func makeValidationOutput(results []validator.FileValidationErrors) []validationOutput {
+	output := []validationOutput{}
+	for _, result := range results {
+		for _, err := range result.Errors {
+			output = append(output, validationOutput{
+				File:    result.Path,
+				Level:   strings.ToLower(err.Level.String()),
+				Rule:    err.Rule,
+				Message: err.Message,
+			})
+		}
+	}
+	return output
+}
+
+func printJSON(data interface{}) error {
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshalling JSON")
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func printValidationErrors(result *validator.FileValidationErrors) {
+	grip.Info(result.Errors)
+	if result.Errors.HasError() {
+		return
+	}
+	if len(result.Errors) > 0 {
+		grip.Infof("%s is valid with warnings", result.Path)
+	} else {
+		grip.Infof("%s is valid", result.Path)
+	}
+}
+
 func getLocalModulesFromInput(localModulePaths []string) (map[string]string, error) {
 	moduleMap := make(map[string]string)
 	catcher := grip.NewBasicCatcher()
@@ -108,10 +202,16 @@ func getLocalModulesFromInput(localModulePaths []string) (map[string]string, err
 	return moduleMap, catcher.Resolve()
 }
 
-func validateFile(path string, ac *legacyClient, quiet, includeLong bool, localModuleMap map[string]string, projectID string) error {
+// validateFile validates the project configuration file. The returned error
+// is only non-nil if the file could not be validated.
+func validateFile(path string, ac *legacyClient, quiet, includeLong bool, localModuleMap map[string]string, projectID string) (*validator.FileValidationErrors, error) {
 	confFile, err := os.ReadFile(path)
 	if err != nil {
-		return errors.Wrapf(err, "reading file '%s'", path)
+		return nil, errors.Wrapf(err, "reading file '%s'", path)
+	}
+	result := &validator.FileValidationErrors{
+		Path:     path,
+		Contents: confFile,
 	}
 	project := &model.Project{}
 	ctx := context.Background()
@@ -123,39 +223,32 @@ func validateFile(path string, ac *legacyClient, quiet, includeLong bool, localM
 		opts.UnmarshalStrict = true
 	}
 	pp, pc, validationErrs := loadProjectIntoWithValidation(ctx, confFile, opts, project)
-	grip.Info(validationErrs)
 	if validationErrs.HasError() {
-		return errors.Errorf("%s is an invalid configuration", path)
+		result.Errors = validationErrs
+		return result, nil
 	}
 
 	projectYaml, err := yaml.Marshal(pp)
 	if err != nil {
-		return errors.Wrapf(err, "marshalling parser project into YAML")
+		return nil, errors.Wrapf(err, "marshalling parser project into YAML")
 	}
 
 	if pc != nil {
 		projectConfigYaml, err := yaml.Marshal(pc.ProjectConfigFields)
 		if err != nil {
-			return errors.Wrapf(err, "marshalling project config into YAML")
+			return nil, errors.Wrapf(err, "marshalling project config into YAML")
 		}
 		projectBytes := [][]byte{projectYaml, projectConfigYaml}
 		projectYaml = bytes.Join(projectBytes, []byte("\n"))
 	}
 	projErrors, err := ac.ValidateLocalConfig(projectYaml, quiet, includeLong, projectID)
 	if err != nil {
-		return nil
+		result.Errors = validationErrs
+		return result, nil
 	}
+	result.Errors = append(validationErrs, projErrors...)
 
-	grip.Info(projErrors)
-	if projErrors.HasError() {
-		return errors.Errorf("%s is an invalid configuration", path)
-	} else if len(projErrors) > 0 {
-		grip.Infof("%s is valid with warnings", path)
-	} else {
-		grip.Infof("%s is valid", path)
-	}
-
-	return nil
+	return result, nil
 }
 
 // loadProjectIntoWithValidation returns a warning (instead of an error) if there's an error with unmarshalling strictly
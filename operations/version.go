@@ -1,12 +1,25 @@
 package operations
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
 
+const (
+	versionIDFlagName   = "version"
+	graphFormatFlagName = "format"
+	graphOutputFlagName = "output"
+
+	graphFormatJSON = "json"
+	graphFormatDOT  = "dot"
+)
+
 func Version() cli.Command {
 	return cli.Command{
 		Name:    "version",
@@ -16,5 +29,86 @@ func Version() cli.Command {
 			fmt.Println(evergreen.ClientVersion)
 			return nil
 		},
+		Subcommands: []cli.Command{
+			versionGraph(),
+		},
+	}
+}
+
+func versionGraph() cli.Command {
+	return cli.Command{
+		Name:  "graph",
+		Usage: "export the task dependency graph of a version, including the critical path of tasks that determined when it finished",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(versionIDFlagName, "v"),
+				Usage: "the ID of the version",
+			},
+			cli.StringFlag{
+				Name:  graphFormatFlagName,
+				Usage: fmt.Sprintf("the format of the graph, either '%s' or '%s' (Graphviz)", graphFormatJSON, graphFormatDOT),
+				Value: graphFormatJSON,
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(graphOutputFlagName, "o"),
+				Usage: "the file to write the graph to (defaults to stdout)",
+			},
+		},
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			requireStringFlag(versionIDFlagName),
+			func(c *cli.Context) error {
+				format := c.String(graphFormatFlagName)
+				if format != graphFormatJSON && format != graphFormatDOT {
+					return errors.Errorf("invalid format '%s', must be one of '%s' or '%s'", format, graphFormatJSON, graphFormatDOT)
+				}
+				return nil
+			},
+		),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			versionID := c.String(versionIDFlagName)
+			format := c.String(graphFormatFlagName)
+			outputPath := c.String(graphOutputFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			var output []byte
+			switch format {
+			case graphFormatDOT:
+				dot, err := client.GetVersionGraphDOT(ctx, versionID)
+				if err != nil {
+					return errors.Wrapf(err, "getting task graph for version '%s'", versionID)
+				}
+				output = []byte(dot)
+			default:
+				graph, err := client.GetVersionGraph(ctx, versionID)
+				if err != nil {
+					return errors.Wrapf(err, "getting task graph for version '%s'", versionID)
+				}
+				output, err = json.MarshalIndent(graph, "", "\t")
+				if err != nil {
+					return errors.Wrap(err, "marshalling task graph to JSON")
+				}
+				output = append(output, '\n')
+			}
+
+			if outputPath == "" {
+				_, err = os.Stdout.Write(output)
+				return errors.Wrap(err, "writing task graph")
+			}
+			return errors.Wrapf(os.WriteFile(outputPath, output, 0644), "writing task graph to file '%s'", outputPath)
+		},
 	}
 }
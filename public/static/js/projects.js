@@ -169,6 +169,22 @@ mciModule.controller(
         validator: validatePercentage,
       },],
     },
+    {
+      trigger: "command-regression",
+      resource_type: "TASK",
+      label: "the peak memory or runtime of a command in a task increases by some percentage",
+      regex_selectors: taskRegexSelectors(),
+      extraFields: [{
+        text: "Peak memory percent increase",
+        key: "command-memory-percent-change",
+        validator: validatePercentage,
+      },
+      {
+        text: "Runtime percent increase",
+        key: "command-duration-percent-change",
+        validator: validatePercentage,
+      },],
+    },
     ];
 
     // refreshTrackedProjects will populate the list of projects that should be displayed
@@ -307,6 +307,21 @@ mciModule.controller('TaskCtrl', function ($scope, $rootScope, $now, $timeout, $
         validator: validatePercentage
       }]
     },
+    {
+      trigger: "command-regression",
+      resource_type: "TASK",
+      label: "the peak memory or runtime of a command in this task increases by some percentage",
+      extraFields: [{
+        text: "Peak memory percent increase",
+        key: "command-memory-percent-change",
+        validator: validatePercentage
+      },
+      {
+        text: "Runtime percent increase",
+        key: "command-duration-percent-change",
+        validator: validatePercentage
+      }]
+    },
   ];
 
   $scope.addSubscription = function () {
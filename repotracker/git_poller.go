@@ -0,0 +1,246 @@
+package repotracker
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	gitPollerTimeout = 5 * time.Minute
+
+	// gitLogFormat separates the fields of each commit with NUL bytes and
+	// the commits with record separators, since commit messages can
+	// contain newlines.
+	gitLogFormat = "--format=%H%x00%an%x00%ae%x00%cI%x00%B%x1e"
+)
+
+// GitRepositoryPoller is a RepoPoller that polls any git remote reachable
+// over SSH or HTTPS by running git. It fetches the project's branch into a
+// local bare repository, so git must be able to authenticate with the
+// remote non-interactively.
+type GitRepositoryPoller struct {
+	ProjectRef *model.ProjectRef
+	// CacheDir is the directory of the local bare repository.
+	CacheDir string
+}
+
+// NewGitRepositoryPoller constructs and returns a pointer to a
+// GitRepositoryPoller struct. The project's repo URL is the git remote URL.
+func NewGitRepositoryPoller(projectRef *model.ProjectRef, cacheDir string) *GitRepositoryPoller {
+	return &GitRepositoryPoller{
+		ProjectRef: projectRef,
+		CacheDir:   cacheDir,
+	}
+}
+
+// git runs the git command with the given arguments against the local bare
+// repository and returns its output.
+func (p *GitRepositoryPoller) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--git-dir", p.CacheDir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "running 'git %s': %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+func (p *GitRepositoryPoller) branchRef() string {
+	return "refs/heads/" + p.ProjectRef.Branch
+}
+
+// fetch updates the project's branch in the local bare repository,
+// initializing the repository if needed.
+func (p *GitRepositoryPoller) fetch(ctx context.Context) error {
+	if p.ProjectRef.RepoURL == "" {
+		return errors.Errorf("project '%s' has no repo URL", p.ProjectRef.Id)
+	}
+	if _, err := os.Stat(filepath.Join(p.CacheDir, "HEAD")); os.IsNotExist(err) {
+		if err = os.MkdirAll(p.CacheDir, 0755); err != nil {
+			return errors.Wrapf(err, "creating cache directory '%s'", p.CacheDir)
+		}
+		if _, err = p.git(ctx, "init", "--bare", "--quiet"); err != nil {
+			return errors.Wrap(err, "initializing local repository")
+		}
+	}
+
+	refspec := "+" + p.branchRef() + ":" + p.branchRef()
+	// The separator keeps git from parsing the project-controlled URL as an
+	// option.
+	if _, err := p.git(ctx, "fetch", "--quiet", "--prune", "--", p.ProjectRef.RepoURL, refspec); err != nil {
+		return errors.Wrapf(err, "fetching branch '%s'", p.ProjectRef.Branch)
+	}
+	return nil
+}
+
+// ensureRevision fetches the project's branch if the revision isn't in the
+// local repository yet.
+func (p *GitRepositoryPoller) ensureRevision(ctx context.Context, revision string) error {
+	if _, err := p.git(ctx, "cat-file", "-e", revision+"^{commit}"); err == nil {
+		return nil
+	}
+	return p.fetch(ctx)
+}
+
+// log returns the revisions output by git log with the given arguments.
+func (p *GitRepositoryPoller) log(ctx context.Context, args ...string) ([]model.Revision, error) {
+	out, err := p.git(ctx, append([]string{"log", gitLogFormat}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []model.Revision
+	for _, record := range strings.Split(string(out), "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.SplitN(record, "\x00", 5)
+		if len(fields) != 5 {
+			return nil, errors.Errorf("malformed git log output '%s'", record)
+		}
+		createTime, err := time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing commit time of revision '%s'", fields[0])
+		}
+		revisions = append(revisions, model.Revision{
+			Revision:        fields[0],
+			Author:          fields[1],
+			AuthorEmail:     fields[2],
+			CreateTime:      createTime,
+			RevisionMessage: strings.TrimRight(fields[4], "\n"),
+		})
+	}
+	return revisions, nil
+}
+
+// GetRemoteConfig fetches the contents of the project's configuration data
+// as at a given revision.
+func (p *GitRepositoryPoller) GetRemoteConfig(ctx context.Context, revision string) (model.ProjectInfo, error) {
+	if err := p.ensureRevision(ctx, revision); err != nil {
+		return model.ProjectInfo{}, err
+	}
+
+	opts := model.GetProjectOpts{
+		Ref:        p.ProjectRef,
+		RemotePath: p.ProjectRef.RemotePath,
+		Revision:   revision,
+		FileGetter: func(ctx context.Context, path, revision string) ([]byte, error) {
+			return p.git(ctx, "show", revision+":"+path)
+		},
+	}
+	return model.GetProjectFromFile(ctx, opts)
+}
+
+// GetChangedFiles returns the paths of the files modified by the revision.
+// Merge commits are compared against their first parent.
+func (p *GitRepositoryPoller) GetChangedFiles(ctx context.Context, revision string) ([]string, error) {
+	if err := p.ensureRevision(ctx, revision); err != nil {
+		return nil, err
+	}
+
+	out, err := p.git(ctx, "log", "-1", "-m", "--first-parent", "--name-only", "--format=", revision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting files changed by revision '%s'", revision)
+	}
+
+	files := []string{}
+	for _, file := range strings.Split(string(out), "\n") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// GetRevisionsSince fetches the commits on the project's branch that were
+// made after the given revision, from most to least recent. If the revision
+// isn't found within maxRevisionsToSearch commits, the merge base of the
+// revision and the branch is used as the new last revision.
+func (p *GitRepositoryPoller) GetRevisionsSince(revision string, maxRevisionsToSearch int) ([]model.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), gitPollerTimeout)
+	defer cancel()
+
+	if err := p.fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	commits, err := p.log(ctx, "--max-count", maxCountArg(maxRevisionsToSearch), p.branchRef())
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting revisions for branch '%s'", p.ProjectRef.Branch)
+	}
+	revisions := []model.Revision{}
+	for _, commit := range commits {
+		if commit.Revision == revision {
+			return revisions, nil
+		}
+		revisions = append(revisions, commit)
+	}
+
+	out, err := p.git(ctx, "merge-base", revision, p.branchRef())
+	if err != nil {
+		return []model.Revision{}, setInvalidRevisionError(p.ProjectRef, revision, err)
+	}
+	baseRevision := strings.TrimSpace(string(out))
+
+	base, err := p.log(ctx, "-1", baseRevision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading base commit '%s'", baseRevision)
+	}
+	if len(base) != 1 {
+		return nil, errors.Errorf("expected 1 base commit '%s' but found %d", baseRevision, len(base))
+	}
+	revisions = append(revisions, base[0])
+
+	grip.Info(message.Fields{
+		"message":            "updating last repo revision for project",
+		"source":             "git poller",
+		"old_revision":       revision,
+		"new_revision":       baseRevision,
+		"project":            p.ProjectRef.Id,
+		"project_identifier": p.ProjectRef.Identifier,
+	})
+	if err = model.UpdateLastRevision(p.ProjectRef.Id, baseRevision); err != nil {
+		return nil, errors.Wrapf(err, "updating last revision to base revision '%s'", baseRevision)
+	}
+
+	return revisions, nil
+}
+
+// GetRecentRevisions fetches the most recent maxRevisions commits on the
+// project's branch, from most to least recent.
+func (p *GitRepositoryPoller) GetRecentRevisions(maxRevisions int) ([]model.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), gitPollerTimeout)
+	defer cancel()
+
+	if err := p.fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	revisions, err := p.log(ctx, "--max-count", maxCountArg(maxRevisions), p.branchRef())
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting revisions for branch '%s'", p.ProjectRef.Branch)
+	}
+	return revisions, nil
+}
+
+func maxCountArg(n int) string {
+	if n <= 0 {
+		return "-1"
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,146 @@
+package repotracker
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitTestRepo is a local bare repository with a working clone that commits
+// can be pushed from.
+type gitTestRepo struct {
+	t        *testing.T
+	remote   string
+	worktree string
+}
+
+func newGitTestRepo(t *testing.T) *gitTestRepo {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	repo := &gitTestRepo{
+		t:        t,
+		remote:   filepath.Join(dir, "remote.git"),
+		worktree: filepath.Join(dir, "worktree"),
+	}
+	repo.run(dir, "init", "--bare", "--quiet", repo.remote)
+	repo.run(dir, "init", "--quiet", repo.worktree)
+	repo.run(repo.worktree, "checkout", "--quiet", "-b", "main")
+	return repo
+}
+
+func (r *gitTestRepo) run(dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Octo Cat",
+		"GIT_AUTHOR_EMAIL=octocat@example.com",
+		"GIT_COMMITTER_NAME=Octo Cat",
+		"GIT_COMMITTER_EMAIL=octocat@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(r.t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes the files to the working clone, commits them with the given
+// message and pushes the commit. It returns the commit's SHA.
+func (r *gitTestRepo) commit(message string, files map[string]string) string {
+	for path, contents := range files {
+		fullPath := filepath.Join(r.worktree, path)
+		require.NoError(r.t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(r.t, os.WriteFile(fullPath, []byte(contents), 0644))
+	}
+	r.run(r.worktree, "add", "-A")
+	r.run(r.worktree, "commit", "--quiet", "-m", message)
+	r.run(r.worktree, "push", "--quiet", r.remote, "main")
+	return r.run(r.worktree, "rev-parse", "HEAD")
+}
+
+func TestGitRepositoryPoller(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := newGitTestRepo(t)
+	first := repo.commit("initial commit", map[string]string{
+		"evergreen.yml": "tasks:\n- name: compile\n",
+	})
+	second := repo.commit("add readme", map[string]string{
+		"README.md": "hello\n",
+	})
+	third := repo.commit("multi-line message\n\nwith a body", map[string]string{
+		"src/main.go":   "package main\n",
+		"evergreen.yml": "tasks:\n- name: compile\n- name: test\n",
+	})
+
+	projectRef := &model.ProjectRef{
+		Id:           "project",
+		Branch:       "main",
+		RemotePath:   "evergreen.yml",
+		RepoProvider: model.RepoProviderGit,
+		RepoURL:      repo.remote,
+	}
+	poller := NewGitRepositoryPoller(projectRef, filepath.Join(t.TempDir(), "cache"))
+
+	t.Run("GetRecentRevisions", func(t *testing.T) {
+		revisions, err := poller.GetRecentRevisions(2)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, third, revisions[0].Revision)
+		assert.Equal(t, second, revisions[1].Revision)
+		assert.Equal(t, "Octo Cat", revisions[0].Author)
+		assert.Equal(t, "octocat@example.com", revisions[0].AuthorEmail)
+		assert.Equal(t, "multi-line message\n\nwith a body", revisions[0].RevisionMessage)
+		assert.False(t, revisions[0].CreateTime.IsZero())
+	})
+	t.Run("GetRevisionsSince", func(t *testing.T) {
+		revisions, err := poller.GetRevisionsSince(first, 10)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, third, revisions[0].Revision)
+		assert.Equal(t, second, revisions[1].Revision)
+
+		revisions, err = poller.GetRevisionsSince(third, 10)
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+	t.Run("GetRevisionsSincePicksUpNewCommits", func(t *testing.T) {
+		fourth := repo.commit("new commit", map[string]string{
+			"README.md": "hello again\n",
+		})
+		revisions, err := poller.GetRevisionsSince(third, 10)
+		require.NoError(t, err)
+		require.Len(t, revisions, 1)
+		assert.Equal(t, fourth, revisions[0].Revision)
+	})
+	t.Run("GetChangedFiles", func(t *testing.T) {
+		files, err := poller.GetChangedFiles(ctx, third)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"evergreen.yml", "src/main.go"}, files)
+	})
+	t.Run("GetRemoteConfig", func(t *testing.T) {
+		projectInfo, err := poller.GetRemoteConfig(ctx, first)
+		require.NoError(t, err)
+		require.NotNil(t, projectInfo.Project)
+		require.Len(t, projectInfo.Project.Tasks, 1)
+		assert.Equal(t, "compile", projectInfo.Project.Tasks[0].Name)
+
+		projectInfo, err = poller.GetRemoteConfig(ctx, third)
+		require.NoError(t, err)
+		require.NotNil(t, projectInfo.Project)
+		assert.Len(t, projectInfo.Project.Tasks, 2)
+	})
+	t.Run("MissingRepoURL", func(t *testing.T) {
+		noURL := NewGitRepositoryPoller(&model.ProjectRef{Id: "project", Branch: "main"}, filepath.Join(t.TempDir(), "cache"))
+		_, err := noURL.GetRecentRevisions(1)
+		assert.Error(t, err)
+	})
+}
@@ -185,19 +185,7 @@ func (gRepoPoller *GithubRepositoryPoller) GetRevisionsSince(revision string, ma
 			return nil, errors.Errorf("invalid revision '%s'", revision)
 		}
 		if err != nil {
-			// unable to get merge base commit so set projectRef revision details with a blank base revision
-			revisionDetails := &model.RepositoryErrorDetails{
-				Exists:            true,
-				InvalidRevision:   revision[:10],
-				MergeBaseRevision: "",
-			}
-			revisionError := errors.Wrapf(err,
-				"unable to find a suggested merge base commit for revision '%s', must fix on projects settings page",
-				revision)
-			if err := gRepoPoller.ProjectRef.SetRepotrackerError(revisionDetails); err != nil {
-				return []model.Revision{}, errors.Wrap(err, "setting repotracker error")
-			}
-			return []model.Revision{}, revisionError
+			return []model.Revision{}, setInvalidRevisionError(gRepoPoller.ProjectRef, revision, err)
 		}
 
 		// automatically set the newly found base revision as base revision and append revisions
@@ -0,0 +1,164 @@
+package repotracker
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// GitLabRepositoryPoller is a RepoPoller for projects whose repository is
+// hosted on GitLab, which it polls using the GitLab REST API.
+type GitLabRepositoryPoller struct {
+	ProjectRef *model.ProjectRef
+	// BaseURL is the base URL of the GitLab instance. It comes from the admin
+	// settings rather than the project, since the token is sent to it.
+	BaseURL string
+	Token   string
+}
+
+// NewGitLabRepositoryPoller constructs and returns a pointer to a
+// GitLabRepositoryPoller struct.
+func NewGitLabRepositoryPoller(projectRef *model.ProjectRef, baseURL, token string) *GitLabRepositoryPoller {
+	return &GitLabRepositoryPoller{
+		ProjectRef: projectRef,
+		BaseURL:    baseURL,
+		Token:      token,
+	}
+}
+
+func (p *GitLabRepositoryPoller) gitLabProject() thirdparty.GitLabProject {
+	return thirdparty.GitLabProject{
+		BaseURL: p.BaseURL,
+		Token:   p.Token,
+		Owner:   p.ProjectRef.Owner,
+		Repo:    p.ProjectRef.Repo,
+	}
+}
+
+func gitLabCommitToRevision(commit thirdparty.GitLabCommit) model.Revision {
+	return model.Revision{
+		Author:          commit.AuthorName,
+		AuthorEmail:     commit.AuthorEmail,
+		RevisionMessage: commit.Message,
+		Revision:        commit.ID,
+		CreateTime:      commit.CommittedDate,
+	}
+}
+
+// GetRemoteConfig fetches the contents of the project's configuration data
+// as at a given revision.
+func (p *GitLabRepositoryPoller) GetRemoteConfig(ctx context.Context, revision string) (model.ProjectInfo, error) {
+	opts := model.GetProjectOpts{
+		Ref:        p.ProjectRef,
+		RemotePath: p.ProjectRef.RemotePath,
+		Revision:   revision,
+		FileGetter: func(ctx context.Context, path, revision string) ([]byte, error) {
+			return thirdparty.GetGitLabFile(ctx, p.gitLabProject(), path, revision)
+		},
+	}
+	return model.GetProjectFromFile(ctx, opts)
+}
+
+// GetChangedFiles returns the paths of the files modified by the revision.
+func (p *GitLabRepositoryPoller) GetChangedFiles(ctx context.Context, revision string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return thirdparty.GetGitLabCommitFiles(ctx, p.gitLabProject(), revision)
+}
+
+// GetRevisionsSince fetches the commits on the project's branch that were
+// made after the given revision, from most to least recent. If the revision
+// isn't found within maxRevisionsToSearch commits, the merge base of the
+// revision and the branch is used as the new last revision.
+func (p *GitLabRepositoryPoller) GetRevisionsSince(revision string, maxRevisionsToSearch int) ([]model.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	var foundLatest bool
+	var firstCommit string
+	revisions := []model.Revision{}
+	for page := 1; page != 0 && !foundLatest && len(revisions) < maxRevisionsToSearch; {
+		var commits []thirdparty.GitLabCommit
+		var err error
+		commits, page, err = thirdparty.GetGitLabCommits(ctx, p.gitLabProject(), p.ProjectRef.Branch, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			if len(revisions) >= maxRevisionsToSearch {
+				break
+			}
+			if firstCommit == "" {
+				firstCommit = commit.ID
+			}
+			if commit.ID == revision {
+				foundLatest = true
+				break
+			}
+			revisions = append(revisions, gitLabCommitToRevision(commit))
+		}
+	}
+	if foundLatest {
+		return revisions, nil
+	}
+
+	var baseRevision string
+	err := errors.New("no recent commit found")
+	if firstCommit != "" {
+		baseRevision, err = thirdparty.GetGitLabMergeBase(ctx, p.gitLabProject(), revision, firstCommit)
+	}
+	if err != nil {
+		return []model.Revision{}, setInvalidRevisionError(p.ProjectRef, revision, err)
+	}
+
+	commit, err := thirdparty.GetGitLabCommit(ctx, p.gitLabProject(), baseRevision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading base commit '%s'", baseRevision)
+	}
+	revisions = append(revisions, gitLabCommitToRevision(*commit))
+
+	grip.Info(message.Fields{
+		"message":            "updating last repo revision for project",
+		"source":             "gitlab poller",
+		"old_revision":       revision,
+		"new_revision":       baseRevision,
+		"project":            p.ProjectRef.Id,
+		"project_identifier": p.ProjectRef.Identifier,
+	})
+	if err = model.UpdateLastRevision(p.ProjectRef.Id, baseRevision); err != nil {
+		return nil, errors.Wrapf(err, "updating last revision to base revision '%s'", baseRevision)
+	}
+
+	return revisions, nil
+}
+
+// GetRecentRevisions fetches the most recent maxRevisions commits on the
+// project's branch, from most to least recent.
+func (p *GitLabRepositoryPoller) GetRecentRevisions(maxRevisions int) ([]model.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	var revisions []model.Revision
+	for page := 1; page != 0 && len(revisions) < maxRevisions; {
+		var commits []thirdparty.GitLabCommit
+		var err error
+		commits, page, err = thirdparty.GetGitLabCommits(ctx, p.gitLabProject(), p.ProjectRef.Branch, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			if len(revisions) == maxRevisions {
+				break
+			}
+			revisions = append(revisions, gitLabCommitToRevision(commit))
+		}
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,99 @@
+package repotracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabRepositoryPoller(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	commits := []thirdparty.GitLabCommit{}
+	for _, sha := range []string{"cccccccccc", "bbbbbbbbbb", "aaaaaaaaaa"} {
+		commits = append(commits, thirdparty.GitLabCommit{
+			ID:            sha,
+			AuthorName:    "Octo Cat",
+			AuthorEmail:   "octocat@example.com",
+			Message:       "commit " + sha,
+			CommittedDate: time.Now().Round(time.Second),
+		})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/group/project/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+		assert.Equal(t, "main", r.URL.Query().Get("ref_name"))
+		// Serve one commit per page to exercise paging.
+		page := map[string]int{"1": 0, "2": 1, "3": 2}[r.URL.Query().Get("page")]
+		if page < len(commits)-1 {
+			w.Header().Set("X-Next-Page", []string{"2", "3"}[page])
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(commits[page:page+1]))
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/repository/commits/cccccccccc/diff", func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`[{"new_path": "evergreen.yml"}, {"new_path": "src/main.go"}]`))
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/repository/files/evergreen.yml/raw", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "cccccccccc", r.URL.Query().Get("ref"))
+		_, err := w.Write([]byte("tasks:\n- name: compile\n"))
+		assert.NoError(t, err)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	projectRef := &model.ProjectRef{
+		Id:           "project",
+		Owner:        "group",
+		Repo:         "project",
+		Branch:       "main",
+		RemotePath:   "evergreen.yml",
+		RepoProvider: model.RepoProviderGitLab,
+	}
+	poller := NewGitLabRepositoryPoller(projectRef, server.URL, "token")
+
+	t.Run("GetRecentRevisions", func(t *testing.T) {
+		revisions, err := poller.GetRecentRevisions(2)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "cccccccccc", revisions[0].Revision)
+		assert.Equal(t, "bbbbbbbbbb", revisions[1].Revision)
+		assert.Equal(t, "Octo Cat", revisions[0].Author)
+		assert.Equal(t, "octocat@example.com", revisions[0].AuthorEmail)
+		assert.Equal(t, "commit cccccccccc", revisions[0].RevisionMessage)
+	})
+	t.Run("GetRevisionsSince", func(t *testing.T) {
+		revisions, err := poller.GetRevisionsSince("aaaaaaaaaa", 10)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "cccccccccc", revisions[0].Revision)
+		assert.Equal(t, "bbbbbbbbbb", revisions[1].Revision)
+	})
+	t.Run("GetChangedFiles", func(t *testing.T) {
+		files, err := poller.GetChangedFiles(ctx, "cccccccccc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"evergreen.yml", "src/main.go"}, files)
+	})
+	t.Run("GetRemoteConfig", func(t *testing.T) {
+		projectInfo, err := poller.GetRemoteConfig(ctx, "cccccccccc")
+		require.NoError(t, err)
+		require.NotNil(t, projectInfo.Project)
+		require.Len(t, projectInfo.Project.Tasks, 1)
+		assert.Equal(t, "compile", projectInfo.Project.Tasks[0].Name)
+	})
+	t.Run("NonexistentProject", func(t *testing.T) {
+		missing := NewGitLabRepositoryPoller(&model.ProjectRef{Owner: "group", Repo: "missing", Branch: "main"}, server.URL, "token")
+		_, err := missing.GetRecentRevisions(1)
+		assert.Error(t, err)
+	})
+}
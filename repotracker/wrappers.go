@@ -2,6 +2,8 @@ package repotracker
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -22,24 +24,61 @@ const (
 )
 
 func getTracker(conf *evergreen.Settings, project model.ProjectRef) (*RepoTracker, error) {
-	token, err := conf.GetGithubOauthToken()
-	if err != nil {
-		grip.Warning(message.Fields{
-			"runner":  RunnerName,
-			"message": "GitHub credentials not specified in Evergreen credentials file",
-		})
-		return nil, errors.WithStack(err)
+	var poller RepoPoller
+	switch project.GetRepoProvider() {
+	case model.RepoProviderGitLab:
+		token, err := conf.GetGitLabToken()
+		if err != nil {
+			grip.Warning(message.Fields{
+				"runner":  RunnerName,
+				"message": "GitLab credentials not specified in Evergreen credentials file",
+			})
+			return nil, errors.WithStack(err)
+		}
+		poller = NewGitLabRepositoryPoller(&project, conf.GitLabURL, token)
+	case model.RepoProviderGit:
+		poller = NewGitRepositoryPoller(&project, filepath.Join(os.TempDir(), "evergreen-repotracker", project.Id))
+	default:
+		token, err := conf.GetGithubOauthToken()
+		if err != nil {
+			grip.Warning(message.Fields{
+				"runner":  RunnerName,
+				"message": "GitHub credentials not specified in Evergreen credentials file",
+			})
+			return nil, errors.WithStack(err)
+		}
+		poller = NewGithubRepositoryPoller(&project, token)
 	}
 
 	tracker := &RepoTracker{
 		Settings:   conf,
 		ProjectRef: &project,
-		RepoPoller: NewGithubRepositoryPoller(&project, token),
+		RepoPoller: poller,
 	}
 
 	return tracker, nil
 }
 
+// setInvalidRevisionError records on the project that its last known revision
+// couldn't be found on its branch and that no merge base could be suggested
+// in its place, so that it must be fixed on the project settings page.
+func setInvalidRevisionError(projectRef *model.ProjectRef, revision string, err error) error {
+	if len(revision) < 10 {
+		return errors.Errorf("invalid revision '%s'", revision)
+	}
+	revisionDetails := &model.RepositoryErrorDetails{
+		Exists:            true,
+		InvalidRevision:   revision[:10],
+		MergeBaseRevision: "",
+	}
+	if setErr := projectRef.SetRepotrackerError(revisionDetails); setErr != nil {
+		return errors.Wrap(setErr, "setting repotracker error")
+	}
+	return errors.Wrapf(err,
+		"unable to find a suggested merge base commit for revision '%s', must fix on projects settings page",
+		revision)
+}
+
 func CollectRevisionsForProject(ctx context.Context, conf *evergreen.Settings, project model.ProjectRef) error {
 	if !project.Enabled || project.IsRepotrackerDisabled() {
 		return errors.Errorf("project disabled: %s", project.Id)
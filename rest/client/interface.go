@@ -46,6 +46,15 @@ type Communicator interface {
 	GetServiceUsers(ctx context.Context) ([]restmodel.APIDBUser, error)
 	UpdateServiceUser(context.Context, string, string, []string) error
 	DeleteServiceUser(context.Context, string) error
+	// EncryptProjectVars encrypts all private and admin-only project
+	// variables that are not yet encrypted. If reencrypt is set, they're all
+	// re-encrypted with a new data key. It returns the number of projects
+	// that were updated.
+	EncryptProjectVars(ctx context.Context, reencrypt bool) (int, error)
+	// RotateProjectVarsKey rewraps the data keys that encrypt project
+	// variables with the current key encryption key. It returns the number
+	// of data keys that were rewrapped.
+	RotateProjectVarsKey(ctx context.Context) (int, error)
 
 	// Spawnhost methods
 	//
@@ -65,6 +74,10 @@ type Communicator interface {
 	ModifyVolume(context.Context, string, *restmodel.VolumeModifyOptions) error
 	GetVolume(context.Context, string) (*restmodel.APIVolume, error)
 	GetVolumesByUser(context.Context) ([]restmodel.APIVolume, error)
+	CreateSpawnHostSnapshot(context.Context, string, string) (*restmodel.APISpawnHostSnapshot, error)
+	GetSpawnHostSnapshots(context.Context) ([]restmodel.APISpawnHostSnapshot, error)
+	RestoreSpawnHostSnapshot(context.Context, string, *restmodel.HostRequestOptions) (*restmodel.APIHost, error)
+	DeleteSpawnHostSnapshot(context.Context, string) error
 	StartHostProcesses(context.Context, []string, string, int) ([]restmodel.APIHostProcess, error)
 	GetHostProcessOutput(context.Context, []restmodel.APIHostProcess, int) ([]restmodel.APIHostProcess, error)
 	FindHostByIpAddress(context.Context, string) (*restmodel.APIHost, error)
@@ -113,6 +126,22 @@ type Communicator interface {
 	GetManifestByTask(ctx context.Context, taskId string) (*manifest.Manifest, error)
 
 	GetRecentVersionsForProject(ctx context.Context, projectID, requester string) ([]restmodel.APIVersion, error)
+	// GetVersionGraph returns the task dependency graph of the version,
+	// including its critical path.
+	GetVersionGraph(ctx context.Context, versionID string) (*restmodel.APIVersionGraph, error)
+	// GetVersionGraphDOT returns the task dependency graph of the version in
+	// the Graphviz DOT language.
+	GetVersionGraphDOT(ctx context.Context, versionID string) (string, error)
+
+	// SearchTaskLogs returns a page of the task log lines that match the
+	// search, along with the key of the next page, which is empty once all
+	// logs have been searched.
+	SearchTaskLogs(ctx context.Context, params restmodel.APITaskLogSearchParams) ([]restmodel.APITaskLogSearchMatch, string, error)
+
+	// Test quarantine
+	GetQuarantinedTests(ctx context.Context, projectID string) ([]restmodel.APIQuarantinedTest, error)
+	QuarantineTest(ctx context.Context, projectID, pattern, reason string) (*restmodel.APIQuarantinedTest, error)
+	UnquarantineTest(ctx context.Context, projectID, pattern string) error
 
 	// GetTaskSyncReadCredentials returns the credentials to fetch task
 	// directory from S3.
@@ -129,4 +158,7 @@ type Communicator interface {
 
 	// CompareTasks returns the order that the given tasks would be scheduled, along with the scheduling logic.
 	CompareTasks(context.Context, []string, bool) ([]string, map[string]map[string]string, error)
+	// SimulateScheduler returns the expected wait times of a snapshot of a
+	// distro's queued tasks under its current and candidate planner settings.
+	SimulateScheduler(context.Context, restmodel.APISchedulerSimulationRequest) (*restmodel.APISchedulerSimulationResult, error)
 }
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -299,6 +301,109 @@ func (c *communicatorImpl) GetVolumesByUser(ctx context.Context) ([]model.APIVol
 	return getVolumesResp, nil
 }
 
+func (c *communicatorImpl) CreateSpawnHostSnapshot(ctx context.Context, hostID, displayName string) (*model.APISpawnHostSnapshot, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("hosts/%s/snapshots", hostID),
+	}
+
+	resp, err := c.request(ctx, info, model.SnapshotPostRequest{DisplayName: displayName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to create snapshot of host '%s'", hostID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "creating snapshot of host '%s'", hostID)
+	}
+
+	snapshotResp := model.APISpawnHostSnapshot{}
+	if err = utility.ReadJSON(resp.Body, &snapshotResp); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return &snapshotResp, nil
+}
+
+func (c *communicatorImpl) GetSpawnHostSnapshots(ctx context.Context) ([]model.APISpawnHostSnapshot, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   "snapshots",
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get snapshots for user '%s'", c.apiUser)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting snapshots for user '%s'", c.apiUser)
+	}
+
+	snapshotsResp := []model.APISpawnHostSnapshot{}
+	if err = utility.ReadJSON(resp.Body, &snapshotsResp); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return snapshotsResp, nil
+}
+
+func (c *communicatorImpl) RestoreSpawnHostSnapshot(ctx context.Context, snapshotID string, spawnRequest *model.HostRequestOptions) (*model.APIHost, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("snapshots/%s/restore", snapshotID),
+	}
+
+	resp, err := c.request(ctx, info, spawnRequest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to restore snapshot '%s'", snapshotID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "restoring snapshot '%s'", snapshotID)
+	}
+
+	spawnHostResp := model.APIHost{}
+	if err = utility.ReadJSON(resp.Body, &spawnHostResp); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return &spawnHostResp, nil
+}
+
+func (c *communicatorImpl) DeleteSpawnHostSnapshot(ctx context.Context, snapshotID string) error {
+	info := requestInfo{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("snapshots/%s", snapshotID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "sending request to delete snapshot '%s'", snapshotID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return util.RespErrorf(resp, "deleting snapshot '%s'", snapshotID)
+	}
+
+	return nil
+}
+
 func (c *communicatorImpl) StartSpawnHost(ctx context.Context, hostID string, subscriptionType string, wait bool) error {
 	info := requestInfo{
 		method: http.MethodPost,
@@ -1418,6 +1523,197 @@ func (c *communicatorImpl) GetRecentVersionsForProject(ctx context.Context, proj
 	return getVersionsResp, nil
 }
 
+func (c *communicatorImpl) GetVersionGraph(ctx context.Context, versionID string) (*model.APIVersionGraph, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("versions/%s/graph", versionID),
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get task graph for version '%s'", versionID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting task graph for version '%s'", versionID)
+	}
+
+	graph := &model.APIVersionGraph{}
+	if err = utility.ReadJSON(resp.Body, graph); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return graph, nil
+}
+
+func (c *communicatorImpl) GetVersionGraphDOT(ctx context.Context, versionID string) (string, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("versions/%s/graph?format=dot", versionID),
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "sending request to get task graph for version '%s'", versionID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return "", util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", util.RespErrorf(resp, "getting task graph for version '%s'", versionID)
+	}
+
+	dot, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "reading response body")
+	}
+
+	return string(dot), nil
+}
+
+func (c *communicatorImpl) SearchTaskLogs(ctx context.Context, params model.APITaskLogSearchParams) ([]model.APITaskLogSearchMatch, string, error) {
+	var path string
+	switch {
+	case params.TaskID != "":
+		path = fmt.Sprintf("tasks/%s/logs/search", url.PathEscape(params.TaskID))
+	case params.BuildID != "":
+		path = fmt.Sprintf("builds/%s/logs/search", url.PathEscape(params.BuildID))
+	case params.VersionID != "":
+		path = fmt.Sprintf("versions/%s/logs/search", url.PathEscape(params.VersionID))
+	default:
+		return nil, "", errors.New("must specify a version, build or task to search")
+	}
+
+	query := url.Values{}
+	query.Set("pattern", params.Pattern)
+	if params.LogType != "" {
+		query.Set("log_type", params.LogType)
+	}
+	if params.AllExecutions {
+		query.Set("all_executions", "true")
+	}
+	if params.ContextLines != nil {
+		query.Set("context", strconv.Itoa(*params.ContextLines))
+	}
+	if params.Limit > 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.StartAt != "" {
+		query.Set("start_at", params.StartAt)
+	}
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("%s?%s", path, query.Encode()),
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "sending request to search task logs")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, "", util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", util.RespErrorf(resp, "searching task logs")
+	}
+
+	matches := []model.APITaskLogSearchMatch{}
+	if err = utility.ReadJSON(resp.Body, &matches); err != nil {
+		return nil, "", errors.Wrap(err, "reading JSON response body")
+	}
+
+	return matches, getNextPageKey(resp.Header.Get("Link"), "start_at"), nil
+}
+
+func (c *communicatorImpl) GetQuarantinedTests(ctx context.Context, projectID string) ([]model.APIQuarantinedTest, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("projects/%s/quarantined_tests", projectID),
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get quarantined tests for project '%s'", projectID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting quarantined tests for project '%s'", projectID)
+	}
+
+	quarantined := []model.APIQuarantinedTest{}
+	if err = utility.ReadJSON(resp.Body, &quarantined); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return quarantined, nil
+}
+
+func (c *communicatorImpl) QuarantineTest(ctx context.Context, projectID, pattern, reason string) (*model.APIQuarantinedTest, error) {
+	info := requestInfo{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("projects/%s/quarantined_tests", projectID),
+	}
+	body := model.APIQuarantinedTest{
+		Pattern: utility.ToStringPtr(pattern),
+		Reason:  utility.ToStringPtr(reason),
+	}
+
+	resp, err := c.request(ctx, info, body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to quarantine test pattern '%s' in project '%s'", pattern, projectID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "quarantining test pattern '%s' in project '%s'", pattern, projectID)
+	}
+
+	quarantined := &model.APIQuarantinedTest{}
+	if err = utility.ReadJSON(resp.Body, quarantined); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return quarantined, nil
+}
+
+func (c *communicatorImpl) UnquarantineTest(ctx context.Context, projectID, pattern string) error {
+	info := requestInfo{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("projects/%s/quarantined_tests", projectID),
+	}
+	body := model.APIQuarantinedTest{Pattern: utility.ToStringPtr(pattern)}
+
+	resp, err := c.request(ctx, info, body)
+	if err != nil {
+		return errors.Wrapf(err, "sending request to remove quarantined test pattern '%s' from project '%s'", pattern, projectID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return util.RespErrorf(resp, "removing quarantined test pattern '%s' from project '%s'", pattern, projectID)
+	}
+
+	return nil
+}
+
 func (c *communicatorImpl) GetTaskSyncReadCredentials(ctx context.Context) (*evergreen.S3Credentials, error) {
 	info := requestInfo{
 		method: http.MethodGet,
@@ -1570,6 +1866,94 @@ func (c *communicatorImpl) CompareTasks(ctx context.Context, tasks []string, use
 	return results.Order, results.Logic, nil
 }
 
+func (c *communicatorImpl) SimulateScheduler(ctx context.Context, req restmodel.APISchedulerSimulationRequest) (*restmodel.APISchedulerSimulationResult, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   "/admin/scheduler/simulate",
+	}
+
+	resp, err := c.request(ctx, info, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "sending request to simulate scheduler")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "simulating scheduler")
+	}
+
+	result := &restmodel.APISchedulerSimulationResult{}
+	if err = utility.ReadJSON(resp.Body, result); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return result, nil
+}
+
+func (c *communicatorImpl) EncryptProjectVars(ctx context.Context, reencrypt bool) (int, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   "/admin/project_vars/encrypt",
+	}
+
+	body := struct {
+		Reencrypt bool `json:"reencrypt"`
+	}{reencrypt}
+	resp, err := c.request(ctx, info, body)
+	if err != nil {
+		return 0, errors.Wrap(err, "sending request to encrypt project variables")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return 0, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, util.RespErrorf(resp, "encrypting project variables")
+	}
+
+	var result struct {
+		UpdatedCount int `json:"updated_count"`
+	}
+	if err = utility.ReadJSON(resp.Body, &result); err != nil {
+		return 0, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return result.UpdatedCount, nil
+}
+
+func (c *communicatorImpl) RotateProjectVarsKey(ctx context.Context) (int, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   "/admin/project_vars/rotate_key",
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "sending request to rotate project variable encryption key")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return 0, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, util.RespErrorf(resp, "rotating project variable encryption key")
+	}
+
+	var result struct {
+		RotatedCount int `json:"rotated_count"`
+	}
+	if err = utility.ReadJSON(resp.Body, &result); err != nil {
+		return 0, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return result.RotatedCount, nil
+}
+
 // FindHostByIpAddress queries the database for the host with ip matching the ip address
 func (c *communicatorImpl) FindHostByIpAddress(ctx context.Context, ip string) (*model.APIHost, error) {
 	info := requestInfo{
@@ -129,6 +129,22 @@ func (c *Mock) GetVolume(context.Context, string) (*model.APIVolume, error) {
 	return nil, errors.New("(*Mock) GetVolume is not implemented")
 }
 
+func (*Mock) CreateSpawnHostSnapshot(context.Context, string, string) (*model.APISpawnHostSnapshot, error) {
+	return nil, errors.New("(*Mock) CreateSpawnHostSnapshot is not implemented")
+}
+
+func (*Mock) GetSpawnHostSnapshots(context.Context) ([]model.APISpawnHostSnapshot, error) {
+	return nil, errors.New("(*Mock) GetSpawnHostSnapshots is not implemented")
+}
+
+func (*Mock) RestoreSpawnHostSnapshot(context.Context, string, *model.HostRequestOptions) (*model.APIHost, error) {
+	return nil, errors.New("(*Mock) RestoreSpawnHostSnapshot is not implemented")
+}
+
+func (*Mock) DeleteSpawnHostSnapshot(context.Context, string) error {
+	return errors.New("(*Mock) DeleteSpawnHostSnapshot is not implemented")
+}
+
 // GetHosts will return an array with a single mock host
 func (c *Mock) GetHosts(ctx context.Context, data model.APIHostParams) ([]*model.APIHost, error) {
 	spawnRequest := &model.HostRequestOptions{
@@ -161,6 +177,8 @@ func (c *Mock) RevertSettings(ctx context.Context, guid string) error { return n
 func (c *Mock) ExecuteOnDistro(context.Context, string, model.APIDistroScriptOptions) ([]string, error) {
 	return nil, nil
 }
+func (c *Mock) EncryptProjectVars(context.Context, bool) (int, error) { return 0, nil }
+func (c *Mock) RotateProjectVarsKey(context.Context) (int, error)     { return 0, nil }
 
 func (c *Mock) GetDistrosList(ctx context.Context) ([]model.APIDistro, error) {
 	mockDistros := []model.APIDistro{
@@ -312,6 +330,30 @@ func (c *Mock) GetRecentVersionsForProject(context.Context, string, string) ([]r
 	return nil, nil
 }
 
+func (c *Mock) GetVersionGraph(context.Context, string) (*restmodel.APIVersionGraph, error) {
+	return nil, nil
+}
+
+func (c *Mock) GetVersionGraphDOT(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func (c *Mock) SearchTaskLogs(context.Context, restmodel.APITaskLogSearchParams) ([]restmodel.APITaskLogSearchMatch, string, error) {
+	return nil, "", nil
+}
+
+func (c *Mock) GetQuarantinedTests(context.Context, string) ([]restmodel.APIQuarantinedTest, error) {
+	return nil, nil
+}
+
+func (c *Mock) QuarantineTest(context.Context, string, string, string) (*restmodel.APIQuarantinedTest, error) {
+	return nil, nil
+}
+
+func (c *Mock) UnquarantineTest(context.Context, string, string) error {
+	return nil
+}
+
 func (c *Mock) GetTaskSyncReadCredentials(context.Context) (*evergreen.S3Credentials, error) {
 	return &evergreen.S3Credentials{}, nil
 }
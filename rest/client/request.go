@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -145,6 +146,24 @@ func (c *communicatorImpl) getPath(path string) string {
 	return fmt.Sprintf("%s%s/%s", c.serverURL, evergreen.APIRoutePrefixV2, strings.TrimPrefix(path, "/"))
 }
 
+// getNextPageKey returns the value of the key query parameter in the next
+// page's link from a paginated response's Link header, or an empty string if
+// there is no next page.
+func getNextPageKey(linkHeader, keyParam string) string {
+	for _, link := range strings.Split(linkHeader, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 || strings.TrimSpace(parts[1]) != `rel="next"` {
+			continue
+		}
+		nextURL, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		if err != nil {
+			return ""
+		}
+		return nextURL.Query().Get(keyParam)
+	}
+	return ""
+}
+
 func (r *requestInfo) validateRequestInfo() error {
 	switch r.method {
 	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
@@ -55,3 +55,10 @@ func (s *RequestTestSuite) TestValidateRequestInfo() {
 		s.Error(err)
 	}
 }
+
+func (s *RequestTestSuite) TestGetNextPageKey() {
+	s.Equal("task:0:10", getNextPageKey(`<https://example.com/rest/v2/versions/v1/logs/search?limit=100&pattern=segfault&start_at=task%3A0%3A10>; rel="next"`, "start_at"))
+	s.Equal("next", getNextPageKey(`<https://example.com/hosts?start_at=prev>; rel="prev", <https://example.com/hosts?start_at=next>; rel="next"`, "start_at"))
+	s.Empty(getNextPageKey(`<https://example.com/hosts?start_at=prev>; rel="prev"`, "start_at"))
+	s.Empty(getNextPageKey("", "start_at"))
+}
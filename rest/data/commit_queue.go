@@ -57,6 +57,10 @@ func (pc *DBCommitQueueConnector) AddPatchForPR(ctx context.Context, projectRef
 	isConfigDefined := len(patchDoc.PatchedProjectConfig) > 0
 	errs = append(errs, validator.CheckProjectSettings(settings, proj, &projectRef, isConfigDefined)...)
 	errs = append(errs, validator.CheckPatchedProjectConfigErrors(patchDoc.PatchedProjectConfig)...)
+	// Warnings are always checked because the project may raise a warning
+	// rule to an error.
+	errs = append(errs, validator.CheckProjectWarnings(proj)...)
+	errs = validator.ApplyPatchedLintRules(errs, patchDoc.PatchedProjectConfig)
 	catcher := grip.NewBasicCatcher()
 	for _, validationErr := range errs.AtLevel(validator.Error) {
 		catcher.Add(validationErr)
@@ -160,6 +164,8 @@ func writePatchInfo(patchDoc *patch.Patch, patchSummaries []thirdparty.Summary,
 
 // EnqueueItem will enqueue an item to a project's commit queue.
 // If enqueueNext is true, move the commit queue item to be processed next.
+// If the item doesn't already have a lane, its lane is computed from its
+// patch.
 func EnqueueItem(projectID string, item restModel.APICommitQueueItem, enqueueNext bool) (int, error) {
 	q, err := commitqueue.FindOneId(projectID)
 	if err != nil {
@@ -170,6 +176,18 @@ func EnqueueItem(projectID string, item restModel.APICommitQueueItem, enqueueNex
 	}
 
 	itemService := item.ToService()
+	if itemService.Lane == commitqueue.SharedLane && itemService.PatchId != "" {
+		p, err := patch.FindOneId(itemService.PatchId)
+		if err != nil {
+			return 0, errors.Wrapf(err, "finding patch '%s'", itemService.PatchId)
+		}
+		if p != nil {
+			itemService.Lane, err = model.CommitQueueLaneForPatch(p)
+			if err != nil {
+				return 0, errors.Wrapf(err, "getting commit queue lane for patch '%s'", itemService.PatchId)
+			}
+		}
+	}
 	if enqueueNext {
 		var position int
 		position, err = q.EnqueueAtFront(itemService)
@@ -462,6 +480,10 @@ func tryEnqueueItemForPR(ctx context.Context, sc Connector, projectRef *model.Pr
 	if err != nil {
 		return nil, errors.Wrap(err, "creating patch for PR")
 	}
+	lane, err := model.CommitQueueLaneForPatch(patchDoc)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting commit queue lane for PR patch")
+	}
 
 	item := restModel.APICommitQueueItem{
 		Issue:           utility.ToStringPtr(strconv.Itoa(prNum)),
@@ -469,6 +491,7 @@ func tryEnqueueItemForPR(ctx context.Context, sc Connector, projectRef *model.Pr
 		Modules:         cqInfo.Modules,
 		Source:          utility.ToStringPtr(commitqueue.SourcePullRequest),
 		PatchId:         utility.ToStringPtr(patchDoc.Id.Hex()),
+		Lane:            utility.ToStringPtr(lane),
 	}
 	if _, err = EnqueueItem(projectRef.Id, item, false); err != nil {
 		return nil, errors.Wrap(err, "enqueueing commit queue item")
@@ -579,7 +602,7 @@ func GetAdditionalPatches(patchId string) ([]string, error) {
 		}
 	}
 	additionalPatches := []string{}
-	for _, item := range cq.Queue {
+	for _, item := range cq.LaneForItem(patchId).Queue {
 		if item.Version == patchId {
 			return additionalPatches, nil
 		} else if item.Version != "" {
@@ -102,6 +102,38 @@ func (s *CommitQueueSuite) TestEnqueue() {
 	s.Equal("important", q.Queue[0].Issue)
 }
 
+func (s *CommitQueueSuite) TestTryEnqueueItemForPRComputesLane() {
+	s.projectRef.CommitQueue.Lanes = []model.CommitQueueLane{
+		{Name: "docs", Paths: []string{"docs/"}},
+	}
+	s.Require().NoError(s.projectRef.Upsert())
+	sc := &MockGitHubConnector{MockGitHubConnectorImpl: MockGitHubConnectorImpl{
+		PRPatch: &patch.Patch{
+			Id:      mgobson.NewObjectId(),
+			Project: s.projectRef.Id,
+			Patches: []patch.ModulePatch{
+				{
+					PatchSet: patch.PatchSet{
+						Summary: []thirdparty.Summary{{Name: "docs/README.md"}},
+					},
+				},
+			},
+		},
+	}}
+
+	p, err := tryEnqueueItemForPR(s.ctx, sc, s.projectRef, 1234, restModel.GithubCommentCqData{})
+	s.Require().NoError(err)
+	s.Require().NotNil(p)
+
+	cq, err := commitqueue.FindOneId(s.projectRef.Id)
+	s.Require().NoError(err)
+	s.Require().Len(cq.Queue, 1)
+	s.Equal("1234", cq.Queue[0].Issue)
+	s.Equal(commitqueue.SourcePullRequest, cq.Queue[0].Source)
+	s.Equal(p.Id.Hex(), cq.Queue[0].PatchId)
+	s.Equal("docs", cq.Queue[0].Lane)
+}
+
 func (s *CommitQueueSuite) TestFindCommitQueueByID() {
 	cq, err := FindCommitQueueForProject("mci")
 	s.NoError(err)
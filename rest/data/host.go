@@ -234,6 +234,7 @@ func makeSpawnOptions(options *restmodel.HostRequestOptions, user *user.DBUser)
 		IsCluster:             options.IsCluster,
 		HomeVolumeSize:        options.HomeVolumeSize,
 		HomeVolumeID:          options.HomeVolumeID,
+		SnapshotID:            options.SnapshotID,
 		Region:                options.Region,
 		Expiration:            options.Expiration,
 		UseProjectSetupScript: options.UseProjectSetupScript,
@@ -44,12 +44,15 @@ func ListHostsForTask(ctx context.Context, taskID string) ([]host.Host, error) {
 	catcher.Add(err)
 	hostsSpawnedByBuild, err := host.FindHostsSpawnedByBuild(t.BuildId)
 	catcher.Add(err)
+	hostsLeasedByTask, err := host.FindHostsLeasedByTask(t.Id, t.Execution)
+	catcher.Add(err)
 	if catcher.HasErrors() {
 		return nil, gimlet.ErrorResponse{StatusCode: http.StatusInternalServerError, Message: catcher.String()}
 	}
 	hosts := []host.Host{}
 	hosts = append(hosts, hostsSpawnedByBuild...)
 	hosts = append(hosts, hostsSpawnedByTask...)
+	hosts = append(hosts, hostsLeasedByTask...)
 	for idx, h := range hosts {
 		if h.IsContainer() {
 			p, err := h.GetParent()
@@ -162,6 +165,107 @@ func CreateHostsFromTask(ctx context.Context, settings *evergreen.Settings, t *t
 	return catcher.Resolve()
 }
 
+// LeaseHostsFromPool leases the hosts requested by host.create from the host
+// pool of the task's version. Hosts are created for the pool as they're
+// needed, up to the pool's size, and are then reused by the version's tasks.
+// If the pool doesn't have enough free hosts for the request, no hosts are
+// leased and no host IDs are returned, so the caller should try again later.
+func LeaseHostsFromPool(ctx context.Context, settings *evergreen.Settings, taskID string, createHost apimodels.CreateHost) ([]string, error) {
+	t, err := task.FindOneId(taskID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding task '%s'", taskID)
+	}
+	if t == nil {
+		return nil, gimlet.ErrorResponse{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("task '%s' not found", taskID)}
+	}
+
+	proj, expansions, err := makeProjectAndExpansionsFromTask(ctx, settings, t)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	pool := proj.FindHostPool(createHost.Pool)
+	if pool == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("host pool '%s' is not defined in the project", createHost.Pool),
+		}
+	}
+	numHosts, err := strconv.Atoi(createHost.NumHosts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing host.create number of hosts '%s' as int", createHost.NumHosts)
+	}
+	if numHosts > pool.Size {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("cannot lease %d hosts from host pool '%s' of size %d", numHosts, pool.Name, pool.Size),
+		}
+	}
+
+	poolHost := pool.Host
+	if err = poolHost.Expand(expansions); err != nil {
+		return nil, errors.Wrapf(err, "handling expansions for host pool '%s'", pool.Name)
+	}
+	if err = poolHost.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid host settings for host pool '%s'", pool.Name)
+	}
+	poolHost.Pool = pool.Name
+
+	ids := []string{}
+	for len(ids) < numHosts {
+		id, err := leasePoolHost(t, pool.Name, pool.Size, poolHost)
+		if err != nil {
+			catcher := grip.NewBasicCatcher()
+			catcher.Add(err)
+			catcher.Wrap(releasePoolHosts(ids, t), "releasing hosts already leased")
+			return nil, catcher.Resolve()
+		}
+		if id == "" {
+			// Don't hold on to some of the hosts while waiting for the rest,
+			// since that could prevent other tasks from leasing enough hosts.
+			return []string{}, releasePoolHosts(ids, t)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// leasePoolHost leases a free host in the pool to the task, or creates one if
+// the pool has room for another host. It returns an empty ID if the pool is
+// full and none of its hosts are free.
+func leasePoolHost(t *task.Task, pool string, size int, poolHost apimodels.CreateHost) (string, error) {
+	timeoutTeardown := time.Now().Add(time.Duration(poolHost.TeardownTimeoutSecs) * time.Second)
+	h, err := host.LeaseFreePoolHost(t.Version, pool, t.Id, t.Execution, timeoutTeardown)
+	if err != nil {
+		return "", err
+	}
+	if h != nil {
+		return h.Id, nil
+	}
+
+	intent, err := makeEC2Intent(t.Id, "", "", poolHost)
+	if err != nil {
+		return "", errors.Wrapf(err, "creating intent host for host pool '%s'", pool)
+	}
+	inserted, err := host.InsertPoolHost(intent, size)
+	if err != nil {
+		return "", errors.Wrapf(err, "inserting intent host for host pool '%s'", pool)
+	}
+	if !inserted {
+		return "", nil
+	}
+	return intent.Id, nil
+}
+
+// releasePoolHosts returns the hosts leased by the task to their pool.
+func releasePoolHosts(hostIDs []string, t *task.Task) error {
+	catcher := grip.NewBasicCatcher()
+	for _, id := range hostIDs {
+		catcher.Add(host.ReleasePoolHost(id, t.Id, t.Execution))
+	}
+	return catcher.Resolve()
+}
+
 func makeProjectAndExpansionsFromTask(ctx context.Context, settings *evergreen.Settings, t *task.Task) (*model.Project, *util.Expansions, error) {
 	v, err := model.VersionFindOne(model.VersionById(t.Version))
 	if err != nil {
@@ -217,6 +321,9 @@ func updateExpansions(expansions *util.Expansions, projectId string, params []pa
 	if projVars == nil {
 		return errors.New("project variables not found")
 	}
+	if err = projVars.Decrypt(); err != nil {
+		return errors.Wrap(err, "decrypting project variables")
+	}
 
 	expansions.Update(projVars.Vars)
 
@@ -326,6 +433,20 @@ func makeDockerIntentHost(taskID, userID string, createHost apimodels.CreateHost
 }
 
 func makeEC2IntentHost(taskID, userID, publicKey string, createHost apimodels.CreateHost) (*host.Host, error) {
+	intent, err := makeEC2Intent(taskID, userID, publicKey, createHost)
+	if err != nil {
+		return nil, err
+	}
+	if err = intent.Insert(); err != nil {
+		return nil, errors.Wrap(err, "inserting intent host")
+	}
+
+	return intent, nil
+}
+
+// makeEC2Intent returns an EC2 intent host for host.create without inserting
+// it.
+func makeEC2Intent(taskID, userID, publicKey string, createHost apimodels.CreateHost) (*host.Host, error) {
 	if createHost.Region == "" {
 		createHost.Region = evergreen.DefaultEC2Region
 	}
@@ -422,12 +543,8 @@ func makeEC2IntentHost(taskID, userID, publicKey string, createHost apimodels.Cr
 	if err != nil {
 		return nil, errors.Wrap(err, "making intent host options")
 	}
-	intent := host.NewIntent(*options)
-	if err = intent.Insert(); err != nil {
-		return nil, errors.Wrap(err, "inserting intent host")
-	}
 
-	return intent, nil
+	return host.NewIntent(*options), nil
 }
 
 func getHostCreationOptions(d distro.Distro, taskID, userID string, createHost apimodels.CreateHost) (*host.CreateOptions, error) {
@@ -452,12 +569,19 @@ func getHostCreationOptions(d distro.Distro, taskID, userID string, createHost a
 		if t == nil {
 			return nil, errors.Errorf("task '%s' not found", taskID)
 		}
-		if createHost.Scope == "build" {
-			options.SpawnOptions.BuildID = t.BuildId
-		}
-		if createHost.Scope == "task" {
-			options.SpawnOptions.TaskID = taskID
-			options.SpawnOptions.TaskExecutionNumber = t.Execution
+		if createHost.Pool != "" {
+			options.SpawnOptions.HostPool = createHost.Pool
+			options.SpawnOptions.VersionID = t.Version
+			options.SpawnOptions.LeasedByTaskID = taskID
+			options.SpawnOptions.LeasedByTaskExecution = t.Execution
+		} else {
+			if createHost.Scope == "build" {
+				options.SpawnOptions.BuildID = t.BuildId
+			}
+			if createHost.Scope == "task" {
+				options.SpawnOptions.TaskID = taskID
+				options.SpawnOptions.TaskExecutionNumber = t.Execution
+			}
 		}
 		options.SpawnOptions.TimeoutTeardown = time.Now().Add(time.Duration(createHost.TeardownTimeoutSecs) * time.Second)
 		options.SpawnOptions.TimeoutSetup = time.Now().Add(time.Duration(createHost.SetupTimeoutSecs) * time.Second)
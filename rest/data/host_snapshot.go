@@ -0,0 +1,109 @@
+package data
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/units"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateSpawnHostSnapshot records a new snapshot of a spawn host and enqueues
+// a job to create the snapshot's resources in the provider.
+func CreateSpawnHostSnapshot(ctx context.Context, env evergreen.Environment, u *user.DBUser, h *host.Host, displayName string) (*host.Snapshot, int, error) {
+	if h.Status != evergreen.HostRunning && h.Status != evergreen.HostStopped {
+		return nil, http.StatusBadRequest, errors.Errorf("host '%s' cannot be snapshotted when its status is '%s'", h.Id, h.Status)
+	}
+	if h.Provider != evergreen.ProviderNameDocker && !evergreen.IsEc2Provider(h.Provider) {
+		return nil, http.StatusBadRequest, errors.Errorf("snapshots are not supported for provider '%s'", h.Provider)
+	}
+
+	numSnapshots, err := host.CountSnapshotsForUser(u.Id)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrapf(err, "counting snapshots for user '%s'", u.Id)
+	}
+	maxSnapshots := env.Settings().Spawnhost.SnapshotsPerUser
+	if numSnapshots >= maxSnapshots {
+		return nil, http.StatusBadRequest, errors.Errorf("cannot have more than %d snapshots per user", maxSnapshots)
+	}
+
+	snapshot := &host.Snapshot{
+		ID:                   primitive.NewObjectID().Hex(),
+		DisplayName:          displayName,
+		CreatedBy:            u.Id,
+		SourceHostID:         h.Id,
+		DistroID:             h.Distro.Id,
+		Provider:             h.Provider,
+		InstanceType:         h.InstanceType,
+		ParentID:             h.ParentID,
+		IsVirtualWorkstation: h.IsVirtualWorkstation,
+		Status:               host.SnapshotStatusCreating,
+		Expiration:           time.Now().Add(evergreen.SpawnHostSnapshotExpiration),
+	}
+	if evergreen.IsEc2Provider(h.Provider) {
+		snapshot.AvailabilityZone = h.Zone
+		snapshot.Region = cloud.AztoRegion(h.Zone)
+	}
+	if h.HomeVolumeID != "" {
+		volume, err := host.FindVolumeByID(h.HomeVolumeID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, errors.Wrapf(err, "finding home volume '%s'", h.HomeVolumeID)
+		}
+		if volume != nil {
+			snapshot.HomeVolumeSize = int(volume.Size)
+		}
+	}
+	if err = snapshot.Insert(); err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrapf(err, "inserting snapshot of host '%s'", h.Id)
+	}
+	// Concurrent requests can all pass the check above, so check again now
+	// that the snapshot has been inserted and back it out if the user is over
+	// the limit.
+	numSnapshots, err = host.CountSnapshotsForUser(u.Id)
+	if err != nil || numSnapshots > maxSnapshots {
+		catcher := grip.NewBasicCatcher()
+		catcher.Wrapf(err, "counting snapshots for user '%s'", u.Id)
+		catcher.Wrapf(snapshot.Remove(), "removing snapshot '%s'", snapshot.ID)
+		if catcher.HasErrors() {
+			return nil, http.StatusInternalServerError, catcher.Resolve()
+		}
+		return nil, http.StatusBadRequest, errors.Errorf("cannot have more than %d snapshots per user", maxSnapshots)
+	}
+
+	if err = env.RemoteQueue().Put(ctx, units.NewSpawnhostSnapshotJob(h, snapshot, u.Id)); err != nil {
+		if amboy.IsDuplicateJobScopeError(err) {
+			err = errHostStatusChangeConflict
+		}
+		catcher := grip.NewBasicCatcher()
+		catcher.Add(err)
+		catcher.Wrapf(snapshot.Remove(), "removing snapshot '%s'", snapshot.ID)
+		return nil, http.StatusInternalServerError, catcher.Resolve()
+	}
+
+	return snapshot, http.StatusOK, nil
+}
+
+// DeleteSpawnHostSnapshot enqueues a job to delete a spawn host snapshot.
+func DeleteSpawnHostSnapshot(ctx context.Context, env evergreen.Environment, u *user.DBUser, snapshot *host.Snapshot) (int, error) {
+	if snapshot.CreatedBy != u.Id {
+		return http.StatusForbidden, errors.Errorf("not authorized to delete snapshot '%s'", snapshot.ID)
+	}
+	if snapshot.Status == host.SnapshotStatusCreating {
+		return http.StatusBadRequest, errors.Errorf("snapshot '%s' cannot be deleted while it is being created", snapshot.ID)
+	}
+
+	ts := utility.RoundPartOfMinute(1).Format(units.TSFormat)
+	if err := amboy.EnqueueUniqueJob(ctx, env.RemoteQueue(), units.NewSnapshotDeletionJob(ts, snapshot)); err != nil {
+		return http.StatusInternalServerError, errors.Wrapf(err, "enqueueing deletion job for snapshot '%s'", snapshot.ID)
+	}
+	return http.StatusOK, nil
+}
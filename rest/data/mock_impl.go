@@ -20,6 +20,9 @@ type MockGitHubConnectorImpl struct {
 	Aliases         []restModel.APIProjectAlias
 	CachedTests     []testresult.TestResult
 	StoredError     error
+	// PRPatch is the patch returned for a PR. If it's nil, an empty patch is
+	// returned.
+	PRPatch *patch.Patch
 }
 
 func (pc *MockGitHubConnectorImpl) GetGitHubPR(ctx context.Context, owner, repo string, prNum int) (*github.PullRequest, error) {
@@ -39,6 +42,9 @@ func (pc *MockGitHubConnectorImpl) GetGitHubPR(ctx context.Context, owner, repo
 }
 
 func (pc *MockGitHubConnectorImpl) AddPatchForPR(ctx context.Context, projectRef model.ProjectRef, prNum int, modules []restModel.APIModule, messageOverride string) (*patch.Patch, error) {
+	if pc.PRPatch != nil {
+		return pc.PRPatch, nil
+	}
 	return &patch.Patch{}, nil
 }
 
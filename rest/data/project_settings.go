@@ -116,6 +116,11 @@ func PromoteVarsToRepo(projectId string, varNames []string, userId string) error
 	if err != nil {
 		return errors.Wrapf(err, "getting project variables for project '%s'", projectId)
 	}
+	// Encrypted values are bound to their project, so they're decrypted to
+	// move them to the repo.
+	if err = projectVars.Decrypt(); err != nil {
+		return errors.Wrapf(err, "decrypting project variables for project '%s'", projectId)
+	}
 
 	repo, err := model.GetProjectSettingsById(repoId, true)
 	if err != nil {
@@ -349,6 +354,9 @@ func SaveProjectSettingsForSection(ctx context.Context, projectId string, change
 		if err = handleGithubConflicts(mergedSection, "Toggling GitHub features"); err != nil {
 			return nil, err
 		}
+		if err = mergedSection.CommitQueue.ValidateLanes(); err != nil {
+			return nil, errors.Wrap(err, "invalid commit queue lanes")
+		}
 		// At project creation we now insert a commit queue, however older projects still may not have one
 		// so we need to validate that this exists if the feature is being toggled on.
 		if !mergedBeforeRef.CommitQueue.IsEnabled() && mergedSection.CommitQueue.IsEnabled() {
@@ -30,3 +30,26 @@ func GetTaskReliabilityScores(filter reliability.TaskReliabilityFilter) ([]restM
 	}
 	return apiStatsResult, nil
 }
+
+// GetTestFlakinessScores queries the service backend to retrieve the test
+// flakiness scores that match the given filter.
+func GetTestFlakinessScores(filter reliability.TestFlakinessFilter) ([]restModel.APITestFlakiness, error) {
+	if filter.Project != "" {
+		projectID, err := model.GetIdForProject(filter.Project)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting project ref ID for identifier '%s'", filter.Project)
+		}
+		filter.Project = projectID
+	}
+
+	scores, err := reliability.GetTestFlakinessScores(filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting test flakiness scores")
+	}
+
+	apiScores := make([]restModel.APITestFlakiness, len(scores))
+	for i, score := range scores {
+		apiScores[i].BuildFromService(score)
+	}
+	return apiScores, nil
+}
@@ -1,9 +1,15 @@
 package data
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/scheduler"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
 	"github.com/pkg/errors"
 )
 
@@ -58,3 +64,91 @@ func CompareTasks(taskIds []string, useLegacy bool) ([]string, map[string]map[st
 	}
 	return prioritizedIds, logic, nil
 }
+
+// SimulatePlannerSettings simulates how the distro's queued tasks would be
+// planned with the candidate planner settings without modifying its task
+// queue.
+func SimulatePlannerSettings(req restModel.APISchedulerSimulationRequest) (*restModel.APISchedulerSimulationResult, error) {
+	distroID := utility.FromStringPtr(req.Distro)
+	d, err := distro.FindOneId(distroID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding distro '%s'", distroID)
+	}
+	if d == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("distro '%s' not found", distroID),
+		}
+	}
+
+	result, err := scheduler.SimulatePlannerSettings(d, scheduler.SimulationOptions{
+		SnapshotTime:    utility.FromTimePtr(req.SnapshotTime),
+		NumHosts:        req.NumHosts,
+		PlannerSettings: candidatePlannerSettingsToService(req.PlannerSettings),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "simulating planner settings for distro '%s'", distroID)
+	}
+	if result.NumHosts == 0 {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "no hosts were running tasks in the distro at the snapshot time, so the number of hosts must be specified",
+		}
+	}
+
+	apiResult := &restModel.APISchedulerSimulationResult{
+		Distro:       utility.ToStringPtr(result.DistroID),
+		SnapshotTime: restModel.ToTimePtr(result.SnapshotTime),
+		NumTasks:     result.NumTasks,
+		NumHosts:     result.NumHosts,
+		Current:      buildAPIRequesterWaitTimes(result.Current),
+		Candidate:    buildAPIRequesterWaitTimes(result.Candidate),
+	}
+	apiResult.CurrentSettings.BuildFromService(result.CurrentSettings)
+	apiResult.CandidateSettings.BuildFromService(result.CandidateSettings)
+	return apiResult, nil
+}
+
+func buildAPIRequesterWaitTimes(waitTimes []scheduler.RequesterWaitTimes) []restModel.APIRequesterWaitTimes {
+	apiWaitTimes := make([]restModel.APIRequesterWaitTimes, 0, len(waitTimes))
+	for _, w := range waitTimes {
+		apiWaitTimes = append(apiWaitTimes, restModel.APIRequesterWaitTimes{
+			Requester:   utility.ToStringPtr(w.Requester),
+			NumTasks:    w.NumTasks,
+			AverageWait: restModel.NewAPIDuration(w.AverageWait),
+			MaxWait:     restModel.NewAPIDuration(w.MaxWait),
+		})
+	}
+	return apiWaitTimes
+}
+
+// candidatePlannerSettingsToService converts the candidate planner settings
+// in the request to the settings to simulate.
+func candidatePlannerSettingsToService(s restModel.APICandidatePlannerSettings) scheduler.CandidatePlannerSettings {
+	settings := scheduler.CandidatePlannerSettings{
+		GroupVersions:             s.GroupVersions,
+		PatchFactor:               s.PatchFactor,
+		PatchTimeInQueueFactor:    s.PatchTimeInQueueFactor,
+		CommitQueueFactor:         s.CommitQueueFactor,
+		MainlineTimeInQueueFactor: s.MainlineTimeInQueueFactor,
+		ExpectedRuntimeFactor:     s.ExpectedRuntimeFactor,
+		GenerateTaskFactor:        s.GenerateTaskFactor,
+		StepbackTaskFactor:        s.StepbackTaskFactor,
+		FairShare:                 s.FairShare,
+		FairShareFactor:           s.FairShareFactor,
+	}
+	if s.FairShareWindow != nil {
+		window := s.FairShareWindow.ToDuration()
+		settings.FairShareWindow = &window
+	}
+	if s.ProjectShares != nil {
+		settings.ProjectShares = []distro.ProjectShare{}
+		for _, ps := range s.ProjectShares {
+			settings.ProjectShares = append(settings.ProjectShares, distro.ProjectShare{
+				Project: utility.FromStringPtr(ps.Project),
+				Share:   ps.Share,
+			})
+		}
+	}
+	return settings
+}
@@ -0,0 +1,315 @@
+package data
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// MaxLogSearchLogsPerPage is the maximum number of task execution logs
+	// that are read to produce a single page of log search results.
+	MaxLogSearchLogsPerPage = 100
+
+	buildloggerTimestampLayout = "2006/01/02 15:04:05.000"
+)
+
+// TaskLogSearchOptions are the options for searching the logs of a set of
+// tasks. Exactly one of the version, build or task IDs must be set.
+type TaskLogSearchOptions struct {
+	VersionID string
+	BuildID   string
+	TaskID    string
+
+	Pattern *regexp.Regexp
+	// LogType is the type of log to search, as one of the apimodels log
+	// prefixes, or apimodels.AllTaskLevelLogs.
+	LogType string
+	// AllExecutions searches the logs of every execution of each task,
+	// rather than only the latest.
+	AllExecutions bool
+	ContextLines  int
+	Limit         int
+	// StartAt is the page key returned by a previous search.
+	StartAt string
+}
+
+// TaskLogSearchResult is a page of log search results.
+type TaskLogSearchResult struct {
+	Matches []log.TaskSearchMatch
+	// NextKey is the page key at which to resume the search, or empty if
+	// there are no more logs to search.
+	NextKey string
+}
+
+type taskLogSearchKey struct {
+	taskID    string
+	execution int
+	line      int
+}
+
+func (k taskLogSearchKey) String() string {
+	return fmt.Sprintf("%s:%d:%d", k.taskID, k.execution, k.line)
+}
+
+func parseTaskLogSearchKey(key string) (taskLogSearchKey, error) {
+	parts := strings.Split(key, ":")
+	if len(parts) < 3 {
+		return taskLogSearchKey{}, errors.Errorf("invalid page key '%s'", key)
+	}
+	execution, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		return taskLogSearchKey{}, errors.Wrapf(err, "parsing execution from page key '%s'", key)
+	}
+	line, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return taskLogSearchKey{}, errors.Wrapf(err, "parsing line from page key '%s'", key)
+	}
+	return taskLogSearchKey{
+		taskID:    strings.Join(parts[:len(parts)-2], ":"),
+		execution: execution,
+		line:      line,
+	}, nil
+}
+
+type taskLogSearchTarget struct {
+	task      *task.Task
+	execution int
+}
+
+// SearchTaskLogs searches the logs of the tasks in a version or build, or of
+// a single task, for lines matching a pattern. Tasks are searched in order of
+// their IDs, and each page of results reads at most MaxLogSearchLogsPerPage
+// logs, so the work done per request is bounded even for versions with
+// thousands of tasks.
+func SearchTaskLogs(ctx context.Context, opts TaskLogSearchOptions) (*TaskLogSearchResult, error) {
+	if opts.Pattern == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a search pattern",
+		}
+	}
+	var start taskLogSearchKey
+	if opts.StartAt != "" {
+		var err error
+		start, err = parseTaskLogSearchKey(opts.StartAt)
+		if err != nil {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    err.Error(),
+			}
+		}
+	}
+
+	filter := bson.M{task.DisplayOnlyKey: bson.M{"$ne": true}}
+	switch {
+	case opts.TaskID != "":
+		filter[task.IdKey] = opts.TaskID
+	case opts.BuildID != "":
+		filter[task.BuildIdKey] = opts.BuildID
+	case opts.VersionID != "":
+		filter[task.VersionKey] = opts.VersionID
+	default:
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a version, build or task to search",
+		}
+	}
+	if start.taskID != "" && opts.TaskID == "" {
+		filter[task.IdKey] = bson.M{"$gte": start.taskID}
+	}
+	tasks, err := task.FindAll(db.Query(filter).
+		WithFields(task.IdKey, task.ExecutionKey, task.DisplayNameKey, task.BuildVariantKey, task.ProjectKey, task.VersionKey, task.StatusKey).
+		Sort([]string{task.IdKey}).
+		Limit(MaxLogSearchLogsPerPage + 1))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding tasks to search")
+	}
+
+	// Tasks past the first page are only used to find where the next page
+	// starts.
+	var nextTask *task.Task
+	if len(tasks) > MaxLogSearchLogsPerPage {
+		nextTask = &tasks[MaxLogSearchLogsPerPage]
+		tasks = tasks[:MaxLogSearchLogsPerPage]
+	}
+
+	targets := []taskLogSearchTarget{}
+	for i := range tasks {
+		first := tasks[i].Execution
+		if opts.AllExecutions {
+			first = 0
+		}
+		for execution := first; execution <= tasks[i].Execution; execution++ {
+			if tasks[i].Id == start.taskID && execution < start.execution {
+				continue
+			}
+			if execution == tasks[i].Execution && evergreen.IsUnstartedTaskStatus(tasks[i].Status) {
+				continue
+			}
+			targets = append(targets, taskLogSearchTarget{task: &tasks[i], execution: execution})
+		}
+	}
+
+	result := &TaskLogSearchResult{Matches: []log.TaskSearchMatch{}}
+	defaultLoggers := map[string]string{}
+	for i, target := range targets {
+		if i == MaxLogSearchLogsPerPage {
+			result.NextKey = taskLogSearchKey{taskID: target.task.Id, execution: target.execution}.String()
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		startAfter := 0
+		if target.task.Id == start.taskID && target.execution == start.execution {
+			startAfter = start.line
+		}
+		searcher := log.NewSearcher(opts.Pattern, opts.ContextLines, opts.Limit-len(result.Matches), startAfter)
+
+		defaultLogger, ok := defaultLoggers[target.task.Project]
+		if !ok {
+			defaultLogger, err = getTaskDefaultLogger(target.task)
+			if err != nil {
+				return nil, err
+			}
+			defaultLoggers[target.task.Project] = defaultLogger
+		}
+		if err = searchTaskExecutionLog(ctx, target, defaultLogger, opts.LogType, searcher); err != nil {
+			return nil, errors.Wrapf(err, "searching logs for task '%s' execution %d", target.task.Id, target.execution)
+		}
+
+		for _, match := range searcher.Matches() {
+			result.Matches = append(result.Matches, log.TaskSearchMatch{
+				TaskOptions:  log.TaskOptions{TaskID: target.task.Id, Execution: target.execution},
+				DisplayName:  target.task.DisplayName,
+				BuildVariant: target.task.BuildVariant,
+				SearchMatch:  match,
+			})
+		}
+		if len(result.Matches) >= opts.Limit {
+			last := result.Matches[len(result.Matches)-1]
+			result.NextKey = taskLogSearchKey{taskID: last.TaskID, execution: last.Execution, line: last.LineNumber}.String()
+			return result, nil
+		}
+	}
+	if result.NextKey == "" && nextTask != nil {
+		result.NextKey = taskLogSearchKey{taskID: nextTask.Id}.String()
+	}
+
+	return result, nil
+}
+
+func getTaskDefaultLogger(t *task.Task) (string, error) {
+	projectRef, err := model.FindMergedProjectRef(t.Project, t.Version, false)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding project '%s'", t.Project)
+	}
+	if projectRef != nil && projectRef.DefaultLogger != "" {
+		return projectRef.DefaultLogger, nil
+	}
+	return evergreen.GetEnvironment().Settings().LoggerConfig.DefaultLogger, nil
+}
+
+// searchTaskExecutionLog adds the lines of a task execution's log to the
+// searcher until it's done.
+func searchTaskExecutionLog(ctx context.Context, target taskLogSearchTarget, defaultLogger, logType string, searcher *log.Searcher) error {
+	if defaultLogger == model.BuildloggerLogSender {
+		logReader, err := apimodels.GetBuildloggerLogs(ctx, apimodels.GetBuildloggerLogsOptions{
+			BaseURL:   evergreen.GetEnvironment().Settings().Cedar.BaseURL,
+			TaskID:    target.task.Id,
+			Execution: utility.ToIntPtr(target.execution),
+			LogType:   logType,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			grip.Warning(message.WrapError(logReader.Close(), message.Fields{
+				"task_id": target.task.Id,
+				"message": "failed to close buildlogger log ReadCloser",
+			}))
+		}()
+
+		return searchBuildloggerLog(target.task.Id, logReader, searcher)
+	}
+
+	logTypes := []string{}
+	if logType != apimodels.AllTaskLevelLogs {
+		logTypes = []string{logType}
+	}
+	messages, err := model.GetRawTaskLogChannel(target.task.Id, target.execution, []string{}, logTypes)
+	if err != nil {
+		return errors.Wrap(err, "getting task logs")
+	}
+	// The channel must be drained so that the goroutine sending to it can
+	// exit and close its session.
+	defer func() {
+		for range messages {
+		}
+	}()
+	for msg := range messages {
+		if !searcher.Add(logMessageToLine(msg)) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func searchBuildloggerLog(taskID string, r io.Reader, searcher *log.Searcher) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := logMessageToLine(apimodels.ParseBuildloggerLine(taskID, scanner.Text()))
+		// Buildlogger lines start with the time they were logged.
+		if strings.HasPrefix(line.Data, "[") && len(line.Data) > len(buildloggerTimestampLayout)+2 {
+			if ts, err := time.Parse(buildloggerTimestampLayout, line.Data[1:len(buildloggerTimestampLayout)+1]); err == nil {
+				line.Timestamp = ts
+			}
+		}
+		if !searcher.Add(line) {
+			return nil
+		}
+	}
+	return errors.Wrap(scanner.Err(), "reading buildlogger logs")
+}
+
+func logMessageToLine(msg apimodels.LogMessage) log.LogLine {
+	priority := level.Info
+	switch msg.Severity {
+	case apimodels.LogErrorPrefix:
+		priority = level.Error
+	case apimodels.LogWarnPrefix:
+		priority = level.Warning
+	case apimodels.LogDebugPrefix:
+		priority = level.Debug
+	}
+	return log.LogLine{
+		LogName:   msg.Type,
+		Timestamp: msg.Timestamp,
+		Priority:  priority,
+		Data:      msg.Message,
+	}
+}
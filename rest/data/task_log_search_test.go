@@ -0,0 +1,172 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestTaskLogSearchKey(t *testing.T) {
+	key := taskLogSearchKey{taskID: "task:with:colons", execution: 2, line: 40}
+	parsed, err := parseTaskLogSearchKey(key.String())
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = parseTaskLogSearchKey("task:1")
+	assert.Error(t, err)
+	_, err = parseTaskLogSearchKey("task:one:1")
+	assert.Error(t, err)
+}
+
+func TestSearchBuildloggerLog(t *testing.T) {
+	logs := strings.Join([]string{
+		"[P: 30] [2023/05/01 12:00:00.000] compiling",
+		"[P: 70] [2023/05/01 12:00:01.500] segfault in main",
+		"[P: 30] [2023/05/01 12:00:02.000] exiting",
+	}, "\n")
+	searcher := log.NewSearcher(regexp.MustCompile("segfault"), 1, 10, 0)
+	require.NoError(t, searchBuildloggerLog("task", strings.NewReader(logs), searcher))
+
+	matches := searcher.Matches()
+	require.Len(t, matches, 1)
+	assert.Equal(t, 2, matches[0].LineNumber)
+	assert.Equal(t, level.Error, matches[0].Line.Priority)
+	assert.Equal(t, time.Date(2023, 5, 1, 12, 0, 1, 500000000, time.UTC), matches[0].Line.Timestamp)
+	require.Len(t, matches[0].Before, 1)
+	assert.Contains(t, matches[0].Before[0].Data, "compiling")
+	require.Len(t, matches[0].After, 1)
+	assert.Contains(t, matches[0].After[0].Data, "exiting")
+}
+
+func clearTaskLogs(t *testing.T) {
+	session, _, err := db.GetGlobalSessionFactory().GetSession()
+	require.NoError(t, err)
+	defer session.Close()
+	_, err = session.DB(model.TaskLogDB).C(model.TaskLogCollection).RemoveAll(bson.M{})
+	require.NoError(t, err)
+}
+
+func TestSearchTaskLogs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(task.Collection, model.ProjectRefCollection))
+	clearTaskLogs(t)
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, model.ProjectRefCollection))
+		clearTaskLogs(t)
+	}()
+
+	projectRef := model.ProjectRef{Id: "project", DefaultLogger: model.EvergreenLogSender}
+	require.NoError(t, projectRef.Insert())
+
+	now := time.Now()
+	addLogs := func(taskID string, execution int, lines ...string) {
+		taskLog := model.TaskLog{TaskId: taskID, Execution: execution, Timestamp: now}
+		for i, line := range lines {
+			taskLog.Messages = append(taskLog.Messages, apimodels.LogMessage{
+				Type:      apimodels.TaskLogPrefix,
+				Severity:  apimodels.LogInfoPrefix,
+				Message:   line,
+				Timestamp: now.Add(time.Duration(i) * time.Second),
+			})
+		}
+		taskLog.MessageCount = len(taskLog.Messages)
+		require.NoError(t, taskLog.Insert())
+	}
+
+	tasks := []task.Task{
+		{Id: "t1", Version: "v1", BuildId: "b1", Project: "project", DisplayName: "compile", BuildVariant: "ubuntu", Status: evergreen.TaskFailed, Execution: 1},
+		{Id: "t2", Version: "v1", BuildId: "b1", Project: "project", DisplayName: "test", BuildVariant: "ubuntu", Status: evergreen.TaskFailed},
+		{Id: "t3", Version: "v1", BuildId: "b2", Project: "project", DisplayName: "lint", BuildVariant: "windows", Status: evergreen.TaskUndispatched},
+		{Id: "t4", Version: "v2", BuildId: "b3", Project: "project", DisplayName: "compile", BuildVariant: "ubuntu", Status: evergreen.TaskFailed},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, tsk.Insert())
+	}
+	addLogs("t1", 0, "starting", "segfault in old execution", "done")
+	addLogs("t1", 1, "starting", "all good", "done")
+	addLogs("t2", 0, "starting", "segfault in main", "core dumped", "segfault in cleanup")
+	addLogs("t4", 0, "segfault in another version")
+
+	t.Run("Version", func(t *testing.T) {
+		result, err := SearchTaskLogs(ctx, TaskLogSearchOptions{
+			VersionID:    "v1",
+			Pattern:      regexp.MustCompile("segfault"),
+			LogType:      apimodels.AllTaskLevelLogs,
+			ContextLines: 1,
+			Limit:        10,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Matches, 2)
+		assert.Empty(t, result.NextKey)
+
+		assert.Equal(t, "t2", result.Matches[0].TaskID)
+		assert.Equal(t, "test", result.Matches[0].DisplayName)
+		assert.Equal(t, 2, result.Matches[0].LineNumber)
+		assert.Equal(t, "segfault in main", result.Matches[0].Line.Data)
+		require.Len(t, result.Matches[0].Before, 1)
+		assert.Equal(t, "starting", result.Matches[0].Before[0].Data)
+		require.Len(t, result.Matches[0].After, 1)
+		assert.Equal(t, "core dumped", result.Matches[0].After[0].Data)
+		assert.Equal(t, 4, result.Matches[1].LineNumber)
+	})
+	t.Run("AllExecutions", func(t *testing.T) {
+		result, err := SearchTaskLogs(ctx, TaskLogSearchOptions{
+			BuildID:       "b1",
+			Pattern:       regexp.MustCompile("segfault"),
+			LogType:       apimodels.TaskLogPrefix,
+			AllExecutions: true,
+			Limit:         10,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Matches, 3)
+		assert.Equal(t, "t1", result.Matches[0].TaskID)
+		assert.Equal(t, 0, result.Matches[0].Execution)
+		assert.Equal(t, "t2", result.Matches[1].TaskID)
+	})
+	t.Run("Paginates", func(t *testing.T) {
+		opts := TaskLogSearchOptions{
+			VersionID: "v1",
+			Pattern:   regexp.MustCompile("segfault"),
+			LogType:   apimodels.AllTaskLevelLogs,
+			Limit:     1,
+		}
+		found := []string{}
+		for i := 0; i < 5; i++ {
+			result, err := SearchTaskLogs(ctx, opts)
+			require.NoError(t, err)
+			for _, match := range result.Matches {
+				found = append(found, fmt.Sprintf("%s:%d", match.TaskID, match.LineNumber))
+			}
+			if result.NextKey == "" {
+				break
+			}
+			opts.StartAt = result.NextKey
+		}
+		assert.Equal(t, []string{"t2:2", "t2:4"}, found)
+	})
+	t.Run("InvalidPageKey", func(t *testing.T) {
+		_, err := SearchTaskLogs(ctx, TaskLogSearchOptions{
+			TaskID:  "t1",
+			Pattern: regexp.MustCompile("segfault"),
+			Limit:   10,
+			StartAt: "invalid",
+		})
+		assert.Error(t, err)
+	})
+}
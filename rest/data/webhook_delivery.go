@@ -0,0 +1,123 @@
+package data
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultWebhookDeliveriesLimit is the default number of deliveries
+	// returned from a webhook subscription's delivery log.
+	DefaultWebhookDeliveriesLimit = 20
+	// MaxWebhookDeliveriesLimit is the maximum number of deliveries returned
+	// from a webhook subscription's delivery log.
+	MaxWebhookDeliveriesLimit = 100
+)
+
+// GetWebhookDeliveries returns up to limit of the most recent deliveries of
+// the webhook subscription, from most to least recent.
+func GetWebhookDeliveries(u gimlet.User, subscriptionID string, limit int) ([]restModel.APIWebhookDelivery, error) {
+	if limit <= 0 || limit > MaxWebhookDeliveriesLimit {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Errorf("limit must be between 1 and %d", MaxWebhookDeliveriesLimit).Error(),
+		}
+	}
+	if _, err := findWebhookSubscription(u, subscriptionID, evergreen.ProjectSettingsView); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := notification.FindRecentWebhookDeliveries(subscriptionID, limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding deliveries for subscription '%s'", subscriptionID)
+	}
+	apiDeliveries := make([]restModel.APIWebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		apiDelivery := restModel.APIWebhookDelivery{}
+		apiDelivery.BuildFromService(delivery)
+		apiDeliveries = append(apiDeliveries, apiDelivery)
+	}
+	return apiDeliveries, nil
+}
+
+// ReplayWebhookDelivery sends a previous delivery of a webhook subscription
+// again and returns the new delivery.
+func ReplayWebhookDelivery(ctx context.Context, u gimlet.User, subscriptionID, deliveryID string) (*restModel.APIWebhookDelivery, error) {
+	delivery, err := notification.FindWebhookDeliveryByID(deliveryID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding webhook delivery '%s'", deliveryID)
+	}
+	if delivery == nil || delivery.SubscriptionID != subscriptionID {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    errors.Errorf("webhook delivery '%s' not found for subscription '%s'", deliveryID, subscriptionID).Error(),
+		}
+	}
+	sub, err := findWebhookSubscription(u, delivery.SubscriptionID, evergreen.ProjectSettingsEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	replayed, err := notification.ReplayWebhookDelivery(ctx, delivery, sub)
+	if err != nil {
+		return nil, errors.Wrapf(err, "replaying webhook delivery '%s'", deliveryID)
+	}
+	apiDelivery := &restModel.APIWebhookDelivery{}
+	apiDelivery.BuildFromService(*replayed)
+	return apiDelivery, nil
+}
+
+// findWebhookSubscription returns the webhook subscriber of the subscription,
+// checking that the user can access it. Users can access their own
+// subscriptions, and project subscriptions if they have the given level of
+// permission to the project's settings.
+func findWebhookSubscription(u gimlet.User, subscriptionID string, level evergreen.PermissionLevel) (*event.WebhookSubscriber, error) {
+	subscription, err := event.FindSubscriptionByID(subscriptionID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding subscription '%s'", subscriptionID)
+	}
+	if subscription == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    errors.Errorf("subscription '%s' not found", subscriptionID).Error(),
+		}
+	}
+
+	var authorized bool
+	switch subscription.OwnerType {
+	case event.OwnerTypePerson:
+		authorized = subscription.Owner == u.Username()
+	case event.OwnerTypeProject:
+		authorized = u.HasPermission(gimlet.PermissionOpts{
+			Resource:      subscription.Owner,
+			ResourceType:  evergreen.ProjectResourceType,
+			Permission:    evergreen.PermissionProjectSettings,
+			RequiredLevel: level.Value,
+		})
+	}
+	if !authorized {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message:    errors.Errorf("not authorized to access subscription '%s'", subscriptionID).Error(),
+		}
+	}
+
+	if subscription.Subscriber.Type != event.EvergreenWebhookSubscriberType {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Errorf("subscription '%s' is not a webhook subscription", subscriptionID).Error(),
+		}
+	}
+	sub, ok := subscription.Subscriber.Target.(*event.WebhookSubscriber)
+	if !ok {
+		return nil, errors.Errorf("subscription '%s' has invalid webhook subscriber of type %T", subscriptionID, subscription.Subscriber.Target)
+	}
+	return sub, nil
+}
@@ -12,90 +12,93 @@ import (
 
 func NewConfigModel() *APIAdminSettings {
 	return &APIAdminSettings{
-		Alerts:            &APIAlertsConfig{},
-		Amboy:             &APIAmboyConfig{},
-		Api:               &APIapiConfig{},
-		AuthConfig:        &APIAuthConfig{},
-		Cedar:             &APICedarConfig{},
-		CommitQueue:       &APICommitQueueConfig{},
-		ContainerPools:    &APIContainerPoolsConfig{},
-		Credentials:       map[string]string{},
-		DataPipes:         &APIDataPipesConfig{},
-		Expansions:        map[string]string{},
-		HostInit:          &APIHostInitConfig{},
-		HostJasper:        &APIHostJasperConfig{},
-		Jira:              &APIJiraConfig{},
-		JIRANotifications: &APIJIRANotificationsConfig{},
-		Keys:              map[string]string{},
-		LDAPRoleMap:       &APILDAPRoleMap{},
-		LoggerConfig:      &APILoggerConfig{},
-		NewRelic:          &APINewRelicConfig{},
-		Notify:            &APINotifyConfig{},
-		Plugins:           map[string]map[string]interface{}{},
-		PodLifecycle:      &APIPodLifecycleConfig{},
-		ProjectCreation:   &APIProjectCreationConfig{},
-		Providers:         &APICloudProviders{},
-		RepoTracker:       &APIRepoTrackerConfig{},
-		Scheduler:         &APISchedulerConfig{},
-		ServiceFlags:      &APIServiceFlags{},
-		Slack:             &APISlackConfig{},
-		Splunk:            &APISplunkConfig{},
-		Triggers:          &APITriggerConfig{},
-		Ui:                &APIUIConfig{},
-		Spawnhost:         &APISpawnHostConfig{},
-		Tracer:            &APITracerSettings{},
+		Alerts:                &APIAlertsConfig{},
+		Amboy:                 &APIAmboyConfig{},
+		Api:                   &APIapiConfig{},
+		AuthConfig:            &APIAuthConfig{},
+		Cedar:                 &APICedarConfig{},
+		CommitQueue:           &APICommitQueueConfig{},
+		ContainerPools:        &APIContainerPoolsConfig{},
+		Credentials:           map[string]string{},
+		DataPipes:             &APIDataPipesConfig{},
+		Expansions:            map[string]string{},
+		HostInit:              &APIHostInitConfig{},
+		HostJasper:            &APIHostJasperConfig{},
+		Jira:                  &APIJiraConfig{},
+		JIRANotifications:     &APIJIRANotificationsConfig{},
+		Keys:                  map[string]string{},
+		LDAPRoleMap:           &APILDAPRoleMap{},
+		LoggerConfig:          &APILoggerConfig{},
+		NewRelic:              &APINewRelicConfig{},
+		Notify:                &APINotifyConfig{},
+		Plugins:               map[string]map[string]interface{}{},
+		PodLifecycle:          &APIPodLifecycleConfig{},
+		ProjectCreation:       &APIProjectCreationConfig{},
+		ProjectVarsEncryption: &APIProjectVarsEncryptionConfig{},
+		Providers:             &APICloudProviders{},
+		RepoTracker:           &APIRepoTrackerConfig{},
+		Scheduler:             &APISchedulerConfig{},
+		ServiceFlags:          &APIServiceFlags{},
+		Slack:                 &APISlackConfig{},
+		Splunk:                &APISplunkConfig{},
+		Triggers:              &APITriggerConfig{},
+		Ui:                    &APIUIConfig{},
+		Spawnhost:             &APISpawnHostConfig{},
+		Tracer:                &APITracerSettings{},
 	}
 }
 
 // APIAdminSettings is the structure of a response to the admin route
 type APIAdminSettings struct {
-	Alerts              *APIAlertsConfig                  `json:"alerts,omitempty"`
-	Amboy               *APIAmboyConfig                   `json:"amboy,omitempty"`
-	Api                 *APIapiConfig                     `json:"api,omitempty"`
-	ApiUrl              *string                           `json:"api_url,omitempty"`
-	AWSInstanceRole     *string                           `json:"aws_instance_role,omitempty"`
-	AuthConfig          *APIAuthConfig                    `json:"auth,omitempty"`
-	Banner              *string                           `json:"banner,omitempty"`
-	BannerTheme         *string                           `json:"banner_theme,omitempty"`
-	Cedar               *APICedarConfig                   `json:"cedar,omitempty"`
-	ClientBinariesDir   *string                           `json:"client_binaries_dir,omitempty"`
-	CommitQueue         *APICommitQueueConfig             `json:"commit_queue,omitempty"`
-	ConfigDir           *string                           `json:"configdir,omitempty"`
-	ContainerPools      *APIContainerPoolsConfig          `json:"container_pools,omitempty"`
-	Credentials         map[string]string                 `json:"credentials,omitempty"`
-	DomainName          *string                           `json:"domain_name,omitempty"`
-	DataPipes           *APIDataPipesConfig               `json:"data_pipes,omitempty"`
-	Expansions          map[string]string                 `json:"expansions,omitempty"`
-	GithubPRCreatorOrg  *string                           `json:"github_pr_creator_org,omitempty"`
-	GithubOrgs          []string                          `json:"github_orgs,omitempty"`
-	DisabledGQLQueries  []string                          `json:"disabled_gql_queries"`
-	HostInit            *APIHostInitConfig                `json:"hostinit,omitempty"`
-	HostJasper          *APIHostJasperConfig              `json:"host_jasper,omitempty"`
-	Jira                *APIJiraConfig                    `json:"jira,omitempty"`
-	JIRANotifications   *APIJIRANotificationsConfig       `json:"jira_notifications,omitempty"`
-	Keys                map[string]string                 `json:"keys,omitempty"`
-	LDAPRoleMap         *APILDAPRoleMap                   `json:"ldap_role_map,omitempty"`
-	LoggerConfig        *APILoggerConfig                  `json:"logger_config,omitempty"`
-	LogPath             *string                           `json:"log_path,omitempty"`
-	NewRelic            *APINewRelicConfig                `json:"newrelic,omitempty"`
-	Notify              *APINotifyConfig                  `json:"notify,omitempty"`
-	Plugins             map[string]map[string]interface{} `json:"plugins,omitempty"`
-	PodLifecycle        *APIPodLifecycleConfig            `json:"pod_lifecycle,omitempty"`
-	PprofPort           *string                           `json:"pprof_port,omitempty"`
-	ProjectCreation     *APIProjectCreationConfig         `json:"project_creation,omitempty"`
-	Providers           *APICloudProviders                `json:"providers,omitempty"`
-	RepoTracker         *APIRepoTrackerConfig             `json:"repotracker,omitempty"`
-	Scheduler           *APISchedulerConfig               `json:"scheduler,omitempty"`
-	ServiceFlags        *APIServiceFlags                  `json:"service_flags,omitempty"`
-	Slack               *APISlackConfig                   `json:"slack,omitempty"`
-	SSHKeyDirectory     *string                           `json:"ssh_key_directory,omitempty"`
-	SSHKeyPairs         []APISSHKeyPair                   `json:"ssh_key_pairs,omitempty"`
-	Splunk              *APISplunkConfig                  `json:"splunk,omitempty"`
-	Triggers            *APITriggerConfig                 `json:"triggers,omitempty"`
-	Ui                  *APIUIConfig                      `json:"ui,omitempty"`
-	Spawnhost           *APISpawnHostConfig               `json:"spawnhost,omitempty"`
-	Tracer              *APITracerSettings                `json:"tracer,omitempty"`
-	ShutdownWaitSeconds *int                              `json:"shutdown_wait_seconds,omitempty"`
+	Alerts                *APIAlertsConfig                  `json:"alerts,omitempty"`
+	Amboy                 *APIAmboyConfig                   `json:"amboy,omitempty"`
+	Api                   *APIapiConfig                     `json:"api,omitempty"`
+	ApiUrl                *string                           `json:"api_url,omitempty"`
+	AWSInstanceRole       *string                           `json:"aws_instance_role,omitempty"`
+	AuthConfig            *APIAuthConfig                    `json:"auth,omitempty"`
+	Banner                *string                           `json:"banner,omitempty"`
+	BannerTheme           *string                           `json:"banner_theme,omitempty"`
+	Cedar                 *APICedarConfig                   `json:"cedar,omitempty"`
+	ClientBinariesDir     *string                           `json:"client_binaries_dir,omitempty"`
+	CommitQueue           *APICommitQueueConfig             `json:"commit_queue,omitempty"`
+	ConfigDir             *string                           `json:"configdir,omitempty"`
+	ContainerPools        *APIContainerPoolsConfig          `json:"container_pools,omitempty"`
+	Credentials           map[string]string                 `json:"credentials,omitempty"`
+	DomainName            *string                           `json:"domain_name,omitempty"`
+	DataPipes             *APIDataPipesConfig               `json:"data_pipes,omitempty"`
+	Expansions            map[string]string                 `json:"expansions,omitempty"`
+	GithubPRCreatorOrg    *string                           `json:"github_pr_creator_org,omitempty"`
+	GithubOrgs            []string                          `json:"github_orgs,omitempty"`
+	GitLabURL             *string                           `json:"gitlab_url,omitempty"`
+	DisabledGQLQueries    []string                          `json:"disabled_gql_queries"`
+	HostInit              *APIHostInitConfig                `json:"hostinit,omitempty"`
+	HostJasper            *APIHostJasperConfig              `json:"host_jasper,omitempty"`
+	Jira                  *APIJiraConfig                    `json:"jira,omitempty"`
+	JIRANotifications     *APIJIRANotificationsConfig       `json:"jira_notifications,omitempty"`
+	Keys                  map[string]string                 `json:"keys,omitempty"`
+	LDAPRoleMap           *APILDAPRoleMap                   `json:"ldap_role_map,omitempty"`
+	LoggerConfig          *APILoggerConfig                  `json:"logger_config,omitempty"`
+	LogPath               *string                           `json:"log_path,omitempty"`
+	NewRelic              *APINewRelicConfig                `json:"newrelic,omitempty"`
+	Notify                *APINotifyConfig                  `json:"notify,omitempty"`
+	Plugins               map[string]map[string]interface{} `json:"plugins,omitempty"`
+	PodLifecycle          *APIPodLifecycleConfig            `json:"pod_lifecycle,omitempty"`
+	PprofPort             *string                           `json:"pprof_port,omitempty"`
+	ProjectCreation       *APIProjectCreationConfig         `json:"project_creation,omitempty"`
+	ProjectVarsEncryption *APIProjectVarsEncryptionConfig   `json:"project_vars_encryption,omitempty"`
+	Providers             *APICloudProviders                `json:"providers,omitempty"`
+	RepoTracker           *APIRepoTrackerConfig             `json:"repotracker,omitempty"`
+	Scheduler             *APISchedulerConfig               `json:"scheduler,omitempty"`
+	ServiceFlags          *APIServiceFlags                  `json:"service_flags,omitempty"`
+	Slack                 *APISlackConfig                   `json:"slack,omitempty"`
+	SSHKeyDirectory       *string                           `json:"ssh_key_directory,omitempty"`
+	SSHKeyPairs           []APISSHKeyPair                   `json:"ssh_key_pairs,omitempty"`
+	Splunk                *APISplunkConfig                  `json:"splunk,omitempty"`
+	Triggers              *APITriggerConfig                 `json:"triggers,omitempty"`
+	Ui                    *APIUIConfig                      `json:"ui,omitempty"`
+	Spawnhost             *APISpawnHostConfig               `json:"spawnhost,omitempty"`
+	Tracer                *APITracerSettings                `json:"tracer,omitempty"`
+	ShutdownWaitSeconds   *int                              `json:"shutdown_wait_seconds,omitempty"`
 }
 
 // BuildFromService builds a model from the service layer
@@ -134,6 +137,7 @@ func (as *APIAdminSettings) BuildFromService(h interface{}) error {
 		as.ConfigDir = &v.ConfigDir
 		as.DomainName = utility.ToStringPtr(v.DomainName)
 		as.GithubPRCreatorOrg = &v.GithubPRCreatorOrg
+		as.GitLabURL = utility.ToStringPtr(v.GitLabURL)
 		as.LogPath = &v.LogPath
 		as.Plugins = v.Plugins
 		as.PprofPort = &v.PprofPort
@@ -221,6 +225,7 @@ func (as *APIAdminSettings) ToService() (interface{}, error) {
 	if as.GithubPRCreatorOrg != nil {
 		settings.GithubPRCreatorOrg = *as.GithubPRCreatorOrg
 	}
+	settings.GitLabURL = utility.FromStringPtr(as.GitLabURL)
 	if as.LogPath != nil {
 		settings.LogPath = *as.LogPath
 	}
@@ -1340,6 +1345,7 @@ type APICloudProviders struct {
 	AWS       *APIAWSConfig       `json:"aws"`
 	Docker    *APIDockerConfig    `json:"docker"`
 	GCE       *APIGCEConfig       `json:"gce"`
+	Libvirt   *APILibvirtConfig   `json:"libvirt"`
 	OpenStack *APIOpenStackConfig `json:"openstack"`
 	VSphere   *APIVSphereConfig   `json:"vsphere"`
 }
@@ -1350,6 +1356,7 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		a.AWS = &APIAWSConfig{}
 		a.Docker = &APIDockerConfig{}
 		a.GCE = &APIGCEConfig{}
+		a.Libvirt = &APILibvirtConfig{}
 		a.OpenStack = &APIOpenStackConfig{}
 		a.VSphere = &APIVSphereConfig{}
 		if err := a.AWS.BuildFromService(v.AWS); err != nil {
@@ -1361,6 +1368,9 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		if err := a.GCE.BuildFromService(v.GCE); err != nil {
 			return err
 		}
+		if err := a.Libvirt.BuildFromService(v.Libvirt); err != nil {
+			return err
+		}
 		if err := a.OpenStack.BuildFromService(v.OpenStack); err != nil {
 			return err
 		}
@@ -1386,6 +1396,10 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	libvirt, err := a.Libvirt.ToService()
+	if err != nil {
+		return nil, err
+	}
 	openstack, err := a.OpenStack.ToService()
 	if err != nil {
 		return nil, err
@@ -1398,6 +1412,7 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 		AWS:       aws.(evergreen.AWSConfig),
 		Docker:    docker.(evergreen.DockerConfig),
 		GCE:       gce.(evergreen.GCEConfig),
+		Libvirt:   libvirt.(evergreen.LibvirtConfig),
 		OpenStack: openstack.(evergreen.OpenStackConfig),
 		VSphere:   vsphere.(evergreen.VSphereConfig),
 	}, nil
@@ -1409,6 +1424,8 @@ type APICommitQueueConfig struct {
 	CommitterEmail             *string `json:"committer_email"`
 	BatchSize                  int     `json:"batch_size"`
 	MaxSystemFailedTaskRetries int     `json:"max_system_failed_task_retries"`
+	BisectBatches              bool    `json:"bisect_batches"`
+	SpeculativeDepth           int     `json:"speculative_depth"`
 }
 
 func (a *APICommitQueueConfig) BuildFromService(h interface{}) error {
@@ -1418,6 +1435,8 @@ func (a *APICommitQueueConfig) BuildFromService(h interface{}) error {
 		a.CommitterEmail = utility.ToStringPtr(v.CommitterEmail)
 		a.BatchSize = v.BatchSize
 		a.MaxSystemFailedTaskRetries = v.MaxSystemFailedTaskRetries
+		a.BisectBatches = v.BisectBatches
+		a.SpeculativeDepth = v.SpeculativeDepth
 
 		return nil
 	}
@@ -1432,6 +1451,8 @@ func (a *APICommitQueueConfig) ToService() (interface{}, error) {
 		CommitterEmail:             utility.FromStringPtr(a.CommitterEmail),
 		BatchSize:                  a.BatchSize,
 		MaxSystemFailedTaskRetries: a.MaxSystemFailedTaskRetries,
+		BisectBatches:              a.BisectBatches,
+		SpeculativeDepth:           a.SpeculativeDepth,
 	}, nil
 }
 
@@ -2088,6 +2109,48 @@ func (a *APIOpenStackConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APILibvirtConfig struct {
+	Hypervisors []APILibvirtHypervisor `json:"hypervisors"`
+}
+
+func (a *APILibvirtConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.LibvirtConfig:
+		a.Hypervisors = nil
+		for _, hv := range v.Hypervisors {
+			a.Hypervisors = append(a.Hypervisors, APILibvirtHypervisor{
+				Name:       utility.ToStringPtr(hv.Name),
+				URI:        utility.ToStringPtr(hv.URI),
+				VolumePool: utility.ToStringPtr(hv.VolumePool),
+			})
+		}
+	default:
+		return errors.Errorf("programmatic error: expected libvirt config but got type %T", h)
+	}
+	return nil
+}
+
+func (a *APILibvirtConfig) ToService() (interface{}, error) {
+	if a == nil {
+		return evergreen.LibvirtConfig{}, nil
+	}
+	config := evergreen.LibvirtConfig{}
+	for _, hv := range a.Hypervisors {
+		config.Hypervisors = append(config.Hypervisors, evergreen.LibvirtHypervisor{
+			Name:       utility.FromStringPtr(hv.Name),
+			URI:        utility.FromStringPtr(hv.URI),
+			VolumePool: utility.FromStringPtr(hv.VolumePool),
+		})
+	}
+	return config, nil
+}
+
+type APILibvirtHypervisor struct {
+	Name       *string `json:"name"`
+	URI        *string `json:"uri"`
+	VolumePool *string `json:"volume_pool"`
+}
+
 type APIVSphereConfig struct {
 	Host     *string `json:"host"`
 	Username *string `json:"username"`
@@ -2228,6 +2291,8 @@ type APIServiceFlags struct {
 	CacheStatsJobDisabled           bool `json:"cache_stats_job_disabled"`
 	CacheStatsEndpointDisabled      bool `json:"cache_stats_endpoint_disabled"`
 	TaskReliabilityDisabled         bool `json:"task_reliability_disabled"`
+	TestFlakinessDisabled           bool `json:"test_flakiness_disabled"`
+	PredictiveTaskSelectionDisabled bool `json:"predictive_task_selection_disabled"`
 	CommitQueueDisabled             bool `json:"commit_queue_disabled"`
 	HostAllocatorDisabled           bool `json:"host_allocator_disabled"`
 	PodAllocatorDisabled            bool `json:"pod_allocator_disabled"`
@@ -2517,6 +2582,8 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 		as.CacheStatsJobDisabled = v.CacheStatsJobDisabled
 		as.CacheStatsEndpointDisabled = v.CacheStatsEndpointDisabled
 		as.TaskReliabilityDisabled = v.TaskReliabilityDisabled
+		as.TestFlakinessDisabled = v.TestFlakinessDisabled
+		as.PredictiveTaskSelectionDisabled = v.PredictiveTaskSelectionDisabled
 		as.CommitQueueDisabled = v.CommitQueueDisabled
 		as.HostAllocatorDisabled = v.HostAllocatorDisabled
 		as.PodAllocatorDisabled = v.PodAllocatorDisabled
@@ -2559,6 +2626,8 @@ func (as *APIServiceFlags) ToService() (interface{}, error) {
 		CacheStatsJobDisabled:           as.CacheStatsJobDisabled,
 		CacheStatsEndpointDisabled:      as.CacheStatsEndpointDisabled,
 		TaskReliabilityDisabled:         as.TaskReliabilityDisabled,
+		TestFlakinessDisabled:           as.TestFlakinessDisabled,
+		PredictiveTaskSelectionDisabled: as.PredictiveTaskSelectionDisabled,
 		CommitQueueDisabled:             as.CommitQueueDisabled,
 		HostAllocatorDisabled:           as.HostAllocatorDisabled,
 		PodAllocatorDisabled:            as.PodAllocatorDisabled,
@@ -2765,6 +2834,7 @@ type APISpawnHostConfig struct {
 	UnexpirableHostsPerUser   *int `json:"unexpirable_hosts_per_user"`
 	UnexpirableVolumesPerUser *int `json:"unexpirable_volumes_per_user"`
 	SpawnHostsPerUser         *int `json:"spawn_hosts_per_user"`
+	SnapshotsPerUser          *int `json:"snapshots_per_user"`
 }
 
 func (c *APISpawnHostConfig) BuildFromService(h interface{}) error {
@@ -2773,6 +2843,7 @@ func (c *APISpawnHostConfig) BuildFromService(h interface{}) error {
 		c.UnexpirableHostsPerUser = &v.UnexpirableHostsPerUser
 		c.UnexpirableVolumesPerUser = &v.UnexpirableVolumesPerUser
 		c.SpawnHostsPerUser = &v.SpawnHostsPerUser
+		c.SnapshotsPerUser = &v.SnapshotsPerUser
 	default:
 		return errors.Errorf("programmatic error: expected spawn host config but got type %T", h)
 	}
@@ -2784,6 +2855,7 @@ func (c *APISpawnHostConfig) ToService() (interface{}, error) {
 		UnexpirableHostsPerUser:   evergreen.DefaultUnexpirableHostsPerUser,
 		UnexpirableVolumesPerUser: evergreen.DefaultUnexpirableVolumesPerUser,
 		SpawnHostsPerUser:         evergreen.DefaultMaxSpawnHostsPerUser,
+		SnapshotsPerUser:          evergreen.DefaultMaxSnapshotsPerUser,
 	}
 	if c.UnexpirableHostsPerUser != nil {
 		config.UnexpirableHostsPerUser = *c.UnexpirableHostsPerUser
@@ -2794,6 +2866,9 @@ func (c *APISpawnHostConfig) ToService() (interface{}, error) {
 	if c.SpawnHostsPerUser != nil {
 		config.SpawnHostsPerUser = *c.SpawnHostsPerUser
 	}
+	if c.SnapshotsPerUser != nil {
+		config.SnapshotsPerUser = *c.SnapshotsPerUser
+	}
 
 	return config, nil
 }
@@ -2823,6 +2898,35 @@ func (c *APITracerSettings) ToService() (interface{}, error) {
 	return config, nil
 }
 
+type APIProjectVarsEncryptionConfig struct {
+	Provider    *string `json:"provider"`
+	KeyringPath *string `json:"keyring_path"`
+	KMSKeyID    *string `json:"kms_key_id"`
+	KMSRegion   *string `json:"kms_region"`
+}
+
+func (c *APIProjectVarsEncryptionConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.ProjectVarsEncryptionConfig:
+		c.Provider = utility.ToStringPtr(v.Provider)
+		c.KeyringPath = utility.ToStringPtr(v.KeyringPath)
+		c.KMSKeyID = utility.ToStringPtr(v.KMSKeyID)
+		c.KMSRegion = utility.ToStringPtr(v.KMSRegion)
+	default:
+		return errors.Errorf("programmatic error: expected project vars encryption config but got type %T", h)
+	}
+	return nil
+}
+
+func (c *APIProjectVarsEncryptionConfig) ToService() (interface{}, error) {
+	return evergreen.ProjectVarsEncryptionConfig{
+		Provider:    utility.FromStringPtr(c.Provider),
+		KeyringPath: utility.FromStringPtr(c.KeyringPath),
+		KMSKeyID:    utility.FromStringPtr(c.KMSKeyID),
+		KMSRegion:   utility.FromStringPtr(c.KMSRegion),
+	}, nil
+}
+
 type APIDataPipesConfig struct {
 	Host         *string `json:"host"`
 	Region       *string `json:"region"`
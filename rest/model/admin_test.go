@@ -208,6 +208,8 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, utility.FromStringPtr(apiSettings.Providers.GCE.ClientEmail))
 	assert.EqualValues(testSettings.Providers.OpenStack.IdentityEndpoint, utility.FromStringPtr(apiSettings.Providers.OpenStack.IdentityEndpoint))
 	assert.EqualValues(testSettings.Providers.VSphere.Host, utility.FromStringPtr(apiSettings.Providers.VSphere.Host))
+	require.Len(apiSettings.Providers.Libvirt.Hypervisors, len(testSettings.Providers.Libvirt.Hypervisors))
+	assert.EqualValues(testSettings.Providers.Libvirt.Hypervisors[0].URI, utility.FromStringPtr(apiSettings.Providers.Libvirt.Hypervisors[0].URI))
 	assert.EqualValues(testSettings.RepoTracker.MaxConcurrentRequests, apiSettings.RepoTracker.MaxConcurrentRequests)
 	assert.EqualValues(testSettings.Scheduler.TaskFinder, utility.FromStringPtr(apiSettings.Scheduler.TaskFinder))
 	assert.EqualValues(testSettings.ServiceFlags.HostInitDisabled, apiSettings.ServiceFlags.HostInitDisabled)
@@ -224,8 +226,11 @@ func TestModelConversion(t *testing.T) {
 	assert.Equal(testSettings.Spawnhost.SpawnHostsPerUser, *apiSettings.Spawnhost.SpawnHostsPerUser)
 	assert.Equal(testSettings.Spawnhost.UnexpirableHostsPerUser, *apiSettings.Spawnhost.UnexpirableHostsPerUser)
 	assert.Equal(testSettings.Spawnhost.UnexpirableVolumesPerUser, *apiSettings.Spawnhost.UnexpirableVolumesPerUser)
+	assert.Equal(testSettings.Spawnhost.SnapshotsPerUser, *apiSettings.Spawnhost.SnapshotsPerUser)
 	assert.Equal(testSettings.Tracer.Enabled, *apiSettings.Tracer.Enabled)
 	assert.Equal(testSettings.Tracer.CollectorEndpoint, *apiSettings.Tracer.CollectorEndpoint)
+	assert.Equal(testSettings.ProjectVarsEncryption.Provider, utility.FromStringPtr(apiSettings.ProjectVarsEncryption.Provider))
+	assert.Equal(testSettings.ProjectVarsEncryption.KeyringPath, utility.FromStringPtr(apiSettings.ProjectVarsEncryption.KeyringPath))
 
 	// test converting from the API model back to a DB model
 	dbInterface, err := apiSettings.ToService()
@@ -302,6 +307,7 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, dbSettings.Providers.GCE.ClientEmail)
 	assert.EqualValues(testSettings.Providers.OpenStack.IdentityEndpoint, dbSettings.Providers.OpenStack.IdentityEndpoint)
 	assert.EqualValues(testSettings.Providers.VSphere.Host, dbSettings.Providers.VSphere.Host)
+	assert.EqualValues(testSettings.Providers.Libvirt, dbSettings.Providers.Libvirt)
 	assert.EqualValues(testSettings.RepoTracker.MaxConcurrentRequests, dbSettings.RepoTracker.MaxConcurrentRequests)
 	assert.EqualValues(testSettings.Scheduler.TaskFinder, dbSettings.Scheduler.TaskFinder)
 	assert.EqualValues(testSettings.ServiceFlags.HostInitDisabled, dbSettings.ServiceFlags.HostInitDisabled)
@@ -325,8 +331,10 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Spawnhost.SpawnHostsPerUser, dbSettings.Spawnhost.SpawnHostsPerUser)
 	assert.EqualValues(testSettings.Spawnhost.UnexpirableHostsPerUser, dbSettings.Spawnhost.UnexpirableHostsPerUser)
 	assert.EqualValues(testSettings.Spawnhost.UnexpirableVolumesPerUser, dbSettings.Spawnhost.UnexpirableVolumesPerUser)
+	assert.EqualValues(testSettings.Spawnhost.SnapshotsPerUser, dbSettings.Spawnhost.SnapshotsPerUser)
 	assert.EqualValues(testSettings.Tracer.Enabled, dbSettings.Tracer.Enabled)
 	assert.EqualValues(testSettings.Tracer.CollectorEndpoint, dbSettings.Tracer.CollectorEndpoint)
+	assert.EqualValues(testSettings.ProjectVarsEncryption, dbSettings.ProjectVarsEncryption)
 }
 
 func TestRestart(t *testing.T) {
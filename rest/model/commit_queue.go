@@ -29,6 +29,22 @@ type APICommitQueueItem struct {
 	MessageOverride      *string     `json:"message_override"`
 	Source               *string     `json:"source"`
 	QueueLengthAtEnqueue *int        `json:"queue_length_at_enqueue"`
+	// Lane is the name of the lane that the item is processed in. It's empty
+	// if the item is in the shared lane.
+	Lane *string `json:"lane"`
+	// BisectionStatus is the status of the item in the bisection of the
+	// batch that it's being tested in, if any.
+	BisectionStatus  *string            `json:"bisection_status"`
+	BisectionHistory []APIBisectionStep `json:"bisection_history"`
+}
+
+// APIBisectionStep is a test of a batch of commit queue items.
+type APIBisectionStep struct {
+	Version    *string    `json:"version"`
+	Items      []string   `json:"items"`
+	Status     *string    `json:"status"`
+	StartTime  *time.Time `json:"start_time"`
+	FinishTime *time.Time `json:"finish_time"`
 }
 
 type APICommitQueuePosition struct {
@@ -56,10 +72,26 @@ func (item *APICommitQueueItem) BuildFromService(cqItemService commitqueue.Commi
 	item.Source = utility.ToStringPtr(cqItemService.Source)
 	item.PatchId = utility.ToStringPtr(cqItemService.PatchId)
 	item.QueueLengthAtEnqueue = utility.ToIntPtr(cqItemService.QueueLengthAtEnqueue)
+	item.Lane = utility.ToStringPtr(cqItemService.Lane)
+
+	item.BisectionStatus = utility.ToStringPtr(cqItemService.BisectionStatus)
 
 	for _, module := range cqItemService.Modules {
 		item.Modules = append(item.Modules, *APIModuleBuildFromService(module))
 	}
+	for _, step := range cqItemService.BisectionHistory {
+		apiStep := APIBisectionStep{}
+		apiStep.BuildFromService(step)
+		item.BisectionHistory = append(item.BisectionHistory, apiStep)
+	}
+}
+
+func (s *APIBisectionStep) BuildFromService(step commitqueue.BisectionStep) {
+	s.Version = utility.ToStringPtr(step.Version)
+	s.Items = step.Items
+	s.Status = utility.ToStringPtr(step.Status)
+	s.StartTime = ToTimePtr(step.StartTime)
+	s.FinishTime = ToTimePtr(step.FinishTime)
 }
 
 func (item *APICommitQueueItem) ToService() commitqueue.CommitQueueItem {
@@ -69,6 +101,7 @@ func (item *APICommitQueueItem) ToService() commitqueue.CommitQueueItem {
 		MessageOverride: utility.FromStringPtr(item.MessageOverride),
 		Source:          utility.FromStringPtr(item.Source),
 		PatchId:         utility.FromStringPtr(item.PatchId),
+		Lane:            utility.FromStringPtr(item.Lane),
 	}
 	for _, module := range item.Modules {
 		serviceItem.Modules = append(serviceItem.Modules, *APIModuleToService(module))
@@ -23,7 +23,15 @@ func TestCommitQueueBuildFromService(t *testing.T) {
 				},
 			},
 			commitqueue.CommitQueueItem{
-				Issue: "2",
+				Issue:           "2",
+				BisectionStatus: commitqueue.BisectionStatusFailed,
+				BisectionHistory: []commitqueue.BisectionStep{
+					{
+						Version: "v3",
+						Items:   []string{"2", "3"},
+						Status:  commitqueue.BisectionStatusFailed,
+					},
+				},
 			},
 			commitqueue.CommitQueueItem{
 				Issue: "3",
@@ -40,6 +48,15 @@ func TestCommitQueueBuildFromService(t *testing.T) {
 	}
 	assert.Equal(cq.Queue[0].Modules[0].Module, utility.FromStringPtr(cqAPI.Queue[0].Modules[0].Module))
 	assert.Equal(cq.Queue[0].Modules[0].Issue, utility.FromStringPtr(cqAPI.Queue[0].Modules[0].Issue))
+	assert.Empty(utility.FromStringPtr(cqAPI.Queue[0].BisectionStatus))
+	assert.Empty(cqAPI.Queue[0].BisectionHistory)
+	assert.Equal(commitqueue.BisectionStatusFailed, utility.FromStringPtr(cqAPI.Queue[1].BisectionStatus))
+	if assert.Len(cqAPI.Queue[1].BisectionHistory, 1) {
+		step := cqAPI.Queue[1].BisectionHistory[0]
+		assert.Equal("v3", utility.FromStringPtr(step.Version))
+		assert.Equal([]string{"2", "3"}, step.Items)
+		assert.Equal(commitqueue.BisectionStatusFailed, utility.FromStringPtr(step.Status))
+	}
 }
 
 func TestParseGitHubComment(t *testing.T) {
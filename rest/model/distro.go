@@ -12,14 +12,27 @@ import (
 // APIPlannerSettings is the model to be returned by the API whenever distro.PlannerSettings are fetched
 
 type APIPlannerSettings struct {
-	Version                   *string     `json:"version"`
-	TargetTime                APIDuration `json:"target_time"`
-	GroupVersions             *bool       `json:"group_versions"`
-	PatchFactor               int64       `json:"patch_factor"`
-	PatchTimeInQueueFactor    int64       `json:"patch_time_in_queue_factor"`
-	MainlineTimeInQueueFactor int64       `json:"mainline_time_in_queue_factor"`
-	ExpectedRuntimeFactor     int64       `json:"expected_runtime_factor"`
-	GenerateTaskFactor        int64       `json:"generate_task_factor"`
+	Version                   *string           `json:"version"`
+	TargetTime                APIDuration       `json:"target_time"`
+	GroupVersions             *bool             `json:"group_versions"`
+	PatchFactor               int64             `json:"patch_factor"`
+	PatchTimeInQueueFactor    int64             `json:"patch_time_in_queue_factor"`
+	CommitQueueFactor         int64             `json:"commit_queue_factor"`
+	MainlineTimeInQueueFactor int64             `json:"mainline_time_in_queue_factor"`
+	ExpectedRuntimeFactor     int64             `json:"expected_runtime_factor"`
+	GenerateTaskFactor        int64             `json:"generate_task_factor"`
+	StepbackTaskFactor        int64             `json:"stepback_task_factor"`
+	FairShare                 *bool             `json:"fair_share"`
+	FairShareFactor           int64             `json:"fair_share_factor"`
+	FairShareWindow           APIDuration       `json:"fair_share_window"`
+	ProjectShares             []APIProjectShare `json:"project_shares"`
+}
+
+// APIProjectShare is the share of a distro's host time that a project is
+// entitled to when fair share planning is enabled.
+type APIProjectShare struct {
+	Project *string `json:"project"`
+	Share   float64 `json:"share"`
 }
 
 // BuildFromService converts from service level distro.PlannerSetting to an APIPlannerSettings
@@ -34,8 +47,20 @@ func (s *APIPlannerSettings) BuildFromService(settings distro.PlannerSettings) {
 	s.PatchFactor = settings.PatchFactor
 	s.ExpectedRuntimeFactor = settings.ExpectedRuntimeFactor
 	s.PatchTimeInQueueFactor = settings.PatchTimeInQueueFactor
+	s.CommitQueueFactor = settings.CommitQueueFactor
 	s.MainlineTimeInQueueFactor = settings.MainlineTimeInQueueFactor
 	s.GenerateTaskFactor = settings.GenerateTaskFactor
+	s.StepbackTaskFactor = settings.StepbackTaskFactor
+	s.FairShare = settings.FairShare
+	s.FairShareFactor = settings.FairShareFactor
+	s.FairShareWindow = NewAPIDuration(settings.FairShareWindow)
+	s.ProjectShares = nil
+	for _, ps := range settings.ProjectShares {
+		s.ProjectShares = append(s.ProjectShares, APIProjectShare{
+			Project: utility.ToStringPtr(ps.Project),
+			Share:   ps.Share,
+		})
+	}
 }
 
 // ToService returns a service layer distro.PlannerSettings using the data from APIPlannerSettings
@@ -49,9 +74,20 @@ func (s *APIPlannerSettings) ToService() distro.PlannerSettings {
 	settings.GroupVersions = s.GroupVersions
 	settings.PatchFactor = s.PatchFactor
 	settings.PatchTimeInQueueFactor = s.PatchTimeInQueueFactor
+	settings.CommitQueueFactor = s.CommitQueueFactor
 	settings.MainlineTimeInQueueFactor = s.MainlineTimeInQueueFactor
 	settings.ExpectedRuntimeFactor = s.ExpectedRuntimeFactor
 	settings.GenerateTaskFactor = s.GenerateTaskFactor
+	settings.StepbackTaskFactor = s.StepbackTaskFactor
+	settings.FairShare = s.FairShare
+	settings.FairShareFactor = s.FairShareFactor
+	settings.FairShareWindow = s.FairShareWindow.ToDuration()
+	for _, ps := range s.ProjectShares {
+		settings.ProjectShares = append(settings.ProjectShares, distro.ProjectShare{
+			Project: utility.FromStringPtr(ps.Project),
+			Share:   ps.Share,
+		})
+	}
 
 	return settings
 }
@@ -57,6 +57,7 @@ type HostRequestOptions struct {
 	IsCluster             bool       `json:"is_cluster" yaml:"is_cluster"`
 	HomeVolumeSize        int        `json:"home_volume_size" yaml:"home_volume_size"`
 	HomeVolumeID          string     `json:"home_volume_id" yaml:"home_volume_id"`
+	SnapshotID            string     `json:"snapshot_id" yaml:"snapshot_id"`
 	Expiration            *time.Time `json:"expiration" yaml:"expiration"`
 }
 
@@ -230,6 +231,72 @@ func (apiVolume *APIVolume) ToService() (host.Volume, error) {
 	}, nil
 }
 
+// APISpawnHostSnapshot is the model to be returned by the API whenever spawn
+// host snapshots are fetched.
+type APISpawnHostSnapshot struct {
+	ID                   *string    `json:"snapshot_id"`
+	DisplayName          *string    `json:"display_name"`
+	CreatedBy            *string    `json:"created_by"`
+	SourceHostID         *string    `json:"source_host_id"`
+	DistroID             *string    `json:"distro"`
+	Provider             *string    `json:"provider"`
+	Region               *string    `json:"region"`
+	InstanceType         *string    `json:"instance_type"`
+	IsVirtualWorkstation bool       `json:"is_virtual_workstation"`
+	HomeVolumeSize       int        `json:"home_volume_size"`
+	Status               *string    `json:"status"`
+	CreationTime         *time.Time `json:"creation_time"`
+	Expiration           *time.Time `json:"expiration"`
+}
+
+// SnapshotPostRequest is the request body to create a spawn host snapshot.
+type SnapshotPostRequest struct {
+	DisplayName string `json:"display_name"`
+}
+
+func (apiSnapshot *APISpawnHostSnapshot) BuildFromService(s host.Snapshot) {
+	apiSnapshot.ID = utility.ToStringPtr(s.ID)
+	apiSnapshot.DisplayName = utility.ToStringPtr(s.DisplayName)
+	apiSnapshot.CreatedBy = utility.ToStringPtr(s.CreatedBy)
+	apiSnapshot.SourceHostID = utility.ToStringPtr(s.SourceHostID)
+	apiSnapshot.DistroID = utility.ToStringPtr(s.DistroID)
+	apiSnapshot.Provider = utility.ToStringPtr(s.Provider)
+	apiSnapshot.Region = utility.ToStringPtr(s.Region)
+	apiSnapshot.InstanceType = utility.ToStringPtr(s.InstanceType)
+	apiSnapshot.IsVirtualWorkstation = s.IsVirtualWorkstation
+	apiSnapshot.HomeVolumeSize = s.HomeVolumeSize
+	apiSnapshot.Status = utility.ToStringPtr(s.Status)
+	apiSnapshot.CreationTime = ToTimePtr(s.CreationTime)
+	apiSnapshot.Expiration = ToTimePtr(s.Expiration)
+}
+
+func (apiSnapshot *APISpawnHostSnapshot) ToService() (host.Snapshot, error) {
+	creationTime, err := FromTimePtr(apiSnapshot.CreationTime)
+	if err != nil {
+		return host.Snapshot{}, errors.Wrap(err, "getting creation time")
+	}
+	expiration, err := FromTimePtr(apiSnapshot.Expiration)
+	if err != nil {
+		return host.Snapshot{}, errors.Wrap(err, "getting expiration time")
+	}
+
+	return host.Snapshot{
+		ID:                   utility.FromStringPtr(apiSnapshot.ID),
+		DisplayName:          utility.FromStringPtr(apiSnapshot.DisplayName),
+		CreatedBy:            utility.FromStringPtr(apiSnapshot.CreatedBy),
+		SourceHostID:         utility.FromStringPtr(apiSnapshot.SourceHostID),
+		DistroID:             utility.FromStringPtr(apiSnapshot.DistroID),
+		Provider:             utility.FromStringPtr(apiSnapshot.Provider),
+		Region:               utility.FromStringPtr(apiSnapshot.Region),
+		InstanceType:         utility.FromStringPtr(apiSnapshot.InstanceType),
+		IsVirtualWorkstation: apiSnapshot.IsVirtualWorkstation,
+		HomeVolumeSize:       apiSnapshot.HomeVolumeSize,
+		Status:               utility.FromStringPtr(apiSnapshot.Status),
+		CreationTime:         creationTime,
+		Expiration:           expiration,
+	}, nil
+}
+
 type APISpawnHostModify struct {
 	Action       *string    `json:"action"`
 	HostID       *string    `json:"host_id"`
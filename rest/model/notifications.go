@@ -23,6 +23,7 @@ type apiNotificationStats struct {
 	EvergreenWebhook  int `json:"evergreen_webhook"`
 	Email             int `json:"email"`
 	Slack             int `json:"slack"`
+	ChatWebhook       int `json:"chat_webhook"`
 }
 
 func (n *apiNotificationStats) BuildFromService(data notification.NotificationStats) {
@@ -32,4 +33,5 @@ func (n *apiNotificationStats) BuildFromService(data notification.NotificationSt
 	n.EvergreenWebhook = data.EvergreenWebhook
 	n.Email = data.Email
 	n.Slack = data.Slack
+	n.ChatWebhook = data.ChatWebhook
 }
@@ -37,6 +37,7 @@ type APIPatch struct {
 	Tasks                   []*string            `json:"tasks"`
 	DownstreamTasks         []DownstreamTasks    `json:"downstream_tasks"`
 	VariantsTasks           []VariantTask        `json:"variants_tasks"`
+	SkippedTasks            []APISkippedTask     `json:"skipped_tasks,omitempty"`
 	Activated               bool                 `json:"activated"`
 	Alias                   *string              `json:"alias,omitempty"`
 	GithubPatchData         githubPatch          `json:"github_patch_data,omitempty"`
@@ -68,6 +69,15 @@ type VariantTask struct {
 	Tasks []*string `json:"tasks"`
 }
 
+// APISkippedTask is a task that predictive task selection chose not to run in
+// a patch.
+type APISkippedTask struct {
+	Variant *string `json:"variant"`
+	Task    *string `json:"task"`
+	Score   float64 `json:"score"`
+	Reason  *string `json:"reason"`
+}
+
 type FileDiff struct {
 	FileName    *string `json:"file_name"`
 	Additions   int     `json:"additions"`
@@ -208,6 +218,14 @@ func (apiPatch *APIPatch) buildBasePatch(p patch.Patch) {
 		})
 	}
 	apiPatch.VariantsTasks = variantTasks
+	for _, st := range p.SkippedTasks {
+		apiPatch.SkippedTasks = append(apiPatch.SkippedTasks, APISkippedTask{
+			Variant: utility.ToStringPtr(st.Variant),
+			Task:    utility.ToStringPtr(st.Task),
+			Score:   st.Score,
+			Reason:  utility.ToStringPtr(st.Reason),
+		})
+	}
 	apiPatch.Activated = p.Activated
 	apiPatch.Alias = utility.ToStringPtr(p.Alias)
 	apiPatch.GithubPatchData = githubPatch{}
@@ -375,6 +393,14 @@ func (apiPatch *APIPatch) ToService() (patch.Patch, error) {
 		tasks[i] = utility.FromStringPtr(t)
 	}
 	res.Tasks = tasks
+	for _, st := range apiPatch.SkippedTasks {
+		res.SkippedTasks = append(res.SkippedTasks, patch.SkippedTask{
+			Variant: utility.FromStringPtr(st.Variant),
+			Task:    utility.FromStringPtr(st.Task),
+			Score:   st.Score,
+			Reason:  utility.FromStringPtr(st.Reason),
+		})
+	}
 	if apiPatch.Parameters != nil {
 		res.Parameters = []patch.Parameter{}
 		for _, param := range apiPatch.Parameters {
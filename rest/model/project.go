@@ -209,9 +209,17 @@ func (t *APIProjectBanner) BuildFromService(h model.ProjectBanner) {
 }
 
 type APICommitQueueParams struct {
-	Enabled     *bool   `json:"enabled"`
-	MergeMethod *string `json:"merge_method"`
-	Message     *string `json:"message"`
+	Enabled     *bool                `json:"enabled"`
+	MergeMethod *string              `json:"merge_method"`
+	Message     *string              `json:"message"`
+	Lanes       []APICommitQueueLane `json:"lanes"`
+	// SpeculativeDepth overrides the admin's speculative depth if it's set.
+	SpeculativeDepth int `json:"speculative_depth"`
+}
+
+type APICommitQueueLane struct {
+	Name  *string  `json:"name"`
+	Paths []string `json:"paths"`
 }
 
 func (bd *APIPeriodicBuildDefinition) ToService() model.PeriodicBuildDefinition {
@@ -240,6 +248,14 @@ func (cqParams *APICommitQueueParams) BuildFromService(params model.CommitQueueP
 	cqParams.Enabled = utility.BoolPtrCopy(params.Enabled)
 	cqParams.MergeMethod = utility.ToStringPtr(params.MergeMethod)
 	cqParams.Message = utility.ToStringPtr(params.Message)
+	cqParams.SpeculativeDepth = params.SpeculativeDepth
+	cqParams.Lanes = nil
+	for _, lane := range params.Lanes {
+		cqParams.Lanes = append(cqParams.Lanes, APICommitQueueLane{
+			Name:  utility.ToStringPtr(lane.Name),
+			Paths: lane.Paths,
+		})
+	}
 }
 
 func (cqParams *APICommitQueueParams) ToService() model.CommitQueueParams {
@@ -247,6 +263,13 @@ func (cqParams *APICommitQueueParams) ToService() model.CommitQueueParams {
 	serviceParams.Enabled = utility.BoolPtrCopy(cqParams.Enabled)
 	serviceParams.MergeMethod = utility.FromStringPtr(cqParams.MergeMethod)
 	serviceParams.Message = utility.FromStringPtr(cqParams.Message)
+	serviceParams.SpeculativeDepth = cqParams.SpeculativeDepth
+	for _, lane := range cqParams.Lanes {
+		serviceParams.Lanes = append(serviceParams.Lanes, model.CommitQueueLane{
+			Name:  utility.FromStringPtr(lane.Name),
+			Paths: lane.Paths,
+		})
+	}
 
 	return serviceParams
 }
@@ -500,6 +523,8 @@ type APIProjectRef struct {
 	Private                     *bool                     `json:"private"`
 	BatchTime                   int                       `json:"batch_time"`
 	RemotePath                  *string                   `json:"remote_path"`
+	RepoProvider                *string                   `json:"repo_provider"`
+	RepoURL                     *string                   `json:"repo_url"`
 	SpawnHostScriptPath         *string                   `json:"spawn_host_script_path"`
 	Identifier                  *string                   `json:"identifier"`
 	DisplayName                 *string                   `json:"display_name"`
@@ -521,6 +546,7 @@ type APIProjectRef struct {
 	RepotrackerDisabled         *bool                     `json:"repotracker_disabled"`
 	DispatchingDisabled         *bool                     `json:"dispatching_disabled"`
 	StepbackDisabled            *bool                     `json:"stepback_disabled"`
+	StepbackBisect              *bool                     `json:"stepback_bisect"`
 	VersionControlEnabled       *bool                     `json:"version_control_enabled"`
 	DisabledStatsCache          *bool                     `json:"disabled_stats_cache"`
 	Admins                      []*string                 `json:"admins"`
@@ -563,6 +589,8 @@ func (p *APIProjectRef) ToService() (*model.ProjectRef, error) {
 		Restricted:             utility.BoolPtrCopy(p.Restricted),
 		BatchTime:              p.BatchTime,
 		RemotePath:             utility.FromStringPtr(p.RemotePath),
+		RepoProvider:           utility.FromStringPtr(p.RepoProvider),
+		RepoURL:                utility.FromStringPtr(p.RepoURL),
 		Id:                     utility.FromStringPtr(p.Id),
 		Identifier:             utility.FromStringPtr(p.Identifier),
 		DisplayName:            utility.FromStringPtr(p.DisplayName),
@@ -584,6 +612,7 @@ func (p *APIProjectRef) ToService() (*model.ProjectRef, error) {
 		RepotrackerDisabled:    utility.BoolPtrCopy(p.RepotrackerDisabled),
 		DispatchingDisabled:    utility.BoolPtrCopy(p.DispatchingDisabled),
 		StepbackDisabled:       utility.BoolPtrCopy(p.StepbackDisabled),
+		StepbackBisect:         utility.BoolPtrCopy(p.StepbackBisect),
 		VersionControlEnabled:  utility.BoolPtrCopy(p.VersionControlEnabled),
 		DisabledStatsCache:     utility.BoolPtrCopy(p.DisabledStatsCache),
 		NotifyOnBuildFailure:   utility.BoolPtrCopy(p.NotifyOnBuildFailure),
@@ -678,6 +707,8 @@ func (p *APIProjectRef) BuildPublicFields(projectRef model.ProjectRef) error {
 	p.Restricted = utility.BoolPtrCopy(projectRef.Restricted)
 	p.BatchTime = projectRef.BatchTime
 	p.RemotePath = utility.ToStringPtr(projectRef.RemotePath)
+	p.RepoProvider = utility.ToStringPtr(projectRef.RepoProvider)
+	p.RepoURL = utility.ToStringPtr(projectRef.RepoURL)
 	p.DeactivatePrevious = projectRef.DeactivatePrevious
 	p.TracksPushEvents = utility.BoolPtrCopy(projectRef.TracksPushEvents)
 	p.PRTestingEnabled = utility.BoolPtrCopy(projectRef.PRTestingEnabled)
@@ -692,6 +723,7 @@ func (p *APIProjectRef) BuildPublicFields(projectRef model.ProjectRef) error {
 	p.RepotrackerDisabled = utility.BoolPtrCopy(projectRef.RepotrackerDisabled)
 	p.DispatchingDisabled = utility.BoolPtrCopy(projectRef.DispatchingDisabled)
 	p.StepbackDisabled = utility.BoolPtrCopy(projectRef.StepbackDisabled)
+	p.StepbackBisect = utility.BoolPtrCopy(projectRef.StepbackBisect)
 	p.VersionControlEnabled = utility.BoolPtrCopy(projectRef.VersionControlEnabled)
 	p.DisabledStatsCache = utility.BoolPtrCopy(projectRef.DisabledStatsCache)
 	p.NotifyOnBuildFailure = utility.BoolPtrCopy(projectRef.NotifyOnBuildFailure)
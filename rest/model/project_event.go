@@ -56,6 +56,8 @@ type APIProjectAlias struct {
 	TaskTags    []*string `json:"tags,omitempty"`
 	Delete      bool      `json:"delete,omitempty"`
 	ID          *string   `json:"_id,omitempty"`
+	// PredictiveSelection is only used by patch aliases.
+	PredictiveSelection *bool `json:"predictive_selection,omitempty"`
 }
 
 func (e *APIProjectEvent) BuildFromService(entry model.ProjectChangeEventEntry) error {
@@ -153,6 +155,8 @@ func (a *APIProjectAlias) ToService() model.ProjectAlias {
 		RemotePath:  utility.FromStringPtr(a.RemotePath),
 		TaskTags:    utility.FromStringPtrSlice(a.TaskTags),
 		VariantTags: utility.FromStringPtrSlice(a.VariantTags),
+
+		PredictiveSelection: utility.FromBoolPtr(a.PredictiveSelection),
 	}
 	if model.IsValidId(utility.FromStringPtr(a.ID)) {
 		res.ID = model.NewId(utility.FromStringPtr(a.ID))
@@ -173,6 +177,7 @@ func (a *APIProjectAlias) BuildFromService(in model.ProjectAlias) {
 	a.VariantTags = APIVariantTags
 	a.TaskTags = APITaskTags
 	a.ID = utility.ToStringPtr(in.ID.Hex())
+	a.PredictiveSelection = utility.ToBoolPtr(in.PredictiveSelection)
 }
 
 func dbProjectAliasesToRestModel(aliases []model.ProjectAlias) []APIProjectAlias {
@@ -188,6 +193,8 @@ func dbProjectAliasesToRestModel(aliases []model.ProjectAlias) []APIProjectAlias
 			GitTag:      utility.ToStringPtr(alias.GitTag),
 			TaskTags:    utility.ToStringPtrSlice(alias.TaskTags),
 			VariantTags: utility.ToStringPtrSlice(alias.VariantTags),
+
+			PredictiveSelection: utility.ToBoolPtr(alias.PredictiveSelection),
 		}
 		result = append(result, apiAlias)
 	}
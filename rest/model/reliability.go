@@ -50,3 +50,34 @@ func (tr *APITaskReliability) StartAtKey() string {
 		distro:       utility.FromStringPtr(tr.Distro),
 	}.String()
 }
+
+// APITestFlakiness is the model to be returned by the API when querying test
+// flakiness scores.
+type APITestFlakiness struct {
+	TestName     *string `json:"test_name"`
+	TaskName     *string `json:"task_name"`
+	BuildVariant *string `json:"variant,omitempty"`
+
+	NumPass        int     `json:"num_pass"`
+	NumFail        int     `json:"num_fail"`
+	NumCommits     int     `json:"num_commits"`
+	NumFlaky       int     `json:"num_flaky"`
+	FlakeRate      float64 `json:"flake_rate"`
+	FlakinessScore float64 `json:"flakiness_score"`
+}
+
+// BuildFromService converts a service level struct to an API level struct.
+func (tf *APITestFlakiness) BuildFromService(in reliability.TestFlakiness) {
+	tf.TestName = utility.ToStringPtr(in.TestName)
+	tf.TaskName = utility.ToStringPtr(in.TaskName)
+	if in.BuildVariant != "" {
+		tf.BuildVariant = utility.ToStringPtr(in.BuildVariant)
+	}
+
+	tf.NumPass = in.NumPass
+	tf.NumFail = in.NumFail
+	tf.NumCommits = in.NumCommits
+	tf.NumFlaky = in.NumFlaky
+	tf.FlakeRate = in.FlakeRate
+	tf.FlakinessScore = in.FlakinessScore
+}
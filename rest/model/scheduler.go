@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type CompareTasksRequest struct {
 	Tasks     []string `json:"tasks"`
 	UseLegacy bool     `json:"use_legacy"`
@@ -9,3 +11,58 @@ type CompareTasksResponse struct {
 	Order []string                     `json:"order"`
 	Logic map[string]map[string]string `json:"logic"`
 }
+
+// APISchedulerSimulationRequest is the request to simulate how a distro's
+// queued tasks would be planned with candidate planner settings.
+type APISchedulerSimulationRequest struct {
+	Distro *string `json:"distro"`
+	// SnapshotTime is when to take the snapshot of the distro's queued
+	// tasks. Defaults to now.
+	SnapshotTime *time.Time `json:"snapshot_time"`
+	// NumHosts is the number of hosts available to run the queued tasks.
+	// Defaults to the number of hosts running tasks at the snapshot time.
+	NumHosts int `json:"num_hosts"`
+	// PlannerSettings are the candidate planner settings. Settings that are
+	// unset keep the distro's current values.
+	PlannerSettings APICandidatePlannerSettings `json:"planner_settings"`
+}
+
+// APICandidatePlannerSettings are the planner settings to simulate. Settings
+// that are omitted keep the distro's current values, so a factor can be
+// simulated with a value of 0.
+type APICandidatePlannerSettings struct {
+	GroupVersions             *bool             `json:"group_versions"`
+	PatchFactor               *int64            `json:"patch_factor"`
+	PatchTimeInQueueFactor    *int64            `json:"patch_time_in_queue_factor"`
+	CommitQueueFactor         *int64            `json:"commit_queue_factor"`
+	MainlineTimeInQueueFactor *int64            `json:"mainline_time_in_queue_factor"`
+	ExpectedRuntimeFactor     *int64            `json:"expected_runtime_factor"`
+	GenerateTaskFactor        *int64            `json:"generate_task_factor"`
+	StepbackTaskFactor        *int64            `json:"stepback_task_factor"`
+	FairShare                 *bool             `json:"fair_share"`
+	FairShareFactor           *int64            `json:"fair_share_factor"`
+	FairShareWindow           *APIDuration      `json:"fair_share_window"`
+	ProjectShares             []APIProjectShare `json:"project_shares"`
+}
+
+// APIRequesterWaitTimes is the expected wait times of the queued tasks of a
+// single requester.
+type APIRequesterWaitTimes struct {
+	Requester   *string     `json:"requester"`
+	NumTasks    int         `json:"num_tasks"`
+	AverageWait APIDuration `json:"average_wait_ms"`
+	MaxWait     APIDuration `json:"max_wait_ms"`
+}
+
+// APISchedulerSimulationResult is the model to be returned by the API when
+// simulating candidate planner settings.
+type APISchedulerSimulationResult struct {
+	Distro            *string                 `json:"distro"`
+	SnapshotTime      *time.Time              `json:"snapshot_time"`
+	NumTasks          int                     `json:"num_tasks"`
+	NumHosts          int                     `json:"num_hosts"`
+	CurrentSettings   APIPlannerSettings      `json:"current_settings"`
+	CandidateSettings APIPlannerSettings      `json:"candidate_settings"`
+	Current           []APIRequesterWaitTimes `json:"current"`
+	Candidate         []APIRequesterWaitTimes `json:"candidate"`
+}
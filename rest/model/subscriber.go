@@ -12,10 +12,11 @@ import (
 )
 
 type APISubscriber struct {
-	Type                *string                 `json:"type"`
-	Target              interface{}             `json:"target"`
-	WebhookSubscriber   *APIWebhookSubscriber   `json:"-"`
-	JiraIssueSubscriber *APIJIRAIssueSubscriber `json:"-"`
+	Type                  *string                   `json:"type"`
+	Target                interface{}               `json:"target"`
+	WebhookSubscriber     *APIWebhookSubscriber     `json:"-"`
+	JiraIssueSubscriber   *APIJIRAIssueSubscriber   `json:"-"`
+	ChatWebhookSubscriber *APIChatWebhookSubscriber `json:"-"`
 }
 
 type APIGithubPRSubscriber struct {
@@ -46,6 +47,8 @@ type APIWebhookSubscriber struct {
 	MinDelayMS int                `json:"min_delay_ms" mapstructure:"min_delay_ms"`
 	TimeoutMS  int                `json:"timeout_ms" mapstructure:"timeout_ms"`
 	Headers    []APIWebhookHeader `json:"headers" mapstructure:"headers"`
+	// PayloadTemplate is a Go template that shapes the posted body.
+	PayloadTemplate *string `json:"payload_template" mapstructure:"payload_template"`
 }
 
 type APIWebhookHeader struct {
@@ -90,6 +93,14 @@ func (s *APISubscriber) BuildFromService(in event.Subscriber) error {
 		}
 		target = sub
 
+	case event.ChatWebhookSubscriberType:
+		sub := APIChatWebhookSubscriber{}
+		err := sub.BuildFromService(in.Target)
+		if err != nil {
+			return err
+		}
+		target = sub
+
 	case event.JIRACommentSubscriberType, event.EmailSubscriberType,
 		event.SlackSubscriberType, event.EnqueuePatchSubscriberType:
 		target = in.Target
@@ -157,6 +168,20 @@ func (s *APISubscriber) ToService() (event.Subscriber, error) {
 		}
 		target = apiModel.ToService()
 
+	case event.ChatWebhookSubscriberType:
+		apiModel := APIChatWebhookSubscriber{}
+		if s.ChatWebhookSubscriber != nil {
+			apiModel = *s.ChatWebhookSubscriber
+		} else {
+			if err = mapstructure.Decode(s.Target, &apiModel); err != nil {
+				return event.Subscriber{}, gimlet.ErrorResponse{
+					StatusCode: http.StatusBadRequest,
+					Message:    errors.Wrap(err, "chat webhook subscriber target is malformed").Error(),
+				}
+			}
+		}
+		target = apiModel.ToService()
+
 	case event.JIRACommentSubscriberType, event.EmailSubscriberType,
 		event.SlackSubscriberType, event.EnqueuePatchSubscriberType:
 		target = s.Target
@@ -245,6 +270,7 @@ func (s *APIWebhookSubscriber) BuildFromService(h interface{}) error {
 		s.Retries = v.Retries
 		s.MinDelayMS = v.MinDelayMS
 		s.TimeoutMS = v.TimeoutMS
+		s.PayloadTemplate = utility.ToStringPtr(v.PayloadTemplate)
 		for _, header := range v.Headers {
 			apiHeader := APIWebhookHeader{}
 			apiHeader.BuildFromService(header)
@@ -266,6 +292,8 @@ func (s *APIWebhookSubscriber) ToService() event.WebhookSubscriber {
 		Retries:    s.Retries,
 		MinDelayMS: s.MinDelayMS,
 		TimeoutMS:  s.TimeoutMS,
+
+		PayloadTemplate: utility.FromStringPtr(s.PayloadTemplate),
 	}
 	for _, apiHeader := range s.Headers {
 		sub.Headers = append(sub.Headers, apiHeader.ToService())
@@ -309,3 +337,30 @@ func (s *APIJIRAIssueSubscriber) ToService() event.JIRAIssueSubscriber {
 		IssueType: utility.FromStringPtr(s.IssueType),
 	}
 }
+
+type APIChatWebhookSubscriber struct {
+	URL *string `json:"url" mapstructure:"url"`
+	// Format is the message format the incoming webhook accepts, either
+	// "adaptive-card" or "markdown".
+	Format *string `json:"format" mapstructure:"format"`
+}
+
+func (s *APIChatWebhookSubscriber) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case *event.ChatWebhookSubscriber:
+		s.URL = utility.ToStringPtr(v.URL)
+		s.Format = utility.ToStringPtr(v.Format)
+
+	default:
+		return errors.Errorf("programmatic error: expected chat webhook subscriber but got type %T", h)
+	}
+
+	return nil
+}
+
+func (s *APIChatWebhookSubscriber) ToService() event.ChatWebhookSubscriber {
+	return event.ChatWebhookSubscriber{
+		URL:    utility.FromStringPtr(s.URL),
+		Format: utility.FromStringPtr(s.Format),
+	}
+}
@@ -57,6 +57,8 @@ func TestSubscriberModelsWebhook(t *testing.T) {
 		MinDelayMS: 500,
 		TimeoutMS:  10000,
 		Headers:    []event.WebhookHeader{},
+
+		PayloadTemplate: `{"text": {{json .status}}}`,
 	}
 	webhookSubscriber := event.Subscriber{
 		Type:   event.EvergreenWebhookSubscriberType,
@@ -76,11 +78,12 @@ func TestSubscriberModelsWebhook(t *testing.T) {
 	incoming := APISubscriber{
 		Type: utility.ToStringPtr(event.EvergreenWebhookSubscriberType),
 		Target: map[string]interface{}{
-			"url":          "foo",
-			"secret":       "bar",
-			"retries":      3,
-			"min_delay_ms": 500,
-			"timeout_ms":   10000,
+			"url":              "foo",
+			"secret":           "bar",
+			"retries":          3,
+			"min_delay_ms":     500,
+			"timeout_ms":       10000,
+			"payload_template": `{"text": {{json .status}}}`,
 		},
 	}
 
@@ -124,6 +127,41 @@ func TestSubscriberModelsJIRAIssue(t *testing.T) {
 	assert.EqualValues(origJIRAIssueSubscriber, serviceModel)
 }
 
+func TestSubscriberModelsChatWebhook(t *testing.T) {
+	assert := assert.New(t)
+
+	target := event.ChatWebhookSubscriber{
+		URL:    "https://example.webhook.office.com/webhookb2/abc",
+		Format: event.ChatWebhookFormatAdaptiveCard,
+	}
+	chatWebhookSubscriber := event.Subscriber{
+		Type:   event.ChatWebhookSubscriberType,
+		Target: &target,
+	}
+	apiChatWebhookSubscriber := APISubscriber{}
+	err := apiChatWebhookSubscriber.BuildFromService(chatWebhookSubscriber)
+	assert.NoError(err)
+
+	origChatWebhookSubscriber, err := apiChatWebhookSubscriber.ToService()
+	assert.NoError(err)
+
+	assert.EqualValues(chatWebhookSubscriber.Type, origChatWebhookSubscriber.Type)
+	assert.EqualValues(target, origChatWebhookSubscriber.Target)
+
+	// incoming subscribers have target serialized as a map
+	incoming := APISubscriber{
+		Type: utility.ToStringPtr(event.ChatWebhookSubscriberType),
+		Target: map[string]interface{}{
+			"url":    "https://example.webhook.office.com/webhookb2/abc",
+			"format": event.ChatWebhookFormatAdaptiveCard,
+		},
+	}
+
+	serviceModel, err := incoming.ToService()
+	assert.NoError(err)
+	assert.EqualValues(origChatWebhookSubscriber, serviceModel)
+}
+
 func TestSubscriberModelsSlack(t *testing.T) {
 	assert := assert.New(t)
 
@@ -21,6 +21,9 @@ type APISubscription struct {
 	OwnerType      *string           `json:"owner_type"`
 	Owner          *string           `json:"owner"`
 	TriggerData    map[string]string `json:"trigger_data,omitempty"`
+	// DigestIntervalMinutes, if set, sends the subscription's notifications
+	// as a digest at the end of each interval.
+	DigestIntervalMinutes int `json:"digest_interval_minutes,omitempty"`
 }
 
 func (s *APISelector) BuildFromService(selector event.Selector) {
@@ -42,6 +45,7 @@ func (s *APISubscription) BuildFromService(sub event.Subscription) error {
 	s.Owner = utility.ToStringPtr(sub.Owner)
 	s.OwnerType = utility.ToStringPtr(string(sub.OwnerType))
 	s.TriggerData = sub.TriggerData
+	s.DigestIntervalMinutes = sub.DigestIntervalMinutes
 	err := s.Subscriber.BuildFromService(sub.Subscriber)
 	if err != nil {
 		return err
@@ -71,6 +75,8 @@ func (s *APISubscription) ToService() (event.Subscription, error) {
 		Selectors:      []event.Selector{},
 		RegexSelectors: []event.Selector{},
 		TriggerData:    s.TriggerData,
+
+		DigestIntervalMinutes: s.DigestIntervalMinutes,
 	}
 	subscriber, err := s.Subscriber.ToService()
 	if err != nil {
@@ -77,6 +77,7 @@ type APITask struct {
 	TestResults                 []APITest           `json:"test_results"`
 	Aborted                     bool                `json:"aborted"`
 	AbortInfo                   APIAbortInfo        `json:"abort_info,omitempty"`
+	StepbackInfo                *APIStepbackInfo    `json:"stepback_info,omitempty"`
 	CanSync                     bool                `json:"can_sync,omitempty"`
 	SyncAtEndOpts               APISyncAtEndOptions `json:"sync_at_end_opts"`
 	AMI                         *string             `json:"ami"`
@@ -99,6 +100,13 @@ type APIAbortInfo struct {
 	PRClosed   bool   `json:"pr_closed,omitempty"`
 }
 
+// APIStepbackInfo describes the state of a bisect stepback search.
+type APIStepbackInfo struct {
+	LastFailingStepbackTaskId string `json:"last_failing_stepback_task_id"`
+	LastPassingStepbackTaskId string `json:"last_passing_stepback_task_id"`
+	NextStepbackTaskId        string `json:"next_stepback_task_id"`
+}
+
 type LogLinks struct {
 	AllLogLink    *string `json:"all_log"`
 	TaskLogLink   *string `json:"task_log"`
@@ -108,12 +116,13 @@ type LogLinks struct {
 }
 
 type ApiTaskEndDetail struct {
-	Status      *string           `json:"status"`
-	Type        *string           `json:"type"`
-	Description *string           `json:"desc"`
-	TimedOut    bool              `json:"timed_out"`
-	TimeoutType *string           `json:"timeout_type"`
-	OOMTracker  APIOomTrackerInfo `json:"oom_tracker_info"`
+	Status       *string           `json:"status"`
+	Type         *string           `json:"type"`
+	Description  *string           `json:"desc"`
+	TimedOut     bool              `json:"timed_out"`
+	TimeoutType  *string           `json:"timeout_type"`
+	OOMTracker   APIOomTrackerInfo `json:"oom_tracker_info"`
+	CommandStats []APICommandStats `json:"command_stats"`
 }
 
 func (at *ApiTaskEndDetail) BuildFromService(t apimodels.TaskEndDetail) error {
@@ -127,6 +136,13 @@ func (at *ApiTaskEndDetail) BuildFromService(t apimodels.TaskEndDetail) error {
 	apiOomTracker.BuildFromService(t.OOMTracker)
 	at.OOMTracker = apiOomTracker
 
+	at.CommandStats = make([]APICommandStats, 0, len(t.CommandStats))
+	for _, stats := range t.CommandStats {
+		apiStats := APICommandStats{}
+		apiStats.BuildFromService(stats)
+		at.CommandStats = append(at.CommandStats, apiStats)
+	}
+
 	return nil
 }
 
@@ -141,6 +157,36 @@ func (ad *ApiTaskEndDetail) ToService() apimodels.TaskEndDetail {
 	}
 }
 
+// APICommandStats are the resources used on the host while a single command
+// ran.
+type APICommandStats struct {
+	Command        *string     `json:"command"`
+	Block          *string     `json:"block"`
+	Step           *string     `json:"step"`
+	StartTime      *time.Time  `json:"start_time"`
+	Duration       APIDuration `json:"duration"`
+	PeakCPUPercent float64     `json:"peak_cpu_percent"`
+	AvgCPUPercent  float64     `json:"avg_cpu_percent"`
+	PeakRSSBytes   int64       `json:"peak_rss_bytes"`
+	AvgRSSBytes    int64       `json:"avg_rss_bytes"`
+	DiskReadBytes  int64       `json:"disk_read_bytes"`
+	DiskWriteBytes int64       `json:"disk_write_bytes"`
+}
+
+func (s *APICommandStats) BuildFromService(stats apimodels.CommandStats) {
+	s.Command = utility.ToStringPtr(stats.Command)
+	s.Block = utility.ToStringPtr(stats.Block)
+	s.Step = utility.ToStringPtr(stats.Step)
+	s.StartTime = ToTimePtr(stats.StartTime)
+	s.Duration = NewAPIDuration(stats.Duration)
+	s.PeakCPUPercent = stats.PeakCPUPercent
+	s.AvgCPUPercent = stats.AvgCPUPercent
+	s.PeakRSSBytes = int64(stats.PeakRSSBytes)
+	s.AvgRSSBytes = int64(stats.AvgRSSBytes)
+	s.DiskReadBytes = int64(stats.DiskReadBytes)
+	s.DiskWriteBytes = int64(stats.DiskWriteBytes)
+}
+
 type APIOomTrackerInfo struct {
 	Detected bool  `json:"detected"`
 	Pids     []int `json:"pids"`
@@ -254,6 +300,14 @@ func (at *APITask) buildTask(t *task.Task) error {
 
 	at.ContainerOpts.BuildFromService(t.ContainerOpts)
 
+	if t.StepbackInfo != nil {
+		at.StepbackInfo = &APIStepbackInfo{
+			LastFailingStepbackTaskId: t.StepbackInfo.LastFailingStepbackTaskId,
+			LastPassingStepbackTaskId: t.StepbackInfo.LastPassingStepbackTaskId,
+			NextStepbackTaskId:        t.StepbackInfo.NextStepbackTaskId,
+		}
+	}
+
 	if t.BaseTask.Id != "" {
 		at.BaseTask = APIBaseTaskInfo{
 			Id:     utility.ToStringPtr(t.BaseTask.Id),
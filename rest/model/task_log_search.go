@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/utility"
+)
+
+// APILogLine is a single line of a task log.
+type APILogLine struct {
+	LineNumber int        `json:"line_number"`
+	Timestamp  *time.Time `json:"timestamp,omitempty"`
+	Severity   *string    `json:"severity"`
+	LogType    *string    `json:"log_type,omitempty"`
+	Data       *string    `json:"data"`
+}
+
+// BuildFromService converts a log line at the given position in its log to
+// an APILogLine.
+func (l *APILogLine) BuildFromService(line log.LogLine, lineNumber int) {
+	l.LineNumber = lineNumber
+	if !utility.IsZeroTime(line.Timestamp) {
+		l.Timestamp = utility.ToTimePtr(line.Timestamp)
+	}
+	l.Severity = utility.ToStringPtr(line.Priority.String())
+	if line.LogName != "" {
+		l.LogType = utility.ToStringPtr(line.LogName)
+	}
+	l.Data = utility.ToStringPtr(line.Data)
+}
+
+// APITaskLogSearchMatch is a task log line that matched a log search, with
+// the lines of context around it.
+type APITaskLogSearchMatch struct {
+	TaskID       *string      `json:"task_id"`
+	Execution    int          `json:"execution"`
+	DisplayName  *string      `json:"display_name"`
+	BuildVariant *string      `json:"build_variant"`
+	Line         APILogLine   `json:"line"`
+	Before       []APILogLine `json:"before"`
+	After        []APILogLine `json:"after"`
+}
+
+// BuildFromService converts a task log search match to an
+// APITaskLogSearchMatch.
+func (m *APITaskLogSearchMatch) BuildFromService(match log.TaskSearchMatch) {
+	m.TaskID = utility.ToStringPtr(match.TaskID)
+	m.Execution = match.Execution
+	m.DisplayName = utility.ToStringPtr(match.DisplayName)
+	m.BuildVariant = utility.ToStringPtr(match.BuildVariant)
+	m.Line.BuildFromService(match.Line, match.LineNumber)
+
+	m.Before = make([]APILogLine, len(match.Before))
+	for i, line := range match.Before {
+		m.Before[i].BuildFromService(line, match.LineNumber-len(match.Before)+i)
+	}
+	m.After = make([]APILogLine, len(match.After))
+	for i, line := range match.After {
+		m.After[i].BuildFromService(line, match.LineNumber+i+1)
+	}
+}
+
+// APITaskLogSearchParams are the parameters for searching the logs of the
+// tasks in a version or build, or of a single task.
+type APITaskLogSearchParams struct {
+	VersionID     string
+	BuildID       string
+	TaskID        string
+	Pattern       string
+	LogType       string
+	AllExecutions bool
+	ContextLines  *int
+	Limit         int
+	StartAt       string
+}
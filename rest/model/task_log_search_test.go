@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITaskLogSearchMatchBuildFromService(t *testing.T) {
+	ts := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	match := log.TaskSearchMatch{
+		TaskOptions:  log.TaskOptions{TaskID: "task", Execution: 1},
+		DisplayName:  "compile",
+		BuildVariant: "ubuntu",
+		SearchMatch: log.SearchMatch{
+			LineNumber: 10,
+			Line:       log.LogLine{LogName: "T", Timestamp: ts, Priority: level.Error, Data: "segfault"},
+			Before:     []log.LogLine{{Data: "eight"}, {Data: "nine"}},
+			After:      []log.LogLine{{Data: "eleven"}},
+		},
+	}
+
+	apiMatch := APITaskLogSearchMatch{}
+	apiMatch.BuildFromService(match)
+	assert.Equal(t, "task", utility.FromStringPtr(apiMatch.TaskID))
+	assert.Equal(t, 1, apiMatch.Execution)
+	assert.Equal(t, "compile", utility.FromStringPtr(apiMatch.DisplayName))
+	assert.Equal(t, "ubuntu", utility.FromStringPtr(apiMatch.BuildVariant))
+
+	assert.Equal(t, 10, apiMatch.Line.LineNumber)
+	assert.Equal(t, "segfault", utility.FromStringPtr(apiMatch.Line.Data))
+	assert.Equal(t, level.Error.String(), utility.FromStringPtr(apiMatch.Line.Severity))
+	assert.Equal(t, "T", utility.FromStringPtr(apiMatch.Line.LogType))
+	require.NotNil(t, apiMatch.Line.Timestamp)
+	assert.True(t, ts.Equal(*apiMatch.Line.Timestamp))
+
+	require.Len(t, apiMatch.Before, 2)
+	assert.Equal(t, 8, apiMatch.Before[0].LineNumber)
+	assert.Equal(t, 9, apiMatch.Before[1].LineNumber)
+	assert.Nil(t, apiMatch.Before[0].Timestamp)
+	assert.Nil(t, apiMatch.Before[0].LogType)
+	require.Len(t, apiMatch.After, 1)
+	assert.Equal(t, 11, apiMatch.After[0].LineNumber)
+	assert.Equal(t, "eleven", utility.FromStringPtr(apiMatch.After[0].Data))
+}
@@ -5,9 +5,12 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/utility"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type taskCompare struct {
@@ -252,3 +255,42 @@ func TestTaskBuildFromService(t *testing.T) {
 		})
 	})
 }
+
+func TestTaskEndDetailBuildFromService(t *testing.T) {
+	start := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	detail := apimodels.TaskEndDetail{
+		Status: evergreen.TaskSucceeded,
+		CommandStats: []apimodels.CommandStats{
+			{
+				Command:        "'shell.exec'",
+				Block:          "task",
+				Step:           "1.2",
+				StartTime:      start,
+				Duration:       90 * time.Second,
+				PeakCPUPercent: 80,
+				AvgCPUPercent:  40,
+				PeakRSSBytes:   2048,
+				AvgRSSBytes:    1024,
+				DiskReadBytes:  10,
+				DiskWriteBytes: 20,
+			},
+		},
+	}
+
+	apiDetail := ApiTaskEndDetail{}
+	require.NoError(t, apiDetail.BuildFromService(detail))
+	require.Len(t, apiDetail.CommandStats, 1)
+	stats := apiDetail.CommandStats[0]
+	assert.Equal(t, "'shell.exec'", utility.FromStringPtr(stats.Command))
+	assert.Equal(t, "task", utility.FromStringPtr(stats.Block))
+	assert.Equal(t, "1.2", utility.FromStringPtr(stats.Step))
+	require.NotNil(t, stats.StartTime)
+	assert.True(t, start.Equal(*stats.StartTime))
+	assert.Equal(t, 90*time.Second, stats.Duration.ToDuration())
+	assert.Equal(t, 80.0, stats.PeakCPUPercent)
+	assert.Equal(t, 40.0, stats.AvgCPUPercent)
+	assert.EqualValues(t, 2048, stats.PeakRSSBytes)
+	assert.EqualValues(t, 1024, stats.AvgRSSBytes)
+	assert.EqualValues(t, 10, stats.DiskReadBytes)
+	assert.EqualValues(t, 20, stats.DiskWriteBytes)
+}
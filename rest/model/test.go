@@ -19,6 +19,7 @@ type APITest struct {
 	BaseStatus *string    `json:"base_status,omitempty"`
 	TestFile   *string    `json:"test_file"`
 	GroupID    *string    `json:"group_id,omitempty"`
+	Attempt    int        `json:"attempt,omitempty"`
 	Logs       TestLogs   `json:"logs"`
 	StartTime  *time.Time `json:"start_time"`
 	EndTime    *time.Time `json:"end_time"`
@@ -47,6 +48,7 @@ func (at *APITest) BuildFromService(st interface{}) error {
 			at.GroupID = utility.ToStringPtr(v.GroupID)
 		}
 		at.Status = utility.ToStringPtr(v.Status)
+		at.Attempt = v.Attempt
 		if v.BaseStatus != "" {
 			at.BaseStatus = utility.ToStringPtr(v.BaseStatus)
 		}
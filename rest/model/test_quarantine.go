@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/utility"
+)
+
+// APIQuarantinedTest is the model to be returned by the API when querying a
+// project's quarantined tests.
+type APIQuarantinedTest struct {
+	ProjectID *string    `json:"project_id"`
+	Pattern   *string    `json:"pattern"`
+	Reason    *string    `json:"reason,omitempty"`
+	Author    *string    `json:"author,omitempty"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+// BuildFromService converts a service-level quarantined test into an API
+// quarantined test.
+func (a *APIQuarantinedTest) BuildFromService(q model.QuarantinedTest) {
+	a.ProjectID = utility.ToStringPtr(q.ProjectID)
+	a.Pattern = utility.ToStringPtr(q.Pattern)
+	a.Reason = utility.ToStringPtr(q.Reason)
+	a.Author = utility.ToStringPtr(q.Author)
+	a.CreatedAt = ToTimePtr(q.CreatedAt)
+}
+
+// ToService converts an API quarantined test into a service-level quarantined
+// test.
+func (a *APIQuarantinedTest) ToService() model.QuarantinedTest {
+	return model.QuarantinedTest{
+		ProjectID: utility.FromStringPtr(a.ProjectID),
+		Pattern:   utility.FromStringPtr(a.Pattern),
+		Reason:    utility.FromStringPtr(a.Reason),
+		Author:    utility.FromStringPtr(a.Author),
+		CreatedAt: utility.FromTimePtr(a.CreatedAt),
+	}
+}
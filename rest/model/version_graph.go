@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+)
+
+// APIVersionGraph is the model to be returned by the API when exporting a
+// version's task dependency graph.
+type APIVersionGraph struct {
+	VersionID    *string               `json:"version_id"`
+	Nodes        []APIVersionGraphNode `json:"nodes"`
+	Edges        []APIVersionGraphEdge `json:"edges"`
+	CriticalPath []string              `json:"critical_path"`
+	StartTime    *time.Time            `json:"start_time"`
+	FinishTime   *time.Time            `json:"finish_time"`
+	Makespan     APIDuration           `json:"makespan_ms"`
+}
+
+// APIVersionGraphNode is a task in a version's dependency graph.
+type APIVersionGraphNode struct {
+	TaskID              *string     `json:"task_id"`
+	DisplayName         *string     `json:"display_name"`
+	BuildVariant        *string     `json:"build_variant"`
+	Status              *string     `json:"status"`
+	Activated           bool        `json:"activated"`
+	ExpectedDuration    APIDuration `json:"expected_duration_ms"`
+	StartTime           *time.Time  `json:"start_time"`
+	FinishTime          *time.Time  `json:"finish_time"`
+	EstimatedFinishTime *time.Time  `json:"estimated_finish_time"`
+	OnCriticalPath      bool        `json:"on_critical_path"`
+}
+
+// APIVersionGraphEdge is a dependency in a version's dependency graph, which
+// points from the depended on task to the dependent task.
+type APIVersionGraphEdge struct {
+	From   *string `json:"from"`
+	To     *string `json:"to"`
+	Status *string `json:"status,omitempty"`
+}
+
+// BuildFromService converts a service-level version graph into an API
+// version graph.
+func (g *APIVersionGraph) BuildFromService(vg task.VersionGraph) {
+	g.VersionID = utility.ToStringPtr(vg.VersionID)
+	g.Nodes = make([]APIVersionGraphNode, 0, len(vg.Nodes))
+	for _, n := range vg.Nodes {
+		g.Nodes = append(g.Nodes, APIVersionGraphNode{
+			TaskID:              utility.ToStringPtr(n.ID),
+			DisplayName:         utility.ToStringPtr(n.Name),
+			BuildVariant:        utility.ToStringPtr(n.Variant),
+			Status:              utility.ToStringPtr(n.Status),
+			Activated:           n.Activated,
+			ExpectedDuration:    NewAPIDuration(n.ExpectedDuration),
+			StartTime:           ToTimePtr(n.StartTime),
+			FinishTime:          ToTimePtr(n.FinishTime),
+			EstimatedFinishTime: ToTimePtr(n.EstimatedFinishTime),
+			OnCriticalPath:      n.OnCriticalPath,
+		})
+	}
+	g.Edges = make([]APIVersionGraphEdge, 0, len(vg.Edges))
+	for _, e := range vg.Edges {
+		edge := APIVersionGraphEdge{
+			From: utility.ToStringPtr(e.From),
+			To:   utility.ToStringPtr(e.To),
+		}
+		if e.Status != "" {
+			edge.Status = utility.ToStringPtr(e.Status)
+		}
+		g.Edges = append(g.Edges, edge)
+	}
+	g.CriticalPath = append([]string{}, vg.CriticalPath...)
+	g.StartTime = ToTimePtr(vg.StartTime)
+	g.FinishTime = ToTimePtr(vg.FinishTime)
+	g.Makespan = NewAPIDuration(vg.Makespan)
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/utility"
+)
+
+// APIWebhookDelivery is the model to be returned by the API for an attempt to
+// deliver a webhook notification.
+type APIWebhookDelivery struct {
+	ID             *string            `json:"id"`
+	SubscriptionID *string            `json:"subscription_id"`
+	NotificationID *string            `json:"notification_id"`
+	URL            *string            `json:"url"`
+	RequestHeaders []APIWebhookHeader `json:"request_headers"`
+	RequestBody    *string            `json:"request_body"`
+	Attempt        int                `json:"attempt"`
+	Replay         bool               `json:"replay"`
+	StatusCode     int                `json:"status_code"`
+	ResponseBody   *string            `json:"response_body"`
+	LatencyMS      int64              `json:"latency_ms"`
+	Error          *string            `json:"error,omitempty"`
+	Succeeded      bool               `json:"succeeded"`
+	Time           *time.Time         `json:"time"`
+}
+
+// BuildFromService converts a service-level webhook delivery into an API
+// webhook delivery.
+func (d *APIWebhookDelivery) BuildFromService(delivery notification.WebhookDelivery) {
+	d.ID = utility.ToStringPtr(delivery.ID)
+	d.SubscriptionID = utility.ToStringPtr(delivery.SubscriptionID)
+	d.NotificationID = utility.ToStringPtr(delivery.NotificationID)
+	d.URL = utility.ToStringPtr(delivery.URL)
+	d.RequestHeaders = []APIWebhookHeader{}
+	// Deliveries are redacted when they're recorded, but they're redacted
+	// again in case they were recorded before redaction was added.
+	headers := notification.RedactWebhookHeaders(delivery.RequestHeaders)
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range headers[key] {
+			d.RequestHeaders = append(d.RequestHeaders, APIWebhookHeader{
+				Key:   utility.ToStringPtr(key),
+				Value: utility.ToStringPtr(value),
+			})
+		}
+	}
+	d.RequestBody = utility.ToStringPtr(delivery.RequestBody)
+	d.Attempt = delivery.Attempt
+	d.Replay = delivery.Replay
+	d.StatusCode = delivery.StatusCode
+	d.ResponseBody = utility.ToStringPtr(delivery.ResponseBody)
+	d.LatencyMS = delivery.Latency.Milliseconds()
+	if delivery.Error != "" {
+		d.Error = utility.ToStringPtr(delivery.Error)
+	}
+	d.Succeeded = delivery.Succeeded()
+	d.Time = ToTimePtr(delivery.Time)
+}
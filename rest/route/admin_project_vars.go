@@ -0,0 +1,65 @@
+package route
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+type projectVarsEncryptHandler struct {
+	Reencrypt bool `json:"reencrypt"`
+}
+
+func makeEncryptProjectVars() gimlet.RouteHandler {
+	return &projectVarsEncryptHandler{}
+}
+
+func (h *projectVarsEncryptHandler) Factory() gimlet.RouteHandler {
+	return &projectVarsEncryptHandler{}
+}
+
+func (h *projectVarsEncryptHandler) Parse(ctx context.Context, r *http.Request) error {
+	if r.ContentLength == 0 {
+		return nil
+	}
+	return errors.Wrap(gimlet.GetJSON(r.Body, h), "reading encryption options from JSON request body")
+}
+
+func (h *projectVarsEncryptHandler) Run(ctx context.Context) gimlet.Responder {
+	updated, err := model.EncryptAllProjectVars(ctx, h.Reencrypt)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "encrypting project variables (%d projects updated)", updated))
+	}
+
+	return gimlet.NewJSONResponse(struct {
+		UpdatedCount int `json:"updated_count"`
+	}{updated})
+}
+
+type projectVarsRotateKeyHandler struct{}
+
+func makeRotateProjectVarsKey() gimlet.RouteHandler {
+	return &projectVarsRotateKeyHandler{}
+}
+
+func (h *projectVarsRotateKeyHandler) Factory() gimlet.RouteHandler {
+	return &projectVarsRotateKeyHandler{}
+}
+
+func (h *projectVarsRotateKeyHandler) Parse(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *projectVarsRotateKeyHandler) Run(ctx context.Context) gimlet.Responder {
+	rotated, err := model.RotateProjectVarsKeyEncryptionKey(ctx)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "rotating project variable key encryption key (%d data keys rewrapped)", rotated))
+	}
+
+	return gimlet.NewJSONResponse(struct {
+		RotatedCount int `json:"rotated_count"`
+	}{rotated})
+}
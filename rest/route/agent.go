@@ -17,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/utility"
@@ -118,6 +119,12 @@ func (h *agentSetup) Run(ctx context.Context) gimlet.Responder {
 	if h.settings.Tracer.Enabled {
 		data.TraceCollectorEndpoint = h.settings.Tracer.CollectorEndpoint
 	}
+	// Deployments without Cedar store test results in the application
+	// database.
+	data.TestResultsService = testresult.TestResultsServiceCedar
+	if h.settings.Cedar.BaseURL == "" {
+		data.TestResultsService = testresult.TestResultsServiceLocal
+	}
 
 	return gimlet.NewJSONResponse(data)
 }
@@ -382,7 +389,10 @@ func (h *getExpansionsAndVarsHandler) Run(ctx context.Context) gimlet.Responder
 		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting merged project vars"))
 	}
 	if projectVars != nil {
-		res.Vars = projectVars.GetVars(t)
+		res.Vars, err = projectVars.GetVars(t)
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting project vars for task"))
+		}
 		if projectVars.PrivateVars != nil {
 			res.PrivateVars = projectVars.PrivateVars
 		}
@@ -16,6 +16,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/mongodb/amboy/queue"
 	"github.com/mongodb/grip/send"
@@ -297,6 +298,7 @@ func TestAgentSetup(t *testing.T) {
 			assert.Equal(t, data.SplunkClientToken, s.Splunk.SplunkConnectionInfo.Token)
 			assert.Equal(t, data.SplunkChannel, s.Splunk.SplunkConnectionInfo.Channel)
 			assert.Equal(t, data.LogkeeperURL, s.LoggerConfig.LogkeeperURL)
+			assert.Equal(t, testresult.TestResultsServiceCedar, data.TestResultsService)
 		},
 		"ReturnsEmpty": func(ctx context.Context, t *testing.T, rh *agentSetup, s *evergreen.Settings) {
 			*s = evergreen.Settings{}
@@ -306,6 +308,8 @@ func TestAgentSetup(t *testing.T) {
 
 			data, ok := resp.Data().(apimodels.AgentSetupData)
 			require.True(t, ok)
+			assert.Equal(t, testresult.TestResultsServiceLocal, data.TestResultsService, "test results should be stored locally without Cedar")
+			data.TestResultsService = ""
 			assert.Zero(t, data)
 		},
 	} {
@@ -333,6 +337,9 @@ func TestAgentSetup(t *testing.T) {
 				LoggerConfig: evergreen.LoggerConfig{
 					LogkeeperURL: "logkeeper_url",
 				},
+				Cedar: evergreen.CedarConfig{
+					BaseURL: "cedar_url",
+				},
 			}
 
 			r, ok := makeAgentSetup(s).(*agentSetup)
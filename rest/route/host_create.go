@@ -52,6 +52,20 @@ func (h *hostCreateHandler) Parse(ctx context.Context, r *http.Request) error {
 }
 
 func (h *hostCreateHandler) Run(ctx context.Context) gimlet.Responder {
+	if h.createHost.Pool != "" {
+		ids, err := data.LeaseHostsFromPool(ctx, evergreen.GetEnvironment().Settings(), h.taskID, h.createHost)
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "leasing hosts from host pool '%s'", h.createHost.Pool))
+		}
+		grip.Debug(message.Fields{
+			"message":   "host.create pool lease",
+			"host_ids":  ids,
+			"host_pool": h.createHost.Pool,
+			"task_id":   h.taskID,
+		})
+		return gimlet.NewJSONResponse(ids)
+	}
+
 	numHosts, err := strconv.Atoi(h.createHost.NumHosts)
 	if err != nil {
 		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "converting number of hosts to create to integer value"))
@@ -0,0 +1,263 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+// findSnapshotWithOwner finds the snapshot and checks that it belongs to the
+// user. Snapshots owned by other users are reported as not found.
+func findSnapshotWithOwner(snapshotID string, u *user.DBUser) (*host.Snapshot, error) {
+	snapshot, err := host.FindSnapshotByID(snapshotID)
+	if err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    errors.Wrapf(err, "finding snapshot '%s'", snapshotID).Error(),
+		}
+	}
+	if snapshot == nil || snapshot.CreatedBy != u.Id {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("snapshot '%s' not found", snapshotID),
+		}
+	}
+	return snapshot, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/hosts/{host_id}/snapshots
+
+type createSnapshotHandler struct {
+	env    evergreen.Environment
+	hostID string
+
+	options *model.SnapshotPostRequest
+}
+
+func makeCreateSnapshot(env evergreen.Environment) gimlet.RouteHandler {
+	return &createSnapshotHandler{
+		env: env,
+	}
+}
+
+func (h *createSnapshotHandler) Factory() gimlet.RouteHandler {
+	return &createSnapshotHandler{
+		env: h.env,
+	}
+}
+
+func (h *createSnapshotHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	if h.hostID, err = validateID(gimlet.GetVars(r)["host_id"]); err != nil {
+		return err
+	}
+	h.options = &model.SnapshotPostRequest{}
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+	return errors.Wrap(utility.ReadJSON(r.Body, h.options), "reading snapshot options from JSON request body")
+}
+
+func (h *createSnapshotHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	foundHost, err := data.FindHostByIdWithOwner(h.hostID, u)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrapf(err, "finding host '%s' with owner '%s'", h.hostID, u.Id))
+	}
+
+	snapshot, statusCode, err := data.CreateSpawnHostSnapshot(ctx, h.env, u, foundHost, h.options.DisplayName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: statusCode,
+			Message:    errors.Wrap(err, "creating spawn host snapshot").Error(),
+		})
+	}
+
+	snapshotModel := &model.APISpawnHostSnapshot{}
+	snapshotModel.BuildFromService(*snapshot)
+	return gimlet.NewJSONResponse(snapshotModel)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/snapshots
+
+type getSnapshotsHandler struct{}
+
+func makeGetSnapshots() gimlet.RouteHandler {
+	return &getSnapshotsHandler{}
+}
+
+func (h *getSnapshotsHandler) Factory() gimlet.RouteHandler {
+	return &getSnapshotsHandler{}
+}
+
+func (h *getSnapshotsHandler) Parse(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *getSnapshotsHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	snapshots, err := host.FindSnapshotsByUser(u.Id)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding snapshots for user '%s'", u.Id))
+	}
+
+	snapshotDocs := []model.APISpawnHostSnapshot{}
+	for _, s := range snapshots {
+		snapshotDoc := model.APISpawnHostSnapshot{}
+		snapshotDoc.BuildFromService(s)
+		snapshotDocs = append(snapshotDocs, snapshotDoc)
+	}
+	return gimlet.NewJSONResponse(snapshotDocs)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/snapshots/{snapshot_id}
+
+type getSnapshotByIDHandler struct {
+	snapshotID string
+}
+
+func makeGetSnapshotByID() gimlet.RouteHandler {
+	return &getSnapshotByIDHandler{}
+}
+
+func (h *getSnapshotByIDHandler) Factory() gimlet.RouteHandler {
+	return &getSnapshotByIDHandler{}
+}
+
+func (h *getSnapshotByIDHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	h.snapshotID, err = validateID(gimlet.GetVars(r)["snapshot_id"])
+	return err
+}
+
+func (h *getSnapshotByIDHandler) Run(ctx context.Context) gimlet.Responder {
+	snapshot, err := findSnapshotWithOwner(h.snapshotID, MustHaveUser(ctx))
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+
+	snapshotDoc := &model.APISpawnHostSnapshot{}
+	snapshotDoc.BuildFromService(*snapshot)
+	return gimlet.NewJSONResponse(snapshotDoc)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// DELETE /rest/v2/snapshots/{snapshot_id}
+
+type deleteSnapshotHandler struct {
+	env        evergreen.Environment
+	snapshotID string
+}
+
+func makeDeleteSnapshot(env evergreen.Environment) gimlet.RouteHandler {
+	return &deleteSnapshotHandler{
+		env: env,
+	}
+}
+
+func (h *deleteSnapshotHandler) Factory() gimlet.RouteHandler {
+	return &deleteSnapshotHandler{
+		env: h.env,
+	}
+}
+
+func (h *deleteSnapshotHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	h.snapshotID, err = validateID(gimlet.GetVars(r)["snapshot_id"])
+	return err
+}
+
+func (h *deleteSnapshotHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	snapshot, err := findSnapshotWithOwner(h.snapshotID, u)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+
+	statusCode, err := data.DeleteSpawnHostSnapshot(ctx, h.env, u, snapshot)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: statusCode,
+			Message:    errors.Wrap(err, "deleting spawn host snapshot").Error(),
+		})
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/snapshots/{snapshot_id}/restore
+
+type restoreSnapshotHandler struct {
+	settings   *evergreen.Settings
+	snapshotID string
+
+	options *model.HostRequestOptions
+}
+
+func makeRestoreSnapshot(settings *evergreen.Settings) gimlet.RouteHandler {
+	return &restoreSnapshotHandler{
+		settings: settings,
+	}
+}
+
+func (h *restoreSnapshotHandler) Factory() gimlet.RouteHandler {
+	return &restoreSnapshotHandler{
+		settings: h.settings,
+	}
+}
+
+func (h *restoreSnapshotHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	if h.snapshotID, err = validateID(gimlet.GetVars(r)["snapshot_id"]); err != nil {
+		return err
+	}
+	h.options = &model.HostRequestOptions{}
+	return errors.Wrap(utility.ReadJSON(r.Body, h.options), "reading host options from JSON request body")
+}
+
+func (h *restoreSnapshotHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	snapshot, err := findSnapshotWithOwner(h.snapshotID, u)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(err)
+	}
+	if h.options.NoExpiration {
+		if err = CheckUnexpirableHostLimitExceeded(u.Id, h.settings.Spawnhost.UnexpirableHostsPerUser); err != nil {
+			return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "checking expirable host limit"))
+		}
+	}
+
+	h.options.SnapshotID = snapshot.ID
+	if h.options.DistroID == "" {
+		h.options.DistroID = snapshot.DistroID
+	}
+	if h.options.InstanceType == "" {
+		h.options.InstanceType = snapshot.InstanceType
+	}
+	intentHost, err := data.NewIntentHost(ctx, h.options, u, h.settings)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "creating intent host from snapshot '%s'", snapshot.ID))
+	}
+
+	hostModel := &model.APIHost{}
+	hostModel.BuildFromService(intentHost, nil)
+	return gimlet.NewJSONResponse(hostModel)
+}
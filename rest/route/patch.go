@@ -546,3 +546,44 @@ func (p *schedulePatchHandler) Run(ctx context.Context) gimlet.Responder {
 	restVersion.BuildFromService(*dbVersion)
 	return gimlet.NewJSONResponse(restVersion)
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/patches/{patch_id}/schedule_skipped_tasks
+
+type scheduleSkippedTasksHandler struct {
+	patchId string
+	env     evergreen.Environment
+}
+
+func makeScheduleSkippedTasksHandler(env evergreen.Environment) gimlet.RouteHandler {
+	return &scheduleSkippedTasksHandler{env: env}
+}
+
+func (p *scheduleSkippedTasksHandler) Factory() gimlet.RouteHandler {
+	return &scheduleSkippedTasksHandler{env: p.env}
+}
+
+func (p *scheduleSkippedTasksHandler) Parse(ctx context.Context, r *http.Request) error {
+	p.patchId = gimlet.GetVars(r)["patch_id"]
+	if p.patchId == "" {
+		return errors.New("must specify a patch ID")
+	}
+	return nil
+}
+
+func (p *scheduleSkippedTasksHandler) Run(ctx context.Context) gimlet.Responder {
+	code, err := units.ScheduleSkippedTasks(ctx, p.env, p.patchId)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: code,
+			Message:    errors.Wrapf(err, "scheduling skipped tasks for patch '%s'", p.patchId).Error(),
+		})
+	}
+
+	apiPatch, err := data.FindPatchById(p.patchId)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding patch '%s'", p.patchId))
+	}
+	return gimlet.NewJSONResponse(apiPatch)
+}
@@ -443,6 +443,7 @@ func (h *projectIDPatchHandler) Run(ctx context.Context) gimlet.Responder {
 		h.newProjectRef.PatchTriggerAliases[i], err = dbModel.ValidateTriggerDefinition(h.newProjectRef.PatchTriggerAliases[i], h.newProjectRef.Id)
 		catcher.Add(err)
 	}
+	catcher.Wrap(h.newProjectRef.CommitQueue.ValidateLanes(), "invalid commit queue lanes")
 	for _, buildDef := range h.newProjectRef.PeriodicBuilds {
 		catcher.Wrapf(buildDef.Validate(), "invalid periodic build definition")
 	}
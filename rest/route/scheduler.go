@@ -44,3 +44,43 @@ func (p *compareTasksRoute) Run(ctx context.Context) gimlet.Responder {
 	}
 	return gimlet.NewJSONResponse(resp)
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/admin/scheduler/simulate
+
+type schedulerSimulateHandler struct {
+	request model.APISchedulerSimulationRequest
+}
+
+func makeSimulateScheduler() gimlet.RouteHandler {
+	return &schedulerSimulateHandler{}
+}
+
+func (h *schedulerSimulateHandler) Factory() gimlet.RouteHandler {
+	return &schedulerSimulateHandler{}
+}
+
+func (h *schedulerSimulateHandler) Parse(ctx context.Context, r *http.Request) error {
+	if err := utility.ReadJSON(r.Body, &h.request); err != nil {
+		return errors.Wrap(err, "reading scheduler simulation options from JSON request body")
+	}
+	if utility.FromStringPtr(h.request.Distro) == "" {
+		return errors.New("must specify a distro")
+	}
+	if h.request.NumHosts < 0 {
+		return errors.New("number of hosts cannot be negative")
+	}
+	return nil
+}
+
+// Run replays a snapshot of the distro's queued tasks through the planner
+// with the candidate planner settings and returns the expected wait times of
+// each requester's tasks.
+func (h *schedulerSimulateHandler) Run(ctx context.Context) gimlet.Responder {
+	result, err := data.SimulatePlannerSettings(h.request)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "simulating planner settings"))
+	}
+	return gimlet.NewJSONResponse(result)
+}
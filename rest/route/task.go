@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen/apimodels"
 	dbModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/utility"
@@ -338,6 +339,64 @@ func (rh *taskSetResultsInfoHandler) Run(ctx context.Context) gimlet.Responder {
 	return gimlet.NewTextResponse("Results info set in task")
 }
 
+// POST /tasks/{task_id}/test_results
+
+type taskTestResultsPostHandler struct {
+	env     evergreen.Environment
+	taskID  string
+	batchID string
+	results []testresult.TestResult
+}
+
+func makeTaskTestResultsPostHandler(env evergreen.Environment) gimlet.RouteHandler {
+	return &taskTestResultsPostHandler{env: env}
+}
+
+func (rh *taskTestResultsPostHandler) Factory() gimlet.RouteHandler {
+	return &taskTestResultsPostHandler{env: rh.env}
+}
+
+func (rh *taskTestResultsPostHandler) Parse(ctx context.Context, r *http.Request) error {
+	rh.taskID = gimlet.GetVars(r)["task_id"]
+	rh.batchID = r.URL.Query().Get("batch_id")
+	if rh.batchID == "" {
+		return errors.New("must specify a batch ID")
+	}
+
+	if err := gimlet.GetJSON(r.Body, &rh.results); err != nil {
+		return errors.Wrap(err, "reading test results from JSON request body")
+	}
+	if len(rh.results) == 0 {
+		return errors.New("must specify at least one test result")
+	}
+
+	return nil
+}
+
+func (rh *taskTestResultsPostHandler) Run(ctx context.Context) gimlet.Responder {
+	t, err := task.FindOneId(rh.taskID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", rh.taskID))
+	}
+	if t == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", rh.taskID),
+		})
+	}
+
+	// The results always belong to the task's current execution.
+	for i := range rh.results {
+		rh.results[i].TaskID = t.Id
+		rh.results[i].Execution = t.Execution
+	}
+	if err = testresult.InsertLocalBatch(ctx, rh.env, rh.batchID, rh.results...); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "inserting test results for task '%s'", rh.taskID))
+	}
+
+	return gimlet.NewTextResponse("Test results added to task")
+}
+
 // GET /task/sync_read_credentials
 
 type taskSyncReadCredentialsGetHandler struct{}
@@ -0,0 +1,139 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultLogSearchContextLines = 2
+	maxLogSearchContextLines     = 10
+	maxLogSearchLimit            = 1000
+)
+
+// logSearchTypes maps the log types that can be searched to their log
+// prefixes.
+var logSearchTypes = map[string]string{
+	"":       apimodels.AllTaskLevelLogs,
+	"all":    apimodels.AllTaskLevelLogs,
+	"task":   apimodels.TaskLogPrefix,
+	"agent":  apimodels.AgentLogPrefix,
+	"system": apimodels.SystemLogPrefix,
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/versions/{version_id}/logs/search
+// GET /rest/v2/builds/{build_id}/logs/search
+// GET /rest/v2/tasks/{task_id}/logs/search
+
+type taskLogSearchHandler struct {
+	opts data.TaskLogSearchOptions
+	url  string
+}
+
+func makeSearchTaskLogs(url string) gimlet.RouteHandler {
+	return &taskLogSearchHandler{url: url}
+}
+
+func (h *taskLogSearchHandler) Factory() gimlet.RouteHandler {
+	return &taskLogSearchHandler{url: h.url}
+}
+
+func (h *taskLogSearchHandler) Parse(ctx context.Context, r *http.Request) error {
+	vars := gimlet.GetVars(r)
+	h.opts = data.TaskLogSearchOptions{
+		VersionID: vars["version_id"],
+		BuildID:   vars["build_id"],
+		TaskID:    vars["task_id"],
+	}
+	if h.opts.VersionID == "" && h.opts.BuildID == "" && h.opts.TaskID == "" {
+		return errors.New("must specify a version, build or task ID")
+	}
+
+	vals := r.URL.Query()
+	pattern := vals.Get("pattern")
+	if pattern == "" {
+		return errors.New("must specify a search pattern")
+	}
+	var err error
+	h.opts.Pattern, err = regexp.Compile(pattern)
+	if err != nil {
+		return errors.Wrapf(err, "invalid search pattern '%s'", pattern)
+	}
+
+	var ok bool
+	h.opts.LogType, ok = logSearchTypes[vals.Get("log_type")]
+	if !ok {
+		return errors.Errorf("invalid log type '%s', must be one of 'all', 'task', 'agent' or 'system'", vals.Get("log_type"))
+	}
+	h.opts.AllExecutions = vals.Get("all_executions") == "true"
+
+	h.opts.ContextLines = defaultLogSearchContextLines
+	if contextLines := vals.Get("context"); contextLines != "" {
+		h.opts.ContextLines, err = strconv.Atoi(contextLines)
+		if err != nil {
+			return errors.Wrap(err, "invalid number of context lines")
+		}
+		if h.opts.ContextLines < 0 || h.opts.ContextLines > maxLogSearchContextLines {
+			return errors.Errorf("number of context lines must be between 0 and %d", maxLogSearchContextLines)
+		}
+	}
+
+	h.opts.Limit, err = getLimit(vals)
+	if err != nil {
+		return errors.Wrap(err, "getting limit")
+	}
+	if h.opts.Limit > maxLogSearchLimit {
+		return errors.Errorf("limit cannot exceed %d", maxLogSearchLimit)
+	}
+	h.opts.StartAt = vals.Get("start_at")
+
+	return nil
+}
+
+// Run searches the task logs, returning a page of matching lines. A page
+// contains at most the limit of matches, but can contain fewer, or none, if
+// the page's bound on the number of logs read was reached first. The Link
+// header has the next page until every log has been searched.
+func (h *taskLogSearchHandler) Run(ctx context.Context) gimlet.Responder {
+	result, err := data.SearchTaskLogs(ctx, h.opts)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "searching task logs"))
+	}
+
+	resp := gimlet.NewResponseBuilder()
+	if result.NextKey != "" {
+		err = resp.SetPages(&gimlet.ResponsePages{
+			Next: &gimlet.Page{
+				Relation:        "next",
+				LimitQueryParam: "limit",
+				KeyQueryParam:   "start_at",
+				BaseURL:         h.url,
+				Key:             result.NextKey,
+				Limit:           h.opts.Limit,
+			},
+		})
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "paginating response"))
+		}
+	}
+
+	for _, match := range result.Matches {
+		apiMatch := &model.APITaskLogSearchMatch{}
+		apiMatch.BuildFromService(match)
+		if err = resp.AddData(apiMatch); err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "adding response data"))
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,86 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogSearchHandlerParse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parse := func(t *testing.T, vars map[string]string, query string) (*taskLogSearchHandler, error) {
+		req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/versions/v1/logs/search?"+query, nil)
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, vars)
+		h := makeSearchTaskLogs("https://example.com").(*taskLogSearchHandler)
+		return h, h.Parse(ctx, req)
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		h, err := parse(t, map[string]string{"version_id": "v1"}, "pattern=seg.*fault")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", h.opts.VersionID)
+		assert.Equal(t, "seg.*fault", h.opts.Pattern.String())
+		assert.Equal(t, apimodels.AllTaskLevelLogs, h.opts.LogType)
+		assert.Equal(t, defaultLogSearchContextLines, h.opts.ContextLines)
+		assert.Equal(t, defaultLimit, h.opts.Limit)
+		assert.False(t, h.opts.AllExecutions)
+	})
+	t.Run("AllOptions", func(t *testing.T) {
+		h, err := parse(t, map[string]string{"build_id": "b1"}, "pattern=segfault&log_type=task&all_executions=true&context=0&limit=10&start_at=t1:0:5")
+		require.NoError(t, err)
+		assert.Equal(t, "b1", h.opts.BuildID)
+		assert.Equal(t, apimodels.TaskLogPrefix, h.opts.LogType)
+		assert.True(t, h.opts.AllExecutions)
+		assert.Zero(t, h.opts.ContextLines)
+		assert.Equal(t, 10, h.opts.Limit)
+		assert.Equal(t, "t1:0:5", h.opts.StartAt)
+	})
+	t.Run("MissingPattern", func(t *testing.T) {
+		_, err := parse(t, map[string]string{"task_id": "t1"}, "")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidPattern", func(t *testing.T) {
+		_, err := parse(t, map[string]string{"task_id": "t1"}, "pattern=(")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidLogType", func(t *testing.T) {
+		_, err := parse(t, map[string]string{"task_id": "t1"}, "pattern=segfault&log_type=test")
+		assert.Error(t, err)
+	})
+	t.Run("TooMuchContext", func(t *testing.T) {
+		_, err := parse(t, map[string]string{"task_id": "t1"}, "pattern=segfault&context=11")
+		assert.Error(t, err)
+	})
+	t.Run("LimitTooHigh", func(t *testing.T) {
+		_, err := parse(t, map[string]string{"task_id": "t1"}, "pattern=segfault&limit=1001")
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskLogSearchHandlerRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("InvalidPageKey", func(t *testing.T) {
+		h := makeSearchTaskLogs("https://example.com").(*taskLogSearchHandler)
+		h.opts = data.TaskLogSearchOptions{
+			TaskID:  "t1",
+			Pattern: regexp.MustCompile("segfault"),
+			Limit:   10,
+			StartAt: "invalid",
+		}
+		resp := h.Run(ctx)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+}
@@ -1,7 +1,9 @@
 package route
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -12,9 +14,11 @@ import (
 	"github.com/evergreen-ci/evergreen/model/artifact"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/utility"
 	"github.com/stretchr/testify/assert"
@@ -318,3 +322,103 @@ func TestGetTaskSyncPath(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, path, expected.S3Path(expected.BuildVariant, expected.DisplayName))
 }
+
+func TestTaskTestResultsPostHandler(t *testing.T) {
+	const batchID = "5f5f5f5f5f5f5f5f5f5f5f5f"
+	makeRequest := func(t *testing.T, query, body string) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/rest/v2/tasks/t1/test_results"+query, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		return gimlet.SetURLVars(req, map[string]string{"task_id": "t1"})
+	}
+	results := []testresult.TestResult{
+		{TestName: "test0", Status: evergreen.TestSucceededStatus},
+		{TaskID: "other_task", Execution: 5, TestName: "test1", Status: evergreen.TestFailedStatus},
+	}
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler){
+		"ParseSucceeds": func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler) {
+			body, err := json.Marshal(results)
+			require.NoError(t, err)
+			require.NoError(t, rh.Parse(ctx, makeRequest(t, "?batch_id="+batchID, string(body))))
+			assert.Equal(t, "t1", rh.taskID)
+			assert.Equal(t, batchID, rh.batchID)
+			assert.Len(t, rh.results, len(results))
+		},
+		"ParseFailsWithInvalidBody": func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler) {
+			assert.Error(t, rh.Parse(ctx, makeRequest(t, "?batch_id="+batchID, `{"test_name": "test0"`)))
+		},
+		"ParseFailsWithoutResults": func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler) {
+			assert.Error(t, rh.Parse(ctx, makeRequest(t, "?batch_id="+batchID, "[]")))
+		},
+		"ParseFailsWithoutBatchID": func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler) {
+			body, err := json.Marshal(results)
+			require.NoError(t, err)
+			assert.Error(t, rh.Parse(ctx, makeRequest(t, "", string(body))))
+		},
+		"RunFailsWithNonexistentTask": func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler) {
+			rh.taskID = "nonexistent"
+			rh.batchID = batchID
+			rh.results = results
+
+			resp := rh.Run(ctx)
+			require.NotNil(t, resp)
+			assert.Equal(t, http.StatusNotFound, resp.Status())
+		},
+		"RunStoresResultsForRouteTask": func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler) {
+			rh.taskID = "t1"
+			rh.batchID = batchID
+			rh.results = results
+
+			resp := rh.Run(ctx)
+			require.NotNil(t, resp)
+			require.Equal(t, http.StatusOK, resp.Status())
+
+			stored, err := testresult.GetMergedTaskTestResults(ctx, env, []testresult.TaskOptions{{TaskID: "t1", Execution: 1, ResultsService: testresult.TestResultsServiceLocal}}, nil)
+			require.NoError(t, err)
+			assert.Len(t, stored.Results, len(results), "results for the wrong task should be stored under the route's task")
+			for _, result := range stored.Results {
+				assert.Equal(t, "t1", result.TaskID)
+				assert.Equal(t, 1, result.Execution)
+			}
+
+			otherTaskStats, err := testresult.GetMergedTaskTestResultsStats(ctx, env, []testresult.TaskOptions{{TaskID: "other_task", Execution: 5, ResultsService: testresult.TestResultsServiceLocal}})
+			require.NoError(t, err)
+			assert.Zero(t, otherTaskStats.TotalCount)
+		},
+		"RunIsIdempotentForRetriedBatch": func(ctx context.Context, t *testing.T, env evergreen.Environment, rh *taskTestResultsPostHandler) {
+			for i := 0; i < 2; i++ {
+				rh.taskID = "t1"
+				rh.batchID = batchID
+				rh.results = []testresult.TestResult{{TestName: "test0", Status: evergreen.TestSucceededStatus}}
+
+				resp := rh.Run(ctx)
+				require.NotNil(t, resp)
+				require.Equal(t, http.StatusOK, resp.Status())
+			}
+
+			stats, err := testresult.GetMergedTaskTestResultsStats(ctx, env, []testresult.TaskOptions{{TaskID: "t1", Execution: 1, ResultsService: testresult.TestResultsServiceLocal}})
+			require.NoError(t, err)
+			assert.Equal(t, 1, stats.TotalCount, "retrying a batch should not duplicate its results")
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			env := testutil.NewEnvironment(ctx, t)
+
+			require.NoError(t, db.Clear(task.Collection))
+			require.NoError(t, testresult.ClearLocal(ctx, env))
+			defer func() {
+				assert.NoError(t, db.Clear(task.Collection))
+				assert.NoError(t, testresult.ClearLocal(ctx, env))
+			}()
+			tsk := task.Task{Id: "t1", Execution: 1}
+			require.NoError(t, tsk.Insert())
+
+			rh, ok := makeTaskTestResultsPostHandler(env).(*taskTestResultsPostHandler)
+			require.True(t, ok)
+
+			tCase(ctx, t, env, rh)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package route
+
+// This file defines the handler for the endpoint to query test flakiness.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/reliability"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+const (
+	// testFlakinessAPIDefaultLimit is the default number of tests returned.
+	testFlakinessAPIDefaultLimit = 100
+	// testFlakinessAPIDefaultNumDays is the default number of days of
+	// statistics to consider.
+	testFlakinessAPIDefaultNumDays = 28
+)
+
+///////////////////////////////////////////////////
+// /projects/<project_id>/test_flakiness handler //
+///////////////////////////////////////////////////
+
+type testFlakinessHandler struct {
+	taskReliabilityHandler
+	filter reliability.TestFlakinessFilter
+}
+
+func makeGetProjectTestFlakiness() gimlet.RouteHandler {
+	return &testFlakinessHandler{}
+}
+
+func (tfh *testFlakinessHandler) Factory() gimlet.RouteHandler {
+	return &testFlakinessHandler{}
+}
+
+// readTestGroupBy parses a group_by parameter value and returns the
+// corresponding grouping.
+func (tfh *testFlakinessHandler) readTestGroupBy(groupByValue string) (reliability.TestGroupBy, error) {
+	switch reliability.TestGroupBy(groupByValue) {
+	case reliability.TestGroupByTask, reliability.TestGroupByVariant:
+		return reliability.TestGroupBy(groupByValue), nil
+	case "":
+		return reliability.TestGroupByTask, nil
+	default:
+		return "", gimlet.ErrorResponse{
+			Message:    fmt.Sprintf("invalid grouping '%s'", groupByValue),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+}
+
+// parseTestFlakinessFilter parses the query parameter values and fills the
+// struct filter field.
+func (tfh *testFlakinessHandler) parseTestFlakinessFilter(vals url.Values) error {
+	var err error
+
+	tfh.filter.Requesters, err = tfh.readRequesters(tfh.readStringList(vals["requesters"]))
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    errors.Wrap(err, "invalid requesters").Error(),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	tfh.filter.Tests = tfh.readStringList(vals["tests"])
+	tfh.filter.Tasks = tfh.readStringList(vals["tasks"])
+	tfh.filter.BuildVariants = tfh.readStringList(vals["variants"])
+
+	tfh.filter.GroupBy, err = tfh.readTestGroupBy(vals.Get("group_by"))
+	if err != nil {
+		return err
+	}
+
+	tfh.filter.MinCommits, err = tfh.readInt(vals.Get("min_commits"), 0, reliabilityAPIMaxNumTasksLimit, 1)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid minimum number of commits",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	tfh.filter.Limit, err = tfh.readInt(vals.Get("limit"), 1, reliabilityAPIMaxNumTasksLimit, testFlakinessAPIDefaultLimit)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid limit",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// before_date, defaults to today
+	beforeDate := tfh.readString(vals.Get("before_date"), getDefaultBeforeDate())
+	tfh.filter.BeforeDate, err = time.ParseInLocation(statsAPIDateFormat, beforeDate, time.UTC)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid 'before' date",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// after_date, defaults to four weeks before the before_date
+	defaultAfterDate := tfh.filter.BeforeDate.Add(-testFlakinessAPIDefaultNumDays * dayInHours).Format(statsAPIDateFormat)
+	afterDate := tfh.readString(vals.Get("after_date"), defaultAfterDate)
+	tfh.filter.AfterDate, err = time.ParseInLocation(statsAPIDateFormat, afterDate, time.UTC)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid 'after' date",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	tfh.filter.Significance, err = tfh.readFloat(vals.Get("significance"), 0.0, 1.0, reliability.DefaultSignificance)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid significance value",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	return nil
+}
+
+func (tfh *testFlakinessHandler) Parse(ctx context.Context, r *http.Request) error {
+	tfh.filter = reliability.TestFlakinessFilter{Project: gimlet.GetVars(r)["project_id"]}
+
+	if err := tfh.parseTestFlakinessFilter(r.URL.Query()); err != nil {
+		return errors.Wrap(err, "parsing test flakiness parameters")
+	}
+	if err := tfh.filter.ValidateForTestFlakiness(); err != nil {
+		return gimlet.ErrorResponse{
+			Message:    errors.Wrap(err, "invalid test flakiness parameters").Error(),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+func (tfh *testFlakinessHandler) Run(ctx context.Context) gimlet.Responder {
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "retrieving service flags"))
+	}
+	if flags.TestFlakinessDisabled {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			Message:    "endpoint is disabled",
+			StatusCode: http.StatusServiceUnavailable,
+		})
+	}
+
+	scores, err := data.GetTestFlakinessScores(tfh.filter)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting test flakiness scores"))
+	}
+
+	resp := gimlet.NewResponseBuilder()
+	for _, score := range scores {
+		if err = resp.AddData(score); err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "adding response data for test '%s' in task '%s'", utility.FromStringPtr(score.TestName), utility.FromStringPtr(score.TaskName)))
+		}
+	}
+
+	return resp
+}
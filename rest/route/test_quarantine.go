@@ -0,0 +1,170 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	dbModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/projects/{project_id}/quarantined_tests
+
+type quarantinedTestsGetHandler struct {
+	projectName string
+}
+
+func makeGetQuarantinedTests() gimlet.RouteHandler {
+	return &quarantinedTestsGetHandler{}
+}
+
+func (h *quarantinedTestsGetHandler) Factory() gimlet.RouteHandler {
+	return &quarantinedTestsGetHandler{}
+}
+
+func (h *quarantinedTestsGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectName = gimlet.GetVars(r)["project_id"]
+	return nil
+}
+
+func (h *quarantinedTestsGetHandler) Run(ctx context.Context) gimlet.Responder {
+	projectID, err := dbModel.GetIdForProject(h.projectName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    err.Error(),
+		})
+	}
+
+	quarantined, err := dbModel.FindQuarantinedTestsForProject(projectID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	res := make([]model.APIQuarantinedTest, len(quarantined))
+	for i, q := range quarantined {
+		res[i].BuildFromService(q)
+	}
+
+	return gimlet.NewJSONResponse(res)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// PUT /rest/v2/projects/{project_id}/quarantined_tests
+
+type quarantinedTestPutHandler struct {
+	projectName string
+	quarantined model.APIQuarantinedTest
+}
+
+func makePutQuarantinedTest() gimlet.RouteHandler {
+	return &quarantinedTestPutHandler{}
+}
+
+func (h *quarantinedTestPutHandler) Factory() gimlet.RouteHandler {
+	return &quarantinedTestPutHandler{}
+}
+
+func (h *quarantinedTestPutHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectName = gimlet.GetVars(r)["project_id"]
+	if err := utility.ReadJSON(r.Body, &h.quarantined); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "reading quarantined test from JSON request body").Error(),
+		}
+	}
+	if utility.FromStringPtr(h.quarantined.Pattern) == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a test name pattern",
+		}
+	}
+	return nil
+}
+
+func (h *quarantinedTestPutHandler) Run(ctx context.Context) gimlet.Responder {
+	projectID, err := dbModel.GetIdForProject(h.projectName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    err.Error(),
+		})
+	}
+
+	q := h.quarantined.ToService()
+	q.ProjectID = projectID
+	q.Author = MustHaveUser(ctx).Username()
+	q.CreatedAt = time.Now()
+	if err = q.Validate(); err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		})
+	}
+	if err = q.Upsert(); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	var res model.APIQuarantinedTest
+	res.BuildFromService(q)
+	return gimlet.NewJSONResponse(res)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// DELETE /rest/v2/projects/{project_id}/quarantined_tests
+
+type quarantinedTestDeleteHandler struct {
+	projectName string
+	pattern     string
+}
+
+func makeDeleteQuarantinedTest() gimlet.RouteHandler {
+	return &quarantinedTestDeleteHandler{}
+}
+
+func (h *quarantinedTestDeleteHandler) Factory() gimlet.RouteHandler {
+	return &quarantinedTestDeleteHandler{}
+}
+
+func (h *quarantinedTestDeleteHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectName = gimlet.GetVars(r)["project_id"]
+	var quarantined model.APIQuarantinedTest
+	if err := utility.ReadJSON(r.Body, &quarantined); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "reading quarantined test from JSON request body").Error(),
+		}
+	}
+	h.pattern = utility.FromStringPtr(quarantined.Pattern)
+	if h.pattern == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a test name pattern",
+		}
+	}
+	return nil
+}
+
+func (h *quarantinedTestDeleteHandler) Run(ctx context.Context) gimlet.Responder {
+	projectID, err := dbModel.GetIdForProject(h.projectName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    err.Error(),
+		})
+	}
+
+	if err = dbModel.RemoveQuarantinedTest(projectID, h.pattern); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
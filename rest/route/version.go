@@ -181,6 +181,72 @@ func (h *buildsForVersionHandler) Run(ctx context.Context) gimlet.Responder {
 	return gimlet.NewJSONResponse(buildModels)
 }
 
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/versions/{version_id}/graph
+
+const (
+	versionGraphFormatJSON = "json"
+	versionGraphFormatDOT  = "dot"
+)
+
+// versionGraphHandler is a RequestHandler for exporting the task dependency
+// graph of a version.
+type versionGraphHandler struct {
+	versionId string
+	format    string
+}
+
+func makeGetVersionGraph() gimlet.RouteHandler {
+	return &versionGraphHandler{}
+}
+
+func (h *versionGraphHandler) Factory() gimlet.RouteHandler {
+	return &versionGraphHandler{}
+}
+
+func (h *versionGraphHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.versionId = gimlet.GetVars(r)["version_id"]
+	if h.versionId == "" {
+		return errors.New("missing version ID")
+	}
+	h.format = r.URL.Query().Get("format")
+	if h.format == "" {
+		h.format = versionGraphFormatJSON
+	}
+	if h.format != versionGraphFormatJSON && h.format != versionGraphFormatDOT {
+		return errors.Errorf("invalid format '%s', must be one of '%s' or '%s'", h.format, versionGraphFormatJSON, versionGraphFormatDOT)
+	}
+	return nil
+}
+
+// Run returns the version's task dependency graph, either as JSON or in the
+// Graphviz DOT language.
+func (h *versionGraphHandler) Run(ctx context.Context) gimlet.Responder {
+	v, err := dbModel.VersionFindOneId(h.versionId)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding version '%s'", h.versionId))
+	}
+	if v == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("version '%s' not found", h.versionId),
+		})
+	}
+
+	vg, err := task.GetVersionGraph(h.versionId)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "getting task graph for version '%s'", h.versionId))
+	}
+	if h.format == versionGraphFormatDOT {
+		return gimlet.NewTextResponse(vg.DOT())
+	}
+
+	graphModel := &model.APIVersionGraph{}
+	graphModel.BuildFromService(*vg)
+	return gimlet.NewJSONResponse(graphModel)
+}
+
 // versionAbortHandler is a RequestHandler for aborting all tasks of a version.
 type versionAbortHandler struct {
 	versionId string
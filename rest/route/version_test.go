@@ -213,6 +213,31 @@ func (s *VersionSuite) TestFindBuildsForVersionByVariant() {
 }
 
 // TestAbortVersion tests the route for aborting a version.
+func (s *VersionSuite) TestGetVersionGraph() {
+	handler := &versionGraphHandler{
+		versionId: versionId,
+		format:    versionGraphFormatJSON,
+	}
+	res := handler.Run(context.TODO())
+	s.Require().Equal(http.StatusOK, res.Status())
+
+	graph, ok := res.Data().(*model.APIVersionGraph)
+	s.Require().True(ok)
+	s.Equal(utility.ToStringPtr(versionId), graph.VersionID)
+	s.Len(graph.Nodes, 3)
+
+	handler.format = versionGraphFormatDOT
+	res = handler.Run(context.TODO())
+	s.Require().Equal(http.StatusOK, res.Status())
+	dot, ok := res.Data().(string)
+	s.Require().True(ok)
+	s.Contains(dot, `"task1"`)
+
+	handler.versionId = "nonexistent"
+	res = handler.Run(context.TODO())
+	s.Equal(http.StatusNotFound, res.Status())
+}
+
 func (s *VersionSuite) TestAbortVersion() {
 	handler := &versionAbortHandler{versionId: "versionId"}
 
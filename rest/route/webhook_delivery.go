@@ -0,0 +1,87 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/subscriptions/{subscription_id}/webhook_deliveries
+
+type webhookDeliveriesGetHandler struct {
+	subscriptionID string
+	limit          int
+}
+
+func makeGetWebhookDeliveries() gimlet.RouteHandler {
+	return &webhookDeliveriesGetHandler{}
+}
+
+func (h *webhookDeliveriesGetHandler) Factory() gimlet.RouteHandler {
+	return &webhookDeliveriesGetHandler{}
+}
+
+func (h *webhookDeliveriesGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.subscriptionID = gimlet.GetVars(r)["subscription_id"]
+	h.limit = data.DefaultWebhookDeliveriesLimit
+	if limit := r.FormValue("limit"); limit != "" {
+		var err error
+		if h.limit, err = strconv.Atoi(limit); err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "invalid limit").Error(),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *webhookDeliveriesGetHandler) Run(ctx context.Context) gimlet.Responder {
+	deliveries, err := data.GetWebhookDeliveries(MustHaveUser(ctx), h.subscriptionID, h.limit)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "getting webhook deliveries for subscription '%s'", h.subscriptionID))
+	}
+
+	return gimlet.NewJSONResponse(deliveries)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/subscriptions/{subscription_id}/webhook_deliveries/{delivery_id}/replay
+
+type webhookDeliveryReplayHandler struct {
+	subscriptionID string
+	deliveryID     string
+}
+
+func makeReplayWebhookDelivery() gimlet.RouteHandler {
+	return &webhookDeliveryReplayHandler{}
+}
+
+func (h *webhookDeliveryReplayHandler) Factory() gimlet.RouteHandler {
+	return &webhookDeliveryReplayHandler{}
+}
+
+func (h *webhookDeliveryReplayHandler) Parse(ctx context.Context, r *http.Request) error {
+	vars := gimlet.GetVars(r)
+	h.subscriptionID = vars["subscription_id"]
+	h.deliveryID = vars["delivery_id"]
+
+	return nil
+}
+
+func (h *webhookDeliveryReplayHandler) Run(ctx context.Context) gimlet.Responder {
+	delivery, err := data.ReplayWebhookDelivery(ctx, MustHaveUser(ctx), h.subscriptionID, h.deliveryID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "replaying webhook delivery '%s'", h.deliveryID))
+	}
+
+	return gimlet.NewJSONResponse(delivery)
+}
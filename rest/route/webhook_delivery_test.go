@@ -0,0 +1,148 @@
+package route
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryRoutes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(event.SubscriptionsCollection, notification.WebhookDeliveriesCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(event.SubscriptionsCollection, notification.WebhookDeliveriesCollection))
+	}()
+
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		receivedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sub := event.Subscription{
+		ID:           "subscription",
+		ResourceType: event.ResourceTypeTask,
+		Trigger:      "outcome",
+		Selectors:    []event.Selector{{Type: event.SelectorObject, Data: "task"}},
+		Subscriber: event.Subscriber{
+			Type:   event.EvergreenWebhookSubscriberType,
+			Target: &event.WebhookSubscriber{URL: server.URL, Secret: []byte("secret")},
+		},
+		Owner:     "me",
+		OwnerType: event.OwnerTypePerson,
+	}
+	require.NoError(t, sub.Upsert())
+	emailSub := event.Subscription{
+		ID:           "email-subscription",
+		ResourceType: event.ResourceTypeTask,
+		Trigger:      "outcome",
+		Selectors:    []event.Selector{{Type: event.SelectorObject, Data: "task"}},
+		Subscriber:   event.Subscriber{Type: event.EmailSubscriberType, Target: "me@example.com"},
+		Owner:        "me",
+		OwnerType:    event.OwnerTypePerson,
+	}
+	require.NoError(t, emailSub.Upsert())
+
+	failed := notification.WebhookDelivery{
+		ID:             "failed",
+		SubscriptionID: sub.ID,
+		NotificationID: "notification",
+		URL:            "https://old.example.com",
+		RequestHeaders: http.Header{"Authorization": []string{"Bearer token"}},
+		RequestBody:    `{"status": "failed"}`,
+		Attempt:        1,
+		StatusCode:     http.StatusServiceUnavailable,
+		Error:          "response was 503 (Service Unavailable)",
+		Latency:        1500 * time.Millisecond,
+		Time:           time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, failed.Insert())
+
+	me := gimlet.AttachUser(ctx, &user.DBUser{Id: "me"})
+	someoneElse := gimlet.AttachUser(ctx, &user.DBUser{Id: "someone-else"})
+
+	getDeliveries := func(ctx context.Context, subscriptionID, query string) gimlet.Responder {
+		rh := makeGetWebhookDeliveries()
+		req, err := http.NewRequest(http.MethodGet, "/subscriptions/"+subscriptionID+"/webhook_deliveries"+query, nil)
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, map[string]string{"subscription_id": subscriptionID})
+		if err = rh.Parse(ctx, req); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+		return rh.Run(ctx)
+	}
+	replay := func(ctx context.Context, subscriptionID, deliveryID string) gimlet.Responder {
+		rh := makeReplayWebhookDelivery()
+		req, err := http.NewRequest(http.MethodPost, "/subscriptions/"+subscriptionID+"/webhook_deliveries/"+deliveryID+"/replay", nil)
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, map[string]string{"subscription_id": subscriptionID, "delivery_id": deliveryID})
+		require.NoError(t, rh.Parse(ctx, req))
+		return rh.Run(ctx)
+	}
+
+	t.Run("ListDeliveries", func(t *testing.T) {
+		resp := getDeliveries(me, sub.ID, "")
+		require.Equal(t, http.StatusOK, resp.Status())
+		deliveries, ok := resp.Data().([]model.APIWebhookDelivery)
+		require.True(t, ok)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "failed", *deliveries[0].ID)
+		assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].StatusCode)
+		assert.EqualValues(t, 1500, deliveries[0].LatencyMS)
+		assert.False(t, deliveries[0].Succeeded)
+		require.Len(t, deliveries[0].RequestHeaders, 1)
+		assert.Equal(t, "Authorization", *deliveries[0].RequestHeaders[0].Key)
+		assert.Empty(t, *deliveries[0].RequestHeaders[0].Value, "sensitive header values should not be returned")
+	})
+	t.Run("InvalidLimit", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, getDeliveries(me, sub.ID, "?limit=abc").Status())
+		assert.Equal(t, http.StatusBadRequest, getDeliveries(me, sub.ID, "?limit=1000").Status())
+	})
+	t.Run("OtherUsersSubscription", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, getDeliveries(someoneElse, sub.ID, "").Status())
+		assert.Equal(t, http.StatusUnauthorized, replay(someoneElse, sub.ID, failed.ID).Status())
+	})
+	t.Run("NonexistentSubscription", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, getDeliveries(me, "nonexistent", "").Status())
+	})
+	t.Run("NotWebhookSubscription", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, getDeliveries(me, emailSub.ID, "").Status())
+	})
+	t.Run("ReplayDeliveryFromOtherSubscription", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, replay(me, emailSub.ID, failed.ID).Status())
+	})
+	t.Run("Replay", func(t *testing.T) {
+		resp := replay(me, sub.ID, failed.ID)
+		require.Equal(t, http.StatusOK, resp.Status())
+		delivery, ok := resp.Data().(*model.APIWebhookDelivery)
+		require.True(t, ok)
+		assert.True(t, delivery.Replay)
+		assert.True(t, delivery.Succeeded)
+		assert.Equal(t, server.URL, *delivery.URL)
+		assert.Equal(t, failed.RequestBody, receivedBody)
+
+		resp = getDeliveries(me, sub.ID, "?limit=1")
+		require.Equal(t, http.StatusOK, resp.Status())
+		deliveries, ok := resp.Data().([]model.APIWebhookDelivery)
+		require.True(t, ok)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, *delivery.ID, *deliveries[0].ID, "replayed delivery should be the most recent")
+	})
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+// FindProjectShares returns the share of the distro's host time that each
+// project is entitled to along with the share of the host time it used
+// within the distro's fair share window ending at the given time. Projects
+// that have tasks queued or that used host time but don't have a configured
+// share split the remaining share equally.
+func FindProjectShares(d *distro.Distro, queued []task.Task, now time.Time) ([]model.ProjectShareInfo, error) {
+	hostTime, err := findProjectHostTime(d.Id, now.Add(-d.PlannerSettings.GetFairShareWindow()), now)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding host time used by projects in distro '%s'", d.Id)
+	}
+
+	return computeProjectShares(d.PlannerSettings, queued, hostTime), nil
+}
+
+// findProjectHostTime returns how much host time each project's tasks spent
+// running in the distro between the given times.
+func findProjectHostTime(distroID string, since, now time.Time) (map[string]time.Duration, error) {
+	tasks, err := task.FindWithFields(task.RanInDistroSince(distroID, since),
+		task.ProjectKey, task.StatusKey, task.StartTimeKey, task.FinishTimeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hostTime := map[string]time.Duration{}
+	for _, t := range tasks {
+		start := t.StartTime
+		if start.Before(since) {
+			start = since
+		}
+		end := t.FinishTime
+		if !t.IsFinished() || end.After(now) {
+			end = now
+		}
+		if end.After(start) {
+			hostTime[t.Project] += end.Sub(start)
+		}
+	}
+
+	return hostTime, nil
+}
+
+func computeProjectShares(settings distro.PlannerSettings, queued []task.Task, hostTime map[string]time.Duration) []model.ProjectShareInfo {
+	projects := map[string]bool{}
+	var configuredShare float64
+	for _, ps := range settings.ProjectShares {
+		projects[ps.Project] = true
+		configuredShare += ps.Share
+	}
+	for _, t := range queued {
+		projects[t.Project] = true
+	}
+	var totalHostTime time.Duration
+	for project, dur := range hostTime {
+		projects[project] = true
+		totalHostTime += dur
+	}
+
+	var numUnconfigured int
+	for project := range projects {
+		if _, ok := settings.GetProjectShare(project); !ok {
+			numUnconfigured++
+		}
+	}
+	var unconfiguredShare float64
+	if numUnconfigured > 0 && configuredShare < 1 {
+		unconfiguredShare = (1 - configuredShare) / float64(numUnconfigured)
+	}
+
+	shares := make([]model.ProjectShareInfo, 0, len(projects))
+	for project := range projects {
+		info := model.ProjectShareInfo{
+			Project:  project,
+			Share:    unconfiguredShare,
+			HostTime: hostTime[project],
+		}
+		if share, ok := settings.GetProjectShare(project); ok {
+			info.Share = share
+		}
+		if totalHostTime > 0 {
+			info.Usage = float64(info.HostTime) / float64(totalHostTime)
+		}
+		shares = append(shares, info)
+	}
+	sort.Slice(shares, func(i, j int) bool { return shares[i].Project < shares[j].Project })
+
+	return shares
+}
+
+// fairShareWeight returns the multiplier for the value of a unit whose
+// project is entitled to the given share of the distro's host time and used
+// the given share of it. Projects that used less than their share are
+// weighted up to 1+factor times higher, and projects that used more are
+// weighted lower the further they are over their share.
+func fairShareWeight(share, usage float64, factor int64) float64 {
+	f := float64(factor)
+	if share <= 0 {
+		return 1 / (1 + f)
+	}
+	return (1 + f) / (1 + f*usage/share)
+}
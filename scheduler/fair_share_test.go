@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeProjectShares(t *testing.T) {
+	settings := distro.PlannerSettings{
+		ProjectShares: []distro.ProjectShare{{Project: "configured", Share: 0.5}},
+	}
+	queued := []task.Task{{Project: "configured"}, {Project: "queued"}}
+	hostTime := map[string]time.Duration{
+		"configured": 3 * time.Hour,
+		"used":       time.Hour,
+	}
+
+	shares := computeProjectShares(settings, queued, hostTime)
+	require.Len(t, shares, 3)
+
+	assert.Equal(t, "configured", shares[0].Project)
+	assert.Equal(t, 0.5, shares[0].Share)
+	assert.Equal(t, 0.75, shares[0].Usage)
+	assert.Equal(t, 3*time.Hour, shares[0].HostTime)
+
+	assert.Equal(t, "queued", shares[1].Project)
+	assert.Equal(t, 0.25, shares[1].Share, "unconfigured projects should split the remaining share")
+	assert.Zero(t, shares[1].Usage)
+
+	assert.Equal(t, "used", shares[2].Project)
+	assert.Equal(t, 0.25, shares[2].Share)
+	assert.Equal(t, 0.25, shares[2].Usage)
+
+	t.Run("NoHostTime", func(t *testing.T) {
+		shares := computeProjectShares(distro.PlannerSettings{}, queued, nil)
+		require.Len(t, shares, 2)
+		for _, share := range shares {
+			assert.Equal(t, 0.5, share.Share)
+			assert.Zero(t, share.Usage)
+		}
+	})
+	t.Run("FullyConfigured", func(t *testing.T) {
+		settings := distro.PlannerSettings{
+			ProjectShares: []distro.ProjectShare{{Project: "configured", Share: 1}},
+		}
+		shares := computeProjectShares(settings, queued, nil)
+		require.Len(t, shares, 2)
+		assert.Equal(t, 1.0, shares[0].Share)
+		assert.Zero(t, shares[1].Share)
+	})
+}
+
+func TestFairShareWeight(t *testing.T) {
+	assert.Equal(t, 2.0, fairShareWeight(0.5, 0, 1))
+	assert.Equal(t, 1.0, fairShareWeight(0.5, 0.5, 1))
+	assert.Equal(t, 1.0, fairShareWeight(0.5, 0.5, 100))
+	assert.Less(t, fairShareWeight(0.5, 1, 10), fairShareWeight(0.5, 1, 1), "a larger factor should penalize overuse more")
+	assert.Equal(t, 0.5, fairShareWeight(0, 0, 1))
+}
+
+func TestFindProjectHostTime(t *testing.T) {
+	require.NoError(t, db.Clear(task.Collection))
+	defer func() {
+		assert.NoError(t, db.Clear(task.Collection))
+	}()
+
+	now := time.Now().Round(time.Second)
+	since := now.Add(-24 * time.Hour)
+	tasks := []task.Task{
+		{
+			Id:         "finished",
+			DistroId:   "distro",
+			Project:    "p1",
+			Status:     evergreen.TaskSucceeded,
+			StartTime:  now.Add(-2 * time.Hour),
+			FinishTime: now.Add(-time.Hour),
+		},
+		{
+			Id:         "started_before_window",
+			DistroId:   "distro",
+			Project:    "p1",
+			Status:     evergreen.TaskFailed,
+			StartTime:  since.Add(-time.Hour),
+			FinishTime: since.Add(time.Hour),
+		},
+		{
+			Id:        "running",
+			DistroId:  "distro",
+			Project:   "p2",
+			Status:    evergreen.TaskStarted,
+			StartTime: now.Add(-30 * time.Minute),
+		},
+		{
+			Id:         "finished_before_window",
+			DistroId:   "distro",
+			Project:    "p2",
+			Status:     evergreen.TaskSucceeded,
+			StartTime:  since.Add(-2 * time.Hour),
+			FinishTime: since.Add(-time.Hour),
+		},
+		{
+			Id:         "other_distro",
+			DistroId:   "other",
+			Project:    "p2",
+			Status:     evergreen.TaskSucceeded,
+			StartTime:  now.Add(-2 * time.Hour),
+			FinishTime: now.Add(-time.Hour),
+		},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, tsk.Insert())
+	}
+
+	hostTime, err := findProjectHostTime("distro", since, now)
+	require.NoError(t, err)
+	assert.Len(t, hostTime, 2)
+	assert.Equal(t, 2*time.Hour, hostTime["p1"])
+	assert.Equal(t, 30*time.Minute, hostTime["p2"])
+}
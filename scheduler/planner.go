@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
 )
@@ -92,10 +93,11 @@ func (cache UnitCache) Export() TaskPlan {
 // and their dependencies, or even all tasks of a version. All tasks
 // in a Unit must be unique with regards to their ID.
 type Unit struct {
-	tasks       map[string]task.Task
-	cachedValue int64
-	id          string
-	distro      *distro.Distro
+	tasks         map[string]task.Task
+	cachedValue   int64
+	id            string
+	distro        *distro.Distro
+	projectShares map[string]model.ProjectShareInfo
 }
 
 // MakeuUnit constructs a new unit, caching a reference to the distro
@@ -140,6 +142,18 @@ func (unit *Unit) SetDistro(d *distro.Distro) {
 	unit.distro = d
 }
 
+// SetProjectShares caches the projects' shares of the distro's host time
+// in the unit, so that the unit's value reflects the fair share of the
+// projects of its tasks.
+func (unit *Unit) SetProjectShares(shares map[string]model.ProjectShareInfo) {
+	if unit == nil {
+		return
+	}
+
+	unit.projectShares = shares
+	unit.cachedValue = 0
+}
+
 // Keys returns all of the ids of tasks in the unit.
 func (unit *Unit) Keys() []string {
 	out := []string{}
@@ -193,6 +207,12 @@ type unitInfo struct {
 	ContainsGenerateTask bool `json:"contains_generate_task"`
 	// ContainsStepbackTask indicates if the unit contains task activated by stepback.
 	ContainsStepbackTask bool `json:"contains_stepback_task"`
+	// HasProjectShare indicates if the projects' shares of the distro's host time are known.
+	HasProjectShare bool `json:"has_project_share"`
+	// ProjectShare is the sum of the shares of the distro's host time that the projects of the tasks in the unit are entitled to.
+	ProjectShare float64 `json:"project_share"`
+	// ProjectUsage is the sum of the shares of the distro's recent host time that the projects of the tasks in the unit used.
+	ProjectUsage float64 `json:"project_usage"`
 }
 
 func (u *unitInfo) value() int64 {
@@ -266,6 +286,15 @@ func (u *unitInfo) value() int64 {
 	// have to execute after shorter running tasks.
 	value += priority * u.Settings.GetExpectedRuntimeFactor() * int64(math.Floor(u.ExpectedRuntime.Minutes()/float64(length)))
 
+	// Finally, weight the value so that projects that have recently
+	// used less than their share of the distro's hosts are preferred
+	// over projects that have used more than their share, to keep a
+	// single project from starving the others.
+	if u.Settings.ShouldUseFairShare() && u.HasProjectShare {
+		weight := fairShareWeight(u.ProjectShare/float64(length), u.ProjectUsage/float64(length), u.Settings.GetFairShareFactor())
+		value = int64(math.Round(float64(value) * weight))
+	}
+
 	return value
 }
 
@@ -295,6 +324,12 @@ func (unit *Unit) info() unitInfo {
 		info.ExpectedRuntime += t.FetchExpectedDuration().Average
 		info.NumDeps += int64(t.NumDependents)
 		info.TaskIDs = append(info.TaskIDs, t.Id)
+
+		if share, ok := unit.projectShares[t.Project]; ok {
+			info.HasProjectShare = true
+			info.ProjectShare += share.Share
+			info.ProjectUsage += share.Usage
+		}
 	}
 
 	return info
@@ -375,6 +410,18 @@ func (tpl TaskPlan) Len() int           { return len(tpl) }
 func (tpl TaskPlan) Less(i, j int) bool { return tpl[i].RankValue() > tpl[j].RankValue() }
 func (tpl TaskPlan) Swap(i, j int)      { tpl[i], tpl[j] = tpl[j], tpl[i] }
 
+// SetProjectShares caches the projects' shares of the distro's host time in
+// all of the units in the plan.
+func (tpl TaskPlan) SetProjectShares(shares []model.ProjectShareInfo) {
+	byProject := make(map[string]model.ProjectShareInfo, len(shares))
+	for _, share := range shares {
+		byProject[share.Project] = share
+	}
+	for _, unit := range tpl {
+		unit.SetProjectShares(byProject)
+	}
+}
+
 func (tpl TaskPlan) Keys() []string {
 	out := []string{}
 	for _, unit := range tpl {
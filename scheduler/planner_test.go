@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -259,6 +261,38 @@ func TestPlanner(t *testing.T) {
 					unit.SetDistro(&distro.Distro{})
 					assert.EqualValues(t, 182, unit.RankValue())
 				})
+				t.Run("FairShare", func(t *testing.T) {
+					fairShareDistro := &distro.Distro{PlannerSettings: distro.PlannerSettings{FairShare: utility.TruePtr()}}
+					t.Run("UnderShare", func(t *testing.T) {
+						unit := NewUnit(task.Task{Id: "foo", Project: "proj"})
+						unit.SetDistro(fairShareDistro)
+						unit.SetProjectShares(map[string]model.ProjectShareInfo{"proj": {Project: "proj", Share: 0.5}})
+						assert.EqualValues(t, 360, unit.RankValue())
+					})
+					t.Run("AtShare", func(t *testing.T) {
+						unit := NewUnit(task.Task{Id: "foo", Project: "proj"})
+						unit.SetDistro(fairShareDistro)
+						unit.SetProjectShares(map[string]model.ProjectShareInfo{"proj": {Project: "proj", Share: 0.5, Usage: 0.5}})
+						assert.EqualValues(t, 180, unit.RankValue())
+					})
+					t.Run("OverShare", func(t *testing.T) {
+						unit := NewUnit(task.Task{Id: "foo", Project: "proj"})
+						unit.SetDistro(fairShareDistro)
+						unit.SetProjectShares(map[string]model.ProjectShareInfo{"proj": {Project: "proj", Share: 0.5, Usage: 1}})
+						assert.EqualValues(t, 120, unit.RankValue())
+					})
+					t.Run("NoShares", func(t *testing.T) {
+						unit := NewUnit(task.Task{Id: "foo", Project: "proj"})
+						unit.SetDistro(fairShareDistro)
+						assert.EqualValues(t, 180, unit.RankValue())
+					})
+					t.Run("Disabled", func(t *testing.T) {
+						unit := NewUnit(task.Task{Id: "foo", Project: "proj"})
+						unit.SetDistro(&distro.Distro{})
+						unit.SetProjectShares(map[string]model.ProjectShareInfo{"proj": {Project: "proj", Share: 0.5}})
+						assert.EqualValues(t, 180, unit.RankValue())
+					})
+				})
 			})
 			t.Run("RankCachesValue", func(t *testing.T) {
 				unit := NewUnit(task.Task{Id: "foo", Priority: 100})
@@ -42,10 +42,21 @@ func runTunablePlanner(d *distro.Distro, tasks []task.Task, opts TaskPlannerOpti
 		return nil, errors.WithStack(err)
 	}
 
-	plan := PrepareTasksForPlanning(d, tasks).Export()
+	taskPlan := PrepareTasksForPlanning(d, tasks)
+	var shares []model.ProjectShareInfo
+	if d.PlannerSettings.ShouldUseFairShare() {
+		shares, err = FindProjectShares(d, tasks, time.Now())
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		taskPlan.SetProjectShares(shares)
+	}
+
+	plan := taskPlan.Export()
 	info := GetDistroQueueInfo(d.Id, plan, d.GetTargetTime(), opts)
 	info.SecondaryQueue = opts.IsSecondaryQueue
 	info.PlanCreatedAt = opts.StartedAt
+	info.ProjectShares = shares
 
 	if err = PersistTaskQueue(d.Id, plan, info); err != nil {
 		return nil, errors.WithStack(err)
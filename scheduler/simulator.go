@@ -0,0 +1,357 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SimulationOptions represent the options for simulating how a distro's
+// queued tasks would be planned with candidate planner settings.
+type SimulationOptions struct {
+	// SnapshotTime is the time at which to take a snapshot of the distro's
+	// queued tasks and busy hosts. Defaults to now.
+	SnapshotTime time.Time
+	// NumHosts is the number of hosts available to run the queued tasks. If
+	// it's not set, only the hosts that were running tasks at the snapshot
+	// time are used.
+	NumHosts int
+	// PlannerSettings are the candidate planner settings. Settings that are
+	// unset keep the distro's current values.
+	PlannerSettings CandidatePlannerSettings
+}
+
+// CandidatePlannerSettings are the planner settings to simulate. Settings
+// that are nil keep the distro's current values, so a factor can be
+// simulated with a value of 0.
+type CandidatePlannerSettings struct {
+	GroupVersions             *bool
+	PatchFactor               *int64
+	PatchTimeInQueueFactor    *int64
+	CommitQueueFactor         *int64
+	MainlineTimeInQueueFactor *int64
+	ExpectedRuntimeFactor     *int64
+	GenerateTaskFactor        *int64
+	StepbackTaskFactor        *int64
+	FairShare                 *bool
+	FairShareFactor           *int64
+	FairShareWindow           *time.Duration
+	ProjectShares             []distro.ProjectShare
+}
+
+// defaultSimulatedTaskDuration is the expected duration of a task that has no
+// cached duration prediction, which is the same default that's used when
+// there's no history to predict the task's duration from.
+const defaultSimulatedTaskDuration = 10 * time.Minute
+
+// RequesterWaitTimes summarizes how long the queued tasks of a single
+// requester are expected to wait before starting.
+type RequesterWaitTimes struct {
+	Requester   string
+	NumTasks    int
+	AverageWait time.Duration
+	MaxWait     time.Duration
+}
+
+// SimulationResult compares the expected wait times of a snapshot of a
+// distro's queued tasks under its current planner settings with those under
+// candidate planner settings. If there were no hosts to run the tasks, the
+// wait times are not simulated.
+type SimulationResult struct {
+	DistroID          string
+	SnapshotTime      time.Time
+	NumTasks          int
+	NumHosts          int
+	CurrentSettings   distro.PlannerSettings
+	CandidateSettings distro.PlannerSettings
+	Current           []RequesterWaitTimes
+	Candidate         []RequesterWaitTimes
+}
+
+// SimulatePlannerSettings replays a snapshot of the distro's queued tasks
+// through the planner with both the distro's current and the candidate
+// planner settings, and estimates how long the tasks of each requester would
+// wait to start under each. A task's wait includes the time it had already
+// spent in the queue at the snapshot time. The tasks' cached expected
+// durations are used as they are, so neither the distro's task queue nor the
+// tasks are modified.
+func SimulatePlannerSettings(d *distro.Distro, opts SimulationOptions) (*SimulationResult, error) {
+	if utility.IsZeroTime(opts.SnapshotTime) {
+		opts.SnapshotTime = time.Now()
+	}
+	if opts.NumHosts < 0 {
+		return nil, errors.New("number of hosts cannot be negative")
+	}
+
+	queued, err := findQueuedTasksAtTime(d.Id, opts.SnapshotTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding queued tasks")
+	}
+	hosts, err := findHostAvailabilityAtTime(d.Id, opts.SnapshotTime, opts.NumHosts)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding busy hosts")
+	}
+
+	candidate := *d
+	candidate.PlannerSettings = mergePlannerSettings(d.PlannerSettings, opts.PlannerSettings)
+	result := &SimulationResult{
+		DistroID:          d.Id,
+		SnapshotTime:      opts.SnapshotTime,
+		NumTasks:          len(queued),
+		NumHosts:          len(hosts),
+		CurrentSettings:   d.PlannerSettings,
+		CandidateSettings: candidate.PlannerSettings,
+	}
+	if len(hosts) == 0 {
+		return result, nil
+	}
+
+	currentShares, err := findProjectSharesAtTime(d, queued, opts.SnapshotTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding current project shares")
+	}
+	candidateShares, err := findProjectSharesAtTime(&candidate, queued, opts.SnapshotTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding candidate project shares")
+	}
+
+	now := time.Now()
+	result.Current = simulateWaitTimes(d, queued, hosts, currentShares, opts.SnapshotTime, now)
+	result.Candidate = simulateWaitTimes(&candidate, queued, hosts, candidateShares, opts.SnapshotTime, now)
+
+	return result, nil
+}
+
+// findQueuedTasksAtTime returns the tasks that were queued in the distro at
+// the given time and whose dependencies had all finished by then.
+func findQueuedTasksAtTime(distroID string, ts time.Time) ([]task.Task, error) {
+	tasks, err := task.Find(task.QueuedInDistroAtTime(distroID, ts))
+	if err != nil {
+		return nil, err
+	}
+
+	var depIDs []string
+	for _, t := range tasks {
+		if t.OverrideDependencies {
+			continue
+		}
+		for _, dep := range t.DependsOn {
+			depIDs = append(depIDs, dep.TaskId)
+		}
+	}
+	finishedDeps := map[string]bool{}
+	if len(depIDs) > 0 {
+		deps, err := task.FindWithFields(bson.M{
+			task.IdKey:         bson.M{"$in": utility.UniqueStrings(depIDs)},
+			task.FinishTimeKey: bson.M{"$gt": utility.ZeroTime, "$lte": ts},
+		}, task.IdKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding finished dependencies")
+		}
+		for _, dep := range deps {
+			finishedDeps[dep.Id] = true
+		}
+	}
+
+	runnable := make([]task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		depsMet := true
+		for _, dep := range t.DependsOn {
+			if !t.OverrideDependencies && !finishedDeps[dep.TaskId] {
+				depsMet = false
+				break
+			}
+		}
+		if !depsMet {
+			continue
+		}
+		pinExpectedDuration(&t, time.Now())
+		runnable = append(runnable, t)
+	}
+
+	return runnable, nil
+}
+
+// findHostAvailabilityAtTime returns how long after the given time each of
+// the distro's hosts became available to run a queued task. If numHosts is
+// set, the result is trimmed or padded with idle hosts to that many hosts.
+func findHostAvailabilityAtTime(distroID string, ts time.Time, numHosts int) ([]time.Duration, error) {
+	running, err := task.Find(task.RunningInDistroAtTime(distroID, ts))
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]time.Duration, 0, len(running))
+	for _, t := range running {
+		var remaining time.Duration
+		if t.IsFinished() {
+			remaining = t.FinishTime.Sub(ts)
+		} else {
+			remaining = t.StartTime.Add(cachedExpectedDuration(t).Average).Sub(ts)
+		}
+		if remaining < 0 {
+			remaining = 0
+		}
+		hosts = append(hosts, remaining)
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i] < hosts[j] })
+
+	if numHosts == 0 {
+		return hosts, nil
+	}
+	if len(hosts) > numHosts {
+		return hosts[:numHosts], nil
+	}
+	idle := make([]time.Duration, numHosts-len(hosts))
+	return append(idle, hosts...), nil
+}
+
+// cachedExpectedDuration returns the task's cached expected duration without
+// refreshing it.
+func cachedExpectedDuration(t task.Task) util.DurationStats {
+	if t.DurationPrediction.Value > 0 {
+		return util.DurationStats{Average: t.DurationPrediction.Value, StdDev: t.DurationPrediction.StdDev}
+	}
+	if t.ExpectedDuration > 0 {
+		return util.DurationStats{Average: t.ExpectedDuration, StdDev: t.ExpectedDurationStdDev}
+	}
+	return util.DurationStats{Average: defaultSimulatedTaskDuration}
+}
+
+// pinExpectedDuration sets the in-memory task's duration prediction to its
+// cached expected duration and marks it as fresh, so that the planner uses it
+// as is instead of recomputing it and saving it to the task.
+func pinExpectedDuration(t *task.Task, now time.Time) {
+	stats := cachedExpectedDuration(*t)
+	t.ExpectedDuration = stats.Average
+	t.ExpectedDurationStdDev = stats.StdDev
+	t.DurationPrediction = util.CachedDurationValue{
+		Value:       stats.Average,
+		StdDev:      stats.StdDev,
+		CollectedAt: now,
+		TTL:         24 * time.Hour,
+	}
+}
+
+// findProjectSharesAtTime returns the projects' shares of the distro's host
+// time as of the given time if the distro plans its tasks using fair share.
+func findProjectSharesAtTime(d *distro.Distro, queued []task.Task, ts time.Time) ([]model.ProjectShareInfo, error) {
+	if !d.PlannerSettings.ShouldUseFairShare() {
+		return nil, nil
+	}
+	return FindProjectShares(d, queued, ts)
+}
+
+// mergePlannerSettings returns the current planner settings overridden by
+// the candidate settings that are set.
+func mergePlannerSettings(current distro.PlannerSettings, candidate CandidatePlannerSettings) distro.PlannerSettings {
+	merged := current
+	if candidate.GroupVersions != nil {
+		merged.GroupVersions = candidate.GroupVersions
+	}
+	if candidate.PatchFactor != nil {
+		merged.PatchFactor = *candidate.PatchFactor
+	}
+	if candidate.PatchTimeInQueueFactor != nil {
+		merged.PatchTimeInQueueFactor = *candidate.PatchTimeInQueueFactor
+	}
+	if candidate.CommitQueueFactor != nil {
+		merged.CommitQueueFactor = *candidate.CommitQueueFactor
+	}
+	if candidate.MainlineTimeInQueueFactor != nil {
+		merged.MainlineTimeInQueueFactor = *candidate.MainlineTimeInQueueFactor
+	}
+	if candidate.ExpectedRuntimeFactor != nil {
+		merged.ExpectedRuntimeFactor = *candidate.ExpectedRuntimeFactor
+	}
+	if candidate.GenerateTaskFactor != nil {
+		merged.GenerateTaskFactor = *candidate.GenerateTaskFactor
+	}
+	if candidate.StepbackTaskFactor != nil {
+		merged.StepbackTaskFactor = *candidate.StepbackTaskFactor
+	}
+	if candidate.FairShare != nil {
+		merged.FairShare = candidate.FairShare
+	}
+	if candidate.FairShareFactor != nil {
+		merged.FairShareFactor = *candidate.FairShareFactor
+	}
+	if candidate.FairShareWindow != nil {
+		merged.FairShareWindow = *candidate.FairShareWindow
+	}
+	if candidate.ProjectShares != nil {
+		merged.ProjectShares = candidate.ProjectShares
+	}
+	return merged
+}
+
+// simulateWaitTimes plans the queued tasks as they were at the snapshot time
+// and estimates how long the tasks of each requester wait to start once
+// dispatched in the planned order to hosts that become available after the
+// given durations. If the projects' shares of the distro's host time are
+// given, the tasks are planned using fair share.
+func simulateWaitTimes(d *distro.Distro, tasks []task.Task, hosts []time.Duration, shares []model.ProjectShareInfo, snapshot, now time.Time) []RequesterWaitTimes {
+	// The planner ranks tasks by how long they have been in the queue as of
+	// now, so shift the tasks' queue times to make the snapshot current.
+	offset := now.Sub(snapshot)
+	queuedSince := make(map[string]time.Time, len(tasks))
+	shifted := make([]task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		queuedSince[t.Id] = t.ActivatedTime
+		if !t.ActivatedTime.IsZero() {
+			t.ActivatedTime = t.ActivatedTime.Add(offset)
+		}
+		if !t.IngestTime.IsZero() {
+			t.IngestTime = t.IngestTime.Add(offset)
+		}
+		shifted = append(shifted, t)
+	}
+
+	plan := PrepareTasksForPlanning(d, shifted)
+	if len(shares) > 0 {
+		plan.SetProjectShares(shares)
+	}
+	planned := plan.Export()
+	durations := make([]time.Duration, 0, len(planned))
+	for _, t := range planned {
+		durations = append(durations, cachedExpectedDuration(t).Average)
+	}
+	startTimes := model.SimulateStartTimes(durations, hosts)
+
+	byRequester := map[string]*RequesterWaitTimes{}
+	totals := map[string]time.Duration{}
+	for i, t := range planned {
+		wait := startTimes[i]
+		if since := queuedSince[t.Id]; !since.IsZero() && since.Before(snapshot) {
+			wait += snapshot.Sub(since)
+		}
+		stats, ok := byRequester[t.Requester]
+		if !ok {
+			stats = &RequesterWaitTimes{Requester: t.Requester}
+			byRequester[t.Requester] = stats
+		}
+		stats.NumTasks++
+		totals[t.Requester] += wait
+		if wait > stats.MaxWait {
+			stats.MaxWait = wait
+		}
+	}
+
+	waitTimes := make([]RequesterWaitTimes, 0, len(byRequester))
+	for requester, stats := range byRequester {
+		stats.AverageWait = totals[requester] / time.Duration(stats.NumTasks)
+		waitTimes = append(waitTimes, *stats)
+	}
+	sort.Slice(waitTimes, func(i, j int) bool {
+		return waitTimes[i].Requester < waitTimes[j].Requester
+	})
+
+	return waitTimes
+}
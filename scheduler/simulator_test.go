@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePlannerSettings(t *testing.T) {
+	current := distro.PlannerSettings{
+		Version:               evergreen.PlannerVersionTunable,
+		PatchFactor:           10,
+		ExpectedRuntimeFactor: 5,
+		GroupVersions:         utility.FalsePtr(),
+	}
+	patchFactor := int64(20)
+	stepbackTaskFactor := int64(3)
+	expectedRuntimeFactor := int64(0)
+	merged := mergePlannerSettings(current, CandidatePlannerSettings{
+		PatchFactor:           &patchFactor,
+		StepbackTaskFactor:    &stepbackTaskFactor,
+		ExpectedRuntimeFactor: &expectedRuntimeFactor,
+		GroupVersions:         utility.TruePtr(),
+		FairShare:             utility.TruePtr(),
+		ProjectShares:         []distro.ProjectShare{{Project: "project", Share: 0.5}},
+	})
+	assert.Equal(t, evergreen.PlannerVersionTunable, merged.Version)
+	assert.EqualValues(t, 20, merged.PatchFactor)
+	assert.Zero(t, merged.ExpectedRuntimeFactor, "factors should be able to be set to 0")
+	assert.Zero(t, merged.MainlineTimeInQueueFactor)
+
+	merged = mergePlannerSettings(current, CandidatePlannerSettings{})
+	assert.Equal(t, current, merged, "unset factors should keep their current value")
+
+	merged = mergePlannerSettings(current, CandidatePlannerSettings{
+		PatchFactor:        &patchFactor,
+		StepbackTaskFactor: &stepbackTaskFactor,
+		GroupVersions:      utility.TruePtr(),
+		FairShare:          utility.TruePtr(),
+		ProjectShares:      []distro.ProjectShare{{Project: "project", Share: 0.5}},
+	})
+	assert.EqualValues(t, 5, merged.ExpectedRuntimeFactor, "unset factors should keep their current value")
+	assert.EqualValues(t, 3, merged.StepbackTaskFactor)
+	assert.True(t, merged.ShouldGroupVersions())
+	assert.True(t, merged.ShouldUseFairShare())
+	assert.Equal(t, []distro.ProjectShare{{Project: "project", Share: 0.5}}, merged.ProjectShares)
+	assert.EqualValues(t, 10, current.PatchFactor, "current settings should not be modified")
+}
+
+func TestPinExpectedDuration(t *testing.T) {
+	now := time.Now()
+	t.Run("UsesCachedPrediction", func(t *testing.T) {
+		tsk := task.Task{
+			ExpectedDuration: time.Hour,
+			DurationPrediction: util.CachedDurationValue{
+				Value:       20 * time.Minute,
+				StdDev:      time.Minute,
+				CollectedAt: now.Add(-30 * 24 * time.Hour),
+				TTL:         time.Minute,
+			},
+		}
+		pinExpectedDuration(&tsk, now)
+		assert.Equal(t, 20*time.Minute, tsk.ExpectedDuration)
+		stats := tsk.FetchExpectedDuration()
+		assert.Equal(t, 20*time.Minute, stats.Average, "stale prediction should not be refreshed")
+		assert.Equal(t, time.Minute, stats.StdDev)
+	})
+	t.Run("FallsBackToExpectedDuration", func(t *testing.T) {
+		tsk := task.Task{ExpectedDuration: time.Hour}
+		pinExpectedDuration(&tsk, now)
+		assert.Equal(t, time.Hour, tsk.FetchExpectedDuration().Average)
+	})
+	t.Run("DefaultsWithoutHistory", func(t *testing.T) {
+		tsk := task.Task{}
+		pinExpectedDuration(&tsk, now)
+		assert.Equal(t, defaultSimulatedTaskDuration, tsk.FetchExpectedDuration().Average)
+	})
+}
+
+func TestSimulateWaitTimes(t *testing.T) {
+	now := time.Now()
+	snapshot := now.Add(-24 * time.Hour)
+	makeTask := func(id, requester string, queuedFor time.Duration) task.Task {
+		return task.Task{
+			Id:            id,
+			Requester:     requester,
+			ActivatedTime: snapshot.Add(-queuedFor),
+			DurationPrediction: util.CachedDurationValue{
+				Value:       10 * time.Minute,
+				CollectedAt: now,
+				TTL:         time.Hour,
+			},
+		}
+	}
+	tasks := []task.Task{
+		makeTask("mainline", evergreen.RepotrackerVersionRequester, 0),
+		makeTask("patch", evergreen.PatchVersionRequester, 0),
+	}
+	hosts := []time.Duration{0}
+
+	d := &distro.Distro{Id: "distro", PlannerSettings: distro.PlannerSettings{PatchFactor: 1000}}
+	waitTimes := simulateWaitTimes(d, tasks, hosts, nil, snapshot, now)
+	require.Len(t, waitTimes, 2)
+	assert.Equal(t, evergreen.RepotrackerVersionRequester, waitTimes[0].Requester)
+	assert.Equal(t, 1, waitTimes[0].NumTasks)
+	assert.Equal(t, 10*time.Minute, waitTimes[0].AverageWait, "mainline task should wait for the patch task")
+	assert.Equal(t, evergreen.PatchVersionRequester, waitTimes[1].Requester)
+	assert.Zero(t, waitTimes[1].AverageWait, "patch task should be planned first")
+
+	t.Run("IncludesTimeQueuedBeforeSnapshot", func(t *testing.T) {
+		tasks := []task.Task{makeTask("patch", evergreen.PatchVersionRequester, time.Hour)}
+		waitTimes := simulateWaitTimes(d, tasks, []time.Duration{5 * time.Minute}, nil, snapshot, now)
+		require.Len(t, waitTimes, 1)
+		assert.Equal(t, time.Hour+5*time.Minute, waitTimes[0].AverageWait)
+		assert.Equal(t, time.Hour+5*time.Minute, waitTimes[0].MaxWait)
+	})
+}
@@ -243,16 +243,16 @@ func (as *APIServer) validateProjectConfig(w http.ResponseWriter, r *http.Reques
 		errs = append(errs, validator.CheckProjectConfigErrors(projectConfig)...)
 	}
 
-	if input.Quiet {
-		errs = errs.AtLevel(validator.Error)
-	} else if projectRef == nil {
+	// Warnings are always checked because the project may raise a warning
+	// rule to an error.
+	errs = append(errs, validator.CheckProjectWarnings(project)...)
+	if projectRef == nil {
 		validationErr = validator.ValidationError{
 			Message: "no project specified; validation will proceed without checking alias coverage",
 			Level:   validator.Warning,
 		}
 		errs = append(errs, validationErr)
 	} else {
-		errs = append(errs, validator.CheckProjectWarnings(project)...)
 		// Check project aliases
 		aliases, err := model.ConstructMergedAliasesByPrecedence(projectRef, projectConfig, projectRef.RepoRefId)
 		if err != nil {
@@ -265,6 +265,11 @@ func (as *APIServer) validateProjectConfig(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	errs = validator.ApplyLintRules(errs, projectConfig)
+	if input.Quiet {
+		errs = errs.AtLevel(validator.Error)
+	}
+
 	if len(errs) > 0 {
 		gimlet.WriteJSONError(w, errs)
 		return
@@ -365,6 +365,15 @@ func MockConfig() *evergreen.Settings {
 				PrivateKeyID: "gce_key_id",
 				TokenURI:     "gce_token",
 			},
+			Libvirt: evergreen.LibvirtConfig{
+				Hypervisors: []evergreen.LibvirtHypervisor{
+					{
+						Name:       "hypervisor",
+						URI:        "qemu+ssh://user@hypervisor/system",
+						VolumePool: "pool",
+					},
+				},
+			},
 			OpenStack: evergreen.OpenStackConfig{
 				IdentityEndpoint: "endpoint",
 				Username:         "username",
@@ -456,11 +465,16 @@ func MockConfig() *evergreen.Settings {
 			SpawnHostsPerUser:         5,
 			UnexpirableHostsPerUser:   2,
 			UnexpirableVolumesPerUser: 2,
+			SnapshotsPerUser:          2,
 		},
 		Tracer: evergreen.TracerConfig{
 			Enabled:           true,
 			CollectorEndpoint: "localhost:4317",
 		},
+		ProjectVarsEncryption: evergreen.ProjectVarsEncryptionConfig{
+			Provider:    evergreen.ProjectVarsEncryptionProviderLocal,
+			KeyringPath: "/etc/evergreen/keyring.yml",
+		},
 		ShutdownWaitSeconds: 15,
 	}
 }
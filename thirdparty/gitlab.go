@@ -0,0 +1,178 @@
+package thirdparty
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+// DefaultGitLabURL is the base URL of the GitLab instance used when a project
+// doesn't specify one.
+const DefaultGitLabURL = "https://gitlab.com"
+
+const gitLabCommitsPerPage = 100
+
+// GitLabCommit is a commit returned by the GitLab REST API.
+type GitLabCommit struct {
+	ID            string    `json:"id"`
+	AuthorName    string    `json:"author_name"`
+	AuthorEmail   string    `json:"author_email"`
+	Message       string    `json:"message"`
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+type gitLabDiff struct {
+	NewPath string `json:"new_path"`
+}
+
+// GitLabProject identifies a project on a GitLab instance.
+type GitLabProject struct {
+	// BaseURL is the base URL of the GitLab instance. If unset, it defaults
+	// to DefaultGitLabURL.
+	BaseURL string
+	// Token is a personal, project or group access token.
+	Token string
+	// Owner is the namespace (user or group path) of the project.
+	Owner string
+	// Repo is the name of the project.
+	Repo string
+}
+
+// GetGitLabFile returns the contents of the file at the given path as of the
+// given ref.
+func GetGitLabFile(ctx context.Context, project GitLabProject, path, ref string) ([]byte, error) {
+	resp, err := gitLabRequest(ctx, project, "/repository/files/"+url.PathEscape(path)+"/raw", url.Values{"ref": []string{ref}})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting file '%s' at ref '%s'", path, ref)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading file '%s' at ref '%s'", path, ref)
+	}
+	return contents, nil
+}
+
+// GetGitLabCommits returns a page of the commits on the branch, from most to
+// least recent, along with the next page number. The next page number is 0 if
+// there are no more pages.
+func GetGitLabCommits(ctx context.Context, project GitLabProject, branch string, page int) ([]GitLabCommit, int, error) {
+	if page <= 0 {
+		page = 1
+	}
+	query := url.Values{
+		"ref_name": []string{branch},
+		"per_page": []string{strconv.Itoa(gitLabCommitsPerPage)},
+		"page":     []string{strconv.Itoa(page)},
+	}
+	commits := []GitLabCommit{}
+	nextPage, err := getGitLabJSON(ctx, project, "/repository/commits", query, &commits)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "getting commits for branch '%s'", branch)
+	}
+	return commits, nextPage, nil
+}
+
+// GetGitLabCommit returns the commit with the given SHA.
+func GetGitLabCommit(ctx context.Context, project GitLabProject, sha string) (*GitLabCommit, error) {
+	commit := &GitLabCommit{}
+	if _, err := getGitLabJSON(ctx, project, "/repository/commits/"+url.PathEscape(sha), nil, commit); err != nil {
+		return nil, errors.Wrapf(err, "getting commit '%s'", sha)
+	}
+	return commit, nil
+}
+
+// GetGitLabCommitFiles returns the paths of the files modified by the commit
+// with the given SHA.
+func GetGitLabCommitFiles(ctx context.Context, project GitLabProject, sha string) ([]string, error) {
+	var files []string
+	for page := 1; page != 0; {
+		diffs := []gitLabDiff{}
+		query := url.Values{
+			"per_page": []string{strconv.Itoa(gitLabCommitsPerPage)},
+			"page":     []string{strconv.Itoa(page)},
+		}
+		var err error
+		page, err = getGitLabJSON(ctx, project, "/repository/commits/"+url.PathEscape(sha)+"/diff", query, &diffs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting diff for commit '%s'", sha)
+		}
+		for _, diff := range diffs {
+			files = append(files, diff.NewPath)
+		}
+	}
+	return files, nil
+}
+
+// GetGitLabMergeBase returns the SHA of the best common ancestor of the two
+// revisions.
+func GetGitLabMergeBase(ctx context.Context, project GitLabProject, revision1, revision2 string) (string, error) {
+	query := url.Values{"refs[]": []string{revision1, revision2}}
+	commit := &GitLabCommit{}
+	if _, err := getGitLabJSON(ctx, project, "/repository/merge_base", query, commit); err != nil {
+		return "", errors.Wrapf(err, "getting merge base of '%s' and '%s'", revision1, revision2)
+	}
+	return commit.ID, nil
+}
+
+func getGitLabJSON(ctx context.Context, project GitLabProject, path string, query url.Values, out interface{}) (int, error) {
+	resp, err := gitLabRequest(ctx, project, path, query)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err = utility.ReadJSON(resp.Body, out); err != nil {
+		return 0, errors.Wrap(err, "reading JSON response body")
+	}
+
+	nextPage, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return nextPage, nil
+}
+
+// gitLabRequest sends a GET request to the project's GitLab API endpoint at
+// the given path. The caller must close the response body.
+func gitLabRequest(ctx context.Context, project GitLabProject, path string, query url.Values) (*http.Response, error) {
+	baseURL := project.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	projectID := url.PathEscape(project.Owner + "/" + project.Repo)
+	u := fmt.Sprintf("%s/api/v4/projects/%s%s", strings.TrimSuffix(baseURL, "/"), projectID, path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating GitLab request")
+	}
+	if project.Token != "" {
+		req.Header.Add("PRIVATE-TOKEN", project.Token)
+	}
+	req.Header.Add("Accept", "application/json")
+
+	client := utility.GetHTTPClient()
+	defer utility.PutHTTPClient(client)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "sending GitLab request")
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("GitLab request for project '%s/%s' returned HTTP status code %d: %s", project.Owner, project.Repo, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
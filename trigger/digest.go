@@ -0,0 +1,428 @@
+package trigger
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TriggerDigest is the trigger of notifications that send a digest.
+	TriggerDigest = "digest"
+
+	digestNoVersion = "other"
+)
+
+// digest is a subscription's accumulated notifications, grouped by version
+// and build.
+type digest struct {
+	SubscriptionID string        `json:"subscription_id"`
+	Start          time.Time     `json:"start"`
+	End            time.Time     `json:"end"`
+	Total          int           `json:"total"`
+	Versions       []digestGroup `json:"versions"`
+}
+
+// digestGroup is the notifications for a version, or for objects that aren't
+// in a version.
+type digestGroup struct {
+	Version      string        `json:"version,omitempty"`
+	Project      string        `json:"project,omitempty"`
+	URL          string        `json:"url,omitempty"`
+	Total        int           `json:"total"`
+	StatusCounts []digestCount `json:"status_counts"`
+	Builds       []digestBuild `json:"builds,omitempty"`
+	Items        []digestItem  `json:"items"`
+
+	buildIndex  map[string]int
+	statusIndex map[string]int
+}
+
+type digestBuild struct {
+	Build        string        `json:"build"`
+	BuildVariant string        `json:"build_variant,omitempty"`
+	Total        int           `json:"total"`
+	StatusCounts []digestCount `json:"status_counts"`
+	statusIndex  map[string]int
+}
+
+type digestCount struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+type digestItem struct {
+	Object      string `json:"object"`
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Trigger     string `json:"trigger"`
+	URL         string `json:"url,omitempty"`
+}
+
+func addDigestCount(counts []digestCount, index map[string]int, status string) []digestCount {
+	if status == "" {
+		status = "unknown"
+	}
+	if i, ok := index[status]; ok {
+		counts[i].Count++
+		return counts
+	}
+	index[status] = len(counts)
+	return append(counts, digestCount{Status: status, Count: 1})
+}
+
+func formatDigestCounts(counts []digestCount) string {
+	parts := make([]string, 0, len(counts))
+	for _, count := range counts {
+		parts = append(parts, fmt.Sprintf("%d %s", count.Count, count.Status))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func digestItemURL(uiBase string, entry *notification.DigestEntry) string {
+	switch entry.Object {
+	case event.ObjectTask:
+		return taskLink(uiBase, entry.ResourceID, -1)
+	case event.ObjectVersion, event.ObjectPatch:
+		return versionLink(versionLinkInput{uiBase: uiBase, versionID: entry.ResourceID})
+	case event.ObjectBuild:
+		return fmt.Sprintf("%s/build/%s?redirect_spruce_users=true", uiBase, url.PathEscape(entry.ResourceID))
+	case event.ObjectHost:
+		return hostLink(uiBase, entry.ResourceID)
+	default:
+		return ""
+	}
+}
+
+// makeDigest groups the entries by version, and the entries in a version by
+// build, counting the statuses of each. Versions are ordered by their number
+// of entries.
+func makeDigest(subscriptionID string, entries []notification.DigestEntry, uiBase string) *digest {
+	d := &digest{SubscriptionID: subscriptionID}
+	groupIndex := map[string]int{}
+	for i := range entries {
+		entry := &entries[i]
+		if d.Start.IsZero() || entry.CreatedAt.Before(d.Start) {
+			d.Start = entry.CreatedAt
+		}
+		if entry.DueAt.After(d.End) {
+			d.End = entry.DueAt
+		}
+
+		key := entry.Version
+		if key == "" {
+			key = digestNoVersion
+		}
+		gi, ok := groupIndex[key]
+		if !ok {
+			gi = len(d.Versions)
+			groupIndex[key] = gi
+			group := digestGroup{
+				Version:     entry.Version,
+				Project:     entry.Project,
+				buildIndex:  map[string]int{},
+				statusIndex: map[string]int{},
+			}
+			if entry.Version != "" {
+				group.URL = versionLink(versionLinkInput{uiBase: uiBase, versionID: entry.Version})
+			}
+			d.Versions = append(d.Versions, group)
+		}
+		group := &d.Versions[gi]
+
+		d.Total++
+		group.Total++
+		group.StatusCounts = addDigestCount(group.StatusCounts, group.statusIndex, entry.Status)
+		group.Items = append(group.Items, digestItem{
+			Object:      entry.Object,
+			ID:          entry.ResourceID,
+			DisplayName: entry.DisplayName,
+			Status:      entry.Status,
+			Trigger:     entry.Trigger,
+			URL:         digestItemURL(uiBase, entry),
+		})
+
+		if entry.Build == "" {
+			continue
+		}
+		bi, ok := group.buildIndex[entry.Build]
+		if !ok {
+			bi = len(group.Builds)
+			group.buildIndex[entry.Build] = bi
+			group.Builds = append(group.Builds, digestBuild{
+				Build:        entry.Build,
+				BuildVariant: entry.BuildVariant,
+				statusIndex:  map[string]int{},
+			})
+		}
+		build := &group.Builds[bi]
+		build.Total++
+		build.StatusCounts = addDigestCount(build.StatusCounts, build.statusIndex, entry.Status)
+	}
+
+	sort.SliceStable(d.Versions, func(i, j int) bool {
+		return d.Versions[i].Total > d.Versions[j].Total
+	})
+
+	return d
+}
+
+func (d *digest) summary() string {
+	versions := 0
+	for _, group := range d.Versions {
+		if group.Version != "" {
+			versions++
+		}
+	}
+	summary := fmt.Sprintf("Evergreen digest: %d notification", d.Total)
+	if d.Total != 1 {
+		summary += "s"
+	}
+	if versions > 0 {
+		summary += fmt.Sprintf(" across %d version", versions)
+		if versions != 1 {
+			summary += "s"
+		}
+	}
+	return summary
+}
+
+func (g *digestGroup) title() string {
+	if g.Version == "" {
+		return "Other notifications"
+	}
+	if g.Project == "" {
+		return fmt.Sprintf("Version %s", g.Version)
+	}
+	return fmt.Sprintf("Version %s in '%s'", g.Version, g.Project)
+}
+
+func (g *digestBuild) title() string {
+	if g.BuildVariant != "" {
+		return g.BuildVariant
+	}
+	return g.Build
+}
+
+// DigestNotification creates a single notification for the subscription that
+// summarizes the digest entries.
+func DigestNotification(sub *event.Subscription, entries []notification.DigestEntry, uiBase string) (*notification.Notification, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("cannot create a digest with no entries")
+	}
+
+	d := makeDigest(sub.ID, entries, uiBase)
+	var payload interface{}
+	var err error
+	switch sub.Subscriber.Type {
+	case event.EmailSubscriberType:
+		payload, err = d.email()
+	case event.SlackSubscriberType:
+		payload = d.slack()
+	case event.ChatWebhookSubscriberType:
+		payload = notification.NewChatPayload(d.slack())
+	case event.JIRACommentSubscriberType:
+		payload = d.jiraComment()
+	case event.EvergreenWebhookSubscriberType:
+		payload, err = d.webhook()
+	default:
+		return nil, errors.Errorf("subscriber type '%s' does not support digests", sub.Subscriber.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating digest payload for subscriber type '%s'", sub.Subscriber.Type)
+	}
+
+	n, err := notification.New(fmt.Sprintf("%s-%d", sub.ID, d.End.Unix()), TriggerDigest, &sub.Subscriber, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating digest notification")
+	}
+	n.SetSubscriptionMetadata(sub.ID)
+
+	return n, nil
+}
+
+func (d *digest) slack() *notification.SlackPayload {
+	payload := &notification.SlackPayload{Body: d.summary()}
+	for i, group := range d.Versions {
+		if i == slackAttachmentsLimit {
+			payload.Attachments[len(payload.Attachments)-1].Footer = fmt.Sprintf("and %d more", len(d.Versions)-slackAttachmentsLimit)
+			break
+		}
+		attachment := message.SlackAttachment{
+			Title:     group.title(),
+			TitleLink: group.URL,
+			Text:      formatDigestCounts(group.StatusCounts),
+			Color:     evergreenSuccessColor,
+		}
+		if hasFailedStatus(group.StatusCounts) {
+			attachment.Color = evergreenFailColor
+		}
+		for _, build := range group.Builds {
+			attachment.Fields = append(attachment.Fields, &message.SlackAttachmentField{
+				Title: build.title(),
+				Value: formatDigestCounts(build.StatusCounts),
+				Short: true,
+			})
+		}
+		payload.Attachments = append(payload.Attachments, attachment)
+	}
+
+	if len(payload.Attachments) > 0 {
+		footer := fmt.Sprintf("Subscription: %s", d.SubscriptionID)
+		last := &payload.Attachments[len(payload.Attachments)-1]
+		if last.Footer != "" {
+			footer = last.Footer + "; " + footer
+		}
+		last.Footer = footer
+	}
+
+	return payload
+}
+
+func hasFailedStatus(counts []digestCount) bool {
+	for _, count := range counts {
+		if strings.Contains(count.Status, "fail") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *digest) jiraComment() *string {
+	lines := []string{d.summary()}
+	for _, group := range d.Versions {
+		title := group.title()
+		if group.URL != "" {
+			title = fmt.Sprintf("[%s|%s]", title, group.URL)
+		}
+		lines = append(lines, fmt.Sprintf("* %s: %s", title, formatDigestCounts(group.StatusCounts)))
+		for _, build := range group.Builds {
+			lines = append(lines, fmt.Sprintf("** %s: %s", build.title(), formatDigestCounts(build.StatusCounts)))
+		}
+	}
+	comment := strings.Join(lines, "\n")
+	return &comment
+}
+
+func (d *digest) webhook() (*util.EvergreenWebhook, error) {
+	headers := http.Header{}
+	headers[evergreenHeaderPrefix+"object"] = []string{"digest"}
+	return webhookPayload(d, headers)
+}
+
+const emailDigestTemplateString = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+</head>
+<body>
+<p>{{ .Summary }} between {{ .Start.Format "Jan 2 15:04 MST" }} and {{ .End.Format "Jan 2 15:04 MST" }}.</p>
+{{ range .Versions }}
+<h3>{{ if .URL }}<a href="{{ .URL }}">{{ .Title }}</a>{{ else }}{{ .Title }}{{ end }}</h3>
+<p>{{ .Counts }}</p>
+{{ if .Builds }}
+<table cellpadding="4">
+{{ range .Builds }}
+<tr><td><b>{{ .Title }}</b></td><td>{{ .Counts }}</td></tr>
+{{ end }}
+</table>
+{{ end }}
+<ul>
+{{ range .Items }}
+<li>{{ if .URL }}<a href="{{ .URL }}">{{ .Name }}</a>{{ else }}{{ .Name }}{{ end }}{{ if .Status }}: {{ .Status }}{{ end }}</li>
+{{ end }}
+</ul>
+{{ end }}
+<p>Subscription: {{ .SubscriptionID }}</p>
+</body>
+</html>
+`
+
+var emailDigestTemplate = template.Must(template.New("digest").Parse(emailDigestTemplateString))
+
+type emailDigestData struct {
+	Summary        string
+	SubscriptionID string
+	Start          time.Time
+	End            time.Time
+	Versions       []emailDigestGroup
+}
+
+type emailDigestGroup struct {
+	Title  string
+	URL    string
+	Counts string
+	Builds []emailDigestRow
+	Items  []emailDigestRow
+}
+
+type emailDigestRow struct {
+	Title  string
+	Name   string
+	URL    string
+	Status string
+	Counts string
+}
+
+func (d *digest) email() (*message.Email, error) {
+	data := emailDigestData{
+		Summary:        d.summary(),
+		SubscriptionID: d.SubscriptionID,
+		Start:          d.Start,
+		End:            d.End,
+	}
+	for _, group := range d.Versions {
+		emailGroup := emailDigestGroup{
+			Title:  group.title(),
+			URL:    group.URL,
+			Counts: formatDigestCounts(group.StatusCounts),
+		}
+		for _, build := range group.Builds {
+			emailGroup.Builds = append(emailGroup.Builds, emailDigestRow{
+				Title:  build.title(),
+				Counts: formatDigestCounts(build.StatusCounts),
+			})
+		}
+		for _, item := range group.Items {
+			name := item.DisplayName
+			if name == "" {
+				name = item.ID
+			}
+			emailGroup.Items = append(emailGroup.Items, emailDigestRow{
+				Name:   fmt.Sprintf("%s %s", item.Object, name),
+				URL:    item.URL,
+				Status: item.Status,
+			})
+		}
+		data.Versions = append(data.Versions, emailGroup)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := emailDigestTemplate.Execute(buf, data); err != nil {
+		return nil, errors.Wrap(err, "executing email digest template")
+	}
+
+	headers := http.Header{}
+	headers[evergreenHeaderPrefix+"object"] = []string{"digest"}
+	// prevent Gmail from threading digests together
+	headers["X-Entity-Ref-Id"] = []string{fmt.Sprintf("digest-%s-%d", d.SubscriptionID, d.End.Unix())}
+
+	return &message.Email{
+		Subject:           data.Summary,
+		Body:              buf.String(),
+		PlainTextContents: false,
+		Headers:           headers,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package trigger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeDigestTestEntries() []notification.DigestEntry {
+	due := time.Date(2023, 5, 1, 12, 15, 0, 0, time.UTC)
+	entry := func(id, object, status, version, build, variant string) notification.DigestEntry {
+		return notification.DigestEntry{
+			ID:             id,
+			SubscriptionID: "sub",
+			Trigger:        event.TriggerFailure,
+			Object:         object,
+			ResourceID:     id,
+			DisplayName:    "display-" + id,
+			Status:         status,
+			Project:        "mci",
+			Version:        version,
+			Build:          build,
+			BuildVariant:   variant,
+			CreatedAt:      due.Add(-10 * time.Minute),
+			DueAt:          due,
+		}
+	}
+	return []notification.DigestEntry{
+		entry("t1", event.ObjectTask, evergreen.TaskFailed, "v1", "b1", "ubuntu"),
+		entry("t2", event.ObjectTask, evergreen.TaskFailed, "v1", "b1", "ubuntu"),
+		entry("t3", event.ObjectTask, evergreen.TaskSucceeded, "v1", "b2", "windows"),
+		entry("t4", event.ObjectTask, evergreen.TaskFailed, "v2", "b3", "ubuntu"),
+		entry("v1", event.ObjectVersion, evergreen.VersionFailed, "v1", "", ""),
+		entry("h1", event.ObjectHost, "", "", "", ""),
+	}
+}
+
+func TestMakeDigest(t *testing.T) {
+	d := makeDigest("sub", makeDigestTestEntries(), "https://evergreen.example.com")
+	assert.Equal(t, "sub", d.SubscriptionID)
+	assert.Equal(t, 6, d.Total)
+	assert.Equal(t, time.Date(2023, 5, 1, 12, 15, 0, 0, time.UTC), d.End)
+	assert.Equal(t, "Evergreen digest: 6 notifications across 2 versions", d.summary())
+
+	require.Len(t, d.Versions, 3)
+	v1 := d.Versions[0]
+	assert.Equal(t, "v1", v1.Version)
+	assert.Equal(t, "Version v1 in 'mci'", v1.title())
+	assert.Equal(t, 4, v1.Total)
+	assert.Contains(t, v1.URL, "/version/v1")
+	assert.Equal(t, []digestCount{{Status: evergreen.TaskFailed, Count: 3}, {Status: evergreen.TaskSucceeded, Count: 1}}, v1.StatusCounts)
+	require.Len(t, v1.Builds, 2)
+	assert.Equal(t, "ubuntu", v1.Builds[0].title())
+	assert.Equal(t, 2, v1.Builds[0].Total)
+	assert.Equal(t, "windows", v1.Builds[1].title())
+	assert.Len(t, v1.Items, 4)
+
+	assert.Equal(t, "v2", d.Versions[1].Version)
+	assert.Equal(t, 1, d.Versions[1].Total)
+
+	other := d.Versions[2]
+	assert.Empty(t, other.Version)
+	assert.Equal(t, "Other notifications", other.title())
+	assert.Equal(t, []digestCount{{Status: "unknown", Count: 1}}, other.StatusCounts)
+	require.Len(t, other.Items, 1)
+	assert.Contains(t, other.Items[0].URL, "/host/h1")
+}
+
+func TestDigestNotification(t *testing.T) {
+	entries := makeDigestTestEntries()
+	email := "me@example.com"
+	sub := &event.Subscription{
+		ID:                    "sub",
+		DigestIntervalMinutes: 15,
+		Subscriber: event.Subscriber{
+			Type:   event.EmailSubscriberType,
+			Target: &email,
+		},
+	}
+
+	t.Run("Email", func(t *testing.T) {
+		n, err := DigestNotification(sub, entries, "https://evergreen.example.com")
+		require.NoError(t, err)
+		require.NotNil(t, n)
+		assert.Equal(t, "sub", n.Metadata.SubscriptionID)
+		payload, ok := n.Payload.(*message.Email)
+		require.True(t, ok)
+		assert.Equal(t, "Evergreen digest: 6 notifications across 2 versions", payload.Subject)
+		assert.Contains(t, payload.Body, "Version v1 in &#39;mci&#39;")
+		assert.Contains(t, payload.Body, "2 failed")
+		assert.Contains(t, payload.Body, "task display-t1")
+	})
+	t.Run("Slack", func(t *testing.T) {
+		slackSub := *sub
+		slackSub.Subscriber = event.Subscriber{Type: event.SlackSubscriberType, Target: "#evergreen"}
+		n, err := DigestNotification(&slackSub, entries, "https://evergreen.example.com")
+		require.NoError(t, err)
+		payload, ok := n.Payload.(*notification.SlackPayload)
+		require.True(t, ok)
+		require.Len(t, payload.Attachments, 3)
+		assert.Equal(t, "Version v1 in 'mci'", payload.Attachments[0].Title)
+		assert.Equal(t, evergreenFailColor, payload.Attachments[0].Color)
+		require.Len(t, payload.Attachments[0].Fields, 2)
+		assert.Equal(t, "2 failed", payload.Attachments[0].Fields[0].Value)
+		assert.Equal(t, "Subscription: sub", payload.Attachments[2].Footer)
+	})
+	t.Run("ChatWebhook", func(t *testing.T) {
+		chatSub := *sub
+		chatSub.Subscriber = event.NewChatWebhookSubscriber(event.ChatWebhookSubscriber{URL: "https://chat.example.com", Format: event.ChatWebhookFormatMarkdown})
+		n, err := DigestNotification(&chatSub, entries, "https://evergreen.example.com")
+		require.NoError(t, err)
+		payload, ok := n.Payload.(*notification.ChatPayload)
+		require.True(t, ok)
+		assert.Len(t, payload.Sections, 3)
+	})
+	t.Run("Webhook", func(t *testing.T) {
+		webhookSub := *sub
+		webhookSub.Subscriber = event.Subscriber{Type: event.EvergreenWebhookSubscriberType, Target: &event.WebhookSubscriber{URL: "https://example.com", Secret: []byte("shh")}}
+		n, err := DigestNotification(&webhookSub, entries, "https://evergreen.example.com")
+		require.NoError(t, err)
+		payload, ok := n.Payload.(*util.EvergreenWebhook)
+		require.True(t, ok)
+		assert.Equal(t, []string{"digest"}, payload.Headers["X-Evergreen-object"])
+		body := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(payload.Body, &body))
+		assert.EqualValues(t, 6, body["total"])
+	})
+	t.Run("JIRAComment", func(t *testing.T) {
+		jiraSub := *sub
+		jiraSub.Subscriber = event.Subscriber{Type: event.JIRACommentSubscriberType, Target: "BF-1"}
+		n, err := DigestNotification(&jiraSub, entries, "https://evergreen.example.com")
+		require.NoError(t, err)
+		payload, ok := n.Payload.(*string)
+		require.True(t, ok)
+		assert.Contains(t, *payload, "** ubuntu: 2 failed")
+	})
+	t.Run("UnsupportedSubscriber", func(t *testing.T) {
+		githubSub := *sub
+		githubSub.Subscriber = event.Subscriber{Type: event.GithubCheckSubscriberType}
+		_, err := DigestNotification(&githubSub, entries, "https://evergreen.example.com")
+		assert.Error(t, err)
+	})
+	t.Run("NoEntries", func(t *testing.T) {
+		_, err := DigestNotification(sub, nil, "https://evergreen.example.com")
+		assert.Error(t, err)
+	})
+}
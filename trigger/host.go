@@ -118,6 +118,8 @@ func (t *hostTriggers) generate(sub *event.Subscription) (*notification.Notifica
 		payload, err = t.templateData.hostExpirationEmailPayload(expiringHostEmailSubject, expiringHostEmailBody, t.Attributes())
 	case event.SlackSubscriberType:
 		payload, err = t.templateData.hostExpirationSlackPayload(expiringHostSlackBody, expiringHostSlackAttachmentTitle)
+	case event.ChatWebhookSubscriberType:
+		payload, err = t.templateData.hostExpirationChatPayload(expiringHostSlackBody, expiringHostSlackAttachmentTitle)
 	default:
 		return nil, nil
 	}
@@ -176,6 +178,14 @@ func (t *hostTemplateData) hostExpirationSlackPayload(messageString string, link
 	}, nil
 }
 
+func (t *hostTemplateData) hostExpirationChatPayload(messageString string, linkTitle string) (*notification.ChatPayload, error) {
+	payload, err := t.hostExpirationSlackPayload(messageString, linkTitle)
+	if err != nil {
+		return nil, err
+	}
+	return notification.NewChatPayload(payload), nil
+}
+
 func (t *hostTriggers) hostExpiration(sub *event.Subscription) (*notification.Notification, error) {
 	timeZone := time.Local
 	if sub.OwnerType == event.OwnerTypePerson {
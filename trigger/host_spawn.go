@@ -126,6 +126,8 @@ func (t *spawnHostProvisioningTriggers) makePayload(sub *event.Subscription) int
 	switch sub.Subscriber.Type {
 	case event.SlackSubscriberType:
 		return t.slack()
+	case event.ChatWebhookSubscriberType:
+		return notification.NewChatPayload(t.slack())
 
 	case event.EmailSubscriberType:
 		return t.email()
@@ -212,6 +214,9 @@ func (t *spawnHostStateChangeTriggers) makePayload(sub *event.Subscription) (int
 	case event.SlackSubscriberType:
 		return t.slackPayload(action, result, t.host.Id, spawnHostURL(t.uiConfig.Url), hostURL(t.uiConfig.Url, t.host.Id)), nil
 
+	case event.ChatWebhookSubscriberType:
+		return notification.NewChatPayload(t.slackPayload(action, result, t.host.Id, spawnHostURL(t.uiConfig.Url), hostURL(t.uiConfig.Url, t.host.Id))), nil
+
 	case event.EmailSubscriberType:
 		return t.emailPayload(action, result, t.host.Id, spawnHostURL(t.uiConfig.Url), hostURL(t.uiConfig.Url, t.host.Id)), nil
 
@@ -446,6 +446,13 @@ func makeCommonPayload(sub *event.Subscription, eventAttributes event.Attributes
 
 	case event.SlackSubscriberType:
 		return slack(data)
+
+	case event.ChatWebhookSubscriberType:
+		payload, err := slack(data)
+		if err != nil {
+			return nil, err
+		}
+		return notification.NewChatPayload(payload), nil
 	}
 
 	return nil, errors.Errorf("unknown subscriber type '%s'", sub.Subscriber.Type)
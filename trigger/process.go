@@ -54,6 +54,7 @@ func NotificationsFromEvent(e *event.EventLogEntry) ([]notification.Notification
 	}
 
 	notifications := make([]notification.Notification, 0, len(subscriptions))
+	digestEntries := []notification.DigestEntry{}
 
 	catcher := grip.NewSimpleCatcher()
 	for i := range subscriptions {
@@ -78,8 +79,17 @@ func NotificationsFromEvent(e *event.EventLogEntry) ([]notification.Notification
 			continue
 		}
 
+		// Notifications for digest subscriptions are held until the end of
+		// the digest interval and then sent together.
+		if subscriptions[i].DigestIntervalMinutes > 0 {
+			digestEntries = append(digestEntries, notification.NewDigestEntry(e.ID, &subscriptions[i], h.Attributes(), time.Now()))
+			continue
+		}
+
+		n.SetSubscriptionMetadata(subscriptions[i].ID)
 		notifications = append(notifications, *n)
 	}
+	catcher.Wrapf(notification.InsertDigestEntries(digestEntries...), "adding digest entries for event '%s'", e.ID)
 
 	return notifications, catcher.Resolve()
 }
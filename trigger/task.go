@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/evergreen/model"
@@ -40,6 +41,7 @@ const (
 	triggerBuildBreak                        = "build-break"
 	keyFailureType                           = "failure-type"
 	triggerTaskFailedOrBlocked               = "task-failed-or-blocked"
+	triggerTaskCommandRegression             = "command-regression"
 )
 
 func makeTaskTriggers() eventHandler {
@@ -60,6 +62,7 @@ func makeTaskTriggers() eventHandler {
 		triggerTaskRegressionByTest:              t.taskRegressionByTest,
 		triggerBuildBreak:                        t.buildBreak,
 		triggerTaskFailedOrBlocked:               t.taskFailedOrBlocked,
+		triggerTaskCommandRegression:             t.taskCommandRegression,
 	}
 
 	return t
@@ -708,6 +711,118 @@ func (t *taskTriggers) taskRuntimeChange(sub *event.Subscription) (*notification
 	return t.generate(sub, fmt.Sprintf("changed in runtime by %.1f%% (over threshold of %s%%)", percentChange, percentString), "")
 }
 
+func (t *taskTriggers) taskCommandRegression(sub *event.Subscription) (*notification.Notification, error) {
+	if t.task.IsPartOfDisplay() || len(t.task.Details.CommandStats) == 0 {
+		return nil, nil
+	}
+	// Only mainline tasks are ordered by revision, so patches have no
+	// previous task to compare against.
+	if !utility.StringSliceContains(evergreen.SystemVersionRequesterTypes, t.task.Requester) {
+		return nil, nil
+	}
+
+	thresholds := commandRegressionThresholds{}
+	var err error
+	if percentString, ok := sub.TriggerData[event.CommandMemoryPercentChangeKey]; ok {
+		if thresholds.memoryPercent, err = strconv.ParseFloat(percentString, 64); err != nil {
+			return nil, errors.Errorf("subscription '%s' has an invalid memory percentage", sub.ID)
+		}
+	}
+	if percentString, ok := sub.TriggerData[event.CommandDurationPercentChangeKey]; ok {
+		if thresholds.durationPercent, err = strconv.ParseFloat(percentString, 64); err != nil {
+			return nil, errors.Errorf("subscription '%s' has an invalid duration percentage", sub.ID)
+		}
+	}
+	if thresholds.memoryPercent <= 0 && thresholds.durationPercent <= 0 {
+		return nil, errors.Errorf("subscription '%s' has no command memory or duration percentage increase", sub.ID)
+	}
+
+	previousTask, err := t.task.PreviousCompletedTask(t.task.Project, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving previous task")
+	}
+	if previousTask == nil {
+		return nil, nil
+	}
+
+	regressions := findCommandRegressions(previousTask.Details.CommandStats, t.task.Details.CommandStats, thresholds)
+	if len(regressions) == 0 {
+		return nil, nil
+	}
+	description := regressions[0].String()
+	if len(regressions) > 1 {
+		description = fmt.Sprintf("%s and %d other command regression(s)", description, len(regressions)-1)
+	}
+	return t.generate(sub, fmt.Sprintf("regressed in %s", description), "")
+}
+
+const (
+	// minCommandDurationRegression and minCommandMemoryRegression are the
+	// smallest increases in a command's duration and peak memory that count
+	// as a regression, so that small commands whose usage is noisy don't
+	// regress by a large percentage of very little.
+	minCommandDurationRegression = 30 * time.Second
+	minCommandMemoryRegression   = 64 * 1024 * 1024
+)
+
+// commandRegressionThresholds are the percentage increases in a command's
+// peak memory and duration that count as a regression. A threshold of zero
+// is not checked.
+type commandRegressionThresholds struct {
+	memoryPercent   float64
+	durationPercent float64
+}
+
+// commandRegression is an increase in a command's resource usage from the
+// previous task.
+type commandRegression struct {
+	command       string
+	resource      string
+	percentChange float64
+}
+
+func (r commandRegression) String() string {
+	return fmt.Sprintf("%s of command %s (+%.1f%%)", r.resource, r.command, r.percentChange)
+}
+
+// findCommandRegressions compares each command's stats to the stats of the
+// same command in the previous task, returning the commands whose peak memory
+// or duration increased beyond the thresholds. Increases smaller than
+// minCommandMemoryRegression or minCommandDurationRegression are not
+// regressions.
+func findCommandRegressions(previous, current []apimodels.CommandStats, thresholds commandRegressionThresholds) []commandRegression {
+	var regressions []commandRegression
+	for _, cur := range current {
+		for _, prev := range previous {
+			if !cur.SameCommand(prev) {
+				continue
+			}
+			if thresholds.memoryPercent > 0 && cur.PeakRSSBytes >= prev.PeakRSSBytes+minCommandMemoryRegression {
+				if exceeds, percent := increaseExceedsThreshold(thresholds.memoryPercent, float64(prev.PeakRSSBytes), float64(cur.PeakRSSBytes)); exceeds {
+					regressions = append(regressions, commandRegression{command: cur.Command, resource: "peak memory", percentChange: percent})
+				}
+			}
+			if thresholds.durationPercent > 0 && cur.Duration-prev.Duration >= minCommandDurationRegression {
+				if exceeds, percent := increaseExceedsThreshold(thresholds.durationPercent, float64(prev.Duration), float64(cur.Duration)); exceeds {
+					regressions = append(regressions, commandRegression{command: cur.Command, resource: "duration", percentChange: percent})
+				}
+			}
+			break
+		}
+	}
+	return regressions
+}
+
+// increaseExceedsThreshold returns whether the value increased by at least
+// the threshold percentage, and the percentage increase.
+func increaseExceedsThreshold(threshold, prev, cur float64) (bool, float64) {
+	if prev <= 0 || cur <= prev {
+		return false, 0
+	}
+	percentChange := 100*cur/prev - 100
+	return percentChange >= threshold, percentChange
+}
+
 // isValidFailedTaskStatus only matches task statuses that should be triggered for failure.
 // For example, it excludes  setup failures.
 func isValidFailedTaskStatus(status string) bool {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/evergreen/model"
@@ -1231,6 +1232,118 @@ func (s *taskSuite) TestTaskRuntimeChange() {
 	s.NotNil(n)
 }
 
+const mib = 1024 * 1024
+
+func (s *taskSuite) TestTaskCommandRegression() {
+	sub := event.Subscription{
+		ID:           mgobson.NewObjectId().Hex(),
+		ResourceType: event.ResourceTypeTask,
+		Trigger:      triggerTaskCommandRegression,
+		Selectors: []event.Selector{
+			{
+				Type: "id",
+				Data: s.event.ResourceId,
+			},
+		},
+		Subscriber: s.subs[0].Subscriber,
+		TriggerData: map[string]string{
+			event.CommandMemoryPercentChangeKey:   "50",
+			event.CommandDurationPercentChangeKey: "100",
+		},
+	}
+	s.t.task.Details.CommandStats = []apimodels.CommandStats{
+		{Command: "'shell.exec'", Block: "task", Step: "1", Duration: 10 * time.Minute, PeakRSSBytes: 1000 * mib},
+	}
+
+	// no previous task should not generate
+	n, err := s.t.taskCommandRegression(&sub)
+	s.NoError(err)
+	s.Nil(n)
+
+	previous := task.Task{
+		Id:                  "test1",
+		BuildVariant:        "test_build_variant",
+		DistroId:            "test_distro_id",
+		Project:             "test_project",
+		DisplayName:         "test-display-name",
+		RevisionOrderNumber: -1,
+		Status:              evergreen.TaskSucceeded,
+		Requester:           evergreen.RepotrackerVersionRequester,
+		Details: apimodels.TaskEndDetail{
+			CommandStats: []apimodels.CommandStats{
+				{Command: "'shell.exec'", Block: "task", Step: "1", Duration: 8 * time.Minute, PeakRSSBytes: 500 * mib},
+			},
+		},
+	}
+	s.NoError(previous.Insert())
+
+	// command that exceeds the memory threshold should generate
+	n, err = s.t.taskCommandRegression(&sub)
+	s.NoError(err)
+	s.NotNil(n)
+
+	// command within both thresholds should not generate
+	s.t.task.Details.CommandStats[0].PeakRSSBytes = 600 * mib
+	n, err = s.t.taskCommandRegression(&sub)
+	s.NoError(err)
+	s.Nil(n)
+
+	// patch tasks are not compared to mainline tasks
+	s.t.task.Details.CommandStats[0].PeakRSSBytes = 1000 * mib
+	s.t.task.Requester = evergreen.PatchVersionRequester
+	n, err = s.t.taskCommandRegression(&sub)
+	s.NoError(err)
+	s.Nil(n)
+}
+
+func TestFindCommandRegressions(t *testing.T) {
+	previous := []apimodels.CommandStats{
+		{Command: "'shell.exec'", Block: "task", Step: "1", Duration: time.Minute, PeakRSSBytes: 100 * mib},
+		{Command: "'shell.exec'", Block: "task", Step: "2", Duration: time.Minute, PeakRSSBytes: 200 * mib},
+		{Command: "'s3.put'", Block: "post", Step: "1", Duration: time.Minute, PeakRSSBytes: 100 * mib},
+		{Command: "'s3.get'", Block: "pre", Step: "1", Duration: time.Second, PeakRSSBytes: mib},
+	}
+	current := []apimodels.CommandStats{
+		{Command: "'shell.exec'", Block: "task", Step: "1", Duration: 3 * time.Minute, PeakRSSBytes: 100 * mib},
+		{Command: "'shell.exec'", Block: "task", Step: "2", Duration: time.Minute, PeakRSSBytes: 320 * mib},
+		{Command: "'s3.put'", Block: "post", Step: "1", Duration: 30 * time.Second, PeakRSSBytes: 50 * mib},
+		{Command: "'s3.get'", Block: "pre", Step: "1", Duration: 20 * time.Second, PeakRSSBytes: 50 * mib},
+		{Command: "'attach.results'", Block: "post", Step: "2", Duration: time.Hour, PeakRSSBytes: 1000 * mib},
+	}
+
+	t.Run("BothThresholds", func(t *testing.T) {
+		regressions := findCommandRegressions(previous, current, commandRegressionThresholds{memoryPercent: 50, durationPercent: 100})
+		require.Len(t, regressions, 2)
+		assert.Equal(t, "duration", regressions[0].resource)
+		assert.Equal(t, "'shell.exec'", regressions[0].command)
+		assert.InDelta(t, 200, regressions[0].percentChange, 0.001)
+		assert.Equal(t, "peak memory", regressions[1].resource)
+		assert.InDelta(t, 60, regressions[1].percentChange, 0.001)
+	})
+	t.Run("OnlyDuration", func(t *testing.T) {
+		regressions := findCommandRegressions(previous, current, commandRegressionThresholds{durationPercent: 100})
+		require.Len(t, regressions, 1)
+		assert.Equal(t, "duration", regressions[0].resource)
+	})
+	t.Run("HighThresholds", func(t *testing.T) {
+		assert.Empty(t, findCommandRegressions(previous, current, commandRegressionThresholds{memoryPercent: 100, durationPercent: 300}))
+	})
+	t.Run("NoPreviousStats", func(t *testing.T) {
+		assert.Empty(t, findCommandRegressions(nil, current, commandRegressionThresholds{memoryPercent: 1, durationPercent: 1}))
+	})
+	t.Run("SmallIncreases", func(t *testing.T) {
+		// The s3.get command regresses by a large percentage, but by less
+		// than the minimum increase in duration and memory.
+		regressions := findCommandRegressions(previous[3:], current[3:], commandRegressionThresholds{memoryPercent: 1, durationPercent: 1})
+		assert.Empty(t, regressions)
+
+		regressions = findCommandRegressions(previous[3:], []apimodels.CommandStats{
+			{Command: "'s3.get'", Block: "pre", Step: "1", Duration: time.Minute, PeakRSSBytes: 100 * mib},
+		}, commandRegressionThresholds{memoryPercent: 1, durationPercent: 1})
+		assert.Len(t, regressions, 2)
+	})
+}
+
 func (s *taskSuite) TestProjectTrigger() {
 	lastGreen := task.Task{
 		Id:                  "test1",
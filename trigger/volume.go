@@ -83,6 +83,8 @@ func (t *volumeTriggers) generate(sub *event.Subscription) (*notification.Notifi
 		payload, err = t.templateData.hostExpirationEmailPayload(expiringVolumeEmailSubject, expiringVolumeEmailBody, t.Attributes())
 	case event.SlackSubscriberType:
 		payload, err = t.templateData.hostExpirationSlackPayload(expiringVolumeSlackBody, expiringVolumeSlackAttachmentTitle)
+	case event.ChatWebhookSubscriberType:
+		payload, err = t.templateData.hostExpirationChatPayload(expiringVolumeSlackBody, expiringVolumeSlackAttachmentTitle)
 	default:
 		return nil, nil
 	}
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/taskselection"
 	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/evergreen/model/teststats"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/job"
@@ -138,6 +140,51 @@ func (j *cacheHistoricalTaskDataJob) Run(ctx context.Context) {
 			}
 		}
 	}).Seconds()
+	if !j.HasErrors() && !flags.TestFlakinessDisabled {
+		timingMsg["update_daily_test_stats"] = reportTiming(func() {
+			for _, toUpdate := range statsToUpdate {
+				if len(toUpdate.Tasks) == 0 {
+					continue
+				}
+				err := errors.Wrap(teststats.GenerateStats(ctx, evergreen.GetEnvironment(), teststats.GenerateStatsOptions{
+					ProjectID: j.ProjectID,
+					Requester: toUpdate.Requester,
+					Date:      toUpdate.Day,
+					Tasks:     toUpdate.Tasks,
+				}), "generating daily test stats")
+				grip.Warning(message.WrapError(err, message.Fields{
+					"job_id":         j.ID(),
+					"project":        j.ProjectID,
+					"job_type":       j.Type().Name,
+					"job_start_time": startAt,
+					"task_date":      utility.GetUTCDay(toUpdate.Day),
+				}))
+				if err != nil {
+					j.AddError(err)
+					return
+				}
+			}
+		}).Seconds()
+	}
+	if !j.HasErrors() && !flags.PredictiveTaskSelectionDisabled {
+		// The file task stats are incremented rather than regenerated, so
+		// errors are logged instead of preventing the stats status from
+		// advancing, which would count the same patches again.
+		timingMsg["update_file_task_stats"] = reportTiming(func() {
+			err := taskselection.GenerateStats(ctx, evergreen.GetEnvironment(), taskselection.GenerateStatsOptions{
+				ProjectID: j.ProjectID,
+				Start:     update_window_start,
+				End:       update_window_end,
+			})
+			grip.Warning(message.WrapError(err, message.Fields{
+				"message":        "could not generate file task stats",
+				"job_id":         j.ID(),
+				"project":        j.ProjectID,
+				"job_type":       j.Type().Name,
+				"job_start_time": startAt,
+			}))
+		}).Seconds()
+	}
 	if j.HasErrors() {
 		errMsg := j.Error().Error()
 		// The following errors are known to recur. In these cases we
@@ -136,17 +136,32 @@ func (j *commitQueueJob) Run(ctx context.Context) {
 		j.AddError(errors.Wrap(err, "getting global GitHub OAuth token"))
 		return
 	}
-	j.TryUnstick(ctx, cq, projectRef, githubToken)
 
-	if cq.Processing() {
+	// Each lane is processed as a separate queue, since the items in a lane
+	// are tested and merged independently of the items in other lanes.
+	for _, lane := range cq.ActiveLanes() {
+		lane := lane
+		j.processLane(ctx, &lane, projectRef, conf, githubToken)
+	}
+}
+
+// processLane processes the items in a single commit queue lane, starting a
+// new batch of items once the previous batch has been merged.
+func (j *commitQueueJob) processLane(ctx context.Context, cq *commitqueue.CommitQueue, projectRef *model.ProjectRef, conf *evergreen.Settings, githubToken string) {
+	if len(cq.Queue) == 0 {
 		return
 	}
+	laneName := cq.Queue[0].Lane
 
-	batchSize := conf.CommitQueue.BatchSize
-	if batchSize < 1 {
-		batchSize = 1
+	j.AddError(j.bisectBatch(cq))
+	j.TryUnstick(ctx, cq, projectRef, githubToken)
+
+	numProcessing := cq.NumProcessing()
+	batchSize := nextBatchSize(commitQueueConfigForProject(conf.CommitQueue, projectRef), numProcessing, len(cq.BatchItems()) > 0)
+	if batchSize == 0 {
+		return
 	}
-	nextItems := cq.NextUnprocessed(batchSize)
+	nextItems := cq.NextUnprocessed(numProcessing + batchSize)
 	if len(nextItems) == 0 {
 		return
 	}
@@ -158,6 +173,8 @@ func (j *commitQueueJob) Run(ctx context.Context) {
 		"project_id":   cq.ProjectID,
 		"queue_length": len(cq.Queue),
 		"batch_size":   batchSize,
+		"lane":         laneName,
+		"speculative":  numProcessing > 0,
 		"message":      "starting processing batch of commit queue items",
 	})
 	for _, nextItem := range nextItems {
@@ -209,7 +226,119 @@ func (j *commitQueueJob) Run(ctx context.Context) {
 		"message":              "finished processing batch of commit queue items",
 		"processing_time_secs": time.Since(beginBatchProcessingTime).Seconds(),
 	})
-	j.AddError(j.addMergeTaskDependencies(*cq))
+	if err := j.addMergeTaskDependencies(*cq); err != nil {
+		j.AddError(err)
+		return
+	}
+
+	if conf.CommitQueue.BisectBatches && numProcessing == 0 {
+		j.AddError(j.startBatchTest(cq))
+	}
+}
+
+// commitQueueConfigForProject returns the admin commit queue settings with
+// the project's overrides applied.
+func commitQueueConfigForProject(conf evergreen.CommitQueueConfig, projectRef *model.ProjectRef) evergreen.CommitQueueConfig {
+	if projectRef.CommitQueue.SpeculativeDepth > 0 {
+		conf.SpeculativeDepth = projectRef.CommitQueue.SpeculativeDepth
+	}
+	return conf
+}
+
+// nextBatchSize returns the number of items that can start processing given
+// the number of items that are already processing. Without speculation, the
+// next batch only starts once the previous one is merged. In speculative mode,
+// the next items start on top of the items that are still being processed, and
+// no more than the speculative depth of items are processed at once. If one of
+// the earlier items fails, it's dequeued and the later items are restarted
+// without its changes. A batch that's being bisected has to finish before
+// anything else can start, since later items would include the changes of the
+// items that are still being bisected.
+func nextBatchSize(conf evergreen.CommitQueueConfig, numProcessing int, bisecting bool) int {
+	batchSize := conf.BatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if numProcessing > 0 && (bisecting || numProcessing >= conf.SpeculativeDepth) {
+		return 0
+	}
+	if conf.SpeculativeDepth > 0 && batchSize > conf.SpeculativeDepth-numProcessing {
+		batchSize = conf.SpeculativeDepth - numProcessing
+	}
+	return batchSize
+}
+
+// startBatchTest starts testing the items that are being processed as a
+// batch, so that the batch can be bisected if it fails.
+func (j *commitQueueJob) startBatchTest(cq *commitqueue.CommitQueue) error {
+	issues := []string{}
+	for _, item := range cq.Queue {
+		if item.Version == "" {
+			break
+		}
+		issues = append(issues, item.Issue)
+	}
+	if len(issues) < 2 {
+		return nil
+	}
+	return errors.Wrap(model.StartCommitQueueBatchTest(cq, issues, evergreen.APIServerTaskActivator), "starting batch test")
+}
+
+// bisectBatch records the result of the batch test that is running, if it has
+// finished. If the batch failed, it's split in half and each half is tested
+// until the item that caused the failure is found, which is then dequeued.
+func (j *commitQueueJob) bisectBatch(cq *commitqueue.CommitQueue) error {
+	if len(cq.BatchItems()) == 0 {
+		return nil
+	}
+	if testVersion := cq.BatchTestVersion(); testVersion != "" {
+		status, err := model.GetCommitQueueBatchTestStatus(testVersion)
+		if err != nil {
+			return errors.Wrapf(err, "getting status of batch test version '%s'", testVersion)
+		}
+		if status == commitqueue.BisectionStatusTesting {
+			return nil
+		}
+		if err = cq.FinishBatchTest(status == commitqueue.BisectionStatusPassed); err != nil {
+			return errors.Wrapf(err, "finishing batch test version '%s'", testVersion)
+		}
+		grip.Info(message.Fields{
+			"source":     "commit queue",
+			"job_id":     j.ID(),
+			"project_id": cq.ProjectID,
+			"version":    testVersion,
+			"status":     status,
+			"message":    "batch test finished",
+		})
+	}
+
+	culprit, next := cq.PlanBisection()
+	if culprit != "" {
+		item := cq.Queue[cq.FindItem(culprit)]
+		grip.Info(message.Fields{
+			"source":     "commit queue",
+			"job_id":     j.ID(),
+			"project_id": cq.ProjectID,
+			"item":       item,
+			"message":    "bisection found item that caused the batch to fail",
+		})
+		if _, err := model.DequeueAndRestartForVersion(cq, cq.ProjectID, item.Version, evergreen.APIServerTaskActivator, "item caused the batch to fail"); err != nil {
+			return errors.Wrapf(err, "dequeueing item '%s'", culprit)
+		}
+		updated, err := commitqueue.FindOneId(cq.ProjectID)
+		if err != nil {
+			return errors.Wrapf(err, "finding commit queue '%s'", cq.ProjectID)
+		}
+		if updated == nil {
+			return errors.Errorf("commit queue '%s' not found", cq.ProjectID)
+		}
+		*cq = updated.ActiveLane(item.Lane)
+	}
+	if len(next) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(model.StartCommitQueueBatchTest(cq, next, evergreen.APIServerTaskActivator), "starting batch test")
 }
 
 func (j *commitQueueJob) addMergeTaskDependencies(cq commitqueue.CommitQueue) error {
@@ -306,7 +435,9 @@ func (j *commitQueueJob) TryUnstick(ctx context.Context, cq *commitqueue.CommitQ
 			j.dequeue(cq, nextItem)
 			event.LogCommitQueueConcludeTest(nextItem.Version, evergreen.EnqueueFailed)
 		}
-		if mergeTask.Blocked() {
+		// The merge task of an item that is being bisected is blocked until
+		// the batch that it's in is retested.
+		if mergeTask.Blocked() && !nextItem.IsBisecting() {
 			// The head of the commit queue could be blocked temporarily if its
 			// dependencies are in the process of restarting running tasks due
 			// to a failure in a previous commit queue item and the asynchronous
@@ -348,6 +479,12 @@ func (j *commitQueueJob) TryUnstick(ctx context.Context, cq *commitqueue.CommitQ
 		}
 	}
 
+	// An item that is being bisected is done once it's merged, since its
+	// patch can finish before the batch it's in has been retested.
+	if nextItem.IsBisecting() && (mergeTask == nil || !mergeTask.IsFinished()) {
+		return
+	}
+
 	// patch is done
 	if !utility.IsZeroTime(patchDoc.FinishTime) {
 		j.dequeue(cq, nextItem)
@@ -628,6 +765,8 @@ func AddMergeTaskAndVariant(ctx context.Context, patchDoc *patch.Patch, project
 	validationErrors := validator.CheckProjectErrors(project, true)
 	validationErrors = append(validationErrors, validator.CheckProjectSettings(settings, project, projectRef, false)...)
 	validationErrors = append(validationErrors, validator.CheckPatchedProjectConfigErrors(patchDoc.PatchedProjectConfig)...)
+	validationErrors = append(validationErrors, validator.CheckProjectWarnings(project)...)
+	validationErrors = validator.ApplyPatchedLintRules(validationErrors, patchDoc.PatchedProjectConfig)
 	catcher := grip.NewBasicCatcher()
 	for _, validationErr := range validationErrors.AtLevel(validator.Error) {
 		catcher.Add(validationErr)
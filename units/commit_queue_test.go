@@ -2,6 +2,7 @@ package units
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/evergreen/mock"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/patch"
@@ -21,6 +23,7 @@ import (
 	"github.com/evergreen-ci/utility"
 	"github.com/google/go-github/v52/github"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -215,6 +218,52 @@ func (s *commitQueueSuite) TestTryUnstickDoesNotUnstickMergeTaskBlockedByResetti
 	s.Len(cq.Queue, 1, "commit queue item should remain enqueued even when merge task is blocked if waiting to reset dependencies")
 }
 
+func (s *commitQueueSuite) TestTryUnstickDoesNotDequeueItemBeingBisected() {
+	j := commitQueueJob{}
+	s.Require().NoError(db.ClearCollections(task.Collection, patch.Collection))
+
+	// The item's own tests were deactivated so its patch is finished, and its
+	// merge task is blocked until the batch it's in has been retested.
+	p := &patch.Patch{
+		Id:         mgobson.NewObjectId(),
+		Status:     evergreen.PatchFailed,
+		FinishTime: time.Now(),
+	}
+	s.Require().NoError(p.Insert())
+	mergeTask := task.Task{
+		Id:               "merge_task",
+		Activated:        true,
+		CommitQueueMerge: true,
+		Status:           evergreen.TaskUndispatched,
+		Version:          p.Id.Hex(),
+		DependsOn: []task.Dependency{
+			{
+				TaskId:       "batch_test_task",
+				Unattainable: true,
+			},
+		},
+	}
+	s.Require().NoError(mergeTask.Insert())
+	s.Require().True(mergeTask.Blocked())
+
+	cq := &commitqueue.CommitQueue{
+		ProjectID: s.projectRef.Id,
+		Queue: []commitqueue.CommitQueueItem{
+			{
+				Issue:           p.Id.Hex(),
+				Source:          commitqueue.SourceDiff,
+				Version:         p.Id.Hex(),
+				BisectionStatus: commitqueue.BisectionStatusFailed,
+			},
+		},
+	}
+
+	j.TryUnstick(s.ctx, cq, s.projectRef, "")
+	s.NoError(j.Error())
+
+	s.Len(cq.Queue, 1, "item being bisected should not be dequeued before its merge task finishes")
+}
+
 func (s *commitQueueSuite) TestNewCommitQueueJob() {
 	job := NewCommitQueueJob(s.env, "mci", "job-1")
 	s.Equal("commit-queue:mci_job-1", job.ID())
@@ -403,3 +452,417 @@ func TestAddMergeTaskDependencies(t *testing.T) {
 	assert.Len(t, dbTask3.DependsOn, 1)
 	assert.Equal(t, dbTask2.Id, dbTask3.DependsOn[0].TaskId)
 }
+
+func TestCommitQueueConfigForProject(t *testing.T) {
+	conf := evergreen.CommitQueueConfig{BatchSize: 2, SpeculativeDepth: 1}
+	assert.Equal(t, conf, commitQueueConfigForProject(conf, &model.ProjectRef{}))
+	overridden := commitQueueConfigForProject(conf, &model.ProjectRef{CommitQueue: model.CommitQueueParams{SpeculativeDepth: 4}})
+	assert.Equal(t, 4, overridden.SpeculativeDepth)
+	assert.Equal(t, 2, overridden.BatchSize)
+}
+
+func TestNextBatchSize(t *testing.T) {
+	for tName, tCase := range map[string]struct {
+		conf          evergreen.CommitQueueConfig
+		numProcessing int
+		bisecting     bool
+		expected      int
+	}{
+		"DefaultsToOneItem": {
+			expected: 1,
+		},
+		"StartsFullBatchWhenNothingIsProcessing": {
+			conf:     evergreen.CommitQueueConfig{BatchSize: 4},
+			expected: 4,
+		},
+		"CapsFirstBatchAtSpeculativeDepth": {
+			conf:     evergreen.CommitQueueConfig{BatchSize: 4, SpeculativeDepth: 2},
+			expected: 2,
+		},
+		"WaitsForProcessingItemsWithoutSpeculation": {
+			conf:          evergreen.CommitQueueConfig{BatchSize: 4},
+			numProcessing: 1,
+			expected:      0,
+		},
+		"StartsItemsUpToSpeculativeDepth": {
+			conf:          evergreen.CommitQueueConfig{BatchSize: 4, SpeculativeDepth: 3},
+			numProcessing: 1,
+			expected:      2,
+		},
+		"StartsUpToBatchSizeSpeculatively": {
+			conf:          evergreen.CommitQueueConfig{BatchSize: 1, SpeculativeDepth: 3},
+			numProcessing: 1,
+			expected:      1,
+		},
+		"WaitsAtSpeculativeDepth": {
+			conf:          evergreen.CommitQueueConfig{BatchSize: 1, SpeculativeDepth: 3},
+			numProcessing: 3,
+			expected:      0,
+		},
+		"WaitsForBisection": {
+			conf:          evergreen.CommitQueueConfig{BatchSize: 1, SpeculativeDepth: 3},
+			numProcessing: 2,
+			bisecting:     true,
+			expected:      0,
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			assert.Equal(t, tCase.expected, nextBatchSize(tCase.conf, tCase.numProcessing, tCase.bisecting))
+		})
+	}
+}
+
+func TestProcessLane(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(model.VersionCollection, patch.Collection, build.Collection, task.Collection, task.OldCollection, commitqueue.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(model.VersionCollection, patch.Collection, build.Collection, task.Collection, task.OldCollection, commitqueue.Collection))
+	}()
+
+	// The first two items are being processed. The items after them don't
+	// have patches, so an item that processLane starts is dequeued, which
+	// shows which items it started.
+	processingIDs := []mgobson.ObjectId{mgobson.NewObjectId(), mgobson.NewObjectId()}
+	for i, id := range processingIDs {
+		versionID := id.Hex()
+		p := patch.Patch{Id: id, Alias: evergreen.CommitQueueAlias, Version: versionID}
+		require.NoError(t, p.Insert())
+		v := model.Version{Id: versionID}
+		require.NoError(t, v.Insert())
+		b := build.Build{Id: versionID, Version: versionID}
+		require.NoError(t, b.Insert())
+		testTask := task.Task{
+			Id:        fmt.Sprintf("test%d", i),
+			Version:   versionID,
+			BuildId:   versionID,
+			Project:   "p",
+			Status:    evergreen.TaskSucceeded,
+			Requester: evergreen.MergeTestRequester,
+		}
+		require.NoError(t, testTask.Insert())
+		mergeTask := task.Task{
+			Id:               fmt.Sprintf("merge%d", i),
+			Version:          versionID,
+			BuildId:          versionID,
+			Project:          "p",
+			Status:           evergreen.TaskUndispatched,
+			Activated:        true,
+			Requester:        evergreen.MergeTestRequester,
+			CommitQueueMerge: true,
+		}
+		require.NoError(t, mergeTask.Insert())
+	}
+	unprocessed := []string{mgobson.NewObjectId().Hex(), mgobson.NewObjectId().Hex()}
+	cq := &commitqueue.CommitQueue{
+		ProjectID: "p",
+		Queue: []commitqueue.CommitQueueItem{
+			{Issue: processingIDs[0].Hex(), PatchId: processingIDs[0].Hex(), Version: processingIDs[0].Hex(), Source: commitqueue.SourceDiff},
+			{Issue: processingIDs[1].Hex(), PatchId: processingIDs[1].Hex(), Version: processingIDs[1].Hex(), Source: commitqueue.SourceDiff},
+			{Issue: unprocessed[0], PatchId: unprocessed[0], Source: commitqueue.SourceDiff},
+			{Issue: unprocessed[1], PatchId: unprocessed[1], Source: commitqueue.SourceDiff},
+		},
+	}
+	require.NoError(t, commitqueue.InsertQueue(cq))
+
+	projectRef := &model.ProjectRef{Id: "p"}
+	conf := &evergreen.Settings{CommitQueue: evergreen.CommitQueueConfig{BatchSize: 1, SpeculativeDepth: 2}}
+	issues := func() []string {
+		dbCq, err := commitqueue.FindOneId("p")
+		require.NoError(t, err)
+		require.NotNil(t, dbCq)
+		var issues []string
+		for _, item := range dbCq.Queue {
+			issues = append(issues, item.Issue)
+		}
+		return issues
+	}
+
+	j := &commitQueueJob{}
+	j.processLane(ctx, cq, projectRef, conf, "")
+	assert.Equal(t, []string{processingIDs[0].Hex(), processingIDs[1].Hex(), unprocessed[0], unprocessed[1]}, issues(), "no items should start at the speculative depth")
+
+	// A failure in the first item dequeues it and restarts the items after
+	// it, which are rebuilt without its changes.
+	failedTask, err := task.FindOneId("test0")
+	require.NoError(t, err)
+	require.NotNil(t, failedTask)
+	require.NoError(t, model.HandleEndTaskForCommitQueueTask(failedTask, evergreen.TaskFailed))
+	assert.Equal(t, []string{processingIDs[1].Hex(), unprocessed[0], unprocessed[1]}, issues(), "failed item should be dequeued")
+	rebuiltTask, err := task.FindOneId("test1")
+	require.NoError(t, err)
+	require.NotNil(t, rebuiltTask)
+	assert.Equal(t, 1, rebuiltTask.Execution, "later item should be restarted")
+
+	// The dequeued item frees up room for the next item to start on top of
+	// the item that is still being processed.
+	cq, err = commitqueue.FindOneId("p")
+	require.NoError(t, err)
+	require.NotNil(t, cq)
+	j = &commitQueueJob{}
+	j.processLane(ctx, cq, projectRef, conf, "")
+	assert.Equal(t, []string{processingIDs[1].Hex(), unprocessed[1]}, issues(), "next item should start speculatively")
+}
+
+func TestProcessLaneRebuildsItemsAfterFailedItem(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(model.VersionCollection, patch.Collection, build.Collection, task.Collection, task.OldCollection, commitqueue.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(model.VersionCollection, patch.Collection, build.Collection, task.Collection, task.OldCollection, commitqueue.Collection))
+	}()
+
+	// The project's speculative depth lets three items be processed at once.
+	// The first item has passed its tests, the second is about to fail, and
+	// the third is still being tested on top of both of them.
+	const depth = 3
+	testStatuses := []string{evergreen.TaskSucceeded, evergreen.TaskStarted, evergreen.TaskStarted}
+	processingIDs := make([]mgobson.ObjectId, depth)
+	cq := &commitqueue.CommitQueue{ProjectID: "p"}
+	for i := range processingIDs {
+		processingIDs[i] = mgobson.NewObjectId()
+		versionID := processingIDs[i].Hex()
+		p := patch.Patch{Id: processingIDs[i], Alias: evergreen.CommitQueueAlias, Version: versionID}
+		require.NoError(t, p.Insert())
+		v := model.Version{Id: versionID}
+		require.NoError(t, v.Insert())
+		b := build.Build{Id: versionID, Version: versionID}
+		require.NoError(t, b.Insert())
+		testTask := task.Task{
+			Id:           fmt.Sprintf("test%d", i),
+			DisplayName:  "test",
+			BuildVariant: "bv",
+			Version:      versionID,
+			BuildId:      versionID,
+			Project:      "p",
+			Status:       testStatuses[i],
+			Activated:    true,
+			Requester:    evergreen.MergeTestRequester,
+		}
+		require.NoError(t, testTask.Insert())
+		mergeTask := task.Task{
+			Id:               fmt.Sprintf("merge%d", i),
+			DisplayName:      evergreen.MergeTaskName,
+			BuildVariant:     evergreen.MergeTaskVariant,
+			Version:          versionID,
+			BuildId:          versionID,
+			Project:          "p",
+			Status:           evergreen.TaskUndispatched,
+			Activated:        true,
+			Requester:        evergreen.MergeTestRequester,
+			CommitQueueMerge: true,
+		}
+		require.NoError(t, mergeTask.Insert())
+		cq.Queue = append(cq.Queue, commitqueue.CommitQueueItem{Issue: versionID, PatchId: versionID, Version: versionID, Source: commitqueue.SourceDiff})
+	}
+	unprocessed := mgobson.NewObjectId().Hex()
+	cq.Queue = append(cq.Queue, commitqueue.CommitQueueItem{Issue: unprocessed, PatchId: unprocessed, Source: commitqueue.SourceDiff})
+	require.NoError(t, commitqueue.InsertQueue(cq))
+
+	j := &commitQueueJob{}
+	require.NoError(t, j.addMergeTaskDependencies(*cq))
+
+	projectRef := &model.ProjectRef{Id: "p", CommitQueue: model.CommitQueueParams{SpeculativeDepth: depth}}
+	conf := &evergreen.Settings{CommitQueue: evergreen.CommitQueueConfig{BatchSize: 1, SpeculativeDepth: 1}}
+	issues := func() []string {
+		dbCq, err := commitqueue.FindOneId("p")
+		require.NoError(t, err)
+		require.NotNil(t, dbCq)
+		var issues []string
+		for _, item := range dbCq.Queue {
+			issues = append(issues, item.Issue)
+		}
+		return issues
+	}
+
+	j.processLane(ctx, cq, projectRef, conf, "")
+	require.Equal(t, []string{processingIDs[0].Hex(), processingIDs[1].Hex(), processingIDs[2].Hex(), unprocessed}, issues(), "no items should start at the project's speculative depth")
+
+	failedTask, err := task.FindOneId("test1")
+	require.NoError(t, err)
+	require.NotNil(t, failedTask)
+	failedTask.Status = evergreen.TaskFailed
+	require.NoError(t, model.HandleEndTaskForCommitQueueTask(failedTask, evergreen.TaskFailed))
+	assert.Equal(t, []string{processingIDs[0].Hex(), processingIDs[2].Hex(), unprocessed}, issues(), "only the failed item should be dequeued")
+
+	// The test of the item after the failed item is aborted so that it's
+	// rebuilt once it finishes.
+	laterTask, err := task.FindOneId("test2")
+	require.NoError(t, err)
+	require.NotNil(t, laterTask)
+	assert.True(t, laterTask.Aborted, "later item's tests should be aborted")
+	assert.True(t, laterTask.ResetWhenFinished, "later item's tests should be restarted")
+	earlierTask, err := task.FindOneId("test0")
+	require.NoError(t, err)
+	require.NotNil(t, earlierTask)
+	assert.False(t, earlierTask.Aborted, "earlier item's tests should not be aborted")
+	assert.Zero(t, earlierTask.Execution, "earlier item's tests should not be restarted")
+
+	// The later item is now merged on top of the item before the failed
+	// item rather than on top of the failed item.
+	laterMerge, err := task.FindOneId("merge2")
+	require.NoError(t, err)
+	require.NotNil(t, laterMerge)
+	var dependsOn []string
+	for _, dep := range laterMerge.DependsOn {
+		dependsOn = append(dependsOn, dep.TaskId)
+	}
+	assert.Equal(t, []string{"merge0"}, dependsOn)
+
+	// The dequeued item frees up room for the next item to start.
+	cq, err = commitqueue.FindOneId("p")
+	require.NoError(t, err)
+	require.NotNil(t, cq)
+	j = &commitQueueJob{}
+	j.processLane(ctx, cq, projectRef, conf, "")
+	assert.Equal(t, []string{processingIDs[0].Hex(), processingIDs[2].Hex()}, issues(), "next item should start speculatively")
+}
+
+func TestProcessLaneBisectsFailedBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(model.VersionCollection, patch.Collection, build.Collection, task.Collection, task.OldCollection, commitqueue.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(model.VersionCollection, patch.Collection, build.Collection, task.Collection, task.OldCollection, commitqueue.Collection))
+	}()
+
+	// Four items are being processed as a batch. Each item's version tests
+	// the changes of the items before it, so the version of the last item
+	// tests the whole batch.
+	ids := make([]string, 4)
+	cq := &commitqueue.CommitQueue{ProjectID: "p"}
+	for i := range ids {
+		id := mgobson.NewObjectId()
+		ids[i] = id.Hex()
+		p := patch.Patch{Id: id, Alias: evergreen.CommitQueueAlias, Version: ids[i], Status: evergreen.PatchStarted}
+		require.NoError(t, p.Insert())
+		v := model.Version{Id: ids[i]}
+		require.NoError(t, v.Insert())
+		b := build.Build{Id: ids[i], Version: ids[i]}
+		require.NoError(t, b.Insert())
+		testTask := task.Task{
+			Id:           fmt.Sprintf("test%d", i),
+			DisplayName:  "test",
+			BuildVariant: "bv",
+			Version:      ids[i],
+			BuildId:      ids[i],
+			Project:      "p",
+			Status:       evergreen.TaskUndispatched,
+			Activated:    true,
+			Requester:    evergreen.MergeTestRequester,
+		}
+		require.NoError(t, testTask.Insert())
+		mergeTask := task.Task{
+			Id:               fmt.Sprintf("merge%d", i),
+			DisplayName:      evergreen.MergeTaskName,
+			BuildVariant:     evergreen.MergeTaskVariant,
+			Version:          ids[i],
+			BuildId:          ids[i],
+			Project:          "p",
+			Status:           evergreen.TaskUndispatched,
+			Activated:        true,
+			Requester:        evergreen.MergeTestRequester,
+			CommitQueueMerge: true,
+		}
+		require.NoError(t, mergeTask.Insert())
+		cq.Queue = append(cq.Queue, commitqueue.CommitQueueItem{Issue: ids[i], PatchId: ids[i], Version: ids[i], Source: commitqueue.SourceDiff})
+	}
+	require.NoError(t, commitqueue.InsertQueue(cq))
+
+	projectRef := &model.ProjectRef{Id: "p"}
+	conf := &evergreen.Settings{CommitQueue: evergreen.CommitQueueConfig{BatchSize: 4, BisectBatches: true}}
+	j := &commitQueueJob{}
+	require.NoError(t, j.startBatchTest(cq))
+
+	findQueue := func() *commitqueue.CommitQueue {
+		dbCq, err := commitqueue.FindOneId("p")
+		require.NoError(t, err)
+		require.NotNil(t, dbCq)
+		return dbCq
+	}
+	bisectionStatuses := func() map[string]string {
+		statuses := map[string]string{}
+		for _, item := range findQueue().Queue {
+			statuses[item.Issue] = item.BisectionStatus
+		}
+		return statuses
+	}
+	isActivated := func(taskID string) bool {
+		dbTask, err := task.FindOneId(taskID)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		return dbTask.Activated
+	}
+	finishTask := func(taskID, status string) {
+		require.NoError(t, task.UpdateOne(mgobson.M{task.IdKey: taskID}, mgobson.M{"$set": mgobson.M{task.StatusKey: status}}))
+	}
+	processLane := func() {
+		j := &commitQueueJob{}
+		j.processLane(ctx, findQueue(), projectRef, conf, "")
+		require.NoError(t, j.Error())
+	}
+
+	assert.Equal(t, ids[3], findQueue().BatchTestVersion(), "last item's version should test the whole batch")
+	assert.True(t, isActivated("test3"))
+	assert.False(t, isActivated("test0"), "only the version testing the batch should run")
+
+	// The batch is still being tested, so nothing changes.
+	processLane()
+	assert.Equal(t, map[string]string{
+		ids[0]: commitqueue.BisectionStatusTesting,
+		ids[1]: commitqueue.BisectionStatusTesting,
+		ids[2]: commitqueue.BisectionStatusTesting,
+		ids[3]: commitqueue.BisectionStatusTesting,
+	}, bisectionStatuses())
+
+	// The batch fails, so its first half is retested on its own.
+	finishTask("test3", evergreen.TaskFailed)
+	processLane()
+	assert.Equal(t, map[string]string{
+		ids[0]: commitqueue.BisectionStatusTesting,
+		ids[1]: commitqueue.BisectionStatusTesting,
+		ids[2]: commitqueue.BisectionStatusFailed,
+		ids[3]: commitqueue.BisectionStatusFailed,
+	}, bisectionStatuses())
+	assert.Equal(t, ids[1], findQueue().BatchTestVersion())
+	assert.True(t, isActivated("test1"))
+
+	// The first half passes, so the first item of the second half is tested.
+	finishTask("test1", evergreen.TaskSucceeded)
+	processLane()
+	assert.Equal(t, map[string]string{
+		ids[0]: commitqueue.BisectionStatusPassed,
+		ids[1]: commitqueue.BisectionStatusPassed,
+		ids[2]: commitqueue.BisectionStatusTesting,
+		ids[3]: commitqueue.BisectionStatusFailed,
+	}, bisectionStatuses())
+	assert.Equal(t, ids[2], findQueue().BatchTestVersion())
+	assert.True(t, isActivated("test2"))
+
+	// The third item fails on its own, so only it is dequeued, and the last
+	// item is retested without its changes.
+	finishTask("test2", evergreen.TaskFailed)
+	processLane()
+	dbCq := findQueue()
+	var issues []string
+	for _, item := range dbCq.Queue {
+		issues = append(issues, item.Issue)
+	}
+	assert.Equal(t, []string{ids[0], ids[1], ids[3]}, issues, "only the culprit should be dequeued")
+	assert.Equal(t, map[string]string{
+		ids[0]: commitqueue.BisectionStatusPassed,
+		ids[1]: commitqueue.BisectionStatusPassed,
+		ids[3]: commitqueue.BisectionStatusTesting,
+	}, bisectionStatuses())
+	assert.Equal(t, ids[3], dbCq.BatchTestVersion())
+	require.Len(t, dbCq.Queue[2].BisectionHistory, 2)
+	assert.Equal(t, commitqueue.BisectionStatusFailed, dbCq.Queue[2].BisectionHistory[0].Status)
+	assert.Equal(t, []string{ids[3]}, dbCq.Queue[2].BisectionHistory[1].Items)
+	restarted, err := task.FindOneId("test3")
+	require.NoError(t, err)
+	require.NotNil(t, restarted)
+	assert.Equal(t, 1, restarted.Execution, "last item should be retested")
+}
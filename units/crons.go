@@ -191,6 +191,13 @@ func PopulateEventNotifierJobs(env evergreen.Environment) amboy.QueueOperation {
 	}
 }
 
+func PopulateEventDigestJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		ts := utility.RoundPartOfMinute(0).Format(TSFormat)
+		return errors.Wrap(amboy.EnqueueUniqueJob(ctx, queue, NewEventDigestJob(env, queue, ts)), "enqueueing event digest job")
+	}
+}
+
 func PopulateTaskMonitoring(mins int) amboy.QueueOperation {
 	return func(ctx context.Context, queue amboy.Queue) error {
 		flags, err := evergreen.GetServiceFlags()
@@ -269,6 +276,37 @@ func PopulateHostTerminationJobs(env evergreen.Environment) amboy.QueueOperation
 	}
 }
 
+// PopulateHostPoolResetJobs enqueues jobs to reset and return pool hosts to
+// their pool once the tasks that leased them have finished.
+func PopulateHostPoolResetJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.MonitorDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "monitor is disabled",
+				"impact":  "not returning pool hosts to their pools",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		hosts, err := host.FindPoolHostsLeasedByFinishedTasks()
+		if err != nil {
+			return errors.Wrap(err, "finding pool hosts leased by finished tasks")
+		}
+
+		catcher := grip.NewBasicCatcher()
+		for _, h := range hosts {
+			catcher.Wrapf(amboy.EnqueueUniqueJob(ctx, queue, NewHostPoolResetJob(env, h)), "enqueueing host pool reset job for host '%s'", h.Id)
+		}
+		return catcher.Resolve()
+	}
+}
+
 func PopulateIdleHostJobs(env evergreen.Environment) amboy.QueueOperation {
 	return func(ctx context.Context, queue amboy.Queue) error {
 		flags, err := evergreen.GetServiceFlags()
@@ -1137,6 +1175,23 @@ func PopulateVolumeExpirationJob() amboy.QueueOperation {
 	}
 }
 
+func PopulateSnapshotExpirationJob() amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		snapshots, err := host.FindSnapshotsToDelete(time.Now())
+		if err != nil {
+			return errors.Wrap(err, "finding snapshots to delete")
+		}
+
+		catcher := grip.NewBasicCatcher()
+		ts := utility.RoundPartOfHour(0).Format(TSFormat)
+		for i := range snapshots {
+			catcher.Wrapf(amboy.EnqueueUniqueJob(ctx, queue, NewSnapshotDeletionJob(ts, &snapshots[i])), "enqueueing snapshot deletion job for snapshot '%s'", snapshots[i].ID)
+		}
+
+		return errors.Wrap(catcher.Resolve(), "populating expire snapshot jobs")
+	}
+}
+
 func PopulateLocalQueueJobs(env evergreen.Environment) amboy.QueueOperation {
 	return func(ctx context.Context, queue amboy.Queue) error {
 		catcher := grip.NewBasicCatcher()
@@ -50,9 +50,11 @@ func (j *cronsRemoteMinuteJob) Run(ctx context.Context) {
 		PopulateBackgroundStatsJobs(j.env, 0),
 		PopulateContainerStateJobs(j.env),
 		PopulateEventSendJobs(j.env),
+		PopulateEventDigestJobs(j.env),
 		PopulateGenerateTasksJobs(j.env),
 		PopulateHostMonitoring(j.env),
 		PopulateHostTerminationJobs(j.env),
+		PopulateHostPoolResetJobs(j.env),
 		PopulateIdleHostJobs(j.env),
 		PopulateLastContainerFinishTimeJobs(),
 		PopulateOldestImageRemovalJobs(),
@@ -0,0 +1,200 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/trigger"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventDigestJobName = "event-digest"
+
+	// maxDigestAttempts is the number of times that a digest is tried
+	// before its entries are dropped.
+	maxDigestAttempts = 3
+)
+
+func init() {
+	registry.AddJobType(eventDigestJobName, func() amboy.Job { return makeEventDigestJob() })
+}
+
+type eventDigestJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	env      evergreen.Environment
+	q        amboy.Queue
+	flags    *evergreen.ServiceFlags
+}
+
+func makeEventDigestJob() *eventDigestJob {
+	j := &eventDigestJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    eventDigestJobName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewEventDigestJob returns a job that sends the digests of subscriptions
+// whose digest interval has ended.
+func NewEventDigestJob(env evergreen.Environment, q amboy.Queue, ts string) amboy.Job {
+	j := makeEventDigestJob()
+	j.env = env
+	j.q = q
+
+	j.SetID(fmt.Sprintf("%s.%s", eventDigestJobName, ts))
+	j.SetScopes([]string{eventDigestJobName})
+	j.SetEnqueueAllScopes(true)
+
+	return j
+}
+
+func (j *eventDigestJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	if j.q == nil {
+		j.q = j.env.RemoteQueue()
+	}
+	if j.flags == nil {
+		var err error
+		j.flags, err = evergreen.GetServiceFlags()
+		if err != nil {
+			j.AddError(errors.Wrap(err, "getting service flags"))
+			return
+		}
+	}
+	if j.flags.EventProcessingDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job_type": j.Type().Name,
+			"message":  "events processing is disabled",
+		})
+		return
+	}
+
+	uiConfig := evergreen.UIConfig{}
+	if err := uiConfig.Get(j.env); err != nil {
+		j.AddError(errors.Wrap(err, "getting UI config"))
+		return
+	}
+
+	entries, err := notification.FindDueDigestEntries(time.Now())
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	entriesBySubscription := map[string][]notification.DigestEntry{}
+	subscriptionIDs := []string{}
+	for _, entry := range entries {
+		if _, ok := entriesBySubscription[entry.SubscriptionID]; !ok {
+			subscriptionIDs = append(subscriptionIDs, entry.SubscriptionID)
+		}
+		entriesBySubscription[entry.SubscriptionID] = append(entriesBySubscription[entry.SubscriptionID], entry)
+	}
+
+	for _, subID := range subscriptionIDs {
+		if err := ctx.Err(); err != nil {
+			j.AddError(err)
+			return
+		}
+		j.AddError(errors.Wrapf(j.sendDigest(ctx, subID, entriesBySubscription[subID], uiConfig.Url), "sending digest for subscription '%s'", subID))
+	}
+}
+
+// sendDigest creates and dispatches the digest notification for the
+// subscription's entries, then removes the entries.
+func (j *eventDigestJob) sendDigest(ctx context.Context, subID string, entries []notification.DigestEntry, uiBase string) error {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	sub, err := event.FindSubscriptionByID(subID)
+	if err != nil {
+		return errors.Wrap(err, "finding subscription")
+	}
+	if sub == nil {
+		// The subscription was deleted, so its digest is dropped.
+		return errors.Wrap(notification.RemoveDigestEntries(ids), "removing digest entries for deleted subscription")
+	}
+
+	n, err := insertDigestNotification(sub, entries, uiBase)
+	if err != nil {
+		return j.failDigest(subID, entries, ids, err)
+	}
+	if err = notification.RemoveDigestEntries(ids); err != nil {
+		return err
+	}
+
+	grip.Info(message.Fields{
+		"message":         "sending notification digest",
+		"job_id":          j.ID(),
+		"job_type":        j.Type().Name,
+		"subscription_id": subID,
+		"notification_id": n.ID,
+		"entries":         len(entries),
+	})
+
+	return dispatchNotifications(ctx, []notification.Notification{*n}, j.q, j.flags)
+}
+
+func insertDigestNotification(sub *event.Subscription, entries []notification.DigestEntry, uiBase string) (*notification.Notification, error) {
+	n, err := trigger.DigestNotification(sub, entries, uiBase)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating digest notification")
+	}
+	if err = notification.InsertMany(*n); err != nil && !db.IsDuplicateKey(err) {
+		return nil, errors.Wrap(err, "inserting digest notification")
+	}
+	return n, nil
+}
+
+// failDigest records a failed attempt to send the subscription's digest, so
+// that it's tried again by the next job. Once the digest has failed
+// maxDigestAttempts times, its entries are removed instead, so a digest that
+// can't be created isn't retried forever.
+func (j *eventDigestJob) failDigest(subID string, entries []notification.DigestEntry, ids []string, digestErr error) error {
+	attempts := 0
+	for _, entry := range entries {
+		if entry.Attempts > attempts {
+			attempts = entry.Attempts
+		}
+	}
+	attempts++
+
+	catcher := grip.NewBasicCatcher()
+	catcher.Add(digestErr)
+	if attempts < maxDigestAttempts {
+		catcher.Add(notification.IncDigestEntryAttempts(ids))
+		return catcher.Resolve()
+	}
+
+	grip.Error(message.WrapError(digestErr, message.Fields{
+		"message":         "dropping digest that failed too many times",
+		"job_id":          j.ID(),
+		"job_type":        j.Type().Name,
+		"subscription_id": subID,
+		"entries":         len(entries),
+		"attempts":        attempts,
+	}))
+	catcher.Wrap(notification.RemoveDigestEntries(ids), "removing entries of failed digest")
+	return catcher.Resolve()
+}
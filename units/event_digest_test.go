@@ -0,0 +1,47 @@
+package units
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDigestJobFailDigest(t *testing.T) {
+	require.NoError(t, db.Clear(notification.DigestEntriesCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(notification.DigestEntriesCollection))
+	}()
+
+	now := time.Now()
+	entries := []notification.DigestEntry{
+		{ID: "e1", SubscriptionID: "sub", DueAt: now.Add(-time.Minute)},
+		{ID: "e2", SubscriptionID: "sub", DueAt: now.Add(-time.Minute)},
+	}
+	require.NoError(t, notification.InsertDigestEntries(entries...))
+	ids := []string{"e1", "e2"}
+	j := makeEventDigestJob()
+
+	for attempt := 1; attempt < maxDigestAttempts; attempt++ {
+		due, err := notification.FindDueDigestEntries(now)
+		require.NoError(t, err)
+		require.Len(t, due, 2, "entries should be kept for attempt %d", attempt)
+		assert.Error(t, j.failDigest("sub", due, ids, errors.New("digest failed")))
+	}
+
+	due, err := notification.FindDueDigestEntries(now)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	for _, entry := range due {
+		assert.Equal(t, maxDigestAttempts-1, entry.Attempts)
+	}
+
+	assert.Error(t, j.failDigest("sub", due, ids, errors.New("digest failed")))
+	due, err = notification.FindDueDigestEntries(now)
+	require.NoError(t, err)
+	assert.Empty(t, due, "entries should be removed after the last attempt")
+}
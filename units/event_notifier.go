@@ -235,7 +235,7 @@ func notificationIsEnabled(flags *evergreen.ServiceFlags, n *notification.Notifi
 	case event.JIRAIssueSubscriberType, event.JIRACommentSubscriberType:
 		return !flags.JIRANotificationsDisabled
 
-	case event.EvergreenWebhookSubscriberType:
+	case event.EvergreenWebhookSubscriberType, event.ChatWebhookSubscriberType:
 		return !flags.WebhookNotificationsDisabled
 
 	case event.EmailSubscriberType:
@@ -151,7 +151,7 @@ func (j *eventSendJob) checkDegradedMode(n *notification.Notification) error {
 	case event.JIRACommentSubscriberType:
 		return checkFlag(j.flags.JIRANotificationsDisabled)
 
-	case event.EvergreenWebhookSubscriberType:
+	case event.EvergreenWebhookSubscriberType, event.ChatWebhookSubscriberType:
 		return checkFlag(j.flags.WebhookNotificationsDisabled)
 
 	case event.EmailSubscriberType:
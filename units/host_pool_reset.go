@@ -0,0 +1,164 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	hostPoolResetJobName    = "host-pool-reset"
+	hostPoolResetRetryLimit = 5
+)
+
+func init() {
+	registry.AddJobType(hostPoolResetJobName, func() amboy.Job { return makeHostPoolResetJob() })
+}
+
+type hostPoolResetJob struct {
+	HostID        string `bson:"host_id" json:"host_id" yaml:"host_id"`
+	TaskID        string `bson:"task_id" json:"task_id" yaml:"task_id"`
+	TaskExecution int    `bson:"task_execution" json:"task_execution" yaml:"task_execution"`
+	job.Base      `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	host *host.Host
+	env  evergreen.Environment
+}
+
+func makeHostPoolResetJob() *hostPoolResetJob {
+	j := &hostPoolResetJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    hostPoolResetJobName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewHostPoolResetJob creates a job that runs the host pool's reset script on
+// a pool host whose task has finished, and then returns the host to its pool
+// so that it can be leased to another task. If the reset script fails, the
+// host is terminated instead.
+func NewHostPoolResetJob(env evergreen.Environment, h host.Host) amboy.Job {
+	j := makeHostPoolResetJob()
+	j.env = env
+	j.host = &h
+	j.HostID = h.Id
+	j.TaskID = h.SpawnOptions.LeasedByTaskID
+	j.TaskExecution = h.SpawnOptions.LeasedByTaskExecution
+	j.SetPriority(1)
+	j.SetScopes([]string{fmt.Sprintf("%s.%s", hostPoolResetJobName, h.Id)})
+	j.SetEnqueueAllScopes(true)
+	j.UpdateRetryInfo(amboy.JobRetryOptions{
+		Retryable:   utility.TruePtr(),
+		MaxAttempts: utility.ToIntPtr(hostPoolResetRetryLimit),
+	})
+	j.SetID(fmt.Sprintf("%s.%s.%s.%d", hostPoolResetJobName, j.HostID, j.TaskID, j.TaskExecution))
+	return j
+}
+
+func (j *hostPoolResetJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	if j.host == nil {
+		var err error
+		j.host, err = host.FindOneId(j.HostID)
+		if err != nil {
+			j.AddRetryableError(errors.Wrapf(err, "finding host '%s'", j.HostID))
+			return
+		}
+		if j.host == nil {
+			j.AddError(errors.Errorf("host '%s' not found", j.HostID))
+			return
+		}
+	}
+
+	if j.host.SpawnOptions.LeasedByTaskID != j.TaskID || j.host.SpawnOptions.LeasedByTaskExecution != j.TaskExecution {
+		// The host has already been returned to the pool.
+		return
+	}
+
+	// A host that hasn't started running can't have been used by the task,
+	// so it doesn't need to be reset.
+	if j.host.Status == evergreen.HostRunning {
+		script, err := j.getResetScript(ctx)
+		if err != nil {
+			j.AddRetryableError(err)
+			return
+		}
+		if script != "" {
+			logs, err := j.host.RunSSHShellScript(ctx, script, false, "")
+			if err != nil {
+				event.LogHostScriptExecuteFailed(j.host.Id, err)
+				grip.Error(message.WrapError(err, message.Fields{
+					"message":   "host pool reset script failed, terminating host",
+					"host_id":   j.host.Id,
+					"host_pool": j.host.SpawnOptions.HostPool,
+					"version":   j.host.SpawnOptions.VersionID,
+					"task_id":   j.TaskID,
+					"logs":      logs,
+					"job":       j.ID(),
+				}))
+				// The host may be left in an unknown state, so it shouldn't
+				// be leased to another task.
+				j.AddError(amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), NewHostTerminationJob(j.env, j.host, HostTerminationOptions{
+					TerminateIfBusy:   true,
+					TerminationReason: "host pool reset script failed",
+				})))
+				return
+			}
+			event.LogHostScriptExecuted(j.host.Id, logs)
+		}
+	}
+
+	if err := host.ReleasePoolHost(j.host.Id, j.TaskID, j.TaskExecution); err != nil {
+		j.AddRetryableError(err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"message":   "returned host to host pool",
+		"host_id":   j.host.Id,
+		"host_pool": j.host.SpawnOptions.HostPool,
+		"version":   j.host.SpawnOptions.VersionID,
+		"task_id":   j.TaskID,
+		"job":       j.ID(),
+	})
+}
+
+// getResetScript returns the reset script of the host's pool from the
+// project configuration of the pool's version.
+func (j *hostPoolResetJob) getResetScript(ctx context.Context) (string, error) {
+	v, err := model.VersionFindOneId(j.host.SpawnOptions.VersionID)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding version '%s'", j.host.SpawnOptions.VersionID)
+	}
+	if v == nil {
+		return "", errors.Errorf("version '%s' not found", j.host.SpawnOptions.VersionID)
+	}
+	project, _, err := model.FindAndTranslateProjectForVersion(ctx, j.env.Settings(), v)
+	if err != nil {
+		return "", errors.Wrapf(err, "loading project for version '%s'", v.Id)
+	}
+	pool := project.FindHostPool(j.host.SpawnOptions.HostPool)
+	if pool == nil {
+		return "", nil
+	}
+	return pool.ResetScript, nil
+}
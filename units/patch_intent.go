@@ -255,13 +255,18 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 		patchedParserProject = patchConfig.PatchedParserProject
 		patchedProjectConfig = patchConfig.PatchedProjectConfig
 	}
-	if errs := validator.CheckProjectErrors(patchedProject, false); len(errs.AtLevel(validator.Error)) != 0 {
+	// Warnings are always checked because the project may raise a warning
+	// rule to an error.
+	projectErrs := append(validator.CheckProjectErrors(patchedProject, false), validator.CheckProjectWarnings(patchedProject)...)
+	if errs := validator.ApplyPatchedLintRules(projectErrs, patchedProjectConfig).AtLevel(validator.Error); len(errs) != 0 {
 		validationCatcher.Errorf("invalid patched config syntax: %s", validator.ValidationErrorsToString(errs))
 	}
-	if errs := validator.CheckProjectSettings(j.env.Settings(), patchedProject, pref, false); len(errs.AtLevel(validator.Error)) != 0 {
+	settingsErrs := validator.CheckProjectSettings(j.env.Settings(), patchedProject, pref, false)
+	if errs := validator.ApplyPatchedLintRules(settingsErrs, patchedProjectConfig).AtLevel(validator.Error); len(errs) != 0 {
 		validationCatcher.Errorf("invalid patched config for current project settings: %s", validator.ValidationErrorsToString(errs))
 	}
-	if errs := validator.CheckPatchedProjectConfigErrors(patchedProjectConfig); len(errs.AtLevel(validator.Error)) != 0 {
+	configErrs := validator.CheckPatchedProjectConfigErrors(patchedProjectConfig)
+	if errs := validator.ApplyPatchedLintRules(configErrs, patchedProjectConfig).AtLevel(validator.Error); len(errs) != 0 {
 		validationCatcher.Errorf("invalid patched project config syntax: %s", validator.ValidationErrorsToString(errs))
 	}
 	if validationCatcher.HasErrors() {
@@ -511,6 +516,16 @@ func (j *patchIntentProcessor) buildTasksAndVariants(patchDoc *patch.Patch, proj
 
 	if len(patchDoc.VariantsTasks) == 0 && !skipForFailed {
 		project.BuildProjectTVPairs(patchDoc, j.intent.GetAlias())
+		if !reuseDef && !failedOnly {
+			// If selection fails, the patch runs all of its tasks.
+			grip.Error(message.WrapError(project.SelectPredictedTasks(patchDoc, j.intent.GetAlias()), message.Fields{
+				"message": "could not select predicted tasks for patch",
+				"job":     j.ID(),
+				"patch":   patchDoc.Id.Hex(),
+				"alias":   j.intent.GetAlias(),
+				"source":  "patch intents",
+			}))
+		}
 	}
 	return nil
 }
@@ -82,11 +82,6 @@ func (j *repotrackerJob) Run(ctx context.Context) {
 		j.AddError(errors.New("settings is empty"))
 		return
 	}
-	token, err := settings.GetGithubOauthToken()
-	if err != nil {
-		j.AddError(errors.New("GitHub OAuth token is missing"))
-		return
-	}
 
 	ref, err := model.FindMergedProjectRef(j.ProjectID, "", true)
 	if err != nil {
@@ -98,9 +93,16 @@ func (j *repotrackerJob) Run(ctx context.Context) {
 		return
 	}
 
-	if !repotracker.CheckGithubAPIResources(ctx, token) {
-		j.AddError(errors.Errorf("skipping repotracker run for project '%s' because of GitHub API limit issues", j.ProjectID))
-		return
+	if ref.GetRepoProvider() == model.RepoProviderGithub {
+		token, err := settings.GetGithubOauthToken()
+		if err != nil {
+			j.AddError(errors.New("GitHub OAuth token is missing"))
+			return
+		}
+		if !repotracker.CheckGithubAPIResources(ctx, token) {
+			j.AddError(errors.Errorf("skipping repotracker run for project '%s' because of GitHub API limit issues", j.ProjectID))
+			return
+		}
 	}
 
 	if err = repotracker.CollectRevisionsForProject(ctx, settings, *ref); err != nil {
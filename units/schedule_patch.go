@@ -67,3 +67,32 @@ func SchedulePatch(ctx context.Context, env evergreen.Environment, patchId strin
 	}
 	return http.StatusOK, nil
 }
+
+// ScheduleSkippedTasks schedules the tasks that predictive task selection
+// skipped in a patch. It returns an error and an HTTP status code.
+func ScheduleSkippedTasks(ctx context.Context, env evergreen.Environment, patchId string) (int, error) {
+	p, err := patch.FindOneId(patchId)
+	if err != nil {
+		return http.StatusInternalServerError, errors.Wrapf(err, "loading patch '%s'", patchId)
+	}
+	if p == nil {
+		return http.StatusNotFound, errors.Errorf("patch '%s' not found", patchId)
+	}
+	if len(p.SkippedTasks) == 0 {
+		return http.StatusBadRequest, errors.Errorf("patch '%s' has no skipped tasks", patchId)
+	}
+
+	var version *model.Version
+	if p.Version != "" {
+		version, err = model.VersionFindOneId(p.Version)
+		if err != nil {
+			return http.StatusInternalServerError, errors.Wrapf(err, "finding version '%s'", p.Version)
+		}
+	}
+
+	patchUpdateReq := model.PatchUpdate{
+		Description:   p.Description,
+		VariantsTasks: patch.MergeVariantsTasks(p.VariantsTasks, p.SkippedVariantsTasks()),
+	}
+	return SchedulePatch(ctx, env, patchId, version, patchUpdateReq)
+}
@@ -0,0 +1,98 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotDeletionName = "snapshot-deletion"
+)
+
+func init() {
+	registry.AddJobType(snapshotDeletionName,
+		func() amboy.Job { return makeSnapshotDeletionJob() })
+}
+
+type snapshotDeletionJob struct {
+	job.Base   `bson:"job_base" json:"job_base" yaml:"job_base"`
+	SnapshotID string `bson:"snapshot_id" yaml:"snapshot_id"`
+
+	snapshot *host.Snapshot
+	env      evergreen.Environment
+}
+
+func makeSnapshotDeletionJob() *snapshotDeletionJob {
+	j := &snapshotDeletionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    snapshotDeletionName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewSnapshotDeletionJob returns a job to delete a spawn host snapshot and
+// its resources in the provider.
+func NewSnapshotDeletionJob(ts string, s *host.Snapshot) amboy.Job {
+	j := makeSnapshotDeletionJob()
+	j.SetID(fmt.Sprintf("%s.%s.%s", snapshotDeletionName, s.ID, ts))
+	j.SetScopes([]string{fmt.Sprintf("%s.%s", snapshotDeletionName, s.ID)})
+	j.SetEnqueueAllScopes(true)
+	j.SnapshotID = s.ID
+	return j
+}
+
+func (j *snapshotDeletionJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+	var err error
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	if j.snapshot == nil {
+		j.snapshot, err = host.FindSnapshotByID(j.SnapshotID)
+		if err != nil {
+			j.AddError(errors.Wrapf(err, "finding snapshot '%s'", j.SnapshotID))
+			return
+		}
+		if j.snapshot == nil {
+			// The snapshot has already been deleted.
+			return
+		}
+	}
+	if j.snapshot.Status == host.SnapshotStatusCreating && time.Now().Before(j.snapshot.Expiration) {
+		// Deleting the snapshot's resources while they're still being created
+		// could leak them, so wait until the snapshot is finished unless it
+		// has been stuck long enough to expire.
+		j.AddError(errors.Errorf("snapshot '%s' is still being created", j.SnapshotID))
+		return
+	}
+
+	mgrOpts := cloud.ManagerOpts{
+		Provider: j.snapshot.Provider,
+		Region:   j.snapshot.Region,
+	}
+	mgr, err := cloud.GetManager(ctx, j.env, mgrOpts)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "getting cloud manager for snapshot '%s'", j.SnapshotID))
+		return
+	}
+
+	if err := mgr.DeleteSnapshot(ctx, j.snapshot); err != nil {
+		j.AddError(errors.Wrapf(err, "deleting snapshot '%s'", j.SnapshotID))
+		return
+	}
+}
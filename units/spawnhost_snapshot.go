@@ -0,0 +1,107 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	spawnhostSnapshotName = "spawnhost-snapshot"
+)
+
+func init() {
+	registry.AddJobType(spawnhostSnapshotName, func() amboy.Job {
+		return makeSpawnhostSnapshotJob()
+	})
+}
+
+type spawnhostSnapshotJob struct {
+	CloudHostModification `bson:"cloud_host_modification" json:"cloud_host_modification" yaml:"cloud_host_modification"`
+	SnapshotID            string `bson:"snapshot_id" json:"snapshot_id" yaml:"snapshot_id"`
+	job.Base              `bson:"job_base" json:"job_base" yaml:"job_base"`
+}
+
+func makeSpawnhostSnapshotJob() *spawnhostSnapshotJob {
+	j := &spawnhostSnapshotJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    spawnhostSnapshotName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewSpawnhostSnapshotJob returns a job to create the provider resources for
+// a snapshot of a spawn host.
+func NewSpawnhostSnapshotJob(h *host.Host, snapshot *host.Snapshot, user string) amboy.Job {
+	j := makeSpawnhostSnapshotJob()
+	j.SetID(fmt.Sprintf("%s.%s.%s.%s", spawnhostSnapshotName, user, h.Id, snapshot.ID))
+	j.SetScopes([]string{fmt.Sprintf("%s.%s", spawnHostStatusChangeScopeName, h.Id)})
+	j.SetEnqueueAllScopes(true)
+	j.CloudHostModification.HostID = h.Id
+	j.CloudHostModification.UserID = user
+	j.SnapshotID = snapshot.ID
+	return j
+}
+
+func (j *spawnhostSnapshotJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	snapshot, err := host.FindSnapshotByID(j.SnapshotID)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "finding snapshot '%s'", j.SnapshotID))
+		return
+	}
+	if snapshot == nil {
+		j.AddError(errors.Errorf("snapshot '%s' not found", j.SnapshotID))
+		return
+	}
+	if snapshot.Status != host.SnapshotStatusCreating {
+		return
+	}
+
+	snapshotCloudHost := func(mgr cloud.Manager, h *host.Host, user string) error {
+		if err := mgr.CreateSnapshot(ctx, h, snapshot); err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message":     "error creating spawn host snapshot",
+				"host_id":     h.Id,
+				"host_tag":    h.Tag,
+				"distro":      h.Distro.Id,
+				"snapshot_id": snapshot.ID,
+				"user":        user,
+			}))
+			return errors.Wrap(err, "creating spawn host snapshot")
+		}
+
+		grip.Info(message.Fields{
+			"message":     "created spawn host snapshot",
+			"host_id":     h.Id,
+			"host_tag":    h.Tag,
+			"distro":      h.Distro.Id,
+			"snapshot_id": snapshot.ID,
+			"user":        user,
+		})
+
+		return nil
+	}
+	if err := j.CloudHostModification.modifyHost(ctx, snapshotCloudHost); err != nil {
+		j.AddError(err)
+		// Failed snapshots are cleaned up by the snapshot expiration job,
+		// which deletes any resources that were already created.
+		j.AddError(errors.Wrapf(snapshot.SetStatus(host.SnapshotStatusFailed), "marking snapshot '%s' as failed", snapshot.ID))
+		return
+	}
+
+	j.AddError(errors.Wrapf(snapshot.SetStatus(host.SnapshotStatusAvailable), "marking snapshot '%s' as available", snapshot.ID))
+}
@@ -0,0 +1,68 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnhostSnapshotJob(t *testing.T) {
+	require.NoError(t, db.ClearCollections(host.Collection, host.SnapshotsCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(host.Collection, host.SnapshotsCollection))
+	}()
+	mock := cloud.GetMockProvider()
+
+	t.Run("FailsWithMissingInstance", func(t *testing.T) {
+		h := host.Host{
+			Id:       "host-missing",
+			Status:   evergreen.HostRunning,
+			Provider: evergreen.ProviderNameMock,
+			Distro:   distro.Distro{Provider: evergreen.ProviderNameMock},
+		}
+		require.NoError(t, h.Insert())
+		snapshot := host.Snapshot{ID: "snapshot-missing", CreatedBy: "user", Status: host.SnapshotStatusCreating}
+		require.NoError(t, snapshot.Insert())
+
+		j := NewSpawnhostSnapshotJob(&h, &snapshot, "user")
+		j.Run(context.Background())
+		assert.Error(t, j.Error())
+
+		dbSnapshot, err := host.FindSnapshotByID(snapshot.ID)
+		require.NoError(t, err)
+		require.NotNil(t, dbSnapshot)
+		assert.Equal(t, host.SnapshotStatusFailed, dbSnapshot.Status)
+	})
+	t.Run("Succeeds", func(t *testing.T) {
+		h := host.Host{
+			Id:       "host-running",
+			Status:   evergreen.HostRunning,
+			Provider: evergreen.ProviderNameMock,
+			Distro:   distro.Distro{Provider: evergreen.ProviderNameMock},
+		}
+		require.NoError(t, h.Insert())
+		mock.Set(h.Id, cloud.MockInstance{
+			Status: cloud.StatusRunning,
+		})
+		snapshot := host.Snapshot{ID: "snapshot-running", CreatedBy: "user", Status: host.SnapshotStatusCreating}
+		require.NoError(t, snapshot.Insert())
+
+		j := NewSpawnhostSnapshotJob(&h, &snapshot, "user")
+		j.Run(context.Background())
+		assert.NoError(t, j.Error())
+		assert.True(t, j.Status().Completed)
+
+		dbSnapshot, err := host.FindSnapshotByID(snapshot.ID)
+		require.NoError(t, err)
+		require.NotNil(t, dbSnapshot)
+		assert.Equal(t, host.SnapshotStatusAvailable, dbSnapshot.Status)
+		assert.Equal(t, "mock-image-"+snapshot.ID, dbSnapshot.ImageID)
+	})
+}
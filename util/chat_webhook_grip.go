@@ -0,0 +1,156 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/pkg/errors"
+)
+
+const (
+	chatWebhookAttempts = 3
+
+	// maxChatWebhookResponseSize is the maximum number of bytes of a chat
+	// service's error response included in the returned error.
+	maxChatWebhookResponseSize = 1024
+)
+
+// ChatWebhook is a message to post to a chat service's incoming webhook URL.
+// The body is the already-formatted JSON message.
+type ChatWebhook struct {
+	NotificationID string `bson:"notification_id"`
+	URL            string `bson:"url"`
+	Body           []byte `bson:"body"`
+}
+
+type chatWebhookMessage struct {
+	raw ChatWebhook
+
+	message.Base
+}
+
+func NewChatWebhookMessage(raw ChatWebhook) message.Composer {
+	return &chatWebhookMessage{
+		raw: raw,
+	}
+}
+
+func (c *chatWebhookMessage) Loggable() bool {
+	if len(c.raw.NotificationID) == 0 {
+		return false
+	}
+	if len(c.raw.Body) == 0 {
+		return false
+	}
+	if len(c.raw.URL) == 0 {
+		return false
+	}
+
+	_, err := url.Parse(c.raw.URL)
+	grip.Error(message.WrapError(err, message.Fields{
+		"message":         "chat-webhook invalid url",
+		"notification_id": c.raw.NotificationID,
+	}))
+
+	return err == nil
+}
+
+func (c *chatWebhookMessage) Raw() interface{} {
+	return &c.raw
+}
+
+func (c *chatWebhookMessage) String() string {
+	return string(c.raw.Body)
+}
+
+type chatWebhookLogger struct {
+	client *http.Client
+	*send.Base
+}
+
+// NewChatWebhookLogger returns a sender that posts ChatWebhook messages to
+// their incoming webhook URL.
+func NewChatWebhookLogger() (send.Sender, error) {
+	s := &chatWebhookLogger{
+		Base: send.NewBase("evergreen"),
+	}
+
+	return s, nil
+}
+
+func (c *chatWebhookLogger) Send(m message.Composer) {
+	if c.Level().ShouldLog(m) {
+		if err := c.send(m); err != nil {
+			c.ErrorHandler()(err, m)
+		}
+	}
+}
+
+func (c *chatWebhookLogger) send(m message.Composer) error {
+	raw, ok := m.Raw().(*ChatWebhook)
+	if !ok {
+		return errors.Errorf("received unexpected composer %T", m.Raw())
+	}
+
+	return PostChatWebhook(context.Background(), c.client, raw)
+}
+
+// PostChatWebhook posts the message to its incoming webhook URL, retrying
+// failed attempts. If client is nil, a client from the shared pool is used.
+func PostChatWebhook(ctx context.Context, client *http.Client, raw *ChatWebhook) error {
+	if client == nil {
+		client = utility.GetHTTPClient()
+		defer utility.PutHTTPClient(client)
+	}
+
+	return utility.Retry(ctx, func() (bool, error) {
+		return raw.post(ctx, client)
+	}, utility.RetryOptions{
+		MaxAttempts: chatWebhookAttempts,
+		MinDelay:    defaultMinDelay,
+	})
+}
+
+// post sends the message once. It returns whether the request should be
+// retried.
+func (c *ChatWebhook) post(ctx context.Context, client *http.Client) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(c.Body))
+	if err != nil {
+		return false, errors.Wrap(err, "creating chat webhook HTTP request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "sending chat webhook message")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxChatWebhookResponseSize))
+		// Client errors mean the message or URL was rejected, so retrying
+		// won't help, except when the service is rate limiting.
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retry, errors.Errorf("response was %d (%s): %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
+	}
+
+	grip.Info(message.Fields{
+		"message":         "send chat webhook notification",
+		"notification_id": c.NotificationID,
+		"response_code":   resp.StatusCode,
+	})
+
+	return false, nil
+}
+
+func (c *chatWebhookLogger) Flush(_ context.Context) error { return nil }
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatWebhookComposer(t *testing.T) {
+	assert.False(t, NewChatWebhookMessage(ChatWebhook{}).Loggable())
+	assert.False(t, NewChatWebhookMessage(ChatWebhook{
+		NotificationID: "evergreen",
+		URL:            "https://chat.example.com/hooks/abc",
+	}).Loggable())
+
+	m := NewChatWebhookMessage(ChatWebhook{
+		NotificationID: "evergreen",
+		URL:            "https://chat.example.com/hooks/abc",
+		Body:           []byte(`{"text": "hi"}`),
+	})
+	assert.True(t, m.Loggable())
+	assert.Equal(t, `{"text": "hi"}`, m.String())
+	raw, ok := m.Raw().(*ChatWebhook)
+	require.True(t, ok)
+	assert.Equal(t, "https://chat.example.com/hooks/abc", raw.URL)
+}
+
+func TestPostChatWebhook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for name, test := range map[string]struct {
+		status           int
+		expectedAttempts int32
+		expectErr        bool
+	}{
+		"Succeeds": {
+			status:           http.StatusOK,
+			expectedAttempts: 1,
+		},
+		"DoesNotRetryRejectedMessage": {
+			status:           http.StatusBadRequest,
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		"RetriesServerErrors": {
+			status:           http.StatusServiceUnavailable,
+			expectedAttempts: chatWebhookAttempts,
+			expectErr:        true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, `{"text": "hi"}`, string(body))
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			err := PostChatWebhook(ctx, server.Client(), &ChatWebhook{
+				NotificationID: "evergreen",
+				URL:            server.URL,
+				Body:           []byte(`{"text": "hi"}`),
+			})
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
@@ -20,6 +20,10 @@ const (
 	defaultMinDelay               = 500 * time.Millisecond
 	evergreenNotificationIDHeader = "X-Evergreen-Notification-ID"
 	evergreenHMACHeader           = "X-Evergreen-Signature"
+
+	// maxWebhookAttemptResponseSize is the maximum number of bytes of a
+	// webhook response body passed to the attempt callback.
+	maxWebhookAttemptResponseSize = 4 * 1024
 )
 
 type EvergreenWebhook struct {
@@ -31,6 +35,26 @@ type EvergreenWebhook struct {
 	Retries        int         `bson:"retries"`
 	MinDelayMS     int         `bson:"min_delay_ms"`
 	TimeoutMS      int         `bson:"timeout_ms"`
+
+	// OnAttempt, if set, is called after every attempt to deliver the
+	// webhook.
+	OnAttempt func(WebhookAttempt) `bson:"-"`
+}
+
+// WebhookAttempt describes a single attempt to deliver a webhook.
+type WebhookAttempt struct {
+	// Attempt is the 1-based number of the attempt.
+	Attempt        int
+	StartedAt      time.Time
+	Latency        time.Duration
+	RequestHeaders http.Header
+	// StatusCode is the HTTP status code of the response, or 0 if no
+	// response was received.
+	StatusCode int
+	// ResponseBody is the beginning of the response body.
+	ResponseBody []byte
+	// Err is the error that caused the attempt to fail, if any.
+	Err error
 }
 
 type evergreenWebhookMessage struct {
@@ -134,6 +158,14 @@ func (w *evergreenWebhookLogger) send(m message.Composer) error {
 	if !ok {
 		return errors.Errorf("received unexpected composer %T", m.Raw())
 	}
+
+	return DeliverWebhook(context.Background(), w.client, raw)
+}
+
+// DeliverWebhook posts the webhook to its URL, retrying failed attempts up to
+// the webhook's number of retries. If client is nil, a client from the shared
+// pool is used.
+func DeliverWebhook(ctx context.Context, client *http.Client, raw *EvergreenWebhook) error {
 	timeout := defaultWebhookTimeout
 	if raw.TimeoutMS > 0 {
 		timeout = time.Duration(raw.TimeoutMS) * time.Millisecond
@@ -143,51 +175,76 @@ func (w *evergreenWebhookLogger) send(m message.Composer) error {
 		minDelay = time.Duration(raw.MinDelayMS) * time.Millisecond
 	}
 
-	client := w.client
-	return utility.Retry(context.Background(), func() (bool, error) {
+	if client == nil {
+		client = utility.GetHTTPClient()
+		defer utility.PutHTTPClient(client)
+	}
+
+	attempt := 0
+	return utility.Retry(ctx, func() (bool, error) {
+		attempt++
 		req, err := raw.request()
 		if err != nil {
 			return false, errors.Wrap(err, "making webhook request")
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-		req = req.WithContext(ctx)
-
-		if client == nil {
-			client = utility.GetHTTPClient()
-			defer utility.PutHTTPClient(client)
-		}
-
-		resp, err := client.Do(req)
-		if resp != nil {
-			defer resp.Body.Close()
+		result := WebhookAttempt{
+			Attempt:        attempt,
+			StartedAt:      time.Now(),
+			RequestHeaders: req.Header.Clone(),
 		}
-		if err != nil {
-			return true, errors.Wrap(err, "sending webhook data")
-		}
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return true, errors.Errorf("response was %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return true, errors.Wrap(err, "reading webhook response")
+		retry, err := raw.attempt(ctx, client, req, timeout, &result)
+		result.Latency = time.Since(result.StartedAt)
+		result.Err = err
+		if raw.OnAttempt != nil {
+			raw.OnAttempt(result)
 		}
 
-		grip.Info(message.Fields{
-			"message":         "send webhook notification",
-			"notification_id": raw.NotificationID,
-			"url":             raw.URL,
-			"response_code":   resp.StatusCode,
-			"response_body":   body,
-		})
-
-		return false, nil
+		return retry, err
 	}, utility.RetryOptions{
 		MaxAttempts: raw.Retries + 1,
 		MinDelay:    minDelay,
 	})
 }
 
+// attempt sends the webhook request once, recording the response in result.
+// It returns whether the request should be retried.
+func (w *EvergreenWebhook) attempt(ctx context.Context, client *http.Client, req *http.Request, timeout time.Duration, result *WebhookAttempt) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return true, errors.Wrap(err, "sending webhook data")
+	}
+	result.StatusCode = resp.StatusCode
+
+	body, err := io.ReadAll(resp.Body)
+	if len(body) > maxWebhookAttemptResponseSize {
+		result.ResponseBody = body[:maxWebhookAttemptResponseSize]
+	} else {
+		result.ResponseBody = body
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return true, errors.Errorf("response was %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if err != nil {
+		return true, errors.Wrap(err, "reading webhook response")
+	}
+
+	grip.Info(message.Fields{
+		"message":         "send webhook notification",
+		"notification_id": w.NotificationID,
+		"url":             w.URL,
+		"response_code":   resp.StatusCode,
+		"response_body":   body,
+	})
+
+	return false, nil
+}
+
 func (w *evergreenWebhookLogger) Flush(_ context.Context) error { return nil }
@@ -158,6 +158,40 @@ func TestEvergreenWebhookSender(t *testing.T) {
 			assert.Equal(t, attempts, transport.attemptCount)
 			assert.Equal(t, body, transport.lastBody)
 		},
+		"RecordsAttempts": func(t *testing.T) {
+			transport.minAttempts = 2
+			secret := []byte("hi")
+			transport.secret = secret
+			var attempts []WebhookAttempt
+			m := NewWebhookMessage(EvergreenWebhook{
+				NotificationID: "evergreen",
+				URL:            "https://example.com",
+				Secret:         secret,
+				Body:           []byte("something important"),
+				Retries:        1,
+				OnAttempt: func(attempt WebhookAttempt) {
+					attempts = append(attempts, attempt)
+				},
+			})
+			assert.NoError(t, s.SetErrorHandler(func(err error, _ message.Composer) {
+				t.Fatal("error handler was called, but shouldn't have been")
+			}))
+
+			s.Send(m)
+			require.Len(t, attempts, 2)
+
+			assert.Equal(t, 1, attempts[0].Attempt)
+			assert.Equal(t, http.StatusBadRequest, attempts[0].StatusCode)
+			assert.Equal(t, "won't succeed before 2 requests", string(attempts[0].ResponseBody))
+			assert.Error(t, attempts[0].Err)
+			assert.Equal(t, "evergreen", attempts[0].RequestHeaders.Get(evergreenNotificationIDHeader))
+			assert.NotEmpty(t, attempts[0].RequestHeaders.Get(evergreenHMACHeader))
+			assert.False(t, attempts[0].StartedAt.IsZero())
+
+			assert.Equal(t, 2, attempts[1].Attempt)
+			assert.Equal(t, http.StatusNoContent, attempts[1].StatusCode)
+			assert.NoError(t, attempts[1].Err)
+		},
 	} {
 		transport = mockWebhookTransport{}
 		s.client = &http.Client{
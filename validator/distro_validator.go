@@ -205,7 +205,7 @@ func validateSingleProviderSettings(d *distro.Distro) error {
 // ensureUniqueId checks that the distro's id does not collide with an existing id.
 func ensureUniqueId(d *distro.Distro, distroIds []string) ValidationErrors {
 	if utility.StringSliceContains(distroIds, d.Id) {
-		return ValidationErrors{{Error, fmt.Sprintf("distro '%v' uses an existing identifier", d.Id)}}
+		return ValidationErrors{{Level: Error, Message: fmt.Sprintf("distro '%v' uses an existing identifier", d.Id)}}
 	}
 	return nil
 }
@@ -244,7 +244,7 @@ func ensureNoAliases(d *distro.Distro, distroAliases []string) ValidationErrors
 func ensureValidExpansions(ctx context.Context, d *distro.Distro, s *evergreen.Settings) ValidationErrors {
 	for _, e := range d.Expansions {
 		if e.Key == "" {
-			return ValidationErrors{{Error, "distro cannot be blank expansion key"}}
+			return ValidationErrors{{Level: Error, Message: "distro cannot be blank expansion key"}}
 		}
 	}
 	return nil
@@ -254,7 +254,7 @@ func ensureValidExpansions(ctx context.Context, d *distro.Distro, s *evergreen.S
 func ensureValidSSHOptions(ctx context.Context, d *distro.Distro, s *evergreen.Settings) ValidationErrors {
 	for _, o := range d.SSHOptions {
 		if o == "" {
-			return ValidationErrors{{Error, "distro cannot be blank SSH option"}}
+			return ValidationErrors{{Level: Error, Message: "distro cannot be blank SSH option"}}
 		}
 	}
 	return nil
@@ -337,11 +337,11 @@ func ensureValidCloneMethod(ctx context.Context, d *distro.Distro, s *evergreen.
 
 func ensureHasNonZeroID(ctx context.Context, d *distro.Distro, s *evergreen.Settings) ValidationErrors {
 	if d == nil {
-		return ValidationErrors{{Error, "distro cannot be nil"}}
+		return ValidationErrors{{Level: Error, Message: "distro cannot be nil"}}
 	}
 
 	if d.Id == "" {
-		return ValidationErrors{{Error, "distro must specify id"}}
+		return ValidationErrors{{Level: Error, Message: "distro must specify id"}}
 	}
 
 	return nil
@@ -351,7 +351,7 @@ func ensureHasNonZeroID(ctx context.Context, d *distro.Distro, s *evergreen.Sett
 func ensureHasNoUnauthorizedCharacters(ctx context.Context, d *distro.Distro, s *evergreen.Settings) ValidationErrors {
 	if strings.ContainsAny(d.Id, unauthorizedDistroCharacters) {
 		message := fmt.Sprintf("distro '%v' contains unauthorized characters (%v)", d.Id, unauthorizedDistroCharacters)
-		return ValidationErrors{{Error, message}}
+		return ValidationErrors{{Level: Error, Message: message}}
 	}
 	return nil
 }
@@ -363,12 +363,12 @@ func ensureValidContainerPool(ctx context.Context, d *distro.Distro, s *evergree
 		// check if container pool exists
 		pool := s.ContainerPools.GetContainerPool(d.ContainerPool)
 		if pool == nil {
-			return ValidationErrors{{Error, "distro container pool does not exist"}}
+			return ValidationErrors{{Level: Error, Message: "distro container pool does not exist"}}
 		}
 		// warn if container pool exists without valid distro
 		err := distro.ValidateContainerPoolDistros(s)
 		if err != nil {
-			return ValidationErrors{{Error, "error in container pool settings: " + err.Error()}}
+			return ValidationErrors{{Level: Error, Message: "error in container pool settings: " + err.Error()}}
 		}
 	}
 	return nil
@@ -504,6 +504,47 @@ func ensureHasValidPlannerSettings(ctx context.Context, d *distro.Distro, s *eve
 			Level:   Error,
 		})
 	}
+	if settings.FairShareFactor < 0 || settings.FairShareFactor > 100 {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("invalid planner_settings.fair_share_factor value of %d for distro '%s' - its value must be a non-negative integer between 0 and 100, inclusive", settings.FairShareFactor, d.Id),
+			Level:   Error,
+		})
+	}
+	if settings.FairShareWindow < 0 {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("invalid planner_settings.fair_share_window value of %s for distro '%s' - its value must be non-negative", settings.FairShareWindow, d.Id),
+			Level:   Error,
+		})
+	}
+	var totalShare float64
+	projects := map[string]bool{}
+	for _, ps := range settings.ProjectShares {
+		if ps.Project == "" {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("planner_settings.project_shares for distro '%s' cannot have an empty project", d.Id),
+				Level:   Error,
+			})
+		} else if projects[ps.Project] {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("planner_settings.project_shares for distro '%s' has duplicate project '%s'", d.Id, ps.Project),
+				Level:   Error,
+			})
+		}
+		projects[ps.Project] = true
+		if ps.Share <= 0 || ps.Share > 1 {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("invalid planner_settings.project_shares value of %g for project '%s' in distro '%s' - its value must be greater than 0 and at most 1", ps.Share, ps.Project, d.Id),
+				Level:   Error,
+			})
+		}
+		totalShare += ps.Share
+	}
+	if totalShare > 1 {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("planner_settings.project_shares for distro '%s' add up to %g, which is more than 1", d.Id, totalShare),
+			Level:   Error,
+		})
+	}
 
 	return errs
 }
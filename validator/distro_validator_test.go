@@ -486,14 +486,14 @@ func TestEnsureValidContainerPool(t *testing.T) {
 	assert.NoError(d4.Insert())
 
 	err := ensureValidContainerPool(ctx, d1, conf)
-	assert.Equal(err, ValidationErrors{{Error,
-		"error in container pool settings: container pool 'test-pool-invalid' has invalid distro 'd1'"}})
+	assert.Equal(err, ValidationErrors{{Level: Error,
+		Message: "error in container pool settings: container pool 'test-pool-invalid' has invalid distro 'd1'"}})
 	err = ensureValidContainerPool(ctx, d2, conf)
-	assert.Equal(err, ValidationErrors{{Error,
-		"error in container pool settings: container pool 'test-pool-invalid' has invalid distro 'd1'"}})
+	assert.Equal(err, ValidationErrors{{Level: Error,
+		Message: "error in container pool settings: container pool 'test-pool-invalid' has invalid distro 'd1'"}})
 	err = ensureValidContainerPool(ctx, d3, conf)
-	assert.Equal(err, ValidationErrors{{Error,
-		"distro container pool does not exist"}})
+	assert.Equal(err, ValidationErrors{{Level: Error,
+		Message: "distro container pool does not exist"}})
 	err = ensureValidContainerPool(ctx, d4, conf)
 	assert.Nil(err)
 }
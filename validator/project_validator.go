@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ import (
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/level"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
 )
 
 type projectValidator func(*model.Project) ValidationErrors
@@ -32,6 +34,27 @@ type projectSettingsValidator func(*evergreen.Settings, *model.Project, *model.P
 // bool indicates if we should still run the validator if the project is complex
 type longValidator func(*model.Project, bool) ValidationErrors
 
+// projectRule is a validator for the rule with the given ID.
+type projectRule struct {
+	rule     string
+	validate projectValidator
+}
+
+type projectConfigRule struct {
+	rule     string
+	validate projectConfigValidator
+}
+
+type projectSettingsRule struct {
+	rule     string
+	validate projectSettingsValidator
+}
+
+type longRule struct {
+	rule     string
+	validate longValidator
+}
+
 type ValidationErrorLevel int64
 
 const (
@@ -57,6 +80,8 @@ func (vel ValidationErrorLevel) String() string {
 type ValidationError struct {
 	Level   ValidationErrorLevel `json:"level"`
 	Message string               `json:"message"`
+	// Rule is the ID of the rule that found the error, if any.
+	Rule string `json:"rule,omitempty"`
 }
 
 type ValidationErrors []ValidationError
@@ -74,6 +99,9 @@ func (v ValidationErrors) String() string {
 			out += "\n"
 		}
 		out += fmt.Sprintf("%s: %s", validationErr.Level.String(), validationErr.Message)
+		if validationErr.Rule != "" {
+			out += fmt.Sprintf(" [%s]", validationErr.Rule)
+		}
 	}
 
 	return out
@@ -117,53 +145,59 @@ type ValidationInput struct {
 }
 
 // Functions used to validate the syntax of a project configuration file.
-var projectErrorValidators = []projectValidator{
-	validateBVFields,
-	validateDependencyGraph,
-	validatePluginCommands,
-	validateProjectFields,
-	validateTaskDependencies,
-	validateTaskNames,
-	validateBVNames,
-	validateBVBatchTimes,
-	validateDisplayTaskNames,
-	validateBVTaskNames,
-	validateAllDependenciesSpec,
-	validateProjectTaskNames,
-	validateProjectTaskIdsAndTags,
-	validateParameters,
-	validateTaskGroups,
-	validateHostCreates,
-	validateDuplicateBVTasks,
-	validateGenerateTasks,
+var projectErrorValidators = []projectRule{
+	{rule: ruleBuildVariantFields, validate: validateBVFields},
+	{rule: ruleDependencyCycle, validate: validateDependencyGraph},
+	{rule: rulePluginCommands, validate: validatePluginCommands},
+	{rule: ruleProjectFields, validate: validateProjectFields},
+	{rule: ruleTaskDependencies, validate: validateTaskDependencies},
+	{rule: ruleTaskNameCharacters, validate: validateTaskNames},
+	{rule: ruleBuildVariantNames, validate: validateBVNames},
+	{rule: ruleBuildVariantBatchTimes, validate: validateBVBatchTimes},
+	{rule: ruleDisplayTaskNames, validate: validateDisplayTaskNames},
+	{rule: ruleBuildVariantTaskNames, validate: validateBVTaskNames},
+	{rule: ruleAllDependenciesSpec, validate: validateAllDependenciesSpec},
+	{rule: ruleDuplicateTaskNames, validate: validateProjectTaskNames},
+	{rule: ruleTaskIDsAndTags, validate: validateProjectTaskIdsAndTags},
+	{rule: ruleParameters, validate: validateParameters},
+	{rule: ruleTaskGroupDefinitions, validate: validateTaskGroups},
+	{rule: ruleHostCreateLimits, validate: validateHostCreates},
+	{rule: ruleHostPools, validate: validateHostPools},
+	{rule: ruleDuplicateBuildVariantTasks, validate: validateDuplicateBVTasks},
+	{rule: ruleGenerateTasksLimit, validate: validateGenerateTasks},
 }
 
 // Functions used to validate the syntax of project configs representing properties found on the project page.
-var projectConfigErrorValidators = []projectConfigValidator{
-	validateProjectConfigAliases,
-	validateProjectConfigPlugins,
-	validateProjectConfigContainers,
+var projectConfigErrorValidators = []projectConfigRule{
+	{rule: ruleProjectConfigAliases, validate: validateProjectConfigAliases},
+	{rule: ruleProjectConfigPlugins, validate: validateProjectConfigPlugins},
+	{rule: ruleProjectConfigContainers, validate: validateProjectConfigContainers},
+	{rule: ruleLintRules, validate: validateProjectConfigLintRules},
 }
 
 // Functions used to validate the semantics of a project configuration file.
-var projectWarningValidators = []projectValidator{
-	checkTaskGroups,
-	checkProjectFields,
-	checkTaskRuns,
-	checkModules,
-	checkTasks,
-	checkBuildVariants,
+var projectWarningValidators = []projectRule{
+	{rule: ruleTaskGroupSettings, validate: checkTaskGroups},
+	{rule: ruleBatchTimeLimit, validate: checkProjectFields},
+	{rule: ruleTaskNeverRuns, validate: checkTaskRuns},
+	{rule: ruleModuleFields, validate: checkModules},
+	{rule: ruleTaskDefinitions, validate: checkTasks},
+	{rule: ruleBuildVariantDefinitions, validate: checkBuildVariants},
+	{rule: ruleUnusedFunction, validate: checkUnusedFunctions},
+	{rule: ruleTaskNotInVariant, validate: checkTasksNotInVariants},
+	{rule: ruleDuplicateCommandBlocks, validate: checkDuplicateCommandBlocks},
 }
 
-var projectSettingsValidators = []projectSettingsValidator{
-	validateTaskSyncSettings,
-	validateVersionControl,
-	validateContainers,
+var projectSettingsValidators = []projectSettingsRule{
+	{rule: ruleTaskSyncSettings, validate: validateTaskSyncSettings},
+	{rule: ruleVersionControl, validate: validateVersionControl},
+	{rule: ruleContainerSettings, validate: validateContainers},
+	{rule: ruleUndefinedExpansion, validate: checkUndefinedExpansions},
 }
 
 // These validators have the potential to be very long, and may not be fully run unless specified.
-var longErrorValidators = []longValidator{
-	validateTaskSyncCommands,
+var longErrorValidators = []longRule{
+	{rule: ruleTaskSyncCommands, validate: validateTaskSyncCommands},
 }
 
 func (vr ValidationError) Error() string {
@@ -223,13 +257,13 @@ func CheckProjectWarnings(project *model.Project) ValidationErrors {
 	validationErrs := ValidationErrors{}
 	for _, projectWarningValidator := range projectWarningValidators {
 		validationErrs = append(validationErrs,
-			projectWarningValidator(project)...)
+			projectWarningValidator.validate(project).withRule(projectWarningValidator.rule)...)
 	}
 	return validationErrs
 }
 
 func CheckAliasWarnings(project *model.Project, aliases model.ProjectAliases) ValidationErrors {
-	return validateAliasCoverage(project, aliases)
+	return validateAliasCoverage(project, aliases).withRule(ruleAliasCoverage)
 }
 
 // verify that the project configuration syntax is valid
@@ -237,11 +271,11 @@ func CheckProjectErrors(project *model.Project, includeLong bool) ValidationErro
 	validationErrs := ValidationErrors{}
 	for _, projectErrorValidator := range projectErrorValidators {
 		validationErrs = append(validationErrs,
-			projectErrorValidator(project)...)
+			projectErrorValidator.validate(project).withRule(projectErrorValidator.rule)...)
 	}
 	for _, longSyntaxValidator := range longErrorValidators {
 		validationErrs = append(validationErrs,
-			longSyntaxValidator(project, includeLong)...)
+			longSyntaxValidator.validate(project, includeLong).withRule(longSyntaxValidator.rule)...)
 	}
 
 	// get distro IDs and aliases for ensureReferentialIntegrity validation
@@ -252,11 +286,14 @@ func CheckProjectErrors(project *model.Project, includeLong bool) ValidationErro
 	containerNameMap := map[string]bool{}
 	for _, container := range project.Containers {
 		if containerNameMap[container.Name] {
-			validationErrs = append(validationErrs, ValidationError{Message: fmt.Sprintf("container '%s' is defined multiple times", container.Name)})
+			validationErrs = append(validationErrs, ValidationError{
+				Message: fmt.Sprintf("container '%s' is defined multiple times", container.Name),
+				Rule:    ruleDuplicateContainers,
+			})
 		}
 		containerNameMap[container.Name] = true
 	}
-	validationErrs = append(validationErrs, ensureReferentialIntegrity(project, containerNameMap, distroIDs, distroAliases)...)
+	validationErrs = append(validationErrs, ensureReferentialIntegrity(project, containerNameMap, distroIDs, distroAliases).withRule(ruleReferentialIntegrity)...)
 	return validationErrs
 }
 
@@ -283,7 +320,7 @@ func CheckProjectConfigErrors(projectConfig *model.ProjectConfig) ValidationErro
 	}
 	for _, projectConfigErrorValidator := range projectConfigErrorValidators {
 		validationErrs = append(validationErrs,
-			projectConfigErrorValidator(projectConfig)...)
+			projectConfigErrorValidator.validate(projectConfig).withRule(projectConfigErrorValidator.rule)...)
 	}
 	return validationErrs
 }
@@ -293,7 +330,7 @@ func CheckProjectConfigErrors(projectConfig *model.ProjectConfig) ValidationErro
 func CheckProjectSettings(settings *evergreen.Settings, p *model.Project, ref *model.ProjectRef, isConfigDefined bool) ValidationErrors {
 	var errs ValidationErrors
 	for _, validateSettings := range projectSettingsValidators {
-		errs = append(errs, validateSettings(settings, p, ref, isConfigDefined)...)
+		errs = append(errs, validateSettings.validate(settings, p, ref, isConfigDefined).withRule(validateSettings.rule)...)
 	}
 	return errs
 }
@@ -810,6 +847,7 @@ func ensureReferentialIntegrity(project *model.Project, containerNameMap map[str
 						Message: fmt.Sprintf("task '%s' in build variant '%s' is already referenced in task group '%s'",
 							task.Name, buildVariant.Name, taskGroupTaskSet[task.Name]),
 						Level: Warning,
+						Rule:  ruleTaskInVariantAndGroup,
 					})
 			}
 			buildVariantTasks[task.Name] = true
@@ -822,6 +860,7 @@ func ensureReferentialIntegrity(project *model.Project, containerNameMap map[str
 							Message: fmt.Sprintf("task '%s' in buildvariant '%s' references a nonexistent distro or container named '%s'",
 								task.Name, buildVariant.Name, name),
 							Level: Warning,
+							Rule:  ruleNonexistentDistro,
 						},
 					)
 				} else if utility.StringSliceContains(distroIDs, name) && containerNameMap[name] {
@@ -832,6 +871,7 @@ func ensureReferentialIntegrity(project *model.Project, containerNameMap map[str
 								"configuration will override the distro",
 								task.Name, buildVariant.Name, name),
 							Level: Warning,
+							Rule:  ruleContainerDistroOverlap,
 						},
 					)
 				}
@@ -853,6 +893,7 @@ func ensureReferentialIntegrity(project *model.Project, containerNameMap map[str
 						Message: fmt.Sprintf("buildvariant '%s' references a nonexistent distro or container named '%s'",
 							buildVariant.Name, name),
 						Level: Warning,
+						Rule:  ruleNonexistentDistro,
 					},
 				)
 			} else if utility.StringSliceContains(distroIDs, name) && containerNameMap[name] {
@@ -863,6 +904,7 @@ func ensureReferentialIntegrity(project *model.Project, containerNameMap map[str
 							"configuration will override the distro",
 							buildVariant.Name, name),
 						Level: Warning,
+						Rule:  ruleContainerDistroOverlap,
 					},
 				)
 			}
@@ -889,6 +931,7 @@ func checkRunOn(runOnHasDistro, runOnHasContainer bool, runOn []string) []Valida
 		return []ValidationError{{
 			Message: "only one container can be used from run_on; the first container in the list will be used",
 			Level:   Warning,
+			Rule:    ruleMultipleContainers,
 		}}
 	}
 	return nil
@@ -1293,6 +1336,7 @@ func validatePluginCommands(project *model.Project) ValidationErrors {
 		errs = append(errs, ValidationError{
 			Message: "early_termination block is deprecated and will be removed in the future",
 			Level:   Warning,
+			Rule:    ruleDeprecatedEarlyTermination,
 		})
 	}
 
@@ -1757,6 +1801,86 @@ func validateHostCreateTotals(p *model.Project, counts hostCreateCounts) Validat
 	return errs
 }
 
+// validateHostPools validates that host pools have unique names, a valid size
+// and valid host settings, and that host.create only leases hosts from pools
+// that are defined.
+func validateHostPools(p *model.Project) ValidationErrors {
+	errs := ValidationErrors{}
+	pools := map[string]bool{}
+	for _, pool := range p.HostPools {
+		if pool.Name == "" {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: "host pool must have a name",
+			})
+			continue
+		}
+		if pools[pool.Name] {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("host pool '%s' is defined multiple times", pool.Name),
+			})
+		}
+		pools[pool.Name] = true
+
+		if pool.Size <= 0 {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("host pool '%s' must have a positive size", pool.Name),
+			})
+		}
+		if pool.Host.Pool != "" || pool.Host.Scope != "" {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("host pool '%s' cannot set the pool or scope of its hosts", pool.Name),
+			})
+		}
+		if pool.Host.CloudProvider == evergreen.ProviderNameDocker {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("host pool '%s' must use EC2 hosts", pool.Name),
+			})
+		}
+		if pool.ResetScript != "" && pool.Host.Distro == "" {
+			// Evergreen runs the reset script over SSH using the distro's
+			// SSH settings.
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("host pool '%s' must create its hosts from a distro to run a reset script", pool.Name),
+			})
+		}
+	}
+
+	checkPoolParam := func(cmd model.PluginCommandConf, location string) {
+		if cmd.Command != evergreen.HostCreateCommandName {
+			return
+		}
+		pool, ok := cmd.Params["pool"].(string)
+		if !ok || pool == "" || strings.Contains(pool, "${") || pools[pool] {
+			return
+		}
+		errs = append(errs, ValidationError{
+			Level:   Error,
+			Message: fmt.Sprintf("%s leases hosts from host pool '%s', which is not defined", location, pool),
+		})
+	}
+	for name, cmds := range p.Functions {
+		if cmds == nil {
+			continue
+		}
+		for _, cmd := range cmds.List() {
+			checkPoolParam(cmd, fmt.Sprintf("function '%s'", name))
+		}
+	}
+	for _, t := range p.Tasks {
+		for _, cmd := range t.Commands {
+			checkPoolParam(cmd, fmt.Sprintf("task '%s'", t.Name))
+		}
+	}
+
+	return errs
+}
+
 // validateGenerateTasks validates that no task calls 'generate.tasks' more than once, since if one
 // does, the server will noop it.
 func validateGenerateTasks(p *model.Project) ValidationErrors {
@@ -1900,7 +2024,7 @@ func validateTaskSyncCommands(p *model.Project, runLong bool) ValidationErrors {
 
 	// A task should not call s3.push multiple times.
 	s3PushCalls := p.TasksThatCallCommand(evergreen.S3PushCommandName)
-	errs = append(errs, validateTimesCalledPerTask(p, s3PushCalls, evergreen.S3PushCommandName, 1, Warning)...)
+	errs = append(errs, validateTimesCalledPerTask(p, s3PushCalls, evergreen.S3PushCommandName, 1, Warning).withRule(ruleMultipleS3Push)...)
 
 	bvToTaskCmds, numCmds, err := bvsWithTasksThatCallCommand(p, evergreen.S3PullCommandName)
 	if err != nil {
@@ -2109,15 +2233,16 @@ func checkTasks(project *model.Project) ValidationErrors {
 			)
 			execTimeoutWarningAdded = true
 		}
-		errs = append(errs, checkLoggerConfig(&task)...)
-		errs = append(errs, checkTaskDependencies(&task, allTasks)...)
-		errs = append(errs, checkTaskNames(project, &task)...)
+		errs = append(errs, checkLoggerConfig(&task).withRule(ruleLoggerConfig)...)
+		errs = append(errs, checkTaskDependencies(&task, allTasks).withRule(ruleTaskDependencyRequesters)...)
+		errs = append(errs, checkTaskNames(project, &task).withRule(ruleTaskNamesAmbiguous)...)
 	}
 	if project.Loggers != nil {
 		if err := project.Loggers.IsValid(); err != nil {
 			errs = append(errs, ValidationError{
 				Message: errors.Wrap(err, "error in project-level logger config").Error(),
 				Level:   Warning,
+				Rule:    ruleLoggerConfig,
 			})
 		}
 	}
@@ -2141,8 +2266,8 @@ func checkBuildVariants(project *model.Project) ValidationErrors {
 				},
 			)
 		}
-		errs = append(errs, checkBVNames(&buildVariant)...)
-		errs = append(errs, checkBVBatchTimes(&buildVariant)...)
+		errs = append(errs, checkBVNames(&buildVariant).withRule(ruleVariantNamesAmbiguous)...)
+		errs = append(errs, checkBVBatchTimes(&buildVariant).withRule(ruleBatchTimeActivation)...)
 	}
 
 	for k, v := range displayNames {
@@ -2158,3 +2283,356 @@ func checkBuildVariants(project *model.Project) ValidationErrors {
 	}
 	return errs
 }
+
+// commandBlock is a list of commands that run together, such as a function,
+// a task's commands or a task group's setup_group.
+type commandBlock struct {
+	name       string
+	commands   []model.PluginCommandConf
+	isFunction bool
+}
+
+// projectCommandBlocks returns all the blocks of commands in the project.
+func projectCommandBlocks(p *model.Project) []commandBlock {
+	var blocks []commandBlock
+	addBlock := func(name string, cmds *model.YAMLCommandSet, isFunction bool) {
+		if cmds == nil {
+			return
+		}
+		blocks = append(blocks, commandBlock{name: name, commands: cmds.List(), isFunction: isFunction})
+	}
+
+	funcNames := make([]string, 0, len(p.Functions))
+	for name := range p.Functions {
+		funcNames = append(funcNames, name)
+	}
+	sort.Strings(funcNames)
+	for _, name := range funcNames {
+		addBlock(fmt.Sprintf("function '%s'", name), p.Functions[name], true)
+	}
+
+	addBlock("pre", p.Pre, false)
+	addBlock("post", p.Post, false)
+	addBlock("timeout", p.Timeout, false)
+	addBlock("early_termination", p.EarlyTermination, false)
+
+	for _, t := range p.Tasks {
+		blocks = append(blocks, commandBlock{name: fmt.Sprintf("task '%s'", t.Name), commands: t.Commands})
+	}
+
+	taskGroups := p.TaskGroups
+	for _, bv := range p.BuildVariants {
+		for _, t := range bv.Tasks {
+			if t.TaskGroup != nil {
+				taskGroups = append(taskGroups, *t.TaskGroup)
+			}
+		}
+	}
+	for _, tg := range taskGroups {
+		addBlock(fmt.Sprintf("setup_group of task group '%s'", tg.Name), tg.SetupGroup, false)
+		addBlock(fmt.Sprintf("setup_task of task group '%s'", tg.Name), tg.SetupTask, false)
+		addBlock(fmt.Sprintf("teardown_task of task group '%s'", tg.Name), tg.TeardownTask, false)
+		addBlock(fmt.Sprintf("teardown_group of task group '%s'", tg.Name), tg.TeardownGroup, false)
+		addBlock(fmt.Sprintf("timeout of task group '%s'", tg.Name), tg.Timeout, false)
+	}
+
+	return blocks
+}
+
+// checkUnusedFunctions checks that every function is called by at least one
+// task or block of commands.
+func checkUnusedFunctions(project *model.Project) ValidationErrors {
+	called := map[string]bool{}
+	for _, block := range projectCommandBlocks(project) {
+		if block.isFunction {
+			continue
+		}
+		for _, cmd := range block.commands {
+			if cmd.Function != "" {
+				called[cmd.Function] = true
+			}
+		}
+	}
+
+	funcNames := make([]string, 0, len(project.Functions))
+	for name := range project.Functions {
+		funcNames = append(funcNames, name)
+	}
+	sort.Strings(funcNames)
+
+	errs := ValidationErrors{}
+	for _, name := range funcNames {
+		if !called[name] {
+			errs = append(errs, ValidationError{
+				Level:   Warning,
+				Message: fmt.Sprintf("function '%s' is not called by any task or block of commands", name),
+			})
+		}
+	}
+	return errs
+}
+
+// checkTasksNotInVariants checks that every task is listed in at least one
+// build variant, either directly or through a task group.
+func checkTasksNotInVariants(project *model.Project) ValidationErrors {
+	inVariant := map[string]bool{}
+	for _, bvtu := range project.FindAllBuildVariantTasks() {
+		inVariant[bvtu.Name] = true
+	}
+
+	errs := ValidationErrors{}
+	for _, t := range project.Tasks {
+		if !inVariant[t.Name] {
+			errs = append(errs, ValidationError{
+				Level:   Warning,
+				Message: fmt.Sprintf("task '%s' is not listed in any build variant, so it will never run unless it is generated", t.Name),
+			})
+		}
+	}
+	return errs
+}
+
+// minDuplicateCommandBlockSize is the minimum number of commands in a block
+// for it to be reported as a duplicate, since blocks that call a single
+// function are expected to be repeated.
+const minDuplicateCommandBlockSize = 2
+
+// checkDuplicateCommandBlocks checks that blocks of several commands are not
+// repeated verbatim in multiple places.
+func checkDuplicateCommandBlocks(project *model.Project) ValidationErrors {
+	var keys []string
+	blockNamesByKey := map[string][]string{}
+	for _, block := range projectCommandBlocks(project) {
+		if len(block.commands) < minDuplicateCommandBlockSize {
+			continue
+		}
+		out, err := yaml.Marshal(block.commands)
+		if err != nil {
+			continue
+		}
+		key := string(out)
+		if _, ok := blockNamesByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		blockNamesByKey[key] = append(blockNamesByKey[key], block.name)
+	}
+
+	errs := ValidationErrors{}
+	for _, key := range keys {
+		names := blockNamesByKey[key]
+		if len(names) < 2 {
+			continue
+		}
+		errs = append(errs, ValidationError{
+			Level:   Warning,
+			Message: fmt.Sprintf("%s have the same commands; consider moving them into a function", strings.Join(names, ", ")),
+		})
+	}
+	return errs
+}
+
+// builtInExpansions are the expansions that Evergreen defines for every task.
+var builtInExpansions = []string{
+	"alias",
+	"author",
+	"author_email",
+	"branch_name",
+	"build_id",
+	"build_variant",
+	"commit_message",
+	"created_at",
+	"distro_id",
+	"execution",
+	"github_author",
+	"github_commit",
+	"github_org",
+	"github_pr_number",
+	"github_repo",
+	"is_commit_queue",
+	"is_github_merge_queue",
+	"is_patch",
+	"is_stepback",
+	"project",
+	"project_id",
+	"project_identifier",
+	"requester",
+	"revision",
+	"revision_order_id",
+	"task_id",
+	"task_name",
+	"trigger_branch",
+	"trigger_event_identifier",
+	"trigger_event_type",
+	"trigger_id",
+	"trigger_repo_name",
+	"trigger_repo_owner",
+	"trigger_revision",
+	"trigger_status",
+	"triggered_by_git_tag",
+	"version_id",
+	"workdir",
+	evergreen.GlobalGitHubTokenExpansion,
+	command.AWSAccessKeyId,
+	command.AWSSecretAccessKey,
+	command.AWSSessionToken,
+	command.AWSRoleExpiration,
+}
+
+// checkUndefinedExpansions checks that the expansions that commands reference
+// are defined by the project, its variables, its distros or Evergreen.
+func checkUndefinedExpansions(_ *evergreen.Settings, p *model.Project, ref *model.ProjectRef, _ bool) ValidationErrors {
+	defined := map[string]bool{}
+	vars, err := model.FindMergedProjectVars(ref.Id)
+	if err != nil {
+		return ValidationErrors{{
+			Level:   Warning,
+			Message: "can't get project variables from database; validation will proceed without checking for undefined expansions",
+		}}
+	}
+	if vars != nil {
+		for name := range vars.Vars {
+			defined[name] = true
+		}
+	}
+	distros, err := distro.Find(distro.All)
+	if err != nil {
+		return ValidationErrors{{
+			Level:   Warning,
+			Message: "can't get distros from database; validation will proceed without checking for undefined expansions",
+		}}
+	}
+	for _, d := range distros {
+		for _, e := range d.Expansions {
+			defined[e.Key] = true
+		}
+	}
+
+	return findUndefinedExpansions(p, defined)
+}
+
+// findUndefinedExpansions returns the expansions that commands reference
+// without a default value but that are neither in the given defined
+// expansions nor defined by the project or Evergreen. Since expansions loaded
+// from a file can't be known ahead of time, it doesn't check projects that
+// load expansions from files.
+func findUndefinedExpansions(p *model.Project, defined map[string]bool) ValidationErrors {
+	isDefined := map[string]bool{}
+	for name := range defined {
+		isDefined[name] = true
+	}
+	for _, name := range builtInExpansions {
+		isDefined[name] = true
+	}
+	for _, param := range p.Parameters {
+		isDefined[param.Parameter.Key] = true
+	}
+	for _, module := range p.Modules {
+		for _, suffix := range []string{"rev", "branch", "repo", "owner"} {
+			isDefined[fmt.Sprintf("%s_%s", module.Name, suffix)] = true
+		}
+	}
+	for _, bv := range p.BuildVariants {
+		for name := range bv.Expansions {
+			isDefined[name] = true
+		}
+	}
+
+	// referencedBy maps each referenced expansion to the first block that
+	// references it.
+	referencedBy := map[string]string{}
+	var referenced []string
+	addReferences := func(blockName string, value interface{}) {
+		for _, name := range findExpansionReferences(value) {
+			if _, ok := referencedBy[name]; !ok {
+				referencedBy[name] = blockName
+				referenced = append(referenced, name)
+			}
+		}
+	}
+	for _, bv := range p.BuildVariants {
+		for _, value := range bv.Expansions {
+			addReferences(fmt.Sprintf("build variant '%s'", bv.Name), value)
+		}
+	}
+	for _, block := range projectCommandBlocks(p) {
+		for _, cmd := range block.commands {
+			for name, value := range cmd.Vars {
+				isDefined[name] = true
+				addReferences(block.name, value)
+			}
+			addReferences(block.name, cmd.Params)
+
+			switch cmd.Command {
+			case "expansions.update":
+				if _, ok := cmd.Params["file"]; ok {
+					return nil
+				}
+				updates, _ := cmd.Params["updates"].([]interface{})
+				for _, update := range updates {
+					if key, ok := paramString(update, "key"); ok {
+						isDefined[key] = true
+					}
+				}
+			case "keyval.inc":
+				if dest, ok := paramString(cmd.Params, "destination"); ok {
+					isDefined[dest] = true
+				}
+			}
+		}
+	}
+
+	sort.Strings(referenced)
+	errs := ValidationErrors{}
+	for _, name := range referenced {
+		if isDefined[name] {
+			continue
+		}
+		errs = append(errs, ValidationError{
+			Level:   Warning,
+			Message: fmt.Sprintf("expansion '%s' is referenced in %s without a default value but is not defined", name, referencedBy[name]),
+		})
+	}
+	return errs
+}
+
+// findExpansionReferences returns the names of the expansions referenced
+// without a default value in the strings in the value.
+func findExpansionReferences(value interface{}) []string {
+	var names []string
+	switch v := value.(type) {
+	case string:
+		for _, match := range expansionReferenceRegex.FindAllStringSubmatch(v, -1) {
+			names = append(names, match[1])
+		}
+	case []interface{}:
+		for _, elem := range v {
+			names = append(names, findExpansionReferences(elem)...)
+		}
+	case map[string]interface{}:
+		for _, elem := range v {
+			names = append(names, findExpansionReferences(elem)...)
+		}
+	case map[interface{}]interface{}:
+		for _, elem := range v {
+			names = append(names, findExpansionReferences(elem)...)
+		}
+	}
+	return names
+}
+
+// expansionReferenceRegex matches expansions without a default value, such as
+// "${name}" but not "${name|default}".
+var expansionReferenceRegex = regexp.MustCompile(`\$\{([^|{}]+)\}`)
+
+// paramString returns the string value of the key in a command parameter map.
+func paramString(params interface{}, key string) (string, bool) {
+	var value interface{}
+	switch m := params.(type) {
+	case map[string]interface{}:
+		value = m[key]
+	case map[interface{}]interface{}:
+		value = m[key]
+	}
+	s, ok := value.(string)
+	return s, ok && s != ""
+}
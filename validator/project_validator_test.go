@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/evergreen/model"
@@ -2872,7 +2873,8 @@ buildvariants:
 	assert.Equal("execution task 'display_three' has prefix 'display_' which is invalid",
 		errors[0].Message)
 	warnings := CheckProjectWarnings(&proj)
-	assert.Len(warnings, 0)
+	require.Len(warnings, 1)
+	assert.Equal(ruleTaskNotInVariant, warnings[0].Rule)
 }
 
 func TestValidateCreateHosts(t *testing.T) {
@@ -2921,6 +2923,78 @@ func TestValidateCreateHosts(t *testing.T) {
 	assert.Len(errs, 1)
 }
 
+func TestValidateHostPools(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ValidPool", func(t *testing.T) {
+		yml := `
+  host_pools:
+  - name: cluster
+    size: 6
+    reset_script: rm -rf /data/db
+    host:
+      distro: ubuntu2204-large
+      timeout_teardown_secs: 86400
+  tasks:
+  - name: t_1
+    commands:
+    - command: host.create
+      params:
+        pool: cluster
+        num_hosts: 3
+  buildvariants:
+  - name: "bv"
+    display_name: "bv_display"
+    tasks:
+    - name: t_1
+  `
+		var p model.Project
+		pp, err := model.LoadProjectInto(ctx, []byte(yml), nil, "id", &p)
+		require.NoError(t, err)
+		require.NotNil(t, pp)
+		require.Len(t, p.HostPools, 1)
+		assert.Equal(t, 6, p.HostPools[0].Size)
+		assert.Equal(t, "ubuntu2204-large", p.HostPools[0].Host.Distro)
+		assert.Equal(t, 86400, p.HostPools[0].Host.TeardownTimeoutSecs)
+		assert.Empty(t, validateHostPools(&p))
+	})
+	t.Run("InvalidPools", func(t *testing.T) {
+		p := model.Project{
+			HostPools: []model.HostPool{
+				{Name: "cluster", Size: 3, Host: apimodels.CreateHost{Distro: "d"}},
+				{Name: "cluster", Size: 3, Host: apimodels.CreateHost{Distro: "d"}},
+				{Name: "empty", Host: apimodels.CreateHost{Distro: "d"}},
+				{Name: "scoped", Size: 1, Host: apimodels.CreateHost{Distro: "d", Scope: "task"}},
+				{Name: "ami", Size: 1, ResetScript: "reset", Host: apimodels.CreateHost{AMI: "ami"}},
+			},
+		}
+		assert.Len(t, validateHostPools(&p), 4)
+	})
+	t.Run("UndefinedPool", func(t *testing.T) {
+		p := model.Project{
+			HostPools: []model.HostPool{
+				{Name: "cluster", Size: 3, Host: apimodels.CreateHost{Distro: "d"}},
+			},
+			Functions: map[string]*model.YAMLCommandSet{
+				"lease": {SingleCommand: &model.PluginCommandConf{
+					Command: evergreen.HostCreateCommandName,
+					Params:  map[string]interface{}{"pool": "nonexistent"},
+				}},
+			},
+			Tasks: []model.ProjectTask{
+				{Name: "t_1", Commands: []model.PluginCommandConf{
+					{Command: evergreen.HostCreateCommandName, Params: map[string]interface{}{"pool": "cluster"}},
+					{Command: evergreen.HostCreateCommandName, Params: map[string]interface{}{"pool": "${pool_name}"}},
+					{Command: evergreen.HostCreateCommandName, Params: map[string]interface{}{"pool": "other"}},
+				}},
+			},
+		}
+		errs := validateHostPools(&p)
+		require.Len(t, errs, 2)
+		assert.Contains(t, errs[0].Message, "function 'lease'")
+		assert.Contains(t, errs[1].Message, "task 't_1'")
+	})
+}
+
 func TestValidateParameters(t *testing.T) {
 	p := &model.Project{
 		Parameters: []model.ParameterInfo{
@@ -5456,3 +5530,162 @@ func TestValidationErrorsAtLevel(t *testing.T) {
 		assert.Empty(t, errs.AtLevel(Error))
 	})
 }
+
+func TestCheckUnusedFunctions(t *testing.T) {
+	yml := `
+functions:
+  used_by_task:
+    command: shell.exec
+  used_by_pre:
+    command: shell.exec
+  unused:
+    command: shell.exec
+pre:
+  - func: used_by_pre
+tasks:
+  - name: t1
+    commands:
+      - func: used_by_task
+`
+	project := &model.Project{}
+	_, err := model.LoadProjectInto(context.Background(), []byte(yml), nil, "", project)
+	require.NoError(t, err)
+
+	errs := checkUnusedFunctions(project)
+	require.Len(t, errs, 1)
+	assert.Equal(t, Warning, errs[0].Level)
+	assert.Contains(t, errs[0].Message, "'unused'")
+}
+
+func TestCheckTasksNotInVariants(t *testing.T) {
+	yml := `
+tasks:
+  - name: in_variant
+  - name: in_group
+  - name: not_in_variant
+task_groups:
+  - name: tg
+    max_hosts: 1
+    tasks:
+      - in_group
+buildvariants:
+  - name: bv
+    tasks:
+      - name: in_variant
+      - name: tg
+`
+	project := &model.Project{}
+	_, err := model.LoadProjectInto(context.Background(), []byte(yml), nil, "", project)
+	require.NoError(t, err)
+
+	errs := checkTasksNotInVariants(project)
+	require.Len(t, errs, 1)
+	assert.Equal(t, Warning, errs[0].Level)
+	assert.Contains(t, errs[0].Message, "'not_in_variant'")
+}
+
+func TestCheckDuplicateCommandBlocks(t *testing.T) {
+	yml := `
+functions:
+  setup:
+    - command: git.get_project
+      params:
+        directory: src
+    - command: shell.exec
+      params:
+        script: make
+tasks:
+  - name: t1
+    commands:
+      - command: git.get_project
+        params:
+          directory: src
+      - command: shell.exec
+        params:
+          script: make
+  - name: t2
+    commands:
+      - command: git.get_project
+        params:
+          directory: src
+      - command: shell.exec
+        params:
+          script: make test
+  - name: t3
+    commands:
+      - func: setup
+  - name: t4
+    commands:
+      - func: setup
+`
+	project := &model.Project{}
+	_, err := model.LoadProjectInto(context.Background(), []byte(yml), nil, "", project)
+	require.NoError(t, err)
+
+	errs := checkDuplicateCommandBlocks(project)
+	require.Len(t, errs, 1, "single commands should not be reported as duplicates")
+	assert.Equal(t, Warning, errs[0].Level)
+	assert.Contains(t, errs[0].Message, "function 'setup', task 't1'")
+	assert.NotContains(t, errs[0].Message, "'t2'")
+}
+
+func TestFindUndefinedExpansions(t *testing.T) {
+	yml := `
+parameters:
+  - key: param
+modules:
+  - name: mod
+    repo: git@github.com:evergreen-ci/evergreen.git
+functions:
+  f:
+    command: shell.exec
+    params:
+      script: echo ${from_func_vars} ${from_project_vars} ${mod_rev} ${param} ${task_id} ${missing_in_func}
+tasks:
+  - name: t1
+    commands:
+      - command: expansions.update
+        params:
+          updates:
+            - key: from_update
+              value: ${from_bv}
+      - command: keyval.inc
+        params:
+          key: counter
+          destination: from_keyval
+      - func: f
+        vars:
+          from_func_vars: value
+      - command: shell.exec
+        params:
+          script: echo ${from_update} ${from_keyval} ${with_default|default} ${missing} ${missing}
+buildvariants:
+  - name: bv
+    expansions:
+      from_bv: value
+      bv_reference: ${missing_in_bv}
+    tasks:
+      - name: t1
+`
+	project := &model.Project{}
+	_, err := model.LoadProjectInto(context.Background(), []byte(yml), nil, "", project)
+	require.NoError(t, err)
+
+	t.Run("FindsUndefinedExpansions", func(t *testing.T) {
+		errs := findUndefinedExpansions(project, map[string]bool{"from_project_vars": true})
+		require.Len(t, errs, 3)
+		for _, err := range errs {
+			assert.Equal(t, Warning, err.Level)
+		}
+		assert.Contains(t, errs[0].Message, "'missing' is referenced in task 't1'")
+		assert.Contains(t, errs[1].Message, "'missing_in_bv' is referenced in build variant 'bv'")
+		assert.Contains(t, errs[2].Message, "'missing_in_func' is referenced in function 'f'")
+	})
+	t.Run("SkipsProjectsThatLoadExpansionsFromFiles", func(t *testing.T) {
+		project.Tasks[0].Commands = append(project.Tasks[0].Commands, model.PluginCommandConf{
+			Command: "expansions.update",
+			Params:  map[string]interface{}{"file": "expansions.yml"},
+		})
+		assert.Empty(t, findUndefinedExpansions(project, nil))
+	})
+}
@@ -0,0 +1,325 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/pkg/errors"
+)
+
+// RuleSeverity is the severity of a validation rule, which determines the
+// level of the validation errors it finds.
+type RuleSeverity string
+
+const (
+	// RuleSeverityError reports the rule's findings as errors.
+	RuleSeverityError RuleSeverity = "error"
+	// RuleSeverityWarning reports the rule's findings as warnings.
+	RuleSeverityWarning RuleSeverity = "warning"
+	// RuleSeverityIgnore suppresses the rule's findings.
+	RuleSeverityIgnore RuleSeverity = "ignore"
+)
+
+// Validate checks that the severity is one of the recognized severities.
+func (s RuleSeverity) Validate() error {
+	switch s {
+	case RuleSeverityError, RuleSeverityWarning, RuleSeverityIgnore:
+		return nil
+	default:
+		return errors.Errorf("invalid rule severity '%s'", s)
+	}
+}
+
+// ValidationRule describes a single check that the validator runs against a
+// project.
+type ValidationRule struct {
+	// ID is the stable identifier that projects use to refer to the rule.
+	ID string `json:"id"`
+	// Description describes what the rule checks.
+	Description string `json:"description"`
+	// DefaultSeverity is the severity of the rule's findings unless the
+	// project overrides it. Rules that find errors by default find problems
+	// that prevent the project from running, so projects can't lower or
+	// ignore them.
+	DefaultSeverity RuleSeverity `json:"default_severity"`
+}
+
+// canSetSeverity returns whether a project can set the rule's severity to the
+// given severity.
+func (r ValidationRule) canSetSeverity(severity RuleSeverity) bool {
+	return r.DefaultSeverity != RuleSeverityError || severity == RuleSeverityError
+}
+
+const (
+	ruleAliasCoverage              = "alias-coverage"
+	ruleAllDependenciesSpec        = "all-dependencies-spec"
+	ruleBatchTimeActivation        = "batchtime-activation"
+	ruleBatchTimeLimit             = "batchtime-limit"
+	ruleBuildVariantBatchTimes     = "build-variant-batchtimes"
+	ruleBuildVariantDefinitions    = "build-variant-definitions"
+	ruleBuildVariantFields         = "build-variant-fields"
+	ruleBuildVariantNames          = "build-variant-names"
+	ruleBuildVariantTaskNames      = "build-variant-task-names"
+	ruleContainerDistroOverlap     = "container-distro-overlap"
+	ruleContainerSettings          = "container-settings"
+	ruleDependencyCycle            = "dependency-cycle"
+	ruleDeprecatedEarlyTermination = "deprecated-early-termination"
+	ruleDisplayTaskNames           = "display-task-names"
+	ruleDuplicateBuildVariantTasks = "duplicate-build-variant-tasks"
+	ruleDuplicateCommandBlocks     = "duplicate-command-blocks"
+	ruleDuplicateContainers        = "duplicate-containers"
+	ruleDuplicateTaskNames         = "duplicate-task-names"
+	ruleGenerateTasksLimit         = "generate-tasks-limit"
+	ruleHostCreateLimits           = "host-create-limits"
+	ruleHostPools                  = "host-pools"
+	ruleLintRules                  = "lint-rules"
+	ruleLoggerConfig               = "logger-config"
+	ruleModuleFields               = "module-fields"
+	ruleMultipleContainers         = "multiple-containers"
+	ruleMultipleS3Push             = "multiple-s3-push"
+	ruleNonexistentDistro          = "nonexistent-distro"
+	ruleParameters                 = "parameters"
+	rulePluginCommands             = "plugin-commands"
+	ruleProjectConfigAliases       = "project-config-aliases"
+	ruleProjectConfigContainers    = "project-config-containers"
+	ruleProjectConfigPlugins       = "project-config-plugins"
+	ruleProjectFields              = "project-fields"
+	ruleReferentialIntegrity       = "referential-integrity"
+	ruleTaskDefinitions            = "task-definitions"
+	ruleTaskDependencies           = "task-dependencies"
+	ruleTaskDependencyRequesters   = "task-dependency-requesters"
+	ruleTaskGroupDefinitions       = "task-group-definitions"
+	ruleTaskGroupSettings          = "task-group-settings"
+	ruleTaskIDsAndTags             = "task-ids-and-tags"
+	ruleTaskInVariantAndGroup      = "task-in-variant-and-group"
+	ruleTaskNameCharacters         = "task-name-characters"
+	ruleTaskNamesAmbiguous         = "task-names-ambiguous"
+	ruleTaskNeverRuns              = "task-never-runs"
+	ruleTaskNotInVariant           = "task-not-in-variant"
+	ruleTaskSyncCommands           = "task-sync-commands"
+	ruleTaskSyncSettings           = "task-sync-settings"
+	ruleUndefinedExpansion         = "undefined-expansion"
+	ruleUnusedFunction             = "unused-function"
+	ruleVariantNamesAmbiguous      = "variant-names-ambiguous"
+	ruleVersionControl             = "version-control"
+)
+
+// validationRules are all the rules that the validator checks, sorted by ID.
+var validationRules = []ValidationRule{
+	{ID: ruleAliasCoverage, DefaultSeverity: RuleSeverityWarning,
+		Description: "Patch aliases must match at least one variant and task."},
+	{ID: ruleAllDependenciesSpec, DefaultSeverity: RuleSeverityError,
+		Description: "A task that depends on all tasks ('*') in a variant must not list other dependencies in that variant."},
+	{ID: ruleBatchTimeActivation, DefaultSeverity: RuleSeverityWarning,
+		Description: "Variants and tasks that set a batchtime should not also set activate, since it is ignored."},
+	{ID: ruleBatchTimeLimit, DefaultSeverity: RuleSeverityWarning,
+		Description: "The project batchtime should not exceed the maximum batchtime."},
+	{ID: ruleBuildVariantBatchTimes, DefaultSeverity: RuleSeverityError,
+		Description: "Variants and tasks must not set both batchtime and cron, and cron batchtimes must be valid."},
+	{ID: ruleBuildVariantDefinitions, DefaultSeverity: RuleSeverityWarning,
+		Description: "Variants should have tasks and unique display names."},
+	{ID: ruleBuildVariantFields, DefaultSeverity: RuleSeverityError,
+		Description: "The project must have at least one variant, and every variant must have a name, tasks and a distro to run on."},
+	{ID: ruleBuildVariantNames, DefaultSeverity: RuleSeverityError,
+		Description: "Variant names must be unique and valid, and variants must have display names."},
+	{ID: ruleBuildVariantTaskNames, DefaultSeverity: RuleSeverityError,
+		Description: "A variant must not list the same task more than once."},
+	{ID: ruleContainerDistroOverlap, DefaultSeverity: RuleSeverityWarning,
+		Description: "Containers should not have the same name as a distro, since the container overrides the distro."},
+	{ID: ruleContainerSettings, DefaultSeverity: RuleSeverityError,
+		Description: "Containers must be valid for the project settings."},
+	{ID: ruleDependencyCycle, DefaultSeverity: RuleSeverityError,
+		Description: "Task dependencies must not form a cycle."},
+	{ID: ruleDeprecatedEarlyTermination, DefaultSeverity: RuleSeverityWarning,
+		Description: "The early_termination block is deprecated."},
+	{ID: ruleDisplayTaskNames, DefaultSeverity: RuleSeverityError,
+		Description: "Execution tasks must not start with the reserved 'display_' prefix."},
+	{ID: ruleDuplicateBuildVariantTasks, DefaultSeverity: RuleSeverityError,
+		Description: "A task must not run more than once in a variant, such as through a task group."},
+	{ID: ruleDuplicateCommandBlocks, DefaultSeverity: RuleSeverityWarning,
+		Description: "Blocks of several commands should not be repeated in multiple places; move them into a function instead."},
+	{ID: ruleDuplicateContainers, DefaultSeverity: RuleSeverityError,
+		Description: "Container names must be unique."},
+	{ID: ruleDuplicateTaskNames, DefaultSeverity: RuleSeverityError,
+		Description: "Task names must be unique."},
+	{ID: ruleGenerateTasksLimit, DefaultSeverity: RuleSeverityError,
+		Description: "A task must not call generate.tasks more than once."},
+	{ID: ruleHostCreateLimits, DefaultSeverity: RuleSeverityError,
+		Description: "Tasks and the project must not call host.create more times than allowed."},
+	{ID: ruleHostPools, DefaultSeverity: RuleSeverityError,
+		Description: "Host pools must have unique names, a positive size and valid host settings, and host.create must only lease hosts from defined pools."},
+	{ID: ruleLintRules, DefaultSeverity: RuleSeverityError,
+		Description: "The project's lint rules must refer to existing rules with valid severities."},
+	{ID: ruleLoggerConfig, DefaultSeverity: RuleSeverityWarning,
+		Description: "Logger configurations should be valid."},
+	{ID: ruleModuleFields, DefaultSeverity: RuleSeverityWarning,
+		Description: "Modules should have a unique name, a branch and a valid repo."},
+	{ID: ruleMultipleContainers, DefaultSeverity: RuleSeverityWarning,
+		Description: "run_on should list at most one container, since only the first is used."},
+	{ID: ruleMultipleS3Push, DefaultSeverity: RuleSeverityWarning,
+		Description: "A task should not call s3.push more than once."},
+	{ID: ruleNonexistentDistro, DefaultSeverity: RuleSeverityWarning,
+		Description: "run_on should refer to existing distros or containers."},
+	{ID: ruleParameters, DefaultSeverity: RuleSeverityError,
+		Description: "Parameters must have unique, valid names."},
+	{ID: rulePluginCommands, DefaultSeverity: RuleSeverityError,
+		Description: "Commands and functions must be valid."},
+	{ID: ruleProjectConfigAliases, DefaultSeverity: RuleSeverityError,
+		Description: "Aliases in the project config must be valid."},
+	{ID: ruleProjectConfigContainers, DefaultSeverity: RuleSeverityError,
+		Description: "Container sizes in the project config must be valid."},
+	{ID: ruleProjectConfigPlugins, DefaultSeverity: RuleSeverityError,
+		Description: "Build baron and task annotation settings in the project config must be valid."},
+	{ID: ruleProjectFields, DefaultSeverity: RuleSeverityError,
+		Description: "Project-level fields such as batchtime and command_type must be valid."},
+	{ID: ruleReferentialIntegrity, DefaultSeverity: RuleSeverityError,
+		Description: "Variants must refer to existing tasks, and run_on must not mix distros and containers."},
+	{ID: ruleTaskDefinitions, DefaultSeverity: RuleSeverityWarning,
+		Description: "Tasks should have commands and an exec timeout."},
+	{ID: ruleTaskDependencies, DefaultSeverity: RuleSeverityError,
+		Description: "Task dependencies must refer to existing tasks and variants, with valid statuses and no duplicates."},
+	{ID: ruleTaskDependencyRequesters, DefaultSeverity: RuleSeverityWarning,
+		Description: "Tasks should not depend on tasks that run for fewer kinds of versions, such as patch-only tasks."},
+	{ID: ruleTaskGroupDefinitions, DefaultSeverity: RuleSeverityError,
+		Description: "Task groups must have unique tasks, must not share a name with a task, and must not attach results in teardown_group."},
+	{ID: ruleTaskGroupSettings, DefaultSeverity: RuleSeverityWarning,
+		Description: "Task groups should be defined once and have a valid number of max hosts."},
+	{ID: ruleTaskIDsAndTags, DefaultSeverity: RuleSeverityError,
+		Description: "Task names and tags must not start with invalid characters, and tags must not contain white space."},
+	{ID: ruleTaskInVariantAndGroup, DefaultSeverity: RuleSeverityWarning,
+		Description: "Variants should not list a task that is already in a task group."},
+	{ID: ruleTaskNameCharacters, DefaultSeverity: RuleSeverityError,
+		Description: "Task names must not contain unauthorized characters."},
+	{ID: ruleTaskNamesAmbiguous, DefaultSeverity: RuleSeverityWarning,
+		Description: "Task names should not be ambiguous with task selectors, such as '*', 'all' or names with commas."},
+	{ID: ruleTaskNeverRuns, DefaultSeverity: RuleSeverityWarning,
+		Description: "Tasks should not have settings that prevent them from ever running."},
+	{ID: ruleTaskNotInVariant, DefaultSeverity: RuleSeverityWarning,
+		Description: "Tasks should be listed in at least one variant, either directly or through a task group."},
+	{ID: ruleTaskSyncCommands, DefaultSeverity: RuleSeverityError,
+		Description: "Tasks that call s3.pull must depend on a task that calls s3.push."},
+	{ID: ruleTaskSyncSettings, DefaultSeverity: RuleSeverityError,
+		Description: "Task sync commands must be enabled in the project settings."},
+	{ID: ruleUndefinedExpansion, DefaultSeverity: RuleSeverityWarning,
+		Description: "Expansions that commands reference without a default value should be defined by the project, its variables, its distros or Evergreen."},
+	{ID: ruleUnusedFunction, DefaultSeverity: RuleSeverityWarning,
+		Description: "Functions should be called by at least one task or block."},
+	{ID: ruleVariantNamesAmbiguous, DefaultSeverity: RuleSeverityWarning,
+		Description: "Variant names should not be ambiguous with variant selectors, such as '*', 'all' or names with commas."},
+	{ID: ruleVersionControl, DefaultSeverity: RuleSeverityWarning,
+		Description: "Project config fields should only be set when version control is enabled."},
+}
+
+// Rules returns all the rules that the validator checks, sorted by ID.
+func Rules() []ValidationRule {
+	rules := make([]ValidationRule, len(validationRules))
+	copy(rules, validationRules)
+	return rules
+}
+
+// FindRule returns the rule with the given ID, or nil if there is none.
+func FindRule(id string) *ValidationRule {
+	i := sort.Search(len(validationRules), func(i int) bool {
+		return validationRules[i].ID >= id
+	})
+	if i < len(validationRules) && validationRules[i].ID == id {
+		rule := validationRules[i]
+		return &rule
+	}
+	return nil
+}
+
+// withRule attributes the validation errors that aren't attributed to a more
+// specific rule to the given rule.
+func (v ValidationErrors) withRule(id string) ValidationErrors {
+	for i := range v {
+		if v[i].Rule == "" {
+			v[i].Rule = id
+		}
+	}
+	return v
+}
+
+// ApplyLintRules applies the rule severities in the project config to the
+// validation errors. The level of each validation error is set to the
+// severity of its rule, and validation errors for rules that the project
+// ignores are removed. Severities that the project can't set for a rule are
+// ignored.
+func ApplyLintRules(errs ValidationErrors, pc *model.ProjectConfig) ValidationErrors {
+	if pc == nil || len(pc.LintRules) == 0 {
+		return errs
+	}
+
+	applied := ValidationErrors{}
+	for _, err := range errs {
+		severity, ok := pc.LintRules[err.Rule]
+		rule := FindRule(err.Rule)
+		if !ok || rule == nil || !rule.canSetSeverity(RuleSeverity(severity)) {
+			applied = append(applied, err)
+			continue
+		}
+		switch RuleSeverity(severity) {
+		case RuleSeverityIgnore:
+			continue
+		case RuleSeverityError:
+			err.Level = Error
+		case RuleSeverityWarning:
+			err.Level = Warning
+		}
+		applied = append(applied, err)
+	}
+	return applied
+}
+
+// ApplyPatchedLintRules is like ApplyLintRules for a patched project config
+// that hasn't been parsed yet. If the patched project config can't be parsed,
+// the validation errors are returned unchanged, since
+// CheckPatchedProjectConfigErrors already reports that as an error.
+func ApplyPatchedLintRules(errs ValidationErrors, patchedProjectConfig string) ValidationErrors {
+	if len(patchedProjectConfig) == 0 {
+		return errs
+	}
+	pc, err := model.CreateProjectConfig([]byte(patchedProjectConfig), "")
+	if err != nil {
+		return errs
+	}
+	return ApplyLintRules(errs, pc)
+}
+
+// validateProjectConfigLintRules checks that the project config only sets
+// valid severities for existing rules.
+func validateProjectConfigLintRules(pc *model.ProjectConfig) ValidationErrors {
+	errs := ValidationErrors{}
+	ids := make([]string, 0, len(pc.LintRules))
+	for id := range pc.LintRules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		severity := RuleSeverity(pc.LintRules[id])
+		rule := FindRule(id)
+		if rule == nil {
+			errs = append(errs, ValidationError{
+				Level:   Warning,
+				Message: fmt.Sprintf("lint rule '%s' does not exist", id),
+			})
+			continue
+		}
+		if err := severity.Validate(); err != nil {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("lint rule '%s' has invalid severity '%s': must be one of '%s', '%s' or '%s'", id, severity, RuleSeverityError, RuleSeverityWarning, RuleSeverityIgnore),
+			})
+			continue
+		}
+		if !rule.canSetSeverity(severity) {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("lint rule '%s' finds errors that prevent the project from running, so its severity cannot be lowered to '%s'", id, severity),
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	rules := Rules()
+	require.NotEmpty(t, rules)
+	assert.True(t, sort.SliceIsSorted(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	}), "rules must be sorted by ID")
+	for i, rule := range rules {
+		if i > 0 {
+			assert.NotEqual(t, rules[i-1].ID, rule.ID, "rule IDs must be unique")
+		}
+		assert.NotEmpty(t, rule.Description, rule.ID)
+		assert.NoError(t, rule.DefaultSeverity.Validate(), rule.ID)
+	}
+
+	rules[0].ID = "modified"
+	assert.NotEqual(t, "modified", Rules()[0].ID, "rules should be copied")
+}
+
+func TestFindRule(t *testing.T) {
+	rule := FindRule(ruleUnusedFunction)
+	require.NotNil(t, rule)
+	assert.Equal(t, ruleUnusedFunction, rule.ID)
+	assert.Equal(t, RuleSeverityWarning, rule.DefaultSeverity)
+
+	assert.Nil(t, FindRule("nonexistent"))
+}
+
+func TestApplyLintRules(t *testing.T) {
+	errs := ValidationErrors{
+		{Level: Warning, Message: "unused function", Rule: ruleUnusedFunction},
+		{Level: Warning, Message: "task not in variant", Rule: ruleTaskNotInVariant},
+		{Level: Warning, Message: "undefined expansion", Rule: ruleUndefinedExpansion},
+		{Level: Error, Message: "duplicate task names", Rule: ruleDuplicateTaskNames},
+		{Level: Warning, Message: "no rule"},
+	}
+
+	t.Run("NoLintRules", func(t *testing.T) {
+		assert.Equal(t, errs, ApplyLintRules(errs, nil))
+		assert.Equal(t, errs, ApplyLintRules(errs, &model.ProjectConfig{}))
+	})
+	t.Run("AppliesSeverities", func(t *testing.T) {
+		pc := &model.ProjectConfig{ProjectConfigFields: model.ProjectConfigFields{
+			LintRules: map[string]string{
+				ruleUnusedFunction:     string(RuleSeverityError),
+				ruleTaskNotInVariant:   string(RuleSeverityIgnore),
+				ruleUndefinedExpansion: string(RuleSeverityWarning),
+				ruleDuplicateTaskNames: string(RuleSeverityIgnore),
+			},
+		}}
+		applied := ApplyLintRules(errs, pc)
+		require.Len(t, applied, 4)
+		assert.Equal(t, Error, applied[0].Level)
+		assert.Equal(t, ruleUndefinedExpansion, applied[1].Rule)
+		assert.Equal(t, Warning, applied[1].Level)
+		assert.Equal(t, ruleDuplicateTaskNames, applied[2].Rule)
+		assert.Equal(t, Error, applied[2].Level, "error rules should not be lowered")
+		assert.Equal(t, "no rule", applied[3].Message)
+
+		assert.Equal(t, Warning, errs[0].Level, "original errors should not be modified")
+	})
+}
+
+func TestApplyPatchedLintRules(t *testing.T) {
+	errs := ValidationErrors{
+		{Level: Warning, Message: "unused function", Rule: ruleUnusedFunction},
+	}
+
+	t.Run("NoPatchedProjectConfig", func(t *testing.T) {
+		assert.Equal(t, errs, ApplyPatchedLintRules(errs, ""))
+	})
+	t.Run("InvalidPatchedProjectConfig", func(t *testing.T) {
+		assert.Equal(t, errs, ApplyPatchedLintRules(errs, "lint_rules: ["))
+	})
+	t.Run("AppliesSeverities", func(t *testing.T) {
+		applied := ApplyPatchedLintRules(errs, "lint_rules:\n  "+ruleUnusedFunction+": error\n")
+		require.Len(t, applied, 1)
+		assert.Equal(t, Error, applied[0].Level)
+	})
+}
+
+func TestValidateProjectConfigLintRules(t *testing.T) {
+	t.Run("ValidRules", func(t *testing.T) {
+		pc := &model.ProjectConfig{ProjectConfigFields: model.ProjectConfigFields{
+			LintRules: map[string]string{
+				ruleUnusedFunction:     string(RuleSeverityIgnore),
+				ruleDuplicateTaskNames: string(RuleSeverityError),
+			},
+		}}
+		assert.Empty(t, validateProjectConfigLintRules(pc))
+	})
+	t.Run("UnknownRule", func(t *testing.T) {
+		pc := &model.ProjectConfig{ProjectConfigFields: model.ProjectConfigFields{
+			LintRules: map[string]string{"nonexistent": string(RuleSeverityIgnore)},
+		}}
+		errs := validateProjectConfigLintRules(pc)
+		require.Len(t, errs, 1)
+		assert.Equal(t, Warning, errs[0].Level)
+	})
+	t.Run("InvalidSeverity", func(t *testing.T) {
+		pc := &model.ProjectConfig{ProjectConfigFields: model.ProjectConfigFields{
+			LintRules: map[string]string{ruleUnusedFunction: "fatal"},
+		}}
+		errs := validateProjectConfigLintRules(pc)
+		require.Len(t, errs, 1)
+		assert.Equal(t, Error, errs[0].Level)
+	})
+	t.Run("LoweredErrorRule", func(t *testing.T) {
+		pc := &model.ProjectConfig{ProjectConfigFields: model.ProjectConfigFields{
+			LintRules: map[string]string{ruleDuplicateTaskNames: string(RuleSeverityWarning)},
+		}}
+		errs := validateProjectConfigLintRules(pc)
+		require.Len(t, errs, 1)
+		assert.Equal(t, Error, errs[0].Level)
+	})
+}
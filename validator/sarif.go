@@ -0,0 +1,194 @@
+package validator
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	sarifVersion        = "2.1.0"
+	sarifSchema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName       = "evergreen-validate"
+	sarifInformationURI = "https://github.com/evergreen-ci/evergreen"
+)
+
+// SARIFLog is a Static Analysis Results Interchange Format (SARIF) log, which
+// code review tools can use to annotate the project configuration files with
+// validation errors. Only the subset of SARIF 2.1.0 that the validator uses
+// is included.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     SARIFMessage           `json:"shortDescription"`
+	DefaultConfiguration SARIFRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type SARIFRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           SARIFRegion           `json:"region"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// FileValidationErrors are the validation errors for a single project
+// configuration file.
+type FileValidationErrors struct {
+	// Path is the path to the file.
+	Path string
+	// Contents are the file's contents, which are used to find the line
+	// that each validation error refers to.
+	Contents []byte
+	// Errors are the file's validation errors.
+	Errors ValidationErrors
+}
+
+// MakeSARIFLog returns a SARIF log with the validation errors for the files.
+func MakeSARIFLog(files []FileValidationErrors) SARIFLog {
+	rules := make([]SARIFRule, 0, len(validationRules))
+	for _, rule := range validationRules {
+		rules = append(rules, SARIFRule{
+			ID:                   rule.ID,
+			ShortDescription:     SARIFMessage{Text: rule.Description},
+			DefaultConfiguration: SARIFRuleConfiguration{Level: sarifLevelForSeverity(rule.DefaultSeverity)},
+		})
+	}
+
+	results := []SARIFResult{}
+	for _, file := range files {
+		lines := strings.Split(string(file.Contents), "\n")
+		for _, err := range file.Errors {
+			results = append(results, SARIFResult{
+				RuleID:  err.Rule,
+				Level:   sarifLevelForValidationLevel(err.Level),
+				Message: SARIFMessage{Text: err.Message},
+				Locations: []SARIFLocation{{
+					PhysicalLocation: SARIFPhysicalLocation{
+						ArtifactLocation: SARIFArtifactLocation{URI: filepath.ToSlash(file.Path)},
+						Region:           SARIFRegion{StartLine: findValidationErrorLine(lines, err.Message)},
+					},
+				}},
+			})
+		}
+	}
+
+	return SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifInformationURI,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifLevelForSeverity(severity RuleSeverity) string {
+	switch severity {
+	case RuleSeverityError:
+		return "error"
+	case RuleSeverityWarning:
+		return "warning"
+	default:
+		return "none"
+	}
+}
+
+func sarifLevelForValidationLevel(level ValidationErrorLevel) string {
+	if level == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+// quotedNameRegex matches the single-quoted names, such as task and variant
+// names, in validation error messages.
+var quotedNameRegex = regexp.MustCompile(`'([^'\s]+)'`)
+
+// findValidationErrorLine returns the 1-indexed line that a validation error
+// most likely refers to, which is the first line that defines or refers to
+// the first name quoted in the message that appears in the file. It returns
+// the first line if the message does not quote any name in the file.
+func findValidationErrorLine(lines []string, message string) int {
+	for _, match := range quotedNameRegex.FindAllStringSubmatch(message, -1) {
+		name := match[1]
+		for i, line := range lines {
+			if lineRefersToName(line, name) {
+				return i + 1
+			}
+		}
+	}
+	return 1
+}
+
+// lineRefersToName returns whether the YAML line is a key, value or list
+// element that is exactly the name.
+func lineRefersToName(line, name string) bool {
+	trimmed := strings.TrimSpace(line)
+	trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+	if i := strings.Index(trimmed, " #"); i != -1 {
+		trimmed = strings.TrimSpace(trimmed[:i])
+	}
+	if unquote(trimmed) == name || unquote(strings.TrimSuffix(trimmed, ":")) == name {
+		return true
+	}
+	if i := strings.Index(trimmed, ":"); i != -1 {
+		return unquote(strings.TrimSpace(trimmed[i+1:])) == name
+	}
+	return false
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
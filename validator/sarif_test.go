@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeSARIFLog(t *testing.T) {
+	contents := []byte(`functions:
+  "unused":
+    command: shell.exec
+tasks:
+  - name: compile # the compile task
+    commands:
+      - func: unused
+`)
+	log := MakeSARIFLog([]FileValidationErrors{
+		{
+			Path:     "project.yml",
+			Contents: contents,
+			Errors: ValidationErrors{
+				{Level: Warning, Message: "function 'unused' is not called by any task or block of commands", Rule: ruleUnusedFunction},
+				{Level: Error, Message: "task 'compile' is bad"},
+				{Level: Warning, Message: "task 'nonexistent' is bad"},
+			},
+		},
+	})
+
+	assert.Equal(t, sarifVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, len(Rules()))
+
+	require.Len(t, run.Results, 3)
+	assert.Equal(t, ruleUnusedFunction, run.Results[0].RuleID)
+	assert.Equal(t, "warning", run.Results[0].Level)
+	require.Len(t, run.Results[0].Locations, 1)
+	assert.Equal(t, "project.yml", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 2, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+
+	assert.Empty(t, run.Results[1].RuleID)
+	assert.Equal(t, "error", run.Results[1].Level)
+	assert.Equal(t, 5, run.Results[1].Locations[0].PhysicalLocation.Region.StartLine)
+
+	assert.Equal(t, 1, run.Results[2].Locations[0].PhysicalLocation.Region.StartLine, "unknown names should point to the first line")
+}